@@ -29,6 +29,7 @@ type Config struct {
 	JWTRefreshTTL                     time.Duration
 	RefreshTokenPepper                string
 	StateSigningSecret                string
+	PaginationCursorSecret            string
 	CookieDomain                      string
 	CookieSecure                      bool
 	CookieSameSite                    string
@@ -48,74 +49,100 @@ type Config struct {
 	RBACProtectedPermissions          []string
 	BootstrapAdminEmail               string
 
-	AuthRateLimitPerMin          int
-	APIRateLimitPerMin           int
-	RateLimitLoginPerMin         int
-	RateLimitRefreshPerMin       int
-	RateLimitAdminWritePerMin    int
-	RateLimitAdminSyncPerMin     int
-	RateLimitBurstMultiplier     float64
-	RateLimitSustainedWindow     time.Duration
-	RateLimitOutagePolicyAPI     string
-	RateLimitOutagePolicyAuth    string
-	RateLimitOutagePolicyForgot  string
-	RateLimitOutagePolicyLogin   string
-	RateLimitOutagePolicyRefresh string
-	RateLimitOutagePolicyAdminW  string
-	RateLimitOutagePolicyAdminS  string
-	AuthAbuseProtectionEnabled   bool
-	AuthAbuseFreeAttempts        int
-	AuthAbuseBaseDelay           time.Duration
-	AuthAbuseMultiplier          float64
-	AuthAbuseMaxDelay            time.Duration
-	AuthAbuseResetWindow         time.Duration
-	AuthAbuseRedisPrefix         string
-	BypassInternalProbes         bool
-	BypassTrustedActors          bool
-	BypassTrustedActorCIDRs      []string
-	BypassTrustedActorSubjects   []string
-	AdminListCacheEnabled        bool
-	AdminListCacheTTL            time.Duration
-	AdminListCacheRedisPrefix    string
-	NegativeLookupCacheEnabled   bool
-	NegativeLookupCacheTTL       time.Duration
-	NegativeLookupCacheRedisPref string
-	RBACPermissionCacheEnabled   bool
-	RBACPermissionCacheTTL       time.Duration
-	RBACPermissionCacheRedisPref string
-	FeatureFlagEvalCacheRedis    bool
-	RateLimitRedisEnabled        bool
-	IdempotencyEnabled           bool
-	IdempotencyRedisEnabled      bool
-	IdempotencyTTL               time.Duration
-	IdempotencyDBCleanupEnabled  bool
-	IdempotencyDBCleanupInterval time.Duration
-	IdempotencyDBCleanupBatch    int
-	IdempotencyRedisPrefix       string
-	RedisKeyNamespace            string
-	RedisAddr                    string
-	RedisUsername                string
-	RedisPassword                string
-	RedisDB                      int
-	RedisTLSEnabled              bool
-	RedisTLSServerName           string
-	RedisTLSCACertFile           string
-	RedisTLSInsecureSkipVerify   bool
-	RedisDialTimeout             time.Duration
-	RedisReadTimeout             time.Duration
-	RedisWriteTimeout            time.Duration
-	RedisMaxRetries              int
-	RedisMinRetryBackoff         time.Duration
-	RedisMaxRetryBackoff         time.Duration
-	RedisPoolSize                int
-	RedisMinIdleConns            int
-	RedisPoolTimeout             time.Duration
-	RateLimitRedisPrefix         string
-	ReadinessProbeTimeout        time.Duration
-	ServerStartGracePeriod       time.Duration
-	ShutdownTimeout              time.Duration
-	ShutdownHTTPDrainTimeout     time.Duration
-	ShutdownObservabilityTimeout time.Duration
+	AuthRateLimitPerMin              int
+	APIRateLimitPerMin               int
+	RateLimitLoginPerMin             int
+	RateLimitRefreshPerMin           int
+	RateLimitAdminWritePerMin        int
+	RateLimitAdminSyncPerMin         int
+	RateLimitBurstMultiplier         float64
+	RateLimitSustainedWindow         time.Duration
+	RateLimitOutagePolicyAPI         string
+	RateLimitOutagePolicyAuth        string
+	RateLimitOutagePolicyForgot      string
+	RateLimitOutagePolicyLogin       string
+	RateLimitOutagePolicyRefresh     string
+	RateLimitOutagePolicyAdminW      string
+	RateLimitOutagePolicyAdminS      string
+	AuthAbuseProtectionEnabled       bool
+	AuthAbuseFreeAttempts            int
+	AuthAbuseBaseDelay               time.Duration
+	AuthAbuseMultiplier              float64
+	AuthAbuseMaxDelay                time.Duration
+	AuthAbuseResetWindow             time.Duration
+	AuthAbuseRedisPrefix             string
+	BypassInternalProbes             bool
+	BypassTrustedActors              bool
+	BypassTrustedActorCIDRs          []string
+	BypassTrustedActorSubjects       []string
+	AdminListCacheEnabled            bool
+	AdminListCacheTTL                time.Duration
+	AdminListCacheRedisPrefix        string
+	NegativeLookupCacheEnabled       bool
+	NegativeLookupCacheTTL           time.Duration
+	NegativeLookupCacheRedisPref     string
+	RBACPermissionCacheEnabled       bool
+	RBACPermissionCacheTTL           time.Duration
+	RBACPermissionCacheRedisPref     string
+	RBACRoleGrantReaperEnabled       bool
+	RBACRoleGrantReaperInterval      time.Duration
+	RBACRoleGrantReaperBatch         int
+	RBACRoleApprovalEnabled          bool
+	RBACRoleApprovalTTL              time.Duration
+	FeatureFlagEvalCacheRedis        bool
+	FeatureFlagSchedulerEnabled      bool
+	FeatureFlagSchedulerInterval     time.Duration
+	FeatureFlagSchedulerBatch        int
+	FeatureFlagGuardrailWindow       time.Duration
+	FeatureFlagGuardrailMinReqs      int
+	FeatureFlagExposureEnabled       bool
+	FeatureFlagExposureFlushInterval time.Duration
+	FeatureFlagExposureRetention     time.Duration
+	FeatureFlagExposureBuffer        int
+	TrashPurgeEnabled                bool
+	TrashPurgeInterval               time.Duration
+	TrashPurgeBatch                  int
+	TrashRetentionDays               int
+	InventoryReservationTTL          time.Duration
+	InventoryReservationMaxTTL       time.Duration
+	InventoryReaperEnabled           bool
+	InventoryReaperInterval          time.Duration
+	InventoryReaperBatch             int
+	PaymentProvider                  string
+	ProductImportMaxBytes            int
+	ProductImportMaxRows             int
+	RateLimitRedisEnabled            bool
+	IdempotencyEnabled               bool
+	IdempotencyRedisEnabled          bool
+	IdempotencyTTL                   time.Duration
+	IdempotencyDBCleanupEnabled      bool
+	IdempotencyDBCleanupInterval     time.Duration
+	IdempotencyDBCleanupBatch        int
+	IdempotencyRedisPrefix           string
+	RedisKeyNamespace                string
+	RedisAddr                        string
+	RedisUsername                    string
+	RedisPassword                    string
+	RedisDB                          int
+	RedisTLSEnabled                  bool
+	RedisTLSServerName               string
+	RedisTLSCACertFile               string
+	RedisTLSInsecureSkipVerify       bool
+	RedisDialTimeout                 time.Duration
+	RedisReadTimeout                 time.Duration
+	RedisWriteTimeout                time.Duration
+	RedisMaxRetries                  int
+	RedisMinRetryBackoff             time.Duration
+	RedisMaxRetryBackoff             time.Duration
+	RedisPoolSize                    int
+	RedisMinIdleConns                int
+	RedisPoolTimeout                 time.Duration
+	RateLimitRedisPrefix             string
+	ReadinessProbeTimeout            time.Duration
+	ServerStartGracePeriod           time.Duration
+	ShutdownTimeout                  time.Duration
+	ShutdownHTTPDrainTimeout         time.Duration
+	ShutdownObservabilityTimeout     time.Duration
 
 	OTELServiceName           string
 	OTELEnvironment           string
@@ -165,6 +192,7 @@ func Load() (cfg *Config, err error) {
 		JWTRefreshSecret:                  os.Getenv("JWT_REFRESH_SECRET"),
 		RefreshTokenPepper:                os.Getenv("REFRESH_TOKEN_PEPPER"),
 		StateSigningSecret:                os.Getenv("OAUTH_STATE_SECRET"),
+		PaginationCursorSecret:            getEnv("PAGINATION_CURSOR_SECRET", os.Getenv("OAUTH_STATE_SECRET")),
 		CookieDomain:                      os.Getenv("COOKIE_DOMAIN"),
 		CookieSecure:                      getEnvBool("COOKIE_SECURE", true),
 		CookieSameSite:                    strings.ToLower(getEnv("COOKIE_SAMESITE", "lax")),
@@ -208,7 +236,23 @@ func Load() (cfg *Config, err error) {
 		NegativeLookupCacheRedisPref:      getEnv("NEGATIVE_LOOKUP_CACHE_REDIS_PREFIX", "negative_lookup_cache"),
 		RBACPermissionCacheEnabled:        getEnvBool("RBAC_PERMISSION_CACHE_ENABLED", true),
 		RBACPermissionCacheRedisPref:      getEnv("RBAC_PERMISSION_CACHE_REDIS_PREFIX", "rbac_perm"),
+		RBACRoleGrantReaperEnabled:        getEnvBool("RBAC_ROLE_GRANT_REAPER_ENABLED", true),
+		RBACRoleGrantReaperBatch:          getEnvInt("RBAC_ROLE_GRANT_REAPER_BATCH_SIZE", 500),
+		RBACRoleApprovalEnabled:           getEnvBool("RBAC_ROLE_APPROVAL_ENABLED", true),
 		FeatureFlagEvalCacheRedis:         getEnvBool("FEATURE_FLAG_EVAL_CACHE_REDIS_ENABLED", true),
+		FeatureFlagSchedulerEnabled:       getEnvBool("FEATURE_FLAG_SCHEDULER_ENABLED", true),
+		FeatureFlagSchedulerBatch:         getEnvInt("FEATURE_FLAG_SCHEDULER_BATCH_SIZE", 100),
+		FeatureFlagGuardrailMinReqs:       getEnvInt("FEATURE_FLAG_GUARDRAIL_MIN_REQUESTS", 100),
+		FeatureFlagExposureEnabled:        getEnvBool("FEATURE_FLAG_EXPOSURE_ENABLED", true),
+		FeatureFlagExposureBuffer:         getEnvInt("FEATURE_FLAG_EXPOSURE_BUFFER_SIZE", 10000),
+		TrashPurgeEnabled:                 getEnvBool("TRASH_PURGE_ENABLED", true),
+		TrashPurgeBatch:                   getEnvInt("TRASH_PURGE_BATCH_SIZE", 500),
+		TrashRetentionDays:                getEnvInt("TRASH_RETENTION_DAYS", 30),
+		InventoryReaperEnabled:            getEnvBool("INVENTORY_RESERVATION_REAPER_ENABLED", true),
+		InventoryReaperBatch:              getEnvInt("INVENTORY_RESERVATION_REAPER_BATCH_SIZE", 500),
+		PaymentProvider:                   strings.ToLower(strings.TrimSpace(getEnv("PAYMENT_PROVIDER", "fake"))),
+		ProductImportMaxBytes:             getEnvInt("PRODUCT_IMPORT_MAX_BYTES", 32<<20),
+		ProductImportMaxRows:              getEnvInt("PRODUCT_IMPORT_MAX_ROWS", 50000),
 		RateLimitRedisEnabled:             getEnvBool("RATE_LIMIT_REDIS_ENABLED", true),
 		IdempotencyEnabled:                getEnvBool("IDEMPOTENCY_ENABLED", true),
 		IdempotencyRedisEnabled:           getEnvBool("IDEMPOTENCY_REDIS_ENABLED", true),
@@ -295,6 +339,66 @@ func Load() (cfg *Config, err error) {
 	}
 	cfg.IdempotencyDBCleanupInterval = idempotencyDBCleanupInterval
 
+	rbacRoleGrantReaperInterval, err := time.ParseDuration(getEnv("RBAC_ROLE_GRANT_REAPER_INTERVAL", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("parse RBAC_ROLE_GRANT_REAPER_INTERVAL: %w", err)
+	}
+	cfg.RBACRoleGrantReaperInterval = rbacRoleGrantReaperInterval
+
+	trashPurgeInterval, err := time.ParseDuration(getEnv("TRASH_PURGE_INTERVAL", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("parse TRASH_PURGE_INTERVAL: %w", err)
+	}
+	cfg.TrashPurgeInterval = trashPurgeInterval
+
+	inventoryReservationTTL, err := time.ParseDuration(getEnv("INVENTORY_RESERVATION_TTL", "15m"))
+	if err != nil {
+		return nil, fmt.Errorf("parse INVENTORY_RESERVATION_TTL: %w", err)
+	}
+	cfg.InventoryReservationTTL = inventoryReservationTTL
+
+	inventoryReservationMaxTTL, err := time.ParseDuration(getEnv("INVENTORY_RESERVATION_MAX_TTL", "24h"))
+	if err != nil {
+		return nil, fmt.Errorf("parse INVENTORY_RESERVATION_MAX_TTL: %w", err)
+	}
+	cfg.InventoryReservationMaxTTL = inventoryReservationMaxTTL
+
+	inventoryReaperInterval, err := time.ParseDuration(getEnv("INVENTORY_RESERVATION_REAPER_INTERVAL", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("parse INVENTORY_RESERVATION_REAPER_INTERVAL: %w", err)
+	}
+	cfg.InventoryReaperInterval = inventoryReaperInterval
+
+	featureFlagSchedulerInterval, err := time.ParseDuration(getEnv("FEATURE_FLAG_SCHEDULER_INTERVAL", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("parse FEATURE_FLAG_SCHEDULER_INTERVAL: %w", err)
+	}
+	cfg.FeatureFlagSchedulerInterval = featureFlagSchedulerInterval
+
+	featureFlagGuardrailWindow, err := time.ParseDuration(getEnv("FEATURE_FLAG_GUARDRAIL_WINDOW", "5m"))
+	if err != nil {
+		return nil, fmt.Errorf("parse FEATURE_FLAG_GUARDRAIL_WINDOW: %w", err)
+	}
+	cfg.FeatureFlagGuardrailWindow = featureFlagGuardrailWindow
+
+	featureFlagExposureFlushInterval, err := time.ParseDuration(getEnv("FEATURE_FLAG_EXPOSURE_FLUSH_INTERVAL", "10s"))
+	if err != nil {
+		return nil, fmt.Errorf("parse FEATURE_FLAG_EXPOSURE_FLUSH_INTERVAL: %w", err)
+	}
+	cfg.FeatureFlagExposureFlushInterval = featureFlagExposureFlushInterval
+
+	featureFlagExposureRetention, err := time.ParseDuration(getEnv("FEATURE_FLAG_EXPOSURE_RETENTION", "2160h"))
+	if err != nil {
+		return nil, fmt.Errorf("parse FEATURE_FLAG_EXPOSURE_RETENTION: %w", err)
+	}
+	cfg.FeatureFlagExposureRetention = featureFlagExposureRetention
+
+	rbacRoleApprovalTTL, err := time.ParseDuration(getEnv("RBAC_ROLE_APPROVAL_TTL", "24h"))
+	if err != nil {
+		return nil, fmt.Errorf("parse RBAC_ROLE_APPROVAL_TTL: %w", err)
+	}
+	cfg.RBACRoleApprovalTTL = rbacRoleApprovalTTL
+
 	adminListCacheTTL, err := time.ParseDuration(getEnv("ADMIN_LIST_CACHE_TTL", "30s"))
 	if err != nil {
 		return nil, fmt.Errorf("parse ADMIN_LIST_CACHE_TTL: %w", err)
@@ -423,6 +527,9 @@ func (c *Config) Validate() error {
 	if len(c.StateSigningSecret) < 16 {
 		errs = append(errs, "OAUTH_STATE_SECRET must be at least 16 chars")
 	}
+	if c.PaginationCursorSecret != "" && len(c.PaginationCursorSecret) < 16 {
+		errs = append(errs, "PAGINATION_CURSOR_SECRET must be at least 16 chars")
+	}
 	if !c.AuthLocalEnabled && !c.AuthGoogleEnabled {
 		errs = append(errs, "at least one auth provider must be enabled")
 	}
@@ -535,6 +642,76 @@ func (c *Config) Validate() error {
 	if c.RBACPermissionCacheEnabled && (c.RBACPermissionCacheTTL <= 0 || c.RBACPermissionCacheTTL > (30*time.Minute)) {
 		errs = append(errs, "RBAC_PERMISSION_CACHE_TTL must be between 1s and 30m when rbac permission cache is enabled")
 	}
+	if c.RBACRoleGrantReaperEnabled {
+		if c.RBACRoleGrantReaperInterval < time.Second || c.RBACRoleGrantReaperInterval > time.Hour {
+			errs = append(errs, "RBAC_ROLE_GRANT_REAPER_INTERVAL must be between 1s and 1h")
+		}
+		if c.RBACRoleGrantReaperBatch < 1 || c.RBACRoleGrantReaperBatch > 10000 {
+			errs = append(errs, "RBAC_ROLE_GRANT_REAPER_BATCH_SIZE must be between 1 and 10000")
+		}
+	}
+	if c.TrashPurgeEnabled {
+		if c.TrashPurgeInterval < time.Minute || c.TrashPurgeInterval > 24*time.Hour {
+			errs = append(errs, "TRASH_PURGE_INTERVAL must be between 1m and 24h")
+		}
+		if c.TrashPurgeBatch < 1 || c.TrashPurgeBatch > 10000 {
+			errs = append(errs, "TRASH_PURGE_BATCH_SIZE must be between 1 and 10000")
+		}
+		if c.TrashRetentionDays < 1 || c.TrashRetentionDays > 3650 {
+			errs = append(errs, "TRASH_RETENTION_DAYS must be between 1 and 3650")
+		}
+	}
+	if c.InventoryReservationMaxTTL < time.Minute || c.InventoryReservationMaxTTL > 7*24*time.Hour {
+		errs = append(errs, "INVENTORY_RESERVATION_MAX_TTL must be between 1m and 168h")
+	}
+	if c.InventoryReservationTTL < time.Second || c.InventoryReservationTTL > c.InventoryReservationMaxTTL {
+		errs = append(errs, "INVENTORY_RESERVATION_TTL must be between 1s and INVENTORY_RESERVATION_MAX_TTL")
+	}
+	if c.InventoryReaperEnabled {
+		if c.InventoryReaperInterval < time.Second || c.InventoryReaperInterval > time.Hour {
+			errs = append(errs, "INVENTORY_RESERVATION_REAPER_INTERVAL must be between 1s and 1h")
+		}
+		if c.InventoryReaperBatch < 1 || c.InventoryReaperBatch > 10000 {
+			errs = append(errs, "INVENTORY_RESERVATION_REAPER_BATCH_SIZE must be between 1 and 10000")
+		}
+	}
+	if c.PaymentProvider != "fake" {
+		errs = append(errs, "PAYMENT_PROVIDER must be fake")
+	}
+	if c.ProductImportMaxBytes < 1<<10 || c.ProductImportMaxBytes > 1<<30 {
+		errs = append(errs, "PRODUCT_IMPORT_MAX_BYTES must be between 1024 and 1073741824")
+	}
+	if c.ProductImportMaxRows < 1 || c.ProductImportMaxRows > 1000000 {
+		errs = append(errs, "PRODUCT_IMPORT_MAX_ROWS must be between 1 and 1000000")
+	}
+	if c.FeatureFlagSchedulerEnabled {
+		if c.FeatureFlagSchedulerInterval < time.Second || c.FeatureFlagSchedulerInterval > time.Hour {
+			errs = append(errs, "FEATURE_FLAG_SCHEDULER_INTERVAL must be between 1s and 1h")
+		}
+		if c.FeatureFlagSchedulerBatch < 1 || c.FeatureFlagSchedulerBatch > 1000 {
+			errs = append(errs, "FEATURE_FLAG_SCHEDULER_BATCH_SIZE must be between 1 and 1000")
+		}
+		if c.FeatureFlagGuardrailWindow < time.Minute || c.FeatureFlagGuardrailWindow > time.Hour {
+			errs = append(errs, "FEATURE_FLAG_GUARDRAIL_WINDOW must be between 1m and 1h")
+		}
+		if c.FeatureFlagGuardrailMinReqs < 1 {
+			errs = append(errs, "FEATURE_FLAG_GUARDRAIL_MIN_REQUESTS must be at least 1")
+		}
+	}
+	if c.FeatureFlagExposureEnabled {
+		if c.FeatureFlagExposureFlushInterval < time.Second || c.FeatureFlagExposureFlushInterval > 10*time.Minute {
+			errs = append(errs, "FEATURE_FLAG_EXPOSURE_FLUSH_INTERVAL must be between 1s and 10m")
+		}
+		if c.FeatureFlagExposureRetention < 24*time.Hour {
+			errs = append(errs, "FEATURE_FLAG_EXPOSURE_RETENTION must be at least 24h")
+		}
+		if c.FeatureFlagExposureBuffer < 1 || c.FeatureFlagExposureBuffer > 1000000 {
+			errs = append(errs, "FEATURE_FLAG_EXPOSURE_BUFFER_SIZE must be between 1 and 1000000")
+		}
+	}
+	if c.RBACRoleApprovalEnabled && (c.RBACRoleApprovalTTL < time.Minute || c.RBACRoleApprovalTTL > (7*24*time.Hour)) {
+		errs = append(errs, "RBAC_ROLE_APPROVAL_TTL must be between 1m and 168h when role approvals are enabled")
+	}
 	if c.IdempotencyTTL <= 0 || c.IdempotencyTTL > (7*24*time.Hour) {
 		errs = append(errs, "IDEMPOTENCY_TTL must be between 1s and 168h")
 	}
@@ -657,7 +834,8 @@ func (c *Config) Validate() error {
 			errs = append(errs, "OTEL_TRACE_SAMPLING_RATIO must be <= 0.2 in production/staging")
 		}
 		if looksPlaceholder(c.JWTAccessSecret) || looksPlaceholder(c.JWTRefreshSecret) ||
-			looksPlaceholder(c.RefreshTokenPepper) || looksPlaceholder(c.StateSigningSecret) {
+			looksPlaceholder(c.RefreshTokenPepper) || looksPlaceholder(c.StateSigningSecret) ||
+			looksPlaceholder(c.PaginationCursorSecret) {
 			errs = append(errs, "secrets must not use placeholder values in production/staging")
 		}
 		if strings.EqualFold(c.RateLimitOutagePolicyAuth, stringFailOpen()) {
@@ -40,6 +40,11 @@ func TestValidateProdProfileStrictRules(t *testing.T) {
 		IdempotencyEnabled:                true,
 		IdempotencyRedisEnabled:           true,
 		IdempotencyTTL:                    24 * time.Hour,
+		InventoryReservationTTL:           15 * time.Minute,
+		InventoryReservationMaxTTL:        24 * time.Hour,
+		ProductImportMaxBytes:             32 << 20,
+		ProductImportMaxRows:              50000,
+		PaymentProvider:                   "fake",
 		RedisAddr:                         "localhost:6379",
 		RedisDialTimeout:                  5 * time.Second,
 		RedisReadTimeout:                  3 * time.Second,
@@ -100,6 +105,11 @@ func TestValidateDevelopmentProfileAllowsRelaxedSettings(t *testing.T) {
 		IdempotencyEnabled:                true,
 		IdempotencyRedisEnabled:           true,
 		IdempotencyTTL:                    24 * time.Hour,
+		InventoryReservationTTL:           15 * time.Minute,
+		InventoryReservationMaxTTL:        24 * time.Hour,
+		ProductImportMaxBytes:             32 << 20,
+		ProductImportMaxRows:              50000,
+		PaymentProvider:                   "fake",
 		RedisAddr:                         "localhost:6379",
 		RedisDialTimeout:                  5 * time.Second,
 		RedisReadTimeout:                  3 * time.Second,
@@ -161,6 +171,11 @@ func TestValidateTrustedActorBypassRequiresAllowlist(t *testing.T) {
 		IdempotencyEnabled:                true,
 		IdempotencyRedisEnabled:           true,
 		IdempotencyTTL:                    24 * time.Hour,
+		InventoryReservationTTL:           15 * time.Minute,
+		InventoryReservationMaxTTL:        24 * time.Hour,
+		ProductImportMaxBytes:             32 << 20,
+		ProductImportMaxRows:              50000,
+		PaymentProvider:                   "fake",
 		RedisAddr:                         "localhost:6379",
 		RedisDialTimeout:                  5 * time.Second,
 		RedisReadTimeout:                  3 * time.Second,
@@ -223,6 +238,11 @@ func TestValidateTrustedActorBypassAcceptsCIDR(t *testing.T) {
 		IdempotencyEnabled:                true,
 		IdempotencyRedisEnabled:           true,
 		IdempotencyTTL:                    24 * time.Hour,
+		InventoryReservationTTL:           15 * time.Minute,
+		InventoryReservationMaxTTL:        24 * time.Hour,
+		ProductImportMaxBytes:             32 << 20,
+		ProductImportMaxRows:              50000,
+		PaymentProvider:                   "fake",
 		RedisAddr:                         "localhost:6379",
 		RedisDialTimeout:                  5 * time.Second,
 		RedisReadTimeout:                  3 * time.Second,
@@ -283,6 +303,11 @@ func TestValidateRedisPoolSettings(t *testing.T) {
 		IdempotencyEnabled:                true,
 		IdempotencyRedisEnabled:           true,
 		IdempotencyTTL:                    24 * time.Hour,
+		InventoryReservationTTL:           15 * time.Minute,
+		InventoryReservationMaxTTL:        24 * time.Hour,
+		ProductImportMaxBytes:             32 << 20,
+		ProductImportMaxRows:              50000,
+		PaymentProvider:                   "fake",
 		RedisAddr:                         "localhost:6379",
 		RedisDialTimeout:                  5 * time.Second,
 		RedisReadTimeout:                  3 * time.Second,
@@ -349,6 +374,11 @@ func TestValidateNonLocalRedisRequiresACLAndTLS(t *testing.T) {
 		IdempotencyEnabled:                true,
 		IdempotencyRedisEnabled:           true,
 		IdempotencyTTL:                    24 * time.Hour,
+		InventoryReservationTTL:           15 * time.Minute,
+		InventoryReservationMaxTTL:        24 * time.Hour,
+		ProductImportMaxBytes:             32 << 20,
+		ProductImportMaxRows:              50000,
+		PaymentProvider:                   "fake",
 		RedisAddr:                         "redis.internal:6379",
 		RedisDialTimeout:                  5 * time.Second,
 		RedisReadTimeout:                  3 * time.Second,
@@ -422,6 +452,11 @@ func TestValidateRedisNamespacePattern(t *testing.T) {
 		IdempotencyEnabled:                true,
 		IdempotencyRedisEnabled:           true,
 		IdempotencyTTL:                    24 * time.Hour,
+		InventoryReservationTTL:           15 * time.Minute,
+		InventoryReservationMaxTTL:        24 * time.Hour,
+		ProductImportMaxBytes:             32 << 20,
+		ProductImportMaxRows:              50000,
+		PaymentProvider:                   "fake",
 		RedisKeyNamespace:                 "v1:bad",
 		RedisAddr:                         "localhost:6379",
 		RedisDialTimeout:                  5 * time.Second,
@@ -478,6 +513,177 @@ func TestValidateIdempotencyDBCleanupSettings(t *testing.T) {
 	}
 }
 
+func TestValidateTrashPurgeSettings(t *testing.T) {
+	cfg := newValidConfigForProfileTests()
+	cfg.TrashPurgeEnabled = true
+	cfg.TrashPurgeInterval = time.Hour
+	cfg.TrashPurgeBatch = 500
+	cfg.TrashRetentionDays = 0
+
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected validation error when TRASH_RETENTION_DAYS is below 1")
+	}
+
+	cfg.TrashRetentionDays = 30
+	cfg.TrashPurgeInterval = 10 * time.Second
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected validation error when TRASH_PURGE_INTERVAL is below 1m")
+	}
+
+	cfg.TrashPurgeInterval = time.Hour
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected valid trash purge config: %v", err)
+	}
+}
+
+func TestValidateInventoryReservationSettings(t *testing.T) {
+	cfg := newValidConfigForProfileTests()
+	cfg.InventoryReservationTTL = 48 * time.Hour
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected validation error when INVENTORY_RESERVATION_TTL exceeds the max TTL")
+	}
+
+	cfg.InventoryReservationTTL = 15 * time.Minute
+	cfg.InventoryReaperEnabled = true
+	cfg.InventoryReaperInterval = 0
+	cfg.InventoryReaperBatch = 500
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected validation error when INVENTORY_RESERVATION_REAPER_INTERVAL is below 1s")
+	}
+
+	cfg.InventoryReaperInterval = 30 * time.Second
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected valid inventory reservation config: %v", err)
+	}
+}
+
+func TestValidatePaymentProvider(t *testing.T) {
+	cfg := newValidConfigForProfileTests()
+	cfg.PaymentProvider = "stripe"
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected validation error for an unsupported PAYMENT_PROVIDER")
+	}
+
+	cfg.PaymentProvider = "fake"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected valid payment provider config: %v", err)
+	}
+}
+
+func TestValidateProductImportLimits(t *testing.T) {
+	cfg := newValidConfigForProfileTests()
+	cfg.ProductImportMaxBytes = 0
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected validation error when PRODUCT_IMPORT_MAX_BYTES is 0")
+	}
+
+	cfg.ProductImportMaxBytes = 32 << 20
+	cfg.ProductImportMaxRows = 2000000
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected validation error when PRODUCT_IMPORT_MAX_ROWS is above 1000000")
+	}
+
+	cfg.ProductImportMaxRows = 50000
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected valid product import limits: %v", err)
+	}
+}
+
+func TestValidateRBACRoleGrantReaperSettings(t *testing.T) {
+	cfg := newValidConfigForProfileTests()
+	cfg.RBACRoleGrantReaperEnabled = true
+	cfg.RBACRoleGrantReaperInterval = 2 * time.Hour
+	cfg.RBACRoleGrantReaperBatch = 100
+
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected validation error when RBAC_ROLE_GRANT_REAPER_INTERVAL is above 1h")
+	}
+
+	cfg.RBACRoleGrantReaperInterval = time.Minute
+	cfg.RBACRoleGrantReaperBatch = 0
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected validation error when RBAC_ROLE_GRANT_REAPER_BATCH_SIZE is below 1")
+	}
+
+	cfg.RBACRoleGrantReaperBatch = 500
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected valid role grant reaper config: %v", err)
+	}
+}
+
+func TestValidateFeatureFlagSchedulerSettings(t *testing.T) {
+	cfg := newValidConfigForProfileTests()
+	cfg.FeatureFlagSchedulerEnabled = true
+	cfg.FeatureFlagSchedulerInterval = 30 * time.Second
+	cfg.FeatureFlagSchedulerBatch = 100
+	cfg.FeatureFlagGuardrailWindow = 30 * time.Second
+	cfg.FeatureFlagGuardrailMinReqs = 100
+
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected validation error when FEATURE_FLAG_GUARDRAIL_WINDOW is below 1m")
+	}
+
+	cfg.FeatureFlagGuardrailWindow = 5 * time.Minute
+	cfg.FeatureFlagSchedulerBatch = 0
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected validation error when FEATURE_FLAG_SCHEDULER_BATCH_SIZE is below 1")
+	}
+
+	cfg.FeatureFlagSchedulerBatch = 100
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected valid feature flag scheduler config: %v", err)
+	}
+}
+
+func TestValidateFeatureFlagExposureSettings(t *testing.T) {
+	cfg := newValidConfigForProfileTests()
+	cfg.FeatureFlagExposureEnabled = true
+	cfg.FeatureFlagExposureFlushInterval = 10 * time.Second
+	cfg.FeatureFlagExposureRetention = time.Hour
+	cfg.FeatureFlagExposureBuffer = 10000
+
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected validation error when FEATURE_FLAG_EXPOSURE_RETENTION is below 24h")
+	}
+
+	cfg.FeatureFlagExposureRetention = 90 * 24 * time.Hour
+	cfg.FeatureFlagExposureFlushInterval = 0
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected validation error when FEATURE_FLAG_EXPOSURE_FLUSH_INTERVAL is below 1s")
+	}
+
+	cfg.FeatureFlagExposureFlushInterval = 10 * time.Second
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected valid feature flag exposure config: %v", err)
+	}
+}
+
+func TestValidatePaginationCursorSecret(t *testing.T) {
+	cfg := newValidConfigForProfileTests()
+	cfg.PaginationCursorSecret = "short"
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected validation error when PAGINATION_CURSOR_SECRET is shorter than 16 chars")
+	}
+
+	cfg.PaginationCursorSecret = "cursor-secret-12345"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected valid pagination cursor secret: %v", err)
+	}
+}
+
+func TestValidateRBACRoleApprovalTTL(t *testing.T) {
+	cfg := newValidConfigForProfileTests()
+	cfg.RBACRoleApprovalEnabled = true
+	cfg.RBACRoleApprovalTTL = 30 * time.Second
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected validation error when RBAC_ROLE_APPROVAL_TTL is below 1m")
+	}
+	cfg.RBACRoleApprovalTTL = 24 * time.Hour
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected valid role approval config: %v", err)
+	}
+}
+
 func TestValidateRateLimitRedisOutagePolicies(t *testing.T) {
 	cfg := newValidConfigForProfileTests()
 	cfg.RateLimitOutagePolicyAPI = "fail_open"
@@ -581,6 +787,11 @@ func newValidConfigForProfileTests() *Config {
 		IdempotencyEnabled:                true,
 		IdempotencyRedisEnabled:           true,
 		IdempotencyTTL:                    24 * time.Hour,
+		InventoryReservationTTL:           15 * time.Minute,
+		InventoryReservationMaxTTL:        24 * time.Hour,
+		ProductImportMaxBytes:             32 << 20,
+		ProductImportMaxRows:              50000,
+		PaymentProvider:                   "fake",
 		RedisAddr:                         "localhost:6379",
 		RedisDialTimeout:                  5 * time.Second,
 		RedisReadTimeout:                  3 * time.Second,
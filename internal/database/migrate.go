@@ -12,21 +12,55 @@ import (
 
 func Migrate(db *gorm.DB) error {
 	start := time.Now()
-	err := db.AutoMigrate(
-		&domain.User{},
-		&domain.LocalCredential{},
-		&domain.Role{},
-		&domain.Permission{},
-		&domain.UserRole{},
-		&domain.RolePermission{},
-		&domain.OAuthAccount{},
-		&domain.Session{},
-		&domain.VerificationToken{},
-		&domain.IdempotencyRecord{},
-		&domain.FeatureFlag{},
-		&domain.FeatureFlagRule{},
-		&domain.Product{},
-	)
+	err := prepareLegacyProductPriceColumn(db)
+	if err == nil {
+		err = db.AutoMigrate(
+			&domain.User{},
+			&domain.LocalCredential{},
+			&domain.Role{},
+			&domain.Permission{},
+			&domain.UserRole{},
+			&domain.RolePermission{},
+			&domain.RoleChangeRequest{},
+			&domain.Group{},
+			&domain.GroupMember{},
+			&domain.OAuthAccount{},
+			&domain.Session{},
+			&domain.VerificationToken{},
+			&domain.IdempotencyRecord{},
+			&domain.FeatureFlag{},
+			&domain.FeatureFlagRule{},
+			&domain.FeatureFlagVariant{},
+			&domain.FeatureFlagPrerequisite{},
+			&domain.FeatureFlagVersion{},
+			&domain.FeatureFlagSchedule{},
+			&domain.FeatureFlagScheduleStep{},
+			&domain.FeatureFlagEvaluationStat{},
+			&domain.FeatureFlagExposure{},
+			&domain.Organization{},
+			&domain.Membership{},
+			&domain.Category{},
+			&domain.Product{},
+			&domain.ProductGrant{},
+			&domain.ProductTag{},
+			&domain.ProductPrice{},
+			&domain.ProductImage{},
+			&domain.ProductStock{},
+			&domain.ProductImportJob{},
+			&domain.StockReservation{},
+			&domain.StockMovement{},
+			&domain.Cart{},
+			&domain.CartItem{},
+			&domain.Order{},
+			&domain.OrderItem{},
+		)
+	}
+	if err == nil {
+		err = migrateLegacyProductPrices(db)
+	}
+	if err == nil {
+		err = migrateProductSearchIndexes(db)
+	}
 	observability.RecordDatabaseStartupDuration(context.Background(), "migrate", time.Since(start))
 	if err != nil {
 		observability.RecordDatabaseStartupEvent(context.Background(), "migrate", "error")
@@ -35,3 +69,161 @@ func Migrate(db *gorm.DB) error {
 	observability.RecordDatabaseStartupEvent(context.Background(), "migrate", "success")
 	return nil
 }
+
+// productSearchIndexes back the product list search on Postgres: a GIN
+// full-text index over name and description, and a trigram index on name for
+// partial matches. Expressions must stay in sync with
+// repository.GormProductRepository.
+var productSearchIndexes = []string{
+	`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+	`CREATE INDEX IF NOT EXISTS products_search_idx ON products USING GIN (to_tsvector('simple', name || ' ' || description))`,
+	`CREATE INDEX IF NOT EXISTS products_name_trgm_idx ON products USING GIN (name gin_trgm_ops)`,
+}
+
+func migrateProductSearchIndexes(db *gorm.DB) error {
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+	for _, stmt := range productSearchIndexes {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// legacyProductPrice gives the legacy products.price column a default, so
+// inserts that do not name it still pass its NOT NULL constraint.
+type legacyProductPrice struct {
+	Price float64 `gorm:"column:price;not null;default:0"`
+}
+
+func (legacyProductPrice) TableName() string { return "products" }
+
+// legacyProductPriceSync keeps products.price and price_minor in step while
+// both exist, so the previous release keeps reading and writing prices during
+// a rolling deploy or after a rollback. New writes mirror price_minor into
+// price; writes of price alone, from the previous release, are converted into
+// price_minor.
+var legacyProductPriceSync = map[string][]string{
+	"postgres": {
+		`CREATE OR REPLACE FUNCTION products_sync_legacy_price() RETURNS trigger AS $$
+BEGIN
+	IF TG_OP = 'INSERT' THEN
+		IF NEW.price_minor = 0 AND COALESCE(NEW.price, 0) <> 0 THEN
+			NEW.price_minor := ROUND(NEW.price * 100);
+			NEW.currency := '` + domain.DefaultCurrency + `';
+		ELSE
+			NEW.price := NEW.price_minor / 100.0;
+		END IF;
+	ELSIF NEW.price_minor IS DISTINCT FROM OLD.price_minor OR NEW.currency IS DISTINCT FROM OLD.currency THEN
+		NEW.price := NEW.price_minor / 100.0;
+	ELSIF NEW.price IS DISTINCT FROM OLD.price THEN
+		NEW.price_minor := ROUND(NEW.price * 100);
+	END IF;
+	RETURN NEW;
+END
+$$ LANGUAGE plpgsql`,
+		`DROP TRIGGER IF EXISTS products_legacy_price ON products`,
+		`CREATE TRIGGER products_legacy_price BEFORE INSERT OR UPDATE ON products FOR EACH ROW EXECUTE FUNCTION products_sync_legacy_price()`,
+	},
+	"sqlite": {
+		`CREATE TRIGGER IF NOT EXISTS products_legacy_price_insert AFTER INSERT ON products BEGIN
+	UPDATE products SET price_minor = CAST(ROUND(NEW.price * 100) AS INTEGER), currency = '` + domain.DefaultCurrency + `'
+		WHERE id = NEW.id AND NEW.price_minor = 0 AND NEW.price <> 0;
+	UPDATE products SET price = NEW.price_minor / 100.0
+		WHERE id = NEW.id AND NOT (NEW.price_minor = 0 AND NEW.price <> 0);
+END`,
+		`CREATE TRIGGER IF NOT EXISTS products_legacy_price_update AFTER UPDATE OF price_minor, currency ON products BEGIN
+	UPDATE products SET price = NEW.price_minor / 100.0 WHERE id = NEW.id;
+END`,
+		`CREATE TRIGGER IF NOT EXISTS products_legacy_price_update_legacy AFTER UPDATE OF price ON products
+	WHEN NEW.price_minor = OLD.price_minor AND NEW.price IS NOT OLD.price BEGIN
+	UPDATE products SET price_minor = CAST(ROUND(NEW.price * 100) AS INTEGER) WHERE id = NEW.id;
+END`,
+	},
+}
+
+var legacyProductPriceUnsync = map[string][]string{
+	"postgres": {
+		`DROP TRIGGER IF EXISTS products_legacy_price ON products`,
+		`DROP FUNCTION IF EXISTS products_sync_legacy_price()`,
+	},
+	"sqlite": {
+		`DROP TRIGGER IF EXISTS products_legacy_price_insert`,
+		`DROP TRIGGER IF EXISTS products_legacy_price_update`,
+		`DROP TRIGGER IF EXISTS products_legacy_price_update_legacy`,
+	},
+}
+
+// prepareLegacyProductPriceColumn runs before AutoMigrate: SQLite can only
+// change a column's default by rebuilding the table, which would drop the
+// indexes AutoMigrate creates.
+func prepareLegacyProductPriceColumn(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&domain.Product{}, "price") {
+		return nil
+	}
+	if db.Dialector.Name() == "postgres" {
+		return db.Exec("ALTER TABLE products ALTER COLUMN price SET DEFAULT 0").Error
+	}
+	columns, err := db.Migrator().ColumnTypes(&legacyProductPrice{})
+	if err != nil {
+		return err
+	}
+	for _, column := range columns {
+		if _, ok := column.DefaultValue(); column.Name() == "price" && ok {
+			return nil
+		}
+	}
+	return db.Migrator().AlterColumn(&legacyProductPrice{}, "Price")
+}
+
+// migrateLegacyProductPrices converts the float products.price column used
+// before prices were stored in minor units. Legacy prices carried no currency
+// and are taken to be DefaultCurrency amounts, rounded to the nearest cent.
+// The column is kept and synced for one release; DropLegacyProductPrice
+// removes it once no running release reads it any more.
+func migrateLegacyProductPrices(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&domain.Product{}, "price") {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := convertLegacyProductPrices(tx); err != nil {
+			return err
+		}
+		for _, stmt := range legacyProductPriceSync[tx.Dialector.Name()] {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func convertLegacyProductPrices(tx *gorm.DB) error {
+	return tx.Exec(
+		"UPDATE products SET price_minor = CAST(ROUND(price * 100) AS BIGINT), currency = ? WHERE price_minor = 0 AND price IS NOT NULL AND price <> 0",
+		domain.DefaultCurrency,
+	).Error
+}
+
+// DropLegacyProductPrice is the follow-up to migrateLegacyProductPrices: it
+// removes the sync triggers and the legacy products.price column. Run it
+// explicitly (migrate drop-legacy-price) once no instance of the release
+// before minor-unit prices is left to roll back to.
+func DropLegacyProductPrice(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&domain.Product{}, "price") {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, stmt := range legacyProductPriceUnsync[tx.Dialector.Name()] {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		if err := convertLegacyProductPrices(tx); err != nil {
+			return err
+		}
+		return tx.Exec("ALTER TABLE products DROP COLUMN price").Error
+	})
+}
@@ -46,3 +46,86 @@ func TestMigrateFailureWhenDBClosed(t *testing.T) {
 		t.Fatal("expected migrate error on closed database")
 	}
 }
+
+func TestMigrateConvertsLegacyFloatPrices(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:legacy_prices?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.Exec("CREATE TABLE products (id integer PRIMARY KEY AUTOINCREMENT, name text NOT NULL, description text, price real NOT NULL)").Error; err != nil {
+		t.Fatalf("create legacy table: %v", err)
+	}
+	if err := db.Exec("INSERT INTO products (name, description, price) VALUES ('Pen', '', 19.99), ('Ink', '', 0.29)").Error; err != nil {
+		t.Fatalf("insert legacy rows: %v", err)
+	}
+
+	legacyPrice := func(id uint) float64 {
+		t.Helper()
+		var price float64
+		if err := db.Raw("SELECT price FROM products WHERE id = ?", id).Scan(&price).Error; err != nil {
+			t.Fatalf("read legacy price: %v", err)
+		}
+		return price
+	}
+
+	for run := 1; run <= 2; run++ {
+		if err := Migrate(db); err != nil {
+			t.Fatalf("migrate run %d: %v", run, err)
+		}
+	}
+	if !db.Migrator().HasColumn(&domain.Product{}, "price") {
+		t.Fatal("expected legacy price column to be kept until it is dropped explicitly")
+	}
+	if !db.Migrator().HasIndex(&domain.Product{}, "idx_products_org_sku") {
+		t.Fatal("expected product indexes to survive the legacy column change")
+	}
+	var products []domain.Product
+	if err := db.Order("id asc").Find(&products).Error; err != nil {
+		t.Fatalf("load products: %v", err)
+	}
+	if len(products) != 2 || products[0].PriceMinor != 1999 || products[1].PriceMinor != 29 || products[0].Currency != "USD" {
+		t.Fatalf("unexpected converted prices: %+v", products)
+	}
+
+	// New writes keep the legacy column in step for the previous release.
+	hat := &domain.Product{Name: "Cap", PriceMinor: 500, Currency: "USD"}
+	if err := db.Create(hat).Error; err != nil {
+		t.Fatalf("insert product beside legacy column: %v", err)
+	}
+	if got := legacyPrice(hat.ID); got != 5 {
+		t.Fatalf("expected legacy price 5 on insert, got %v", got)
+	}
+	if err := db.Model(&domain.Product{}).Where("id = ?", products[0].ID).Update("price_minor", 0).Error; err != nil {
+		t.Fatalf("make product free: %v", err)
+	}
+	if got := legacyPrice(products[0].ID); got != 0 {
+		t.Fatalf("expected legacy price 0 for a free product, got %v", got)
+	}
+
+	// Writes from the previous release reach price_minor.
+	if err := db.Exec("INSERT INTO products (name, description, price) VALUES ('Nib', '', 1.5)").Error; err != nil {
+		t.Fatalf("insert legacy row: %v", err)
+	}
+	if err := db.Exec("UPDATE products SET price = 0.35 WHERE id = ?", products[1].ID).Error; err != nil {
+		t.Fatalf("update legacy price: %v", err)
+	}
+	if err := db.Order("id asc").Find(&products).Error; err != nil {
+		t.Fatalf("load products: %v", err)
+	}
+	if len(products) != 4 || products[0].PriceMinor != 0 || products[1].PriceMinor != 35 || products[3].PriceMinor != 150 {
+		t.Fatalf("unexpected prices after legacy writes: %+v", products)
+	}
+
+	if err := DropLegacyProductPrice(db); err != nil {
+		t.Fatalf("drop legacy price: %v", err)
+	}
+	if db.Migrator().HasColumn(&domain.Product{}, "price") {
+		t.Fatal("expected the explicit step to drop the legacy price column")
+	}
+	if err := db.Create(&domain.Product{Name: "Pad", PriceMinor: 100, Currency: "USD"}).Error; err != nil {
+		t.Fatalf("insert after drop: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("migrate after drop: %v", err)
+	}
+}
@@ -17,15 +17,37 @@ var defaultPermissions = []domain.Permission{
 	{Resource: "users", Action: "write"},
 	{Resource: "roles", Action: "read"},
 	{Resource: "roles", Action: "write"},
+	{Resource: "role_requests", Action: "read"},
+	{Resource: "role_requests", Action: "approve"},
 	{Resource: "permissions", Action: "read"},
 	{Resource: "permissions", Action: "write"},
 	{Resource: "feature_flags", Action: "read"},
 	{Resource: "feature_flags", Action: "write"},
+	{Resource: "feature_flags", Action: "evaluate"},
 	{Resource: "products", Action: "read"},
 	{Resource: "products", Action: "write"},
 	{Resource: "products", Action: "delete"},
+	{Resource: "products", Action: "read:own"},
+	{Resource: "products", Action: "write:own"},
+	{Resource: "products", Action: "delete:own"},
+	{Resource: "categories", Action: "read"},
+	{Resource: "categories", Action: "write"},
+	{Resource: "inventory", Action: "read"},
+	{Resource: "inventory", Action: "write"},
+	{Resource: "orders", Action: "read"},
+	{Resource: "orders", Action: "write"},
+	{Resource: "groups", Action: "read"},
+	{Resource: "groups", Action: "write"},
+	{Resource: "orgs", Action: "read"},
+	{Resource: "orgs", Action: "write"},
+	{Resource: "members", Action: "read"},
+	{Resource: "members", Action: "write"},
 }
 
+// orgAdminPermissions are bound to the org_admin role, which is meant to be
+// granted through organization memberships rather than global user roles.
+var orgAdminPermissions = []string{"members:read", "members:write", "users:read", "products:read", "products:write", "products:delete"}
+
 type RBACSyncReport struct {
 	CreatedPermissions int  `json:"created_permissions"`
 	CreatedRoles       int  `json:"created_roles"`
@@ -77,30 +99,40 @@ func SeedSync(db *gorm.DB, bootstrapAdminEmail string) (*RBACSyncReport, error)
 	}
 
 	var perms []domain.Permission
-	if err := db.Where("resource IN ?", []string{"users", "roles", "permissions", "feature_flags", "products"}).Find(&perms).Error; err != nil {
+	if err := db.Where("resource IN ?", []string{"users", "roles", "role_requests", "groups", "permissions", "feature_flags", "products", "categories", "inventory", "orders", "orgs", "members"}).Find(&perms).Error; err != nil {
 		observability.RecordDatabaseStartupEvent(context.Background(), "seed", "error")
 		return nil, err
 	}
-	if len(perms) > 0 {
-		var before domain.Role
-		if err := db.Preload("Permissions").Where("id = ?", adminRole.ID).First(&before).Error; err != nil {
-			observability.RecordDatabaseStartupEvent(context.Background(), "seed", "error")
-			return nil, err
-		}
-		beforeSet := make(map[uint]struct{}, len(before.Permissions))
-		for _, p := range before.Permissions {
-			beforeSet[p.ID] = struct{}{}
-		}
-		if err := db.Model(&adminRole).Association("Permissions").Replace(&perms); err != nil {
-			observability.RecordDatabaseStartupEvent(context.Background(), "seed", "error")
-			return nil, err
-		}
-		for _, p := range perms {
-			if _, ok := beforeSet[p.ID]; !ok {
-				report.BoundPermissions++
+	bound, err := bindRolePermissions(db, &adminRole, perms)
+	if err != nil {
+		observability.RecordDatabaseStartupEvent(context.Background(), "seed", "error")
+		return nil, err
+	}
+	report.BoundPermissions += bound
+
+	orgAdminRole := domain.Role{Name: "org_admin", Description: "Organization administrator role"}
+	res = db.Where("name = ?", orgAdminRole.Name).FirstOrCreate(&orgAdminRole)
+	if res.Error != nil {
+		observability.RecordDatabaseStartupEvent(context.Background(), "seed", "error")
+		return nil, res.Error
+	}
+	if res.RowsAffected > 0 {
+		report.CreatedRoles++
+	}
+	var orgAdminPerms []domain.Permission
+	for _, p := range perms {
+		for _, name := range orgAdminPermissions {
+			if p.Resource+":"+p.Action == name {
+				orgAdminPerms = append(orgAdminPerms, p)
 			}
 		}
 	}
+	bound, err = bindRolePermissions(db, &orgAdminRole, orgAdminPerms)
+	if err != nil {
+		observability.RecordDatabaseStartupEvent(context.Background(), "seed", "error")
+		return nil, err
+	}
+	report.BoundPermissions += bound
 
 	email := strings.TrimSpace(strings.ToLower(bootstrapAdminEmail))
 	if email != "" {
@@ -131,6 +163,32 @@ func SeedSync(db *gorm.DB, bootstrapAdminEmail string) (*RBACSyncReport, error)
 	return report, nil
 }
 
+// bindRolePermissions replaces the role's permission set and reports how many
+// permissions were newly bound.
+func bindRolePermissions(db *gorm.DB, role *domain.Role, perms []domain.Permission) (int, error) {
+	if len(perms) == 0 {
+		return 0, nil
+	}
+	var before domain.Role
+	if err := db.Preload("Permissions").Where("id = ?", role.ID).First(&before).Error; err != nil {
+		return 0, err
+	}
+	beforeSet := make(map[uint]struct{}, len(before.Permissions))
+	for _, p := range before.Permissions {
+		beforeSet[p.ID] = struct{}{}
+	}
+	if err := db.Model(role).Association("Permissions").Replace(&perms); err != nil {
+		return 0, err
+	}
+	bound := 0
+	for _, p := range perms {
+		if _, ok := beforeSet[p.ID]; !ok {
+			bound++
+		}
+	}
+	return bound, nil
+}
+
 func VerifyLocalEmail(db *gorm.DB, email string) error {
 	normalized := strings.TrimSpace(strings.ToLower(email))
 	if normalized == "" {
@@ -47,7 +47,17 @@ var RepositorySet = wire.NewSet(
 	repository.NewRoleRepository,
 	repository.NewPermissionRepository,
 	repository.NewFeatureFlagRepository,
+	repository.NewFeatureFlagScheduleRepository,
+	repository.NewFeatureFlagExposureRepository,
 	repository.NewProductRepository,
+	repository.NewProductImportJobRepository,
+	repository.NewCategoryRepository,
+	repository.NewInventoryRepository,
+	repository.NewCartRepository,
+	repository.NewOrderRepository,
+	repository.NewOrganizationRepository,
+	repository.NewGroupRepository,
+	repository.NewRoleChangeRequestRepository,
 	repository.NewSessionRepository,
 	repository.NewOAuthRepository,
 	repository.NewLocalCredentialRepository,
@@ -73,14 +83,38 @@ var ServiceSet = wire.NewSet(
 	service.NewOAuthService,
 	service.NewAuthService,
 	provideFeatureFlagEvaluationCacheStore,
+	provideFeatureFlagChangeBroker,
+	provideFeatureFlagExposureRecorder,
 	service.NewFeatureFlagService,
+	provideFeatureFlagGuardrailTracker,
+	service.NewFeatureFlagScheduleService,
+	service.NewFeatureFlagUsageService,
 	service.NewProductService,
+	provideProductImportService,
+	service.NewCategoryService,
+	service.NewRoleGrantReaper,
+	service.NewTrashPurger,
+	service.NewReservationReaper,
+	provideInventoryService,
+	service.NewCartService,
+	providePaymentProvider,
+	service.NewOrderService,
+	provideRoleChangeRequestService,
+	provideOrganizationService,
+	provideGroupService,
 	wire.Bind(new(service.UserServiceInterface), new(*service.UserService)),
 	wire.Bind(new(service.SessionServiceInterface), new(*service.SessionService)),
 	wire.Bind(new(service.AuthServiceInterface), new(*service.AuthService)),
 	wire.Bind(new(service.RBACAuthorizer), new(*service.RBACService)),
 	wire.Bind(new(service.FeatureFlagService), new(*service.DefaultFeatureFlagService)),
+	wire.Bind(new(service.FeatureFlagGuardrailSource), new(*service.HTTPErrorRateTracker)),
+	wire.Bind(new(service.FeatureFlagScheduleService), new(*service.DefaultFeatureFlagScheduleService)),
+	wire.Bind(new(service.FeatureFlagUsageService), new(*service.DefaultFeatureFlagUsageService)),
 	wire.Bind(new(service.ProductService), new(*service.ProductServiceImpl)),
+	wire.Bind(new(service.ProductImportService), new(*service.DefaultProductImportService)),
+	wire.Bind(new(service.CategoryService), new(*service.DefaultCategoryService)),
+	wire.Bind(new(service.CartService), new(*service.DefaultCartService)),
+	wire.Bind(new(service.OrderService), new(*service.DefaultOrderService)),
 )
 
 var HTTPSet = wire.NewSet(
@@ -94,7 +128,16 @@ var HTTPSet = wire.NewSet(
 	provideNegativeLookupCacheStore,
 	handler.NewAdminHandler,
 	handler.NewFeatureFlagHandler,
+	handler.NewFeatureFlagScheduleHandler,
+	handler.NewFeatureFlagUsageHandler,
 	handler.NewProductHandler,
+	handler.NewProductImportHandler,
+	handler.NewCategoryHandler,
+	handler.NewInventoryHandler,
+	handler.NewCartHandler,
+	handler.NewOrderHandler,
+	handler.NewOrganizationHandler,
+	handler.NewGroupHandler,
 	provideGlobalRateLimiter,
 	provideAuthRateLimiter,
 	provideForgotRateLimiter,
@@ -223,6 +266,63 @@ func composeRedisPrefix(namespace, prefix string) string {
 	return ns + ":" + p
 }
 
+func provideRoleChangeRequestService(
+	cfg *config.Config,
+	repo repository.RoleChangeRequestRepository,
+	userSvc service.UserServiceInterface,
+) service.RoleChangeRequestService {
+	return service.NewRoleChangeRequestService(repo, userSvc, cfg.RBACRoleApprovalTTL)
+}
+
+func provideOrganizationService(
+	cfg *config.Config,
+	repo repository.OrganizationRepository,
+	roleRepo repository.RoleRepository,
+	rbac *service.RBACService,
+) service.OrganizationService {
+	return service.NewOrganizationService(repo, roleRepo, rbac, cfg.RBACProtectedRoles)
+}
+
+func provideGroupService(
+	cfg *config.Config,
+	repo repository.GroupRepository,
+	roleRepo repository.RoleRepository,
+	userRepo repository.UserRepository,
+	resolver service.PermissionResolver,
+) service.GroupService {
+	return service.NewGroupService(repo, roleRepo, userRepo, resolver, cfg.RBACProtectedRoles)
+}
+
+// providePaymentProvider builds the gateway named by PAYMENT_PROVIDER. Only
+// the in-process fake ships with the kit; real gateways are added here as
+// service.PaymentProvider implementations.
+func providePaymentProvider(cfg *config.Config) (service.PaymentProvider, error) {
+	switch cfg.PaymentProvider {
+	case "fake":
+		return service.NewFakePaymentProvider(), nil
+	default:
+		return nil, fmt.Errorf("unsupported payment provider %q", cfg.PaymentProvider)
+	}
+}
+
+func provideInventoryService(
+	cfg *config.Config,
+	repo repository.InventoryRepository,
+	products repository.ProductRepository,
+) service.InventoryService {
+	return service.NewInventoryService(repo, products, cfg.InventoryReservationTTL, cfg.InventoryReservationMaxTTL)
+}
+
+func provideProductImportService(
+	cfg *config.Config,
+	jobs repository.ProductImportJobRepository,
+	repo repository.ProductRepository,
+	products service.ProductService,
+	logger *slog.Logger,
+) *service.DefaultProductImportService {
+	return service.NewProductImportService(jobs, repo, products, cfg.ProductImportMaxRows, logger)
+}
+
 func provideRBACPermissionCacheStore(cfg *config.Config, redisClient redis.UniversalClient) service.RBACPermissionCacheStore {
 	if !cfg.RBACPermissionCacheEnabled {
 		return service.NewNoopRBACPermissionCacheStore()
@@ -257,6 +357,37 @@ func provideNegativeLookupCacheStore(cfg *config.Config, redisClient redis.Unive
 	return service.NewRedisNegativeLookupCacheStore(redisClient, composeRedisPrefix(cfg.RedisKeyNamespace, cfg.NegativeLookupCacheRedisPref))
 }
 
+// provideFeatureFlagChangeBroker fans flag changes out across replicas through
+// Redis when the shared evaluation cache is enabled, since per-replica caches
+// and streams are otherwise only consistent within one process.
+func provideFeatureFlagChangeBroker(cfg *config.Config, redisClient redis.UniversalClient) service.FeatureFlagChangeBroker {
+	if !cfg.FeatureFlagEvalCacheRedis || redisClient == nil {
+		return service.NewInProcessFeatureFlagChangeBroker()
+	}
+	return service.NewRedisFeatureFlagChangeBroker(redisClient, composeRedisPrefix(cfg.RedisKeyNamespace, "feature_flag_changes"))
+}
+
+// provideFeatureFlagExposureRecorder buffers evaluation telemetry in memory
+// for the exposure flush loop; with exposure tracking disabled nothing is
+// recorded.
+func provideFeatureFlagExposureRecorder(cfg *config.Config, repo repository.FeatureFlagExposureRepository) service.FeatureFlagExposureRecorder {
+	if !cfg.FeatureFlagExposureEnabled {
+		return service.NewNoopFeatureFlagExposureRecorder()
+	}
+	return service.NewBufferedFeatureFlagExposureRecorder(repo, cfg.FeatureFlagExposureBuffer)
+}
+
+// provideFeatureFlagGuardrailTracker counts HTTP responses for the
+// http_error_rate schedule guardrail. With Redis-backed flag evaluation every
+// replica shares its counts, so the guardrail covers the whole fleet.
+func provideFeatureFlagGuardrailTracker(cfg *config.Config, redisClient redis.UniversalClient) *service.HTTPErrorRateTracker {
+	if !cfg.FeatureFlagEvalCacheRedis || redisClient == nil {
+		return service.NewHTTPErrorRateTracker(cfg.FeatureFlagGuardrailWindow, cfg.FeatureFlagGuardrailMinReqs)
+	}
+	store := service.NewRedisHTTPErrorRateStore(redisClient, composeRedisPrefix(cfg.RedisKeyNamespace, "feature_flag_guardrail"))
+	return service.NewSharedHTTPErrorRateTracker(cfg.FeatureFlagGuardrailWindow, cfg.FeatureFlagGuardrailMinReqs, store)
+}
+
 func provideFeatureFlagEvaluationCacheStore(cfg *config.Config, redisClient redis.UniversalClient) service.FeatureFlagEvaluationCacheStore {
 	if !cfg.FeatureFlagEvalCacheRedis || redisClient == nil {
 		return service.NewInMemoryFeatureFlagEvaluationCacheStore()
@@ -332,9 +463,10 @@ func provideAuthHandler(
 	abuseGuard service.AuthAbuseGuard,
 	cookieMgr *security.CookieManager,
 	bypassEvaluator middleware.BypassEvaluator,
+	carts service.CartService,
 	cfg *config.Config,
 ) *handler.AuthHandler {
-	return handler.NewAuthHandler(authSvc, abuseGuard, cookieMgr, bypassEvaluator, cfg.StateSigningSecret, cfg.JWTRefreshTTL)
+	return handler.NewAuthHandler(authSvc, abuseGuard, cookieMgr, bypassEvaluator, cfg.StateSigningSecret, cfg.JWTRefreshTTL).WithCartMerger(carts)
 }
 
 func provideRequestBypassEvaluator(cfg *config.Config, jwt *security.JWTManager) middleware.BypassEvaluator {
@@ -541,7 +673,17 @@ func provideRouterDependencies(
 	userHandler *handler.UserHandler,
 	adminHandler *handler.AdminHandler,
 	featureFlagHandler *handler.FeatureFlagHandler,
+	featureFlagScheduleHandler *handler.FeatureFlagScheduleHandler,
+	featureFlagUsageHandler *handler.FeatureFlagUsageHandler,
 	productHandler *handler.ProductHandler,
+	productImportHandler *handler.ProductImportHandler,
+	categoryHandler *handler.CategoryHandler,
+	inventoryHandler *handler.InventoryHandler,
+	cartHandler *handler.CartHandler,
+	orderHandler *handler.OrderHandler,
+	organizationHandler *handler.OrganizationHandler,
+	organizationService service.OrganizationService,
+	groupHandler *handler.GroupHandler,
 	jwt *security.JWTManager,
 	rbac service.RBACAuthorizer,
 	permissionResolver service.PermissionResolver,
@@ -551,14 +693,25 @@ func provideRouterDependencies(
 	routePolicies router.RouteRateLimitPolicies,
 	idempotencyFactory router.IdempotencyMiddlewareFactory,
 	readiness *health.ProbeRunner,
+	errorRateTracker *service.HTTPErrorRateTracker,
 	cfg *config.Config,
 ) router.Dependencies {
-	return router.Dependencies{
+	dep := router.Dependencies{
 		AuthHandler:                authHandler,
 		UserHandler:                userHandler,
 		AdminHandler:               adminHandler,
 		FeatureFlagHandler:         featureFlagHandler,
+		FeatureFlagScheduleHandler: featureFlagScheduleHandler,
+		FeatureFlagUsageHandler:    featureFlagUsageHandler,
 		ProductHandler:             productHandler,
+		ProductImportHandler:       productImportHandler,
+		CategoryHandler:            categoryHandler,
+		InventoryHandler:           inventoryHandler,
+		CartHandler:                cartHandler,
+		OrderHandler:               orderHandler,
+		OrganizationHandler:        organizationHandler,
+		OrganizationService:        organizationService,
+		GroupHandler:               groupHandler,
 		JWTManager:                 jwt,
 		RBACService:                rbac,
 		PermissionResolver:         permissionResolver,
@@ -566,6 +719,7 @@ func provideRouterDependencies(
 		AuthRateLimitRPM:           cfg.AuthRateLimitPerMin,
 		PasswordForgotRateLimitRPM: cfg.AuthPasswordForgotRateLimitPerMin,
 		APIRateLimitRPM:            cfg.APIRateLimitPerMin,
+		ProductImportMaxBytes:      int64(cfg.ProductImportMaxBytes),
 		GlobalRateLimiter:          globalRateLimiter,
 		AuthRateLimiter:            authRateLimiter,
 		ForgotRateLimiter:          forgotRateLimiter,
@@ -574,6 +728,11 @@ func provideRouterDependencies(
 		Readiness:                  readiness,
 		EnableOTelHTTP:             cfg.OTELMetricsEnabled || cfg.OTELTracingEnabled,
 	}
+	// A nil tracker must not become a non-nil interface value.
+	if errorRateTracker != nil {
+		dep.ResponseStatusRecorder = errorRateTracker
+	}
+	return dep
 }
 
 func provideHTTPServer(cfg *config.Config, h http.Handler) *http.Server {
@@ -609,8 +768,57 @@ func provideApp(
 	redisClient redis.UniversalClient,
 	readiness *health.ProbeRunner,
 	idempotencyStore service.IdempotencyStore,
+	roleGrantReaper *service.RoleGrantReaper,
+	roleChangeRequests service.RoleChangeRequestService,
+	featureFlagChanges service.FeatureFlagChangeBroker,
+	featureFlagScheduler *service.DefaultFeatureFlagScheduleService,
+	featureFlagExposures service.FeatureFlagExposureRecorder,
+	featureFlagGuardrails *service.HTTPErrorRateTracker,
+	trashPurger *service.TrashPurger,
+	reservationReaper *service.ReservationReaper,
+	productImports *service.DefaultProductImportService,
 ) *app.App {
-	stopBackgroundTasks := startDBIdempotencyCleanup(cfg, logger, idempotencyStore)
+	stopIdempotencyCleanup := startDBIdempotencyCleanup(cfg, logger, idempotencyStore)
+	stopRoleGrantReaper := startRoleGrantReaper(cfg, logger, roleGrantReaper)
+	stopRoleChangeRequestExpiry := startRoleChangeRequestExpiry(cfg, logger, roleChangeRequests)
+	stopFeatureFlagChangeRelay := startFeatureFlagChangeRelay(logger, featureFlagChanges)
+	stopFeatureFlagScheduler := startFeatureFlagScheduler(cfg, logger, featureFlagScheduler)
+	stopFeatureFlagExposureFlush := startFeatureFlagExposureFlush(cfg, logger, featureFlagExposures)
+	stopFeatureFlagGuardrailFlush := startFeatureFlagGuardrailFlush(logger, featureFlagGuardrails)
+	stopTrashPurger := startTrashPurger(cfg, logger, trashPurger)
+	stopReservationReaper := startReservationReaper(cfg, logger, reservationReaper)
+	stopBackgroundTasks := func() {
+		if stopIdempotencyCleanup != nil {
+			stopIdempotencyCleanup()
+		}
+		if stopRoleGrantReaper != nil {
+			stopRoleGrantReaper()
+		}
+		if stopRoleChangeRequestExpiry != nil {
+			stopRoleChangeRequestExpiry()
+		}
+		if stopFeatureFlagChangeRelay != nil {
+			stopFeatureFlagChangeRelay()
+		}
+		if stopFeatureFlagScheduler != nil {
+			stopFeatureFlagScheduler()
+		}
+		if stopFeatureFlagExposureFlush != nil {
+			stopFeatureFlagExposureFlush()
+		}
+		if stopFeatureFlagGuardrailFlush != nil {
+			stopFeatureFlagGuardrailFlush()
+		}
+		if stopTrashPurger != nil {
+			stopTrashPurger()
+		}
+		if stopReservationReaper != nil {
+			stopReservationReaper()
+		}
+		if productImports != nil {
+			productImports.Close()
+		}
+	}
 	return app.New(cfg, logger, server, runtime, db, redisClient, readiness, stopBackgroundTasks)
 }
 
@@ -630,3 +838,117 @@ func startDBIdempotencyCleanup(
 	go dbStore.RunCleanupLoop(ctx, cfg.IdempotencyDBCleanupInterval, cfg.IdempotencyDBCleanupBatch, logger)
 	return cancel
 }
+
+func startRoleGrantReaper(
+	cfg *config.Config,
+	logger *slog.Logger,
+	reaper *service.RoleGrantReaper,
+) func() {
+	if !cfg.RBACRoleGrantReaperEnabled || reaper == nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go reaper.RunCleanupLoop(ctx, cfg.RBACRoleGrantReaperInterval, cfg.RBACRoleGrantReaperBatch, logger)
+	return cancel
+}
+
+// startRoleChangeRequestExpiry runs on the role grant reaper's schedule so
+// pending role change requests expire as promptly as the grants they carry.
+func startRoleChangeRequestExpiry(
+	cfg *config.Config,
+	logger *slog.Logger,
+	requests service.RoleChangeRequestService,
+) func() {
+	if !cfg.RBACRoleGrantReaperEnabled {
+		return nil
+	}
+	expirer, ok := requests.(*service.DefaultRoleChangeRequestService)
+	if !ok || expirer == nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go expirer.RunExpiryLoop(ctx, cfg.RBACRoleGrantReaperInterval, cfg.RBACRoleGrantReaperBatch, logger)
+	return cancel
+}
+
+func startFeatureFlagChangeRelay(logger *slog.Logger, broker service.FeatureFlagChangeBroker) func() {
+	redisBroker, ok := broker.(*service.RedisFeatureFlagChangeBroker)
+	if !ok || redisBroker == nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go redisBroker.Run(ctx, logger)
+	return cancel
+}
+
+func startFeatureFlagScheduler(
+	cfg *config.Config,
+	logger *slog.Logger,
+	scheduler *service.DefaultFeatureFlagScheduleService,
+) func() {
+	if !cfg.FeatureFlagSchedulerEnabled || scheduler == nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go scheduler.RunLoop(ctx, cfg.FeatureFlagSchedulerInterval, cfg.FeatureFlagSchedulerBatch, logger)
+	return cancel
+}
+
+func startFeatureFlagExposureFlush(
+	cfg *config.Config,
+	logger *slog.Logger,
+	recorder service.FeatureFlagExposureRecorder,
+) func() {
+	buffered, ok := recorder.(*service.BufferedFeatureFlagExposureRecorder)
+	if !ok || buffered == nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		buffered.RunLoop(ctx, cfg.FeatureFlagExposureFlushInterval, cfg.FeatureFlagExposureRetention, logger)
+	}()
+	// Wait for the final flush, so buffered exposures are written before
+	// the database is closed.
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func startFeatureFlagGuardrailFlush(logger *slog.Logger, tracker *service.HTTPErrorRateTracker) func() {
+	if tracker == nil || !tracker.Shared() {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go tracker.RunFlushLoop(ctx, logger)
+	return cancel
+}
+
+func startTrashPurger(
+	cfg *config.Config,
+	logger *slog.Logger,
+	purger *service.TrashPurger,
+) func() {
+	if !cfg.TrashPurgeEnabled || purger == nil {
+		return nil
+	}
+	retention := time.Duration(cfg.TrashRetentionDays) * 24 * time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	go purger.RunCleanupLoop(ctx, cfg.TrashPurgeInterval, retention, cfg.TrashPurgeBatch, logger)
+	return cancel
+}
+
+func startReservationReaper(
+	cfg *config.Config,
+	logger *slog.Logger,
+	reaper *service.ReservationReaper,
+) func() {
+	if !cfg.InventoryReaperEnabled || reaper == nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go reaper.RunCleanupLoop(ctx, cfg.InventoryReaperInterval, cfg.InventoryReaperBatch, logger)
+	return cancel
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/http/middleware"
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/http/router"
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/security"
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
 )
@@ -37,7 +38,7 @@ func TestProvideHTTPServer(t *testing.T) {
 
 func TestProvideRouterDependencies(t *testing.T) {
 	cfg := &config.Config{CORSAllowedOrigins: []string{"http://localhost:3000"}, AuthRateLimitPerMin: 10, APIRateLimitPerMin: 100, OTELMetricsEnabled: true}
-	dep := provideRouterDependencies(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, cfg)
+	dep := provideRouterDependencies(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, cfg)
 	if dep.AuthRateLimitRPM != 10 || dep.APIRateLimitRPM != 100 {
 		t.Fatalf("unexpected rate limits: %+v", dep)
 	}
@@ -425,7 +426,7 @@ func TestProvideApp(t *testing.T) {
 	srv := &http.Server{Addr: ":8080", ReadHeaderTimeout: time.Second}
 	runtime := &observability.Runtime{}
 
-	app := provideApp(cfg, logger, srv, runtime, nil, nil, nil, nil)
+	app := provideApp(cfg, logger, srv, runtime, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	if app == nil {
 		t.Fatal("expected app")
 	}
@@ -467,6 +468,166 @@ func TestStartDBIdempotencyCleanupDisabledWhenRedisStoreUsed(t *testing.T) {
 	}
 }
 
+func TestStartRoleGrantReaper(t *testing.T) {
+	db := newDIUnitTestDB(t)
+	reaper := service.NewRoleGrantReaper(repository.NewUserRepository(db), service.NewNoopRBACPermissionCacheStore())
+	cfg := &config.Config{
+		RBACRoleGrantReaperEnabled:  true,
+		RBACRoleGrantReaperInterval: 10 * time.Millisecond,
+		RBACRoleGrantReaperBatch:    100,
+	}
+	stop := startRoleGrantReaper(cfg, slog.Default(), reaper)
+	if stop == nil {
+		t.Fatal("expected stop function when role grant reaper is enabled")
+	}
+	stop()
+
+	cfg.RBACRoleGrantReaperEnabled = false
+	if stop := startRoleGrantReaper(cfg, slog.Default(), reaper); stop != nil {
+		t.Fatal("expected no stop function when role grant reaper is disabled")
+	}
+}
+
+func TestStartRoleChangeRequestExpiry(t *testing.T) {
+	db := newDIUnitTestDB(t)
+	userRepo := repository.NewUserRepository(db)
+	requests := service.NewRoleChangeRequestService(repository.NewRoleChangeRequestRepository(db), service.NewUserService(userRepo, service.NewRBACService()), time.Hour)
+	cfg := &config.Config{
+		RBACRoleGrantReaperEnabled:  true,
+		RBACRoleGrantReaperInterval: 10 * time.Millisecond,
+		RBACRoleGrantReaperBatch:    100,
+	}
+	stop := startRoleChangeRequestExpiry(cfg, slog.Default(), requests)
+	if stop == nil {
+		t.Fatal("expected stop function when the role grant reaper is enabled")
+	}
+	stop()
+
+	cfg.RBACRoleGrantReaperEnabled = false
+	if stop := startRoleChangeRequestExpiry(cfg, slog.Default(), requests); stop != nil {
+		t.Fatal("expected no stop function when the role grant reaper is disabled")
+	}
+}
+
+func TestStartTrashPurger(t *testing.T) {
+	db := newDIUnitTestDB(t)
+	purger := service.NewTrashPurger(repository.NewProductRepository(db), repository.NewUserRepository(db), nil)
+	cfg := &config.Config{
+		TrashPurgeEnabled:  true,
+		TrashPurgeInterval: 10 * time.Millisecond,
+		TrashPurgeBatch:    100,
+		TrashRetentionDays: 30,
+	}
+	stop := startTrashPurger(cfg, slog.Default(), purger)
+	if stop == nil {
+		t.Fatal("expected stop function when trash purger is enabled")
+	}
+	stop()
+
+	cfg.TrashPurgeEnabled = false
+	if stop := startTrashPurger(cfg, slog.Default(), purger); stop != nil {
+		t.Fatal("expected no stop function when trash purger is disabled")
+	}
+}
+
+func TestStartReservationReaper(t *testing.T) {
+	db := newDIUnitTestDB(t)
+	reaper := service.NewReservationReaper(repository.NewInventoryRepository(db))
+	cfg := &config.Config{
+		InventoryReaperEnabled:  true,
+		InventoryReaperInterval: 10 * time.Millisecond,
+		InventoryReaperBatch:    100,
+	}
+	stop := startReservationReaper(cfg, slog.Default(), reaper)
+	if stop == nil {
+		t.Fatal("expected stop function when reservation reaper is enabled")
+	}
+	stop()
+
+	cfg.InventoryReaperEnabled = false
+	if stop := startReservationReaper(cfg, slog.Default(), reaper); stop != nil {
+		t.Fatal("expected no stop function when reservation reaper is disabled")
+	}
+}
+
+func TestStartFeatureFlagExposureFlushWaitsForFinalFlush(t *testing.T) {
+	db := newDIUnitTestDB(t)
+	if err := db.AutoMigrate(&domain.FeatureFlagEvaluationStat{}, &domain.FeatureFlagExposure{}); err != nil {
+		t.Fatalf("migrate exposures: %v", err)
+	}
+	repo := repository.NewFeatureFlagExposureRepository(db)
+	recorder := service.NewBufferedFeatureFlagExposureRecorder(repo, 100)
+	cfg := &config.Config{FeatureFlagExposureFlushInterval: time.Hour}
+	stop := startFeatureFlagExposureFlush(cfg, slog.Default(), recorder)
+	if stop == nil {
+		t.Fatal("expected stop function for buffered recorder")
+	}
+	recorder.Record(context.Background(), service.FeatureFlagEvaluationContext{UserID: 7}, []service.FeatureFlagEvaluationResult{{Key: "beta", Enabled: true, Source: "default"}})
+	stop()
+
+	exposures, err := repo.ListExposures("beta", 0, 10)
+	if err != nil {
+		t.Fatalf("list exposures: %v", err)
+	}
+	if len(exposures) != 1 || exposures[0].UserID != 7 {
+		t.Fatalf("expected exposure flushed before stop returned, got %+v", exposures)
+	}
+}
+
+func TestProvideFeatureFlagChangeBroker(t *testing.T) {
+	cfg := &config.Config{FeatureFlagEvalCacheRedis: true, RedisKeyNamespace: "app"}
+	if _, ok := provideFeatureFlagChangeBroker(cfg, nil).(*service.InProcessFeatureFlagChangeBroker); !ok {
+		t.Fatal("expected in-process broker without redis client")
+	}
+	if stop := startFeatureFlagChangeRelay(slog.Default(), provideFeatureFlagChangeBroker(cfg, nil)); stop != nil {
+		t.Fatal("expected no relay for in-process broker")
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+	defer client.Close()
+	broker := provideFeatureFlagChangeBroker(cfg, client)
+	if _, ok := broker.(*service.RedisFeatureFlagChangeBroker); !ok {
+		t.Fatal("expected redis broker when feature flag redis cache is enabled")
+	}
+	stop := startFeatureFlagChangeRelay(slog.Default(), broker)
+	if stop == nil {
+		t.Fatal("expected relay stop function for redis broker")
+	}
+	stop()
+
+	cfg.FeatureFlagEvalCacheRedis = false
+	if _, ok := provideFeatureFlagChangeBroker(cfg, client).(*service.InProcessFeatureFlagChangeBroker); !ok {
+		t.Fatal("expected in-process broker when feature flag redis cache is disabled")
+	}
+}
+
+func TestProvideFeatureFlagGuardrailTrackerSharesCountsOverRedis(t *testing.T) {
+	cfg := &config.Config{FeatureFlagEvalCacheRedis: true, FeatureFlagGuardrailWindow: time.Minute, FeatureFlagGuardrailMinReqs: 1}
+	if tracker := provideFeatureFlagGuardrailTracker(cfg, nil); tracker.Shared() {
+		t.Fatal("expected a replica-local tracker without redis client")
+	}
+	if stop := startFeatureFlagGuardrailFlush(slog.Default(), provideFeatureFlagGuardrailTracker(cfg, nil)); stop != nil {
+		t.Fatal("expected no flush loop for a replica-local tracker")
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+	defer client.Close()
+	tracker := provideFeatureFlagGuardrailTracker(cfg, client)
+	if !tracker.Shared() {
+		t.Fatal("expected a shared tracker when feature flag redis cache is enabled")
+	}
+	stop := startFeatureFlagGuardrailFlush(slog.Default(), tracker)
+	if stop == nil {
+		t.Fatal("expected flush stop function for a shared tracker")
+	}
+	stop()
+
+	cfg.FeatureFlagEvalCacheRedis = false
+	if provideFeatureFlagGuardrailTracker(cfg, client).Shared() {
+		t.Fatal("expected a replica-local tracker when feature flag redis cache is disabled")
+	}
+}
+
 func newDIUnitTestDB(t *testing.T) *gorm.DB {
 	t.Helper()
 	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"))
@@ -49,7 +49,10 @@ func InitializeApp() (*app.App, error) {
 	authAbuseGuard := provideAuthAbuseGuard(configConfig, universalClient)
 	cookieManager := provideCookieManager(configConfig)
 	bypassEvaluator := provideRequestBypassEvaluator(configConfig, jwtManager)
-	authHandler := provideAuthHandler(authService, authAbuseGuard, cookieManager, bypassEvaluator, configConfig)
+	cartRepository := repository.NewCartRepository(db)
+	productRepository := repository.NewProductRepository(db)
+	defaultCartService := service.NewCartService(cartRepository, productRepository)
+	authHandler := provideAuthHandler(authService, authAbuseGuard, cookieManager, bypassEvaluator, defaultCartService, configConfig)
 	sessionService := provideSessionService(configConfig, sessionRepository)
 	storageService, err := provideStorageService(configConfig)
 	if err != nil {
@@ -61,14 +64,47 @@ func InitializeApp() (*app.App, error) {
 	permissionResolver := providePermissionResolver(configConfig, userService, rbacPermissionCacheStore)
 	adminListCacheStore := provideAdminListCacheStore(configConfig, universalClient)
 	negativeLookupCacheStore := provideNegativeLookupCacheStore(configConfig, universalClient)
-	adminHandler := handler.NewAdminHandler(userService, userRepository, roleRepository, permissionRepository, rbacService, permissionResolver, adminListCacheStore, negativeLookupCacheStore, db, configConfig)
+	roleChangeRequestRepository := repository.NewRoleChangeRequestRepository(db)
+	roleChangeRequestService := provideRoleChangeRequestService(configConfig, roleChangeRequestRepository, userService)
+	adminHandler := handler.NewAdminHandler(userService, userRepository, roleRepository, permissionRepository, rbacService, permissionResolver, adminListCacheStore, negativeLookupCacheStore, db, configConfig, roleChangeRequestService)
 	featureFlagRepository := repository.NewFeatureFlagRepository(db)
 	featureFlagEvaluationCacheStore := provideFeatureFlagEvaluationCacheStore(configConfig, universalClient)
-	defaultFeatureFlagService := service.NewFeatureFlagService(featureFlagRepository, featureFlagEvaluationCacheStore)
+	featureFlagChangeBroker := provideFeatureFlagChangeBroker(configConfig, universalClient)
+	featureFlagExposureRepository := repository.NewFeatureFlagExposureRepository(db)
+	featureFlagExposureRecorder := provideFeatureFlagExposureRecorder(configConfig, featureFlagExposureRepository)
+	defaultFeatureFlagService := service.NewFeatureFlagService(featureFlagRepository, featureFlagEvaluationCacheStore, featureFlagChangeBroker, featureFlagExposureRecorder)
 	featureFlagHandler := handler.NewFeatureFlagHandler(defaultFeatureFlagService)
-	productRepository := repository.NewProductRepository(db)
-	productServiceImpl := service.NewProductService(productRepository)
-	productHandler := handler.NewProductHandler(productServiceImpl)
+	featureFlagScheduleRepository := repository.NewFeatureFlagScheduleRepository(db)
+	httpErrorRateTracker := provideFeatureFlagGuardrailTracker(configConfig, universalClient)
+	defaultFeatureFlagScheduleService := service.NewFeatureFlagScheduleService(featureFlagScheduleRepository, defaultFeatureFlagService, httpErrorRateTracker)
+	featureFlagScheduleHandler := handler.NewFeatureFlagScheduleHandler(defaultFeatureFlagScheduleService)
+	defaultFeatureFlagUsageService := service.NewFeatureFlagUsageService(featureFlagRepository, featureFlagExposureRepository)
+	featureFlagUsageHandler := handler.NewFeatureFlagUsageHandler(defaultFeatureFlagUsageService)
+	categoryRepository := repository.NewCategoryRepository(db)
+	productServiceImpl := service.NewProductService(productRepository, categoryRepository, storageService)
+	productHandler := handler.NewProductHandler(productServiceImpl, configConfig)
+	productImportJobRepository := repository.NewProductImportJobRepository(db)
+	defaultProductImportService := provideProductImportService(configConfig, productImportJobRepository, productRepository, productServiceImpl, logger)
+	productImportHandler := handler.NewProductImportHandler(defaultProductImportService)
+	defaultCategoryService := service.NewCategoryService(categoryRepository)
+	categoryHandler := handler.NewCategoryHandler(defaultCategoryService)
+	inventoryRepository := repository.NewInventoryRepository(db)
+	inventoryService := provideInventoryService(configConfig, inventoryRepository, productRepository)
+	inventoryHandler := handler.NewInventoryHandler(inventoryService)
+	cartHandler := handler.NewCartHandler(defaultCartService, cookieManager)
+	orderRepository := repository.NewOrderRepository(db)
+	paymentProvider, err := providePaymentProvider(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	defaultOrderService := service.NewOrderService(orderRepository, cartRepository, productRepository, paymentProvider)
+	orderHandler := handler.NewOrderHandler(defaultOrderService)
+	organizationRepository := repository.NewOrganizationRepository(db)
+	organizationService := provideOrganizationService(configConfig, organizationRepository, roleRepository, rbacService)
+	organizationHandler := handler.NewOrganizationHandler(organizationService)
+	groupRepository := repository.NewGroupRepository(db)
+	groupService := provideGroupService(configConfig, groupRepository, roleRepository, userRepository, permissionResolver)
+	groupHandler := handler.NewGroupHandler(groupService, adminListCacheStore)
 	globalRateLimiterFunc := provideGlobalRateLimiter(configConfig, universalClient, jwtManager, bypassEvaluator)
 	authRateLimiterFunc := provideAuthRateLimiter(configConfig, universalClient, bypassEvaluator)
 	forgotRateLimiterFunc := provideForgotRateLimiter(configConfig, universalClient, bypassEvaluator)
@@ -76,10 +112,13 @@ func InitializeApp() (*app.App, error) {
 	idempotencyStore := provideIdempotencyStore(configConfig, db, universalClient)
 	idempotencyMiddlewareFactory := provideIdempotencyMiddlewareFactory(configConfig, idempotencyStore)
 	probeRunner := provideReadinessProbeRunner(configConfig, db, universalClient)
-	dependencies := provideRouterDependencies(authHandler, userHandler, adminHandler, featureFlagHandler, productHandler, jwtManager, rbacService, permissionResolver, globalRateLimiterFunc, authRateLimiterFunc, forgotRateLimiterFunc, routeRateLimitPolicies, idempotencyMiddlewareFactory, probeRunner, configConfig)
+	dependencies := provideRouterDependencies(authHandler, userHandler, adminHandler, featureFlagHandler, featureFlagScheduleHandler, featureFlagUsageHandler, productHandler, productImportHandler, categoryHandler, inventoryHandler, cartHandler, orderHandler, organizationHandler, organizationService, groupHandler, jwtManager, rbacService, permissionResolver, globalRateLimiterFunc, authRateLimiterFunc, forgotRateLimiterFunc, routeRateLimitPolicies, idempotencyMiddlewareFactory, probeRunner, httpErrorRateTracker, configConfig)
 	httpHandler := router.NewRouter(dependencies)
 	server := provideHTTPServer(configConfig, httpHandler)
-	appApp := provideApp(configConfig, logger, server, runtime, db, universalClient, probeRunner, idempotencyStore)
+	roleGrantReaper := service.NewRoleGrantReaper(userRepository, rbacPermissionCacheStore)
+	trashPurger := service.NewTrashPurger(productRepository, userRepository, storageService)
+	reservationReaper := service.NewReservationReaper(inventoryRepository)
+	appApp := provideApp(configConfig, logger, server, runtime, db, universalClient, probeRunner, idempotencyStore, roleGrantReaper, roleChangeRequestService, featureFlagChangeBroker, defaultFeatureFlagScheduleService, featureFlagExposureRecorder, httpErrorRateTracker, trashPurger, reservationReaper, defaultProductImportService)
 	return appApp, nil
 }
 
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// Cart belongs either to a user or, before sign-in, to a guest identified by
+// an opaque token of which only the SHA-256 hash is stored. A guest cart is
+// merged into the user's cart when the guest signs in.
+type Cart struct {
+	ID             uint    `gorm:"primaryKey" json:"id"`
+	UserID         *uint   `gorm:"uniqueIndex" json:"user_id,omitempty"`
+	GuestTokenHash *string `gorm:"size:64;uniqueIndex" json:"-"`
+	// GuestToken is only set on the response that issued it.
+	GuestToken string     `gorm:"-" json:"guest_token,omitempty"`
+	Items      []CartItem `gorm:"foreignKey:CartID" json:"items"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+type CartItem struct {
+	ID        uint      `gorm:"primaryKey" json:"-"`
+	CartID    uint      `gorm:"not null;uniqueIndex:idx_cart_items_cart_product,priority:1" json:"-"`
+	ProductID uint      `gorm:"not null;uniqueIndex:idx_cart_items_cart_product,priority:2" json:"product_id"`
+	Quantity  int64     `gorm:"not null" json:"quantity"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+const (
+	CategoryAttributeString  = "string"
+	CategoryAttributeNumber  = "number"
+	CategoryAttributeBoolean = "boolean"
+	CategoryAttributeEnum    = "enum"
+)
+
+// Category groups products into a tree. A category's attribute schema is
+// inherited by its descendants, so a product is validated against the
+// attributes of its category and every ancestor.
+type Category struct {
+	ID          uint                `gorm:"primaryKey" json:"id"`
+	ParentID    *uint               `gorm:"index" json:"parent_id,omitempty"`
+	Name        string              `gorm:"size:100;not null" json:"name"`
+	Slug        string              `gorm:"size:100;not null;uniqueIndex" json:"slug"`
+	Description string              `gorm:"size:500" json:"description"`
+	Attributes  []CategoryAttribute `gorm:"serializer:json;type:text" json:"attributes"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// CategoryAttribute declares one typed product attribute. Options lists the
+// allowed values of an enum attribute and is empty for other types.
+type CategoryAttribute struct {
+	Key      string   `json:"key"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Options  []string `json:"options,omitempty"`
+}
@@ -1,25 +1,187 @@
 package domain
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	FeatureFlagTypeBoolean = "boolean"
+	FeatureFlagTypeString  = "string"
+	FeatureFlagTypeNumber  = "number"
+	FeatureFlagTypeJSON    = "json"
+)
 
 type FeatureFlag struct {
-	ID          uint              `gorm:"primaryKey" json:"id"`
-	Key         string            `gorm:"uniqueIndex;size:128;not null" json:"key"`
-	Description string            `gorm:"size:512" json:"description"`
-	Enabled     bool              `gorm:"not null;default:false" json:"enabled"`
-	Rules       []FeatureFlagRule `gorm:"foreignKey:FeatureFlagID;constraint:OnDelete:CASCADE" json:"rules,omitempty"`
-	CreatedAt   time.Time         `json:"created_at"`
-	UpdatedAt   time.Time         `json:"updated_at"`
+	ID             uint                      `gorm:"primaryKey" json:"id"`
+	Key            string                    `gorm:"uniqueIndex;size:128;not null" json:"key"`
+	Description    string                    `gorm:"size:512" json:"description"`
+	Enabled        bool                      `gorm:"not null;default:false" json:"enabled"`
+	Type           string                    `gorm:"size:16;not null;default:boolean" json:"type"`
+	DefaultVariant string                    `gorm:"size:64" json:"default_variant,omitempty"`
+	Variants       []FeatureFlagVariant      `gorm:"foreignKey:FeatureFlagID;constraint:OnDelete:CASCADE" json:"variants,omitempty"`
+	Rules          []FeatureFlagRule         `gorm:"foreignKey:FeatureFlagID;constraint:OnDelete:CASCADE" json:"rules,omitempty"`
+	Prerequisites  []FeatureFlagPrerequisite `gorm:"foreignKey:FeatureFlagID;constraint:OnDelete:CASCADE" json:"prerequisites,omitempty"`
+	CreatedAt      time.Time                 `json:"created_at"`
+	UpdatedAt      time.Time                 `json:"updated_at"`
+}
+
+// FeatureFlagVariant is one typed value of a multivariate flag. Value holds
+// the JSON encoding of the payload; Weight is the share (out of 100) of
+// rollout traffic allocated to the variant.
+type FeatureFlagVariant struct {
+	ID            uint            `gorm:"primaryKey" json:"id"`
+	FeatureFlagID uint            `gorm:"not null;uniqueIndex:idx_feature_flag_variant_key" json:"feature_flag_id"`
+	Key           string          `gorm:"size:64;not null;uniqueIndex:idx_feature_flag_variant_key" json:"key"`
+	Value         json.RawMessage `gorm:"type:text;not null" json:"value"`
+	Weight        int             `gorm:"not null;default:0" json:"weight"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
 }
 
+// FeatureFlagPrerequisite makes its flag serve the off state unless the
+// prerequisite flag, evaluated for the same context, serves Variant or, when
+// Variant is empty, evaluates to Enabled. Prerequisites are checked before
+// the flag's own rules.
+type FeatureFlagPrerequisite struct {
+	ID                 uint      `gorm:"primaryKey" json:"id"`
+	FeatureFlagID      uint      `gorm:"not null;uniqueIndex:idx_feature_flag_prerequisite" json:"feature_flag_id"`
+	PrerequisiteFlagID uint      `gorm:"not null;uniqueIndex:idx_feature_flag_prerequisite;index" json:"prerequisite_flag_id"`
+	Variant            string    `gorm:"size:64" json:"variant,omitempty"`
+	Enabled            *bool     `json:"enabled,omitempty"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// FeatureFlagRule matches an evaluation context. Attribute rules carry
+// Clauses combined by ClauseOperator ("and" or "or"); other rule types use
+// MatchValue or Percentage.
 type FeatureFlagRule struct {
-	ID            uint      `gorm:"primaryKey" json:"id"`
-	FeatureFlagID uint      `gorm:"not null;index" json:"feature_flag_id"`
-	Type          string    `gorm:"size:32;not null;index" json:"type"`
-	MatchValue    string    `gorm:"size:255" json:"match_value"`
-	Percentage    int       `gorm:"not null;default:0" json:"percentage"`
-	Enabled       bool      `gorm:"not null;default:false" json:"enabled"`
-	Priority      int       `gorm:"not null;default:100" json:"priority"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID             uint                `gorm:"primaryKey" json:"id"`
+	FeatureFlagID  uint                `gorm:"not null;index" json:"feature_flag_id"`
+	Type           string              `gorm:"size:32;not null;index" json:"type"`
+	MatchValue     string              `gorm:"size:255" json:"match_value"`
+	Percentage     int                 `gorm:"not null;default:0" json:"percentage"`
+	Enabled        bool                `gorm:"not null;default:false" json:"enabled"`
+	Priority       int                 `gorm:"not null;default:100" json:"priority"`
+	Variant        string              `gorm:"size:64" json:"variant,omitempty"`
+	ClauseOperator string              `gorm:"size:8" json:"clause_operator,omitempty"`
+	Clauses        []FeatureFlagClause `gorm:"serializer:json;type:text" json:"clauses,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
+
+// FeatureFlagClause tests one evaluation context attribute with an operator
+// against Values; Negate inverts the outcome.
+type FeatureFlagClause struct {
+	Attribute string   `json:"attribute"`
+	Operator  string   `json:"operator"`
+	Values    []string `json:"values"`
+	Negate    bool     `json:"negate,omitempty"`
+}
+
+// FindVariant returns the variant with the given key, if present.
+func (f FeatureFlag) FindVariant(key string) (FeatureFlagVariant, bool) {
+	for _, v := range f.Variants {
+		if v.Key == key {
+			return v, true
+		}
+	}
+	return FeatureFlagVariant{}, false
+}
+
+// FeatureFlagVersion is an immutable history entry written after every flag
+// or rule mutation. Snapshot is the flag with its variants and rules as they
+// stood after the change (before it, for deletions); Diff lists the fields
+// that changed. SourceVersion is set when the entry records a rollback.
+type FeatureFlagVersion struct {
+	ID            uint                     `gorm:"primaryKey" json:"id"`
+	FeatureFlagID uint                     `gorm:"not null;uniqueIndex:idx_feature_flag_version" json:"feature_flag_id"`
+	Version       int                      `gorm:"not null;uniqueIndex:idx_feature_flag_version" json:"version"`
+	Action        string                   `gorm:"size:32;not null" json:"action"`
+	ActorUserID   uint                     `gorm:"index" json:"actor_user_id,omitempty"`
+	SourceVersion int                      `json:"source_version,omitempty"`
+	Snapshot      json.RawMessage          `gorm:"type:text;not null" json:"snapshot"`
+	Diff          []FeatureFlagFieldChange `gorm:"serializer:json;type:text" json:"diff"`
+	CreatedAt     time.Time                `json:"created_at"`
+}
+
+// FeatureFlagFieldChange is one entry of a version diff. Field is a flag
+// column name, "variants[<key>]" or "rules[<id>]"; Before and After hold the
+// JSON values and are omitted when the element was added or removed.
+type FeatureFlagFieldChange struct {
+	Field  string          `json:"field"`
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+const (
+	FeatureFlagScheduleStatusActive    = "active"
+	FeatureFlagScheduleStatusPaused    = "paused"
+	FeatureFlagScheduleStatusCompleted = "completed"
+	FeatureFlagScheduleStatusCancelled = "cancelled"
+	FeatureFlagScheduleStatusFailed    = "failed"
+
+	FeatureFlagScheduleStepPending  = "pending"
+	FeatureFlagScheduleStepApplying = "applying"
+	FeatureFlagScheduleStepApplied  = "applied"
+	FeatureFlagScheduleStepFailed   = "failed"
+)
+
+// FeatureFlagSchedule is an ordered list of timed changes to one flag. With a
+// RuleID the steps ramp that percent rule's percentage; without one they set
+// the flag's enabled state. When GuardrailMetric is set the schedule pauses
+// instead of applying a step while the metric is above GuardrailThreshold.
+type FeatureFlagSchedule struct {
+	ID                 uint                      `gorm:"primaryKey" json:"id"`
+	FeatureFlagID      uint                      `gorm:"not null;index" json:"feature_flag_id"`
+	RuleID             uint                      `json:"rule_id,omitempty"`
+	Status             string                    `gorm:"size:16;not null;index" json:"status"`
+	StatusReason       string                    `gorm:"size:255" json:"status_reason,omitempty"`
+	GuardrailMetric    string                    `gorm:"size:64" json:"guardrail_metric,omitempty"`
+	GuardrailThreshold float64                   `json:"guardrail_threshold,omitempty"`
+	CreatedBy          uint                      `json:"created_by,omitempty"`
+	Steps              []FeatureFlagScheduleStep `gorm:"foreignKey:ScheduleID;constraint:OnDelete:CASCADE" json:"steps"`
+	NextRunAt          *time.Time                `gorm:"-" json:"next_run_at,omitempty"`
+	CreatedAt          time.Time                 `json:"created_at"`
+	UpdatedAt          time.Time                 `json:"updated_at"`
+}
+
+// FeatureFlagScheduleStep sets Enabled or Percentage at RunAt. Steps run in
+// Position order; ClaimedAt is the lease taken by the replica applying it.
+type FeatureFlagScheduleStep struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	ScheduleID uint       `gorm:"not null;uniqueIndex:idx_feature_flag_schedule_step" json:"schedule_id"`
+	Position   int        `gorm:"not null;uniqueIndex:idx_feature_flag_schedule_step" json:"position"`
+	RunAt      time.Time  `gorm:"not null;index" json:"run_at"`
+	Enabled    *bool      `json:"enabled,omitempty"`
+	Percentage *int       `json:"percentage,omitempty"`
+	Status     string     `gorm:"size:16;not null;index" json:"status"`
+	ClaimedAt  *time.Time `json:"-"`
+	AppliedAt  *time.Time `json:"applied_at,omitempty"`
+	Error      string     `gorm:"size:255" json:"error,omitempty"`
+}
+
+// FeatureFlagEvaluationStat counts evaluations of one flag by served variant
+// and result source. Rows are keyed by flag key and bumped in batches by the
+// exposure recorder, so they trail live traffic by one flush interval.
+type FeatureFlagEvaluationStat struct {
+	ID              uint      `gorm:"primaryKey" json:"-"`
+	FlagKey         string    `gorm:"size:128;not null;uniqueIndex:idx_feature_flag_evaluation_stat" json:"flag_key"`
+	Variant         string    `gorm:"size:64;not null;default:'';uniqueIndex:idx_feature_flag_evaluation_stat" json:"variant,omitempty"`
+	Source          string    `gorm:"size:128;not null;uniqueIndex:idx_feature_flag_evaluation_stat" json:"source"`
+	Count           int64     `gorm:"not null;default:0" json:"count"`
+	LastEvaluatedAt time.Time `gorm:"not null;index" json:"last_evaluated_at"`
+}
+
+// FeatureFlagExposure records that an identified user was served a flag
+// outcome. The recorder keeps the first exposure per user, flag and variant
+// in each flush window.
+type FeatureFlagExposure struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	FlagKey     string    `gorm:"size:128;not null;index:idx_feature_flag_exposure_flag" json:"flag_key"`
+	UserID      uint      `gorm:"not null;index" json:"user_id"`
+	Variant     string    `gorm:"size:64" json:"variant,omitempty"`
+	Enabled     bool      `gorm:"not null" json:"enabled"`
+	Source      string    `gorm:"size:128;not null" json:"source"`
+	EvaluatedAt time.Time `gorm:"not null;index;index:idx_feature_flag_exposure_flag" json:"evaluated_at"`
 }
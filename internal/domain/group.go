@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// Group bundles users so roles can be assigned once to the whole group.
+// Members inherit the group's roles in addition to their direct roles.
+type Group struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"uniqueIndex;size:100;not null" json:"name"`
+	Description string    `gorm:"size:255" json:"description"`
+	Roles       []Role    `gorm:"many2many:group_roles" json:"roles,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type GroupMember struct {
+	GroupID   uint      `gorm:"primaryKey" json:"group_id"`
+	UserID    uint      `gorm:"primaryKey;index" json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ProductStock holds a product's stock level. Reserved units are held by
+// active reservations and are not available to new ones.
+type ProductStock struct {
+	ProductID uint      `gorm:"primaryKey" json:"product_id"`
+	OnHand    int64     `gorm:"not null;default:0" json:"on_hand"`
+	Reserved  int64     `gorm:"not null;default:0" json:"reserved"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (s ProductStock) Available() int64 { return s.OnHand - s.Reserved }
+
+func (s ProductStock) MarshalJSON() ([]byte, error) {
+	type productStock ProductStock
+	return json.Marshal(struct {
+		productStock
+		Available int64 `json:"available"`
+	}{productStock(s), s.Available()})
+}
+
+const (
+	ReservationActive    = "active"
+	ReservationCommitted = "committed"
+	ReservationReleased  = "released"
+	ReservationExpired   = "expired"
+)
+
+// StockReservation holds Quantity units of a product until it is committed,
+// released, or released automatically once ExpiresAt passes.
+type StockReservation struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	ProductID uint      `gorm:"not null;index" json:"product_id"`
+	Quantity  int64     `gorm:"not null" json:"quantity"`
+	Status    string    `gorm:"size:20;not null;index:idx_stock_reservations_status_expiry,priority:1" json:"status"`
+	ExpiresAt time.Time `gorm:"not null;index:idx_stock_reservations_status_expiry,priority:2" json:"expires_at"`
+	CreatedBy *uint     `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+const (
+	StockMovementAdjust  = "adjust"
+	StockMovementReserve = "reserve"
+	StockMovementCommit  = "commit"
+	StockMovementRelease = "release"
+	StockMovementExpire  = "expire"
+)
+
+// StockMovement is an append-only ledger entry recording one change to a
+// product's stock and the levels that resulted from it.
+type StockMovement struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	ProductID     uint      `gorm:"not null;index:idx_stock_movements_product,priority:1" json:"product_id"`
+	ReservationID *uint     `gorm:"index" json:"reservation_id,omitempty"`
+	Kind          string    `gorm:"size:20;not null" json:"kind"`
+	OnHandDelta   int64     `gorm:"not null" json:"on_hand_delta"`
+	ReservedDelta int64     `gorm:"not null" json:"reserved_delta"`
+	OnHandAfter   int64     `gorm:"not null" json:"on_hand_after"`
+	ReservedAfter int64     `gorm:"not null" json:"reserved_after"`
+	Reason        string    `gorm:"size:200" json:"reason,omitempty"`
+	ActorUserID   *uint     `json:"actor_user_id,omitempty"`
+	CreatedAt     time.Time `gorm:"index:idx_stock_movements_product,priority:2" json:"created_at"`
+}
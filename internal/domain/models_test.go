@@ -147,3 +147,48 @@ func TestAssociationJoinModelsHaveCompositePrimaryKeys(t *testing.T) {
 	checkCompositePK("UserRole", reflect.TypeOf(UserRole{}), "UserID", "RoleID")
 	checkCompositePK("RolePermission", reflect.TypeOf(RolePermission{}), "RoleID", "PermissionID")
 }
+
+func TestUserEffectiveRolesMergesGroupRoles(t *testing.T) {
+	u := User{
+		Roles:      []Role{{ID: 1, Name: "user"}, {ID: 2, Name: "editor"}},
+		GroupRoles: []Role{{ID: 2, Name: "editor"}, {ID: 3, Name: "oncall"}},
+	}
+	got := u.EffectiveRoles()
+	if len(got) != 3 || got[0].ID != 1 || got[1].ID != 2 || got[2].ID != 3 {
+		t.Fatalf("unexpected effective roles: %+v", got)
+	}
+
+	direct := User{Roles: []Role{{ID: 1, Name: "user"}}}
+	if got := direct.EffectiveRoles(); len(got) != 1 || got[0].ID != 1 {
+		t.Fatalf("expected direct roles unchanged, got %+v", got)
+	}
+}
+
+func TestCanTransitionOrder(t *testing.T) {
+	allowed := [][2]string{
+		{OrderPending, OrderPaid},
+		{OrderPending, OrderCancelled},
+		{OrderPaid, OrderFulfilled},
+		{OrderPaid, OrderCancelled},
+		{OrderPaid, OrderRefunded},
+		{OrderFulfilled, OrderRefunded},
+	}
+	for _, tc := range allowed {
+		if !CanTransitionOrder(tc[0], tc[1]) {
+			t.Fatalf("expected %s -> %s to be allowed", tc[0], tc[1])
+		}
+	}
+	rejected := [][2]string{
+		{OrderPending, OrderFulfilled},
+		{OrderPending, OrderRefunded},
+		{OrderFulfilled, OrderCancelled},
+		{OrderCancelled, OrderPaid},
+		{OrderRefunded, OrderPaid},
+		{OrderPaid, OrderPaid},
+	}
+	for _, tc := range rejected {
+		if CanTransitionOrder(tc[0], tc[1]) {
+			t.Fatalf("expected %s -> %s to be rejected", tc[0], tc[1])
+		}
+	}
+}
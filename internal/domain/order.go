@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	OrderPending   = "pending"
+	OrderPaid      = "paid"
+	OrderFulfilled = "fulfilled"
+	OrderCancelled = "cancelled"
+	OrderRefunded  = "refunded"
+)
+
+// orderTransitions lists the statuses each status may move to. Cancelled and
+// refunded orders are final.
+var orderTransitions = map[string][]string{
+	OrderPending:   {OrderPaid, OrderCancelled},
+	OrderPaid:      {OrderFulfilled, OrderCancelled, OrderRefunded},
+	OrderFulfilled: {OrderRefunded},
+}
+
+// CanTransitionOrder reports whether an order in status from may move to to.
+func CanTransitionOrder(from, to string) bool {
+	for _, next := range orderTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Order is a snapshot of a checked-out cart. Item prices and the total are
+// copied from the products at checkout, in minor units of Currency, so later
+// price changes do not alter existing orders.
+type Order struct {
+	ID               uint        `gorm:"primaryKey" json:"id"`
+	UserID           uint        `gorm:"not null;index" json:"user_id"`
+	OrganizationID   *uint       `gorm:"index" json:"organization_id,omitempty"`
+	Status           string      `gorm:"size:20;not null;index" json:"status"`
+	Currency         string      `gorm:"size:3;not null" json:"currency"`
+	TotalMinor       int64       `gorm:"not null" json:"total_minor"`
+	PaymentProvider  string      `gorm:"size:40" json:"payment_provider,omitempty"`
+	PaymentReference string      `gorm:"size:100" json:"payment_reference,omitempty"`
+	Items            []OrderItem `gorm:"foreignKey:OrderID" json:"items"`
+	PaidAt           *time.Time  `json:"paid_at,omitempty"`
+	FulfilledAt      *time.Time  `json:"fulfilled_at,omitempty"`
+	CancelledAt      *time.Time  `json:"cancelled_at,omitempty"`
+	RefundedAt       *time.Time  `json:"refunded_at,omitempty"`
+	CreatedAt        time.Time   `json:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at"`
+}
+
+func (o Order) MarshalJSON() ([]byte, error) {
+	type order Order
+	return json.Marshal(struct {
+		order
+		Total string `json:"total"`
+	}{order(o), FormatMinorUnits(o.TotalMinor, o.Currency)})
+}
+
+type OrderItem struct {
+	ID             uint   `gorm:"primaryKey" json:"-"`
+	OrderID        uint   `gorm:"not null;index" json:"-"`
+	ProductID      uint   `gorm:"not null;index" json:"product_id"`
+	Name           string `gorm:"size:120;not null" json:"name"`
+	UnitPriceMinor int64  `gorm:"not null" json:"unit_price_minor"`
+	Quantity       int64  `gorm:"not null" json:"quantity"`
+	LineTotalMinor int64  `gorm:"not null" json:"line_total_minor"`
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+type Organization struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Slug      string    `gorm:"uniqueIndex;size:64;not null" json:"slug"`
+	Name      string    `gorm:"size:120;not null" json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+const (
+	MembershipActive  = "active"
+	MembershipInvited = "invited"
+)
+
+// Membership links a user to an organization. Roles on a membership are
+// org-scoped: their permissions apply only while that organization is the
+// active tenant of a request. An invited membership grants nothing until
+// the user accepts it.
+type Membership struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	OrganizationID uint      `gorm:"not null;uniqueIndex:idx_membership_org_user" json:"organization_id"`
+	UserID         uint      `gorm:"not null;uniqueIndex:idx_membership_org_user;index" json:"user_id"`
+	Status         string    `gorm:"size:16;not null;default:active" json:"status"`
+	Roles          []Role    `gorm:"many2many:membership_roles" json:"roles,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
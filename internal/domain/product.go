@@ -1,12 +1,151 @@
 package domain
 
-import "time"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
 
 type Product struct {
+	ID             uint  `gorm:"primaryKey" json:"id"`
+	OrganizationID *uint `gorm:"index;uniqueIndex:idx_products_org_sku" json:"organization_id,omitempty"`
+	OwnerID        *uint `gorm:"index" json:"owner_id,omitempty"`
+	CreatedBy      *uint `json:"created_by,omitempty"`
+	// SKU is an optional stock keeping unit, unique within the organization.
+	// Bulk imports match existing products by it.
+	SKU         *string `gorm:"size:64;uniqueIndex:idx_products_org_sku" json:"sku,omitempty"`
+	Name        string  `gorm:"size:120;not null;index" json:"name"`
+	Description string  `gorm:"size:500" json:"description"`
+	// PriceMinor is the price in the minor units of Currency, e.g. cents for
+	// USD. The JSON form also carries the decimal "price" string.
+	PriceMinor int64  `gorm:"not null;default:0;index" json:"price_minor"`
+	Currency   string `gorm:"size:3;not null;default:USD;index" json:"currency"`
+	CategoryID *uint  `gorm:"index" json:"category_id,omitempty"`
+	// Tags, Prices and Images live in the product_tags, product_prices and
+	// product_images tables and are loaded by the repository.
+	Tags       []string          `gorm:"-" json:"tags"`
+	Prices     []ProductPrice    `gorm:"-" json:"prices"`
+	Images     []ProductImage    `gorm:"-" json:"images"`
+	Attributes ProductAttributes `gorm:"not null;default:'{}'" json:"attributes"`
+	// Version starts at 1 and increases with every update. It backs the
+	// product ETag used for optimistic concurrency.
+	Version   uint      `gorm:"not null;default:1" json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// DeletedAt marks a product as moved to the trash. GORM excludes such
+	// rows from every query unless Unscoped is used.
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+}
+
+func (p Product) MarshalJSON() ([]byte, error) {
+	type product Product
+	return json.Marshal(struct {
+		product
+		Price string `json:"price"`
+	}{product(p), FormatMinorUnits(p.PriceMinor, p.Currency)})
+}
+
+// ProductPrice is an additional list price for a product in a currency other
+// than the product's own.
+type ProductPrice struct {
+	ProductID   uint   `gorm:"primaryKey" json:"-"`
+	Currency    string `gorm:"primaryKey;size:3" json:"currency"`
+	AmountMinor int64  `gorm:"not null" json:"amount_minor"`
+}
+
+func (p ProductPrice) MarshalJSON() ([]byte, error) {
+	type productPrice ProductPrice
+	return json.Marshal(struct {
+		productPrice
+		Amount string `json:"amount"`
+	}{productPrice(p), FormatMinorUnits(p.AmountMinor, p.Currency)})
+}
+
+// ProductTag attaches one free-form, lower-case tag to a product.
+type ProductTag struct {
+	ProductID uint   `gorm:"primaryKey" json:"product_id"`
+	Tag       string `gorm:"primaryKey;size:50;index" json:"tag"`
+}
+
+// ProductImage is one image in a product's ordered gallery. The file lives in
+// object storage under the product's prefix; URL is filled in for responses
+// with an API path that redirects to a short-lived presigned link.
+type ProductImage struct {
 	ID          uint      `gorm:"primaryKey" json:"id"`
-	Name        string    `gorm:"size:120;not null;index" json:"name"`
-	Description string    `gorm:"size:500" json:"description"`
-	Price       float64   `gorm:"not null" json:"price"`
+	ProductID   uint      `gorm:"not null;index" json:"product_id"`
+	ObjectKey   string    `gorm:"size:255;not null" json:"-"`
+	ContentType string    `gorm:"size:64;not null" json:"content_type"`
+	Size        int64     `gorm:"not null" json:"size"`
+	Position    int       `gorm:"not null" json:"position"`
+	CreatedBy   *uint     `json:"created_by,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	URL         string    `gorm:"-" json:"url,omitempty"`
+}
+
+// ProductAttributes holds the typed custom attributes declared by the
+// product's category. It is stored as JSONB on Postgres and as JSON text on
+// other dialects.
+type ProductAttributes map[string]any
+
+func (ProductAttributes) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "JSONB"
+	}
+	return "TEXT"
+}
+
+func (a ProductAttributes) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+	raw, err := json.Marshal(map[string]any(a))
+	if err != nil {
+		return nil, err
+	}
+	return string(raw), nil
+}
+
+func (a *ProductAttributes) Scan(value any) error {
+	var raw []byte
+	switch v := value.(type) {
+	case nil:
+		*a = ProductAttributes{}
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("unsupported product attributes type %T", value)
+	}
+	attrs := ProductAttributes{}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &attrs); err != nil {
+			return err
+		}
+	}
+	*a = attrs
+	return nil
+}
+
+const (
+	ProductAccessRead  = "read"
+	ProductAccessWrite = "write"
+)
+
+// ProductGrant shares a single product with a user or a group. Exactly one of
+// UserID and GroupID is set. Write access implies read access.
+type ProductGrant struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	ProductID uint      `gorm:"not null;index" json:"product_id"`
+	UserID    *uint     `gorm:"index" json:"user_id,omitempty"`
+	GroupID   *uint     `gorm:"index" json:"group_id,omitempty"`
+	Access    string    `gorm:"size:16;not null" json:"access"`
+	CreatedBy uint      `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	ProductImportPending   = "pending"
+	ProductImportRunning   = "running"
+	ProductImportCompleted = "completed"
+	ProductImportFailed    = "failed"
+)
+
+// ProductImportJob tracks one bulk product import. Row counters are updated
+// while the job runs; in a dry run CreatedRows and UpdatedRows count the
+// rows that would have been created or updated.
+type ProductImportJob struct {
+	ID             uint   `gorm:"primaryKey" json:"id"`
+	OrganizationID *uint  `gorm:"index" json:"organization_id,omitempty"`
+	CreatedBy      *uint  `gorm:"index" json:"created_by,omitempty"`
+	Format         string `gorm:"size:16;not null" json:"format"`
+	DryRun         bool   `gorm:"not null;default:false" json:"dry_run"`
+	Status         string `gorm:"size:16;not null;index" json:"status"`
+	ProcessedRows  int    `gorm:"not null;default:0" json:"processed_rows"`
+	CreatedRows    int    `gorm:"not null;default:0" json:"created_rows"`
+	UpdatedRows    int    `gorm:"not null;default:0" json:"updated_rows"`
+	FailedRows     int    `gorm:"not null;default:0" json:"failed_rows"`
+	// Errors lists failed rows in file order. It is capped, so FailedRows
+	// may exceed its length.
+	Errors ProductImportErrors `gorm:"not null;default:'[]'" json:"errors"`
+	// Failure explains why a failed job stopped before the end of the file.
+	Failure    string     `gorm:"size:500" json:"failure,omitempty"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// ProductImportError reports why one row of an import was rejected. Row is
+// the 1-based position of the record in the file, not counting a CSV header.
+type ProductImportError struct {
+	Row   int    `json:"row"`
+	SKU   string `json:"sku,omitempty"`
+	Error string `json:"error"`
+}
+
+// ProductImportErrors is stored as JSON text.
+type ProductImportErrors []ProductImportError
+
+func (e ProductImportErrors) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	raw, err := json.Marshal([]ProductImportError(e))
+	if err != nil {
+		return nil, err
+	}
+	return string(raw), nil
+}
+
+func (e *ProductImportErrors) Scan(value any) error {
+	var raw []byte
+	switch v := value.(type) {
+	case nil:
+		*e = ProductImportErrors{}
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("unsupported product import errors type %T", value)
+	}
+	errs := ProductImportErrors{}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &errs); err != nil {
+			return err
+		}
+	}
+	*e = errs
+	return nil
+}
@@ -11,8 +11,13 @@ type Role struct {
 	UpdatedAt   time.Time    `json:"updated_at"`
 }
 
+// UserRole is a role grant. A nil ValidFrom/ValidUntil leaves that side of the
+// validity window open, so plain assignments never expire.
 type UserRole struct {
-	UserID    uint      `gorm:"primaryKey"`
-	RoleID    uint      `gorm:"primaryKey"`
-	CreatedAt time.Time `json:"created_at"`
+	UserID        uint       `gorm:"primaryKey" json:"user_id"`
+	RoleID        uint       `gorm:"primaryKey" json:"role_id"`
+	ValidFrom     *time.Time `json:"valid_from,omitempty"`
+	ValidUntil    *time.Time `gorm:"index" json:"valid_until,omitempty"`
+	Justification string     `gorm:"size:512" json:"justification,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
 }
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+const (
+	RoleChangeRequestStatusPending  = "pending"
+	RoleChangeRequestStatusApproved = "approved"
+	RoleChangeRequestStatusRejected = "rejected"
+	RoleChangeRequestStatusExpired  = "expired"
+	RoleChangeRequestStatusFailed   = "failed"
+)
+
+// RoleChangeRequest replaces the target's role set with RoleIDs once
+// approved. BaseRoleIDs records the roles the target held at submit time;
+// the request cannot be approved after that set has changed. It is nil for
+// requests created before the snapshot was recorded.
+type RoleChangeRequest struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	TargetUserID   uint       `gorm:"not null;index" json:"target_user_id"`
+	RequestedBy    uint       `gorm:"not null;index" json:"requested_by"`
+	RoleIDs        []uint     `gorm:"serializer:json;type:text;not null" json:"role_ids"`
+	BaseRoleIDs    []uint     `gorm:"serializer:json;type:text" json:"base_role_ids"`
+	Status         string     `gorm:"size:32;not null;index" json:"status"`
+	DecidedBy      *uint      `json:"decided_by,omitempty"`
+	DecisionReason string     `gorm:"size:512" json:"decision_reason,omitempty"`
+	DecidedAt      *time.Time `json:"decided_at,omitempty"`
+	ExpiresAt      time.Time  `gorm:"index;not null" json:"expires_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type User struct {
 	ID          uint      `gorm:"primaryKey" json:"id"`
@@ -11,5 +15,29 @@ type User struct {
 	LastLoginAt time.Time `json:"last_login_at"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
-	Roles       []Role    `gorm:"many2many:user_roles" json:"roles,omitempty"`
+	// DeletedAt is set when an admin deletes the user. Deleted users cannot
+	// sign in and keep their email reserved until they are purged.
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	Roles     []Role         `gorm:"many2many:user_roles" json:"roles,omitempty"`
+	// GroupRoles are inherited through group membership. They are loaded
+	// alongside Roles but never persisted through the user.
+	GroupRoles []Role `gorm:"-" json:"group_roles,omitempty"`
+}
+
+// EffectiveRoles returns direct roles followed by any group roles not already
+// held directly.
+func (u *User) EffectiveRoles() []Role {
+	if len(u.GroupRoles) == 0 {
+		return u.Roles
+	}
+	roles := make([]Role, 0, len(u.Roles)+len(u.GroupRoles))
+	seen := make(map[uint]struct{}, len(u.Roles)+len(u.GroupRoles))
+	for _, role := range append(append([]Role{}, u.Roles...), u.GroupRoles...) {
+		if _, ok := seen[role.ID]; ok {
+			continue
+		}
+		seen[role.ID] = struct{}{}
+		roles = append(roles, role)
+	}
+	return roles
 }
@@ -24,11 +24,15 @@ import (
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/http/response"
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/security"
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
 )
 
 var permissionPartRe = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 
+// permissionActionRe additionally allows the ":own" scope suffix on actions.
+var permissionActionRe = regexp.MustCompile(`^[a-zA-Z0-9_-]+(:own)?$`)
+
 const (
 	roleNegativeLookupNamespace       = "admin.role.not_found"
 	permissionNegativeLookupNamespace = "admin.permission.not_found"
@@ -41,6 +45,7 @@ type AdminHandler struct {
 	permRepo             repository.PermissionRepository
 	rbac                 service.RBACAuthorizer
 	permissionResolver   service.PermissionResolver
+	roleChangeRequests   service.RoleChangeRequestService
 	adminListCache       service.AdminListCacheStore
 	negativeLookupCache  service.NegativeLookupCacheStore
 	adminListSingleGroup singleflight.Group
@@ -48,6 +53,7 @@ type AdminHandler struct {
 	negativeLookupTTL    time.Duration
 	db                   *gorm.DB
 	cfg                  *config.Config
+	cursors              *security.CursorSigner
 	protectedRoles       map[string]struct{}
 	protectedPermissions map[string]struct{}
 }
@@ -63,6 +69,7 @@ func NewAdminHandler(
 	negativeLookupCache service.NegativeLookupCacheStore,
 	db *gorm.DB,
 	cfg *config.Config,
+	roleChangeRequests service.RoleChangeRequestService,
 ) *AdminHandler {
 	protectedRoles := make(map[string]struct{}, len(cfg.RBACProtectedRoles))
 	for _, role := range cfg.RBACProtectedRoles {
@@ -85,12 +92,14 @@ func NewAdminHandler(
 		permRepo:             permRepo,
 		rbac:                 rbac,
 		permissionResolver:   permissionResolver,
+		roleChangeRequests:   roleChangeRequests,
 		adminListCache:       adminListCache,
 		negativeLookupCache:  negativeLookupCache,
 		adminListCacheTTL:    cfg.AdminListCacheTTL,
 		negativeLookupTTL:    cfg.NegativeLookupCacheTTL,
 		db:                   db,
 		cfg:                  cfg,
+		cursors:              security.NewCursorSigner(cfg.PaginationCursorSecret),
 		protectedRoles:       protectedRoles,
 		protectedPermissions: protectedPerms,
 	}
@@ -103,8 +112,19 @@ func (h *AdminHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 		observability.RecordAdminListRequestDuration(r.Context(), "admin.users", status, time.Since(start))
 	}()
 
+	// Org admins reach this route with a tenant selected; the list, its cache
+	// entries and its cursors are then limited to that organization's members.
+	cursorList := "admin.users"
+	var orgID uint
+	if tenant, ok := service.TenantFromContext(r.Context()); ok {
+		orgID = tenant.OrganizationID
+		cursorList = fmt.Sprintf("admin.users|org=%d", orgID)
+	}
 	cacheNamespace := "admin.users.list"
 	cacheKey := h.adminListCacheKey(r, cacheNamespace)
+	if orgID != 0 {
+		cacheKey += fmt.Sprintf("|org=%d", orgID)
+	}
 	if cachedData, ok := h.readAdminListCache(r, cacheNamespace, cacheKey); ok {
 		response.JSON(w, r, http.StatusOK, cachedData)
 		return
@@ -135,16 +155,46 @@ func (h *AdminHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 	filterEmail := strings.TrimSpace(r.URL.Query().Get("email"))
 	filterStatus := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("status")))
 	filterRole := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("role")))
+	var filterGroup uint
+	if raw := strings.TrimSpace(r.URL.Query().Get("group")); raw != "" {
+		filterGroup, err = parsePathID(raw)
+		if err != nil {
+			status = "bad_request"
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid group filter", nil)
+			return
+		}
+	}
+	cursorReq, scope, cursorMode, err := parseCursorRequest(r, h.cursors, cursorList, pageReq.PageSize)
+	if err != nil {
+		status = "bad_request"
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
+	}
+	listQuery := repository.UserListQuery{
+		PageRequest:    pageReq,
+		SortBy:         sortBy,
+		SortOrder:      sortOrder,
+		Email:          filterEmail,
+		Status:         filterStatus,
+		Role:           filterRole,
+		GroupID:        filterGroup,
+		OrganizationID: orgID,
+	}
 	sfKey := cacheNamespace + "|" + cacheKey
 	result, err, shared := h.adminListSingleGroup.Do(sfKey, func() (interface{}, error) {
-		usersPage, err := h.userRepo.ListPaged(repository.UserListQuery{
-			PageRequest: pageReq,
-			SortBy:      sortBy,
-			SortOrder:   sortOrder,
-			Email:       filterEmail,
-			Status:      filterStatus,
-			Role:        filterRole,
-		})
+		if cursorMode {
+			usersPage, err := h.userRepo.ListByCursor(listQuery, cursorReq)
+			if err != nil {
+				return nil, err
+			}
+			payload, err := cursorPaginatedData(h.cursors, scope, usersPage)
+			if err != nil {
+				return nil, err
+			}
+			h.writeAdminListCache(r, cacheNamespace, cacheKey, payload)
+			return payload, nil
+		}
+		usersPage, err := h.userRepo.ListPaged(listQuery)
 		if err != nil {
 			return nil, err
 		}
@@ -158,6 +208,11 @@ func (h *AdminHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 		observability.RecordAdminListCacheEvent(r.Context(), cacheNamespace, "singleflight_leader")
 	}
 	if err != nil {
+		if isCursorRequestError(err) {
+			status = "bad_request"
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+			return
+		}
 		observability.RecordAdminListCacheEvent(r.Context(), cacheNamespace, "error")
 		status = "error"
 		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to list users", nil)
@@ -186,6 +241,24 @@ func (h *AdminHandler) SetUserRoles(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
 		return
 	}
+	if h.cfg.RBACRoleApprovalEnabled && h.roleChangeRequests != nil {
+		needsApproval, err := h.roleChangeTouchesProtectedRole(userID, body.RoleIDs)
+		if err != nil {
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "user not found", nil)
+			case errors.Is(err, repository.ErrRoleNotFound):
+				response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "one or more roles do not exist", nil)
+			default:
+				response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to evaluate role change", nil)
+			}
+			return
+		}
+		if needsApproval {
+			h.submitRoleChangeRequest(w, r, userID, body.RoleIDs)
+			return
+		}
+	}
 	if err := h.userSvc.SetRoles(userID, body.RoleIDs); err != nil {
 		observability.RecordAdminRBACMutation(r.Context(), "user_role", "set_user_roles", "error")
 		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to set roles", nil)
@@ -206,6 +279,447 @@ func (h *AdminHandler) SetUserRoles(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, r, http.StatusOK, map[string]any{"user_id": userID, "role_ids": body.RoleIDs})
 }
 
+// holdsProtectedRole reports whether userID has a grant of a protected role,
+// including grants whose validity window has not started or has ended.
+func (h *AdminHandler) holdsProtectedRole(userID uint) (bool, error) {
+	grants, err := h.userRepo.ListRoleGrants(userID)
+	if err != nil {
+		return false, err
+	}
+	for _, grant := range grants {
+		role, err := h.roleRepo.FindByID(grant.RoleID)
+		if err != nil {
+			if errors.Is(err, repository.ErrRoleNotFound) {
+				continue
+			}
+			return false, err
+		}
+		if h.isProtectedRole(role.Name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// roleChangeTouchesProtectedRole reports whether replacing userID's roles with
+// roleIDs would add or remove any protected role.
+func (h *AdminHandler) roleChangeTouchesProtectedRole(userID uint, roleIDs []uint) (bool, error) {
+	user, _, err := h.userSvc.GetByID(userID)
+	if err != nil {
+		return false, err
+	}
+	current := make(map[uint]string, len(user.Roles))
+	for _, role := range user.Roles {
+		current[role.ID] = role.Name
+	}
+	requested := make(map[uint]struct{}, len(roleIDs))
+	for _, id := range roleIDs {
+		requested[id] = struct{}{}
+		if _, ok := current[id]; ok {
+			continue
+		}
+		role, err := h.roleRepo.FindByID(id)
+		if err != nil {
+			return false, err
+		}
+		if h.isProtectedRole(role.Name) {
+			return true, nil
+		}
+	}
+	for id, name := range current {
+		if _, ok := requested[id]; !ok && h.isProtectedRole(name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (h *AdminHandler) submitRoleChangeRequest(w http.ResponseWriter, r *http.Request, userID uint, roleIDs []uint) {
+	requesterID, err := actorIDFromRequest(r)
+	if err != nil {
+		response.Error(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "missing auth context", nil)
+		return
+	}
+	req, err := h.roleChangeRequests.Submit(r.Context(), userID, requesterID, roleIDs)
+	if err != nil {
+		observability.RecordAdminRBACMutation(r.Context(), "role_change_request", "submit", "error")
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to create role change request", nil)
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "admin.role_change_request.submit",
+		ActorUserID: adminActorID(r),
+		TargetType:  "user",
+		TargetID:    strconv.FormatUint(uint64(userID), 10),
+		Action:      "submit",
+		Outcome:     "pending",
+		Reason:      "protected_role_change",
+	}, "request_id_ref", req.ID, "role_ids", roleIDs, "expires_at", req.ExpiresAt)
+	observability.RecordAdminRBACMutation(r.Context(), "role_change_request", "submit", "success")
+	response.JSON(w, r, http.StatusAccepted, req)
+}
+
+func (h *AdminHandler) ListRoleChangeRequests(w http.ResponseWriter, r *http.Request) {
+	if h.roleChangeRequests == nil {
+		response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "role change approvals are disabled", nil)
+		return
+	}
+	pageReq, err := parsePageRequest(r)
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
+	}
+	status := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("status")))
+	switch status {
+	case "", domain.RoleChangeRequestStatusPending, domain.RoleChangeRequestStatusApproved,
+		domain.RoleChangeRequestStatusRejected, domain.RoleChangeRequestStatusExpired, domain.RoleChangeRequestStatusFailed:
+	default:
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid status filter", nil)
+		return
+	}
+	page, err := h.roleChangeRequests.List(r.Context(), pageReq, status)
+	if err != nil {
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to list role change requests", nil)
+		return
+	}
+	response.JSON(w, r, http.StatusOK, paginatedData(page.Items, page.Page, page.PageSize, page.Total, page.TotalPages))
+}
+
+func (h *AdminHandler) ApproveRoleChangeRequest(w http.ResponseWriter, r *http.Request) {
+	h.decideRoleChangeRequest(w, r, true)
+}
+
+func (h *AdminHandler) RejectRoleChangeRequest(w http.ResponseWriter, r *http.Request) {
+	h.decideRoleChangeRequest(w, r, false)
+}
+
+func (h *AdminHandler) decideRoleChangeRequest(w http.ResponseWriter, r *http.Request, approve bool) {
+	action := "reject"
+	if approve {
+		action = "approve"
+	}
+	if h.roleChangeRequests == nil {
+		response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "role change approvals are disabled", nil)
+		return
+	}
+	requestID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid role change request id", nil)
+		return
+	}
+	approverID, err := actorIDFromRequest(r)
+	if err != nil {
+		response.Error(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "missing auth context", nil)
+		return
+	}
+	var body struct {
+		Reason string `json:"reason"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
+			return
+		}
+	}
+	body.Reason = strings.TrimSpace(body.Reason)
+	if len(body.Reason) > 512 {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "reason must be at most 512 characters", nil)
+		return
+	}
+
+	var req *domain.RoleChangeRequest
+	if approve {
+		req, err = h.roleChangeRequests.Approve(r.Context(), requestID, approverID, body.Reason)
+	} else {
+		req, err = h.roleChangeRequests.Reject(r.Context(), requestID, approverID, body.Reason)
+	}
+	auditInput := observability.AuditInput{
+		EventName:   "admin.role_change_request." + action,
+		ActorUserID: adminActorID(r),
+		TargetType:  "role_change_request",
+		TargetID:    strconv.FormatUint(uint64(requestID), 10),
+		Action:      action,
+	}
+	if err != nil {
+		auditInput.Outcome = "rejected"
+		switch {
+		case errors.Is(err, repository.ErrRoleChangeRequestNotFound):
+			auditInput.Reason = "not_found"
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "role change request not found", nil)
+		case errors.Is(err, service.ErrRoleChangeSelfApproval):
+			auditInput.Reason = "self_decision"
+			response.Error(w, r, http.StatusForbidden, "FORBIDDEN", "role change request must be decided by a different admin", nil)
+		case errors.Is(err, service.ErrRoleChangeExpired):
+			auditInput.Reason = "expired"
+			response.Error(w, r, http.StatusConflict, "CONFLICT", "role change request has expired", nil)
+		case errors.Is(err, repository.ErrRoleChangeRequestNotPending):
+			auditInput.Reason = "not_pending"
+			response.Error(w, r, http.StatusConflict, "CONFLICT", "role change request is not pending", nil)
+		case errors.Is(err, service.ErrRoleChangeStale):
+			auditInput.Reason = "roles_changed"
+			response.Error(w, r, http.StatusConflict, "CONFLICT", "target user's roles changed since the request was submitted; submit a new request", nil)
+		default:
+			auditInput.Outcome = "error"
+			auditInput.Reason = "internal_error"
+			response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to "+action+" role change request", nil)
+		}
+		observability.EmitAudit(r, auditInput)
+		observability.RecordAdminRBACMutation(r.Context(), "role_change_request", action, auditInput.Outcome)
+		return
+	}
+	auditInput.Outcome = "success"
+	auditInput.Reason = req.Status
+	observability.EmitAudit(r, auditInput, "target_user_id", req.TargetUserID, "requested_by", req.RequestedBy, "role_ids", req.RoleIDs)
+	observability.RecordAdminRBACMutation(r.Context(), "role_change_request", action, "success")
+	if approve {
+		h.invalidateRBACPermissionCacheUser(r, req.TargetUserID)
+		h.invalidateAdminListCaches(r, "admin.users.list")
+	}
+	response.JSON(w, r, http.StatusOK, req)
+}
+
+func (h *AdminHandler) GrantUserRole(w http.ResponseWriter, r *http.Request) {
+	userID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid user id", nil)
+		return
+	}
+	var body struct {
+		RoleID        uint       `json:"role_id"`
+		ValidFrom     *time.Time `json:"valid_from"`
+		ValidUntil    *time.Time `json:"valid_until"`
+		Justification string     `json:"justification"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
+		return
+	}
+	body.Justification = strings.TrimSpace(body.Justification)
+	if body.RoleID == 0 {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "role_id is required", nil)
+		return
+	}
+	if len(body.Justification) > 512 {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "justification must be at most 512 characters", nil)
+		return
+	}
+	if body.ValidUntil != nil {
+		if !body.ValidUntil.After(time.Now()) {
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "valid_until must be in the future", nil)
+			return
+		}
+		if body.ValidFrom != nil && !body.ValidUntil.After(*body.ValidFrom) {
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "valid_until must be after valid_from", nil)
+			return
+		}
+		if body.Justification == "" {
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "justification is required for time-bound grants", nil)
+			return
+		}
+	}
+	if _, err := h.userRepo.FindByID(userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "user not found", nil)
+			return
+		}
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to load user", nil)
+		return
+	}
+	role, err := h.roleRepo.FindByID(body.RoleID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRoleNotFound) {
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "role not found", nil)
+			return
+		}
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to load role", nil)
+		return
+	}
+	// Protected roles need a second admin's approval, which only the role
+	// change request flow of SetUserRoles provides.
+	if h.cfg.RBACRoleApprovalEnabled && h.roleChangeRequests != nil && h.isProtectedRole(role.Name) {
+		observability.RecordAdminRBACMutation(r.Context(), "user_role", "grant_role", "rejected")
+		response.Error(w, r, http.StatusForbidden, "FORBIDDEN", "protected roles can only be assigned through an approved role change request", nil)
+		return
+	}
+
+	grant := &domain.UserRole{
+		UserID:        userID,
+		RoleID:        body.RoleID,
+		ValidFrom:     utcTimePtr(body.ValidFrom),
+		ValidUntil:    utcTimePtr(body.ValidUntil),
+		Justification: body.Justification,
+	}
+	if err := h.userRepo.GrantRole(grant); err != nil {
+		if errors.Is(err, repository.ErrRoleGrantWindowConflict) {
+			observability.RecordAdminRBACMutation(r.Context(), "user_role", "grant_role", "rejected")
+			response.Error(w, r, http.StatusConflict, "CONFLICT", "user already holds the role for a window that does not overlap the requested one", nil)
+			return
+		}
+		observability.RecordAdminRBACMutation(r.Context(), "user_role", "grant_role", "error")
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to grant role", nil)
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "admin.user_role_grant.create",
+		ActorUserID: adminActorID(r),
+		TargetType:  "user",
+		TargetID:    strconv.FormatUint(uint64(userID), 10),
+		Action:      "grant_role",
+		Outcome:     "success",
+		Reason:      "role_granted",
+	}, "role_id", body.RoleID, "valid_from", grant.ValidFrom, "valid_until", grant.ValidUntil, "justification", grant.Justification)
+	observability.RecordAdminRBACMutation(r.Context(), "user_role", "grant_role", "success")
+	h.invalidateRBACPermissionCacheUser(r, userID)
+	h.invalidateAdminListCaches(r, "admin.users.list")
+	response.JSON(w, r, http.StatusOK, grant)
+}
+
+func (h *AdminHandler) ListUserRoleGrants(w http.ResponseWriter, r *http.Request) {
+	userID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid user id", nil)
+		return
+	}
+	grants, err := h.userRepo.ListRoleGrants(userID)
+	if err != nil {
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to list role grants", nil)
+		return
+	}
+	response.JSON(w, r, http.StatusOK, map[string]any{"user_id": userID, "grants": grants})
+}
+
+// DeleteUser moves a user to the trash and revokes their sessions. Admins
+// cannot delete themselves, and while role approvals are enabled a holder of
+// a protected role can only be deleted once an approved role change request
+// has removed it.
+func (h *AdminHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid user id", nil)
+		return
+	}
+	if actorID, err := actorIDFromRequest(r); err == nil && actorID == userID {
+		response.Error(w, r, http.StatusConflict, "CONFLICT", "cannot delete your own account", nil)
+		return
+	}
+	if h.cfg.RBACRoleApprovalEnabled && h.roleChangeRequests != nil {
+		protected, err := h.holdsProtectedRole(userID)
+		if err != nil {
+			response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to load user roles", nil)
+			return
+		}
+		if protected {
+			response.Error(w, r, http.StatusForbidden, "FORBIDDEN", "users holding a protected role can only be deleted after an approved role change removes it", nil)
+			return
+		}
+	}
+	if err := h.userRepo.DeleteByID(userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "user not found", nil)
+			return
+		}
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to delete user", nil)
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "admin.user.delete",
+		ActorUserID: adminActorID(r),
+		TargetType:  "user",
+		TargetID:    strconv.FormatUint(uint64(userID), 10),
+		Action:      "delete",
+		Outcome:     "success",
+		Reason:      "user_deleted",
+	})
+	h.invalidateRBACPermissionCacheUser(r, userID)
+	h.invalidateAdminListCaches(r, "admin.users.list")
+	response.JSON(w, r, http.StatusOK, map[string]any{"deleted": true})
+}
+
+// ListDeletedUsers lists users in the trash, most recently deleted first.
+func (h *AdminHandler) ListDeletedUsers(w http.ResponseWriter, r *http.Request) {
+	pageReq, err := parsePageRequest(r)
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
+	}
+	res, err := h.userRepo.ListDeleted(pageReq)
+	if err != nil {
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to list deleted users", nil)
+		return
+	}
+	response.JSON(w, r, http.StatusOK, paginatedData(res.Items, res.Page, res.PageSize, res.Total, res.TotalPages))
+}
+
+func (h *AdminHandler) RestoreUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid user id", nil)
+		return
+	}
+	if err := h.userRepo.Restore(userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "user not found in trash", nil)
+			return
+		}
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to restore user", nil)
+		return
+	}
+	user, err := h.userRepo.FindByID(userID)
+	if err != nil {
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to load user", nil)
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "admin.user.restore",
+		ActorUserID: adminActorID(r),
+		TargetType:  "user",
+		TargetID:    strconv.FormatUint(uint64(userID), 10),
+		Action:      "restore",
+		Outcome:     "success",
+		Reason:      "user_restored",
+	})
+	h.invalidateRBACPermissionCacheUser(r, userID)
+	h.invalidateAdminListCaches(r, "admin.users.list")
+	response.JSON(w, r, http.StatusOK, user)
+}
+
+// PurgeUser permanently removes a user from the trash along with their
+// credentials, sessions, grants and memberships.
+func (h *AdminHandler) PurgeUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid user id", nil)
+		return
+	}
+	if err := h.userRepo.Purge(userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "user not found in trash", nil)
+			return
+		}
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to purge user", nil)
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "admin.user.purge",
+		ActorUserID: adminActorID(r),
+		TargetType:  "user",
+		TargetID:    strconv.FormatUint(uint64(userID), 10),
+		Action:      "purge",
+		Outcome:     "success",
+		Reason:      "user_purged",
+	})
+	h.invalidateRBACPermissionCacheUser(r, userID)
+	response.JSON(w, r, http.StatusOK, map[string]any{"purged": true})
+}
+
+func utcTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := t.UTC()
+	return &v
+}
+
 func (h *AdminHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
 	status := "success"
@@ -239,8 +753,26 @@ func (h *AdminHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	filterName := strings.TrimSpace(r.URL.Query().Get("name"))
+	cursorReq, scope, cursorMode, err := parseCursorRequest(r, h.cursors, "admin.roles", pageReq.PageSize)
+	if err != nil {
+		status = "bad_request"
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
+	}
 	sfKey := cacheNamespace + "|" + cacheKey
 	result, err, shared := h.adminListSingleGroup.Do(sfKey, func() (interface{}, error) {
+		if cursorMode {
+			rolesPage, err := h.roleRepo.ListByCursor(cursorReq, sortBy, sortOrder, filterName)
+			if err != nil {
+				return nil, err
+			}
+			payload, err := cursorPaginatedData(h.cursors, scope, rolesPage)
+			if err != nil {
+				return nil, err
+			}
+			h.writeAdminListCache(r, cacheNamespace, cacheKey, payload)
+			return payload, nil
+		}
 		rolesPage, err := h.roleRepo.ListPaged(pageReq, sortBy, sortOrder, filterName)
 		if err != nil {
 			return nil, err
@@ -255,6 +787,11 @@ func (h *AdminHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
 		observability.RecordAdminListCacheEvent(r.Context(), cacheNamespace, "singleflight_leader")
 	}
 	if err != nil {
+		if isCursorRequestError(err) {
+			status = "bad_request"
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+			return
+		}
 		observability.RecordAdminListCacheEvent(r.Context(), cacheNamespace, "error")
 		status = "error"
 		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to list roles", nil)
@@ -565,8 +1102,26 @@ func (h *AdminHandler) ListPermissions(w http.ResponseWriter, r *http.Request) {
 	}
 	filterResource := strings.TrimSpace(r.URL.Query().Get("resource"))
 	filterAction := strings.TrimSpace(r.URL.Query().Get("action"))
+	cursorReq, scope, cursorMode, err := parseCursorRequest(r, h.cursors, "admin.permissions", pageReq.PageSize)
+	if err != nil {
+		status = "bad_request"
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
+	}
 	sfKey := cacheNamespace + "|" + cacheKey
 	result, err, shared := h.adminListSingleGroup.Do(sfKey, func() (interface{}, error) {
+		if cursorMode {
+			permsPage, err := h.permRepo.ListByCursor(cursorReq, sortBy, sortOrder, filterResource, filterAction)
+			if err != nil {
+				return nil, err
+			}
+			payload, err := cursorPaginatedData(h.cursors, scope, permsPage)
+			if err != nil {
+				return nil, err
+			}
+			h.writeAdminListCache(r, cacheNamespace, cacheKey, payload)
+			return payload, nil
+		}
 		permsPage, err := h.permRepo.ListPaged(pageReq, sortBy, sortOrder, filterResource, filterAction)
 		if err != nil {
 			return nil, err
@@ -581,6 +1136,11 @@ func (h *AdminHandler) ListPermissions(w http.ResponseWriter, r *http.Request) {
 		observability.RecordAdminListCacheEvent(r.Context(), cacheNamespace, "singleflight_leader")
 	}
 	if err != nil {
+		if isCursorRequestError(err) {
+			status = "bad_request"
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+			return
+		}
 		observability.RecordAdminListCacheEvent(r.Context(), cacheNamespace, "error")
 		status = "error"
 		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to list permissions", nil)
@@ -869,7 +1429,7 @@ func (h *AdminHandler) wouldLockOutRoleMutation(actorID, roleID uint, requiredPe
 	for _, p := range newRolePermissions {
 		newSet[strings.ToLower(strings.TrimSpace(p))] = struct{}{}
 	}
-	for _, role := range actor.Roles {
+	for _, role := range actor.EffectiveRoles() {
 		if role.ID == roleID {
 			for p := range newSet {
 				next[p] = struct{}{}
@@ -896,7 +1456,7 @@ func (h *AdminHandler) wouldLockOutRoleDeletion(actorID, roleID uint, requiredPe
 		return false
 	}
 	next := make(map[string]struct{})
-	for _, role := range actor.Roles {
+	for _, role := range actor.EffectiveRoles() {
 		if role.ID == roleID {
 			continue
 		}
@@ -986,7 +1546,7 @@ func parsePermissionPairs(perms []string) ([][2]string, error) {
 func validatePermissionParts(resource, action string) (string, string, error) {
 	resource = strings.ToLower(strings.TrimSpace(resource))
 	action = strings.ToLower(strings.TrimSpace(action))
-	if !permissionPartRe.MatchString(resource) || !permissionPartRe.MatchString(action) {
+	if !permissionPartRe.MatchString(resource) || !permissionActionRe.MatchString(action) {
 		return "", "", fmt.Errorf("permission format must be resource:action")
 	}
 	return resource, action, nil
@@ -14,6 +14,7 @@ import (
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
 	repogomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository/gomock"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
 	servicegomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/service/gomock"
 	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
@@ -320,6 +321,7 @@ func newAdminHandlerFixture() (*AdminHandler, *permissionResolverState, *adminLi
 		negMock,
 		nil,
 		cfg,
+		nil,
 	)
 	return h, resolver, adminCache, neg, roleRepo, permRepo, userSvc
 }
@@ -633,3 +635,265 @@ func FuzzParseAdminListSortParamsRobustness(f *testing.F) {
 		}
 	})
 }
+
+func TestAdminHandlerListUsersScopesToTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userRepoMock := repogomock.NewMockUserRepository(ctrl)
+	h := NewAdminHandler(nil, userRepoMock, nil, nil, nil, nil, nil, nil, nil, &config.Config{}, nil)
+
+	t.Run("platform list is unscoped", func(t *testing.T) {
+		userRepoMock.EXPECT().ListPaged(gomock.Any()).DoAndReturn(func(q repository.UserListQuery) (repository.PageResult[domain.User], error) {
+			if q.OrganizationID != 0 {
+				t.Fatalf("expected unscoped query, got org %d", q.OrganizationID)
+			}
+			return repository.PageResult[domain.User]{Page: 1, PageSize: 20}, nil
+		})
+		rr := httptest.NewRecorder()
+		h.ListUsers(rr, withClaims(httptest.NewRequest(http.MethodGet, "/api/v1/admin/users", nil), "42"))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("org admin list is limited to members", func(t *testing.T) {
+		userRepoMock.EXPECT().ListPaged(gomock.Any()).DoAndReturn(func(q repository.UserListQuery) (repository.PageResult[domain.User], error) {
+			if q.OrganizationID != 7 {
+				t.Fatalf("expected org 7, got %d", q.OrganizationID)
+			}
+			return repository.PageResult[domain.User]{Items: []domain.User{{ID: 10}}, Page: 1, PageSize: 20, Total: 1, TotalPages: 1}, nil
+		})
+		req := withClaims(httptest.NewRequest(http.MethodGet, "/api/v1/admin/users", nil), "42")
+		req = req.WithContext(service.WithTenant(req.Context(), service.Tenant{OrganizationID: 7, Slug: "acme"}))
+		rr := httptest.NewRecorder()
+		h.ListUsers(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+}
+
+func TestAdminHandlerGrantUserRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userRepoMock := repogomock.NewMockUserRepository(ctrl)
+	roleRepoMock := repogomock.NewMockRoleRepository(ctrl)
+	resolverMock := servicegomock.NewMockPermissionResolver(ctrl)
+	h := NewAdminHandler(nil, userRepoMock, roleRepoMock, nil, nil, resolverMock, nil, nil, nil, &config.Config{}, nil)
+
+	t.Run("time-bound grant requires justification", func(t *testing.T) {
+		until := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+		req := withURLParam(httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/10/role-grants", strings.NewReader(`{"role_id":2,"valid_until":"`+until+`"}`)), "id", "10")
+		rr := httptest.NewRecorder()
+		h.GrantUserRole(rr, req)
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", rr.Code)
+		}
+	})
+
+	t.Run("past valid_until rejected", func(t *testing.T) {
+		until := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+		req := withURLParam(httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/10/role-grants", strings.NewReader(`{"role_id":2,"valid_until":"`+until+`","justification":"INC-7"}`)), "id", "10")
+		rr := httptest.NewRecorder()
+		h.GrantUserRole(rr, req)
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", rr.Code)
+		}
+	})
+
+	t.Run("protected role needs approval instead of a direct grant", func(t *testing.T) {
+		approving := NewAdminHandler(nil, userRepoMock, roleRepoMock, nil, nil, resolverMock, nil, nil, nil, &config.Config{
+			RBACRoleApprovalEnabled: true,
+			RBACProtectedRoles:      []string{"admin"},
+		}, nil)
+		approving.roleChangeRequests = servicegomock.NewMockRoleChangeRequestService(ctrl)
+		userRepoMock.EXPECT().FindByID(uint(10)).Return(&domain.User{ID: 10}, nil)
+		roleRepoMock.EXPECT().FindByID(uint(1)).Return(&domain.Role{ID: 1, Name: "admin"}, nil)
+
+		req := withURLParam(httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/10/role-grants", strings.NewReader(`{"role_id":1}`)), "id", "10")
+		req = withClaims(req, "42")
+		rr := httptest.NewRecorder()
+		approving.GrantUserRole(rr, req)
+		if rr.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("disjoint window conflicts with the current grant", func(t *testing.T) {
+		userRepoMock.EXPECT().FindByID(uint(10)).Return(&domain.User{ID: 10}, nil)
+		roleRepoMock.EXPECT().FindByID(uint(2)).Return(&domain.Role{ID: 2, Name: "oncall"}, nil)
+		userRepoMock.EXPECT().GrantRole(gomock.Any()).Return(repository.ErrRoleGrantWindowConflict)
+
+		req := withURLParam(httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/10/role-grants", strings.NewReader(`{"role_id":2}`)), "id", "10")
+		req = withClaims(req, "42")
+		rr := httptest.NewRecorder()
+		h.GrantUserRole(rr, req)
+		if rr.Code != http.StatusConflict {
+			t.Fatalf("expected 409, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("grant stores window and invalidates user permission cache", func(t *testing.T) {
+		until := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		userRepoMock.EXPECT().FindByID(uint(10)).Return(&domain.User{ID: 10}, nil)
+		roleRepoMock.EXPECT().FindByID(uint(2)).Return(&domain.Role{ID: 2, Name: "oncall"}, nil)
+		userRepoMock.EXPECT().GrantRole(gomock.Any()).DoAndReturn(func(grant *domain.UserRole) error {
+			if grant.UserID != 10 || grant.RoleID != 2 || grant.ValidUntil == nil || !grant.ValidUntil.Equal(until) || grant.Justification != "INC-7" {
+				t.Fatalf("unexpected grant: %+v", grant)
+			}
+			return nil
+		})
+		resolverMock.EXPECT().InvalidateUser(gomock.Any(), uint(10)).Return(nil)
+
+		req := withURLParam(httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/10/role-grants", strings.NewReader(`{"role_id":2,"valid_until":"`+until.Format(time.RFC3339)+`","justification":" INC-7 "}`)), "id", "10")
+		req = withClaims(req, "42")
+		rr := httptest.NewRecorder()
+		h.GrantUserRole(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+}
+
+func TestAdminHandlerUserTrash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userRepoMock := repogomock.NewMockUserRepository(ctrl)
+	resolverMock := servicegomock.NewMockPermissionResolver(ctrl)
+	h := NewAdminHandler(nil, userRepoMock, nil, nil, nil, resolverMock, nil, nil, nil, &config.Config{}, nil)
+
+	t.Run("admins cannot delete themselves", func(t *testing.T) {
+		req := withClaims(withURLParam(httptest.NewRequest(http.MethodDelete, "/api/v1/admin/users/42", nil), "id", "42"), "42")
+		rr := httptest.NewRecorder()
+		h.DeleteUser(rr, req)
+		if rr.Code != http.StatusConflict {
+			t.Fatalf("expected 409, got %d", rr.Code)
+		}
+	})
+
+	t.Run("delete soft-deletes and invalidates permissions", func(t *testing.T) {
+		userRepoMock.EXPECT().DeleteByID(uint(10)).Return(nil)
+		resolverMock.EXPECT().InvalidateUser(gomock.Any(), uint(10)).Return(nil)
+		req := withClaims(withURLParam(httptest.NewRequest(http.MethodDelete, "/api/v1/admin/users/10", nil), "id", "10"), "42")
+		rr := httptest.NewRecorder()
+		h.DeleteUser(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("delete of missing user is 404", func(t *testing.T) {
+		userRepoMock.EXPECT().DeleteByID(uint(11)).Return(gorm.ErrRecordNotFound)
+		req := withClaims(withURLParam(httptest.NewRequest(http.MethodDelete, "/api/v1/admin/users/11", nil), "id", "11"), "42")
+		rr := httptest.NewRecorder()
+		h.DeleteUser(rr, req)
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected 404, got %d", rr.Code)
+		}
+	})
+
+	t.Run("protected role holders need an approved role change first", func(t *testing.T) {
+		roleRepoMock := repogomock.NewMockRoleRepository(ctrl)
+		approving := NewAdminHandler(nil, userRepoMock, roleRepoMock, nil, nil, resolverMock, nil, nil, nil, &config.Config{
+			RBACRoleApprovalEnabled: true,
+			RBACProtectedRoles:      []string{"admin"},
+		}, nil)
+		approving.roleChangeRequests = servicegomock.NewMockRoleChangeRequestService(ctrl)
+		userRepoMock.EXPECT().ListRoleGrants(uint(10)).Return([]domain.UserRole{{UserID: 10, RoleID: 2}, {UserID: 10, RoleID: 1}}, nil)
+		roleRepoMock.EXPECT().FindByID(uint(2)).Return(&domain.Role{ID: 2, Name: "oncall"}, nil)
+		roleRepoMock.EXPECT().FindByID(uint(1)).Return(&domain.Role{ID: 1, Name: "admin"}, nil)
+
+		req := withClaims(withURLParam(httptest.NewRequest(http.MethodDelete, "/api/v1/admin/users/10", nil), "id", "10"), "42")
+		rr := httptest.NewRecorder()
+		approving.DeleteUser(rr, req)
+		if rr.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("restore returns the user", func(t *testing.T) {
+		userRepoMock.EXPECT().Restore(uint(10)).Return(nil)
+		userRepoMock.EXPECT().FindByID(uint(10)).Return(&domain.User{ID: 10, Email: "restored@example.com"}, nil)
+		resolverMock.EXPECT().InvalidateUser(gomock.Any(), uint(10)).Return(nil)
+		req := withClaims(withURLParam(httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/trash/10/restore", nil), "id", "10"), "42")
+		rr := httptest.NewRecorder()
+		h.RestoreUser(rr, req)
+		if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), "restored@example.com") {
+			t.Fatalf("expected restored user, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("purge requires the user in the trash", func(t *testing.T) {
+		userRepoMock.EXPECT().Purge(uint(12)).Return(gorm.ErrRecordNotFound)
+		req := withClaims(withURLParam(httptest.NewRequest(http.MethodDelete, "/api/v1/admin/users/trash/12", nil), "id", "12"), "42")
+		rr := httptest.NewRecorder()
+		h.PurgeUser(rr, req)
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected 404, got %d", rr.Code)
+		}
+	})
+}
+
+func TestAdminHandlerSetUserRolesRequiresApprovalForProtectedRoles(t *testing.T) {
+	h, _, _, _, roleRepo, _, userSvc := newAdminHandlerFixture()
+	ctrl := gomock.NewController(t)
+	approvals := servicegomock.NewMockRoleChangeRequestService(ctrl)
+	h.cfg.RBACRoleApprovalEnabled = true
+	h.roleChangeRequests = approvals
+	roleRepo.rolesByID[7] = &domain.Role{ID: 7, Name: "auditor"}
+
+	setRolesCalls := 0
+	userSvc.setRolesFn = func(userID uint, roleIDs []uint) error {
+		setRolesCalls++
+		return nil
+	}
+
+	t.Run("non-protected addition applies directly", func(t *testing.T) {
+		req := withURLParam(httptest.NewRequest(http.MethodPatch, "/api/v1/admin/users/10/roles", strings.NewReader(`{"role_ids":[100,101,7]}`)), "id", "10")
+		req = withClaims(req, "42")
+		rr := httptest.NewRecorder()
+		h.SetUserRoles(rr, req)
+		if rr.Code != http.StatusOK || setRolesCalls != 1 {
+			t.Fatalf("expected direct apply, got status=%d setRolesCalls=%d", rr.Code, setRolesCalls)
+		}
+	})
+
+	t.Run("removing protected role creates pending request", func(t *testing.T) {
+		approvals.EXPECT().Submit(gomock.Any(), uint(10), uint(42), []uint{101}).Return(&domain.RoleChangeRequest{
+			ID:           1,
+			TargetUserID: 10,
+			RequestedBy:  42,
+			RoleIDs:      []uint{101},
+			Status:       domain.RoleChangeRequestStatusPending,
+		}, nil)
+		req := withURLParam(httptest.NewRequest(http.MethodPatch, "/api/v1/admin/users/10/roles", strings.NewReader(`{"role_ids":[101]}`)), "id", "10")
+		req = withClaims(req, "42")
+		rr := httptest.NewRecorder()
+		h.SetUserRoles(rr, req)
+		if rr.Code != http.StatusAccepted {
+			t.Fatalf("expected 202, got %d body=%s", rr.Code, rr.Body.String())
+		}
+		if setRolesCalls != 1 {
+			t.Fatalf("expected roles not applied before approval, got %d SetRoles calls", setRolesCalls)
+		}
+	})
+
+	t.Run("self approval is forbidden", func(t *testing.T) {
+		approvals.EXPECT().Approve(gomock.Any(), uint(1), uint(42), "").Return(nil, service.ErrRoleChangeSelfApproval)
+		req := withURLParam(httptest.NewRequest(http.MethodPost, "/api/v1/admin/role-change-requests/1/approve", nil), "id", "1")
+		req = withClaims(req, "42")
+		rr := httptest.NewRecorder()
+		h.ApproveRoleChangeRequest(rr, req)
+		if rr.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d", rr.Code)
+		}
+	})
+
+	t.Run("roles changed since submit conflict", func(t *testing.T) {
+		approvals.EXPECT().Approve(gomock.Any(), uint(2), uint(42), "").Return(nil, service.ErrRoleChangeStale)
+		req := withURLParam(httptest.NewRequest(http.MethodPost, "/api/v1/admin/role-change-requests/2/approve", nil), "id", "2")
+		req = withClaims(req, "42")
+		rr := httptest.NewRecorder()
+		h.ApproveRoleChangeRequest(rr, req)
+		if rr.Code != http.StatusConflict {
+			t.Fatalf("expected 409, got %d", rr.Code)
+		}
+	})
+}
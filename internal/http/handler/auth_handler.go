@@ -20,6 +20,7 @@ type AuthHandler struct {
 	abuseGuard  service.AuthAbuseGuard
 	cookieMgr   *security.CookieManager
 	abuseBypass middleware.BypassEvaluator
+	cartMerger  service.CartMerger
 	stateKey    string
 	refreshTTL  time.Duration
 }
@@ -45,6 +46,13 @@ func NewAuthHandler(
 	}
 }
 
+// WithCartMerger makes sign-ins move the caller's guest cart into their
+// account.
+func (h *AuthHandler) WithCartMerger(cartMerger service.CartMerger) *AuthHandler {
+	h.cartMerger = cartMerger
+	return h
+}
+
 func (h *AuthHandler) GoogleLogin(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
 	status := "success"
@@ -115,6 +123,7 @@ func (h *AuthHandler) GoogleCallback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	h.cookieMgr.SetTokenCookies(w, result.AccessToken, result.RefreshToken, result.CSRFToken, h.refreshTTL)
+	h.mergeGuestCart(w, r, result.User.ID)
 	auditAuth(r, "auth.login", "login", "success", "oauth_google", observability.ActorUserID(result.User.ID), "user", observability.ActorUserID(result.User.ID), "provider", "google")
 	observability.RecordAuthLogin(r.Context(), "google", "success")
 	response.JSON(w, r, http.StatusOK, map[string]any{"user": result.User, "csrf_token": result.CSRFToken, "expires_at": result.ExpiresAt})
@@ -233,6 +242,7 @@ func (h *AuthHandler) LocalRegister(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	h.cookieMgr.SetTokenCookies(w, result.AccessToken, result.RefreshToken, result.CSRFToken, h.refreshTTL)
+	h.mergeGuestCart(w, r, result.User.ID)
 	auditAuth(r, "auth.local.register", "register", "success", "session_created", observability.ActorUserID(result.User.ID), "user", observability.ActorUserID(result.User.ID))
 	observability.RecordAuthLogin(r.Context(), "local", "success")
 	response.JSON(w, r, http.StatusCreated, map[string]any{"user": result.User, "csrf_token": result.CSRFToken, "expires_at": result.ExpiresAt})
@@ -306,6 +316,7 @@ func (h *AuthHandler) LocalLogin(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	h.cookieMgr.SetTokenCookies(w, result.AccessToken, result.RefreshToken, result.CSRFToken, h.refreshTTL)
+	h.mergeGuestCart(w, r, result.User.ID)
 	auditAuth(r, "auth.local.login", "login", "success", "credentials_valid", observability.ActorUserID(result.User.ID), "user", observability.ActorUserID(result.User.ID))
 	observability.RecordAuthLogin(r.Context(), "local", "success")
 	response.JSON(w, r, http.StatusOK, map[string]any{"user": result.User, "csrf_token": result.CSRFToken, "expires_at": result.ExpiresAt})
@@ -453,6 +464,21 @@ func (h *AuthHandler) LocalPasswordForgot(w http.ResponseWriter, r *http.Request
 	response.JSON(w, r, http.StatusOK, map[string]string{"status": "if the account exists, reset instructions were sent"})
 }
 
+// mergeGuestCart moves the guest cart named by the request's cart token into
+// the signed-in user's cart. Failures are audited but never fail the sign-in;
+// the guest cart then simply stays where it is.
+func (h *AuthHandler) mergeGuestCart(w http.ResponseWriter, r *http.Request, userID uint) {
+	token := guestCartToken(r)
+	if h.cartMerger == nil || token == "" {
+		return
+	}
+	if err := h.cartMerger.MergeGuestCart(r.Context(), token, userID); err != nil {
+		auditAuth(r, "cart.merge", "merge", "failure", "merge_error", observability.ActorUserID(userID), "user", observability.ActorUserID(userID), "error", err.Error())
+		return
+	}
+	h.cookieMgr.ClearCartCookie(w)
+}
+
 func (h *AuthHandler) shouldBypassAuthAbuse(r *http.Request) (bool, string) {
 	if h.abuseBypass == nil {
 		return false, ""
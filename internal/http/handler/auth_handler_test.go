@@ -338,3 +338,43 @@ func TestAuthHandlerRefreshAndLogoutCookieSideEffects(t *testing.T) {
 		}
 	})
 }
+
+func TestAuthHandlerLocalLoginMergesGuestCart(t *testing.T) {
+	cookieMgr := security.NewCookieManager("", false, "lax")
+	loginResult := &service.LoginResult{User: &domain.User{ID: 7}, AccessToken: "a", RefreshToken: "r", CSRFToken: "c", ExpiresAt: time.Now().Add(time.Hour)}
+	bypass := func(r *http.Request) (bool, string) { return true, "trusted_subnet" }
+
+	t.Run("merges and clears the cart cookie", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		authSvc := servicegomock.NewMockAuthServiceInterface(ctrl)
+		carts := servicegomock.NewMockCartService(ctrl)
+		authSvc.EXPECT().LoginWithLocalPassword(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(loginResult, nil)
+		carts.EXPECT().MergeGuestCart(gomock.Any(), "guest-token", uint(7)).Return(nil)
+		h := NewAuthHandler(authSvc, servicegomock.NewMockAuthAbuseGuard(ctrl), cookieMgr, bypass, "state", 24*time.Hour).WithCartMerger(carts)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(`{"email":"u@example.com","password":"StrongPass123!"}`))
+		req.AddCookie(&http.Cookie{Name: security.CartCookieName, Value: "guest-token"})
+		rr := httptest.NewRecorder()
+
+		h.LocalLogin(rr, req)
+		if rr.Code != http.StatusOK || !isClearedCookie(rr.Result().Cookies(), security.CartCookieName) {
+			t.Fatalf("expected 200 with cleared cart cookie, got %d cookies=%v", rr.Code, rr.Result().Cookies())
+		}
+	})
+
+	t.Run("merge failure does not fail the login", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		authSvc := servicegomock.NewMockAuthServiceInterface(ctrl)
+		carts := servicegomock.NewMockCartService(ctrl)
+		authSvc.EXPECT().LoginWithLocalPassword(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(loginResult, nil)
+		carts.EXPECT().MergeGuestCart(gomock.Any(), "guest-token", uint(7)).Return(errors.New("db down"))
+		h := NewAuthHandler(authSvc, servicegomock.NewMockAuthAbuseGuard(ctrl), cookieMgr, bypass, "state", 24*time.Hour).WithCartMerger(carts)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(`{"email":"u@example.com","password":"StrongPass123!"}`))
+		req.Header.Set("X-Cart-Token", "guest-token")
+		rr := httptest.NewRecorder()
+
+		h.LocalLogin(rr, req)
+		if rr.Code != http.StatusOK || hasCookie(rr.Result().Cookies(), security.CartCookieName) {
+			t.Fatalf("expected 200 keeping the cart cookie, got %d", rr.Code)
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/http/middleware"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/http/response"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/security"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+)
+
+const (
+	// cartTokenHeader carries a guest's cart token for clients that do not
+	// keep cookies.
+	cartTokenHeader    = "X-Cart-Token"
+	guestCartCookieTTL = 30 * 24 * time.Hour
+)
+
+// CartHandler serves the caller's cart. Signed-in users get the cart of
+// their account; guests get a cart identified by a token that is issued with
+// their first change, as the cart_token cookie and in the response body.
+type CartHandler struct {
+	svc       service.CartService
+	cookieMgr *security.CookieManager
+}
+
+func NewCartHandler(svc service.CartService, cookieMgr *security.CookieManager) *CartHandler {
+	return &CartHandler{svc: svc, cookieMgr: cookieMgr}
+}
+
+func (h *CartHandler) Get(w http.ResponseWriter, r *http.Request) {
+	owner, err := cartOwner(r)
+	if err != nil {
+		response.Error(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "invalid user context", nil)
+		return
+	}
+	cart, err := h.svc.GetCart(r.Context(), owner)
+	if err != nil {
+		h.writeCartError(w, r, err, "failed to load cart")
+		return
+	}
+	response.JSON(w, r, http.StatusOK, cart)
+}
+
+// SetItem sets the quantity of a product in the cart; 0 removes it.
+func (h *CartHandler) SetItem(w http.ResponseWriter, r *http.Request) {
+	productID, err := parsePathID(chi.URLParam(r, "product_id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid product id", nil)
+		return
+	}
+	var body struct {
+		Quantity *int64 `json:"quantity"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
+		return
+	}
+	if body.Quantity == nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "quantity is required", nil)
+		return
+	}
+	h.setItem(w, r, productID, *body.Quantity)
+}
+
+func (h *CartHandler) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	productID, err := parsePathID(chi.URLParam(r, "product_id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid product id", nil)
+		return
+	}
+	h.setItem(w, r, productID, 0)
+}
+
+func (h *CartHandler) Clear(w http.ResponseWriter, r *http.Request) {
+	owner, err := cartOwner(r)
+	if err != nil {
+		response.Error(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "invalid user context", nil)
+		return
+	}
+	if err := h.svc.Clear(r.Context(), owner); err != nil {
+		h.writeCartError(w, r, err, "failed to clear cart")
+		return
+	}
+	response.JSON(w, r, http.StatusOK, map[string]any{"cleared": true})
+}
+
+func (h *CartHandler) setItem(w http.ResponseWriter, r *http.Request, productID uint, quantity int64) {
+	owner, err := cartOwner(r)
+	if err != nil {
+		response.Error(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "invalid user context", nil)
+		return
+	}
+	cart, err := h.svc.SetItem(r.Context(), owner, productID, quantity)
+	if err != nil {
+		h.writeCartError(w, r, err, "failed to update cart")
+		return
+	}
+	if cart.GuestToken != "" {
+		h.cookieMgr.SetCartCookie(w, cart.GuestToken, guestCartCookieTTL)
+	}
+	response.JSON(w, r, http.StatusOK, cart)
+}
+
+func (h *CartHandler) writeCartError(w http.ResponseWriter, r *http.Request, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrCartInvalidQuantity),
+		errors.Is(err, service.ErrCartTooManyItems):
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+	case errors.Is(err, repository.ErrProductNotFound):
+		response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "product not found", nil)
+	default:
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", fallback, nil)
+	}
+}
+
+// cartOwner identifies the caller's cart: the signed-in user's, or the guest
+// cart named by the request's cart token.
+func cartOwner(r *http.Request) (service.CartOwner, error) {
+	if _, ok := middleware.ClaimsFromContext(r.Context()); ok {
+		userID, err := actorIDFromRequest(r)
+		if err != nil {
+			return service.CartOwner{}, err
+		}
+		return service.CartOwner{UserID: userID}, nil
+	}
+	return service.CartOwner{GuestToken: guestCartToken(r)}, nil
+}
+
+func guestCartToken(r *http.Request) string {
+	if token := security.GetCookie(r, security.CartCookieName); token != "" {
+		return token
+	}
+	return strings.TrimSpace(r.Header.Get(cartTokenHeader))
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/security"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+	servicegomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/service/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCartHandlerEndpoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockCartService(ctrl)
+	h := NewCartHandler(svc, security.NewCookieManager("", false, "lax"))
+
+	r := chi.NewRouter()
+	r.Get("/cart", h.Get)
+	r.Put("/cart/items/{product_id}", h.SetItem)
+	r.Delete("/cart/items/{product_id}", h.RemoveItem)
+
+	t.Run("guest write issues a cart cookie", func(t *testing.T) {
+		svc.EXPECT().SetItem(gomock.Any(), service.CartOwner{}, uint(3), int64(2)).
+			Return(&domain.Cart{ID: 1, GuestToken: "new-token", Items: []domain.CartItem{{ProductID: 3, Quantity: 2}}}, nil)
+		req := httptest.NewRequest(http.MethodPut, "/cart/items/3", strings.NewReader(`{"quantity":2}`))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK || !hasCookie(rr.Result().Cookies(), security.CartCookieName) || !strings.Contains(rr.Body.String(), `"guest_token":"new-token"`) {
+			t.Fatalf("expected 200 with new cart token, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("signed-in user owns the cart", func(t *testing.T) {
+		svc.EXPECT().GetCart(gomock.Any(), service.CartOwner{UserID: 42}).Return(&domain.Cart{ID: 2, Items: []domain.CartItem{}}, nil)
+		req := withClaims(httptest.NewRequest(http.MethodGet, "/cart", nil), "42")
+		req.Header.Set(cartTokenHeader, "ignored")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("guest token from header removes item", func(t *testing.T) {
+		svc.EXPECT().SetItem(gomock.Any(), service.CartOwner{GuestToken: "tok"}, uint(3), int64(0)).Return(&domain.Cart{ID: 1, Items: []domain.CartItem{}}, nil)
+		req := httptest.NewRequest(http.MethodDelete, "/cart/items/3", nil)
+		req.Header.Set(cartTokenHeader, "tok")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK || hasCookie(rr.Result().Cookies(), security.CartCookieName) {
+			t.Fatalf("expected 200 without a new cookie, got %d", rr.Code)
+		}
+	})
+
+	t.Run("validation and missing products", func(t *testing.T) {
+		cases := []struct {
+			body string
+			err  error
+			want int
+		}{
+			{body: `{}`, want: http.StatusBadRequest},
+			{body: `{"quantity":5000}`, err: service.ErrCartInvalidQuantity, want: http.StatusBadRequest},
+			{body: `{"quantity":1}`, err: repository.ErrProductNotFound, want: http.StatusNotFound},
+		}
+		for _, tc := range cases {
+			if tc.err != nil {
+				svc.EXPECT().SetItem(gomock.Any(), gomock.Any(), uint(3), gomock.Any()).Return(nil, tc.err)
+			}
+			req := httptest.NewRequest(http.MethodPut, "/cart/items/3", strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			if rr.Code != tc.want {
+				t.Fatalf("body %s: expected %d, got %d", tc.body, tc.want, rr.Code)
+			}
+		}
+	})
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/http/response"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+)
+
+type CategoryHandler struct {
+	svc service.CategoryService
+}
+
+func NewCategoryHandler(svc service.CategoryService) *CategoryHandler {
+	return &CategoryHandler{svc: svc}
+}
+
+func (h *CategoryHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name        string                     `json:"name"`
+		Slug        string                     `json:"slug"`
+		Description string                     `json:"description"`
+		ParentID    *uint                      `json:"parent_id"`
+		Attributes  []domain.CategoryAttribute `json:"attributes"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
+		return
+	}
+	category, err := h.svc.CreateCategory(r.Context(), service.CreateCategoryInput{
+		Name:        body.Name,
+		Slug:        body.Slug,
+		Description: body.Description,
+		ParentID:    body.ParentID,
+		Attributes:  body.Attributes,
+	})
+	if err != nil {
+		h.writeCategoryError(w, r, err, "failed to create category")
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "category.create",
+		ActorUserID: adminActorID(r),
+		TargetType:  "category",
+		TargetID:    strconv.FormatUint(uint64(category.ID), 10),
+		Action:      "create",
+		Outcome:     "success",
+		Reason:      "category_created",
+	}, "slug", category.Slug)
+	response.JSON(w, r, http.StatusCreated, category)
+}
+
+// List returns the whole category tree as a flat list ordered by name.
+func (h *CategoryHandler) List(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.svc.ListCategories(r.Context())
+	if err != nil {
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to list categories", nil)
+		return
+	}
+	response.JSON(w, r, http.StatusOK, map[string]any{"items": categories})
+}
+
+func (h *CategoryHandler) Get(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid category id", nil)
+		return
+	}
+	category, err := h.svc.GetCategory(r.Context(), categoryID)
+	if err != nil {
+		h.writeCategoryError(w, r, err, "failed to load category")
+		return
+	}
+	response.JSON(w, r, http.StatusOK, category)
+}
+
+func (h *CategoryHandler) Update(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid category id", nil)
+		return
+	}
+	var body struct {
+		Name        *string                     `json:"name"`
+		Slug        *string                     `json:"slug"`
+		Description *string                     `json:"description"`
+		ParentID    *uint                       `json:"parent_id"`
+		Attributes  *[]domain.CategoryAttribute `json:"attributes"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
+		return
+	}
+	if body.Name == nil && body.Slug == nil && body.Description == nil && body.ParentID == nil && body.Attributes == nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "at least one field is required", nil)
+		return
+	}
+	category, err := h.svc.UpdateCategory(r.Context(), categoryID, service.UpdateCategoryInput{
+		Name:        body.Name,
+		Slug:        body.Slug,
+		Description: body.Description,
+		ParentID:    body.ParentID,
+		Attributes:  body.Attributes,
+	})
+	if err != nil {
+		h.writeCategoryError(w, r, err, "failed to update category")
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "category.update",
+		ActorUserID: adminActorID(r),
+		TargetType:  "category",
+		TargetID:    strconv.FormatUint(uint64(categoryID), 10),
+		Action:      "update",
+		Outcome:     "success",
+		Reason:      "category_updated",
+	}, "attributes_changed", body.Attributes != nil)
+	response.JSON(w, r, http.StatusOK, category)
+}
+
+func (h *CategoryHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid category id", nil)
+		return
+	}
+	if err := h.svc.DeleteCategory(r.Context(), categoryID); err != nil {
+		h.writeCategoryError(w, r, err, "failed to delete category")
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "category.delete",
+		ActorUserID: adminActorID(r),
+		TargetType:  "category",
+		TargetID:    strconv.FormatUint(uint64(categoryID), 10),
+		Action:      "delete",
+		Outcome:     "success",
+		Reason:      "category_deleted",
+	})
+	response.JSON(w, r, http.StatusOK, map[string]any{"id": categoryID, "deleted": true})
+}
+
+func (h *CategoryHandler) writeCategoryError(w http.ResponseWriter, r *http.Request, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrCategoryInvalidName),
+		errors.Is(err, service.ErrCategoryInvalidSlug),
+		errors.Is(err, service.ErrCategoryInvalidDesc),
+		errors.Is(err, service.ErrCategoryInvalidParent),
+		errors.Is(err, service.ErrCategoryCycle),
+		errors.Is(err, service.ErrCategoryTooDeep),
+		errors.Is(err, service.ErrCategoryInvalidAttributes):
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+	case errors.Is(err, repository.ErrCategoryNotFound):
+		response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "category not found", nil)
+	case errors.Is(err, repository.ErrCategoryInUse):
+		response.Error(w, r, http.StatusConflict, "CONFLICT", err.Error(), nil)
+	case isConflictError(err):
+		response.Error(w, r, http.StatusConflict, "CONFLICT", "category slug already exists", nil)
+	default:
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", fallback, nil)
+	}
+}
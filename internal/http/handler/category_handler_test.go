@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+	servicegomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/service/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCategoryHandlerEndpoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockCategoryService(ctrl)
+	h := NewCategoryHandler(svc)
+
+	r := chi.NewRouter()
+	r.Get("/categories", h.List)
+	r.Post("/categories", h.Create)
+	r.Patch("/categories/{id}", h.Update)
+	r.Delete("/categories/{id}", h.Delete)
+
+	t.Run("create passes the attribute schema", func(t *testing.T) {
+		svc.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, input service.CreateCategoryInput) (*domain.Category, error) {
+			if len(input.Attributes) != 1 || input.Attributes[0].Type != "enum" || len(input.Attributes[0].Options) != 2 {
+				t.Fatalf("unexpected attributes: %+v", input.Attributes)
+			}
+			return &domain.Category{ID: 4, Name: input.Name, Slug: input.Slug, Attributes: input.Attributes}, nil
+		})
+		body := `{"name":"Shirts","slug":"shirts","attributes":[{"key":"size","type":"enum","options":["s","m"]}]}`
+		req := httptest.NewRequest(http.MethodPost, "/categories", strings.NewReader(body))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusCreated || !strings.Contains(rr.Body.String(), `"slug":"shirts"`) {
+			t.Fatalf("expected 201 with category, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("invalid schema maps to 400", func(t *testing.T) {
+		svc.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("%w: key %q is already defined", service.ErrCategoryInvalidAttributes, "size"))
+		req := httptest.NewRequest(http.MethodPost, "/categories", strings.NewReader(`{"name":"Shirts","slug":"shirts"}`))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), "already defined") {
+			t.Fatalf("expected 400 with reason, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("empty update is rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPatch, "/categories/4", strings.NewReader(`{}`))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", rr.Code)
+		}
+	})
+
+	t.Run("cycle maps to 400", func(t *testing.T) {
+		svc.EXPECT().UpdateCategory(gomock.Any(), uint(4), gomock.Any()).Return(nil, service.ErrCategoryCycle)
+		req := httptest.NewRequest(http.MethodPatch, "/categories/4", strings.NewReader(`{"parent_id":5}`))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", rr.Code)
+		}
+	})
+
+	t.Run("category in use maps to 409", func(t *testing.T) {
+		svc.EXPECT().DeleteCategory(gomock.Any(), uint(4)).Return(repository.ErrCategoryInUse)
+		req := httptest.NewRequest(http.MethodDelete, "/categories/4", nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusConflict {
+			t.Fatalf("expected 409, got %d", rr.Code)
+		}
+	})
+
+	t.Run("list returns all categories", func(t *testing.T) {
+		svc.EXPECT().ListCategories(gomock.Any()).Return([]domain.Category{{ID: 1, Slug: "a"}, {ID: 2, Slug: "b"}}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/categories", nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"slug":"b"`) {
+			t.Fatalf("expected 200 with items, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+}
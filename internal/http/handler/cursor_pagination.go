@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/security"
+)
+
+const (
+	paginationModeOffset = "offset"
+	paginationModeCursor = "cursor"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// listCursor is the signed payload behind next_cursor and prev_cursor. Scope
+// ties it to the list and query it was issued for.
+type listCursor struct {
+	Scope    string `json:"s"`
+	Value    string `json:"v"`
+	ID       uint   `json:"i"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+// parseCursorRequest reports whether r asks for cursor pagination, either
+// with pagination=cursor or by passing a cursor, and returns the decoded
+// request with the scope to sign new cursors with. A cursor is only accepted
+// for the list, sort and filters it was issued for; page_size may change
+// between pages.
+func parseCursorRequest(r *http.Request, cursors *security.CursorSigner, list string, pageSize int) (repository.CursorRequest, string, bool, error) {
+	query := r.URL.Query()
+	mode := strings.ToLower(strings.TrimSpace(query.Get("pagination")))
+	token := strings.TrimSpace(query.Get("cursor"))
+	switch mode {
+	case "", paginationModeOffset, paginationModeCursor:
+	default:
+		return repository.CursorRequest{}, "", false, errors.New("pagination must be offset or cursor")
+	}
+	if token == "" && mode != paginationModeCursor {
+		return repository.CursorRequest{}, "", false, nil
+	}
+	if mode == paginationModeOffset {
+		return repository.CursorRequest{}, "", false, errors.New("cursor cannot be used with offset pagination")
+	}
+	if strings.TrimSpace(query.Get("page")) != "" {
+		return repository.CursorRequest{}, "", false, errors.New("page cannot be used with cursor pagination")
+	}
+
+	scope := cursorScope(list, query)
+	req := repository.CursorRequest{PageSize: pageSize}
+	if token == "" {
+		return req, scope, true, nil
+	}
+	var payload listCursor
+	if err := cursors.Decode(token, &payload); err != nil || payload.Scope != scope {
+		return repository.CursorRequest{}, "", false, errInvalidCursor
+	}
+	req.Position = &repository.CursorPosition{Value: payload.Value, ID: payload.ID}
+	req.Backward = payload.Backward
+	return req, scope, true, nil
+}
+
+func cursorScope(list string, values url.Values) string {
+	filters := make(url.Values, len(values))
+	for key, value := range values {
+		switch key {
+		case "cursor", "pagination", "page", "page_size":
+			continue
+		}
+		filters[key] = value
+	}
+	sum := sha256.Sum256([]byte(list + "?" + normalizeQueryValues(filters)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// isCursorRequestError reports whether err is a client error from cursor
+// pagination rather than a storage failure.
+func isCursorRequestError(err error) bool {
+	return errors.Is(err, repository.ErrInvalidCursor) || errors.Is(err, repository.ErrCursorSortNotSupported)
+}
+
+func cursorPaginatedData[T any](cursors *security.CursorSigner, scope string, page repository.CursorResult[T]) (map[string]any, error) {
+	pagination := map[string]any{
+		"mode":        paginationModeCursor,
+		"page_size":   page.PageSize,
+		"next_cursor": nil,
+		"prev_cursor": nil,
+	}
+	for field, position := range map[string]*repository.CursorPosition{"next_cursor": page.Next, "prev_cursor": page.Prev} {
+		if position == nil {
+			continue
+		}
+		token, err := cursors.Encode(listCursor{
+			Scope:    scope,
+			Value:    position.Value,
+			ID:       position.ID,
+			Backward: field == "prev_cursor",
+		})
+		if err != nil {
+			return nil, err
+		}
+		pagination[field] = token
+	}
+	return map[string]any{"items": page.Items, "pagination": pagination}, nil
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/config"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	servicegomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/service/gomock"
+)
+
+func TestProductListCursorPagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockProductService(ctrl)
+	h := NewProductHandler(svc, &config.Config{PaginationCursorSecret: "cursor-secret-123456"})
+
+	list := func(query string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		h.List(rr, httptest.NewRequest(http.MethodGet, "/api/v1/products?"+query, nil))
+		return rr
+	}
+	decode := func(t *testing.T, rr *httptest.ResponseRecorder) map[string]any {
+		t.Helper()
+		var env struct {
+			Data struct {
+				Pagination map[string]any `json:"pagination"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(rr.Body.Bytes(), &env); err != nil {
+			t.Fatalf("unmarshal response: %v", err)
+		}
+		return env.Data.Pagination
+	}
+
+	svc.EXPECT().ListByCursor(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, query repository.ProductListQuery, cursor repository.CursorRequest) (repository.CursorResult[domain.Product], error) {
+			if cursor.Position != nil || cursor.PageSize != 2 || query.SortBy != "price" {
+				t.Fatalf("unexpected first page request %+v %+v", query, cursor)
+			}
+			return repository.CursorResult[domain.Product]{
+				Items:    []domain.Product{{ID: 3, PriceMinor: 500}, {ID: 9, PriceMinor: 750}},
+				PageSize: 2,
+				Next:     &repository.CursorPosition{Value: "750", ID: 9},
+			}, nil
+		})
+	rr := list("pagination=cursor&page_size=2&sort_by=price&currency=usd&q=mug")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	pagination := decode(t, rr)
+	next, _ := pagination["next_cursor"].(string)
+	if pagination["mode"] != "cursor" || next == "" || pagination["prev_cursor"] != nil || pagination["total"] != nil {
+		t.Fatalf("unexpected cursor pagination %+v", pagination)
+	}
+
+	svc.EXPECT().ListByCursor(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, query repository.ProductListQuery, cursor repository.CursorRequest) (repository.CursorResult[domain.Product], error) {
+			if cursor.Position == nil || cursor.Position.ID != 9 || cursor.Position.Value != "750" || cursor.Backward || cursor.PageSize != 5 {
+				t.Fatalf("expected to continue after id 9, got %+v", cursor)
+			}
+			return repository.CursorResult[domain.Product]{PageSize: 5, Prev: &repository.CursorPosition{Value: "8", ID: 4}}, nil
+		})
+	rr = list("cursor=" + url.QueryEscape(next) + "&page_size=5&q=mug&sort_by=price&currency=usd")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 for the next page, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	if prev, _ := decode(t, rr)["prev_cursor"].(string); prev == "" {
+		t.Fatalf("expected a prev_cursor, got %s", rr.Body.String())
+	}
+
+	for name, query := range map[string]string{
+		"cursor for another query": "cursor=" + url.QueryEscape(next) + "&q=teapot&sort_by=price&currency=usd",
+		"cursor for another sort":  "cursor=" + url.QueryEscape(next) + "&q=mug",
+		"tampered cursor":          "cursor=x" + url.QueryEscape(next[1:]) + "&q=mug&sort_by=price&currency=usd",
+		"cursor with page":         "pagination=cursor&page=2",
+		"cursor in offset mode":    "pagination=offset&cursor=" + url.QueryEscape(next),
+		"unknown mode":             "pagination=keyset",
+	} {
+		if rr := list(query); rr.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d body=%s", name, rr.Code, rr.Body.String())
+		}
+	}
+
+	svc.EXPECT().ListByCursor(gomock.Any(), gomock.Any(), gomock.Any()).Return(repository.CursorResult[domain.Product]{}, repository.ErrInvalidCursor)
+	if rr := list("pagination=cursor"); rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected repository cursor errors to map to 400, got %d", rr.Code)
+	}
+}
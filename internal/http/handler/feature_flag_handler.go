@@ -3,10 +3,13 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
@@ -17,14 +20,28 @@ import (
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
 )
 
-var featureFlagKeyRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_\-]{0,127}$`)
+var (
+	featureFlagKeyRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_\-]{0,127}$`)
+	// featureFlagAttributeRe matches the attribute names rule clauses accept.
+	featureFlagAttributeRe = regexp.MustCompile(`^[a-z][a-z0-9_.]{0,63}$`)
+)
+
+const (
+	maxFeatureFlagAttributes      = 32
+	maxFeatureFlagAttributeLength = 256
+	maxFeatureFlagBulkKeys        = 100
+
+	featureFlagStreamHeartbeat  = 15 * time.Second
+	featureFlagStreamRetryMilli = 3000
+)
 
 type FeatureFlagHandler struct {
-	svc service.FeatureFlagService
+	svc             service.FeatureFlagService
+	streamHeartbeat time.Duration
 }
 
 func NewFeatureFlagHandler(svc service.FeatureFlagService) *FeatureFlagHandler {
-	return &FeatureFlagHandler{svc: svc}
+	return &FeatureFlagHandler{svc: svc, streamHeartbeat: featureFlagStreamHeartbeat}
 }
 
 func (h *FeatureFlagHandler) EvaluateAll(w http.ResponseWriter, r *http.Request) {
@@ -33,11 +50,10 @@ func (h *FeatureFlagHandler) EvaluateAll(w http.ResponseWriter, r *http.Request)
 		response.Error(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "invalid user", nil)
 		return
 	}
-	ctx := service.FeatureFlagEvaluationContext{
-		UserID:      userID,
-		Roles:       claims.Roles,
-		Org:         strings.TrimSpace(r.URL.Query().Get("org")),
-		Environment: strings.TrimSpace(r.URL.Query().Get("environment")),
+	ctx, err := featureFlagEvaluationContext(r, userID, claims.Roles)
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
 	}
 	results, err := h.svc.EvaluateAll(r.Context(), ctx)
 	if err != nil {
@@ -58,11 +74,10 @@ func (h *FeatureFlagHandler) EvaluateOne(w http.ResponseWriter, r *http.Request)
 		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid feature flag key", nil)
 		return
 	}
-	ctx := service.FeatureFlagEvaluationContext{
-		UserID:      userID,
-		Roles:       claims.Roles,
-		Org:         strings.TrimSpace(r.URL.Query().Get("org")),
-		Environment: strings.TrimSpace(r.URL.Query().Get("environment")),
+	ctx, err := featureFlagEvaluationContext(r, userID, claims.Roles)
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
 	}
 	result, err := h.svc.EvaluateByKey(r.Context(), key, ctx)
 	if err != nil {
@@ -76,6 +91,241 @@ func (h *FeatureFlagHandler) EvaluateOne(w http.ResponseWriter, r *http.Request)
 	response.JSON(w, r, http.StatusOK, result)
 }
 
+// EvaluateBulk evaluates flags for an explicit context supplied by a backend
+// caller (worker, cron job) rather than the caller's own token.
+func (h *FeatureFlagHandler) EvaluateBulk(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Context struct {
+			UserID      uint              `json:"user_id"`
+			Roles       []string          `json:"roles"`
+			Org         string            `json:"org"`
+			Environment string            `json:"environment"`
+			Attributes  map[string]string `json:"attributes"`
+		} `json:"context"`
+		Keys []string `json:"keys"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
+		return
+	}
+	if len(body.Keys) > maxFeatureFlagBulkKeys {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "too many feature flag keys", map[string]int{"max": maxFeatureFlagBulkKeys})
+		return
+	}
+	keys := make([]string, 0, len(body.Keys))
+	for _, raw := range body.Keys {
+		key := strings.TrimSpace(strings.ToLower(raw))
+		if !featureFlagKeyRe.MatchString(key) {
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid feature flag key", map[string]string{"key": raw})
+			return
+		}
+		keys = append(keys, key)
+	}
+	if len(body.Context.Attributes) > maxFeatureFlagAttributes {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "too many targeting attributes", nil)
+		return
+	}
+	attributes := make(map[string]string, len(body.Context.Attributes))
+	for name, value := range body.Context.Attributes {
+		attr := strings.TrimSpace(strings.ToLower(name))
+		if attr == "" || len(attr) > 64 || len(value) > maxFeatureFlagAttributeLength {
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid targeting attribute", map[string]string{"attribute": name})
+			return
+		}
+		attributes[attr] = strings.TrimSpace(value)
+	}
+
+	evalCtx := service.FeatureFlagEvaluationContext{
+		UserID:      body.Context.UserID,
+		Roles:       body.Context.Roles,
+		Org:         strings.TrimSpace(body.Context.Org),
+		Environment: strings.TrimSpace(body.Context.Environment),
+		Attributes:  attributes,
+	}
+	results, err := h.svc.EvaluateKeys(r.Context(), keys, evalCtx)
+	if err != nil {
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to evaluate feature flags", nil)
+		return
+	}
+
+	found := make(map[string]struct{}, len(results))
+	for _, result := range results {
+		found[result.Key] = struct{}{}
+	}
+	missing := make([]string, 0)
+	for _, key := range keys {
+		if _, ok := found[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	response.JSON(w, r, http.StatusOK, map[string]any{"items": results, "missing": missing})
+}
+
+// Snapshot serves every flag definition for SDKs that evaluate locally. The
+// snapshot version doubles as a strong ETag so unchanged polls get 304.
+func (h *FeatureFlagHandler) Snapshot(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := h.svc.Snapshot(r.Context())
+	if err != nil {
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to load feature flag snapshot", nil)
+		return
+	}
+	etag := strconv.Quote(snapshot.Version)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Header().Set("ETag", etag)
+	if matchesIfNoneMatch(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	response.JSON(w, r, http.StatusOK, snapshot)
+}
+
+// Stream pushes re-evaluated flags for the caller over Server-Sent Events
+// whenever a flag or rule changes. A fresh connection starts with a snapshot
+// event; reconnects carrying Last-Event-ID receive a change event for anything
+// missed, or a snapshot when the ID has aged out of the replay log.
+func (h *FeatureFlagHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	userID, claims, err := authUserIDAndClaims(r)
+	if err != nil {
+		response.Error(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "invalid user", nil)
+		return
+	}
+	evalCtx, err := featureFlagEvaluationContext(r, userID, claims.Roles)
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
+	}
+	sub, err := h.svc.SubscribeChanges(r.Context(), strings.TrimSpace(r.Header.Get("Last-Event-ID")))
+	if err != nil {
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to subscribe to feature flag changes", nil)
+		return
+	}
+	defer sub.Close()
+
+	// The server-wide write timeout would otherwise cut the stream short.
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", featureFlagStreamRetryMilli); err != nil {
+		return
+	}
+
+	// Pushes follow flag changes rather than the caller reading a flag, so
+	// they do not count as exposures.
+	push := func(name string, change *service.FeatureFlagChangeEvent) error {
+		results, err := h.svc.EvaluateAllUnrecorded(r.Context(), evalCtx)
+		if err != nil {
+			return err
+		}
+		payload := map[string]any{"items": results}
+		id := ""
+		if change != nil {
+			payload["change"] = change
+			id = change.ID
+		}
+		if err := writeServerSentEvent(w, id, name, payload); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+
+	switch {
+	case !sub.Resumed:
+		err = push("snapshot", nil)
+	case len(sub.Replay) > 0:
+		err = push("change", &sub.Replay[len(sub.Replay)-1])
+	default:
+		err = rc.Flush()
+	}
+	if err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.streamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		case change, ok := <-sub.Events:
+			if !ok {
+				return
+			}
+			if err := push("change", &change); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeServerSentEvent(w io.Writer, id, name string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	if id != "" {
+		b.WriteString("id: " + id + "\n")
+	}
+	b.WriteString("event: " + name + "\n")
+	b.WriteString("data: ")
+	b.Write(data)
+	b.WriteString("\n\n")
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// featureFlagEvaluationContext builds the evaluation context from the caller
+// and query string. Custom targeting attributes are passed as attr.<name> or
+// attr[<name>].
+func featureFlagEvaluationContext(r *http.Request, userID uint, roles []string) (service.FeatureFlagEvaluationContext, error) {
+	query := r.URL.Query()
+	ctx := service.FeatureFlagEvaluationContext{
+		UserID:      userID,
+		Roles:       roles,
+		Org:         strings.TrimSpace(query.Get("org")),
+		Environment: strings.TrimSpace(query.Get("environment")),
+	}
+	for name, values := range query {
+		attr, ok := featureFlagAttributeName(name)
+		if !ok || len(values) == 0 {
+			continue
+		}
+		value := strings.TrimSpace(values[0])
+		if attr == "" || len(attr) > 64 || len(value) > maxFeatureFlagAttributeLength {
+			return service.FeatureFlagEvaluationContext{}, errors.New("invalid targeting attribute")
+		}
+		if ctx.Attributes == nil {
+			ctx.Attributes = make(map[string]string)
+		}
+		ctx.Attributes[attr] = value
+		if len(ctx.Attributes) > maxFeatureFlagAttributes {
+			return service.FeatureFlagEvaluationContext{}, errors.New("too many targeting attributes")
+		}
+	}
+	return ctx, nil
+}
+
+func featureFlagAttributeName(param string) (string, bool) {
+	switch {
+	case strings.HasPrefix(param, "attr."):
+		return strings.ToLower(strings.TrimPrefix(param, "attr.")), true
+	case strings.HasPrefix(param, "attr[") && strings.HasSuffix(param, "]"):
+		return strings.ToLower(param[len("attr[") : len(param)-1]), true
+	default:
+		return "", false
+	}
+}
+
 func (h *FeatureFlagHandler) ListFlags(w http.ResponseWriter, r *http.Request) {
 	flags, err := h.svc.ListFlags(r.Context())
 	if err != nil {
@@ -104,11 +354,7 @@ func (h *FeatureFlagHandler) GetFlag(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *FeatureFlagHandler) CreateFlag(w http.ResponseWriter, r *http.Request) {
-	var body struct {
-		Key         string `json:"key"`
-		Description string `json:"description"`
-		Enabled     bool   `json:"enabled"`
-	}
+	var body featureFlagRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
 		return
@@ -118,9 +364,13 @@ func (h *FeatureFlagHandler) CreateFlag(w http.ResponseWriter, r *http.Request)
 		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid feature flag key", nil)
 		return
 	}
-	flag := &serviceFeatureFlagDTO{Key: key, Description: strings.TrimSpace(body.Description), Enabled: body.Enabled}
+	flag := &serviceFeatureFlagDTO{Key: key, Description: strings.TrimSpace(body.Description), Enabled: body.Enabled, Type: body.Type, DefaultVariant: body.DefaultVariant, Variants: body.Variants, Prerequisites: body.Prerequisites}
 	domainFlag := flag.toDomain()
 	if err := h.svc.CreateFlag(r.Context(), domainFlag); err != nil {
+		if isFeatureFlagDefinitionError(err) {
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+			return
+		}
 		if isConflictError(err) {
 			response.Error(w, r, http.StatusConflict, "CONFLICT", "feature flag already exists", nil)
 			return
@@ -146,11 +396,7 @@ func (h *FeatureFlagHandler) UpdateFlag(w http.ResponseWriter, r *http.Request)
 		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid flag id", nil)
 		return
 	}
-	var body struct {
-		Key         string `json:"key"`
-		Description string `json:"description"`
-		Enabled     bool   `json:"enabled"`
-	}
+	var body featureFlagRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
 		return
@@ -160,13 +406,17 @@ func (h *FeatureFlagHandler) UpdateFlag(w http.ResponseWriter, r *http.Request)
 		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid feature flag key", nil)
 		return
 	}
-	flag := &serviceFeatureFlagDTO{ID: flagID, Key: key, Description: strings.TrimSpace(body.Description), Enabled: body.Enabled}
+	flag := &serviceFeatureFlagDTO{ID: flagID, Key: key, Description: strings.TrimSpace(body.Description), Enabled: body.Enabled, Type: body.Type, DefaultVariant: body.DefaultVariant, Variants: body.Variants, Prerequisites: body.Prerequisites}
 	domainFlag := flag.toDomain()
 	if err := h.svc.UpdateFlag(r.Context(), domainFlag); err != nil {
 		if errors.Is(err, repository.ErrFeatureFlagNotFound) {
 			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "feature flag not found", nil)
 			return
 		}
+		if isFeatureFlagDefinitionError(err) {
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+			return
+		}
 		if isConflictError(err) {
 			response.Error(w, r, http.StatusConflict, "CONFLICT", "feature flag already exists", nil)
 			return
@@ -197,6 +447,15 @@ func (h *FeatureFlagHandler) DeleteFlag(w http.ResponseWriter, r *http.Request)
 			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "feature flag not found", nil)
 			return
 		}
+		if errors.Is(err, repository.ErrFeatureFlagHasDependents) {
+			var dependentsErr *service.FeatureFlagDependentsError
+			var details any
+			if errors.As(err, &dependentsErr) {
+				details = map[string]any{"dependents": dependentsErr.Dependents}
+			}
+			response.Error(w, r, http.StatusConflict, "CONFLICT", "feature flag is a prerequisite of other flags", details)
+			return
+		}
 		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to delete feature flag", nil)
 		return
 	}
@@ -233,21 +492,28 @@ func (h *FeatureFlagHandler) CreateRule(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	var body struct {
-		Type       string `json:"type"`
-		MatchValue string `json:"match_value"`
-		Percentage int    `json:"percentage"`
-		Enabled    bool   `json:"enabled"`
-		Priority   int    `json:"priority"`
+		Type           string                     `json:"type"`
+		MatchValue     string                     `json:"match_value"`
+		Percentage     int                        `json:"percentage"`
+		Enabled        bool                       `json:"enabled"`
+		Priority       int                        `json:"priority"`
+		Variant        string                     `json:"variant"`
+		ClauseOperator string                     `json:"clause_operator"`
+		Clauses        []domain.FeatureFlagClause `json:"clauses"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
 		return
 	}
-	rule := &serviceFeatureFlagRuleDTO{FeatureFlagID: flagID, Type: body.Type, MatchValue: body.MatchValue, Percentage: body.Percentage, Enabled: body.Enabled, Priority: body.Priority}
+	rule := &serviceFeatureFlagRuleDTO{FeatureFlagID: flagID, Type: body.Type, MatchValue: body.MatchValue, Percentage: body.Percentage, Enabled: body.Enabled, Priority: body.Priority, Variant: body.Variant, ClauseOperator: body.ClauseOperator, Clauses: body.Clauses}
 	domainRule := rule.toDomain()
 	if err := h.svc.CreateRule(r.Context(), domainRule); err != nil {
-		if errors.Is(err, service.ErrFeatureFlagInvalidRuleType) || errors.Is(err, service.ErrFeatureFlagInvalidRuleValue) {
-			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		if errors.Is(err, repository.ErrFeatureFlagNotFound) {
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "feature flag not found", nil)
+			return
+		}
+		if errors.Is(err, service.ErrFeatureFlagInvalidRuleType) || errors.Is(err, service.ErrFeatureFlagInvalidRuleValue) || errors.Is(err, service.ErrFeatureFlagInvalidVariant) {
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), featureFlagRuleErrorDetails(err))
 			return
 		}
 		if isConflictError(err) {
@@ -281,25 +547,32 @@ func (h *FeatureFlagHandler) UpdateRule(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	var body struct {
-		Type       string `json:"type"`
-		MatchValue string `json:"match_value"`
-		Percentage int    `json:"percentage"`
-		Enabled    bool   `json:"enabled"`
-		Priority   int    `json:"priority"`
+		Type           string                     `json:"type"`
+		MatchValue     string                     `json:"match_value"`
+		Percentage     int                        `json:"percentage"`
+		Enabled        bool                       `json:"enabled"`
+		Priority       int                        `json:"priority"`
+		Variant        string                     `json:"variant"`
+		ClauseOperator string                     `json:"clause_operator"`
+		Clauses        []domain.FeatureFlagClause `json:"clauses"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
 		return
 	}
-	rule := &serviceFeatureFlagRuleDTO{ID: ruleID, FeatureFlagID: flagID, Type: body.Type, MatchValue: body.MatchValue, Percentage: body.Percentage, Enabled: body.Enabled, Priority: body.Priority}
+	rule := &serviceFeatureFlagRuleDTO{ID: ruleID, FeatureFlagID: flagID, Type: body.Type, MatchValue: body.MatchValue, Percentage: body.Percentage, Enabled: body.Enabled, Priority: body.Priority, Variant: body.Variant, ClauseOperator: body.ClauseOperator, Clauses: body.Clauses}
 	domainRule := rule.toDomain()
 	if err := h.svc.UpdateRule(r.Context(), domainRule); err != nil {
+		if errors.Is(err, repository.ErrFeatureFlagNotFound) {
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "feature flag not found", nil)
+			return
+		}
 		if errors.Is(err, repository.ErrFeatureFlagRuleNotFound) {
 			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "feature flag rule not found", nil)
 			return
 		}
-		if errors.Is(err, service.ErrFeatureFlagInvalidRuleType) || errors.Is(err, service.ErrFeatureFlagInvalidRuleValue) {
-			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		if errors.Is(err, service.ErrFeatureFlagInvalidRuleType) || errors.Is(err, service.ErrFeatureFlagInvalidRuleValue) || errors.Is(err, service.ErrFeatureFlagInvalidVariant) {
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), featureFlagRuleErrorDetails(err))
 			return
 		}
 		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "failed to update rule", nil)
@@ -329,6 +602,10 @@ func (h *FeatureFlagHandler) DeleteRule(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	if err := h.svc.DeleteRule(r.Context(), flagID, ruleID); err != nil {
+		if errors.Is(err, repository.ErrFeatureFlagNotFound) {
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "feature flag not found", nil)
+			return
+		}
 		if errors.Is(err, repository.ErrFeatureFlagRuleNotFound) {
 			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "feature flag rule not found", nil)
 			return
@@ -348,27 +625,153 @@ func (h *FeatureFlagHandler) DeleteRule(w http.ResponseWriter, r *http.Request)
 	response.JSON(w, r, http.StatusOK, map[string]any{"deleted": true})
 }
 
+func (h *FeatureFlagHandler) History(w http.ResponseWriter, r *http.Request) {
+	flagID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid flag id", nil)
+		return
+	}
+	limit := 0
+	if raw := strings.TrimSpace(r.URL.Query().Get("limit")); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 1 {
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "limit must be a positive integer", nil)
+			return
+		}
+		limit = v
+	}
+	versions, err := h.svc.ListHistory(r.Context(), flagID, limit)
+	if err != nil {
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to list feature flag history", nil)
+		return
+	}
+	response.JSON(w, r, http.StatusOK, map[string]any{"items": versions})
+}
+
+func (h *FeatureFlagHandler) Rollback(w http.ResponseWriter, r *http.Request) {
+	flagID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid flag id", nil)
+		return
+	}
+	version, err := strconv.Atoi(strings.TrimSpace(r.URL.Query().Get("version")))
+	if err != nil || version < 1 {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "version must be a positive integer", nil)
+		return
+	}
+	flag, err := h.svc.Rollback(r.Context(), flagID, version)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrFeatureFlagVersionNotFound):
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "feature flag version not found", nil)
+		case errors.Is(err, repository.ErrFeatureFlagNotFound):
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "feature flag not found", nil)
+		case isConflictError(err):
+			response.Error(w, r, http.StatusConflict, "CONFLICT", "feature flag key is taken by another flag", nil)
+		case errors.Is(err, service.ErrFeatureFlagInvalidPrerequisite), errors.Is(err, service.ErrFeatureFlagPrerequisiteCycle):
+			response.Error(w, r, http.StatusConflict, "CONFLICT", err.Error(), nil)
+		default:
+			response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to roll back feature flag", nil)
+		}
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "feature_flag.rollback",
+		ActorUserID: adminActorID(r),
+		TargetType:  "feature_flag",
+		TargetID:    strconv.FormatUint(uint64(flagID), 10),
+		Action:      "rollback",
+		Outcome:     "success",
+		Reason:      "feature_flag_rolled_back",
+	}, "version", version)
+	response.JSON(w, r, http.StatusOK, flag)
+}
+
+// DependencyGraph returns flags as nodes and prerequisites as edges from the
+// dependent flag to the flag it requires.
+func (h *FeatureFlagHandler) DependencyGraph(w http.ResponseWriter, r *http.Request) {
+	graph, err := h.svc.DependencyGraph(r.Context())
+	if err != nil {
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to load feature flag dependency graph", nil)
+		return
+	}
+	response.JSON(w, r, http.StatusOK, graph)
+}
+
+// isFeatureFlagDefinitionError reports validation failures of a flag's type,
+// variants or prerequisites, whose messages are safe to return.
+func isFeatureFlagDefinitionError(err error) bool {
+	return errors.Is(err, service.ErrFeatureFlagInvalidType) ||
+		errors.Is(err, service.ErrFeatureFlagInvalidVariant) ||
+		errors.Is(err, service.ErrFeatureFlagInvalidPrerequisite) ||
+		errors.Is(err, service.ErrFeatureFlagPrerequisiteCycle)
+}
+
+func featureFlagRuleErrorDetails(err error) any {
+	var ruleErr *service.FeatureFlagRuleError
+	if !errors.As(err, &ruleErr) {
+		return nil
+	}
+	return map[string]string{"field": ruleErr.Field, "reason": ruleErr.Reason}
+}
+
+type featureFlagRequestBody struct {
+	Key            string                           `json:"key"`
+	Description    string                           `json:"description"`
+	Enabled        bool                             `json:"enabled"`
+	Type           string                           `json:"type"`
+	DefaultVariant string                           `json:"default_variant"`
+	Variants       []featureFlagVariantRequest      `json:"variants"`
+	Prerequisites  []featureFlagPrerequisiteRequest `json:"prerequisites"`
+}
+
+type featureFlagPrerequisiteRequest struct {
+	PrerequisiteFlagID uint   `json:"prerequisite_flag_id"`
+	Variant            string `json:"variant"`
+	Enabled            *bool  `json:"enabled"`
+}
+
+type featureFlagVariantRequest struct {
+	Key    string          `json:"key"`
+	Value  json.RawMessage `json:"value"`
+	Weight int             `json:"weight"`
+}
+
 type serviceFeatureFlagDTO struct {
-	ID          uint
-	Key         string
-	Description string
-	Enabled     bool
+	ID             uint
+	Key            string
+	Description    string
+	Enabled        bool
+	Type           string
+	DefaultVariant string
+	Variants       []featureFlagVariantRequest
+	Prerequisites  []featureFlagPrerequisiteRequest
 }
 
 func (d *serviceFeatureFlagDTO) toDomain() *domain.FeatureFlag {
-	return &domain.FeatureFlag{ID: d.ID, Key: d.Key, Description: d.Description, Enabled: d.Enabled}
+	flag := &domain.FeatureFlag{ID: d.ID, Key: d.Key, Description: d.Description, Enabled: d.Enabled, Type: d.Type, DefaultVariant: d.DefaultVariant}
+	for _, v := range d.Variants {
+		flag.Variants = append(flag.Variants, domain.FeatureFlagVariant{Key: v.Key, Value: v.Value, Weight: v.Weight})
+	}
+	for _, p := range d.Prerequisites {
+		flag.Prerequisites = append(flag.Prerequisites, domain.FeatureFlagPrerequisite{PrerequisiteFlagID: p.PrerequisiteFlagID, Variant: p.Variant, Enabled: p.Enabled})
+	}
+	return flag
 }
 
 type serviceFeatureFlagRuleDTO struct {
-	ID            uint
-	FeatureFlagID uint
-	Type          string
-	MatchValue    string
-	Percentage    int
-	Enabled       bool
-	Priority      int
+	ID             uint
+	FeatureFlagID  uint
+	Type           string
+	MatchValue     string
+	Percentage     int
+	Enabled        bool
+	Priority       int
+	Variant        string
+	ClauseOperator string
+	Clauses        []domain.FeatureFlagClause
 }
 
 func (d *serviceFeatureFlagRuleDTO) toDomain() *domain.FeatureFlagRule {
-	return &domain.FeatureFlagRule{ID: d.ID, FeatureFlagID: d.FeatureFlagID, Type: d.Type, MatchValue: d.MatchValue, Percentage: d.Percentage, Enabled: d.Enabled, Priority: d.Priority}
+	return &domain.FeatureFlagRule{ID: d.ID, FeatureFlagID: d.FeatureFlagID, Type: d.Type, MatchValue: d.MatchValue, Percentage: d.Percentage, Enabled: d.Enabled, Priority: d.Priority, Variant: d.Variant, ClauseOperator: d.ClauseOperator, Clauses: d.Clauses}
 }
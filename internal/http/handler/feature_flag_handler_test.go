@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/http/middleware"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/security"
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
 	servicegomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/service/gomock"
@@ -109,3 +111,301 @@ func TestFeatureFlagHandlerGetFlagRejectsMalformedID(t *testing.T) {
 		t.Fatalf("expected 400, got %d body=%s", rr.Code, rr.Body.String())
 	}
 }
+
+func TestFeatureFlagHandlerCreateMultivariateFlag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockFeatureFlagService(ctrl)
+	h := NewFeatureFlagHandler(svc)
+
+	svc.EXPECT().CreateFlag(gomock.Any(), gomock.AssignableToTypeOf(&domain.FeatureFlag{})).DoAndReturn(func(ctx context.Context, flag *domain.FeatureFlag) error {
+		if flag.Type != "string" || flag.DefaultVariant != "control" || len(flag.Variants) != 2 {
+			t.Fatalf("unexpected flag passed to service: %+v", flag)
+		}
+		if string(flag.Variants[1].Value) != `"Hurry"` || flag.Variants[1].Weight != 50 {
+			t.Fatalf("unexpected variant: %+v", flag.Variants[1])
+		}
+		flag.ID = 5
+		return nil
+	})
+	body := `{"key":"checkout_copy","enabled":true,"type":"string","default_variant":"control","variants":[{"key":"control","value":"Buy now","weight":50},{"key":"urgent","value":"Hurry","weight":50}]}`
+	rr := httptest.NewRecorder()
+	h.CreateFlag(rr, httptest.NewRequest(http.MethodPost, "/api/v1/admin/feature-flags", strings.NewReader(body)))
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	svc.EXPECT().CreateFlag(gomock.Any(), gomock.Any()).Return(service.ErrFeatureFlagInvalidVariant)
+	rr = httptest.NewRecorder()
+	h.CreateFlag(rr, httptest.NewRequest(http.MethodPost, "/api/v1/admin/feature-flags", strings.NewReader(`{"key":"bad","type":"number","variants":[]}`)))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestFeatureFlagHandlerTargetingAttributesAndRuleErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockFeatureFlagService(ctrl)
+	h := NewFeatureFlagHandler(svc)
+	jwt := security.NewJWTManager("iss", "aud", "abcdefghijklmnopqrstuvwxyz123456", "abcdefghijklmnopqrstuvwxyz654321")
+
+	r := chi.NewRouter()
+	r.Use(middleware.AuthMiddleware(jwt))
+	r.Get("/api/v1/feature-flags", h.EvaluateAll)
+
+	svc.EXPECT().EvaluateAll(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, evalCtx service.FeatureFlagEvaluationContext) ([]service.FeatureFlagEvaluationResult, error) {
+		if evalCtx.Attributes["plan"] != "pro" || evalCtx.Attributes["app_version"] != "2.1.0" {
+			t.Fatalf("unexpected attributes: %+v", evalCtx.Attributes)
+		}
+		return nil, nil
+	})
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/feature-flags?attr.plan=pro&attr%5BApp_Version%5D=2.1.0", nil)
+	req.Header.Set("Authorization", "Bearer "+accessTokenForTest(t, []string{"users:read"}))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	svc.EXPECT().CreateRule(gomock.Any(), gomock.Any()).Return(&service.FeatureFlagRuleError{Field: "clauses[0].operator", Reason: `unsupported operator "like"`})
+	body := `{"type":"attribute","enabled":true,"clauses":[{"attribute":"plan","operator":"like","values":["pro"]}]}`
+	ruleReq := withURLParam(httptest.NewRequest(http.MethodPost, "/api/v1/admin/feature-flags/1/rules", strings.NewReader(body)), "id", "1")
+	rr = httptest.NewRecorder()
+	h.CreateRule(rr, ruleReq)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	if !strings.Contains(rr.Body.String(), `"field":"clauses[0].operator"`) {
+		t.Fatalf("expected field detail in response, got %s", rr.Body.String())
+	}
+}
+
+func TestFeatureFlagHandlerEvaluateBulk(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockFeatureFlagService(ctrl)
+	h := NewFeatureFlagHandler(svc)
+	jwt := security.NewJWTManager("iss", "aud", "abcdefghijklmnopqrstuvwxyz123456", "abcdefghijklmnopqrstuvwxyz654321")
+	rbac := service.NewRBACService()
+
+	r := chi.NewRouter()
+	r.Use(middleware.AuthMiddleware(jwt))
+	r.With(middleware.RequirePermission(rbac, nil, "feature_flags:evaluate")).Post("/api/v1/feature-flags/evaluate", h.EvaluateBulk)
+
+	body := `{"context":{"user_id":77,"roles":["member"],"environment":"prod","attributes":{"Plan":"pro"}},"keys":["new_checkout","Unknown_Flag"]}`
+
+	t.Run("denied without service permission", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/feature-flags/evaluate", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+accessTokenForTest(t, []string{"feature_flags:read"}))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("evaluates explicit context and reports missing keys", func(t *testing.T) {
+		svc.EXPECT().EvaluateKeys(gomock.Any(), []string{"new_checkout", "unknown_flag"}, gomock.Any()).DoAndReturn(func(ctx context.Context, keys []string, evalCtx service.FeatureFlagEvaluationContext) ([]service.FeatureFlagEvaluationResult, error) {
+			if evalCtx.UserID != 77 || evalCtx.Environment != "prod" || evalCtx.Attributes["plan"] != "pro" {
+				t.Fatalf("unexpected evaluation context: %+v", evalCtx)
+			}
+			return []service.FeatureFlagEvaluationResult{{Key: "new_checkout", Enabled: true, Source: "default"}}, nil
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/feature-flags/evaluate", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+accessTokenForTest(t, []string{"feature_flags:evaluate"}))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+		}
+		var env struct {
+			Data struct {
+				Items   []service.FeatureFlagEvaluationResult `json:"items"`
+				Missing []string                              `json:"missing"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(rr.Body.Bytes(), &env); err != nil {
+			t.Fatalf("unmarshal response: %v", err)
+		}
+		if len(env.Data.Items) != 1 || len(env.Data.Missing) != 1 || env.Data.Missing[0] != "unknown_flag" {
+			t.Fatalf("unexpected bulk response: %+v", env.Data)
+		}
+	})
+
+	t.Run("rejects invalid keys", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/feature-flags/evaluate", strings.NewReader(`{"keys":["bad key"]}`))
+		req.Header.Set("Authorization", "Bearer "+accessTokenForTest(t, []string{"feature_flags:evaluate"}))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+}
+
+func TestFeatureFlagHandlerStream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockFeatureFlagService(ctrl)
+	h := NewFeatureFlagHandler(svc)
+	h.streamHeartbeat = 10 * time.Millisecond
+	jwt := security.NewJWTManager("iss", "aud", "abcdefghijklmnopqrstuvwxyz123456", "abcdefghijklmnopqrstuvwxyz654321")
+
+	r := chi.NewRouter()
+	r.Use(middleware.AuthMiddleware(jwt))
+	r.Get("/api/v1/feature-flags/stream", h.Stream)
+
+	t.Run("snapshot then live change", func(t *testing.T) {
+		events := make(chan service.FeatureFlagChangeEvent, 1)
+		svc.EXPECT().SubscribeChanges(gomock.Any(), "").Return(&service.FeatureFlagChangeSubscription{Events: events, Close: func() {}}, nil)
+		svc.EXPECT().EvaluateAllUnrecorded(gomock.Any(), gomock.Any()).Times(2).Return([]service.FeatureFlagEvaluationResult{{Key: "new_checkout", Enabled: true, Source: "default"}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/feature-flags/stream", nil)
+		req.Header.Set("Authorization", "Bearer "+accessTokenForTest(t, []string{"users:read"}))
+		rr := httptest.NewRecorder()
+		done := make(chan struct{})
+		go func() {
+			r.ServeHTTP(rr, req)
+			close(done)
+		}()
+		time.Sleep(30 * time.Millisecond)
+		events <- service.FeatureFlagChangeEvent{ID: "1700000000000-abcd", Type: service.FeatureFlagChangeFlagUpdated, FlagID: 1, FlagKey: "new_checkout"}
+		close(events)
+		<-done
+
+		body := rr.Body.String()
+		if ct := rr.Header().Get("Content-Type"); ct != "text/event-stream" {
+			t.Fatalf("expected event stream content type, got %q", ct)
+		}
+		for _, want := range []string{"retry: 3000\n", "event: snapshot\n", ": heartbeat\n", "id: 1700000000000-abcd\nevent: change\n", `"flag_key":"new_checkout"`} {
+			if !strings.Contains(body, want) {
+				t.Fatalf("expected %q in stream, got %s", want, body)
+			}
+		}
+	})
+
+	t.Run("resume replays latest missed change", func(t *testing.T) {
+		events := make(chan service.FeatureFlagChangeEvent)
+		close(events)
+		svc.EXPECT().SubscribeChanges(gomock.Any(), "1-a").Return(&service.FeatureFlagChangeSubscription{
+			Events:  events,
+			Replay:  []service.FeatureFlagChangeEvent{{ID: "2-b", Type: service.FeatureFlagChangeRuleCreated}, {ID: "3-c", Type: service.FeatureFlagChangeRuleUpdated}},
+			Resumed: true,
+			Close:   func() {},
+		}, nil)
+		svc.EXPECT().EvaluateAllUnrecorded(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/feature-flags/stream", nil)
+		req.Header.Set("Authorization", "Bearer "+accessTokenForTest(t, []string{"users:read"}))
+		req.Header.Set("Last-Event-ID", "1-a")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		body := rr.Body.String()
+		if strings.Contains(body, "event: snapshot") || !strings.Contains(body, "id: 3-c\nevent: change\n") {
+			t.Fatalf("expected single replayed change event, got %s", body)
+		}
+	})
+}
+
+func TestFeatureFlagHandlerSnapshotETag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockFeatureFlagService(ctrl)
+	h := NewFeatureFlagHandler(svc)
+	svc.EXPECT().Snapshot(gomock.Any()).Times(2).Return(&service.FeatureFlagSnapshot{
+		Version: "abc123",
+		Flags:   []domain.FeatureFlag{{ID: 1, Key: "new_checkout", Enabled: true, Type: domain.FeatureFlagTypeBoolean}},
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	h.Snapshot(rr, httptest.NewRequest(http.MethodGet, "/api/v1/feature-flags/snapshot", nil))
+	if rr.Code != http.StatusOK || rr.Header().Get("ETag") != `"abc123"` {
+		t.Fatalf("expected 200 with etag, got %d etag=%q", rr.Code, rr.Header().Get("ETag"))
+	}
+	if !strings.Contains(rr.Body.String(), `"key":"new_checkout"`) {
+		t.Fatalf("expected flag definitions in snapshot, got %s", rr.Body.String())
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/feature-flags/snapshot", nil)
+	req.Header.Set("If-None-Match", `"abc123"`)
+	rr = httptest.NewRecorder()
+	h.Snapshot(rr, req)
+	if rr.Code != http.StatusNotModified || rr.Body.Len() != 0 {
+		t.Fatalf("expected empty 304, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestFeatureFlagHandlerHistoryAndRollback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockFeatureFlagService(ctrl)
+	h := NewFeatureFlagHandler(svc)
+
+	svc.EXPECT().ListHistory(gomock.Any(), uint(7), 20).Return([]domain.FeatureFlagVersion{
+		{ID: 2, FeatureFlagID: 7, Version: 2, Action: service.FeatureFlagChangeFlagUpdated, ActorUserID: 42, Snapshot: json.RawMessage(`{"id":7}`)},
+	}, nil)
+	rr := httptest.NewRecorder()
+	h.History(rr, withURLParam(httptest.NewRequest(http.MethodGet, "/api/v1/admin/feature-flags/7/history?limit=20", nil), "id", "7"))
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"version":2`) || !strings.Contains(rr.Body.String(), `"actor_user_id":42`) {
+		t.Fatalf("expected history items, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	h.History(rr, withURLParam(httptest.NewRequest(http.MethodGet, "/api/v1/admin/feature-flags/7/history?limit=abc", nil), "id", "7"))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid limit, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	h.Rollback(rr, withURLParam(httptest.NewRequest(http.MethodPost, "/api/v1/admin/feature-flags/7/rollback", nil), "id", "7"))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without version, got %d", rr.Code)
+	}
+
+	svc.EXPECT().Rollback(gomock.Any(), uint(7), 9).Return(nil, repository.ErrFeatureFlagVersionNotFound)
+	rr = httptest.NewRecorder()
+	h.Rollback(rr, withURLParam(httptest.NewRequest(http.MethodPost, "/api/v1/admin/feature-flags/7/rollback?version=9", nil), "id", "7"))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown version, got %d", rr.Code)
+	}
+
+	svc.EXPECT().Rollback(gomock.Any(), uint(7), 1).Return(&domain.FeatureFlag{ID: 7, Key: "new_checkout", Type: domain.FeatureFlagTypeBoolean}, nil)
+	rr = httptest.NewRecorder()
+	h.Rollback(rr, withURLParam(httptest.NewRequest(http.MethodPost, "/api/v1/admin/feature-flags/7/rollback?version=1", nil), "id", "7"))
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"key":"new_checkout"`) {
+		t.Fatalf("expected restored flag, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestFeatureFlagHandlerPrerequisites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockFeatureFlagService(ctrl)
+	h := NewFeatureFlagHandler(svc)
+
+	svc.EXPECT().UpdateFlag(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, flag *domain.FeatureFlag) error {
+		if len(flag.Prerequisites) != 1 || flag.Prerequisites[0].PrerequisiteFlagID != 1 || flag.Prerequisites[0].Enabled == nil {
+			t.Fatalf("expected prerequisite in update, got %+v", flag.Prerequisites)
+		}
+		return fmt.Errorf("%w: new_checkout -> new_checkout_v2 -> new_checkout", service.ErrFeatureFlagPrerequisiteCycle)
+	})
+	body := `{"key":"new_checkout","enabled":true,"prerequisites":[{"prerequisite_flag_id":1,"enabled":true}]}`
+	rr := httptest.NewRecorder()
+	h.UpdateFlag(rr, withURLParam(httptest.NewRequest(http.MethodPatch, "/api/v1/admin/feature-flags/2", strings.NewReader(body)), "id", "2"))
+	if rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), "new_checkout -\\u003e new_checkout_v2") {
+		t.Fatalf("expected 400 naming the cycle, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	svc.EXPECT().DeleteFlag(gomock.Any(), uint(1)).Return(&service.FeatureFlagDependentsError{Dependents: []string{"new_checkout_v2"}})
+	rr = httptest.NewRecorder()
+	h.DeleteFlag(rr, withURLParam(httptest.NewRequest(http.MethodDelete, "/api/v1/admin/feature-flags/1", nil), "id", "1"))
+	if rr.Code != http.StatusConflict || !strings.Contains(rr.Body.String(), `"dependents":["new_checkout_v2"]`) {
+		t.Fatalf("expected 409 listing dependents, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	enabled := true
+	svc.EXPECT().DependencyGraph(gomock.Any()).Return(&service.FeatureFlagDependencyGraph{
+		Nodes: []service.FeatureFlagGraphNode{{ID: 1, Key: "new_checkout", Type: "boolean"}, {ID: 2, Key: "new_checkout_v2", Type: "boolean"}},
+		Edges: []service.FeatureFlagGraphEdge{{From: 2, To: 1, Enabled: &enabled}},
+	}, nil)
+	rr = httptest.NewRecorder()
+	h.DependencyGraph(rr, httptest.NewRequest(http.MethodGet, "/api/v1/admin/feature-flags/graph", nil))
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"edges":[{"from":2,"to":1,"enabled":true}]`) {
+		t.Fatalf("expected dependency graph, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+)
+
+// OpenFeature Remote Evaluation Protocol reasons and error codes.
+const (
+	ofrepReasonTargetingMatch = "TARGETING_MATCH"
+	ofrepReasonSplit          = "SPLIT"
+	ofrepReasonDisabled       = "DISABLED"
+	ofrepReasonDefault        = "DEFAULT"
+
+	ofrepErrorParse          = "PARSE_ERROR"
+	ofrepErrorInvalidContext = "INVALID_CONTEXT"
+	ofrepErrorFlagNotFound   = "FLAG_NOT_FOUND"
+	ofrepErrorGeneral        = "GENERAL"
+)
+
+type ofrepEvaluationRequest struct {
+	Context map[string]json.RawMessage `json:"context"`
+}
+
+// ofrepEvaluation is one OFREP result: a successful evaluation carries
+// Value and Reason, a failed one ErrorCode and ErrorDetails.
+type ofrepEvaluation struct {
+	Key          string            `json:"key"`
+	Value        json.RawMessage   `json:"value,omitempty"`
+	Reason       string            `json:"reason,omitempty"`
+	Variant      string            `json:"variant,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	ErrorCode    string            `json:"errorCode,omitempty"`
+	ErrorDetails string            `json:"errorDetails,omitempty"`
+}
+
+type ofrepError struct {
+	Key          string `json:"key,omitempty"`
+	ErrorCode    string `json:"errorCode,omitempty"`
+	ErrorDetails string `json:"errorDetails"`
+}
+
+// OFREPEvaluateFlag implements POST /ofrep/v1/evaluate/flags/{key}.
+func (h *FeatureFlagHandler) OFREPEvaluateFlag(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimSpace(strings.ToLower(chi.URLParam(r, "key")))
+	userID, claims, err := authUserIDAndClaims(r)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	evalCtx, code, err := ofrepEvaluationContext(r, userID, claims.Roles)
+	if err != nil {
+		writeOFREP(w, http.StatusBadRequest, ofrepError{Key: key, ErrorCode: code, ErrorDetails: err.Error()})
+		return
+	}
+	if !featureFlagKeyRe.MatchString(key) {
+		writeOFREP(w, http.StatusNotFound, ofrepError{Key: key, ErrorCode: ofrepErrorFlagNotFound, ErrorDetails: "flag not found"})
+		return
+	}
+	result, err := h.svc.EvaluateByKey(r.Context(), key, evalCtx)
+	if err != nil {
+		if errors.Is(err, repository.ErrFeatureFlagNotFound) {
+			writeOFREP(w, http.StatusNotFound, ofrepError{Key: key, ErrorCode: ofrepErrorFlagNotFound, ErrorDetails: "flag not found"})
+			return
+		}
+		writeOFREP(w, http.StatusInternalServerError, ofrepError{ErrorDetails: "failed to evaluate feature flag"})
+		return
+	}
+	evaluation := toOFREPEvaluation(*result)
+	if evaluation.ErrorCode != "" {
+		writeOFREP(w, http.StatusBadRequest, ofrepError{Key: key, ErrorCode: evaluation.ErrorCode, ErrorDetails: evaluation.ErrorDetails})
+		return
+	}
+	writeOFREP(w, http.StatusOK, evaluation)
+}
+
+// OFREPEvaluateFlags implements POST /ofrep/v1/evaluate/flags. The ETag is
+// derived from the evaluated flags, so a client whose If-None-Match still
+// matches gets 304 and keeps its cached values. Exposures are recorded only
+// when the values are sent.
+func (h *FeatureFlagHandler) OFREPEvaluateFlags(w http.ResponseWriter, r *http.Request) {
+	userID, claims, err := authUserIDAndClaims(r)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	evalCtx, code, err := ofrepEvaluationContext(r, userID, claims.Roles)
+	if err != nil {
+		writeOFREP(w, http.StatusBadRequest, ofrepError{ErrorCode: code, ErrorDetails: err.Error()})
+		return
+	}
+	results, err := h.svc.EvaluateAllUnrecorded(r.Context(), evalCtx)
+	if err != nil {
+		writeOFREP(w, http.StatusInternalServerError, ofrepError{ErrorDetails: "failed to evaluate feature flags"})
+		return
+	}
+	flags := make([]ofrepEvaluation, 0, len(results))
+	for _, result := range results {
+		flags = append(flags, toOFREPEvaluation(result))
+	}
+	body, err := json.Marshal(map[string]any{"flags": flags})
+	if err != nil {
+		writeOFREP(w, http.StatusInternalServerError, ofrepError{ErrorDetails: "failed to encode feature flags"})
+		return
+	}
+	sum := sha256.Sum256(body)
+	etag := strconv.Quote(hex.EncodeToString(sum[:16]))
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Header().Set("ETag", etag)
+	if matchesIfNoneMatch(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	h.svc.RecordExposures(r.Context(), evalCtx, results)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(append(body, '\n'))
+}
+
+// ofrepEvaluationContext maps an OFREP context onto the caller's evaluation
+// context. The caller and its roles always come from the token; targetingKey
+// is optional but must name the caller when present. org and environment
+// fill the matching fields and any other scalar becomes a custom attribute,
+// whose name must be one a rule clause could target.
+func ofrepEvaluationContext(r *http.Request, userID uint, roles []string) (service.FeatureFlagEvaluationContext, string, error) {
+	evalCtx := service.FeatureFlagEvaluationContext{UserID: userID, Roles: roles}
+	var body ofrepEvaluationRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+		return evalCtx, ofrepErrorParse, errors.New("invalid request body")
+	}
+	for name, raw := range body.Context {
+		value, ok := ofrepScalar(raw)
+		if !ok {
+			return evalCtx, ofrepErrorInvalidContext, fmt.Errorf("context field %q must be a string, number or boolean", name)
+		}
+		switch attr := strings.TrimSpace(strings.ToLower(name)); attr {
+		case "targetingkey":
+			if value != "" && value != strconv.FormatUint(uint64(userID), 10) {
+				return evalCtx, ofrepErrorInvalidContext, errors.New("targetingKey must match the authenticated user")
+			}
+		case "org":
+			evalCtx.Org = value
+		case "environment":
+			evalCtx.Environment = value
+		case "roles", "userid", "user_id":
+			// Identity comes from the token and cannot be overridden.
+		default:
+			if !featureFlagAttributeRe.MatchString(attr) || len(value) > maxFeatureFlagAttributeLength {
+				return evalCtx, ofrepErrorInvalidContext, fmt.Errorf("invalid context field %q", name)
+			}
+			if evalCtx.Attributes == nil {
+				evalCtx.Attributes = make(map[string]string)
+			}
+			evalCtx.Attributes[attr] = value
+			if len(evalCtx.Attributes) > maxFeatureFlagAttributes {
+				return evalCtx, ofrepErrorInvalidContext, errors.New("too many targeting attributes")
+			}
+		}
+	}
+	return evalCtx, "", nil
+}
+
+func ofrepScalar(raw json.RawMessage) (string, bool) {
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return "", false
+	}
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v), true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case nil:
+		return "", true
+	default:
+		return "", false
+	}
+}
+
+// toOFREPEvaluation maps a result onto OFREP. Reasons follow the result
+// source: percent rules are SPLIT, other rules TARGETING_MATCH, a disabled
+// flag DISABLED, and the enabled fallthrough or an unmet prerequisite
+// DEFAULT. The original source is kept in metadata.
+func toOFREPEvaluation(result service.FeatureFlagEvaluationResult) ofrepEvaluation {
+	if len(result.Value) == 0 {
+		return ofrepEvaluation{Key: result.Key, ErrorCode: ofrepErrorGeneral, ErrorDetails: "flag served no variant"}
+	}
+	reason := ofrepReasonDefault
+	switch {
+	case result.Source == "rule:"+service.FeatureFlagRuleTypePercent:
+		reason = ofrepReasonSplit
+	case strings.HasPrefix(result.Source, "rule:"):
+		reason = ofrepReasonTargetingMatch
+	case result.Source == "default" && !result.Enabled:
+		reason = ofrepReasonDisabled
+	}
+	return ofrepEvaluation{
+		Key:      result.Key,
+		Value:    result.Value,
+		Reason:   reason,
+		Variant:  result.Variant,
+		Metadata: map[string]string{"source": result.Source},
+	}
+}
+
+func writeOFREP(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
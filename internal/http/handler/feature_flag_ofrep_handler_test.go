@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/http/middleware"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/security"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+	servicegomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/service/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func newOFREPRouterForTest(t *testing.T) (http.Handler, *servicegomock.MockFeatureFlagService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockFeatureFlagService(ctrl)
+	h := NewFeatureFlagHandler(svc)
+	jwt := security.NewJWTManager("iss", "aud", "abcdefghijklmnopqrstuvwxyz123456", "abcdefghijklmnopqrstuvwxyz654321")
+	r := chi.NewRouter()
+	r.Use(middleware.AuthMiddleware(jwt))
+	r.Post("/ofrep/v1/evaluate/flags", h.OFREPEvaluateFlags)
+	r.Post("/ofrep/v1/evaluate/flags/{key}", h.OFREPEvaluateFlag)
+	return r, svc
+}
+
+func ofrepRequestForTest(t *testing.T, path, body string) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+accessTokenForTest(t, nil))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestFeatureFlagHandlerOFREPEvaluateFlag(t *testing.T) {
+	r, svc := newOFREPRouterForTest(t)
+
+	svc.EXPECT().EvaluateByKey(gomock.Any(), "checkout_theme", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, evalCtx service.FeatureFlagEvaluationContext) (*service.FeatureFlagEvaluationResult, error) {
+			if evalCtx.UserID != 42 || evalCtx.Environment != "prod" || evalCtx.Attributes["plan"] != "pro" || evalCtx.Attributes["seats"] != "12" {
+				t.Fatalf("unexpected evaluation context %+v", evalCtx)
+			}
+			return &service.FeatureFlagEvaluationResult{
+				Key: "checkout_theme", Enabled: true, Source: "rule:percent", Type: "string",
+				Variant: "treatment", Value: json.RawMessage(`"dark"`),
+			}, nil
+		})
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, ofrepRequestForTest(t, "/ofrep/v1/evaluate/flags/checkout_theme",
+		`{"context":{"targetingKey":"42","environment":"prod","plan":"pro","seats":12}}`))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	var got map[string]any
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got["key"] != "checkout_theme" || got["value"] != "dark" || got["reason"] != "SPLIT" || got["variant"] != "treatment" {
+		t.Fatalf("unexpected OFREP body %v", got)
+	}
+	if _, wrapped := got["data"]; wrapped {
+		t.Fatal("expected an unwrapped OFREP body")
+	}
+
+	svc.EXPECT().EvaluateByKey(gomock.Any(), "missing", gomock.Any()).Return(nil, repository.ErrFeatureFlagNotFound)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, ofrepRequestForTest(t, "/ofrep/v1/evaluate/flags/missing", `{"context":{}}`))
+	if rr.Code != http.StatusNotFound || !strings.Contains(rr.Body.String(), `"errorCode":"FLAG_NOT_FOUND"`) {
+		t.Fatalf("expected FLAG_NOT_FOUND, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, ofrepRequestForTest(t, "/ofrep/v1/evaluate/flags/checkout_theme", `{"context":{"targetingKey":"7"}}`))
+	if rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), `"errorCode":"INVALID_CONTEXT"`) {
+		t.Fatalf("expected INVALID_CONTEXT for another user's targeting key, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, ofrepRequestForTest(t, "/ofrep/v1/evaluate/flags/checkout_theme", `{"context":{"address":{"city":"x"}}}`))
+	if rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), `"errorCode":"INVALID_CONTEXT"`) {
+		t.Fatalf("expected INVALID_CONTEXT for a nested field, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, ofrepRequestForTest(t, "/ofrep/v1/evaluate/flags/checkout_theme", `{"context":{"billing plan":"pro"}}`))
+	if rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), `"errorCode":"INVALID_CONTEXT"`) {
+		t.Fatalf("expected INVALID_CONTEXT for an invalid attribute name, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, ofrepRequestForTest(t, "/ofrep/v1/evaluate/flags/checkout_theme", `{"context":`))
+	if rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), `"errorCode":"PARSE_ERROR"`) {
+		t.Fatalf("expected PARSE_ERROR, got %d body=%s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestFeatureFlagHandlerOFREPEvaluateFlagsETag(t *testing.T) {
+	r, svc := newOFREPRouterForTest(t)
+	results := []service.FeatureFlagEvaluationResult{
+		{Key: "beta", Enabled: false, Source: "default", Type: "boolean", Value: json.RawMessage(`false`)},
+		{Key: "new_checkout", Enabled: true, Source: "rule:role", Type: "boolean", Value: json.RawMessage(`true`)},
+		{Key: "theme", Enabled: true, Source: "default", Type: "string"},
+	}
+	svc.EXPECT().EvaluateAllUnrecorded(gomock.Any(), gomock.Any()).Return(results, nil).Times(2)
+	// Only the 200 response counts as an exposure; the 304 serves nothing.
+	svc.EXPECT().RecordExposures(gomock.Any(), gomock.Any(), results).Times(1)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, ofrepRequestForTest(t, "/ofrep/v1/evaluate/flags", `{"context":{"targetingKey":"42"}}`))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+	}
+	etag := rr.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected ETag on bulk evaluation")
+	}
+	var body struct {
+		Flags []map[string]any `json:"flags"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil || len(body.Flags) != 3 {
+		t.Fatalf("unexpected bulk body %s err=%v", rr.Body.String(), err)
+	}
+	if body.Flags[0]["reason"] != "DISABLED" || body.Flags[1]["reason"] != "TARGETING_MATCH" || body.Flags[2]["errorCode"] != "GENERAL" {
+		t.Fatalf("unexpected reasons %v", body.Flags)
+	}
+
+	req := ofrepRequestForTest(t, "/ofrep/v1/evaluate/flags", `{"context":{"targetingKey":"42"}}`)
+	req.Header.Set("If-None-Match", etag)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotModified || rr.Body.Len() != 0 || rr.Header().Get("ETag") != etag {
+		t.Fatalf("expected 304 for a matching ETag, got %d", rr.Code)
+	}
+}
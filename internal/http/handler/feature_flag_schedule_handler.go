@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/http/response"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+)
+
+type FeatureFlagScheduleHandler struct {
+	svc service.FeatureFlagScheduleService
+}
+
+func NewFeatureFlagScheduleHandler(svc service.FeatureFlagScheduleService) *FeatureFlagScheduleHandler {
+	return &FeatureFlagScheduleHandler{svc: svc}
+}
+
+type featureFlagScheduleRequestBody struct {
+	RuleID             uint    `json:"rule_id"`
+	GuardrailMetric    string  `json:"guardrail_metric"`
+	GuardrailThreshold float64 `json:"guardrail_threshold"`
+	Steps              []struct {
+		RunAt      time.Time `json:"run_at"`
+		Enabled    *bool     `json:"enabled"`
+		Percentage *int      `json:"percentage"`
+	} `json:"steps"`
+}
+
+func (h *FeatureFlagScheduleHandler) ListSchedules(w http.ResponseWriter, r *http.Request) {
+	flagID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid flag id", nil)
+		return
+	}
+	schedules, err := h.svc.ListSchedules(r.Context(), flagID)
+	if err != nil {
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to list feature flag schedules", nil)
+		return
+	}
+	response.JSON(w, r, http.StatusOK, map[string]any{"items": schedules})
+}
+
+func (h *FeatureFlagScheduleHandler) CreateSchedule(w http.ResponseWriter, r *http.Request) {
+	flagID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid flag id", nil)
+		return
+	}
+	var body featureFlagScheduleRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
+		return
+	}
+	schedule := &domain.FeatureFlagSchedule{
+		FeatureFlagID:      flagID,
+		RuleID:             body.RuleID,
+		GuardrailMetric:    strings.TrimSpace(strings.ToLower(body.GuardrailMetric)),
+		GuardrailThreshold: body.GuardrailThreshold,
+	}
+	for _, step := range body.Steps {
+		schedule.Steps = append(schedule.Steps, domain.FeatureFlagScheduleStep{RunAt: step.RunAt, Enabled: step.Enabled, Percentage: step.Percentage})
+	}
+	if err := h.svc.CreateSchedule(r.Context(), schedule); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrFeatureFlagNotFound):
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "feature flag not found", nil)
+		case errors.Is(err, service.ErrFeatureFlagScheduleInvalid):
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		default:
+			response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to create feature flag schedule", nil)
+		}
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "feature_flag.schedule.create",
+		ActorUserID: adminActorID(r),
+		TargetType:  "feature_flag_schedule",
+		TargetID:    strconv.FormatUint(uint64(schedule.ID), 10),
+		Action:      "create",
+		Outcome:     "success",
+		Reason:      "feature_flag_schedule_created",
+	}, "feature_flag_id", flagID, "steps", len(schedule.Steps))
+	response.JSON(w, r, http.StatusCreated, schedule)
+}
+
+func (h *FeatureFlagScheduleHandler) CancelSchedule(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, h.svc.CancelSchedule, "cancel", "feature_flag_schedule_cancelled")
+}
+
+func (h *FeatureFlagScheduleHandler) ResumeSchedule(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, h.svc.ResumeSchedule, "resume", "feature_flag_schedule_resumed")
+}
+
+func (h *FeatureFlagScheduleHandler) transition(
+	w http.ResponseWriter,
+	r *http.Request,
+	apply func(ctx context.Context, flagID, scheduleID uint) (*domain.FeatureFlagSchedule, error),
+	action, reason string,
+) {
+	flagID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid flag id", nil)
+		return
+	}
+	scheduleID, err := parsePathID(chi.URLParam(r, "schedule_id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid schedule id", nil)
+		return
+	}
+	schedule, err := apply(r.Context(), flagID, scheduleID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrFeatureFlagScheduleNotFound):
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "feature flag schedule not found", nil)
+		case errors.Is(err, repository.ErrFeatureFlagScheduleStatusConflict):
+			response.Error(w, r, http.StatusConflict, "CONFLICT", "feature flag schedule status does not allow "+action, nil)
+		default:
+			response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to "+action+" feature flag schedule", nil)
+		}
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "feature_flag.schedule." + action,
+		ActorUserID: adminActorID(r),
+		TargetType:  "feature_flag_schedule",
+		TargetID:    strconv.FormatUint(uint64(scheduleID), 10),
+		Action:      action,
+		Outcome:     "success",
+		Reason:      reason,
+	}, "feature_flag_id", flagID)
+	response.JSON(w, r, http.StatusOK, schedule)
+}
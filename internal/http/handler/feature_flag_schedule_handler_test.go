@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+	servicegomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/service/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFeatureFlagScheduleHandlerCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockFeatureFlagScheduleService(ctrl)
+	h := NewFeatureFlagScheduleHandler(svc)
+
+	runAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc.EXPECT().CreateSchedule(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, schedule *domain.FeatureFlagSchedule) error {
+		if schedule.FeatureFlagID != 7 || schedule.RuleID != 3 || schedule.GuardrailMetric != service.FeatureFlagGuardrailHTTPErrorRate {
+			t.Fatalf("unexpected schedule: %+v", schedule)
+		}
+		if len(schedule.Steps) != 2 || *schedule.Steps[1].Percentage != 50 || !schedule.Steps[0].RunAt.Equal(runAt) {
+			t.Fatalf("unexpected steps: %+v", schedule.Steps)
+		}
+		schedule.ID = 11
+		schedule.Status = domain.FeatureFlagScheduleStatusActive
+		return nil
+	})
+	body := `{"rule_id":3,"guardrail_metric":"HTTP_ERROR_RATE","guardrail_threshold":0.05,"steps":[{"run_at":"2026-03-01T12:00:00Z","percentage":10},{"run_at":"2026-03-01T13:00:00Z","percentage":50}]}`
+	rr := httptest.NewRecorder()
+	h.CreateSchedule(rr, withURLParam(httptest.NewRequest(http.MethodPost, "/api/v1/admin/feature-flags/7/schedules", strings.NewReader(body)), "id", "7"))
+	if rr.Code != http.StatusCreated || !strings.Contains(rr.Body.String(), `"id":11`) || !strings.Contains(rr.Body.String(), `"status":"active"`) {
+		t.Fatalf("expected created schedule, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	svc.EXPECT().CreateSchedule(gomock.Any(), gomock.Any()).Return(service.ErrFeatureFlagScheduleInvalid)
+	rr = httptest.NewRecorder()
+	h.CreateSchedule(rr, withURLParam(httptest.NewRequest(http.MethodPost, "/api/v1/admin/feature-flags/7/schedules", strings.NewReader(`{"steps":[]}`)), "id", "7"))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid schedule, got %d", rr.Code)
+	}
+
+	svc.EXPECT().CreateSchedule(gomock.Any(), gomock.Any()).Return(repository.ErrFeatureFlagNotFound)
+	rr = httptest.NewRecorder()
+	h.CreateSchedule(rr, withURLParam(httptest.NewRequest(http.MethodPost, "/api/v1/admin/feature-flags/8/schedules", strings.NewReader(`{"steps":[]}`)), "id", "8"))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown flag, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	h.CreateSchedule(rr, withURLParam(httptest.NewRequest(http.MethodPost, "/api/v1/admin/feature-flags/7/schedules", strings.NewReader(`{`)), "id", "7"))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for malformed payload, got %d", rr.Code)
+	}
+}
+
+func TestFeatureFlagScheduleHandlerListAndTransitions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockFeatureFlagScheduleService(ctrl)
+	h := NewFeatureFlagScheduleHandler(svc)
+
+	svc.EXPECT().ListSchedules(gomock.Any(), uint(7)).Return([]domain.FeatureFlagSchedule{{ID: 11, FeatureFlagID: 7, Status: domain.FeatureFlagScheduleStatusPaused}}, nil)
+	rr := httptest.NewRecorder()
+	h.ListSchedules(rr, withURLParam(httptest.NewRequest(http.MethodGet, "/api/v1/admin/feature-flags/7/schedules", nil), "id", "7"))
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"status":"paused"`) {
+		t.Fatalf("expected schedule list, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	svc.EXPECT().ResumeSchedule(gomock.Any(), uint(7), uint(11)).Return(&domain.FeatureFlagSchedule{ID: 11, FeatureFlagID: 7, Status: domain.FeatureFlagScheduleStatusActive}, nil)
+	rr = httptest.NewRecorder()
+	h.ResumeSchedule(rr, withScheduleURLParams(httptest.NewRequest(http.MethodPost, "/api/v1/admin/feature-flags/7/schedules/11/resume", nil), "7", "11"))
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"status":"active"`) {
+		t.Fatalf("expected resumed schedule, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	svc.EXPECT().CancelSchedule(gomock.Any(), uint(7), uint(11)).Return(nil, repository.ErrFeatureFlagScheduleStatusConflict)
+	rr = httptest.NewRecorder()
+	h.CancelSchedule(rr, withScheduleURLParams(httptest.NewRequest(http.MethodPost, "/api/v1/admin/feature-flags/7/schedules/11/cancel", nil), "7", "11"))
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("expected 409 cancelling a finished schedule, got %d", rr.Code)
+	}
+
+	svc.EXPECT().CancelSchedule(gomock.Any(), uint(7), uint(12)).Return(nil, repository.ErrFeatureFlagScheduleNotFound)
+	rr = httptest.NewRecorder()
+	h.CancelSchedule(rr, withScheduleURLParams(httptest.NewRequest(http.MethodPost, "/api/v1/admin/feature-flags/7/schedules/12/cancel", nil), "7", "12"))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown schedule, got %d", rr.Code)
+	}
+}
+
+func withScheduleURLParams(r *http.Request, flagID, scheduleID string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", flagID)
+	rctx.URLParams.Add("schedule_id", scheduleID)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/http/response"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+)
+
+const defaultFeatureFlagStaleDays = 30
+
+type FeatureFlagUsageHandler struct {
+	svc service.FeatureFlagUsageService
+}
+
+func NewFeatureFlagUsageHandler(svc service.FeatureFlagUsageService) *FeatureFlagUsageHandler {
+	return &FeatureFlagUsageHandler{svc: svc}
+}
+
+func (h *FeatureFlagUsageHandler) Usage(w http.ResponseWriter, r *http.Request) {
+	flagID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid flag id", nil)
+		return
+	}
+	usage, err := h.svc.Usage(r.Context(), flagID)
+	if err != nil {
+		if errors.Is(err, repository.ErrFeatureFlagNotFound) {
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "feature flag not found", nil)
+			return
+		}
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to load feature flag usage", nil)
+		return
+	}
+	response.JSON(w, r, http.StatusOK, usage)
+}
+
+func (h *FeatureFlagUsageHandler) StaleFlags(w http.ResponseWriter, r *http.Request) {
+	days := defaultFeatureFlagStaleDays
+	if raw := strings.TrimSpace(r.URL.Query().Get("days")); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "days must be an integer", nil)
+			return
+		}
+		days = v
+	}
+	flags, err := h.svc.StaleFlags(r.Context(), days)
+	if err != nil {
+		if errors.Is(err, service.ErrFeatureFlagInvalidStaleWindow) {
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+			return
+		}
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to list stale feature flags", nil)
+		return
+	}
+	response.JSON(w, r, http.StatusOK, map[string]any{"days": days, "items": flags})
+}
+
+func (h *FeatureFlagUsageHandler) ListExposures(w http.ResponseWriter, r *http.Request) {
+	flagID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid flag id", nil)
+		return
+	}
+	limit := 0
+	if raw := strings.TrimSpace(r.URL.Query().Get("limit")); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 1 {
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "limit must be a positive integer", nil)
+			return
+		}
+		limit = v
+	}
+	var beforeID uint
+	if raw := strings.TrimSpace(r.URL.Query().Get("before_id")); raw != "" {
+		if beforeID, err = parsePathID(raw); err != nil {
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid before_id", nil)
+			return
+		}
+	}
+	exposures, err := h.svc.ListExposures(r.Context(), flagID, beforeID, limit)
+	if err != nil {
+		if errors.Is(err, repository.ErrFeatureFlagNotFound) {
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "feature flag not found", nil)
+			return
+		}
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to list feature flag exposures", nil)
+		return
+	}
+	body := map[string]any{"items": exposures}
+	if len(exposures) > 0 {
+		body["next_before_id"] = exposures[len(exposures)-1].ID
+	}
+	response.JSON(w, r, http.StatusOK, body)
+}
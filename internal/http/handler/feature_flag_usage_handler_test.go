@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+	servicegomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/service/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFeatureFlagUsageHandlerStaleFlags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockFeatureFlagUsageService(ctrl)
+	h := NewFeatureFlagUsageHandler(svc)
+
+	svc.EXPECT().StaleFlags(gomock.Any(), 30).Return([]service.StaleFeatureFlag{
+		{ID: 4, Key: "old_banner", Reasons: []string{service.FeatureFlagStaleNotEvaluated}},
+	}, nil)
+	rr := httptest.NewRecorder()
+	h.StaleFlags(rr, httptest.NewRequest(http.MethodGet, "/api/v1/admin/feature-flags/stale", nil))
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"days":30`) || !strings.Contains(rr.Body.String(), `"reasons":["not_evaluated"]`) {
+		t.Fatalf("expected stale report with the default window, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	svc.EXPECT().StaleFlags(gomock.Any(), 400).Return(nil, service.ErrFeatureFlagInvalidStaleWindow)
+	rr = httptest.NewRecorder()
+	h.StaleFlags(rr, httptest.NewRequest(http.MethodGet, "/api/v1/admin/feature-flags/stale?days=400", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an out of range window, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	h.StaleFlags(rr, httptest.NewRequest(http.MethodGet, "/api/v1/admin/feature-flags/stale?days=soon", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a non-numeric window, got %d", rr.Code)
+	}
+}
+
+func TestFeatureFlagUsageHandlerUsageAndExposures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockFeatureFlagUsageService(ctrl)
+	h := NewFeatureFlagUsageHandler(svc)
+
+	svc.EXPECT().Usage(gomock.Any(), uint(7)).Return(&service.FeatureFlagUsage{
+		FlagID: 7, FlagKey: "checkout", Evaluations: 12,
+		Breakdown: []domain.FeatureFlagEvaluationStat{{FlagKey: "checkout", Source: "rule:user", Count: 12}},
+	}, nil)
+	rr := httptest.NewRecorder()
+	h.Usage(rr, withURLParam(httptest.NewRequest(http.MethodGet, "/api/v1/admin/feature-flags/7/usage", nil), "id", "7"))
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"evaluations":12`) || !strings.Contains(rr.Body.String(), `"source":"rule:user"`) {
+		t.Fatalf("expected usage body, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	svc.EXPECT().Usage(gomock.Any(), uint(8)).Return(nil, repository.ErrFeatureFlagNotFound)
+	rr = httptest.NewRecorder()
+	h.Usage(rr, withURLParam(httptest.NewRequest(http.MethodGet, "/api/v1/admin/feature-flags/8/usage", nil), "id", "8"))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown flag, got %d", rr.Code)
+	}
+
+	svc.EXPECT().ListExposures(gomock.Any(), uint(7), uint(50), 2).Return([]domain.FeatureFlagExposure{
+		{ID: 49, FlagKey: "checkout", UserID: 3},
+		{ID: 47, FlagKey: "checkout", UserID: 9},
+	}, nil)
+	rr = httptest.NewRecorder()
+	h.ListExposures(rr, withURLParam(httptest.NewRequest(http.MethodGet, "/api/v1/admin/feature-flags/7/exposures?limit=2&before_id=50", nil), "id", "7"))
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"next_before_id":47`) {
+		t.Fatalf("expected exposures with a cursor, got %d body=%s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	h.ListExposures(rr, withURLParam(httptest.NewRequest(http.MethodGet, "/api/v1/admin/feature-flags/7/exposures?limit=0", nil), "id", "7"))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid limit, got %d", rr.Code)
+	}
+}
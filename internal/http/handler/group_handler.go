@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/http/response"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+)
+
+type GroupHandler struct {
+	svc            service.GroupService
+	adminListCache service.AdminListCacheStore
+}
+
+func NewGroupHandler(svc service.GroupService, adminListCache service.AdminListCacheStore) *GroupHandler {
+	return &GroupHandler{svc: svc, adminListCache: adminListCache}
+}
+
+func (h *GroupHandler) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		RoleIDs     []uint `json:"role_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
+		return
+	}
+	group, err := h.svc.CreateGroup(r.Context(), service.CreateGroupInput{
+		Name:        body.Name,
+		Description: body.Description,
+		RoleIDs:     body.RoleIDs,
+	})
+	if err != nil {
+		h.writeGroupError(w, r, err, "failed to create group")
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "admin.group.create",
+		ActorUserID: adminActorID(r),
+		TargetType:  "group",
+		TargetID:    strconv.FormatUint(uint64(group.ID), 10),
+		Action:      "create",
+		Outcome:     "success",
+		Reason:      "group_created",
+	}, "role_ids", body.RoleIDs)
+	response.JSON(w, r, http.StatusCreated, group)
+}
+
+func (h *GroupHandler) ListGroups(w http.ResponseWriter, r *http.Request) {
+	pageReq, err := parsePageRequest(r)
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
+	}
+	res, err := h.svc.ListGroups(r.Context(), pageReq, strings.TrimSpace(r.URL.Query().Get("name")))
+	if err != nil {
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to list groups", nil)
+		return
+	}
+	response.JSON(w, r, http.StatusOK, paginatedData(res.Items, res.Page, res.PageSize, res.Total, res.TotalPages))
+}
+
+func (h *GroupHandler) GetGroup(w http.ResponseWriter, r *http.Request) {
+	groupID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid group id", nil)
+		return
+	}
+	group, err := h.svc.GetGroup(r.Context(), groupID)
+	if err != nil {
+		h.writeGroupError(w, r, err, "failed to load group")
+		return
+	}
+	response.JSON(w, r, http.StatusOK, group)
+}
+
+func (h *GroupHandler) UpdateGroup(w http.ResponseWriter, r *http.Request) {
+	groupID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid group id", nil)
+		return
+	}
+	var body struct {
+		Name        *string `json:"name"`
+		Description *string `json:"description"`
+		RoleIDs     *[]uint `json:"role_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
+		return
+	}
+	if body.Name == nil && body.Description == nil && body.RoleIDs == nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "at least one field is required", nil)
+		return
+	}
+	group, err := h.svc.UpdateGroup(r.Context(), groupID, service.UpdateGroupInput{
+		Name:        body.Name,
+		Description: body.Description,
+		RoleIDs:     body.RoleIDs,
+	})
+	if err != nil {
+		h.writeGroupError(w, r, err, "failed to update group")
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "admin.group.update",
+		ActorUserID: adminActorID(r),
+		TargetType:  "group",
+		TargetID:    strconv.FormatUint(uint64(groupID), 10),
+		Action:      "update",
+		Outcome:     "success",
+		Reason:      "group_updated",
+	}, "roles_changed", body.RoleIDs != nil)
+	response.JSON(w, r, http.StatusOK, group)
+}
+
+func (h *GroupHandler) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	groupID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid group id", nil)
+		return
+	}
+	if err := h.svc.DeleteGroup(r.Context(), groupID); err != nil {
+		h.writeGroupError(w, r, err, "failed to delete group")
+		return
+	}
+	h.invalidateUserList(r)
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "admin.group.delete",
+		ActorUserID: adminActorID(r),
+		TargetType:  "group",
+		TargetID:    strconv.FormatUint(uint64(groupID), 10),
+		Action:      "delete",
+		Outcome:     "success",
+		Reason:      "group_deleted",
+	})
+	response.JSON(w, r, http.StatusOK, map[string]any{"id": groupID, "deleted": true})
+}
+
+func (h *GroupHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	groupID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid group id", nil)
+		return
+	}
+	pageReq, err := parsePageRequest(r)
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
+	}
+	res, err := h.svc.ListMembers(r.Context(), groupID, pageReq)
+	if err != nil {
+		h.writeGroupError(w, r, err, "failed to list group members")
+		return
+	}
+	response.JSON(w, r, http.StatusOK, paginatedData(res.Items, res.Page, res.PageSize, res.Total, res.TotalPages))
+}
+
+func (h *GroupHandler) AddMembers(w http.ResponseWriter, r *http.Request) {
+	groupID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid group id", nil)
+		return
+	}
+	var body struct {
+		UserIDs []uint `json:"user_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
+		return
+	}
+	if len(body.UserIDs) == 0 {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "user_ids is required", nil)
+		return
+	}
+	added, err := h.svc.AddMembers(r.Context(), groupID, body.UserIDs)
+	if err != nil {
+		h.writeGroupError(w, r, err, "failed to add group members")
+		return
+	}
+	h.invalidateUserList(r)
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "admin.group.members.add",
+		ActorUserID: adminActorID(r),
+		TargetType:  "group",
+		TargetID:    strconv.FormatUint(uint64(groupID), 10),
+		Action:      "add_members",
+		Outcome:     "success",
+		Reason:      "group_members_added",
+	}, "user_ids", added)
+	response.JSON(w, r, http.StatusOK, map[string]any{"group_id": groupID, "added_user_ids": added})
+}
+
+func (h *GroupHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	groupID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid group id", nil)
+		return
+	}
+	userID, err := parsePathID(chi.URLParam(r, "user_id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid user id", nil)
+		return
+	}
+	if err := h.svc.RemoveMember(r.Context(), groupID, userID); err != nil {
+		h.writeGroupError(w, r, err, "failed to remove group member")
+		return
+	}
+	h.invalidateUserList(r)
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "admin.group.members.remove",
+		ActorUserID: adminActorID(r),
+		TargetType:  "group",
+		TargetID:    strconv.FormatUint(uint64(groupID), 10),
+		Action:      "remove_member",
+		Outcome:     "success",
+		Reason:      "group_member_removed",
+	}, "user_id", userID)
+	response.JSON(w, r, http.StatusOK, map[string]any{"group_id": groupID, "user_id": userID, "removed": true})
+}
+
+func (h *GroupHandler) writeGroupError(w http.ResponseWriter, r *http.Request, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrGroupInvalidName),
+		errors.Is(err, service.ErrGroupInvalidDesc),
+		errors.Is(err, service.ErrGroupTooManyMembers):
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+	case errors.Is(err, repository.ErrRoleNotFound):
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "one or more roles do not exist", nil)
+	case errors.Is(err, repository.ErrGroupUserNotFound):
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+	case errors.Is(err, service.ErrGroupProtectedRole):
+		response.Error(w, r, http.StatusForbidden, "FORBIDDEN", err.Error(), nil)
+	case errors.Is(err, repository.ErrGroupNotFound):
+		response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "group not found", nil)
+	case errors.Is(err, repository.ErrGroupMemberNotFound):
+		response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "group member not found", nil)
+	case isConflictError(err):
+		response.Error(w, r, http.StatusConflict, "CONFLICT", "group name already exists", nil)
+	default:
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", fallback, nil)
+	}
+}
+
+// invalidateUserList drops cached admin user listings, whose group filter
+// goes stale when membership changes.
+func (h *GroupHandler) invalidateUserList(r *http.Request) {
+	if h.adminListCache == nil {
+		return
+	}
+	if err := h.adminListCache.InvalidateNamespace(r.Context(), "admin.users.list"); err != nil {
+		observability.RecordAdminListCacheEvent(r.Context(), "admin.users.list", "invalidate_error")
+		return
+	}
+	observability.RecordAdminListCacheEvent(r.Context(), "admin.users.list", "invalidate")
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+	servicegomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/service/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGroupHandlerMembershipEndpoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockGroupService(ctrl)
+	cache := servicegomock.NewMockAdminListCacheStore(ctrl)
+	h := NewGroupHandler(svc, cache)
+
+	r := chi.NewRouter()
+	r.Post("/admin/groups/{id}/members", h.AddMembers)
+	r.Delete("/admin/groups/{id}/members/{user_id}", h.RemoveMember)
+	r.Patch("/admin/groups/{id}", h.UpdateGroup)
+
+	t.Run("add members invalidates user list cache", func(t *testing.T) {
+		svc.EXPECT().AddMembers(gomock.Any(), uint(5), []uint{1, 2}).Return([]uint{2}, nil)
+		cache.EXPECT().InvalidateNamespace(gomock.Any(), "admin.users.list").Return(nil)
+		req := httptest.NewRequest(http.MethodPost, "/admin/groups/5/members", strings.NewReader(`{"user_ids":[1,2]}`))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+		}
+		if !strings.Contains(rr.Body.String(), `"added_user_ids":[2]`) {
+			t.Fatalf("expected added ids in body, got %s", rr.Body.String())
+		}
+	})
+
+	t.Run("empty member list is rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/admin/groups/5/members", strings.NewReader(`{"user_ids":[]}`))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", rr.Code)
+		}
+	})
+
+	t.Run("unknown member maps to 404", func(t *testing.T) {
+		svc.EXPECT().RemoveMember(gomock.Any(), uint(5), uint(9)).Return(repository.ErrGroupMemberNotFound)
+		req := httptest.NewRequest(http.MethodDelete, "/admin/groups/5/members/9", nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected 404, got %d", rr.Code)
+		}
+	})
+
+	t.Run("protected role maps to 403", func(t *testing.T) {
+		svc.EXPECT().UpdateGroup(gomock.Any(), uint(5), gomock.Any()).Return(nil, service.ErrGroupProtectedRole)
+		req := httptest.NewRequest(http.MethodPatch, "/admin/groups/5", strings.NewReader(`{"role_ids":[1]}`))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d", rr.Code)
+		}
+	})
+}
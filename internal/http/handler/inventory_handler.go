@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/http/response"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+)
+
+type InventoryHandler struct {
+	svc service.InventoryService
+}
+
+func NewInventoryHandler(svc service.InventoryService) *InventoryHandler {
+	return &InventoryHandler{svc: svc}
+}
+
+func (h *InventoryHandler) GetStock(w http.ResponseWriter, r *http.Request) {
+	productID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid product id", nil)
+		return
+	}
+	stock, err := h.svc.GetStock(r.Context(), productID)
+	if err != nil {
+		h.writeInventoryError(w, r, err, "failed to load stock")
+		return
+	}
+	response.JSON(w, r, http.StatusOK, stock)
+}
+
+// Adjust changes a product's on-hand stock by a signed delta, e.g. +20 for a
+// delivery or -2 for damaged goods.
+func (h *InventoryHandler) Adjust(w http.ResponseWriter, r *http.Request) {
+	productID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid product id", nil)
+		return
+	}
+	var body struct {
+		Delta  int64  `json:"delta"`
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
+		return
+	}
+	stock, err := h.svc.AdjustStock(r.Context(), productID, service.AdjustStockInput{Delta: body.Delta, Reason: body.Reason})
+	if err != nil {
+		h.writeInventoryError(w, r, err, "failed to adjust stock")
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "inventory.adjust",
+		ActorUserID: adminActorID(r),
+		TargetType:  "product",
+		TargetID:    strconv.FormatUint(uint64(productID), 10),
+		Action:      "adjust",
+		Outcome:     "success",
+		Reason:      "stock_adjusted",
+	}, "delta", body.Delta, "on_hand", stock.OnHand)
+	response.JSON(w, r, http.StatusOK, stock)
+}
+
+func (h *InventoryHandler) ListMovements(w http.ResponseWriter, r *http.Request) {
+	productID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid product id", nil)
+		return
+	}
+	pageReq, err := parsePageRequest(r)
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
+	}
+	res, err := h.svc.ListMovements(r.Context(), productID, pageReq)
+	if err != nil {
+		h.writeInventoryError(w, r, err, "failed to list stock movements")
+		return
+	}
+	response.JSON(w, r, http.StatusOK, paginatedData(res.Items, res.Page, res.PageSize, res.Total, res.TotalPages))
+}
+
+func (h *InventoryHandler) Reserve(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		ProductID  uint  `json:"product_id"`
+		Quantity   int64 `json:"quantity"`
+		TTLSeconds int64 `json:"ttl_seconds"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
+		return
+	}
+	if body.ProductID == 0 {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "product_id is required", nil)
+		return
+	}
+	if body.TTLSeconds < 0 {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", service.ErrInventoryInvalidTTL.Error(), nil)
+		return
+	}
+	reservation, err := h.svc.Reserve(r.Context(), service.ReserveStockInput{
+		ProductID: body.ProductID,
+		Quantity:  body.Quantity,
+		TTL:       time.Duration(body.TTLSeconds) * time.Second,
+	})
+	if err != nil {
+		h.writeInventoryError(w, r, err, "failed to reserve stock")
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "inventory.reserve",
+		ActorUserID: adminActorID(r),
+		TargetType:  "stock_reservation",
+		TargetID:    strconv.FormatUint(uint64(reservation.ID), 10),
+		Action:      "reserve",
+		Outcome:     "success",
+		Reason:      "stock_reserved",
+	}, "product_id", reservation.ProductID, "quantity", reservation.Quantity)
+	response.JSON(w, r, http.StatusCreated, reservation)
+}
+
+func (h *InventoryHandler) GetReservation(w http.ResponseWriter, r *http.Request) {
+	reservationID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid reservation id", nil)
+		return
+	}
+	reservation, err := h.svc.GetReservation(r.Context(), reservationID)
+	if err != nil {
+		h.writeInventoryError(w, r, err, "failed to load reservation")
+		return
+	}
+	response.JSON(w, r, http.StatusOK, reservation)
+}
+
+func (h *InventoryHandler) Commit(w http.ResponseWriter, r *http.Request) {
+	reservationID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid reservation id", nil)
+		return
+	}
+	reservation, err := h.svc.CommitReservation(r.Context(), reservationID)
+	if err != nil {
+		h.writeInventoryError(w, r, err, "failed to commit reservation")
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "inventory.commit",
+		ActorUserID: adminActorID(r),
+		TargetType:  "stock_reservation",
+		TargetID:    strconv.FormatUint(uint64(reservationID), 10),
+		Action:      "commit",
+		Outcome:     "success",
+		Reason:      "reservation_committed",
+	}, "product_id", reservation.ProductID, "quantity", reservation.Quantity)
+	response.JSON(w, r, http.StatusOK, reservation)
+}
+
+func (h *InventoryHandler) Release(w http.ResponseWriter, r *http.Request) {
+	reservationID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid reservation id", nil)
+		return
+	}
+	reservation, err := h.svc.ReleaseReservation(r.Context(), reservationID)
+	if err != nil {
+		h.writeInventoryError(w, r, err, "failed to release reservation")
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "inventory.release",
+		ActorUserID: adminActorID(r),
+		TargetType:  "stock_reservation",
+		TargetID:    strconv.FormatUint(uint64(reservationID), 10),
+		Action:      "release",
+		Outcome:     "success",
+		Reason:      "reservation_released",
+	}, "product_id", reservation.ProductID, "quantity", reservation.Quantity)
+	response.JSON(w, r, http.StatusOK, reservation)
+}
+
+func (h *InventoryHandler) writeInventoryError(w http.ResponseWriter, r *http.Request, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrInventoryInvalidQuantity),
+		errors.Is(err, service.ErrInventoryInvalidDelta),
+		errors.Is(err, service.ErrInventoryInvalidReason),
+		errors.Is(err, service.ErrInventoryInvalidTTL):
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+	case errors.Is(err, repository.ErrProductNotFound):
+		response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "product not found", nil)
+	case errors.Is(err, repository.ErrReservationNotFound):
+		response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "reservation not found", nil)
+	case errors.Is(err, repository.ErrInsufficientStock),
+		errors.Is(err, repository.ErrReservationNotActive):
+		response.Error(w, r, http.StatusConflict, "CONFLICT", err.Error(), nil)
+	default:
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", fallback, nil)
+	}
+}
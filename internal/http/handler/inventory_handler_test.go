@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+	servicegomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/service/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInventoryHandlerEndpoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockInventoryService(ctrl)
+	h := NewInventoryHandler(svc)
+
+	r := chi.NewRouter()
+	r.Get("/inventory/products/{id}", h.GetStock)
+	r.Post("/inventory/products/{id}/adjustments", h.Adjust)
+	r.Post("/inventory/reservations", h.Reserve)
+	r.Post("/inventory/reservations/{id}/commit", h.Commit)
+	r.Post("/inventory/reservations/{id}/release", h.Release)
+
+	t.Run("stock includes available units", func(t *testing.T) {
+		svc.EXPECT().GetStock(gomock.Any(), uint(3)).Return(&domain.ProductStock{ProductID: 3, OnHand: 10, Reserved: 4}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/inventory/products/3", nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"available":6`) {
+			t.Fatalf("expected 200 with available stock, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("adjustment below reserved maps to 409", func(t *testing.T) {
+		svc.EXPECT().AdjustStock(gomock.Any(), uint(3), service.AdjustStockInput{Delta: -8, Reason: "damaged"}).Return(nil, repository.ErrInsufficientStock)
+		req := httptest.NewRequest(http.MethodPost, "/inventory/products/3/adjustments", strings.NewReader(`{"delta":-8,"reason":"damaged"}`))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusConflict {
+			t.Fatalf("expected 409, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("reserve converts ttl seconds", func(t *testing.T) {
+		svc.EXPECT().Reserve(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, input service.ReserveStockInput) (*domain.StockReservation, error) {
+			if input.ProductID != 3 || input.Quantity != 2 || input.TTL != 90*time.Second {
+				t.Fatalf("unexpected reserve input: %+v", input)
+			}
+			return &domain.StockReservation{ID: 9, ProductID: 3, Quantity: 2, Status: domain.ReservationActive}, nil
+		})
+		req := httptest.NewRequest(http.MethodPost, "/inventory/reservations", strings.NewReader(`{"product_id":3,"quantity":2,"ttl_seconds":90}`))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusCreated || !strings.Contains(rr.Body.String(), `"status":"active"`) {
+			t.Fatalf("expected 201 with reservation, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("reserve without product is rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/inventory/reservations", strings.NewReader(`{"quantity":2}`))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", rr.Code)
+		}
+	})
+
+	t.Run("committing a released reservation maps to 409", func(t *testing.T) {
+		svc.EXPECT().CommitReservation(gomock.Any(), uint(9)).Return(nil, repository.ErrReservationNotActive)
+		req := httptest.NewRequest(http.MethodPost, "/inventory/reservations/9/commit", nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusConflict {
+			t.Fatalf("expected 409, got %d", rr.Code)
+		}
+	})
+
+	t.Run("unknown reservation maps to 404", func(t *testing.T) {
+		svc.EXPECT().ReleaseReservation(gomock.Any(), uint(99)).Return(nil, repository.ErrReservationNotFound)
+		req := httptest.NewRequest(http.MethodPost, "/inventory/reservations/99/release", nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected 404, got %d", rr.Code)
+		}
+	})
+}
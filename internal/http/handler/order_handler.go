@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/http/response"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+)
+
+// OrderHandler serves customers' own orders under /orders and the staff
+// order desk under /admin/orders. Every order change is audited.
+type OrderHandler struct {
+	svc service.OrderService
+}
+
+func NewOrderHandler(svc service.OrderService) *OrderHandler {
+	return &OrderHandler{svc: svc}
+}
+
+// Checkout turns the caller's cart into a pending order. Prices and the
+// total come from the products; the body may only pick the currency.
+func (h *OrderHandler) Checkout(w http.ResponseWriter, r *http.Request) {
+	userID, err := actorIDFromRequest(r)
+	if err != nil {
+		response.Error(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "invalid user context", nil)
+		return
+	}
+	var body struct {
+		Currency string `json:"currency"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
+		return
+	}
+	order, err := h.svc.Checkout(r.Context(), userID, service.CheckoutInput{Currency: body.Currency})
+	if err != nil {
+		h.writeOrderError(w, r, err, "failed to check out")
+		return
+	}
+	auditOrder(r, "order.create", "create", "order_created", order)
+	response.JSON(w, r, http.StatusCreated, order)
+}
+
+func (h *OrderHandler) ListMine(w http.ResponseWriter, r *http.Request) {
+	userID, err := actorIDFromRequest(r)
+	if err != nil {
+		response.Error(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "invalid user context", nil)
+		return
+	}
+	h.list(w, r, userID)
+}
+
+func (h *OrderHandler) GetMine(w http.ResponseWriter, r *http.Request) {
+	userID, err := actorIDFromRequest(r)
+	if err != nil {
+		response.Error(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "invalid user context", nil)
+		return
+	}
+	h.get(w, r, userID)
+}
+
+func (h *OrderHandler) PayMine(w http.ResponseWriter, r *http.Request) {
+	userID, err := actorIDFromRequest(r)
+	if err != nil {
+		response.Error(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "invalid user context", nil)
+		return
+	}
+	h.change(w, r, "pay", "order_paid", func(ctx context.Context, id uint) (*domain.Order, error) {
+		return h.svc.Pay(ctx, id, userID)
+	})
+}
+
+func (h *OrderHandler) CancelMine(w http.ResponseWriter, r *http.Request) {
+	userID, err := actorIDFromRequest(r)
+	if err != nil {
+		response.Error(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "invalid user context", nil)
+		return
+	}
+	h.change(w, r, "cancel", "order_cancelled", func(ctx context.Context, id uint) (*domain.Order, error) {
+		return h.svc.Cancel(ctx, id, userID)
+	})
+}
+
+// List lists all orders in the caller's tenant, optionally filtered by
+// ?user_id= and ?status=.
+func (h *OrderHandler) List(w http.ResponseWriter, r *http.Request) {
+	var userID uint
+	if raw := strings.TrimSpace(r.URL.Query().Get("user_id")); raw != "" {
+		id, err := parsePathID(raw)
+		if err != nil || id == 0 {
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid user_id", nil)
+			return
+		}
+		userID = id
+	}
+	h.list(w, r, userID)
+}
+
+func (h *OrderHandler) Get(w http.ResponseWriter, r *http.Request) {
+	h.get(w, r, 0)
+}
+
+func (h *OrderHandler) Fulfill(w http.ResponseWriter, r *http.Request) {
+	h.change(w, r, "fulfill", "order_fulfilled", h.svc.Fulfill)
+}
+
+func (h *OrderHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	h.change(w, r, "cancel", "order_cancelled", func(ctx context.Context, id uint) (*domain.Order, error) {
+		return h.svc.Cancel(ctx, id, 0)
+	})
+}
+
+func (h *OrderHandler) Refund(w http.ResponseWriter, r *http.Request) {
+	h.change(w, r, "refund", "order_refunded", h.svc.Refund)
+}
+
+func (h *OrderHandler) list(w http.ResponseWriter, r *http.Request, userID uint) {
+	pageReq, err := parsePageRequest(r)
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
+	}
+	status := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("status")))
+	switch status {
+	case "", domain.OrderPending, domain.OrderPaid, domain.OrderFulfilled, domain.OrderCancelled, domain.OrderRefunded:
+	default:
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid status", nil)
+		return
+	}
+	res, err := h.svc.ListOrders(r.Context(), repository.OrderListQuery{PageRequest: pageReq, UserID: userID, Status: status})
+	if err != nil {
+		h.writeOrderError(w, r, err, "failed to list orders")
+		return
+	}
+	response.JSON(w, r, http.StatusOK, paginatedData(res.Items, res.Page, res.PageSize, res.Total, res.TotalPages))
+}
+
+func (h *OrderHandler) get(w http.ResponseWriter, r *http.Request, ownerID uint) {
+	orderID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid order id", nil)
+		return
+	}
+	order, err := h.svc.GetOrder(r.Context(), orderID, ownerID)
+	if err != nil {
+		h.writeOrderError(w, r, err, "failed to load order")
+		return
+	}
+	response.JSON(w, r, http.StatusOK, order)
+}
+
+// change applies a status change to the order in the path and audits it as
+// order.<action>. Rejected transitions are audited too.
+func (h *OrderHandler) change(w http.ResponseWriter, r *http.Request, action, reason string, apply func(context.Context, uint) (*domain.Order, error)) {
+	orderID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid order id", nil)
+		return
+	}
+	order, err := apply(r.Context(), orderID)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidOrderTransition) || errors.Is(err, service.ErrPaymentDeclined) {
+			observability.EmitAudit(r, observability.AuditInput{
+				EventName:   "order." + action,
+				ActorUserID: adminActorID(r),
+				TargetType:  "order",
+				TargetID:    strconv.FormatUint(uint64(orderID), 10),
+				Action:      action,
+				Outcome:     "rejected",
+				Reason:      err.Error(),
+			})
+		}
+		h.writeOrderError(w, r, err, "failed to "+action+" order")
+		return
+	}
+	auditOrder(r, "order."+action, action, reason, order)
+	response.JSON(w, r, http.StatusOK, order)
+}
+
+func (h *OrderHandler) writeOrderError(w http.ResponseWriter, r *http.Request, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrCartEmpty),
+		errors.Is(err, service.ErrOrderCurrencyMismatch),
+		errors.Is(err, service.ErrOrderTotalTooLarge),
+		errors.Is(err, service.ErrProductInvalidCurrency):
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+	case errors.Is(err, repository.ErrOrderNotFound):
+		response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "order not found", nil)
+	case errors.Is(err, service.ErrOrderItemUnavailable),
+		errors.Is(err, service.ErrInvalidOrderTransition),
+		errors.Is(err, service.ErrOrderPaymentNotRecorded):
+		response.Error(w, r, http.StatusConflict, "CONFLICT", err.Error(), nil)
+	case errors.Is(err, service.ErrPaymentDeclined):
+		response.Error(w, r, http.StatusPaymentRequired, "PAYMENT_DECLINED", err.Error(), nil)
+	default:
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", fallback, nil)
+	}
+}
+
+func auditOrder(r *http.Request, eventName, action, reason string, order *domain.Order) {
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   eventName,
+		ActorUserID: adminActorID(r),
+		TargetType:  "order",
+		TargetID:    strconv.FormatUint(uint64(order.ID), 10),
+		Action:      action,
+		Outcome:     "success",
+		Reason:      reason,
+	}, "status", order.Status, "total_minor", order.TotalMinor, "currency", order.Currency)
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+	servicegomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/service/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOrderHandlerEndpoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockOrderService(ctrl)
+	h := NewOrderHandler(svc)
+
+	r := chi.NewRouter()
+	r.Post("/orders", h.Checkout)
+	r.Get("/orders", h.ListMine)
+	r.Get("/orders/{id}", h.GetMine)
+	r.Post("/orders/{id}/pay", h.PayMine)
+	r.Get("/admin/orders", h.List)
+	r.Post("/admin/orders/{id}/fulfill", h.Fulfill)
+	r.Post("/admin/orders/{id}/refund", h.Refund)
+
+	t.Run("checkout accepts an empty body", func(t *testing.T) {
+		svc.EXPECT().Checkout(gomock.Any(), uint(42), service.CheckoutInput{}).
+			Return(&domain.Order{ID: 5, UserID: 42, Status: domain.OrderPending, Currency: "USD", TotalMinor: 4498}, nil)
+		req := withClaims(httptest.NewRequest(http.MethodPost, "/orders", nil), "42")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusCreated || !strings.Contains(rr.Body.String(), `"total":"44.98"`) {
+			t.Fatalf("expected 201 with total, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("checkout of an empty cart maps to 400", func(t *testing.T) {
+		svc.EXPECT().Checkout(gomock.Any(), uint(42), service.CheckoutInput{Currency: "EUR"}).Return(nil, service.ErrCartEmpty)
+		req := withClaims(httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"currency":"EUR"}`)), "42")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", rr.Code)
+		}
+	})
+
+	t.Run("customers only see their own orders", func(t *testing.T) {
+		svc.EXPECT().ListOrders(gomock.Any(), repository.OrderListQuery{PageRequest: repository.PageRequest{Page: 1, PageSize: repository.DefaultPageSize}, UserID: 42, Status: domain.OrderPaid}).
+			Return(repository.PageResult[domain.Order]{Items: []domain.Order{}, Page: 1, PageSize: repository.DefaultPageSize}, nil)
+		svc.EXPECT().GetOrder(gomock.Any(), uint(9), uint(42)).Return(nil, repository.ErrOrderNotFound)
+		req := withClaims(httptest.NewRequest(http.MethodGet, "/orders?status=paid", nil), "42")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+		}
+		req = withClaims(httptest.NewRequest(http.MethodGet, "/orders/9", nil), "42")
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected 404, got %d", rr.Code)
+		}
+	})
+
+	t.Run("status changes map service errors", func(t *testing.T) {
+		svc.EXPECT().Pay(gomock.Any(), uint(5), uint(42)).Return(nil, service.ErrPaymentDeclined)
+		svc.EXPECT().Fulfill(gomock.Any(), uint(5)).Return(nil, fmt.Errorf("%w: pending to fulfilled", service.ErrInvalidOrderTransition))
+		svc.EXPECT().Refund(gomock.Any(), uint(5)).DoAndReturn(func(_ context.Context, id uint) (*domain.Order, error) {
+			return &domain.Order{ID: id, Status: domain.OrderRefunded, Currency: "USD"}, nil
+		})
+		cases := []struct {
+			path string
+			want int
+		}{
+			{path: "/orders/5/pay", want: http.StatusPaymentRequired},
+			{path: "/admin/orders/5/fulfill", want: http.StatusConflict},
+			{path: "/admin/orders/5/refund", want: http.StatusOK},
+		}
+		for _, tc := range cases {
+			req := withClaims(httptest.NewRequest(http.MethodPost, tc.path, nil), "42")
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			if rr.Code != tc.want {
+				t.Fatalf("%s: expected %d, got %d body=%s", tc.path, tc.want, rr.Code, rr.Body.String())
+			}
+		}
+	})
+
+	t.Run("invalid filters are rejected", func(t *testing.T) {
+		for _, path := range []string{"/admin/orders?status=shipped", "/admin/orders?user_id=abc"} {
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+			if rr.Code != http.StatusBadRequest {
+				t.Fatalf("%s: expected 400, got %d", path, rr.Code)
+			}
+		}
+	})
+}
@@ -0,0 +1,305 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/http/response"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+)
+
+// OrganizationHandler serves two audiences: platform admins manage
+// organizations under /admin/orgs using global permissions, while org admins
+// manage their own members under /orgs/{org_id} using org-scoped permissions.
+type OrganizationHandler struct {
+	svc service.OrganizationService
+}
+
+func NewOrganizationHandler(svc service.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{svc: svc}
+}
+
+func (h *OrganizationHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Slug string `json:"slug"`
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
+		return
+	}
+	org, err := h.svc.CreateOrganization(r.Context(), body.Slug, body.Name)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrOrganizationInvalidSlug), errors.Is(err, service.ErrOrganizationInvalidName):
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		case isConflictError(err):
+			response.Error(w, r, http.StatusConflict, "CONFLICT", "organization slug already exists", nil)
+		default:
+			response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to create organization", nil)
+		}
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "admin.organization.create",
+		ActorUserID: adminActorID(r),
+		TargetType:  "organization",
+		TargetID:    strconv.FormatUint(uint64(org.ID), 10),
+		Action:      "create",
+		Outcome:     "success",
+		Reason:      "organization_created",
+	}, "slug", org.Slug)
+	response.JSON(w, r, http.StatusCreated, org)
+}
+
+func (h *OrganizationHandler) ListOrganizations(w http.ResponseWriter, r *http.Request) {
+	pageReq, err := parsePageRequest(r)
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
+	}
+	res, err := h.svc.ListOrganizations(r.Context(), pageReq)
+	if err != nil {
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to list organizations", nil)
+		return
+	}
+	response.JSON(w, r, http.StatusOK, paginatedData(res.Items, res.Page, res.PageSize, res.Total, res.TotalPages))
+}
+
+func (h *OrganizationHandler) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	orgID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid organization id", nil)
+		return
+	}
+	org, err := h.svc.GetOrganization(r.Context(), orgID)
+	if err != nil {
+		if errors.Is(err, repository.ErrOrganizationNotFound) {
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "organization not found", nil)
+			return
+		}
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to load organization", nil)
+		return
+	}
+	response.JSON(w, r, http.StatusOK, org)
+}
+
+func (h *OrganizationHandler) DeleteOrganization(w http.ResponseWriter, r *http.Request) {
+	orgID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid organization id", nil)
+		return
+	}
+	if err := h.svc.DeleteOrganization(r.Context(), orgID); err != nil {
+		if errors.Is(err, repository.ErrOrganizationNotFound) {
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "organization not found", nil)
+			return
+		}
+		if errors.Is(err, repository.ErrOrganizationHasData) {
+			response.Error(w, r, http.StatusConflict, "CONFLICT", err.Error(), nil)
+			return
+		}
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to delete organization", nil)
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "admin.organization.delete",
+		ActorUserID: adminActorID(r),
+		TargetType:  "organization",
+		TargetID:    strconv.FormatUint(uint64(orgID), 10),
+		Action:      "delete",
+		Outcome:     "success",
+		Reason:      "organization_deleted",
+	})
+	response.JSON(w, r, http.StatusOK, map[string]any{"id": orgID, "deleted": true})
+}
+
+// SetOrganizationMember lets a platform admin assign org-scoped roles in any
+// organization, e.g. to bootstrap its first org admin.
+func (h *OrganizationHandler) SetOrganizationMember(w http.ResponseWriter, r *http.Request) {
+	orgID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid organization id", nil)
+		return
+	}
+	h.setMemberRoles(w, r, orgID, "admin.organization.member.set", h.svc.SetOrganizationMember)
+}
+
+func (h *OrganizationHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	tenant, ok := service.TenantFromContext(r.Context())
+	if !ok {
+		response.Error(w, r, http.StatusBadRequest, "TENANT_REQUIRED", "organization must be selected", nil)
+		return
+	}
+	pageReq, err := parsePageRequest(r)
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
+	}
+	res, err := h.svc.ListMembers(r.Context(), tenant.OrganizationID, pageReq)
+	if err != nil {
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to list members", nil)
+		return
+	}
+	response.JSON(w, r, http.StatusOK, paginatedData(res.Items, res.Page, res.PageSize, res.Total, res.TotalPages))
+}
+
+func (h *OrganizationHandler) SetMemberRoles(w http.ResponseWriter, r *http.Request) {
+	tenant, ok := service.TenantFromContext(r.Context())
+	if !ok {
+		response.Error(w, r, http.StatusBadRequest, "TENANT_REQUIRED", "organization must be selected", nil)
+		return
+	}
+	h.setMemberRoles(w, r, tenant.OrganizationID, "org.member.set", h.svc.SetMemberRoles)
+}
+
+func (h *OrganizationHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	tenant, ok := service.TenantFromContext(r.Context())
+	if !ok {
+		response.Error(w, r, http.StatusBadRequest, "TENANT_REQUIRED", "organization must be selected", nil)
+		return
+	}
+	userID, err := parsePathID(chi.URLParam(r, "user_id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid user id", nil)
+		return
+	}
+	if err := h.svc.RemoveMember(r.Context(), tenant.OrganizationID, userID); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrMembershipNotFound):
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "membership not found", nil)
+		case errors.Is(err, service.ErrOrganizationLastAdmin):
+			response.Error(w, r, http.StatusConflict, "CONFLICT", err.Error(), nil)
+		default:
+			response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to remove member", nil)
+		}
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "org.member.remove",
+		ActorUserID: adminActorID(r),
+		TargetType:  "user",
+		TargetID:    strconv.FormatUint(uint64(userID), 10),
+		Action:      "remove_member",
+		Outcome:     "success",
+		Reason:      "member_removed",
+	}, "organization_id", tenant.OrganizationID)
+	response.JSON(w, r, http.StatusOK, map[string]any{"organization_id": tenant.OrganizationID, "user_id": userID, "removed": true})
+}
+
+// ListInvitations lists the organizations that invited the caller.
+func (h *OrganizationHandler) ListInvitations(w http.ResponseWriter, r *http.Request) {
+	userID, err := actorIDFromRequest(r)
+	if err != nil {
+		response.Error(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "invalid user", nil)
+		return
+	}
+	orgs, err := h.svc.ListInvitations(r.Context(), userID)
+	if err != nil {
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to list invitations", nil)
+		return
+	}
+	response.JSON(w, r, http.StatusOK, orgs)
+}
+
+func (h *OrganizationHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	h.answerInvitation(w, r, true)
+}
+
+func (h *OrganizationHandler) DeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	h.answerInvitation(w, r, false)
+}
+
+func (h *OrganizationHandler) answerInvitation(w http.ResponseWriter, r *http.Request, accept bool) {
+	userID, err := actorIDFromRequest(r)
+	if err != nil {
+		response.Error(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "invalid user", nil)
+		return
+	}
+	orgID, err := parsePathID(chi.URLParam(r, "org_id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid organization id", nil)
+		return
+	}
+	var membership *domain.Membership
+	eventName, action := "org.invitation.accept", "accept_invitation"
+	if accept {
+		membership, err = h.svc.AcceptInvitation(r.Context(), orgID, userID)
+	} else {
+		eventName, action = "org.invitation.decline", "decline_invitation"
+		err = h.svc.DeclineInvitation(r.Context(), orgID, userID)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrMembershipNotFound) {
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "invitation not found", nil)
+			return
+		}
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to answer invitation", nil)
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   eventName,
+		ActorUserID: observability.ActorUserID(userID),
+		TargetType:  "organization",
+		TargetID:    strconv.FormatUint(uint64(orgID), 10),
+		Action:      action,
+		Outcome:     "success",
+		Reason:      "invitation_answered",
+	})
+	if accept {
+		response.JSON(w, r, http.StatusOK, membership)
+		return
+	}
+	response.JSON(w, r, http.StatusOK, map[string]any{"organization_id": orgID, "declined": true})
+}
+
+func (h *OrganizationHandler) setMemberRoles(w http.ResponseWriter, r *http.Request, orgID uint, eventName string, set func(context.Context, uint, uint, []uint) (*domain.Membership, error)) {
+	userID, err := parsePathID(chi.URLParam(r, "user_id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid user id", nil)
+		return
+	}
+	var body struct {
+		RoleIDs []uint `json:"role_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
+		return
+	}
+	membership, err := set(r.Context(), orgID, userID, body.RoleIDs)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrOrganizationNotFound):
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "organization not found", nil)
+		case errors.Is(err, repository.ErrRoleNotFound):
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "one or more roles do not exist", nil)
+		case errors.Is(err, service.ErrOrganizationProtectedRole):
+			response.Error(w, r, http.StatusForbidden, "FORBIDDEN", err.Error(), nil)
+		case errors.Is(err, service.ErrOrganizationLastAdmin):
+			response.Error(w, r, http.StatusConflict, "CONFLICT", err.Error(), nil)
+		case isConflictError(err):
+			response.Error(w, r, http.StatusConflict, "CONFLICT", "membership conflict", nil)
+		default:
+			response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to set member roles", nil)
+		}
+		return
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   eventName,
+		ActorUserID: adminActorID(r),
+		TargetType:  "user",
+		TargetID:    strconv.FormatUint(uint64(userID), 10),
+		Action:      "set_member_roles",
+		Outcome:     "success",
+		Reason:      "member_roles_updated",
+	}, "organization_id", orgID, "role_ids", body.RoleIDs)
+	response.JSON(w, r, http.StatusOK, membership)
+}
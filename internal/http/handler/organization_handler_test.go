@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/http/middleware"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/security"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+	servicegomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/service/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOrganizationHandlerPlatformAndOrgAdminRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockOrganizationService(ctrl)
+	h := NewOrganizationHandler(svc)
+	jwt := security.NewJWTManager("iss", "aud", "abcdefghijklmnopqrstuvwxyz123456", "abcdefghijklmnopqrstuvwxyz654321")
+	rbac := service.NewRBACService()
+
+	r := chi.NewRouter()
+	r.Use(middleware.AuthMiddleware(jwt))
+	r.Route("/api/v1/orgs/{org_id}", func(r chi.Router) {
+		r.Use(middleware.TenantMiddleware(svc, true))
+		r.With(middleware.RequireOrgPermission(rbac, "members:write")).Put("/members/{user_id}", h.SetMemberRoles)
+		r.With(middleware.RequireOrgPermission(rbac, "members:write")).Delete("/members/{user_id}", h.RemoveMember)
+	})
+	r.Post("/api/v1/me/org-invitations/{org_id}/accept", h.AcceptInvitation)
+	r.With(middleware.RequirePermission(rbac, nil, "orgs:write")).Post("/api/v1/admin/orgs", h.CreateOrganization)
+
+	t.Run("platform admin creates organization", func(t *testing.T) {
+		svc.EXPECT().CreateOrganization(gomock.Any(), "acme", "Acme").Return(&domain.Organization{ID: 4, Slug: "acme", Name: "Acme"}, nil)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/orgs", strings.NewReader(`{"slug":"acme","name":"Acme"}`))
+		req.Header.Set("Authorization", "Bearer "+productAccessTokenForTest(t, []string{"orgs:write"}))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("invalid slug is rejected", func(t *testing.T) {
+		svc.EXPECT().CreateOrganization(gomock.Any(), "A", "Acme").Return(nil, service.ErrOrganizationInvalidSlug)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/orgs", strings.NewReader(`{"slug":"A","name":"Acme"}`))
+		req.Header.Set("Authorization", "Bearer "+productAccessTokenForTest(t, []string{"orgs:write"}))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("org admin sets member roles with org-scoped permission", func(t *testing.T) {
+		svc.EXPECT().ResolveTenant(gomock.Any(), "acme", uint(42)).Return(&service.Tenant{OrganizationID: 4, Slug: "acme", Permissions: []string{"members:write"}}, nil)
+		svc.EXPECT().SetMemberRoles(gomock.Any(), uint(4), uint(9), []uint{3}).Return(&domain.Membership{OrganizationID: 4, UserID: 9}, nil)
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/orgs/acme/members/9", strings.NewReader(`{"role_ids":[3]}`))
+		req.Header.Set("Authorization", "Bearer "+productAccessTokenForTest(t, nil))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("org admin cannot grant protected roles", func(t *testing.T) {
+		svc.EXPECT().ResolveTenant(gomock.Any(), "acme", uint(42)).Return(&service.Tenant{OrganizationID: 4, Slug: "acme", Permissions: []string{"members:write"}}, nil)
+		svc.EXPECT().SetMemberRoles(gomock.Any(), uint(4), uint(9), []uint{1}).Return(nil, service.ErrOrganizationProtectedRole)
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/orgs/acme/members/9", strings.NewReader(`{"role_ids":[1]}`))
+		req.Header.Set("Authorization", "Bearer "+productAccessTokenForTest(t, nil))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("last org admin cannot be removed", func(t *testing.T) {
+		svc.EXPECT().ResolveTenant(gomock.Any(), "acme", uint(42)).Return(&service.Tenant{OrganizationID: 4, Slug: "acme", Permissions: []string{"members:write"}}, nil)
+		svc.EXPECT().RemoveMember(gomock.Any(), uint(4), uint(42)).Return(service.ErrOrganizationLastAdmin)
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/orgs/acme/members/42", nil)
+		req.Header.Set("Authorization", "Bearer "+productAccessTokenForTest(t, nil))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusConflict {
+			t.Fatalf("expected 409, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("invited user accepts the invitation", func(t *testing.T) {
+		svc.EXPECT().AcceptInvitation(gomock.Any(), uint(4), uint(42)).Return(&domain.Membership{OrganizationID: 4, UserID: 42, Status: domain.MembershipActive}, nil)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/me/org-invitations/4/accept", nil)
+		req.Header.Set("Authorization", "Bearer "+productAccessTokenForTest(t, nil))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"status":"active"`) {
+			t.Fatalf("expected accepted membership, got %d body=%s", rr.Code, rr.Body.String())
+		}
+
+		svc.EXPECT().AcceptInvitation(gomock.Any(), uint(5), uint(42)).Return(nil, repository.ErrMembershipNotFound)
+		req = httptest.NewRequest(http.MethodPost, "/api/v1/me/org-invitations/5/accept", nil)
+		req.Header.Set("Authorization", "Bearer "+productAccessTokenForTest(t, nil))
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected 404 without an invitation, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("member without org permission is forbidden", func(t *testing.T) {
+		svc.EXPECT().ResolveTenant(gomock.Any(), "acme", uint(42)).Return(&service.Tenant{OrganizationID: 4, Slug: "acme", Permissions: []string{"products:read"}}, nil)
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/orgs/acme/members/9", strings.NewReader(`{"role_ids":[3]}`))
+		req.Header.Set("Authorization", "Bearer "+productAccessTokenForTest(t, []string{"members:write"}))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+}
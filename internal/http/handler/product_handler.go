@@ -3,31 +3,57 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/config"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/http/response"
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/security"
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
 )
 
+const (
+	maxProductSearchLength = 100
+	// maxProductImageForm bounds the multipart form kept in memory; larger
+	// parts spill to temporary files.
+	maxProductImageForm = 10 << 20
+)
+
+var (
+	errProductIfMatchRequired = errors.New("If-Match header is required")
+	errProductIfMatchStale    = errors.New("product has been modified; fetch it again and retry")
+	errProductIfMatchInvalid  = errors.New("If-Match must be a single entity tag or *")
+)
+
 type ProductHandler struct {
-	svc service.ProductService
+	svc     service.ProductService
+	cursors *security.CursorSigner
 }
 
-func NewProductHandler(svc service.ProductService) *ProductHandler {
-	return &ProductHandler{svc: svc}
+func NewProductHandler(svc service.ProductService, cfg *config.Config) *ProductHandler {
+	return &ProductHandler{svc: svc, cursors: security.NewCursorSigner(cfg.PaginationCursorSecret)}
 }
 
 func (h *ProductHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var body struct {
-		Name        string  `json:"name"`
-		Description string  `json:"description"`
-		Price       float64 `json:"price"`
+		SKU         string             `json:"sku"`
+		Name        string             `json:"name"`
+		Description string             `json:"description"`
+		Price       json.Number        `json:"price"`
+		PriceMinor  *int64             `json:"price_minor"`
+		Currency    string             `json:"currency"`
+		Prices      []productPriceBody `json:"prices"`
+		CategoryID  *uint              `json:"category_id"`
+		Tags        []string           `json:"tags"`
+		Attributes  map[string]any     `json:"attributes"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
@@ -35,17 +61,30 @@ func (h *ProductHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	created, err := h.svc.Create(r.Context(), service.CreateProductInput{
+		SKU:         body.SKU,
 		Name:        body.Name,
 		Description: body.Description,
-		Price:       body.Price,
+		Price:       service.PriceInput{Currency: body.Currency, Amount: body.Price.String(), AmountMinor: body.PriceMinor},
+		Prices:      priceInputs(body.Prices),
+		CategoryID:  body.CategoryID,
+		Tags:        body.Tags,
+		Attributes:  body.Attributes,
 	})
 	if err != nil {
 		switch {
 		case errors.Is(err, service.ErrProductInvalidName),
 			errors.Is(err, service.ErrProductInvalidDescription),
-			errors.Is(err, service.ErrProductInvalidPrice):
+			errors.Is(err, service.ErrProductInvalidPrice),
+			errors.Is(err, service.ErrProductInvalidCurrency),
+			errors.Is(err, service.ErrProductInvalidCategory),
+			errors.Is(err, service.ErrProductInvalidTags),
+			errors.Is(err, service.ErrProductInvalidAttributes),
+			errors.Is(err, service.ErrProductInvalidSKU):
 			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
 			return
+		case errors.Is(err, service.ErrProductSKUTaken):
+			response.Error(w, r, http.StatusConflict, "CONFLICT", err.Error(), nil)
+			return
 		case isConflictError(err):
 			response.Error(w, r, http.StatusConflict, "CONFLICT", "product already exists", nil)
 			return
@@ -64,6 +103,7 @@ func (h *ProductHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Outcome:     "success",
 		Reason:      "product_created",
 	}, "name", created.Name)
+	w.Header().Set("ETag", productETag(created))
 	response.JSON(w, r, http.StatusCreated, created)
 }
 
@@ -74,7 +114,38 @@ func (h *ProductHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	res, err := h.svc.ListPaged(r.Context(), pageReq)
+	query, err := parseProductListQuery(r)
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
+	}
+	query.PageRequest = pageReq
+
+	cursorReq, scope, cursorMode, err := parseCursorRequest(r, h.cursors, "products", pageReq.PageSize)
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
+	}
+	if cursorMode {
+		page, err := h.svc.ListByCursor(r.Context(), query, cursorReq)
+		if err != nil {
+			if isCursorRequestError(err) {
+				response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+				return
+			}
+			response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to list products", nil)
+			return
+		}
+		payload, err := cursorPaginatedData(h.cursors, scope, page)
+		if err != nil {
+			response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to encode response", nil)
+			return
+		}
+		response.JSON(w, r, http.StatusOK, payload)
+		return
+	}
+
+	res, err := h.svc.ListPaged(r.Context(), query)
 	if err != nil {
 		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to list products", nil)
 		return
@@ -82,6 +153,109 @@ func (h *ProductHandler) List(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, r, http.StatusOK, paginatedData(res.Items, res.Page, res.PageSize, res.Total, res.TotalPages))
 }
 
+// parseProductListQuery reads the search, currency and price range,
+// created_at range, category, tag and sort parameters of the product list.
+// Sort fields are allow-listed so they can be used in ORDER BY as-is.
+func parseProductListQuery(r *http.Request) (repository.ProductListQuery, error) {
+	sortBy, sortOrder, err := parseSortParams(r, "created_at", map[string]struct{}{
+		"name":       {},
+		"price":      {},
+		"created_at": {},
+	})
+	if err != nil {
+		return repository.ProductListQuery{}, err
+	}
+	query := repository.ProductListQuery{
+		SortBy:    sortBy,
+		SortOrder: sortOrder,
+		Search:    strings.TrimSpace(r.URL.Query().Get("q")),
+	}
+	if len(query.Search) > maxProductSearchLength {
+		return repository.ProductListQuery{}, fmt.Errorf("q must be at most %d characters", maxProductSearchLength)
+	}
+	if raw := strings.TrimSpace(r.URL.Query().Get("currency")); raw != "" {
+		if query.Currency, err = service.NormalizeCurrency(raw); err != nil {
+			return repository.ProductListQuery{}, err
+		}
+	}
+	if query.SortBy == "price" && query.Currency == "" {
+		return repository.ProductListQuery{}, errors.New("sort_by=price requires currency")
+	}
+	if query.MinPriceMinor, err = parsePriceParam(r, "min_price", query.Currency); err != nil {
+		return repository.ProductListQuery{}, err
+	}
+	if query.MaxPriceMinor, err = parsePriceParam(r, "max_price", query.Currency); err != nil {
+		return repository.ProductListQuery{}, err
+	}
+	if query.MinPriceMinor != nil && query.MaxPriceMinor != nil && *query.MinPriceMinor > *query.MaxPriceMinor {
+		return repository.ProductListQuery{}, errors.New("min_price must not exceed max_price")
+	}
+	if query.CreatedAfter, err = parseTimeParam(r, "created_after"); err != nil {
+		return repository.ProductListQuery{}, err
+	}
+	if query.CreatedBefore, err = parseTimeParam(r, "created_before"); err != nil {
+		return repository.ProductListQuery{}, err
+	}
+	if query.CreatedAfter != nil && query.CreatedBefore != nil && !query.CreatedAfter.Before(*query.CreatedBefore) {
+		return repository.ProductListQuery{}, errors.New("created_after must be before created_before")
+	}
+	if raw := strings.TrimSpace(r.URL.Query().Get("category_id")); raw != "" {
+		if query.CategoryID, err = parsePathID(raw); err != nil || query.CategoryID == 0 {
+			return repository.ProductListQuery{}, errors.New("category_id must be a positive integer")
+		}
+	}
+	query.Tag = strings.ToLower(strings.TrimSpace(r.URL.Query().Get("tag")))
+	if len(query.Tag) > 50 {
+		return repository.ProductListQuery{}, errors.New("tag must be at most 50 characters")
+	}
+	return query, nil
+}
+
+// parsePriceParam reads a decimal price bound and converts it to the minor
+// units of currency, which must be set.
+func parsePriceParam(r *http.Request, name, currency string) (*int64, error) {
+	raw := strings.TrimSpace(r.URL.Query().Get(name))
+	if raw == "" {
+		return nil, nil
+	}
+	if currency == "" {
+		return nil, fmt.Errorf("%s requires currency", name)
+	}
+	v, err := service.ParseMinorUnits(raw, currency)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a non-negative amount in %s: %w", name, currency, err)
+	}
+	return &v, nil
+}
+
+// productPriceBody is a price in a request body. The amount may be a JSON
+// number or a decimal string; amount_minor gives it in minor units instead.
+type productPriceBody struct {
+	Currency    string      `json:"currency"`
+	Amount      json.Number `json:"amount"`
+	AmountMinor *int64      `json:"amount_minor"`
+}
+
+func priceInputs(bodies []productPriceBody) []service.PriceInput {
+	inputs := make([]service.PriceInput, 0, len(bodies))
+	for _, b := range bodies {
+		inputs = append(inputs, service.PriceInput{Currency: b.Currency, Amount: b.Amount.String(), AmountMinor: b.AmountMinor})
+	}
+	return inputs
+}
+
+func parseTimeParam(r *http.Request, name string) (*time.Time, error) {
+	raw := strings.TrimSpace(r.URL.Query().Get(name))
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC3339 timestamp", name)
+	}
+	return &v, nil
+}
+
 func (h *ProductHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	productID, err := parsePathID(chi.URLParam(r, "id"))
 	if err != nil {
@@ -98,6 +272,13 @@ func (h *ProductHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to load product", nil)
 		return
 	}
+	etag := productETag(product)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if matchesIfNoneMatch(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
 	response.JSON(w, r, http.StatusOK, product)
 }
 
@@ -107,32 +288,75 @@ func (h *ProductHandler) Update(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid product id", nil)
 		return
 	}
+	version, err := h.ifMatchVersion(r, productID)
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
 	var body struct {
-		Name        *string  `json:"name"`
-		Description *string  `json:"description"`
-		Price       *float64 `json:"price"`
+		SKU         *string             `json:"sku"`
+		Name        *string             `json:"name"`
+		Description *string             `json:"description"`
+		Price       json.Number         `json:"price"`
+		PriceMinor  *int64              `json:"price_minor"`
+		Currency    *string             `json:"currency"`
+		Prices      *[]productPriceBody `json:"prices"`
+		CategoryID  *uint               `json:"category_id"`
+		Tags        *[]string           `json:"tags"`
+		Attributes  *map[string]any     `json:"attributes"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
 		return
 	}
+	var price *service.PriceInput
+	if body.Price != "" || body.PriceMinor != nil || body.Currency != nil {
+		price = &service.PriceInput{Amount: body.Price.String(), AmountMinor: body.PriceMinor}
+		if body.Currency != nil {
+			price.Currency = *body.Currency
+		}
+	}
+	var prices *[]service.PriceInput
+	if body.Prices != nil {
+		inputs := priceInputs(*body.Prices)
+		prices = &inputs
+	}
 
-	updated, err := h.svc.Update(r.Context(), productID, service.UpdateProductInput{
+	updated, err := h.svc.Update(r.Context(), productID, version, service.UpdateProductInput{
+		SKU:         body.SKU,
 		Name:        body.Name,
 		Description: body.Description,
-		Price:       body.Price,
+		Price:       price,
+		Prices:      prices,
+		CategoryID:  body.CategoryID,
+		Tags:        body.Tags,
+		Attributes:  body.Attributes,
 	})
 	if err != nil {
 		switch {
 		case errors.Is(err, repository.ErrProductNotFound):
 			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "product not found", nil)
 			return
+		case errors.Is(err, repository.ErrProductVersionConflict):
+			writeIfMatchError(w, r, errProductIfMatchStale)
+			return
+		case errors.Is(err, service.ErrProductForbidden):
+			response.Error(w, r, http.StatusForbidden, "FORBIDDEN", err.Error(), nil)
+			return
 		case errors.Is(err, service.ErrProductInvalidName),
 			errors.Is(err, service.ErrProductInvalidDescription),
 			errors.Is(err, service.ErrProductInvalidPrice),
+			errors.Is(err, service.ErrProductInvalidCurrency),
+			errors.Is(err, service.ErrProductInvalidCategory),
+			errors.Is(err, service.ErrProductInvalidTags),
+			errors.Is(err, service.ErrProductInvalidAttributes),
+			errors.Is(err, service.ErrProductInvalidSKU),
 			errors.Is(err, service.ErrProductNoUpdates):
 			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
 			return
+		case errors.Is(err, service.ErrProductSKUTaken), isConflictError(err):
+			response.Error(w, r, http.StatusConflict, "CONFLICT", service.ErrProductSKUTaken.Error(), nil)
+			return
 		default:
 			response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to update product", nil)
 			return
@@ -148,6 +372,7 @@ func (h *ProductHandler) Update(w http.ResponseWriter, r *http.Request) {
 		Outcome:     "success",
 		Reason:      "product_updated",
 	}, "name", strings.TrimSpace(updated.Name))
+	w.Header().Set("ETag", productETag(updated))
 	response.JSON(w, r, http.StatusOK, updated)
 }
 
@@ -158,11 +383,25 @@ func (h *ProductHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.svc.DeleteByID(r.Context(), productID); err != nil {
+	version, err := h.ifMatchVersion(r, productID)
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
+
+	if err := h.svc.DeleteByID(r.Context(), productID, version); err != nil {
 		if errors.Is(err, repository.ErrProductNotFound) {
 			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "product not found", nil)
 			return
 		}
+		if errors.Is(err, repository.ErrProductVersionConflict) {
+			writeIfMatchError(w, r, errProductIfMatchStale)
+			return
+		}
+		if errors.Is(err, service.ErrProductForbidden) {
+			response.Error(w, r, http.StatusForbidden, "FORBIDDEN", err.Error(), nil)
+			return
+		}
 		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to delete product", nil)
 		return
 	}
@@ -178,3 +417,389 @@ func (h *ProductHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	})
 	response.JSON(w, r, http.StatusOK, map[string]any{"deleted": true})
 }
+
+// ListTrash lists soft-deleted products, most recently deleted first.
+func (h *ProductHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	pageReq, err := parsePageRequest(r)
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
+	}
+	res, err := h.svc.ListDeleted(r.Context(), pageReq)
+	if err != nil {
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to list deleted products", nil)
+		return
+	}
+	response.JSON(w, r, http.StatusOK, paginatedData(res.Items, res.Page, res.PageSize, res.Total, res.TotalPages))
+}
+
+func (h *ProductHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	productID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid product id", nil)
+		return
+	}
+	product, err := h.svc.Restore(r.Context(), productID)
+	if err != nil {
+		if errors.Is(err, repository.ErrProductNotFound) {
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "product not found in trash", nil)
+			return
+		}
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to restore product", nil)
+		return
+	}
+
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "product.restore",
+		ActorUserID: adminActorID(r),
+		TargetType:  "product",
+		TargetID:    strconv.FormatUint(uint64(productID), 10),
+		Action:      "restore",
+		Outcome:     "success",
+		Reason:      "product_restored",
+	})
+	w.Header().Set("ETag", productETag(product))
+	response.JSON(w, r, http.StatusOK, product)
+}
+
+// Purge permanently removes a product from the trash. Live products must be
+// deleted first.
+func (h *ProductHandler) Purge(w http.ResponseWriter, r *http.Request) {
+	productID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid product id", nil)
+		return
+	}
+	if err := h.svc.Purge(r.Context(), productID); err != nil {
+		if errors.Is(err, repository.ErrProductNotFound) {
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "product not found in trash", nil)
+			return
+		}
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to purge product", nil)
+		return
+	}
+
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "product.purge",
+		ActorUserID: adminActorID(r),
+		TargetType:  "product",
+		TargetID:    strconv.FormatUint(uint64(productID), 10),
+		Action:      "purge",
+		Outcome:     "success",
+		Reason:      "product_purged",
+	})
+	response.JSON(w, r, http.StatusOK, map[string]any{"purged": true})
+}
+
+// productETag is the strong entity tag of a product. It is derived from the
+// ID and version, which every update increments.
+func productETag(product *domain.Product) string {
+	return buildStrongETag(fmt.Appendf(nil, "product:%d:%d", product.ID, product.Version))
+}
+
+// ifMatchVersion returns the product version a write is conditional on: the
+// current version when If-Match carries its ETag. "*" accepts any version of
+// an existing product and yields 0. Weak or stale tags fail the precondition.
+func (h *ProductHandler) ifMatchVersion(r *http.Request, productID uint) (uint, error) {
+	raw := strings.TrimSpace(r.Header.Get("If-Match"))
+	switch {
+	case raw == "":
+		return 0, errProductIfMatchRequired
+	case raw == "*":
+		return 0, nil
+	case strings.Contains(raw, ","):
+		return 0, errProductIfMatchInvalid
+	}
+	current, err := h.svc.GetByID(r.Context(), productID)
+	if err != nil {
+		return 0, err
+	}
+	if raw != productETag(current) {
+		return 0, errProductIfMatchStale
+	}
+	return current.Version, nil
+}
+
+func writeIfMatchError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repository.ErrProductNotFound):
+		response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "product not found", nil)
+	case errors.Is(err, errProductIfMatchRequired):
+		response.Error(w, r, http.StatusPreconditionRequired, "PRECONDITION_REQUIRED", err.Error(), nil)
+	case errors.Is(err, errProductIfMatchInvalid):
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+	case errors.Is(err, errProductIfMatchStale):
+		response.Error(w, r, http.StatusPreconditionFailed, "PRECONDITION_FAILED", err.Error(), nil)
+	default:
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to load product", nil)
+	}
+}
+
+func (h *ProductHandler) ListGrants(w http.ResponseWriter, r *http.Request) {
+	productID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid product id", nil)
+		return
+	}
+
+	grants, err := h.svc.ListGrants(r.Context(), productID)
+	if err != nil {
+		if errors.Is(err, repository.ErrProductNotFound) {
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "product not found", nil)
+			return
+		}
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to list product grants", nil)
+		return
+	}
+	response.JSON(w, r, http.StatusOK, grants)
+}
+
+func (h *ProductHandler) Share(w http.ResponseWriter, r *http.Request) {
+	productID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid product id", nil)
+		return
+	}
+	var body struct {
+		UserID  *uint  `json:"user_id"`
+		GroupID *uint  `json:"group_id"`
+		Access  string `json:"access"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
+		return
+	}
+
+	grant, err := h.svc.ShareProduct(r.Context(), productID, service.ShareProductInput{
+		UserID:  body.UserID,
+		GroupID: body.GroupID,
+		Access:  body.Access,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrProductNotFound):
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "product not found", nil)
+		case errors.Is(err, service.ErrProductForbidden):
+			response.Error(w, r, http.StatusForbidden, "FORBIDDEN", err.Error(), nil)
+		case errors.Is(err, service.ErrProductInvalidGrant):
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		default:
+			response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to share product", nil)
+		}
+		return
+	}
+
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "product.share.create",
+		ActorUserID: adminActorID(r),
+		TargetType:  "product",
+		TargetID:    strconv.FormatUint(uint64(productID), 10),
+		Action:      "share",
+		Outcome:     "success",
+		Reason:      "product_shared",
+	}, "grant_id", grant.ID, "access", grant.Access)
+	response.JSON(w, r, http.StatusOK, grant)
+}
+
+func (h *ProductHandler) RevokeShare(w http.ResponseWriter, r *http.Request) {
+	productID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid product id", nil)
+		return
+	}
+	grantID, err := parsePathID(chi.URLParam(r, "grant_id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid grant id", nil)
+		return
+	}
+
+	if err := h.svc.RevokeShare(r.Context(), productID, grantID); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrProductNotFound):
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "product not found", nil)
+		case errors.Is(err, repository.ErrProductGrantNotFound):
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "grant not found", nil)
+		case errors.Is(err, service.ErrProductForbidden):
+			response.Error(w, r, http.StatusForbidden, "FORBIDDEN", err.Error(), nil)
+		default:
+			response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to revoke product share", nil)
+		}
+		return
+	}
+
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "product.share.delete",
+		ActorUserID: adminActorID(r),
+		TargetType:  "product",
+		TargetID:    strconv.FormatUint(uint64(productID), 10),
+		Action:      "unshare",
+		Outcome:     "success",
+		Reason:      "product_share_revoked",
+	}, "grant_id", grantID)
+	response.JSON(w, r, http.StatusOK, map[string]any{"deleted": true})
+}
+
+// UploadImage adds the multipart "image" file to the product's gallery.
+func (h *ProductHandler) UploadImage(w http.ResponseWriter, r *http.Request) {
+	productID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid product id", nil)
+		return
+	}
+	if err := r.ParseMultipartForm(maxProductImageForm); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			response.Error(w, r, http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", "request body too large", nil)
+			return
+		}
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "failed to parse multipart form", nil)
+		return
+	}
+	defer func() { _ = r.MultipartForm.RemoveAll() }()
+	file, header, err := r.FormFile("image")
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "image file is required", nil)
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	image, err := h.svc.UploadImage(r.Context(), productID, file, header.Size)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrFileTooBig):
+			response.Error(w, r, http.StatusBadRequest, "FILE_TOO_LARGE", "file size exceeds 5MB limit", nil)
+		case errors.Is(err, service.ErrInvalidFileType):
+			response.Error(w, r, http.StatusBadRequest, "INVALID_FILE_TYPE", "only JPEG and PNG images are allowed", nil)
+		case errors.Is(err, repository.ErrProductNotFound):
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "product not found", nil)
+		case errors.Is(err, service.ErrProductForbidden):
+			response.Error(w, r, http.StatusForbidden, "FORBIDDEN", err.Error(), nil)
+		case errors.Is(err, repository.ErrProductImageLimit):
+			response.Error(w, r, http.StatusConflict, "CONFLICT", "product gallery is full", nil)
+		default:
+			response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to upload product image", nil)
+		}
+		return
+	}
+
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "product.image.upload",
+		ActorUserID: adminActorID(r),
+		TargetType:  "product",
+		TargetID:    strconv.FormatUint(uint64(productID), 10),
+		Action:      "upload_image",
+		Outcome:     "success",
+		Reason:      "product_image_uploaded",
+	}, "image_id", image.ID, "file_size", image.Size, "content_type", image.ContentType)
+	response.JSON(w, r, http.StatusCreated, image)
+}
+
+// ReorderImages sets the gallery order from a list of every image ID.
+func (h *ProductHandler) ReorderImages(w http.ResponseWriter, r *http.Request) {
+	productID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid product id", nil)
+		return
+	}
+	var body struct {
+		ImageIDs []uint `json:"image_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid payload", nil)
+		return
+	}
+
+	images, err := h.svc.ReorderImages(r.Context(), productID, body.ImageIDs)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrProductNotFound):
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "product not found", nil)
+		case errors.Is(err, service.ErrProductForbidden):
+			response.Error(w, r, http.StatusForbidden, "FORBIDDEN", err.Error(), nil)
+		case errors.Is(err, repository.ErrProductImageOrder):
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		default:
+			response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to reorder product images", nil)
+		}
+		return
+	}
+
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "product.image.reorder",
+		ActorUserID: adminActorID(r),
+		TargetType:  "product",
+		TargetID:    strconv.FormatUint(uint64(productID), 10),
+		Action:      "reorder_images",
+		Outcome:     "success",
+		Reason:      "product_images_reordered",
+	}, "image_ids", body.ImageIDs)
+	response.JSON(w, r, http.StatusOK, images)
+}
+
+func (h *ProductHandler) DeleteImage(w http.ResponseWriter, r *http.Request) {
+	productID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid product id", nil)
+		return
+	}
+	imageID, err := parsePathID(chi.URLParam(r, "image_id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid image id", nil)
+		return
+	}
+
+	if err := h.svc.DeleteImage(r.Context(), productID, imageID); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrProductNotFound):
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "product not found", nil)
+		case errors.Is(err, repository.ErrProductImageNotFound):
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "image not found", nil)
+		case errors.Is(err, service.ErrProductForbidden):
+			response.Error(w, r, http.StatusForbidden, "FORBIDDEN", err.Error(), nil)
+		default:
+			response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to delete product image", nil)
+		}
+		return
+	}
+
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "product.image.delete",
+		ActorUserID: adminActorID(r),
+		TargetType:  "product",
+		TargetID:    strconv.FormatUint(uint64(productID), 10),
+		Action:      "delete_image",
+		Outcome:     "success",
+		Reason:      "product_image_deleted",
+	}, "image_id", imageID)
+	response.JSON(w, r, http.StatusOK, map[string]any{"deleted": true})
+}
+
+// GetImage redirects to a short-lived presigned link to the image's file.
+// Product bodies link here, so their ETag does not depend on link expiry.
+func (h *ProductHandler) GetImage(w http.ResponseWriter, r *http.Request) {
+	productID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid product id", nil)
+		return
+	}
+	imageID, err := parsePathID(chi.URLParam(r, "image_id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid image id", nil)
+		return
+	}
+
+	url, err := h.svc.ImageURL(r.Context(), productID, imageID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrProductNotFound):
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "product not found", nil)
+		case errors.Is(err, repository.ErrProductImageNotFound):
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "image not found", nil)
+		default:
+			response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to link product image", nil)
+		}
+		return
+	}
+	w.Header().Set("Cache-Control", "private, no-store")
+	http.Redirect(w, r, url, http.StatusFound)
+}
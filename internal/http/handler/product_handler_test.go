@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -11,6 +15,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/config"
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/http/middleware"
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
@@ -18,6 +23,7 @@ import (
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
 	servicegomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/service/gomock"
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 )
 
 func productAccessTokenForTest(t *testing.T, perms []string) string {
@@ -33,7 +39,7 @@ func productAccessTokenForTest(t *testing.T, perms []string) string {
 func TestProductHandlerPaginationAndRBAC(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	svc := servicegomock.NewMockProductService(ctrl)
-	h := NewProductHandler(svc)
+	h := NewProductHandler(svc, &config.Config{})
 	jwt := security.NewJWTManager("iss", "aud", "abcdefghijklmnopqrstuvwxyz123456", "abcdefghijklmnopqrstuvwxyz654321")
 	rbac := service.NewRBACService()
 
@@ -55,11 +61,14 @@ func TestProductHandlerPaginationAndRBAC(t *testing.T) {
 	})
 
 	t.Run("list uses pagination defaults", func(t *testing.T) {
-		svc.EXPECT().ListPaged(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req repository.PageRequest) (repository.PageResult[domain.Product], error) {
+		svc.EXPECT().ListPaged(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req repository.ProductListQuery) (repository.PageResult[domain.Product], error) {
 			if req.Page != repository.DefaultPage || req.PageSize != repository.DefaultPageSize {
 				t.Fatalf("expected default pagination page=%d size=%d, got %+v", repository.DefaultPage, repository.DefaultPageSize, req)
 			}
-			return repository.PageResult[domain.Product]{Items: []domain.Product{{ID: 1, Name: "P", Price: 1.2}}, Page: req.Page, PageSize: req.PageSize, Total: 1, TotalPages: 1}, nil
+			if req.SortBy != "created_at" || req.SortOrder != "desc" || req.Search != "" || req.MinPriceMinor != nil || req.Currency != "" || req.CreatedAfter != nil {
+				t.Fatalf("expected newest first without filters, got %+v", req)
+			}
+			return repository.PageResult[domain.Product]{Items: []domain.Product{{ID: 1, Name: "P", PriceMinor: 120, Currency: "USD"}}, Page: req.Page, PageSize: req.PageSize, Total: 1, TotalPages: 1}, nil
 		})
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
 		req.Header.Set("Authorization", "Bearer "+productAccessTokenForTest(t, []string{"products:read"}))
@@ -68,10 +77,71 @@ func TestProductHandlerPaginationAndRBAC(t *testing.T) {
 		if rr.Code != http.StatusOK {
 			t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
 		}
-		var env map[string]any
+		var env struct {
+			Data struct {
+				Items []map[string]any `json:"items"`
+			} `json:"data"`
+		}
 		if err := json.Unmarshal(rr.Body.Bytes(), &env); err != nil {
 			t.Fatalf("unmarshal response: %v", err)
 		}
+		if len(env.Data.Items) != 1 || env.Data.Items[0]["price"] != "1.20" || env.Data.Items[0]["price_minor"] != 120.0 || env.Data.Items[0]["currency"] != "USD" {
+			t.Fatalf("expected decimal and minor-unit prices, got %s", rr.Body.String())
+		}
+	})
+
+	t.Run("list passes filters and sort", func(t *testing.T) {
+		svc.EXPECT().ListPaged(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req repository.ProductListQuery) (repository.PageResult[domain.Product], error) {
+			if req.Search != "blue mug" || req.SortBy != "price" || req.SortOrder != "asc" {
+				t.Fatalf("unexpected search or sort %+v", req)
+			}
+			if req.Currency != "EUR" || req.MinPriceMinor == nil || *req.MinPriceMinor != 500 || req.MaxPriceMinor == nil || *req.MaxPriceMinor != 2050 {
+				t.Fatalf("unexpected price range %+v", req)
+			}
+			if req.CreatedAfter == nil || !req.CreatedAfter.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) || req.CreatedBefore != nil {
+				t.Fatalf("unexpected created range %+v", req)
+			}
+			if req.CategoryID != 3 || req.Tag != "sale" {
+				t.Fatalf("unexpected category or tag %+v", req)
+			}
+			return repository.PageResult[domain.Product]{Items: []domain.Product{}, Page: req.Page, PageSize: req.PageSize}, nil
+		})
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products?q=blue+mug&currency=eur&min_price=5&max_price=20.5&created_after=2026-01-01T00:00:00Z&sort_by=price&sort_order=asc&category_id=3&tag=Sale", nil)
+		req.Header.Set("Authorization", "Bearer "+productAccessTokenForTest(t, []string{"products:read"}))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("list rejects invalid filters", func(t *testing.T) {
+		for _, query := range []string{
+			"sort_by=description",
+			"sort_order=up",
+			"sort_by=price",
+			"min_price=10",
+			"currency=XYZ",
+			"currency=USD&min_price=-1",
+			"currency=USD&max_price=abc",
+			"currency=USD&min_price=1.005",
+			"currency=JPY&max_price=10.5",
+			"currency=USD&min_price=10&max_price=5",
+			"created_before=yesterday",
+			"created_after=2026-02-01T00:00:00Z&created_before=2026-01-01T00:00:00Z",
+			"q=" + strings.Repeat("a", maxProductSearchLength+1),
+			"category_id=0",
+			"category_id=shoes",
+			"tag=" + strings.Repeat("t", 51),
+		} {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/products?"+query, nil)
+			req.Header.Set("Authorization", "Bearer "+productAccessTokenForTest(t, []string{"products:read"}))
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			if rr.Code != http.StatusBadRequest {
+				t.Fatalf("%s: expected 400, got %d body=%s", query, rr.Code, rr.Body.String())
+			}
+		}
 	})
 
 	t.Run("write denied without products:write", func(t *testing.T) {
@@ -97,9 +167,12 @@ func TestProductHandlerPaginationAndRBAC(t *testing.T) {
 
 	t.Run("write allowed with products:write", func(t *testing.T) {
 		svc.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, input service.CreateProductInput) (*domain.Product, error) {
-			return &domain.Product{ID: 9, Name: input.Name, Description: input.Description, Price: input.Price}, nil
+			if input.Price.Amount != "10.50" || input.Price.Currency != "EUR" || len(input.Prices) != 1 || input.Prices[0].AmountMinor == nil || *input.Prices[0].AmountMinor != 1200 {
+				t.Fatalf("unexpected price input %+v", input)
+			}
+			return &domain.Product{ID: 9, Name: input.Name, Description: input.Description, PriceMinor: 1050, Currency: "EUR"}, nil
 		})
-		req := httptest.NewRequest(http.MethodPost, "/api/v1/products", strings.NewReader(`{"name":"Demo Product","price":10}`))
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/products", strings.NewReader(`{"name":"Demo Product","price":"10.50","currency":"EUR","prices":[{"currency":"USD","amount_minor":1200}]}`))
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("Authorization", "Bearer "+productAccessTokenForTest(t, []string{"products:write"}))
 		rr := httptest.NewRecorder()
@@ -109,8 +182,25 @@ func TestProductHandlerPaginationAndRBAC(t *testing.T) {
 		}
 	})
 
+	t.Run("create passes category, tags and attributes", func(t *testing.T) {
+		svc.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, input service.CreateProductInput) (*domain.Product, error) {
+			if input.CategoryID == nil || *input.CategoryID != 3 || len(input.Tags) != 1 || input.Attributes["ram_gb"] != 16.0 {
+				t.Fatalf("unexpected input %+v", input)
+			}
+			return nil, fmt.Errorf("%w: unknown attribute %q", service.ErrProductInvalidAttributes, "ram_gb")
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/products", strings.NewReader(`{"name":"Laptop","price":10,"category_id":3,"tags":["sale"],"attributes":{"ram_gb":16}}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+productAccessTokenForTest(t, []string{"products:write"}))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), "unknown attribute") {
+			t.Fatalf("expected 400 with reason, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
 	t.Run("delete rejects malformed product id", func(t *testing.T) {
-		svc.EXPECT().DeleteByID(gomock.Any(), gomock.Any()).Times(0)
+		svc.EXPECT().DeleteByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 		req := httptest.NewRequest(http.MethodDelete, "/api/v1/products/12abc", nil)
 		req.Header.Set("Authorization", "Bearer "+productAccessTokenForTest(t, []string{"products:delete"}))
 		rr := httptest.NewRecorder()
@@ -120,3 +210,379 @@ func TestProductHandlerPaginationAndRBAC(t *testing.T) {
 		}
 	})
 }
+
+func TestProductHandlerConditionalRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockProductService(ctrl)
+	h := NewProductHandler(svc, &config.Config{})
+	r := chi.NewRouter()
+	r.Get("/products/{id}", h.GetByID)
+	r.Put("/products/{id}", h.Update)
+	r.Delete("/products/{id}", h.Delete)
+	serve := func(method, path, ifMatch, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	current := &domain.Product{ID: 5, Name: "Mug", Version: 3}
+	etag := productETag(current)
+
+	t.Run("get returns a strong etag and honours If-None-Match", func(t *testing.T) {
+		svc.EXPECT().GetByID(gomock.Any(), uint(5)).Return(current, nil).Times(2)
+		rr := serve(http.MethodGet, "/products/5", "", "")
+		if rr.Code != http.StatusOK || rr.Header().Get("ETag") != etag || !strings.HasPrefix(etag, `"`) {
+			t.Fatalf("expected 200 with ETag %s, got %d etag=%q", etag, rr.Code, rr.Header().Get("ETag"))
+		}
+		req := httptest.NewRequest(http.MethodGet, "/products/5", nil)
+		req.Header.Set("If-None-Match", etag)
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusNotModified {
+			t.Fatalf("expected 304, got %d", rr.Code)
+		}
+	})
+
+	t.Run("writes require If-Match", func(t *testing.T) {
+		if rr := serve(http.MethodPut, "/products/5", "", `{"name":"Renamed"}`); rr.Code != http.StatusPreconditionRequired {
+			t.Fatalf("expected 428 for update, got %d", rr.Code)
+		}
+		if rr := serve(http.MethodDelete, "/products/5", "", ""); rr.Code != http.StatusPreconditionRequired {
+			t.Fatalf("expected 428 for delete, got %d", rr.Code)
+		}
+		svc.EXPECT().GetByID(gomock.Any(), uint(5)).Return(current, nil)
+		if rr := serve(http.MethodPut, "/products/5", "W/"+etag, `{"name":"Renamed"}`); rr.Code != http.StatusPreconditionFailed {
+			t.Fatalf("expected 412 for a weak tag, got %d", rr.Code)
+		}
+		if rr := serve(http.MethodPut, "/products/5", etag+", "+etag, `{"name":"Renamed"}`); rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400 for a tag list, got %d", rr.Code)
+		}
+		svc.EXPECT().GetByID(gomock.Any(), uint(5)).Return(current, nil)
+		stale := productETag(&domain.Product{ID: 5, Version: 2})
+		if rr := serve(http.MethodDelete, "/products/5", stale, ""); rr.Code != http.StatusPreconditionFailed {
+			t.Fatalf("expected 412 for an older version's tag, got %d", rr.Code)
+		}
+		svc.EXPECT().GetByID(gomock.Any(), uint(6)).Return(nil, repository.ErrProductNotFound)
+		if rr := serve(http.MethodPut, "/products/6", etag, `{"name":"Renamed"}`); rr.Code != http.StatusNotFound {
+			t.Fatalf("expected 404 for a missing product, got %d", rr.Code)
+		}
+	})
+
+	t.Run("update passes the version and returns the new etag", func(t *testing.T) {
+		svc.EXPECT().GetByID(gomock.Any(), uint(5)).Return(current, nil)
+		updated := &domain.Product{ID: 5, Name: "Renamed", Version: 4}
+		svc.EXPECT().Update(gomock.Any(), uint(5), uint(3), gomock.Any()).Return(updated, nil)
+		rr := serve(http.MethodPut, "/products/5", etag, `{"name":"Renamed"}`)
+		if rr.Code != http.StatusOK || rr.Header().Get("ETag") != productETag(updated) || rr.Header().Get("ETag") == etag {
+			t.Fatalf("expected 200 with a new ETag, got %d etag=%q body=%s", rr.Code, rr.Header().Get("ETag"), rr.Body.String())
+		}
+	})
+
+	t.Run("stale version maps to 412", func(t *testing.T) {
+		svc.EXPECT().GetByID(gomock.Any(), uint(5)).Return(current, nil).Times(2)
+		svc.EXPECT().Update(gomock.Any(), uint(5), uint(3), gomock.Any()).Return(nil, repository.ErrProductVersionConflict)
+		if rr := serve(http.MethodPut, "/products/5", etag, `{"name":"Renamed"}`); rr.Code != http.StatusPreconditionFailed {
+			t.Fatalf("expected 412, got %d body=%s", rr.Code, rr.Body.String())
+		}
+		svc.EXPECT().DeleteByID(gomock.Any(), uint(5), uint(3)).Return(repository.ErrProductVersionConflict)
+		if rr := serve(http.MethodDelete, "/products/5", etag, ""); rr.Code != http.StatusPreconditionFailed {
+			t.Fatalf("expected 412 for delete, got %d", rr.Code)
+		}
+	})
+
+	t.Run("wildcard deletes any version", func(t *testing.T) {
+		svc.EXPECT().DeleteByID(gomock.Any(), uint(5), uint(0)).Return(nil)
+		if rr := serve(http.MethodDelete, "/products/5", "*", ""); rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+}
+
+func TestProductHandlerOwnScopeAndSharing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockProductService(ctrl)
+	h := NewProductHandler(svc, &config.Config{})
+	jwt := security.NewJWTManager("iss", "aud", "abcdefghijklmnopqrstuvwxyz123456", "abcdefghijklmnopqrstuvwxyz654321")
+	rbac := service.NewRBACService()
+
+	r := chi.NewRouter()
+	r.Use(middleware.AuthMiddleware(jwt))
+	r.Route("/api/v1/products", func(r chi.Router) {
+		r.Use(middleware.RequireAnyPermission(rbac, nil, "products:write", "products:write:own"))
+		r.Put("/{id}", h.Update)
+		r.Post("/{id}/grants", h.Share)
+		r.Delete("/{id}/grants/{grant_id}", h.RevokeShare)
+	})
+
+	t.Run("update by non-owner maps to 403", func(t *testing.T) {
+		product := &domain.Product{ID: 3, Version: 1}
+		svc.EXPECT().GetByID(gomock.Any(), uint(3)).Return(product, nil)
+		svc.EXPECT().Update(gomock.Any(), uint(3), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _, _ uint, _ service.UpdateProductInput) (*domain.Product, error) {
+			principal, ok := service.PrincipalFromContext(ctx)
+			if !ok || principal.UserID != 42 {
+				t.Fatalf("expected principal for user 42, got %+v ok=%v", principal, ok)
+			}
+			return nil, service.ErrProductForbidden
+		})
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/products/3", strings.NewReader(`{"name":"Renamed"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", productETag(product))
+		req.Header.Set("Authorization", "Bearer "+productAccessTokenForTest(t, []string{"products:write:own"}))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("share rejects invalid grant", func(t *testing.T) {
+		svc.EXPECT().ShareProduct(gomock.Any(), uint(3), gomock.Any()).Return(nil, service.ErrProductInvalidGrant)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/products/3/grants", strings.NewReader(`{"access":"read"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+productAccessTokenForTest(t, []string{"products:write:own"}))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("share returns grant", func(t *testing.T) {
+		svc.EXPECT().ShareProduct(gomock.Any(), uint(3), gomock.Any()).DoAndReturn(func(_ context.Context, productID uint, input service.ShareProductInput) (*domain.ProductGrant, error) {
+			if input.UserID == nil || *input.UserID != 7 || input.Access != "write" {
+				t.Fatalf("unexpected share input: %+v", input)
+			}
+			return &domain.ProductGrant{ID: 11, ProductID: productID, UserID: input.UserID, Access: input.Access}, nil
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/products/3/grants", strings.NewReader(`{"user_id":7,"access":"write"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+productAccessTokenForTest(t, []string{"products:write"}))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("revoke missing grant maps to 404", func(t *testing.T) {
+		svc.EXPECT().RevokeShare(gomock.Any(), uint(3), uint(99)).Return(repository.ErrProductGrantNotFound)
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/products/3/grants/99", nil)
+		req.Header.Set("Authorization", "Bearer "+productAccessTokenForTest(t, []string{"products:write"}))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected 404, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+}
+
+func TestProductHandlerTrash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockProductService(ctrl)
+	h := NewProductHandler(svc, &config.Config{})
+
+	r := chi.NewRouter()
+	r.Get("/products/trash", h.ListTrash)
+	r.Get("/products/{id}", h.GetByID)
+	r.Post("/products/trash/{id}/restore", h.Restore)
+	r.Delete("/products/trash/{id}", h.Purge)
+
+	serve := func(method, target string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(method, target, nil))
+		return rr
+	}
+
+	t.Run("list trash is paginated", func(t *testing.T) {
+		deletedAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		svc.EXPECT().ListDeleted(gomock.Any(), repository.PageRequest{Page: 1, PageSize: 20}).Return(repository.PageResult[domain.Product]{
+			Items:    []domain.Product{{ID: 4, Name: "Gone", DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}}},
+			Page:     1,
+			PageSize: 20,
+			Total:    1,
+		}, nil)
+		rr := serve(http.MethodGet, "/products/trash")
+		if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"deleted_at":"2026-03-01T00:00:00Z"`) {
+			t.Fatalf("expected trashed product, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("restore returns product with etag", func(t *testing.T) {
+		restored := &domain.Product{ID: 4, Name: "Back", Version: 3}
+		svc.EXPECT().Restore(gomock.Any(), uint(4)).Return(restored, nil)
+		rr := serve(http.MethodPost, "/products/trash/4/restore")
+		if rr.Code != http.StatusOK || rr.Header().Get("ETag") != productETag(restored) {
+			t.Fatalf("expected restored product, got %d etag=%q", rr.Code, rr.Header().Get("ETag"))
+		}
+	})
+
+	t.Run("restore and purge of unknown product are 404", func(t *testing.T) {
+		svc.EXPECT().Restore(gomock.Any(), uint(5)).Return(nil, repository.ErrProductNotFound)
+		if rr := serve(http.MethodPost, "/products/trash/5/restore"); rr.Code != http.StatusNotFound {
+			t.Fatalf("expected 404, got %d", rr.Code)
+		}
+		svc.EXPECT().Purge(gomock.Any(), uint(5)).Return(repository.ErrProductNotFound)
+		if rr := serve(http.MethodDelete, "/products/trash/5"); rr.Code != http.StatusNotFound {
+			t.Fatalf("expected 404, got %d", rr.Code)
+		}
+	})
+}
+
+func TestProductHandlerSKU(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockProductService(ctrl)
+	h := NewProductHandler(svc, &config.Config{})
+
+	r := chi.NewRouter()
+	r.Post("/products", h.Create)
+	r.Put("/products/{id}", h.Update)
+
+	t.Run("create passes the sku and maps duplicates to 409", func(t *testing.T) {
+		svc.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, input service.CreateProductInput) (*domain.Product, error) {
+			if input.SKU != "WID-1" {
+				t.Fatalf("expected sku in create input, got %+v", input)
+			}
+			return nil, service.ErrProductSKUTaken
+		})
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/products", strings.NewReader(`{"sku":"WID-1","name":"Widget","price":"5"}`)))
+		if rr.Code != http.StatusConflict {
+			t.Fatalf("expected 409, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("update with an invalid sku is 400", func(t *testing.T) {
+		product := &domain.Product{ID: 3, Version: 1}
+		svc.EXPECT().GetByID(gomock.Any(), uint(3)).Return(product, nil)
+		svc.EXPECT().Update(gomock.Any(), uint(3), uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, _, _ uint, input service.UpdateProductInput) (*domain.Product, error) {
+			if input.SKU == nil || *input.SKU != "bad sku" {
+				t.Fatalf("expected sku in update input, got %+v", input)
+			}
+			return nil, service.ErrProductInvalidSKU
+		})
+		req := httptest.NewRequest(http.MethodPut, "/products/3", strings.NewReader(`{"sku":"bad sku"}`))
+		req.Header.Set("If-Match", productETag(product))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+}
+
+func TestProductHandlerImages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockProductService(ctrl)
+	h := NewProductHandler(svc, &config.Config{})
+
+	r := chi.NewRouter()
+	r.Post("/products/{id}/images", h.UploadImage)
+	r.Put("/products/{id}/images/order", h.ReorderImages)
+	r.Get("/products/{id}/images/{image_id}", h.GetImage)
+	r.Delete("/products/{id}/images/{image_id}", h.DeleteImage)
+
+	upload := func(field string, content []byte) *http.Request {
+		var buf bytes.Buffer
+		form := multipart.NewWriter(&buf)
+		part, _ := form.CreateFormFile(field, "photo.png")
+		_, _ = part.Write(content)
+		_ = form.Close()
+		req := httptest.NewRequest(http.MethodPost, "/products/4/images", &buf)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		return req
+	}
+
+	t.Run("upload passes the file and returns the image", func(t *testing.T) {
+		svc.EXPECT().UploadImage(gomock.Any(), uint(4), gomock.Any(), int64(8)).DoAndReturn(func(_ context.Context, _ uint, file io.Reader, _ int64) (*domain.ProductImage, error) {
+			content, _ := io.ReadAll(file)
+			if string(content) != "\x89PNG\r\n\x1a\n" {
+				t.Fatalf("unexpected file content %q", content)
+			}
+			return &domain.ProductImage{ID: 9, ProductID: 4, ContentType: "image/png", Size: 8, URL: "https://storage.local/signed"}, nil
+		})
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, upload("image", []byte("\x89PNG\r\n\x1a\n")))
+		if rr.Code != http.StatusCreated || !strings.Contains(rr.Body.String(), `"url":"https://storage.local/signed"`) || strings.Contains(rr.Body.String(), "object_key") {
+			t.Fatalf("expected 201 with the signed image, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("upload maps validation failures", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, upload("avatar", []byte("x")))
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400 without an image field, got %d", rr.Code)
+		}
+
+		cases := []struct {
+			err  error
+			code int
+		}{
+			{service.ErrInvalidFileType, http.StatusBadRequest},
+			{service.ErrFileTooBig, http.StatusBadRequest},
+			{repository.ErrProductImageLimit, http.StatusConflict},
+			{service.ErrProductForbidden, http.StatusForbidden},
+			{repository.ErrProductNotFound, http.StatusNotFound},
+		}
+		for _, tc := range cases {
+			svc.EXPECT().UploadImage(gomock.Any(), uint(4), gomock.Any(), gomock.Any()).Return(nil, tc.err)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, upload("image", []byte("x")))
+			if rr.Code != tc.code {
+				t.Fatalf("expected %d for %v, got %d body=%s", tc.code, tc.err, rr.Code, rr.Body.String())
+			}
+		}
+	})
+
+	t.Run("reorder passes the ids and rejects incomplete orders", func(t *testing.T) {
+		svc.EXPECT().ReorderImages(gomock.Any(), uint(4), []uint{3, 1, 2}).Return([]domain.ProductImage{{ID: 3}, {ID: 1, Position: 1}, {ID: 2, Position: 2}}, nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/products/4/images/order", strings.NewReader(`{"image_ids":[3,1,2]}`)))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+		}
+
+		svc.EXPECT().ReorderImages(gomock.Any(), uint(4), []uint{3}).Return(nil, repository.ErrProductImageOrder)
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/products/4/images/order", strings.NewReader(`{"image_ids":[3]}`)))
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("delete maps a missing image to 404", func(t *testing.T) {
+		svc.EXPECT().DeleteImage(gomock.Any(), uint(4), uint(9)).Return(nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/products/4/images/9", nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d body=%s", rr.Code, rr.Body.String())
+		}
+
+		svc.EXPECT().DeleteImage(gomock.Any(), uint(4), uint(10)).Return(repository.ErrProductImageNotFound)
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/products/4/images/10", nil))
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected 404, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("get redirects to a presigned link", func(t *testing.T) {
+		svc.EXPECT().ImageURL(gomock.Any(), uint(4), uint(9)).Return("https://storage.local/a?sig=1", nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/products/4/images/9", nil))
+		if rr.Code != http.StatusFound || rr.Header().Get("Location") != "https://storage.local/a?sig=1" || rr.Header().Get("Cache-Control") != "private, no-store" {
+			t.Fatalf("expected uncached redirect, got %d headers=%v", rr.Code, rr.Header())
+		}
+
+		svc.EXPECT().ImageURL(gomock.Any(), uint(4), uint(10)).Return("", repository.ErrProductImageNotFound)
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/products/4/images/10", nil))
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected 404, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/http/response"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+)
+
+// productTransferContentTypes maps import and export formats to their media
+// types. Imports also accept the other common NDJSON media types.
+var productTransferContentTypes = map[string]string{
+	service.ProductTransferCSV:    "text/csv",
+	service.ProductTransferNDJSON: "application/x-ndjson",
+}
+
+// ProductImportHandler serves bulk product imports under /products/import
+// and the catalog export under /products/export.
+type ProductImportHandler struct {
+	svc service.ProductImportService
+}
+
+func NewProductImportHandler(svc service.ProductImportService) *ProductImportHandler {
+	return &ProductImportHandler{svc: svc}
+}
+
+// Import accepts a CSV or NDJSON file, chosen by Content-Type, and answers
+// 202 with the job that processes it. ?dry_run=true validates every row
+// without storing anything.
+func (h *ProductImportHandler) Import(w http.ResponseWriter, r *http.Request) {
+	format, ok := productImportFormat(r.Header.Get("Content-Type"))
+	if !ok {
+		response.Error(w, r, http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE", "content type must be text/csv or application/x-ndjson", nil)
+		return
+	}
+	dryRun := false
+	if raw := strings.TrimSpace(r.URL.Query().Get("dry_run")); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "dry_run must be true or false", nil)
+			return
+		}
+		dryRun = parsed
+	}
+
+	// Large files take longer to upload than the server read timeout allows;
+	// the body limit bounds the upload instead.
+	_ = http.NewResponseController(w).SetReadDeadline(time.Time{})
+	job, err := h.svc.StartImport(r.Context(), service.ProductImportInput{Format: format, DryRun: dryRun, Body: r.Body})
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesErr):
+			response.Error(w, r, http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", fmt.Sprintf("import file exceeds %d bytes", maxBytesErr.Limit), nil)
+		case errors.Is(err, service.ErrProductImportEmpty),
+			errors.Is(err, service.ErrProductImportInvalidFormat):
+			response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		default:
+			response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to start import", nil)
+		}
+		return
+	}
+
+	jobID := strconv.FormatUint(uint64(job.ID), 10)
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "product.import",
+		ActorUserID: adminActorID(r),
+		TargetType:  "product_import_job",
+		TargetID:    jobID,
+		Action:      "import",
+		Outcome:     "success",
+		Reason:      "product_import_started",
+	}, "format", job.Format, "dry_run", job.DryRun)
+	w.Header().Set("Location", "/api/v1/products/import/"+jobID)
+	response.JSON(w, r, http.StatusAccepted, job)
+}
+
+// GetImport reports an import job's progress and per-row errors.
+func (h *ProductImportHandler) GetImport(w http.ResponseWriter, r *http.Request) {
+	jobID, err := parsePathID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "invalid import job id", nil)
+		return
+	}
+	job, err := h.svc.GetImportJob(r.Context(), jobID)
+	if err != nil {
+		if errors.Is(err, repository.ErrProductImportJobNotFound) {
+			response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "import job not found", nil)
+			return
+		}
+		response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to load import job", nil)
+		return
+	}
+	response.JSON(w, r, http.StatusOK, job)
+}
+
+// Export streams every product in the caller's tenant as ?format=csv (the
+// default) or ndjson, in the same layout Import accepts.
+func (h *ProductImportHandler) Export(w http.ResponseWriter, r *http.Request) {
+	format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))
+	if format == "" {
+		format = service.ProductTransferCSV
+	}
+	contentType, ok := productTransferContentTypes[format]
+	if !ok {
+		response.Error(w, r, http.StatusBadRequest, "BAD_REQUEST", "format must be csv or ndjson", nil)
+		return
+	}
+
+	// Exports of large catalogs outlast the server write timeout.
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="products.%s"`, format))
+	out := &writeTracker{ResponseWriter: w}
+	if err := h.svc.Export(r.Context(), format, out); err != nil {
+		if !out.wrote {
+			w.Header().Del("Content-Disposition")
+			response.Error(w, r, http.StatusInternalServerError, "INTERNAL", "failed to export products", nil)
+			return
+		}
+		// Rows were already sent with a 200, so abort the connection to
+		// keep clients from taking a truncated file as complete.
+		slog.Warn("product export aborted", "path", r.URL.Path, "error", err)
+		panic(http.ErrAbortHandler)
+	}
+	observability.EmitAudit(r, observability.AuditInput{
+		EventName:   "product.export",
+		ActorUserID: adminActorID(r),
+		TargetType:  "product",
+		Action:      "export",
+		Outcome:     "success",
+		Reason:      "products_exported",
+	}, "format", format)
+}
+
+func productImportFormat(contentType string) (string, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+	switch mediaType {
+	case "text/csv":
+		return service.ProductTransferCSV, true
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return service.ProductTransferNDJSON, true
+	default:
+		return "", false
+	}
+}
+
+// writeTracker records whether anything was written to the response.
+type writeTracker struct {
+	http.ResponseWriter
+	wrote bool
+}
+
+func (w *writeTracker) Write(p []byte) (int, error) {
+	w.wrote = true
+	return w.ResponseWriter.Write(p)
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+	servicegomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/service/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProductImportHandlerEndpoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := servicegomock.NewMockProductImportService(ctrl)
+	h := NewProductImportHandler(svc)
+
+	r := chi.NewRouter()
+	r.Post("/products/import", h.Import)
+	r.Get("/products/import/{id}", h.GetImport)
+	r.Get("/products/export", h.Export)
+
+	t.Run("import starts a job from the content type", func(t *testing.T) {
+		svc.EXPECT().StartImport(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, input service.ProductImportInput) (*domain.ProductImportJob, error) {
+			body, _ := io.ReadAll(input.Body)
+			if input.Format != service.ProductTransferNDJSON || !input.DryRun || string(body) != `{"sku":"A-1"}` {
+				t.Fatalf("unexpected import input: %+v body=%s", input, body)
+			}
+			return &domain.ProductImportJob{ID: 7, Format: input.Format, DryRun: true, Status: domain.ProductImportPending}, nil
+		})
+		req := httptest.NewRequest(http.MethodPost, "/products/import?dry_run=true", strings.NewReader(`{"sku":"A-1"}`))
+		req.Header.Set("Content-Type", "application/x-ndjson; charset=utf-8")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, withClaims(req, "42"))
+		if rr.Code != http.StatusAccepted || rr.Header().Get("Location") != "/api/v1/products/import/7" || !strings.Contains(rr.Body.String(), `"status":"pending"`) {
+			t.Fatalf("expected 202 with job, got %d headers=%v body=%s", rr.Code, rr.Header(), rr.Body.String())
+		}
+	})
+
+	t.Run("import rejects unknown content types and dry_run values", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/products/import", strings.NewReader("x"))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusUnsupportedMediaType {
+			t.Fatalf("expected 415, got %d body=%s", rr.Code, rr.Body.String())
+		}
+
+		req = httptest.NewRequest(http.MethodPost, "/products/import?dry_run=maybe", strings.NewReader("x"))
+		req.Header.Set("Content-Type", "text/csv")
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("oversized import maps to 413", func(t *testing.T) {
+		svc.EXPECT().StartImport(gomock.Any(), gomock.Any()).Return(nil, &http.MaxBytesError{Limit: 1024})
+		req := httptest.NewRequest(http.MethodPost, "/products/import", strings.NewReader("x"))
+		req.Header.Set("Content-Type", "text/csv")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusRequestEntityTooLarge || !strings.Contains(rr.Body.String(), "1024 bytes") {
+			t.Fatalf("expected 413, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("job lookup maps missing jobs to 404", func(t *testing.T) {
+		svc.EXPECT().GetImportJob(gomock.Any(), uint(8)).Return(nil, repository.ErrProductImportJobNotFound)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/products/import/8", nil))
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected 404, got %d body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("export streams the requested format", func(t *testing.T) {
+		svc.EXPECT().Export(gomock.Any(), service.ProductTransferNDJSON, gomock.Any()).DoAndReturn(func(_ context.Context, _ string, w io.Writer) error {
+			_, err := io.WriteString(w, "{\"sku\":\"A-1\"}\n")
+			return err
+		})
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/products/export?format=ndjson", nil))
+		if rr.Code != http.StatusOK || rr.Header().Get("Content-Type") != "application/x-ndjson" || rr.Body.String() != "{\"sku\":\"A-1\"}\n" {
+			t.Fatalf("expected ndjson export, got %d headers=%v body=%s", rr.Code, rr.Header(), rr.Body.String())
+		}
+	})
+
+	t.Run("export failure before any row is a 500", func(t *testing.T) {
+		svc.EXPECT().Export(gomock.Any(), service.ProductTransferCSV, gomock.Any()).Return(errors.New("db down"))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/products/export", nil))
+		if rr.Code != http.StatusInternalServerError || rr.Header().Get("Content-Disposition") != "" {
+			t.Fatalf("expected 500, got %d headers=%v", rr.Code, rr.Header())
+		}
+
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/products/export?format=xlsx", nil))
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400 for unknown format, got %d", rr.Code)
+		}
+	})
+}
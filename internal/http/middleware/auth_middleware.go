@@ -17,6 +17,17 @@ const (
 )
 
 func AuthMiddleware(jwtMgr *security.JWTManager) func(http.Handler) http.Handler {
+	return authMiddleware(jwtMgr, true)
+}
+
+// OptionalAuthMiddleware authenticates requests that carry an access token
+// and lets anonymous requests through without claims. Invalid tokens are
+// still rejected.
+func OptionalAuthMiddleware(jwtMgr *security.JWTManager) func(http.Handler) http.Handler {
+	return authMiddleware(jwtMgr, false)
+}
+
+func authMiddleware(jwtMgr *security.JWTManager, required bool) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			raw := security.GetCookie(r, "access_token")
@@ -29,6 +40,10 @@ func AuthMiddleware(jwtMgr *security.JWTManager) func(http.Handler) http.Handler
 				}
 			}
 			if raw == "" {
+				if !required {
+					next.ServeHTTP(w, r)
+					return
+				}
 				observability.RecordAccessTokenValidation(r.Context(), "missing", "none")
 				response.Error(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "missing access token", nil)
 				return
@@ -53,3 +53,45 @@ func TestAuthMiddlewareValidBearerTokenPasses(t *testing.T) {
 		t.Fatalf("expected 204 for valid token, got %d", rr.Code)
 	}
 }
+
+func TestOptionalAuthMiddleware(t *testing.T) {
+	jwtMgr := security.NewJWTManager(
+		"iss",
+		"aud",
+		"abcdefghijklmnopqrstuvwxyz123456",
+		"abcdefghijklmnopqrstuvwxyz654321",
+	)
+	token, err := jwtMgr.SignAccessToken(42, nil, nil, 15*time.Minute)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	h := OptionalAuthMiddleware(jwtMgr)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := ClaimsFromContext(r.Context()); ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, tc := range []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{name: "anonymous", want: http.StatusOK},
+		{name: "valid token", header: "Bearer " + token, want: http.StatusNoContent},
+		{name: "invalid token", header: "Bearer nope", want: http.StatusUnauthorized},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/cart", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+			if rr.Code != tc.want {
+				t.Fatalf("expected %d, got %d", tc.want, rr.Code)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package middleware
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/http/response"
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
@@ -9,6 +11,15 @@ import (
 )
 
 func RequirePermission(rbac service.RBACAuthorizer, resolver service.PermissionResolver, permission string) func(http.Handler) http.Handler {
+	return RequireAnyPermission(rbac, resolver, permission)
+}
+
+// RequireAnyPermission allows the request when the caller holds at least one
+// of permissions, e.g. a global permission or its ":own" scoped variant. The
+// resolved permissions are attached to the request as a service.Principal so
+// handlers and services can apply record-level checks.
+func RequireAnyPermission(rbac service.RBACAuthorizer, resolver service.PermissionResolver, permissions ...string) func(http.Handler) http.Handler {
+	label := strings.Join(permissions, "|")
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			claims, ok := ClaimsFromContext(r.Context())
@@ -20,19 +31,35 @@ func RequirePermission(rbac service.RBACAuthorizer, resolver service.PermissionR
 			if resolver != nil {
 				resolved, err := resolver.ResolvePermissions(r.Context(), claims)
 				if err != nil {
-					observability.RecordRBACAuthorizationEvent(r.Context(), permission, "resolver_error")
+					observability.RecordRBACAuthorizationEvent(r.Context(), label, "resolver_error")
 					response.Error(w, r, http.StatusServiceUnavailable, "RBAC_UNAVAILABLE", "permission resolution unavailable", nil)
 					return
 				}
 				perms = resolved
 			}
-			if !rbac.HasPermission(perms, permission) {
-				observability.RecordRBACAuthorizationEvent(r.Context(), permission, "denied")
-				response.Error(w, r, http.StatusForbidden, "FORBIDDEN", "insufficient permission", map[string]string{"required": permission})
+			// Org-scoped roles only widen permissions on routes that run
+			// TenantMiddleware; platform admin routes never carry a tenant.
+			if tenant, ok := service.TenantFromContext(r.Context()); ok && len(tenant.Permissions) > 0 {
+				perms = append(append([]string{}, perms...), tenant.Permissions...)
+			}
+			allowed := false
+			for _, permission := range permissions {
+				if rbac.HasPermission(perms, permission) {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				observability.RecordRBACAuthorizationEvent(r.Context(), label, "denied")
+				response.Error(w, r, http.StatusForbidden, "FORBIDDEN", "insufficient permission", map[string]string{"required": label})
 				return
 			}
-			observability.RecordRBACAuthorizationEvent(r.Context(), permission, "allowed")
-			next.ServeHTTP(w, r)
+			observability.RecordRBACAuthorizationEvent(r.Context(), label, "allowed")
+			ctx := r.Context()
+			if userID, err := strconv.ParseUint(claims.Subject, 10, 64); err == nil {
+				ctx = service.WithPrincipal(ctx, service.Principal{UserID: uint(userID), Permissions: perms})
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/security"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
 	servicegomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/service/gomock"
 	"go.uber.org/mock/gomock"
 )
@@ -77,3 +78,31 @@ func TestRequirePermissionAllowed(t *testing.T) {
 		t.Fatal("expected wrapped handler to be called")
 	}
 }
+
+func TestRequireAnyPermissionAllowsScopedVariantAndSetsPrincipal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authorizer := servicegomock.NewMockRBACAuthorizer(ctrl)
+	perms := []string{"products:write:own"}
+	authorizer.EXPECT().HasPermission(perms, "products:write").Return(false)
+	authorizer.EXPECT().HasPermission(perms, "products:write:own").Return(true)
+	mw := RequireAnyPermission(authorizer, nil, "products:write", "products:write:own")
+
+	claims := &security.Claims{Permissions: perms}
+	claims.Subject = "42"
+	req := httptest.NewRequest(http.MethodPut, "/", nil)
+	req = req.WithContext(context.WithValue(req.Context(), ClaimsContextKey, claims))
+	rr := httptest.NewRecorder()
+
+	var principal service.Principal
+	var found bool
+	mw(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		principal, found = service.PrincipalFromContext(r.Context())
+	})).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if !found || principal.UserID != 42 || !principal.Has("products:write:own") || principal.Has("products:write") {
+		t.Fatalf("unexpected principal: found=%v %+v", found, principal)
+	}
+}
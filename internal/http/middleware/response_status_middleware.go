@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// ResponseStatusRecorder receives the status code of every API response.
+type ResponseStatusRecorder interface {
+	RecordStatus(status int)
+}
+
+// RecordResponseStatus reports each response status to recorder. Health
+// probes are skipped so their steady 200s do not dilute error rates. Install
+// it outside the panic recoverer so recovered panics count as 500s.
+func RecordResponseStatus(recorder ResponseStatusRecorder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/health/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			defer func() {
+				status := ww.Status()
+				if status == 0 {
+					status = http.StatusOK
+				}
+				recorder.RecordStatus(status)
+			}()
+			next.ServeHTTP(ww, r)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type statusRecorderStub struct {
+	statuses []int
+}
+
+func (s *statusRecorderStub) RecordStatus(status int) {
+	s.statuses = append(s.statuses, status)
+}
+
+func TestRecordResponseStatus(t *testing.T) {
+	recorder := &statusRecorderStub{}
+	h := RecordResponseStatus(recorder)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/fail":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			_, _ = w.Write([]byte("ok"))
+		}
+	}))
+
+	for _, path := range []string{"/api/v1/ok", "/api/v1/fail", "/health/live"} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	if len(recorder.statuses) != 2 || recorder.statuses[0] != http.StatusOK || recorder.statuses[1] != http.StatusBadGateway {
+		t.Fatalf("expected 200 and 502 recorded without health probes, got %v", recorder.statuses)
+	}
+}
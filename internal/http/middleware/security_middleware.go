@@ -46,7 +46,8 @@ func CORS(allowedOrigins []string) func(http.Handler) http.Handler {
 					observability.RecordMiddlewareValidationEvent(r.Context(), "cors", "rejected_origin")
 				}
 				w.Header().Set("Access-Control-Allow-Credentials", "true")
-				w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-CSRF-Token")
+				w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-CSRF-Token, If-None-Match, If-Match")
+				w.Header().Set("Access-Control-Expose-Headers", "ETag")
 				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, OPTIONS")
 			}
 			if r.Method == http.MethodOptions {
@@ -59,11 +60,18 @@ func CORS(allowedOrigins []string) func(http.Handler) http.Handler {
 	}
 }
 
+// BodyLimit caps the request body at maxBytes. A BodyLimit on a route
+// replaces one applied further out, so routes can raise the global limit.
 func BodyLimit(maxBytes int64) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body := r.Body
+			if outer, ok := body.(*bodyLimitObserver); ok {
+				body = outer.body
+			}
 			r.Body = &bodyLimitObserver{
-				readCloser: http.MaxBytesReader(w, r.Body, maxBytes),
+				body:       body,
+				readCloser: http.MaxBytesReader(w, body, maxBytes),
 				ctx:        r.Context(),
 			}
 			next.ServeHTTP(w, r)
@@ -72,6 +80,8 @@ func BodyLimit(maxBytes int64) func(http.Handler) http.Handler {
 }
 
 type bodyLimitObserver struct {
+	// body is the unlimited request body wrapped by readCloser.
+	body       io.ReadCloser
 	readCloser io.ReadCloser
 	ctx        context.Context
 	emitted    bool
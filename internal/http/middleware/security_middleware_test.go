@@ -25,6 +25,9 @@ func TestCORSAllowsKnownOrigin(t *testing.T) {
 	if got := rr.Header().Get("Access-Control-Allow-Origin"); got != "https://app.example.com" {
 		t.Fatalf("expected allow-origin header for trusted origin, got %q", got)
 	}
+	if got := rr.Header().Get("Access-Control-Expose-Headers"); got != "ETag" {
+		t.Fatalf("expected ETag to be exposed for conditional polling, got %q", got)
+	}
 }
 
 func TestCORSRejectsUnknownOrigin(t *testing.T) {
@@ -117,6 +120,29 @@ func TestBodyLimitRejectsLargePayload(t *testing.T) {
 	}
 }
 
+func TestBodyLimitOnRouteReplacesOuterLimit(t *testing.T) {
+	read := func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+	raised := BodyLimit(8)(BodyLimit(32)(http.HandlerFunc(read)))
+	lowered := BodyLimit(32)(BodyLimit(4)(http.HandlerFunc(read)))
+
+	rr := httptest.NewRecorder()
+	raised.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("0123456789abcdef")))
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("expected inner limit to allow 16 bytes, got %d", rr.Code)
+	}
+	rr = httptest.NewRecorder()
+	lowered.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("0123456789")))
+	if rr.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected inner limit to reject 10 bytes, got %d", rr.Code)
+	}
+}
+
 func TestCSRFMiddlewareRejectsMismatch(t *testing.T) {
 	h := CSRFMiddleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusNoContent)
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/http/response"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+)
+
+// TenantHeader selects the active organization (numeric ID or slug) on routes
+// that do not carry an {org_id} path parameter.
+const TenantHeader = "X-Organization-ID"
+
+// TenantMiddleware resolves the active organization from the {org_id} route
+// parameter or TenantHeader and verifies the caller is a member. When required
+// is false and no organization is selected, the request proceeds unscoped.
+func TenantMiddleware(orgs service.OrganizationService, required bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			selector := strings.TrimSpace(chi.URLParam(r, "org_id"))
+			if selector == "" {
+				selector = strings.TrimSpace(r.Header.Get(TenantHeader))
+			}
+			if selector == "" {
+				if required {
+					response.Error(w, r, http.StatusBadRequest, "TENANT_REQUIRED", "organization must be selected", nil)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+			if orgs == nil {
+				response.Error(w, r, http.StatusServiceUnavailable, "TENANT_UNAVAILABLE", "tenant resolution unavailable", nil)
+				return
+			}
+			claims, ok := ClaimsFromContext(r.Context())
+			if !ok {
+				response.Error(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "missing auth context", nil)
+				return
+			}
+			userID, err := strconv.ParseUint(claims.Subject, 10, 64)
+			if err != nil {
+				response.Error(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "invalid subject", nil)
+				return
+			}
+			tenant, err := orgs.ResolveTenant(r.Context(), selector, uint(userID))
+			if err != nil {
+				switch {
+				case errors.Is(err, repository.ErrOrganizationNotFound):
+					response.Error(w, r, http.StatusNotFound, "NOT_FOUND", "organization not found", nil)
+				case errors.Is(err, service.ErrOrganizationNotMember):
+					response.Error(w, r, http.StatusForbidden, "FORBIDDEN", "not a member of organization", nil)
+				default:
+					response.Error(w, r, http.StatusServiceUnavailable, "TENANT_UNAVAILABLE", "tenant resolution unavailable", nil)
+				}
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(service.WithTenant(r.Context(), *tenant)))
+		})
+	}
+}
+
+// RequireOrgPermission checks permission against the caller's org-scoped roles
+// in the active tenant. It must run after TenantMiddleware.
+func RequireOrgPermission(rbac service.RBACAuthorizer, permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tenant, ok := service.TenantFromContext(r.Context())
+			if !ok {
+				response.Error(w, r, http.StatusBadRequest, "TENANT_REQUIRED", "organization must be selected", nil)
+				return
+			}
+			if !rbac.HasPermission(tenant.Permissions, permission) {
+				response.Error(w, r, http.StatusForbidden, "FORBIDDEN", "insufficient organization permission", map[string]string{"required": permission})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/security"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+	servicegomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/service/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTenantMiddlewareOptionalPassesThroughWithoutSelector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orgs := servicegomock.NewMockOrganizationService(ctrl)
+	mw := TenantMiddleware(orgs, false)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rr := httptest.NewRecorder()
+	called := false
+	mw(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		called = true
+		if _, ok := service.TenantFromContext(r.Context()); ok {
+			t.Fatal("expected no tenant in context")
+		}
+	})).ServeHTTP(rr, req)
+	if !called {
+		t.Fatal("expected request to pass through")
+	}
+}
+
+func TestTenantMiddlewareResolvesHeader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orgs := servicegomock.NewMockOrganizationService(ctrl)
+	orgs.EXPECT().ResolveTenant(gomock.Any(), "acme", uint(7)).Return(&service.Tenant{OrganizationID: 4, Slug: "acme"}, nil)
+	mw := TenantMiddleware(orgs, true)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(TenantHeader, "acme")
+	req = req.WithContext(context.WithValue(req.Context(), ClaimsContextKey, tenantTestClaims("7")))
+	rr := httptest.NewRecorder()
+
+	var got service.Tenant
+	mw(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got, _ = service.TenantFromContext(r.Context())
+	})).ServeHTTP(rr, req)
+	if got.OrganizationID != 4 {
+		t.Fatalf("expected tenant 4 in context, got %+v", got)
+	}
+}
+
+func TestTenantMiddlewareErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orgs := servicegomock.NewMockOrganizationService(ctrl)
+	orgs.EXPECT().ResolveTenant(gomock.Any(), "missing", gomock.Any()).Return(nil, repository.ErrOrganizationNotFound)
+	orgs.EXPECT().ResolveTenant(gomock.Any(), "other", gomock.Any()).Return(nil, service.ErrOrganizationNotMember)
+
+	cases := []struct {
+		name     string
+		selector string
+		required bool
+		want     int
+	}{
+		{name: "required without selector", selector: "", required: true, want: http.StatusBadRequest},
+		{name: "unknown organization", selector: "missing", want: http.StatusNotFound},
+		{name: "not a member", selector: "other", want: http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.selector != "" {
+				req.Header.Set(TenantHeader, tc.selector)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), ClaimsContextKey, tenantTestClaims("7")))
+			rr := httptest.NewRecorder()
+			TenantMiddleware(orgs, tc.required)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+				t.Fatal("expected middleware to block request")
+			})).ServeHTTP(rr, req)
+			if rr.Code != tc.want {
+				t.Fatalf("expected status %d, got %d", tc.want, rr.Code)
+			}
+		})
+	}
+}
+
+func TestRequireOrgPermissionUsesTenantPermissions(t *testing.T) {
+	rbac := service.NewRBACService()
+	mw := RequireOrgPermission(rbac, "members:write")
+
+	req := httptest.NewRequest(http.MethodPut, "/", nil)
+	req = req.WithContext(service.WithTenant(req.Context(), service.Tenant{OrganizationID: 4, Permissions: []string{"members:read"}}))
+	rr := httptest.NewRecorder()
+	mw(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Fatal("expected middleware to block request")
+	})).ServeHTTP(rr, req)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d", http.StatusForbidden, rr.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/", nil)
+	req = req.WithContext(service.WithTenant(req.Context(), service.Tenant{OrganizationID: 4, Permissions: []string{"members:write"}}))
+	rr = httptest.NewRecorder()
+	called := false
+	mw(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		called = true
+	})).ServeHTTP(rr, req)
+	if !called {
+		t.Fatal("expected org admin to pass")
+	}
+}
+
+func tenantTestClaims(subject string) *security.Claims {
+	claims := &security.Claims{}
+	claims.Subject = subject
+	return claims
+}
@@ -21,7 +21,17 @@ type Dependencies struct {
 	UserHandler                *handler.UserHandler
 	AdminHandler               *handler.AdminHandler
 	FeatureFlagHandler         *handler.FeatureFlagHandler
+	FeatureFlagScheduleHandler *handler.FeatureFlagScheduleHandler
+	FeatureFlagUsageHandler    *handler.FeatureFlagUsageHandler
 	ProductHandler             *handler.ProductHandler
+	ProductImportHandler       *handler.ProductImportHandler
+	CategoryHandler            *handler.CategoryHandler
+	InventoryHandler           *handler.InventoryHandler
+	CartHandler                *handler.CartHandler
+	OrderHandler               *handler.OrderHandler
+	OrganizationHandler        *handler.OrganizationHandler
+	OrganizationService        service.OrganizationService
+	GroupHandler               *handler.GroupHandler
 	JWTManager                 *security.JWTManager
 	RBACService                service.RBACAuthorizer
 	PermissionResolver         service.PermissionResolver
@@ -29,12 +39,14 @@ type Dependencies struct {
 	AuthRateLimitRPM           int
 	PasswordForgotRateLimitRPM int
 	APIRateLimitRPM            int
+	ProductImportMaxBytes      int64
 	GlobalRateLimiter          GlobalRateLimiterFunc
 	AuthRateLimiter            AuthRateLimiterFunc
 	ForgotRateLimiter          ForgotRateLimiterFunc
 	RouteRateLimitPolicies     RouteRateLimitPolicies
 	Idempotency                IdempotencyMiddlewareFactory
 	Readiness                  *health.ProbeRunner
+	ResponseStatusRecorder     middleware.ResponseStatusRecorder
 	EnableOTelHTTP             bool
 }
 
@@ -54,6 +66,9 @@ const (
 func NewRouter(dep Dependencies) http.Handler {
 	r := chi.NewRouter()
 	r.Use(chimiddleware.RealIP)
+	if dep.ResponseStatusRecorder != nil {
+		r.Use(middleware.RecordResponseStatus(dep.ResponseStatusRecorder))
+	}
 	r.Use(chimiddleware.Recoverer)
 	r.Use(middleware.RequestID)
 	r.Use(middleware.StructuredRequestLogger)
@@ -102,6 +117,14 @@ func NewRouter(dep Dependencies) http.Handler {
 		response.Error(w, r, http.StatusServiceUnavailable, "DEPENDENCY_UNREADY", "dependencies are not ready", map[string]any{"checks": results})
 	})
 
+	// OpenFeature Remote Evaluation Protocol, mounted at the root so OFREP
+	// providers can be pointed at the service's base URL.
+	r.Route("/ofrep/v1/evaluate", func(r chi.Router) {
+		r.Use(middleware.AuthMiddleware(dep.JWTManager))
+		r.Post("/flags", dep.FeatureFlagHandler.OFREPEvaluateFlags)
+		r.Post("/flags/{key}", dep.FeatureFlagHandler.OFREPEvaluateFlag)
+	})
+
 	r.Route("/api/v1", func(r chi.Router) {
 		r.Route("/auth", func(r chi.Router) {
 			r.With(authLimiter).Get("/google/login", dep.AuthHandler.GoogleLogin)
@@ -130,25 +153,119 @@ func NewRouter(dep Dependencies) http.Handler {
 
 		r.With(middleware.AuthMiddleware(dep.JWTManager)).Get("/me", dep.UserHandler.Me)
 		r.With(middleware.AuthMiddleware(dep.JWTManager)).Get("/feature-flags", dep.FeatureFlagHandler.EvaluateAll)
+		r.With(middleware.AuthMiddleware(dep.JWTManager)).Get("/feature-flags/stream", dep.FeatureFlagHandler.Stream)
+		r.With(middleware.AuthMiddleware(dep.JWTManager), middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "feature_flags:evaluate")).Get("/feature-flags/snapshot", dep.FeatureFlagHandler.Snapshot)
 		r.With(middleware.AuthMiddleware(dep.JWTManager)).Get("/feature-flags/{key}", dep.FeatureFlagHandler.EvaluateOne)
+		r.With(middleware.AuthMiddleware(dep.JWTManager), middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "feature_flags:evaluate")).Post("/feature-flags/evaluate", dep.FeatureFlagHandler.EvaluateBulk)
 		r.Route("/products", func(r chi.Router) {
 			r.Use(middleware.AuthMiddleware(dep.JWTManager))
+			r.Use(middleware.TenantMiddleware(dep.OrganizationService, false))
 			r.Group(func(r chi.Router) {
-				r.Use(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "products:read"))
+				r.Use(middleware.RequireAnyPermission(dep.RBACService, dep.PermissionResolver, "products:read", "products:read:own"))
 				r.Get("/", dep.ProductHandler.List)
 				r.Get("/{id}", dep.ProductHandler.GetByID)
+				r.Get("/{id}/images/{image_id}", dep.ProductHandler.GetImage)
 			})
 			r.Group(func(r chi.Router) {
-				r.Use(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "products:write"))
+				r.Use(middleware.RequireAnyPermission(dep.RBACService, dep.PermissionResolver, "products:write", "products:write:own"))
 				r.Post("/", dep.ProductHandler.Create)
 				r.Put("/{id}", dep.ProductHandler.Update)
+				r.Get("/{id}/grants", dep.ProductHandler.ListGrants)
+				r.Post("/{id}/grants", dep.ProductHandler.Share)
+				r.Delete("/{id}/grants/{grant_id}", dep.ProductHandler.RevokeShare)
+				// 6MB leaves room for multipart overhead around a 5MB image.
+				r.With(middleware.BodyLimit(6<<20)).Post("/{id}/images", dep.ProductHandler.UploadImage)
+				r.Put("/{id}/images/order", dep.ProductHandler.ReorderImages)
+				r.Delete("/{id}/images/{image_id}", dep.ProductHandler.DeleteImage)
 			})
 			r.Group(func(r chi.Router) {
-				r.Use(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "products:delete"))
+				r.Use(middleware.RequireAnyPermission(dep.RBACService, dep.PermissionResolver, "products:delete", "products:delete:own"))
 				r.Delete("/{id}", dep.ProductHandler.Delete)
 			})
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "products:delete"))
+				r.Get("/trash", dep.ProductHandler.ListTrash)
+				r.Post("/trash/{id}/restore", dep.ProductHandler.Restore)
+				r.Delete("/trash/{id}", dep.ProductHandler.Purge)
+			})
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "products:read"))
+				r.Get("/export", dep.ProductImportHandler.Export)
+			})
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "products:write"))
+				// Import files are streamed to disk, so they may exceed the
+				// global 1MB body limit.
+				r.With(middleware.BodyLimit(dep.ProductImportMaxBytes)).Post("/import", dep.ProductImportHandler.Import)
+				r.Get("/import/{id}", dep.ProductImportHandler.GetImport)
+			})
+		})
+		r.Route("/categories", func(r chi.Router) {
+			r.Use(middleware.AuthMiddleware(dep.JWTManager))
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequireAnyPermission(dep.RBACService, dep.PermissionResolver, "categories:read", "products:read", "products:read:own"))
+				r.Get("/", dep.CategoryHandler.List)
+				r.Get("/{id}", dep.CategoryHandler.Get)
+			})
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "categories:write"))
+				r.Post("/", dep.CategoryHandler.Create)
+				r.Patch("/{id}", dep.CategoryHandler.Update)
+				r.Delete("/{id}", dep.CategoryHandler.Delete)
+			})
+		})
+		r.Route("/inventory", func(r chi.Router) {
+			r.Use(middleware.AuthMiddleware(dep.JWTManager))
+			r.Use(middleware.TenantMiddleware(dep.OrganizationService, false))
+			// Stock writes are not naturally idempotent, so retries must carry
+			// an Idempotency-Key to avoid double adjustments or reservations.
+			inventoryWrite := func(scope string) []func(http.Handler) http.Handler {
+				chain := []func(http.Handler) http.Handler{
+					middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "inventory:write"),
+				}
+				if dep.Idempotency != nil {
+					chain = append(chain, dep.Idempotency(scope))
+				}
+				return chain
+			}
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "inventory:read"))
+				r.Get("/products/{id}", dep.InventoryHandler.GetStock)
+				r.Get("/products/{id}/movements", dep.InventoryHandler.ListMovements)
+				r.Get("/reservations/{id}", dep.InventoryHandler.GetReservation)
+			})
+			r.With(inventoryWrite("inventory.adjust")...).Post("/products/{id}/adjustments", dep.InventoryHandler.Adjust)
+			r.With(inventoryWrite("inventory.reservations.create")...).Post("/reservations", dep.InventoryHandler.Reserve)
+			r.With(inventoryWrite("inventory.reservations.commit")...).Post("/reservations/{id}/commit", dep.InventoryHandler.Commit)
+			r.With(inventoryWrite("inventory.reservations.release")...).Post("/reservations/{id}/release", dep.InventoryHandler.Release)
+		})
+		r.Route("/cart", func(r chi.Router) {
+			// Guests may use the cart; signed-in callers get their own.
+			r.Use(middleware.OptionalAuthMiddleware(dep.JWTManager))
+			r.Use(middleware.TenantMiddleware(dep.OrganizationService, false))
+			r.Get("/", dep.CartHandler.Get)
+			r.Delete("/", dep.CartHandler.Clear)
+			r.Put("/items/{product_id}", dep.CartHandler.SetItem)
+			r.Delete("/items/{product_id}", dep.CartHandler.RemoveItem)
+		})
+		r.Route("/orders", func(r chi.Router) {
+			r.Use(middleware.AuthMiddleware(dep.JWTManager))
+			r.Use(middleware.TenantMiddleware(dep.OrganizationService, false))
+			orderWrite := func(scope string) []func(http.Handler) http.Handler {
+				var chain []func(http.Handler) http.Handler
+				if dep.Idempotency != nil {
+					chain = append(chain, dep.Idempotency(scope))
+				}
+				return chain
+			}
+			r.Get("/", dep.OrderHandler.ListMine)
+			r.Get("/{id}", dep.OrderHandler.GetMine)
+			r.With(orderWrite("orders.create")...).Post("/", dep.OrderHandler.Checkout)
+			r.With(orderWrite("orders.pay")...).Post("/{id}/pay", dep.OrderHandler.PayMine)
+			r.With(orderWrite("orders.cancel")...).Post("/{id}/cancel", dep.OrderHandler.CancelMine)
 		})
 		r.With(middleware.AuthMiddleware(dep.JWTManager)).Get("/me/sessions", dep.UserHandler.Sessions)
+		r.With(middleware.AuthMiddleware(dep.JWTManager)).Get("/me/org-invitations", dep.OrganizationHandler.ListInvitations)
 		r.Group(func(r chi.Router) {
 			r.Use(middleware.AuthMiddleware(dep.JWTManager))
 			r.Use(middleware.CSRFMiddleware)
@@ -157,11 +274,21 @@ func NewRouter(dep Dependencies) http.Handler {
 			// Avatar upload needs higher body limit (6MB) than global default (1MB)
 			r.With(middleware.BodyLimit(6<<20)).Post("/me/avatar", dep.UserHandler.UploadAvatar)
 			r.Delete("/me/avatar", dep.UserHandler.DeleteAvatar)
+			r.Post("/me/org-invitations/{org_id}/accept", dep.OrganizationHandler.AcceptInvitation)
+			r.Delete("/me/org-invitations/{org_id}", dep.OrganizationHandler.DeclineInvitation)
+		})
+
+		r.Route("/orgs/{org_id}", func(r chi.Router) {
+			r.Use(middleware.AuthMiddleware(dep.JWTManager))
+			r.Use(middleware.TenantMiddleware(dep.OrganizationService, true))
+			r.With(middleware.RequireOrgPermission(dep.RBACService, "members:read")).Get("/members", dep.OrganizationHandler.ListMembers)
+			r.With(middleware.RequireOrgPermission(dep.RBACService, "members:write"), routePolicy(RoutePolicyAdminWrite, nil)).Put("/members/{user_id}", dep.OrganizationHandler.SetMemberRoles)
+			r.With(middleware.RequireOrgPermission(dep.RBACService, "members:write"), routePolicy(RoutePolicyAdminWrite, nil)).Delete("/members/{user_id}", dep.OrganizationHandler.RemoveMember)
 		})
 
 		r.Route("/admin", func(r chi.Router) {
 			r.Use(middleware.AuthMiddleware(dep.JWTManager))
-			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "users:read")).Get("/users", dep.AdminHandler.ListUsers)
+			r.With(middleware.TenantMiddleware(dep.OrganizationService, false), middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "users:read")).Get("/users", dep.AdminHandler.ListUsers)
 			userRoleChain := []func(http.Handler) http.Handler{
 				middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "users:write"),
 				routePolicy(RoutePolicyAdminWrite, nil),
@@ -170,6 +297,22 @@ func NewRouter(dep Dependencies) http.Handler {
 				userRoleChain = append(userRoleChain, dep.Idempotency("admin.users.roles.patch"))
 			}
 			r.With(userRoleChain...).Patch("/users/{id}/roles", dep.AdminHandler.SetUserRoles)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "users:read")).Get("/users/{id}/role-grants", dep.AdminHandler.ListUserRoleGrants)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "users:write"), routePolicy(RoutePolicyAdminWrite, nil)).Delete("/users/{id}", dep.AdminHandler.DeleteUser)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "users:read")).Get("/users/trash", dep.AdminHandler.ListDeletedUsers)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "users:write"), routePolicy(RoutePolicyAdminWrite, nil)).Post("/users/trash/{id}/restore", dep.AdminHandler.RestoreUser)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "users:write"), routePolicy(RoutePolicyAdminWrite, nil)).Delete("/users/trash/{id}", dep.AdminHandler.PurgeUser)
+			userRoleGrantChain := []func(http.Handler) http.Handler{
+				middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "users:write"),
+				routePolicy(RoutePolicyAdminWrite, nil),
+			}
+			if dep.Idempotency != nil {
+				userRoleGrantChain = append(userRoleGrantChain, dep.Idempotency("admin.users.role_grants.create"))
+			}
+			r.With(userRoleGrantChain...).Post("/users/{id}/role-grants", dep.AdminHandler.GrantUserRole)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "role_requests:read")).Get("/role-change-requests", dep.AdminHandler.ListRoleChangeRequests)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "role_requests:approve"), routePolicy(RoutePolicyAdminWrite, nil)).Post("/role-change-requests/{id}/approve", dep.AdminHandler.ApproveRoleChangeRequest)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "role_requests:approve"), routePolicy(RoutePolicyAdminWrite, nil)).Post("/role-change-requests/{id}/reject", dep.AdminHandler.RejectRoleChangeRequest)
 			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "roles:read")).Get("/roles", dep.AdminHandler.ListRoles)
 			roleCreateChain := []func(http.Handler) http.Handler{
 				middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "roles:write"),
@@ -186,12 +329,57 @@ func NewRouter(dep Dependencies) http.Handler {
 			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "permissions:write"), routePolicy(RoutePolicyAdminWrite, nil)).Patch("/permissions/{id}", dep.AdminHandler.UpdatePermission)
 			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "permissions:write"), routePolicy(RoutePolicyAdminWrite, nil)).Delete("/permissions/{id}", dep.AdminHandler.DeletePermission)
 			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "roles:write"), routePolicy(RoutePolicyAdminSync, routePolicy(RoutePolicyAdminWrite, nil))).Post("/rbac/sync", dep.AdminHandler.SyncRBAC)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "groups:read")).Get("/groups", dep.GroupHandler.ListGroups)
+			groupCreateChain := []func(http.Handler) http.Handler{
+				middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "groups:write"),
+				routePolicy(RoutePolicyAdminWrite, nil),
+			}
+			if dep.Idempotency != nil {
+				groupCreateChain = append(groupCreateChain, dep.Idempotency("admin.groups.create"))
+			}
+			r.With(groupCreateChain...).Post("/groups", dep.GroupHandler.CreateGroup)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "groups:read")).Get("/groups/{id}", dep.GroupHandler.GetGroup)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "groups:write"), routePolicy(RoutePolicyAdminWrite, nil)).Patch("/groups/{id}", dep.GroupHandler.UpdateGroup)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "groups:write"), routePolicy(RoutePolicyAdminWrite, nil)).Delete("/groups/{id}", dep.GroupHandler.DeleteGroup)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "groups:read")).Get("/groups/{id}/members", dep.GroupHandler.ListMembers)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "groups:write"), routePolicy(RoutePolicyAdminWrite, nil)).Post("/groups/{id}/members", dep.GroupHandler.AddMembers)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "groups:write"), routePolicy(RoutePolicyAdminWrite, nil)).Delete("/groups/{id}/members/{user_id}", dep.GroupHandler.RemoveMember)
+			r.Route("/orders", func(r chi.Router) {
+				r.Use(middleware.TenantMiddleware(dep.OrganizationService, false))
+				r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "orders:read")).Get("/", dep.OrderHandler.List)
+				r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "orders:read")).Get("/{id}", dep.OrderHandler.Get)
+				r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "orders:write"), routePolicy(RoutePolicyAdminWrite, nil)).Post("/{id}/fulfill", dep.OrderHandler.Fulfill)
+				r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "orders:write"), routePolicy(RoutePolicyAdminWrite, nil)).Post("/{id}/cancel", dep.OrderHandler.Cancel)
+				r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "orders:write"), routePolicy(RoutePolicyAdminWrite, nil)).Post("/{id}/refund", dep.OrderHandler.Refund)
+			})
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "orgs:read")).Get("/orgs", dep.OrganizationHandler.ListOrganizations)
+			orgCreateChain := []func(http.Handler) http.Handler{
+				middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "orgs:write"),
+				routePolicy(RoutePolicyAdminWrite, nil),
+			}
+			if dep.Idempotency != nil {
+				orgCreateChain = append(orgCreateChain, dep.Idempotency("admin.orgs.create"))
+			}
+			r.With(orgCreateChain...).Post("/orgs", dep.OrganizationHandler.CreateOrganization)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "orgs:read")).Get("/orgs/{id}", dep.OrganizationHandler.GetOrganization)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "orgs:write"), routePolicy(RoutePolicyAdminWrite, nil)).Delete("/orgs/{id}", dep.OrganizationHandler.DeleteOrganization)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "orgs:write"), routePolicy(RoutePolicyAdminWrite, nil)).Put("/orgs/{id}/members/{user_id}", dep.OrganizationHandler.SetOrganizationMember)
 			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "feature_flags:read")).Get("/feature-flags", dep.FeatureFlagHandler.ListFlags)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "feature_flags:read")).Get("/feature-flags/graph", dep.FeatureFlagHandler.DependencyGraph)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "feature_flags:read")).Get("/feature-flags/stale", dep.FeatureFlagUsageHandler.StaleFlags)
 			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "feature_flags:read")).Get("/feature-flags/{id}", dep.FeatureFlagHandler.GetFlag)
 			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "feature_flags:write"), routePolicy(RoutePolicyAdminWrite, nil)).Post("/feature-flags", dep.FeatureFlagHandler.CreateFlag)
 			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "feature_flags:write"), routePolicy(RoutePolicyAdminWrite, nil)).Patch("/feature-flags/{id}", dep.FeatureFlagHandler.UpdateFlag)
 			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "feature_flags:write"), routePolicy(RoutePolicyAdminWrite, nil)).Delete("/feature-flags/{id}", dep.FeatureFlagHandler.DeleteFlag)
 			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "feature_flags:read")).Get("/feature-flags/{id}/rules", dep.FeatureFlagHandler.ListRules)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "feature_flags:read")).Get("/feature-flags/{id}/history", dep.FeatureFlagHandler.History)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "feature_flags:write"), routePolicy(RoutePolicyAdminWrite, nil)).Post("/feature-flags/{id}/rollback", dep.FeatureFlagHandler.Rollback)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "feature_flags:read")).Get("/feature-flags/{id}/usage", dep.FeatureFlagUsageHandler.Usage)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "feature_flags:read")).Get("/feature-flags/{id}/exposures", dep.FeatureFlagUsageHandler.ListExposures)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "feature_flags:read")).Get("/feature-flags/{id}/schedules", dep.FeatureFlagScheduleHandler.ListSchedules)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "feature_flags:write"), routePolicy(RoutePolicyAdminWrite, nil)).Post("/feature-flags/{id}/schedules", dep.FeatureFlagScheduleHandler.CreateSchedule)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "feature_flags:write"), routePolicy(RoutePolicyAdminWrite, nil)).Post("/feature-flags/{id}/schedules/{schedule_id}/cancel", dep.FeatureFlagScheduleHandler.CancelSchedule)
+			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "feature_flags:write"), routePolicy(RoutePolicyAdminWrite, nil)).Post("/feature-flags/{id}/schedules/{schedule_id}/resume", dep.FeatureFlagScheduleHandler.ResumeSchedule)
 			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "feature_flags:write"), routePolicy(RoutePolicyAdminWrite, nil)).Post("/feature-flags/{id}/rules", dep.FeatureFlagHandler.CreateRule)
 			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "feature_flags:write"), routePolicy(RoutePolicyAdminWrite, nil)).Patch("/feature-flags/{id}/rules/{rule_id}", dep.FeatureFlagHandler.UpdateRule)
 			r.With(middleware.RequirePermission(dep.RBACService, dep.PermissionResolver, "feature_flags:write"), routePolicy(RoutePolicyAdminWrite, nil)).Delete("/feature-flags/{id}/rules/{rule_id}", dep.FeatureFlagHandler.DeleteRule)
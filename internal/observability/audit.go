@@ -1,6 +1,7 @@
 package observability
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -108,9 +109,40 @@ func errInvalidAuditEvent(msg string) error {
 }
 
 func EmitAudit(r *http.Request, in AuditInput, attrs ...any) {
-	ev := BuildAuditEvent(r, in)
+	emitAuditEvent(r.Context(), BuildAuditEvent(r, in), attrs...)
+}
+
+// BuildSystemAuditEvent builds an audit event for work that is not tied to an
+// inbound request, such as background jobs. The actor defaults to "system".
+func BuildSystemAuditEvent(ctx context.Context, in AuditInput) AuditEvent {
+	traceID, spanID := "", ""
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		traceID, spanID = sc.TraceID().String(), sc.SpanID().String()
+	}
+	return AuditEvent{
+		EventName:    strings.TrimSpace(in.EventName),
+		EventVersion: auditEventVersion,
+		ActorUserID:  defaultString(strings.TrimSpace(in.ActorUserID), "system"),
+		ActorIP:      "internal",
+		TargetType:   defaultString(strings.TrimSpace(in.TargetType), "none"),
+		TargetID:     defaultString(strings.TrimSpace(in.TargetID), "none"),
+		Action:       defaultString(strings.TrimSpace(in.Action), "unknown"),
+		Outcome:      defaultString(strings.TrimSpace(in.Outcome), "unknown"),
+		Reason:       defaultString(strings.TrimSpace(in.Reason), "none"),
+		RequestID:    "system",
+		TraceID:      traceID,
+		SpanID:       spanID,
+		TS:           time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+func EmitSystemAudit(ctx context.Context, in AuditInput, attrs ...any) {
+	emitAuditEvent(ctx, BuildSystemAuditEvent(ctx, in), attrs...)
+}
+
+func emitAuditEvent(ctx context.Context, ev AuditEvent, attrs ...any) {
 	if err := ev.Validate(); err != nil {
-		slog.ErrorContext(r.Context(), "audit.schema.invalid",
+		slog.ErrorContext(ctx, "audit.schema.invalid",
 			"error", err.Error(),
 			"event_name", ev.EventName,
 			"request_id", ev.RequestID,
@@ -133,7 +165,7 @@ func EmitAudit(r *http.Request, in AuditInput, attrs ...any) {
 		"ts", ev.TS,
 	}
 	base = append(base, attrs...)
-	slog.InfoContext(r.Context(), "audit.event", base...)
+	slog.InfoContext(ctx, "audit.event", base...)
 }
 
 func ActorUserID(userID uint) string {
@@ -1,6 +1,7 @@
 package observability
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -55,3 +56,20 @@ func TestAuditEventValidateRejectsMissingEventName(t *testing.T) {
 		t.Fatal("expected validation error for missing event_name")
 	}
 }
+
+func TestBuildSystemAuditEventDefaultsActorAndValidates(t *testing.T) {
+	ev := BuildSystemAuditEvent(context.Background(), AuditInput{
+		EventName:  "rbac.role_grant.expire",
+		TargetType: "user",
+		TargetID:   "7",
+		Action:     "expire",
+		Outcome:    "success",
+		Reason:     "valid_until_elapsed",
+	})
+	if ev.ActorUserID != "system" || ev.ActorIP != "internal" || ev.RequestID != "system" {
+		t.Fatalf("unexpected system audit defaults: %+v", ev)
+	}
+	if err := ev.Validate(); err != nil {
+		t.Fatalf("expected valid event, got %v", err)
+	}
+}
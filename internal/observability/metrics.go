@@ -62,6 +62,9 @@ type AppMetrics struct {
 	featureFlagEvalCounter       metric.Int64Counter
 	featureFlagEvalDuration      metric.Float64Histogram
 	featureFlagCacheCounter      metric.Int64Counter
+	featureFlagChangeCounter     metric.Int64Counter
+	featureFlagScheduleCounter   metric.Int64Counter
+	featureFlagExposureCounter   metric.Int64Counter
 	productOperationCounter      metric.Int64Counter
 	productOperationDuration     metric.Float64Histogram
 }
@@ -335,6 +338,18 @@ func InitMetrics(ctx context.Context, cfg *config.Config, logger *slog.Logger) (
 	if err != nil {
 		return nil, err
 	}
+	featureFlagChangeCounter, err := meter.Int64Counter("feature_flag.change.events")
+	if err != nil {
+		return nil, err
+	}
+	featureFlagScheduleCounter, err := meter.Int64Counter("feature_flag.schedule.steps")
+	if err != nil {
+		return nil, err
+	}
+	featureFlagExposureCounter, err := meter.Int64Counter("feature_flag.exposure.records")
+	if err != nil {
+		return nil, err
+	}
 	productOperationCounter, err := meter.Int64Counter("product.operation.events")
 	if err != nil {
 		return nil, err
@@ -393,6 +408,9 @@ func InitMetrics(ctx context.Context, cfg *config.Config, logger *slog.Logger) (
 		featureFlagEvalCounter:       featureFlagEvalCounter,
 		featureFlagEvalDuration:      featureFlagEvalDuration,
 		featureFlagCacheCounter:      featureFlagCacheCounter,
+		featureFlagChangeCounter:     featureFlagChangeCounter,
+		featureFlagScheduleCounter:   featureFlagScheduleCounter,
+		featureFlagExposureCounter:   featureFlagExposureCounter,
 		productOperationCounter:      productOperationCounter,
 		productOperationDuration:     productOperationDuration,
 	}
@@ -947,6 +965,44 @@ func RecordFeatureFlagEvaluationCache(ctx context.Context, outcome string) {
 	))
 }
 
+func RecordFeatureFlagChangePublish(ctx context.Context, changeType, outcome string) {
+	metricsMu.RLock()
+	m := appMetrics
+	metricsMu.RUnlock()
+	if m == nil {
+		return
+	}
+	m.featureFlagChangeCounter.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("type", changeType),
+		attribute.String("outcome", outcome),
+	))
+}
+
+func RecordFeatureFlagScheduleStep(ctx context.Context, outcome string) {
+	metricsMu.RLock()
+	m := appMetrics
+	metricsMu.RUnlock()
+	if m == nil {
+		return
+	}
+	m.featureFlagScheduleCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("outcome", outcome)))
+}
+
+// RecordFeatureFlagExposureRecords counts buffered evaluation records by
+// kind (stat or exposure) and outcome (flushed, dropped or error).
+func RecordFeatureFlagExposureRecords(ctx context.Context, kind, outcome string, count int) {
+	metricsMu.RLock()
+	m := appMetrics
+	metricsMu.RUnlock()
+	if m == nil || count <= 0 {
+		return
+	}
+	m.featureFlagExposureCounter.Add(ctx, int64(count), metric.WithAttributes(
+		attribute.String("kind", kind),
+		attribute.String("outcome", outcome),
+	))
+}
+
 func RecordProductOperation(ctx context.Context, operation, outcome string, duration time.Duration) {
 	metricsMu.RLock()
 	m := appMetrics
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+)
+
+var ErrCartNotFound = errors.New("cart not found")
+
+type CartRepository interface {
+	FindByUser(userID uint) (*domain.Cart, error)
+	FindByGuestTokenHash(hash string) (*domain.Cart, error)
+	Create(cart *domain.Cart) error
+	// SetItem sets the quantity of productID in the cart, adding the line if
+	// it is missing. A quantity of 0 removes the line.
+	SetItem(cartID, productID uint, quantity int64) error
+	Clear(cartID uint) error
+	// MergeGuest moves the guest cart's items into userID's cart, adding up
+	// quantities of products found in both, and deletes the guest cart. When
+	// the user has no cart yet, the guest cart becomes theirs. Merged
+	// quantities are capped at maxQuantity, and guest products that would
+	// take the cart past maxItems lines are dropped.
+	MergeGuest(guestCartID, userID uint, maxItems int, maxQuantity int64) error
+}
+
+type GormCartRepository struct{ db *gorm.DB }
+
+func NewCartRepository(db *gorm.DB) CartRepository {
+	return &GormCartRepository{db: db}
+}
+
+func (r *GormCartRepository) FindByUser(userID uint) (*domain.Cart, error) {
+	return r.find("find_by_user", "user_id = ?", userID)
+}
+
+func (r *GormCartRepository) FindByGuestTokenHash(hash string) (*domain.Cart, error) {
+	return r.find("find_by_guest_token", "guest_token_hash = ?", hash)
+}
+
+func (r *GormCartRepository) find(op, query string, arg any) (*domain.Cart, error) {
+	var cart domain.Cart
+	err := r.db.Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id asc") }).
+		Where(query, arg).Take(&cart).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			observability.RecordRepositoryOperation(context.Background(), "cart", op, "not_found")
+			return nil, ErrCartNotFound
+		}
+		observability.RecordRepositoryOperation(context.Background(), "cart", op, "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "cart", op, "success")
+	return &cart, nil
+}
+
+func (r *GormCartRepository) Create(cart *domain.Cart) error {
+	err := r.db.Omit("Items").Create(cart).Error
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "cart", "create", "error")
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "cart", "create", "success")
+	return nil
+}
+
+func (r *GormCartRepository) SetItem(cartID, productID uint, quantity int64) error {
+	now := time.Now().UTC()
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if quantity == 0 {
+			if err := tx.Where("cart_id = ? AND product_id = ?", cartID, productID).Delete(&domain.CartItem{}).Error; err != nil {
+				return err
+			}
+		} else {
+			item := domain.CartItem{CartID: cartID, ProductID: productID, Quantity: quantity, CreatedAt: now, UpdatedAt: now}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "cart_id"}, {Name: "product_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"quantity", "updated_at"}),
+			}).Create(&item).Error; err != nil {
+				return err
+			}
+		}
+		return touchCart(tx, cartID, now)
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "cart", "set_item", cartOutcome(err))
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "cart", "set_item", "success")
+	return nil
+}
+
+func (r *GormCartRepository) Clear(cartID uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("cart_id = ?", cartID).Delete(&domain.CartItem{}).Error; err != nil {
+			return err
+		}
+		return touchCart(tx, cartID, time.Now().UTC())
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "cart", "clear", cartOutcome(err))
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "cart", "clear", "success")
+	return nil
+}
+
+func (r *GormCartRepository) MergeGuest(guestCartID, userID uint, maxItems int, maxQuantity int64) error {
+	now := time.Now().UTC()
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var guest domain.Cart
+		if err := tx.Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id asc") }).
+			Where("id = ? AND user_id IS NULL", guestCartID).Take(&guest).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCartNotFound
+			}
+			return err
+		}
+		var owned domain.Cart
+		err := tx.Preload("Items").Where("user_id = ?", userID).Take(&owned).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Model(&domain.Cart{}).Where("id = ?", guest.ID).
+				Updates(map[string]any{"user_id": userID, "guest_token_hash": nil, "updated_at": now}).Error
+		}
+		if err != nil {
+			return err
+		}
+		quantities := make(map[uint]int64, len(owned.Items))
+		for _, item := range owned.Items {
+			quantities[item.ProductID] = item.Quantity
+		}
+		for _, item := range guest.Items {
+			current, held := quantities[item.ProductID]
+			if !held && len(quantities) >= maxItems {
+				continue
+			}
+			quantity := min(current+item.Quantity, maxQuantity)
+			quantities[item.ProductID] = quantity
+			merged := domain.CartItem{CartID: owned.ID, ProductID: item.ProductID, Quantity: quantity, CreatedAt: now, UpdatedAt: now}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "cart_id"}, {Name: "product_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"quantity", "updated_at"}),
+			}).Create(&merged).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("cart_id = ?", guest.ID).Delete(&domain.CartItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&domain.Cart{}, guest.ID).Error; err != nil {
+			return err
+		}
+		return touchCart(tx, owned.ID, now)
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "cart", "merge_guest", cartOutcome(err))
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "cart", "merge_guest", "success")
+	return nil
+}
+
+func touchCart(tx *gorm.DB, cartID uint, now time.Time) error {
+	res := tx.Model(&domain.Cart{}).Where("id = ?", cartID).Update("updated_at", now)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrCartNotFound
+	}
+	return nil
+}
+
+func cartOutcome(err error) string {
+	if errors.Is(err, ErrCartNotFound) {
+		return "not_found"
+	}
+	return "error"
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+)
+
+func newCartDBForTest(t *testing.T) CartRepository {
+	t.Helper()
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.Cart{}, &domain.CartItem{}); err != nil {
+		t.Fatalf("migrate carts: %v", err)
+	}
+	return NewCartRepository(db)
+}
+
+func TestCartRepositorySetItemAndClear(t *testing.T) {
+	repo := newCartDBForTest(t)
+	userID := uint(1)
+	cart := &domain.Cart{UserID: &userID}
+	if err := repo.Create(cart); err != nil {
+		t.Fatalf("create cart: %v", err)
+	}
+	if err := repo.SetItem(cart.ID, 10, 2); err != nil {
+		t.Fatalf("set item: %v", err)
+	}
+	if err := repo.SetItem(cart.ID, 10, 5); err != nil {
+		t.Fatalf("update item: %v", err)
+	}
+	if err := repo.SetItem(cart.ID, 11, 1); err != nil {
+		t.Fatalf("set second item: %v", err)
+	}
+	if err := repo.SetItem(cart.ID, 11, 0); err != nil {
+		t.Fatalf("remove item: %v", err)
+	}
+	loaded, err := repo.FindByUser(userID)
+	if err != nil || len(loaded.Items) != 1 || loaded.Items[0].ProductID != 10 || loaded.Items[0].Quantity != 5 {
+		t.Fatalf("unexpected cart: %+v err=%v", loaded, err)
+	}
+	if err := repo.Clear(cart.ID); err != nil {
+		t.Fatalf("clear: %v", err)
+	}
+	if loaded, _ = repo.FindByUser(userID); len(loaded.Items) != 0 {
+		t.Fatalf("expected empty cart, got %+v", loaded.Items)
+	}
+	if err := repo.SetItem(999, 10, 1); !errors.Is(err, ErrCartNotFound) {
+		t.Fatalf("expected ErrCartNotFound, got %v", err)
+	}
+}
+
+func TestCartRepositoryMergeGuest(t *testing.T) {
+	repo := newCartDBForTest(t)
+	userID := uint(1)
+	owned := &domain.Cart{UserID: &userID}
+	hash := "guest-hash"
+	guest := &domain.Cart{GuestTokenHash: &hash}
+	for _, c := range []*domain.Cart{owned, guest} {
+		if err := repo.Create(c); err != nil {
+			t.Fatalf("create cart: %v", err)
+		}
+	}
+	_ = repo.SetItem(owned.ID, 10, 1)
+	_ = repo.SetItem(guest.ID, 10, 2)
+	_ = repo.SetItem(guest.ID, 11, 3)
+
+	if err := repo.MergeGuest(guest.ID, userID, 100, 1000); err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	merged, err := repo.FindByUser(userID)
+	if err != nil || len(merged.Items) != 2 || merged.Items[0].Quantity != 3 || merged.Items[1].Quantity != 3 {
+		t.Fatalf("unexpected merged cart: %+v err=%v", merged, err)
+	}
+	if _, err := repo.FindByGuestTokenHash(hash); !errors.Is(err, ErrCartNotFound) {
+		t.Fatalf("expected guest cart removed, got %v", err)
+	}
+
+	// Without a cart of their own, the user adopts the guest cart.
+	newUser := uint(2)
+	other := "other-hash"
+	adopted := &domain.Cart{GuestTokenHash: &other}
+	_ = repo.Create(adopted)
+	_ = repo.SetItem(adopted.ID, 12, 1)
+	if err := repo.MergeGuest(adopted.ID, newUser, 100, 1000); err != nil {
+		t.Fatalf("adopt: %v", err)
+	}
+	loaded, err := repo.FindByUser(newUser)
+	if err != nil || loaded.ID != adopted.ID || loaded.GuestTokenHash != nil || len(loaded.Items) != 1 {
+		t.Fatalf("expected adopted guest cart, got %+v err=%v", loaded, err)
+	}
+}
+
+func TestCartRepositoryMergeGuestKeepsLimits(t *testing.T) {
+	repo := newCartDBForTest(t)
+	userID := uint(1)
+	owned := &domain.Cart{UserID: &userID}
+	hash := "guest-hash"
+	guest := &domain.Cart{GuestTokenHash: &hash}
+	for _, c := range []*domain.Cart{owned, guest} {
+		if err := repo.Create(c); err != nil {
+			t.Fatalf("create cart: %v", err)
+		}
+	}
+	_ = repo.SetItem(owned.ID, 10, 8)
+	_ = repo.SetItem(guest.ID, 10, 5)
+	_ = repo.SetItem(guest.ID, 11, 1)
+	_ = repo.SetItem(guest.ID, 12, 1)
+
+	if err := repo.MergeGuest(guest.ID, userID, 2, 10); err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	merged, err := repo.FindByUser(userID)
+	if err != nil || len(merged.Items) != 2 {
+		t.Fatalf("expected two merged lines, got %+v err=%v", merged, err)
+	}
+	if merged.Items[0].ProductID != 10 || merged.Items[0].Quantity != 10 || merged.Items[1].ProductID != 11 {
+		t.Fatalf("expected capped quantity and first guest product kept, got %+v", merged.Items)
+	}
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+)
+
+var (
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrCategoryInUse    = errors.New("category still has subcategories or products")
+)
+
+type CategoryRepository interface {
+	Create(category *domain.Category) error
+	FindByID(id uint) (*domain.Category, error)
+	// List returns every category ordered by name. Callers build the tree
+	// from ParentID.
+	List() ([]domain.Category, error)
+	Update(category *domain.Category) error
+	// DeleteByID removes a category that has no subcategories and is not
+	// referenced by any product, including trashed ones. ErrCategoryInUse is
+	// returned otherwise.
+	DeleteByID(id uint) error
+}
+
+type GormCategoryRepository struct{ db *gorm.DB }
+
+func NewCategoryRepository(db *gorm.DB) CategoryRepository {
+	return &GormCategoryRepository{db: db}
+}
+
+func (r *GormCategoryRepository) Create(category *domain.Category) error {
+	if err := r.db.Create(category).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "category", "create", "error")
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "category", "create", "success")
+	return nil
+}
+
+func (r *GormCategoryRepository) FindByID(id uint) (*domain.Category, error) {
+	var category domain.Category
+	if err := r.db.First(&category, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			observability.RecordRepositoryOperation(context.Background(), "category", "find_by_id", "not_found")
+			return nil, ErrCategoryNotFound
+		}
+		observability.RecordRepositoryOperation(context.Background(), "category", "find_by_id", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "category", "find_by_id", "success")
+	return &category, nil
+}
+
+func (r *GormCategoryRepository) List() ([]domain.Category, error) {
+	var categories []domain.Category
+	if err := r.db.Order("name asc").Order("id asc").Find(&categories).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "category", "list", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "category", "list", "success")
+	return categories, nil
+}
+
+func (r *GormCategoryRepository) Update(category *domain.Category) error {
+	res := r.db.Model(&domain.Category{ID: category.ID}).
+		Select("ParentID", "Name", "Slug", "Description", "Attributes").
+		Updates(category)
+	if res.Error != nil {
+		observability.RecordRepositoryOperation(context.Background(), "category", "update", "error")
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		observability.RecordRepositoryOperation(context.Background(), "category", "update", "not_found")
+		return ErrCategoryNotFound
+	}
+	observability.RecordRepositoryOperation(context.Background(), "category", "update", "success")
+	return nil
+}
+
+func (r *GormCategoryRepository) DeleteByID(id uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var children int64
+		if err := tx.Model(&domain.Category{}).Where("parent_id = ?", id).Count(&children).Error; err != nil {
+			return err
+		}
+		var products int64
+		if err := tx.Unscoped().Model(&domain.Product{}).Where("category_id = ?", id).Count(&products).Error; err != nil {
+			return err
+		}
+		if children > 0 || products > 0 {
+			return ErrCategoryInUse
+		}
+		res := tx.Delete(&domain.Category{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrCategoryNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrCategoryNotFound):
+			observability.RecordRepositoryOperation(context.Background(), "category", "delete_by_id", "not_found")
+		case errors.Is(err, ErrCategoryInUse):
+			observability.RecordRepositoryOperation(context.Background(), "category", "delete_by_id", "conflict")
+		default:
+			observability.RecordRepositoryOperation(context.Background(), "category", "delete_by_id", "error")
+		}
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "category", "delete_by_id", "success")
+	return nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+)
+
+func TestCategoryRepositoryCRUDAndProductFilters(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.Category{}, &domain.Product{}, &domain.ProductGrant{}, &domain.ProductTag{}, &domain.ProductPrice{}, &domain.ProductImage{}, &domain.ProductStock{}, &domain.StockReservation{}, &domain.StockMovement{}, &domain.CartItem{}); err != nil {
+		t.Fatalf("migrate categories: %v", err)
+	}
+	repo := NewCategoryRepository(db)
+	products := NewProductRepository(db)
+
+	root := &domain.Category{Name: "Electronics", Slug: "electronics", Attributes: []domain.CategoryAttribute{
+		{Key: "brand", Type: domain.CategoryAttributeString, Required: true},
+	}}
+	if err := repo.Create(root); err != nil {
+		t.Fatalf("create root: %v", err)
+	}
+	laptops := &domain.Category{Name: "Laptops", Slug: "laptops", ParentID: &root.ID}
+	books := &domain.Category{Name: "Books", Slug: "books"}
+	for _, c := range []*domain.Category{laptops, books} {
+		if err := repo.Create(c); err != nil {
+			t.Fatalf("create category %s: %v", c.Slug, err)
+		}
+	}
+	if err := repo.Create(&domain.Category{Name: "Dup", Slug: "books"}); err == nil {
+		t.Fatal("expected duplicate slug to fail")
+	}
+
+	loaded, err := repo.FindByID(root.ID)
+	if err != nil {
+		t.Fatalf("find root: %v", err)
+	}
+	if len(loaded.Attributes) != 1 || loaded.Attributes[0].Key != "brand" || !loaded.Attributes[0].Required {
+		t.Fatalf("attribute schema not persisted: %+v", loaded.Attributes)
+	}
+
+	laptops.Description = "Portable computers"
+	laptops.Attributes = []domain.CategoryAttribute{{Key: "ram_gb", Type: domain.CategoryAttributeNumber}}
+	if err := repo.Update(laptops); err != nil {
+		t.Fatalf("update laptops: %v", err)
+	}
+	if err := repo.Update(&domain.Category{ID: 999, Name: "x", Slug: "x"}); !errors.Is(err, ErrCategoryNotFound) {
+		t.Fatalf("expected ErrCategoryNotFound, got %v", err)
+	}
+	all, err := repo.List()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(all) != 3 || all[0].Slug != "books" || all[2].Slug != "laptops" || all[2].Description != "Portable computers" {
+		t.Fatalf("unexpected categories: %+v", all)
+	}
+
+	laptop := &domain.Product{Name: "Laptop", PriceMinor: 999, CategoryID: &laptops.ID, Tags: []string{"sale", "new"},
+		Attributes: domain.ProductAttributes{"brand": "Acme", "ram_gb": 16.0}}
+	novel := &domain.Product{Name: "Novel", PriceMinor: 12, CategoryID: &books.ID, Tags: []string{"sale"}}
+	for _, p := range []*domain.Product{laptop, novel} {
+		if err := products.Create(p); err != nil {
+			t.Fatalf("create product %s: %v", p.Name, err)
+		}
+	}
+	found, err := products.FindByID(laptop.ID)
+	if err != nil {
+		t.Fatalf("find laptop: %v", err)
+	}
+	if len(found.Tags) != 2 || found.Tags[0] != "new" || found.Attributes["brand"] != "Acme" || found.Attributes["ram_gb"] != 16.0 {
+		t.Fatalf("tags or attributes not persisted: %+v", found)
+	}
+
+	subtree, err := products.ListPaged(ProductListQuery{CategoryID: root.ID})
+	if err != nil {
+		t.Fatalf("list by category: %v", err)
+	}
+	if subtree.Total != 1 || subtree.Items[0].ID != laptop.ID {
+		t.Fatalf("expected only the laptop in the electronics subtree, got %+v", subtree.Items)
+	}
+	tagged, err := products.ListPaged(ProductListQuery{Tag: "SALE"})
+	if err != nil {
+		t.Fatalf("list by tag: %v", err)
+	}
+	if tagged.Total != 2 {
+		t.Fatalf("expected two sale products, got %d", tagged.Total)
+	}
+
+	if err := products.Update(novel.ID, 0, map[string]any{"tags": []string{"classic"}}); err != nil {
+		t.Fatalf("replace tags: %v", err)
+	}
+	tagged, err = products.ListPaged(ProductListQuery{Tag: "sale"})
+	if err != nil {
+		t.Fatalf("list by tag after update: %v", err)
+	}
+	if tagged.Total != 1 || tagged.Items[0].ID != laptop.ID {
+		t.Fatalf("expected tag replacement to drop the novel, got %+v", tagged.Items)
+	}
+
+	if err := repo.DeleteByID(root.ID); !errors.Is(err, ErrCategoryInUse) {
+		t.Fatalf("expected ErrCategoryInUse for parent category, got %v", err)
+	}
+	if err := products.DeleteByID(novel.ID, 0); err != nil {
+		t.Fatalf("trash novel: %v", err)
+	}
+	if err := repo.DeleteByID(books.ID); !errors.Is(err, ErrCategoryInUse) {
+		t.Fatalf("expected trashed products to keep the category in use, got %v", err)
+	}
+	if err := products.Purge(novel.ID); err != nil {
+		t.Fatalf("purge novel: %v", err)
+	}
+	if err := repo.DeleteByID(books.ID); err != nil {
+		t.Fatalf("delete books: %v", err)
+	}
+	if err := repo.DeleteByID(books.ID); !errors.Is(err, ErrCategoryNotFound) {
+		t.Fatalf("expected ErrCategoryNotFound, got %v", err)
+	}
+	var tagCount int64
+	if err := db.Model(&domain.ProductTag{}).Where("product_id = ?", novel.ID).Count(&tagCount).Error; err != nil || tagCount != 0 {
+		t.Fatalf("expected purge to remove tags, count=%d err=%v", tagCount, err)
+	}
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+)
+
+type FeatureFlagExposureRepository interface {
+	// RecordEvaluations adds each stat's Count to the stored row for its flag
+	// key, variant and source, keeping the later LastEvaluatedAt, and inserts
+	// the exposures, all in one transaction.
+	RecordEvaluations(stats []domain.FeatureFlagEvaluationStat, exposures []domain.FeatureFlagExposure) error
+	// ListEvaluationStats returns the stats of flagKey, or of every flag when
+	// flagKey is empty.
+	ListEvaluationStats(flagKey string) ([]domain.FeatureFlagEvaluationStat, error)
+	// ListExposures returns exposures of flagKey newest first, starting below
+	// beforeID when it is set.
+	ListExposures(flagKey string, beforeID uint, limit int) ([]domain.FeatureFlagExposure, error)
+	DeleteExposuresBefore(cutoff time.Time, limit int) (int64, error)
+}
+
+type GormFeatureFlagExposureRepository struct{ db *gorm.DB }
+
+func NewFeatureFlagExposureRepository(db *gorm.DB) FeatureFlagExposureRepository {
+	return &GormFeatureFlagExposureRepository{db: db}
+}
+
+func (r *GormFeatureFlagExposureRepository) RecordEvaluations(stats []domain.FeatureFlagEvaluationStat, exposures []domain.FeatureFlagExposure) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if len(stats) > 0 {
+			err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "flag_key"}, {Name: "variant"}, {Name: "source"}},
+				DoUpdates: clause.Assignments(map[string]any{
+					"count": gorm.Expr("feature_flag_evaluation_stats.count + excluded.count"),
+					"last_evaluated_at": gorm.Expr("CASE WHEN excluded.last_evaluated_at > feature_flag_evaluation_stats.last_evaluated_at " +
+						"THEN excluded.last_evaluated_at ELSE feature_flag_evaluation_stats.last_evaluated_at END"),
+				}),
+			}).CreateInBatches(stats, 100).Error
+			if err != nil {
+				return err
+			}
+		}
+		if len(exposures) > 0 {
+			return tx.CreateInBatches(exposures, 100).Error
+		}
+		return nil
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "feature_flag_exposure", "record", "error")
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "feature_flag_exposure", "record", "success")
+	return nil
+}
+
+func (r *GormFeatureFlagExposureRepository) ListEvaluationStats(flagKey string) ([]domain.FeatureFlagEvaluationStat, error) {
+	var stats []domain.FeatureFlagEvaluationStat
+	query := r.db.Order("flag_key asc").Order("variant asc").Order("source asc")
+	if flagKey != "" {
+		query = query.Where("flag_key = ?", flagKey)
+	}
+	if err := query.Find(&stats).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "feature_flag_exposure", "list_stats", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "feature_flag_exposure", "list_stats", "success")
+	return stats, nil
+}
+
+func (r *GormFeatureFlagExposureRepository) ListExposures(flagKey string, beforeID uint, limit int) ([]domain.FeatureFlagExposure, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	var exposures []domain.FeatureFlagExposure
+	query := r.db.Where("flag_key = ?", flagKey)
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	if err := query.Order("id desc").Limit(limit).Find(&exposures).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "feature_flag_exposure", "list", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "feature_flag_exposure", "list", "success")
+	return exposures, nil
+}
+
+func (r *GormFeatureFlagExposureRepository) DeleteExposuresBefore(cutoff time.Time, limit int) (int64, error) {
+	if limit <= 0 {
+		limit = 1000
+	}
+	sub := r.db.Model(&domain.FeatureFlagExposure{}).
+		Select("id").
+		Where("evaluated_at < ?", cutoff).
+		Order("id asc").
+		Limit(limit)
+	res := r.db.Where("id IN (?)", sub).Delete(&domain.FeatureFlagExposure{})
+	if res.Error != nil {
+		observability.RecordRepositoryOperation(context.Background(), "feature_flag_exposure", "delete_expired", "error")
+		return 0, res.Error
+	}
+	observability.RecordRepositoryOperation(context.Background(), "feature_flag_exposure", "delete_expired", "success")
+	return res.RowsAffected, nil
+}
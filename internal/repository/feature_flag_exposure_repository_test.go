@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+)
+
+func TestFeatureFlagExposureRepositoryAccumulatesStats(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.FeatureFlagEvaluationStat{}, &domain.FeatureFlagExposure{}); err != nil {
+		t.Fatalf("migrate exposures: %v", err)
+	}
+	repo := NewFeatureFlagExposureRepository(db)
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	first := []domain.FeatureFlagEvaluationStat{
+		{FlagKey: "checkout", Source: "default", Count: 3, LastEvaluatedAt: base},
+		{FlagKey: "checkout", Source: "rule:user", Count: 1, LastEvaluatedAt: base},
+	}
+	if err := repo.RecordEvaluations(first, []domain.FeatureFlagExposure{
+		{FlagKey: "checkout", UserID: 7, Enabled: true, Source: "rule:user", EvaluatedAt: base},
+	}); err != nil {
+		t.Fatalf("record first batch: %v", err)
+	}
+	second := []domain.FeatureFlagEvaluationStat{
+		{FlagKey: "checkout", Source: "default", Count: 2, LastEvaluatedAt: base.Add(time.Hour)},
+		{FlagKey: "checkout", Source: "rule:user", Count: 4, LastEvaluatedAt: base.Add(-time.Hour)},
+	}
+	if err := repo.RecordEvaluations(second, nil); err != nil {
+		t.Fatalf("record second batch: %v", err)
+	}
+
+	stats, err := repo.ListEvaluationStats("checkout")
+	if err != nil || len(stats) != 2 {
+		t.Fatalf("expected two stats rows, got %+v err=%v", stats, err)
+	}
+	if stats[0].Source != "default" || stats[0].Count != 5 || !stats[0].LastEvaluatedAt.Equal(base.Add(time.Hour)) {
+		t.Fatalf("unexpected default stat %+v", stats[0])
+	}
+	if stats[1].Count != 5 || !stats[1].LastEvaluatedAt.Equal(base) {
+		t.Fatalf("expected an older batch not to move last_evaluated_at back, got %+v", stats[1])
+	}
+	if all, _ := repo.ListEvaluationStats(""); len(all) != 2 {
+		t.Fatalf("expected every stat without a key filter, got %d", len(all))
+	}
+}
+
+func TestFeatureFlagExposureRepositoryListAndPrune(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.FeatureFlagEvaluationStat{}, &domain.FeatureFlagExposure{}); err != nil {
+		t.Fatalf("migrate exposures: %v", err)
+	}
+	repo := NewFeatureFlagExposureRepository(db)
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	exposures := make([]domain.FeatureFlagExposure, 0, 5)
+	for i := 0; i < 5; i++ {
+		exposures = append(exposures, domain.FeatureFlagExposure{
+			FlagKey: "checkout", UserID: uint(i + 1), Variant: "treatment", Enabled: true, Source: "default",
+			EvaluatedAt: base.Add(time.Duration(i) * time.Hour),
+		})
+	}
+	exposures = append(exposures, domain.FeatureFlagExposure{FlagKey: "other", UserID: 1, Source: "default", EvaluatedAt: base})
+	if err := repo.RecordEvaluations(nil, exposures); err != nil {
+		t.Fatalf("record exposures: %v", err)
+	}
+
+	page, err := repo.ListExposures("checkout", 0, 2)
+	if err != nil || len(page) != 2 || page[0].UserID != 5 || page[1].UserID != 4 {
+		t.Fatalf("expected newest two exposures, got %+v err=%v", page, err)
+	}
+	next, err := repo.ListExposures("checkout", page[1].ID, 10)
+	if err != nil || len(next) != 3 || next[0].UserID != 3 {
+		t.Fatalf("expected remaining exposures below the cursor, got %+v err=%v", next, err)
+	}
+
+	deleted, err := repo.DeleteExposuresBefore(base.Add(2*time.Hour), 10)
+	if err != nil || deleted != 3 {
+		t.Fatalf("expected three expired exposures deleted, got %d err=%v", deleted, err)
+	}
+	if remaining, _ := repo.ListExposures("checkout", 0, 10); len(remaining) != 3 {
+		t.Fatalf("expected three checkout exposures to remain, got %d", len(remaining))
+	}
+}
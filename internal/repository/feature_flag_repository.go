@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"strings"
 
@@ -12,34 +13,58 @@ import (
 )
 
 var (
-	ErrFeatureFlagNotFound     = errors.New("feature flag not found")
-	ErrFeatureFlagRuleNotFound = errors.New("feature flag rule not found")
+	ErrFeatureFlagNotFound        = errors.New("feature flag not found")
+	ErrFeatureFlagRuleNotFound    = errors.New("feature flag rule not found")
+	ErrFeatureFlagVersionNotFound = errors.New("feature flag version not found")
+	ErrFeatureFlagHasDependents   = errors.New("feature flag is a prerequisite of other flags")
 )
 
 type FeatureFlagRepository interface {
 	ListFlags() ([]domain.FeatureFlag, error)
 	FindFlagByID(id uint) (*domain.FeatureFlag, error)
 	FindFlagByKey(key string) (*domain.FeatureFlag, error)
-	CreateFlag(flag *domain.FeatureFlag) error
-	UpdateFlag(flag *domain.FeatureFlag) error
-	DeleteFlag(id uint) error
+	CreateFlag(flag *domain.FeatureFlag, version FeatureFlagVersionFunc) error
+	UpdateFlag(flag *domain.FeatureFlag, version FeatureFlagVersionFunc) error
+	DeleteFlag(id uint, version FeatureFlagVersionFunc) error
 	ListRules(flagID uint) ([]domain.FeatureFlagRule, error)
-	CreateRule(rule *domain.FeatureFlagRule) error
-	UpdateRule(rule *domain.FeatureFlagRule) error
-	DeleteRule(flagID, ruleID uint) error
+	CreateRule(rule *domain.FeatureFlagRule, version FeatureFlagVersionFunc) error
+	UpdateRule(rule *domain.FeatureFlagRule, version FeatureFlagVersionFunc) error
+	DeleteRule(flagID, ruleID uint, version FeatureFlagVersionFunc) error
+	AppendVersion(version *domain.FeatureFlagVersion) error
+	ListVersions(flagID uint, limit int) ([]domain.FeatureFlagVersion, error)
+	FindVersion(flagID uint, version int) (*domain.FeatureFlagVersion, error)
+	RestoreFlag(flag *domain.FeatureFlag, version *domain.FeatureFlagVersion) error
 }
 
+// FeatureFlagVersionFunc builds the history entry of a flag mutation from
+// the flag as it stands after the change, nil once it is deleted. Mutations
+// store the entry in their own transaction, so a change is never committed
+// without its version; a nil func records nothing.
+type FeatureFlagVersionFunc func(after *domain.FeatureFlag) (*domain.FeatureFlagVersion, error)
+
 type GormFeatureFlagRepository struct{ db *gorm.DB }
 
 func NewFeatureFlagRepository(db *gorm.DB) FeatureFlagRepository {
 	return &GormFeatureFlagRepository{db: db}
 }
 
+func (r *GormFeatureFlagRepository) withAssociations() *gorm.DB {
+	return featureFlagWithAssociations(r.db)
+}
+
+func featureFlagWithAssociations(db *gorm.DB) *gorm.DB {
+	return db.Preload("Rules", func(db *gorm.DB) *gorm.DB {
+		return db.Order("priority asc").Order("id asc")
+	}).Preload("Variants", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id asc")
+	}).Preload("Prerequisites", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id asc")
+	})
+}
+
 func (r *GormFeatureFlagRepository) ListFlags() ([]domain.FeatureFlag, error) {
 	var flags []domain.FeatureFlag
-	err := r.db.Preload("Rules", func(db *gorm.DB) *gorm.DB {
-		return db.Order("priority asc").Order("id asc")
-	}).Order("key asc").Find(&flags).Error
+	err := r.withAssociations().Order("key asc").Find(&flags).Error
 	if err != nil {
 		observability.RecordRepositoryOperation(context.Background(), "feature_flag", "list", "error")
 		return nil, err
@@ -50,9 +75,7 @@ func (r *GormFeatureFlagRepository) ListFlags() ([]domain.FeatureFlag, error) {
 
 func (r *GormFeatureFlagRepository) FindFlagByID(id uint) (*domain.FeatureFlag, error) {
 	var flag domain.FeatureFlag
-	err := r.db.Preload("Rules", func(db *gorm.DB) *gorm.DB {
-		return db.Order("priority asc").Order("id asc")
-	}).First(&flag, id).Error
+	err := r.withAssociations().First(&flag, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			observability.RecordRepositoryOperation(context.Background(), "feature_flag", "find_by_id", "not_found")
@@ -67,9 +90,7 @@ func (r *GormFeatureFlagRepository) FindFlagByID(id uint) (*domain.FeatureFlag,
 
 func (r *GormFeatureFlagRepository) FindFlagByKey(key string) (*domain.FeatureFlag, error) {
 	var flag domain.FeatureFlag
-	err := r.db.Preload("Rules", func(db *gorm.DB) *gorm.DB {
-		return db.Order("priority asc").Order("id asc")
-	}).Where("key = ?", strings.TrimSpace(strings.ToLower(key))).First(&flag).Error
+	err := r.withAssociations().Where("key = ?", strings.TrimSpace(strings.ToLower(key))).First(&flag).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			observability.RecordRepositoryOperation(context.Background(), "feature_flag", "find_by_key", "not_found")
@@ -82,8 +103,14 @@ func (r *GormFeatureFlagRepository) FindFlagByKey(key string) (*domain.FeatureFl
 	return &flag, nil
 }
 
-func (r *GormFeatureFlagRepository) CreateFlag(flag *domain.FeatureFlag) error {
-	if err := r.db.Create(flag).Error; err != nil {
+func (r *GormFeatureFlagRepository) CreateFlag(flag *domain.FeatureFlag, version FeatureFlagVersionFunc) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(flag).Error; err != nil {
+			return err
+		}
+		return recordFeatureFlagVersion(tx, flag.ID, false, version)
+	})
+	if err != nil {
 		observability.RecordRepositoryOperation(context.Background(), "feature_flag", "create", "error")
 		return err
 	}
@@ -91,33 +118,85 @@ func (r *GormFeatureFlagRepository) CreateFlag(flag *domain.FeatureFlag) error {
 	return nil
 }
 
-func (r *GormFeatureFlagRepository) UpdateFlag(flag *domain.FeatureFlag) error {
-	res := r.db.Model(&domain.FeatureFlag{}).Where("id = ?", flag.ID).Updates(map[string]any{
-		"key":         strings.TrimSpace(strings.ToLower(flag.Key)),
-		"description": strings.TrimSpace(flag.Description),
-		"enabled":     flag.Enabled,
+// UpdateFlag replaces the flag's scalar fields and its full variant and
+// prerequisite sets.
+func (r *GormFeatureFlagRepository) UpdateFlag(flag *domain.FeatureFlag, version FeatureFlagVersionFunc) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&domain.FeatureFlag{}).Where("id = ?", flag.ID).Updates(map[string]any{
+			"key":             strings.TrimSpace(strings.ToLower(flag.Key)),
+			"description":     strings.TrimSpace(flag.Description),
+			"enabled":         flag.Enabled,
+			"type":            flag.Type,
+			"default_variant": flag.DefaultVariant,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrFeatureFlagNotFound
+		}
+		if err := tx.Where("feature_flag_id = ?", flag.ID).Delete(&domain.FeatureFlagVariant{}).Error; err != nil {
+			return err
+		}
+		for i := range flag.Variants {
+			flag.Variants[i].ID = 0
+			flag.Variants[i].FeatureFlagID = flag.ID
+		}
+		if len(flag.Variants) > 0 {
+			if err := tx.Create(&flag.Variants).Error; err != nil {
+				return err
+			}
+		}
+		if err := replaceFeatureFlagPrerequisites(tx, flag); err != nil {
+			return err
+		}
+		return recordFeatureFlagVersion(tx, flag.ID, false, version)
 	})
-	if res.Error != nil {
-		observability.RecordRepositoryOperation(context.Background(), "feature_flag", "update", "error")
-		return res.Error
-	}
-	if res.RowsAffected == 0 {
-		observability.RecordRepositoryOperation(context.Background(), "feature_flag", "update", "not_found")
-		return ErrFeatureFlagNotFound
+	if err != nil {
+		if errors.Is(err, ErrFeatureFlagNotFound) {
+			observability.RecordRepositoryOperation(context.Background(), "feature_flag", "update", "not_found")
+		} else {
+			observability.RecordRepositoryOperation(context.Background(), "feature_flag", "update", "error")
+		}
+		return err
 	}
 	observability.RecordRepositoryOperation(context.Background(), "feature_flag", "update", "success")
 	return nil
 }
 
-func (r *GormFeatureFlagRepository) DeleteFlag(id uint) error {
-	res := r.db.Delete(&domain.FeatureFlag{}, id)
-	if res.Error != nil {
-		observability.RecordRepositoryOperation(context.Background(), "feature_flag", "delete", "error")
-		return res.Error
-	}
-	if res.RowsAffected == 0 {
+// DeleteFlag removes the flag unless another flag lists it as a prerequisite,
+// in which case it returns ErrFeatureFlagHasDependents.
+func (r *GormFeatureFlagRepository) DeleteFlag(id uint, version FeatureFlagVersionFunc) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var dependents int64
+		if err := tx.Model(&domain.FeatureFlagPrerequisite{}).Where("prerequisite_flag_id = ?", id).Count(&dependents).Error; err != nil {
+			return err
+		}
+		if dependents > 0 {
+			return ErrFeatureFlagHasDependents
+		}
+		if err := tx.Where("feature_flag_id = ?", id).Delete(&domain.FeatureFlagPrerequisite{}).Error; err != nil {
+			return err
+		}
+		res := tx.Delete(&domain.FeatureFlag{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrFeatureFlagNotFound
+		}
+		return recordFeatureFlagVersion(tx, id, true, version)
+	})
+	switch {
+	case errors.Is(err, ErrFeatureFlagNotFound):
 		observability.RecordRepositoryOperation(context.Background(), "feature_flag", "delete", "not_found")
-		return ErrFeatureFlagNotFound
+		return err
+	case errors.Is(err, ErrFeatureFlagHasDependents):
+		observability.RecordRepositoryOperation(context.Background(), "feature_flag", "delete", "conflict")
+		return err
+	case err != nil:
+		observability.RecordRepositoryOperation(context.Background(), "feature_flag", "delete", "error")
+		return err
 	}
 	observability.RecordRepositoryOperation(context.Background(), "feature_flag", "delete", "success")
 	return nil
@@ -134,8 +213,14 @@ func (r *GormFeatureFlagRepository) ListRules(flagID uint) ([]domain.FeatureFlag
 	return rules, nil
 }
 
-func (r *GormFeatureFlagRepository) CreateRule(rule *domain.FeatureFlagRule) error {
-	if err := r.db.Create(rule).Error; err != nil {
+func (r *GormFeatureFlagRepository) CreateRule(rule *domain.FeatureFlagRule, version FeatureFlagVersionFunc) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(rule).Error; err != nil {
+			return err
+		}
+		return recordFeatureFlagVersion(tx, rule.FeatureFlagID, false, version)
+	})
+	if err != nil {
 		observability.RecordRepositoryOperation(context.Background(), "feature_flag_rule", "create", "error")
 		return err
 	}
@@ -143,38 +228,211 @@ func (r *GormFeatureFlagRepository) CreateRule(rule *domain.FeatureFlagRule) err
 	return nil
 }
 
-func (r *GormFeatureFlagRepository) UpdateRule(rule *domain.FeatureFlagRule) error {
-	res := r.db.Model(&domain.FeatureFlagRule{}).
-		Where("id = ? AND feature_flag_id = ?", rule.ID, rule.FeatureFlagID).
-		Updates(map[string]any{
-			"type":        strings.TrimSpace(strings.ToLower(rule.Type)),
-			"match_value": strings.TrimSpace(strings.ToLower(rule.MatchValue)),
-			"percentage":  rule.Percentage,
-			"enabled":     rule.Enabled,
-			"priority":    rule.Priority,
-		})
-	if res.Error != nil {
+func (r *GormFeatureFlagRepository) UpdateRule(rule *domain.FeatureFlagRule, version FeatureFlagVersionFunc) error {
+	// Map updates bypass the field serializer, so clauses are encoded here.
+	clauses, err := json.Marshal(rule.Clauses)
+	if err != nil {
 		observability.RecordRepositoryOperation(context.Background(), "feature_flag_rule", "update", "error")
-		return res.Error
+		return err
 	}
-	if res.RowsAffected == 0 {
-		observability.RecordRepositoryOperation(context.Background(), "feature_flag_rule", "update", "not_found")
-		return ErrFeatureFlagRuleNotFound
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&domain.FeatureFlagRule{}).
+			Where("id = ? AND feature_flag_id = ?", rule.ID, rule.FeatureFlagID).
+			Updates(map[string]any{
+				"type":            strings.TrimSpace(strings.ToLower(rule.Type)),
+				"match_value":     strings.TrimSpace(strings.ToLower(rule.MatchValue)),
+				"percentage":      rule.Percentage,
+				"enabled":         rule.Enabled,
+				"priority":        rule.Priority,
+				"variant":         rule.Variant,
+				"clause_operator": rule.ClauseOperator,
+				"clauses":         string(clauses),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrFeatureFlagRuleNotFound
+		}
+		return recordFeatureFlagVersion(tx, rule.FeatureFlagID, false, version)
+	})
+	if err != nil {
+		if errors.Is(err, ErrFeatureFlagRuleNotFound) {
+			observability.RecordRepositoryOperation(context.Background(), "feature_flag_rule", "update", "not_found")
+		} else {
+			observability.RecordRepositoryOperation(context.Background(), "feature_flag_rule", "update", "error")
+		}
+		return err
 	}
 	observability.RecordRepositoryOperation(context.Background(), "feature_flag_rule", "update", "success")
 	return nil
 }
 
-func (r *GormFeatureFlagRepository) DeleteRule(flagID, ruleID uint) error {
-	res := r.db.Where("id = ? AND feature_flag_id = ?", ruleID, flagID).Delete(&domain.FeatureFlagRule{})
-	if res.Error != nil {
-		observability.RecordRepositoryOperation(context.Background(), "feature_flag_rule", "delete", "error")
-		return res.Error
-	}
-	if res.RowsAffected == 0 {
-		observability.RecordRepositoryOperation(context.Background(), "feature_flag_rule", "delete", "not_found")
-		return ErrFeatureFlagRuleNotFound
+func (r *GormFeatureFlagRepository) DeleteRule(flagID, ruleID uint, version FeatureFlagVersionFunc) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND feature_flag_id = ?", ruleID, flagID).Delete(&domain.FeatureFlagRule{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrFeatureFlagRuleNotFound
+		}
+		return recordFeatureFlagVersion(tx, flagID, false, version)
+	})
+	if err != nil {
+		if errors.Is(err, ErrFeatureFlagRuleNotFound) {
+			observability.RecordRepositoryOperation(context.Background(), "feature_flag_rule", "delete", "not_found")
+		} else {
+			observability.RecordRepositoryOperation(context.Background(), "feature_flag_rule", "delete", "error")
+		}
+		return err
 	}
 	observability.RecordRepositoryOperation(context.Background(), "feature_flag_rule", "delete", "success")
 	return nil
 }
+
+// AppendVersion stores a history entry under the flag's next version number.
+func (r *GormFeatureFlagRepository) AppendVersion(version *domain.FeatureFlagVersion) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return appendFeatureFlagVersion(tx, version)
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "feature_flag_version", "create", "error")
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "feature_flag_version", "create", "success")
+	return nil
+}
+
+// ListVersions returns the flag's history newest first.
+func (r *GormFeatureFlagRepository) ListVersions(flagID uint, limit int) ([]domain.FeatureFlagVersion, error) {
+	var versions []domain.FeatureFlagVersion
+	err := r.db.Where("feature_flag_id = ?", flagID).Order("version desc").Limit(limit).Find(&versions).Error
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "feature_flag_version", "list", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "feature_flag_version", "list", "success")
+	return versions, nil
+}
+
+func (r *GormFeatureFlagRepository) FindVersion(flagID uint, version int) (*domain.FeatureFlagVersion, error) {
+	var record domain.FeatureFlagVersion
+	err := r.db.Where("feature_flag_id = ? AND version = ?", flagID, version).First(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			observability.RecordRepositoryOperation(context.Background(), "feature_flag_version", "find", "not_found")
+			return nil, ErrFeatureFlagVersionNotFound
+		}
+		observability.RecordRepositoryOperation(context.Background(), "feature_flag_version", "find", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "feature_flag_version", "find", "success")
+	return &record, nil
+}
+
+// RestoreFlag overwrites the flag's fields, variants, rules and prerequisites
+// with flag and records version in the same transaction. Rules keep their
+// original IDs so history diffs stay comparable across the rollback.
+func (r *GormFeatureFlagRepository) RestoreFlag(flag *domain.FeatureFlag, version *domain.FeatureFlagVersion) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&domain.FeatureFlag{}).Where("id = ?", flag.ID).Updates(map[string]any{
+			"key":             flag.Key,
+			"description":     flag.Description,
+			"enabled":         flag.Enabled,
+			"type":            flag.Type,
+			"default_variant": flag.DefaultVariant,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrFeatureFlagNotFound
+		}
+		if err := tx.Where("feature_flag_id = ?", flag.ID).Delete(&domain.FeatureFlagVariant{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("feature_flag_id = ?", flag.ID).Delete(&domain.FeatureFlagRule{}).Error; err != nil {
+			return err
+		}
+		for i := range flag.Variants {
+			flag.Variants[i].ID = 0
+			flag.Variants[i].FeatureFlagID = flag.ID
+		}
+		if len(flag.Variants) > 0 {
+			if err := tx.Create(&flag.Variants).Error; err != nil {
+				return err
+			}
+		}
+		for i := range flag.Rules {
+			flag.Rules[i].FeatureFlagID = flag.ID
+		}
+		if len(flag.Rules) > 0 {
+			if err := tx.Create(&flag.Rules).Error; err != nil {
+				return err
+			}
+		}
+		if err := replaceFeatureFlagPrerequisites(tx, flag); err != nil {
+			return err
+		}
+		return appendFeatureFlagVersion(tx, version)
+	})
+	if err != nil {
+		if errors.Is(err, ErrFeatureFlagNotFound) {
+			observability.RecordRepositoryOperation(context.Background(), "feature_flag", "restore", "not_found")
+		} else {
+			observability.RecordRepositoryOperation(context.Background(), "feature_flag", "restore", "error")
+		}
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "feature_flag", "restore", "success")
+	return nil
+}
+
+// recordFeatureFlagVersion appends the entry built by version for flagID
+// inside tx, passing the reloaded flag unless it was deleted.
+func recordFeatureFlagVersion(tx *gorm.DB, flagID uint, deleted bool, version FeatureFlagVersionFunc) error {
+	if version == nil {
+		return nil
+	}
+	var after *domain.FeatureFlag
+	if !deleted {
+		var loaded domain.FeatureFlag
+		if err := featureFlagWithAssociations(tx).First(&loaded, flagID).Error; err != nil {
+			return err
+		}
+		after = &loaded
+	}
+	record, err := version(after)
+	if err != nil {
+		return err
+	}
+	return appendFeatureFlagVersion(tx, record)
+}
+
+func appendFeatureFlagVersion(tx *gorm.DB, version *domain.FeatureFlagVersion) error {
+	var latest int
+	if err := tx.Model(&domain.FeatureFlagVersion{}).
+		Where("feature_flag_id = ?", version.FeatureFlagID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&latest).Error; err != nil {
+		return err
+	}
+	version.ID = 0
+	version.Version = latest + 1
+	return tx.Create(version).Error
+}
+
+func replaceFeatureFlagPrerequisites(tx *gorm.DB, flag *domain.FeatureFlag) error {
+	if err := tx.Where("feature_flag_id = ?", flag.ID).Delete(&domain.FeatureFlagPrerequisite{}).Error; err != nil {
+		return err
+	}
+	for i := range flag.Prerequisites {
+		flag.Prerequisites[i].ID = 0
+		flag.Prerequisites[i].FeatureFlagID = flag.ID
+	}
+	if len(flag.Prerequisites) == 0 {
+		return nil
+	}
+	return tx.Create(&flag.Prerequisites).Error
+}
@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+)
+
+func TestFeatureFlagRepositoryVariantsRoundTrip(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.FeatureFlag{}, &domain.FeatureFlagRule{}, &domain.FeatureFlagVariant{}, &domain.FeatureFlagPrerequisite{}); err != nil {
+		t.Fatalf("migrate feature flags: %v", err)
+	}
+	repo := NewFeatureFlagRepository(db)
+
+	flag := &domain.FeatureFlag{
+		Key:            "checkout_copy",
+		Enabled:        true,
+		Type:           domain.FeatureFlagTypeString,
+		DefaultVariant: "control",
+		Variants: []domain.FeatureFlagVariant{
+			{Key: "control", Value: json.RawMessage(`"Buy now"`), Weight: 50},
+			{Key: "urgent", Value: json.RawMessage(`"Hurry"`), Weight: 50},
+		},
+	}
+	if err := repo.CreateFlag(flag, nil); err != nil {
+		t.Fatalf("create flag: %v", err)
+	}
+	if err := repo.CreateRule(&domain.FeatureFlagRule{FeatureFlagID: flag.ID, Type: "role", MatchValue: "admin", Enabled: true, Priority: 10, Variant: "urgent"}, nil); err != nil {
+		t.Fatalf("create rule: %v", err)
+	}
+
+	loaded, err := repo.FindFlagByKey("checkout_copy")
+	if err != nil {
+		t.Fatalf("find by key: %v", err)
+	}
+	if len(loaded.Variants) != 2 || string(loaded.Variants[1].Value) != `"Hurry"` {
+		t.Fatalf("unexpected variants: %+v", loaded.Variants)
+	}
+	if len(loaded.Rules) != 1 || loaded.Rules[0].Variant != "urgent" {
+		t.Fatalf("unexpected rules: %+v", loaded.Rules)
+	}
+
+	loaded.DefaultVariant = "calm"
+	loaded.Variants = []domain.FeatureFlagVariant{{Key: "calm", Value: json.RawMessage(`"Take your time"`)}}
+	if err := repo.UpdateFlag(loaded, nil); err != nil {
+		t.Fatalf("update flag: %v", err)
+	}
+	reloaded, err := repo.FindFlagByID(flag.ID)
+	if err != nil {
+		t.Fatalf("find by id: %v", err)
+	}
+	if reloaded.DefaultVariant != "calm" || len(reloaded.Variants) != 1 || reloaded.Variants[0].Key != "calm" {
+		t.Fatalf("expected variants replaced, got %+v", reloaded)
+	}
+
+	if err := repo.UpdateFlag(&domain.FeatureFlag{ID: 999, Key: "missing"}, nil); !errors.Is(err, ErrFeatureFlagNotFound) {
+		t.Fatalf("expected ErrFeatureFlagNotFound, got %v", err)
+	}
+}
+
+func TestFeatureFlagRepositoryRuleClausesRoundTrip(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.FeatureFlag{}, &domain.FeatureFlagRule{}, &domain.FeatureFlagVariant{}, &domain.FeatureFlagPrerequisite{}); err != nil {
+		t.Fatalf("migrate feature flags: %v", err)
+	}
+	repo := NewFeatureFlagRepository(db)
+
+	flag := &domain.FeatureFlag{Key: "pro_beta", Enabled: false, Type: domain.FeatureFlagTypeBoolean}
+	if err := repo.CreateFlag(flag, nil); err != nil {
+		t.Fatalf("create flag: %v", err)
+	}
+	rule := &domain.FeatureFlagRule{
+		FeatureFlagID:  flag.ID,
+		Type:           "attribute",
+		Enabled:        true,
+		Priority:       10,
+		ClauseOperator: "and",
+		Clauses: []domain.FeatureFlagClause{
+			{Attribute: "plan", Operator: "in", Values: []string{"pro", "team"}},
+			{Attribute: "country", Operator: "in", Values: []string{"cn"}, Negate: true},
+		},
+	}
+	if err := repo.CreateRule(rule, nil); err != nil {
+		t.Fatalf("create rule: %v", err)
+	}
+
+	rule.ClauseOperator = "or"
+	rule.Clauses = []domain.FeatureFlagClause{{Attribute: "app_version", Operator: "semver_gte", Values: []string{"2.1.0"}}}
+	if err := repo.UpdateRule(rule, nil); err != nil {
+		t.Fatalf("update rule: %v", err)
+	}
+	rules, err := repo.ListRules(flag.ID)
+	if err != nil {
+		t.Fatalf("list rules: %v", err)
+	}
+	if len(rules) != 1 || rules[0].ClauseOperator != "or" || len(rules[0].Clauses) != 1 {
+		t.Fatalf("unexpected rules after update: %+v", rules)
+	}
+	if got := rules[0].Clauses[0]; got.Attribute != "app_version" || got.Operator != "semver_gte" || got.Values[0] != "2.1.0" {
+		t.Fatalf("unexpected clause after update: %+v", got)
+	}
+}
+
+func TestFeatureFlagRepositoryVersionsAndRestore(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.FeatureFlag{}, &domain.FeatureFlagRule{}, &domain.FeatureFlagVariant{}, &domain.FeatureFlagPrerequisite{}, &domain.FeatureFlagVersion{}); err != nil {
+		t.Fatalf("migrate feature flags: %v", err)
+	}
+	repo := NewFeatureFlagRepository(db)
+
+	flag := &domain.FeatureFlag{Key: "new_checkout", Enabled: false, Type: domain.FeatureFlagTypeBoolean}
+	if err := repo.CreateFlag(flag, nil); err != nil {
+		t.Fatalf("create flag: %v", err)
+	}
+	rule := &domain.FeatureFlagRule{FeatureFlagID: flag.ID, Type: "percent", Percentage: 10, Enabled: true, Priority: 50}
+	if err := repo.CreateRule(rule, nil); err != nil {
+		t.Fatalf("create rule: %v", err)
+	}
+	good, err := repo.FindFlagByID(flag.ID)
+	if err != nil {
+		t.Fatalf("find flag: %v", err)
+	}
+	snapshot, _ := json.Marshal(good)
+	for i := 0; i < 2; i++ {
+		if err := repo.AppendVersion(&domain.FeatureFlagVersion{FeatureFlagID: flag.ID, Action: "flag.updated", Snapshot: snapshot}); err != nil {
+			t.Fatalf("append version: %v", err)
+		}
+	}
+
+	rule.Percentage = 100
+	if err := repo.UpdateRule(rule, nil); err != nil {
+		t.Fatalf("update rule: %v", err)
+	}
+	if err := repo.CreateRule(&domain.FeatureFlagRule{FeatureFlagID: flag.ID, Type: "role", MatchValue: "admin", Enabled: true, Priority: 10}, nil); err != nil {
+		t.Fatalf("create second rule: %v", err)
+	}
+
+	restore := &domain.FeatureFlagVersion{FeatureFlagID: flag.ID, Action: "flag.rolled_back", SourceVersion: 1, Snapshot: snapshot}
+	if err := repo.RestoreFlag(good, restore); err != nil {
+		t.Fatalf("restore flag: %v", err)
+	}
+	if restore.Version != 3 {
+		t.Fatalf("expected restore to be version 3, got %d", restore.Version)
+	}
+	restored, err := repo.FindFlagByID(flag.ID)
+	if err != nil {
+		t.Fatalf("reload flag: %v", err)
+	}
+	if len(restored.Rules) != 1 || restored.Rules[0].ID != rule.ID || restored.Rules[0].Percentage != 10 {
+		t.Fatalf("expected original rule restored, got %+v", restored.Rules)
+	}
+
+	versions, err := repo.ListVersions(flag.ID, 2)
+	if err != nil {
+		t.Fatalf("list versions: %v", err)
+	}
+	if len(versions) != 2 || versions[0].Version != 3 || versions[0].SourceVersion != 1 || versions[1].Version != 2 {
+		t.Fatalf("unexpected version list: %+v", versions)
+	}
+	if _, err := repo.FindVersion(flag.ID, 1); err != nil {
+		t.Fatalf("find version: %v", err)
+	}
+	if _, err := repo.FindVersion(flag.ID, 9); !errors.Is(err, ErrFeatureFlagVersionNotFound) {
+		t.Fatalf("expected ErrFeatureFlagVersionNotFound, got %v", err)
+	}
+	if err := repo.RestoreFlag(&domain.FeatureFlag{ID: 999, Key: "missing"}, &domain.FeatureFlagVersion{FeatureFlagID: 999, Snapshot: snapshot}); !errors.Is(err, ErrFeatureFlagNotFound) {
+		t.Fatalf("expected ErrFeatureFlagNotFound, got %v", err)
+	}
+}
+
+func TestFeatureFlagRepositoryMutationsCommitWithTheirVersion(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.FeatureFlag{}, &domain.FeatureFlagRule{}, &domain.FeatureFlagVariant{}, &domain.FeatureFlagPrerequisite{}, &domain.FeatureFlagVersion{}); err != nil {
+		t.Fatalf("migrate feature flags: %v", err)
+	}
+	repo := NewFeatureFlagRepository(db)
+
+	record := func(action string) FeatureFlagVersionFunc {
+		return func(after *domain.FeatureFlag) (*domain.FeatureFlagVersion, error) {
+			snapshot, err := json.Marshal(after)
+			if err != nil {
+				return nil, err
+			}
+			id := uint(0)
+			if after != nil {
+				id = after.ID
+			}
+			return &domain.FeatureFlagVersion{FeatureFlagID: id, Action: action, Snapshot: snapshot}, nil
+		}
+	}
+	flag := &domain.FeatureFlag{Key: "new_checkout", Type: domain.FeatureFlagTypeBoolean}
+	if err := repo.CreateFlag(flag, record("flag.created")); err != nil {
+		t.Fatalf("create flag: %v", err)
+	}
+	rule := &domain.FeatureFlagRule{FeatureFlagID: flag.ID, Type: "percent", Percentage: 10, Enabled: true, Priority: 50}
+	if err := repo.CreateRule(rule, func(after *domain.FeatureFlag) (*domain.FeatureFlagVersion, error) {
+		if len(after.Rules) != 1 || after.Rules[0].ID != rule.ID {
+			t.Fatalf("expected the new rule in the version snapshot, got %+v", after.Rules)
+		}
+		return record("rule.created")(after)
+	}); err != nil {
+		t.Fatalf("create rule: %v", err)
+	}
+
+	historyErr := errors.New("history unavailable")
+	failing := func(*domain.FeatureFlag) (*domain.FeatureFlagVersion, error) { return nil, historyErr }
+	enabled := *flag
+	enabled.Enabled = true
+	if err := repo.UpdateFlag(&enabled, failing); !errors.Is(err, historyErr) {
+		t.Fatalf("expected history error, got %v", err)
+	}
+	rule.Percentage = 100
+	if err := repo.UpdateRule(rule, failing); !errors.Is(err, historyErr) {
+		t.Fatalf("expected history error, got %v", err)
+	}
+	if err := repo.DeleteRule(flag.ID, rule.ID, failing); !errors.Is(err, historyErr) {
+		t.Fatalf("expected history error, got %v", err)
+	}
+	if err := repo.DeleteFlag(flag.ID, failing); !errors.Is(err, historyErr) {
+		t.Fatalf("expected history error, got %v", err)
+	}
+
+	loaded, err := repo.FindFlagByID(flag.ID)
+	if err != nil {
+		t.Fatalf("expected flag to survive the failed delete: %v", err)
+	}
+	if loaded.Enabled || len(loaded.Rules) != 1 || loaded.Rules[0].Percentage != 10 {
+		t.Fatalf("expected failed mutations to roll back, got %+v", loaded)
+	}
+	versions, err := repo.ListVersions(flag.ID, 10)
+	if err != nil {
+		t.Fatalf("list versions: %v", err)
+	}
+	if len(versions) != 2 || versions[0].Action != "rule.created" || versions[1].Action != "flag.created" {
+		t.Fatalf("expected one version per committed mutation, got %+v", versions)
+	}
+}
+
+func TestFeatureFlagRepositoryPrerequisitesBlockDelete(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.FeatureFlag{}, &domain.FeatureFlagRule{}, &domain.FeatureFlagVariant{}, &domain.FeatureFlagPrerequisite{}); err != nil {
+		t.Fatalf("migrate feature flags: %v", err)
+	}
+	repo := NewFeatureFlagRepository(db)
+
+	parent := &domain.FeatureFlag{Key: "new_checkout", Type: domain.FeatureFlagTypeBoolean}
+	if err := repo.CreateFlag(parent, nil); err != nil {
+		t.Fatalf("create parent: %v", err)
+	}
+	enabled := true
+	child := &domain.FeatureFlag{Key: "new_checkout_v2", Type: domain.FeatureFlagTypeBoolean, Prerequisites: []domain.FeatureFlagPrerequisite{
+		{PrerequisiteFlagID: parent.ID, Enabled: &enabled},
+	}}
+	if err := repo.CreateFlag(child, nil); err != nil {
+		t.Fatalf("create child: %v", err)
+	}
+
+	loaded, err := repo.FindFlagByKey("new_checkout_v2")
+	if err != nil {
+		t.Fatalf("find child: %v", err)
+	}
+	if len(loaded.Prerequisites) != 1 || loaded.Prerequisites[0].PrerequisiteFlagID != parent.ID || loaded.Prerequisites[0].Enabled == nil || !*loaded.Prerequisites[0].Enabled {
+		t.Fatalf("unexpected prerequisites: %+v", loaded.Prerequisites)
+	}
+	if err := repo.DeleteFlag(parent.ID, nil); !errors.Is(err, ErrFeatureFlagHasDependents) {
+		t.Fatalf("expected ErrFeatureFlagHasDependents, got %v", err)
+	}
+
+	loaded.Prerequisites = nil
+	if err := repo.UpdateFlag(loaded, nil); err != nil {
+		t.Fatalf("clear prerequisites: %v", err)
+	}
+	if err := repo.DeleteFlag(parent.ID, nil); err != nil {
+		t.Fatalf("delete parent once unreferenced: %v", err)
+	}
+	if err := repo.DeleteFlag(parent.ID, nil); !errors.Is(err, ErrFeatureFlagNotFound) {
+		t.Fatalf("expected ErrFeatureFlagNotFound, got %v", err)
+	}
+}
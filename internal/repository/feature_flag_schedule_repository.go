@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+)
+
+var (
+	ErrFeatureFlagScheduleNotFound       = errors.New("feature flag schedule not found")
+	ErrFeatureFlagScheduleStatusConflict = errors.New("feature flag schedule is not in the expected status")
+)
+
+type FeatureFlagScheduleRepository interface {
+	CreateSchedule(schedule *domain.FeatureFlagSchedule) error
+	FindSchedule(id uint) (*domain.FeatureFlagSchedule, error)
+	ListSchedules(flagID uint) ([]domain.FeatureFlagSchedule, error)
+	// TransitionSchedule moves a schedule into to if its status is one of
+	// from, failing with ErrFeatureFlagScheduleStatusConflict otherwise.
+	TransitionSchedule(id uint, from []string, to, reason string) error
+	// ListDueSteps returns steps of active schedules that are due at now and
+	// whose earlier steps have all been applied. Steps still "applying" with a
+	// claim older than staleBefore are returned again for recovery.
+	ListDueSteps(now, staleBefore time.Time, limit int) ([]domain.FeatureFlagScheduleStep, error)
+	// ClaimStep marks a due step as applying. It returns false when another
+	// replica holds a live claim, the step is finished, or its schedule is
+	// no longer active.
+	ClaimStep(stepID uint, now, staleBefore time.Time) (bool, error)
+	// FinishStep records the outcome of a claimed step. A failure also fails
+	// the schedule and applying the last step completes it, both only while
+	// the schedule is still active.
+	FinishStep(step *domain.FeatureFlagScheduleStep, at time.Time, failure string) error
+}
+
+type GormFeatureFlagScheduleRepository struct{ db *gorm.DB }
+
+func NewFeatureFlagScheduleRepository(db *gorm.DB) FeatureFlagScheduleRepository {
+	return &GormFeatureFlagScheduleRepository{db: db}
+}
+
+func (r *GormFeatureFlagScheduleRepository) withSteps() *gorm.DB {
+	return r.db.Preload("Steps", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position asc")
+	})
+}
+
+func (r *GormFeatureFlagScheduleRepository) CreateSchedule(schedule *domain.FeatureFlagSchedule) error {
+	if err := r.db.Create(schedule).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "feature_flag_schedule", "create", "error")
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "feature_flag_schedule", "create", "success")
+	return nil
+}
+
+func (r *GormFeatureFlagScheduleRepository) FindSchedule(id uint) (*domain.FeatureFlagSchedule, error) {
+	var schedule domain.FeatureFlagSchedule
+	if err := r.withSteps().First(&schedule, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			observability.RecordRepositoryOperation(context.Background(), "feature_flag_schedule", "find_by_id", "not_found")
+			return nil, ErrFeatureFlagScheduleNotFound
+		}
+		observability.RecordRepositoryOperation(context.Background(), "feature_flag_schedule", "find_by_id", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "feature_flag_schedule", "find_by_id", "success")
+	return &schedule, nil
+}
+
+func (r *GormFeatureFlagScheduleRepository) ListSchedules(flagID uint) ([]domain.FeatureFlagSchedule, error) {
+	var schedules []domain.FeatureFlagSchedule
+	if err := r.withSteps().Where("feature_flag_id = ?", flagID).Order("id desc").Find(&schedules).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "feature_flag_schedule", "list", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "feature_flag_schedule", "list", "success")
+	return schedules, nil
+}
+
+func (r *GormFeatureFlagScheduleRepository) TransitionSchedule(id uint, from []string, to, reason string) error {
+	res := r.db.Model(&domain.FeatureFlagSchedule{}).
+		Where("id = ? AND status IN ?", id, from).
+		Updates(map[string]any{"status": to, "status_reason": truncateScheduleReason(reason)})
+	if res.Error != nil {
+		observability.RecordRepositoryOperation(context.Background(), "feature_flag_schedule", "transition", "error")
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		observability.RecordRepositoryOperation(context.Background(), "feature_flag_schedule", "transition", "conflict")
+		return ErrFeatureFlagScheduleStatusConflict
+	}
+	observability.RecordRepositoryOperation(context.Background(), "feature_flag_schedule", "transition", "success")
+	return nil
+}
+
+func (r *GormFeatureFlagScheduleRepository) ListDueSteps(now, staleBefore time.Time, limit int) ([]domain.FeatureFlagScheduleStep, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	var steps []domain.FeatureFlagScheduleStep
+	err := r.db.Table("feature_flag_schedule_steps AS s").
+		Select("s.*").
+		Joins("JOIN feature_flag_schedules AS sc ON sc.id = s.schedule_id").
+		Where("sc.status = ?", domain.FeatureFlagScheduleStatusActive).
+		Where("s.run_at <= ?", now).
+		Where("s.status = ? OR (s.status = ? AND s.claimed_at <= ?)", domain.FeatureFlagScheduleStepPending, domain.FeatureFlagScheduleStepApplying, staleBefore).
+		Where("NOT EXISTS (SELECT 1 FROM feature_flag_schedule_steps AS prev WHERE prev.schedule_id = s.schedule_id AND prev.position < s.position AND prev.status <> ?)", domain.FeatureFlagScheduleStepApplied).
+		Order("s.run_at asc").
+		Order("s.id asc").
+		Limit(limit).
+		Find(&steps).Error
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "feature_flag_schedule", "list_due_steps", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "feature_flag_schedule", "list_due_steps", "success")
+	return steps, nil
+}
+
+func (r *GormFeatureFlagScheduleRepository) ClaimStep(stepID uint, now, staleBefore time.Time) (bool, error) {
+	res := r.db.Model(&domain.FeatureFlagScheduleStep{}).
+		Where("id = ?", stepID).
+		Where("status = ? OR (status = ? AND claimed_at <= ?)", domain.FeatureFlagScheduleStepPending, domain.FeatureFlagScheduleStepApplying, staleBefore).
+		Where("EXISTS (SELECT 1 FROM feature_flag_schedules AS sc WHERE sc.id = feature_flag_schedule_steps.schedule_id AND sc.status = ?)", domain.FeatureFlagScheduleStatusActive).
+		Updates(map[string]any{"status": domain.FeatureFlagScheduleStepApplying, "claimed_at": now})
+	if res.Error != nil {
+		observability.RecordRepositoryOperation(context.Background(), "feature_flag_schedule", "claim_step", "error")
+		return false, res.Error
+	}
+	if res.RowsAffected == 0 {
+		observability.RecordRepositoryOperation(context.Background(), "feature_flag_schedule", "claim_step", "not_found")
+		return false, nil
+	}
+	observability.RecordRepositoryOperation(context.Background(), "feature_flag_schedule", "claim_step", "success")
+	return true, nil
+}
+
+func (r *GormFeatureFlagScheduleRepository) FinishStep(step *domain.FeatureFlagScheduleStep, at time.Time, failure string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]any{"status": domain.FeatureFlagScheduleStepApplied, "applied_at": at, "error": ""}
+		if failure != "" {
+			updates = map[string]any{"status": domain.FeatureFlagScheduleStepFailed, "error": truncateScheduleReason(failure)}
+		}
+		if err := tx.Model(&domain.FeatureFlagScheduleStep{}).Where("id = ?", step.ID).Updates(updates).Error; err != nil {
+			return err
+		}
+		if failure != "" {
+			return tx.Model(&domain.FeatureFlagSchedule{}).
+				Where("id = ? AND status = ?", step.ScheduleID, domain.FeatureFlagScheduleStatusActive).
+				Updates(map[string]any{"status": domain.FeatureFlagScheduleStatusFailed, "status_reason": truncateScheduleReason(failure)}).Error
+		}
+		var remaining int64
+		if err := tx.Model(&domain.FeatureFlagScheduleStep{}).
+			Where("schedule_id = ? AND status <> ?", step.ScheduleID, domain.FeatureFlagScheduleStepApplied).
+			Count(&remaining).Error; err != nil {
+			return err
+		}
+		if remaining > 0 {
+			return nil
+		}
+		return tx.Model(&domain.FeatureFlagSchedule{}).
+			Where("id = ? AND status = ?", step.ScheduleID, domain.FeatureFlagScheduleStatusActive).
+			Update("status", domain.FeatureFlagScheduleStatusCompleted).Error
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "feature_flag_schedule", "finish_step", "error")
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "feature_flag_schedule", "finish_step", "success")
+	return nil
+}
+
+func truncateScheduleReason(reason string) string {
+	if len(reason) > 255 {
+		return reason[:255]
+	}
+	return reason
+}
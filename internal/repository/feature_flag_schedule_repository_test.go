@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+)
+
+func TestFeatureFlagScheduleRepositoryStepLifecycle(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.FeatureFlagSchedule{}, &domain.FeatureFlagScheduleStep{}); err != nil {
+		t.Fatalf("migrate schedules: %v", err)
+	}
+	repo := NewFeatureFlagScheduleRepository(db)
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	pct := func(v int) *int { return &v }
+	schedule := &domain.FeatureFlagSchedule{
+		FeatureFlagID: 7,
+		RuleID:        3,
+		Status:        domain.FeatureFlagScheduleStatusActive,
+		Steps: []domain.FeatureFlagScheduleStep{
+			{Position: 1, RunAt: base, Percentage: pct(25), Status: domain.FeatureFlagScheduleStepPending},
+			{Position: 2, RunAt: base.Add(time.Hour), Percentage: pct(100), Status: domain.FeatureFlagScheduleStepPending},
+		},
+	}
+	if err := repo.CreateSchedule(schedule); err != nil {
+		t.Fatalf("create schedule: %v", err)
+	}
+
+	now := base.Add(2 * time.Hour)
+	stale := now.Add(-5 * time.Minute)
+	due, err := repo.ListDueSteps(now, stale, 10)
+	if err != nil {
+		t.Fatalf("list due: %v", err)
+	}
+	if len(due) != 1 || due[0].Position != 1 {
+		t.Fatalf("expected only the first step due while it is pending, got %+v", due)
+	}
+	claimed, err := repo.ClaimStep(due[0].ID, now, stale)
+	if err != nil || !claimed {
+		t.Fatalf("expected first claim to win, claimed=%v err=%v", claimed, err)
+	}
+	if claimed, _ := repo.ClaimStep(due[0].ID, now, stale); claimed {
+		t.Fatal("expected second claim on a live lease to lose")
+	}
+	if claimed, _ := repo.ClaimStep(due[0].ID, now.Add(10*time.Minute), now.Add(5*time.Minute)); !claimed {
+		t.Fatal("expected an abandoned claim to be reclaimable after the lease")
+	}
+	if err := repo.FinishStep(&due[0], now, ""); err != nil {
+		t.Fatalf("finish first step: %v", err)
+	}
+
+	due, err = repo.ListDueSteps(now, stale, 10)
+	if err != nil || len(due) != 1 || due[0].Position != 2 {
+		t.Fatalf("expected second step due after the first applied, got %+v err=%v", due, err)
+	}
+	if err := repo.TransitionSchedule(schedule.ID, []string{domain.FeatureFlagScheduleStatusActive}, domain.FeatureFlagScheduleStatusPaused, "guardrail"); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	if due, _ := repo.ListDueSteps(now, stale, 10); len(due) != 0 {
+		t.Fatalf("expected no due steps for a paused schedule, got %+v", due)
+	}
+	if err := repo.TransitionSchedule(schedule.ID, []string{domain.FeatureFlagScheduleStatusActive}, domain.FeatureFlagScheduleStatusPaused, ""); !errors.Is(err, ErrFeatureFlagScheduleStatusConflict) {
+		t.Fatalf("expected status conflict, got %v", err)
+	}
+	if err := repo.TransitionSchedule(schedule.ID, []string{domain.FeatureFlagScheduleStatusPaused}, domain.FeatureFlagScheduleStatusActive, ""); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+
+	step := due[0]
+	if claimed, err := repo.ClaimStep(step.ID, now, stale); err != nil || !claimed {
+		t.Fatalf("claim second step: claimed=%v err=%v", claimed, err)
+	}
+	if err := repo.FinishStep(&step, now, ""); err != nil {
+		t.Fatalf("finish second step: %v", err)
+	}
+	loaded, err := repo.FindSchedule(schedule.ID)
+	if err != nil {
+		t.Fatalf("find schedule: %v", err)
+	}
+	if loaded.Status != domain.FeatureFlagScheduleStatusCompleted || loaded.Steps[1].AppliedAt == nil {
+		t.Fatalf("expected completed schedule, got %+v", loaded)
+	}
+	if _, err := repo.FindSchedule(999); !errors.Is(err, ErrFeatureFlagScheduleNotFound) {
+		t.Fatalf("expected ErrFeatureFlagScheduleNotFound, got %v", err)
+	}
+}
+
+func TestFeatureFlagScheduleRepositoryFailedStepFailsSchedule(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.FeatureFlagSchedule{}, &domain.FeatureFlagScheduleStep{}); err != nil {
+		t.Fatalf("migrate schedules: %v", err)
+	}
+	repo := NewFeatureFlagScheduleRepository(db)
+
+	enabled := true
+	runAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	schedule := &domain.FeatureFlagSchedule{
+		FeatureFlagID: 7,
+		Status:        domain.FeatureFlagScheduleStatusActive,
+		Steps:         []domain.FeatureFlagScheduleStep{{Position: 1, RunAt: runAt, Enabled: &enabled, Status: domain.FeatureFlagScheduleStepPending}},
+	}
+	if err := repo.CreateSchedule(schedule); err != nil {
+		t.Fatalf("create schedule: %v", err)
+	}
+	step := schedule.Steps[0]
+	if err := repo.FinishStep(&step, runAt, "feature flag not found"); err != nil {
+		t.Fatalf("finish step: %v", err)
+	}
+	schedules, err := repo.ListSchedules(7)
+	if err != nil || len(schedules) != 1 {
+		t.Fatalf("list schedules: %+v err=%v", schedules, err)
+	}
+	if schedules[0].Status != domain.FeatureFlagScheduleStatusFailed || schedules[0].StatusReason != "feature flag not found" || schedules[0].Steps[0].Status != domain.FeatureFlagScheduleStepFailed {
+		t.Fatalf("expected failed schedule and step, got %+v", schedules[0])
+	}
+}
+
+func TestFeatureFlagScheduleRepositoryInactiveScheduleKeepsItsStatus(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.FeatureFlagSchedule{}, &domain.FeatureFlagScheduleStep{}); err != nil {
+		t.Fatalf("migrate schedules: %v", err)
+	}
+	repo := NewFeatureFlagScheduleRepository(db)
+
+	enabled := true
+	runAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	schedule := &domain.FeatureFlagSchedule{
+		FeatureFlagID: 8,
+		Status:        domain.FeatureFlagScheduleStatusActive,
+		Steps: []domain.FeatureFlagScheduleStep{
+			{Position: 1, RunAt: runAt, Enabled: &enabled, Status: domain.FeatureFlagScheduleStepPending},
+			{Position: 2, RunAt: runAt, Enabled: &enabled, Status: domain.FeatureFlagScheduleStepPending},
+		},
+	}
+	if err := repo.CreateSchedule(schedule); err != nil {
+		t.Fatalf("create schedule: %v", err)
+	}
+	claimed, err := repo.ClaimStep(schedule.Steps[0].ID, runAt, runAt.Add(-time.Minute))
+	if err != nil || !claimed {
+		t.Fatalf("expected claim while active, claimed=%v err=%v", claimed, err)
+	}
+	if err := repo.TransitionSchedule(schedule.ID, []string{domain.FeatureFlagScheduleStatusActive}, domain.FeatureFlagScheduleStatusCancelled, "cancelled"); err != nil {
+		t.Fatalf("cancel schedule: %v", err)
+	}
+
+	claimed, err = repo.ClaimStep(schedule.Steps[1].ID, runAt, runAt.Add(-time.Minute))
+	if err != nil || claimed {
+		t.Fatalf("expected no claim on a cancelled schedule, claimed=%v err=%v", claimed, err)
+	}
+	step := schedule.Steps[0]
+	if err := repo.FinishStep(&step, runAt, "feature flag not found"); err != nil {
+		t.Fatalf("finish step: %v", err)
+	}
+	loaded, err := repo.FindSchedule(schedule.ID)
+	if err != nil {
+		t.Fatalf("find schedule: %v", err)
+	}
+	if loaded.Status != domain.FeatureFlagScheduleStatusCancelled || loaded.StatusReason != "cancelled" {
+		t.Fatalf("expected cancelled schedule to stay cancelled, got %+v", loaded)
+	}
+}
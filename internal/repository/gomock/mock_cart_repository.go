@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/cart_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source internal/repository/cart_repository.go -destination internal/repository/gomock/mock_cart_repository.go -package gomock
+//
+
+// Package gomock is a generated GoMock package.
+package gomock
+
+import (
+	reflect "reflect"
+
+	domain "github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCartRepository is a mock of CartRepository interface.
+type MockCartRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCartRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCartRepositoryMockRecorder is the mock recorder for MockCartRepository.
+type MockCartRepositoryMockRecorder struct {
+	mock *MockCartRepository
+}
+
+// NewMockCartRepository creates a new mock instance.
+func NewMockCartRepository(ctrl *gomock.Controller) *MockCartRepository {
+	mock := &MockCartRepository{ctrl: ctrl}
+	mock.recorder = &MockCartRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCartRepository) EXPECT() *MockCartRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Clear mocks base method.
+func (m *MockCartRepository) Clear(cartID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", cartID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockCartRepositoryMockRecorder) Clear(cartID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockCartRepository)(nil).Clear), cartID)
+}
+
+// Create mocks base method.
+func (m *MockCartRepository) Create(cart *domain.Cart) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", cart)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCartRepositoryMockRecorder) Create(cart any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCartRepository)(nil).Create), cart)
+}
+
+// FindByGuestTokenHash mocks base method.
+func (m *MockCartRepository) FindByGuestTokenHash(hash string) (*domain.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByGuestTokenHash", hash)
+	ret0, _ := ret[0].(*domain.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByGuestTokenHash indicates an expected call of FindByGuestTokenHash.
+func (mr *MockCartRepositoryMockRecorder) FindByGuestTokenHash(hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByGuestTokenHash", reflect.TypeOf((*MockCartRepository)(nil).FindByGuestTokenHash), hash)
+}
+
+// FindByUser mocks base method.
+func (m *MockCartRepository) FindByUser(userID uint) (*domain.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUser", userID)
+	ret0, _ := ret[0].(*domain.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUser indicates an expected call of FindByUser.
+func (mr *MockCartRepositoryMockRecorder) FindByUser(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUser", reflect.TypeOf((*MockCartRepository)(nil).FindByUser), userID)
+}
+
+// MergeGuest mocks base method.
+func (m *MockCartRepository) MergeGuest(guestCartID, userID uint, maxItems int, maxQuantity int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeGuest", guestCartID, userID, maxItems, maxQuantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeGuest indicates an expected call of MergeGuest.
+func (mr *MockCartRepositoryMockRecorder) MergeGuest(guestCartID, userID, maxItems, maxQuantity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeGuest", reflect.TypeOf((*MockCartRepository)(nil).MergeGuest), guestCartID, userID, maxItems, maxQuantity)
+}
+
+// SetItem mocks base method.
+func (m *MockCartRepository) SetItem(cartID, productID uint, quantity int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItem", cartID, productID, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetItem indicates an expected call of SetItem.
+func (mr *MockCartRepositoryMockRecorder) SetItem(cartID, productID, quantity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItem", reflect.TypeOf((*MockCartRepository)(nil).SetItem), cartID, productID, quantity)
+}
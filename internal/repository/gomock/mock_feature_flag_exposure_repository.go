@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/feature_flag_exposure_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source internal/repository/feature_flag_exposure_repository.go -destination internal/repository/gomock/mock_feature_flag_exposure_repository.go -package gomock
+//
+
+// Package gomock is a generated GoMock package.
+package gomock
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeatureFlagExposureRepository is a mock of FeatureFlagExposureRepository interface.
+type MockFeatureFlagExposureRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeatureFlagExposureRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockFeatureFlagExposureRepositoryMockRecorder is the mock recorder for MockFeatureFlagExposureRepository.
+type MockFeatureFlagExposureRepositoryMockRecorder struct {
+	mock *MockFeatureFlagExposureRepository
+}
+
+// NewMockFeatureFlagExposureRepository creates a new mock instance.
+func NewMockFeatureFlagExposureRepository(ctrl *gomock.Controller) *MockFeatureFlagExposureRepository {
+	mock := &MockFeatureFlagExposureRepository{ctrl: ctrl}
+	mock.recorder = &MockFeatureFlagExposureRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeatureFlagExposureRepository) EXPECT() *MockFeatureFlagExposureRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteExposuresBefore mocks base method.
+func (m *MockFeatureFlagExposureRepository) DeleteExposuresBefore(cutoff time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExposuresBefore", cutoff, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExposuresBefore indicates an expected call of DeleteExposuresBefore.
+func (mr *MockFeatureFlagExposureRepositoryMockRecorder) DeleteExposuresBefore(cutoff, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExposuresBefore", reflect.TypeOf((*MockFeatureFlagExposureRepository)(nil).DeleteExposuresBefore), cutoff, limit)
+}
+
+// ListEvaluationStats mocks base method.
+func (m *MockFeatureFlagExposureRepository) ListEvaluationStats(flagKey string) ([]domain.FeatureFlagEvaluationStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvaluationStats", flagKey)
+	ret0, _ := ret[0].([]domain.FeatureFlagEvaluationStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvaluationStats indicates an expected call of ListEvaluationStats.
+func (mr *MockFeatureFlagExposureRepositoryMockRecorder) ListEvaluationStats(flagKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvaluationStats", reflect.TypeOf((*MockFeatureFlagExposureRepository)(nil).ListEvaluationStats), flagKey)
+}
+
+// ListExposures mocks base method.
+func (m *MockFeatureFlagExposureRepository) ListExposures(flagKey string, beforeID uint, limit int) ([]domain.FeatureFlagExposure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExposures", flagKey, beforeID, limit)
+	ret0, _ := ret[0].([]domain.FeatureFlagExposure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExposures indicates an expected call of ListExposures.
+func (mr *MockFeatureFlagExposureRepositoryMockRecorder) ListExposures(flagKey, beforeID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExposures", reflect.TypeOf((*MockFeatureFlagExposureRepository)(nil).ListExposures), flagKey, beforeID, limit)
+}
+
+// RecordEvaluations mocks base method.
+func (m *MockFeatureFlagExposureRepository) RecordEvaluations(stats []domain.FeatureFlagEvaluationStat, exposures []domain.FeatureFlagExposure) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordEvaluations", stats, exposures)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordEvaluations indicates an expected call of RecordEvaluations.
+func (mr *MockFeatureFlagExposureRepositoryMockRecorder) RecordEvaluations(stats, exposures any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordEvaluations", reflect.TypeOf((*MockFeatureFlagExposureRepository)(nil).RecordEvaluations), stats, exposures)
+}
@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 
 	domain "github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	repository "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,60 +41,74 @@ func (m *MockFeatureFlagRepository) EXPECT() *MockFeatureFlagRepositoryMockRecor
 	return m.recorder
 }
 
+// AppendVersion mocks base method.
+func (m *MockFeatureFlagRepository) AppendVersion(version *domain.FeatureFlagVersion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendVersion", version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendVersion indicates an expected call of AppendVersion.
+func (mr *MockFeatureFlagRepositoryMockRecorder) AppendVersion(version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendVersion", reflect.TypeOf((*MockFeatureFlagRepository)(nil).AppendVersion), version)
+}
+
 // CreateFlag mocks base method.
-func (m *MockFeatureFlagRepository) CreateFlag(flag *domain.FeatureFlag) error {
+func (m *MockFeatureFlagRepository) CreateFlag(flag *domain.FeatureFlag, version repository.FeatureFlagVersionFunc) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateFlag", flag)
+	ret := m.ctrl.Call(m, "CreateFlag", flag, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateFlag indicates an expected call of CreateFlag.
-func (mr *MockFeatureFlagRepositoryMockRecorder) CreateFlag(flag any) *gomock.Call {
+func (mr *MockFeatureFlagRepositoryMockRecorder) CreateFlag(flag, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFlag", reflect.TypeOf((*MockFeatureFlagRepository)(nil).CreateFlag), flag)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFlag", reflect.TypeOf((*MockFeatureFlagRepository)(nil).CreateFlag), flag, version)
 }
 
 // CreateRule mocks base method.
-func (m *MockFeatureFlagRepository) CreateRule(rule *domain.FeatureFlagRule) error {
+func (m *MockFeatureFlagRepository) CreateRule(rule *domain.FeatureFlagRule, version repository.FeatureFlagVersionFunc) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRule", rule)
+	ret := m.ctrl.Call(m, "CreateRule", rule, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateRule indicates an expected call of CreateRule.
-func (mr *MockFeatureFlagRepositoryMockRecorder) CreateRule(rule any) *gomock.Call {
+func (mr *MockFeatureFlagRepositoryMockRecorder) CreateRule(rule, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockFeatureFlagRepository)(nil).CreateRule), rule)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockFeatureFlagRepository)(nil).CreateRule), rule, version)
 }
 
 // DeleteFlag mocks base method.
-func (m *MockFeatureFlagRepository) DeleteFlag(id uint) error {
+func (m *MockFeatureFlagRepository) DeleteFlag(id uint, version repository.FeatureFlagVersionFunc) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteFlag", id)
+	ret := m.ctrl.Call(m, "DeleteFlag", id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteFlag indicates an expected call of DeleteFlag.
-func (mr *MockFeatureFlagRepositoryMockRecorder) DeleteFlag(id any) *gomock.Call {
+func (mr *MockFeatureFlagRepositoryMockRecorder) DeleteFlag(id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFlag", reflect.TypeOf((*MockFeatureFlagRepository)(nil).DeleteFlag), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFlag", reflect.TypeOf((*MockFeatureFlagRepository)(nil).DeleteFlag), id, version)
 }
 
 // DeleteRule mocks base method.
-func (m *MockFeatureFlagRepository) DeleteRule(flagID, ruleID uint) error {
+func (m *MockFeatureFlagRepository) DeleteRule(flagID, ruleID uint, version repository.FeatureFlagVersionFunc) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteRule", flagID, ruleID)
+	ret := m.ctrl.Call(m, "DeleteRule", flagID, ruleID, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteRule indicates an expected call of DeleteRule.
-func (mr *MockFeatureFlagRepositoryMockRecorder) DeleteRule(flagID, ruleID any) *gomock.Call {
+func (mr *MockFeatureFlagRepositoryMockRecorder) DeleteRule(flagID, ruleID, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockFeatureFlagRepository)(nil).DeleteRule), flagID, ruleID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockFeatureFlagRepository)(nil).DeleteRule), flagID, ruleID, version)
 }
 
 // FindFlagByID mocks base method.
@@ -126,6 +141,21 @@ func (mr *MockFeatureFlagRepositoryMockRecorder) FindFlagByKey(key any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFlagByKey", reflect.TypeOf((*MockFeatureFlagRepository)(nil).FindFlagByKey), key)
 }
 
+// FindVersion mocks base method.
+func (m *MockFeatureFlagRepository) FindVersion(flagID uint, version int) (*domain.FeatureFlagVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVersion", flagID, version)
+	ret0, _ := ret[0].(*domain.FeatureFlagVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVersion indicates an expected call of FindVersion.
+func (mr *MockFeatureFlagRepositoryMockRecorder) FindVersion(flagID, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersion", reflect.TypeOf((*MockFeatureFlagRepository)(nil).FindVersion), flagID, version)
+}
+
 // ListFlags mocks base method.
 func (m *MockFeatureFlagRepository) ListFlags() ([]domain.FeatureFlag, error) {
 	m.ctrl.T.Helper()
@@ -156,30 +186,59 @@ func (mr *MockFeatureFlagRepositoryMockRecorder) ListRules(flagID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRules", reflect.TypeOf((*MockFeatureFlagRepository)(nil).ListRules), flagID)
 }
 
+// ListVersions mocks base method.
+func (m *MockFeatureFlagRepository) ListVersions(flagID uint, limit int) ([]domain.FeatureFlagVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVersions", flagID, limit)
+	ret0, _ := ret[0].([]domain.FeatureFlagVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVersions indicates an expected call of ListVersions.
+func (mr *MockFeatureFlagRepositoryMockRecorder) ListVersions(flagID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVersions", reflect.TypeOf((*MockFeatureFlagRepository)(nil).ListVersions), flagID, limit)
+}
+
+// RestoreFlag mocks base method.
+func (m *MockFeatureFlagRepository) RestoreFlag(flag *domain.FeatureFlag, version *domain.FeatureFlagVersion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreFlag", flag, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreFlag indicates an expected call of RestoreFlag.
+func (mr *MockFeatureFlagRepositoryMockRecorder) RestoreFlag(flag, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFlag", reflect.TypeOf((*MockFeatureFlagRepository)(nil).RestoreFlag), flag, version)
+}
+
 // UpdateFlag mocks base method.
-func (m *MockFeatureFlagRepository) UpdateFlag(flag *domain.FeatureFlag) error {
+func (m *MockFeatureFlagRepository) UpdateFlag(flag *domain.FeatureFlag, version repository.FeatureFlagVersionFunc) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateFlag", flag)
+	ret := m.ctrl.Call(m, "UpdateFlag", flag, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateFlag indicates an expected call of UpdateFlag.
-func (mr *MockFeatureFlagRepositoryMockRecorder) UpdateFlag(flag any) *gomock.Call {
+func (mr *MockFeatureFlagRepositoryMockRecorder) UpdateFlag(flag, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFlag", reflect.TypeOf((*MockFeatureFlagRepository)(nil).UpdateFlag), flag)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFlag", reflect.TypeOf((*MockFeatureFlagRepository)(nil).UpdateFlag), flag, version)
 }
 
 // UpdateRule mocks base method.
-func (m *MockFeatureFlagRepository) UpdateRule(rule *domain.FeatureFlagRule) error {
+func (m *MockFeatureFlagRepository) UpdateRule(rule *domain.FeatureFlagRule, version repository.FeatureFlagVersionFunc) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateRule", rule)
+	ret := m.ctrl.Call(m, "UpdateRule", rule, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateRule indicates an expected call of UpdateRule.
-func (mr *MockFeatureFlagRepositoryMockRecorder) UpdateRule(rule any) *gomock.Call {
+func (mr *MockFeatureFlagRepositoryMockRecorder) UpdateRule(rule, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRule", reflect.TypeOf((*MockFeatureFlagRepository)(nil).UpdateRule), rule)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRule", reflect.TypeOf((*MockFeatureFlagRepository)(nil).UpdateRule), rule, version)
 }
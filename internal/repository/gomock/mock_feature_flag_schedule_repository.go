@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/feature_flag_schedule_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source internal/repository/feature_flag_schedule_repository.go -destination internal/repository/gomock/mock_feature_flag_schedule_repository.go -package gomock
+//
+
+// Package gomock is a generated GoMock package.
+package gomock
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeatureFlagScheduleRepository is a mock of FeatureFlagScheduleRepository interface.
+type MockFeatureFlagScheduleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeatureFlagScheduleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockFeatureFlagScheduleRepositoryMockRecorder is the mock recorder for MockFeatureFlagScheduleRepository.
+type MockFeatureFlagScheduleRepositoryMockRecorder struct {
+	mock *MockFeatureFlagScheduleRepository
+}
+
+// NewMockFeatureFlagScheduleRepository creates a new mock instance.
+func NewMockFeatureFlagScheduleRepository(ctrl *gomock.Controller) *MockFeatureFlagScheduleRepository {
+	mock := &MockFeatureFlagScheduleRepository{ctrl: ctrl}
+	mock.recorder = &MockFeatureFlagScheduleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeatureFlagScheduleRepository) EXPECT() *MockFeatureFlagScheduleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimStep mocks base method.
+func (m *MockFeatureFlagScheduleRepository) ClaimStep(stepID uint, now, staleBefore time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimStep", stepID, now, staleBefore)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimStep indicates an expected call of ClaimStep.
+func (mr *MockFeatureFlagScheduleRepositoryMockRecorder) ClaimStep(stepID, now, staleBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimStep", reflect.TypeOf((*MockFeatureFlagScheduleRepository)(nil).ClaimStep), stepID, now, staleBefore)
+}
+
+// CreateSchedule mocks base method.
+func (m *MockFeatureFlagScheduleRepository) CreateSchedule(schedule *domain.FeatureFlagSchedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSchedule", schedule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSchedule indicates an expected call of CreateSchedule.
+func (mr *MockFeatureFlagScheduleRepositoryMockRecorder) CreateSchedule(schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchedule", reflect.TypeOf((*MockFeatureFlagScheduleRepository)(nil).CreateSchedule), schedule)
+}
+
+// FindSchedule mocks base method.
+func (m *MockFeatureFlagScheduleRepository) FindSchedule(id uint) (*domain.FeatureFlagSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSchedule", id)
+	ret0, _ := ret[0].(*domain.FeatureFlagSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSchedule indicates an expected call of FindSchedule.
+func (mr *MockFeatureFlagScheduleRepositoryMockRecorder) FindSchedule(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSchedule", reflect.TypeOf((*MockFeatureFlagScheduleRepository)(nil).FindSchedule), id)
+}
+
+// FinishStep mocks base method.
+func (m *MockFeatureFlagScheduleRepository) FinishStep(step *domain.FeatureFlagScheduleStep, at time.Time, failure string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishStep", step, at, failure)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishStep indicates an expected call of FinishStep.
+func (mr *MockFeatureFlagScheduleRepositoryMockRecorder) FinishStep(step, at, failure any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishStep", reflect.TypeOf((*MockFeatureFlagScheduleRepository)(nil).FinishStep), step, at, failure)
+}
+
+// ListDueSteps mocks base method.
+func (m *MockFeatureFlagScheduleRepository) ListDueSteps(now, staleBefore time.Time, limit int) ([]domain.FeatureFlagScheduleStep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueSteps", now, staleBefore, limit)
+	ret0, _ := ret[0].([]domain.FeatureFlagScheduleStep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueSteps indicates an expected call of ListDueSteps.
+func (mr *MockFeatureFlagScheduleRepositoryMockRecorder) ListDueSteps(now, staleBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueSteps", reflect.TypeOf((*MockFeatureFlagScheduleRepository)(nil).ListDueSteps), now, staleBefore, limit)
+}
+
+// ListSchedules mocks base method.
+func (m *MockFeatureFlagScheduleRepository) ListSchedules(flagID uint) ([]domain.FeatureFlagSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSchedules", flagID)
+	ret0, _ := ret[0].([]domain.FeatureFlagSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSchedules indicates an expected call of ListSchedules.
+func (mr *MockFeatureFlagScheduleRepositoryMockRecorder) ListSchedules(flagID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSchedules", reflect.TypeOf((*MockFeatureFlagScheduleRepository)(nil).ListSchedules), flagID)
+}
+
+// TransitionSchedule mocks base method.
+func (m *MockFeatureFlagScheduleRepository) TransitionSchedule(id uint, from []string, to, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionSchedule", id, from, to, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransitionSchedule indicates an expected call of TransitionSchedule.
+func (mr *MockFeatureFlagScheduleRepositoryMockRecorder) TransitionSchedule(id, from, to, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionSchedule", reflect.TypeOf((*MockFeatureFlagScheduleRepository)(nil).TransitionSchedule), id, from, to, reason)
+}
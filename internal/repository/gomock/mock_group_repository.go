@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/group_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source internal/repository/group_repository.go -destination internal/repository/gomock/mock_group_repository.go -package gomock
+//
+
+// Package gomock is a generated GoMock package.
+package gomock
+
+import (
+	reflect "reflect"
+
+	domain "github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	repository "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGroupRepository is a mock of GroupRepository interface.
+type MockGroupRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockGroupRepositoryMockRecorder is the mock recorder for MockGroupRepository.
+type MockGroupRepositoryMockRecorder struct {
+	mock *MockGroupRepository
+}
+
+// NewMockGroupRepository creates a new mock instance.
+func NewMockGroupRepository(ctrl *gomock.Controller) *MockGroupRepository {
+	mock := &MockGroupRepository{ctrl: ctrl}
+	mock.recorder = &MockGroupRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupRepository) EXPECT() *MockGroupRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddMembers mocks base method.
+func (m *MockGroupRepository) AddMembers(groupID uint, userIDs []uint) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMembers", groupID, userIDs)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMembers indicates an expected call of AddMembers.
+func (mr *MockGroupRepositoryMockRecorder) AddMembers(groupID, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMembers", reflect.TypeOf((*MockGroupRepository)(nil).AddMembers), groupID, userIDs)
+}
+
+// Create mocks base method.
+func (m *MockGroupRepository) Create(group *domain.Group, roleIDs []uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", group, roleIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGroupRepositoryMockRecorder) Create(group, roleIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGroupRepository)(nil).Create), group, roleIDs)
+}
+
+// DeleteByID mocks base method.
+func (m *MockGroupRepository) DeleteByID(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockGroupRepositoryMockRecorder) DeleteByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockGroupRepository)(nil).DeleteByID), id)
+}
+
+// FindByID mocks base method.
+func (m *MockGroupRepository) FindByID(id uint) (*domain.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*domain.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockGroupRepositoryMockRecorder) FindByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockGroupRepository)(nil).FindByID), id)
+}
+
+// ListMemberIDs mocks base method.
+func (m *MockGroupRepository) ListMemberIDs(groupID uint) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMemberIDs", groupID)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMemberIDs indicates an expected call of ListMemberIDs.
+func (mr *MockGroupRepositoryMockRecorder) ListMemberIDs(groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMemberIDs", reflect.TypeOf((*MockGroupRepository)(nil).ListMemberIDs), groupID)
+}
+
+// ListPaged mocks base method.
+func (m *MockGroupRepository) ListPaged(req repository.PageRequest, name string) (repository.PageResult[domain.Group], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPaged", req, name)
+	ret0, _ := ret[0].(repository.PageResult[domain.Group])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPaged indicates an expected call of ListPaged.
+func (mr *MockGroupRepositoryMockRecorder) ListPaged(req, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaged", reflect.TypeOf((*MockGroupRepository)(nil).ListPaged), req, name)
+}
+
+// RemoveMember mocks base method.
+func (m *MockGroupRepository) RemoveMember(groupID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", groupID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockGroupRepositoryMockRecorder) RemoveMember(groupID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockGroupRepository)(nil).RemoveMember), groupID, userID)
+}
+
+// Update mocks base method.
+func (m *MockGroupRepository) Update(group *domain.Group, roleIDs []uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", group, roleIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGroupRepositoryMockRecorder) Update(group, roleIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGroupRepository)(nil).Update), group, roleIDs)
+}
@@ -0,0 +1,162 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/inventory_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source internal/repository/inventory_repository.go -destination internal/repository/gomock/mock_inventory_repository.go -package gomock
+//
+
+// Package gomock is a generated GoMock package.
+package gomock
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	repository "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInventoryRepository is a mock of InventoryRepository interface.
+type MockInventoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockInventoryRepositoryMockRecorder is the mock recorder for MockInventoryRepository.
+type MockInventoryRepositoryMockRecorder struct {
+	mock *MockInventoryRepository
+}
+
+// NewMockInventoryRepository creates a new mock instance.
+func NewMockInventoryRepository(ctrl *gomock.Controller) *MockInventoryRepository {
+	mock := &MockInventoryRepository{ctrl: ctrl}
+	mock.recorder = &MockInventoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryRepository) EXPECT() *MockInventoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Adjust mocks base method.
+func (m *MockInventoryRepository) Adjust(productID uint, delta int64, actorID *uint, reason string) (*domain.ProductStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Adjust", productID, delta, actorID, reason)
+	ret0, _ := ret[0].(*domain.ProductStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Adjust indicates an expected call of Adjust.
+func (mr *MockInventoryRepositoryMockRecorder) Adjust(productID, delta, actorID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Adjust", reflect.TypeOf((*MockInventoryRepository)(nil).Adjust), productID, delta, actorID, reason)
+}
+
+// CommitReservation mocks base method.
+func (m *MockInventoryRepository) CommitReservation(id uint, actorID *uint, now time.Time) (*domain.StockReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitReservation", id, actorID, now)
+	ret0, _ := ret[0].(*domain.StockReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitReservation indicates an expected call of CommitReservation.
+func (mr *MockInventoryRepositoryMockRecorder) CommitReservation(id, actorID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitReservation", reflect.TypeOf((*MockInventoryRepository)(nil).CommitReservation), id, actorID, now)
+}
+
+// FindReservation mocks base method.
+func (m *MockInventoryRepository) FindReservation(id uint) (*domain.StockReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindReservation", id)
+	ret0, _ := ret[0].(*domain.StockReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindReservation indicates an expected call of FindReservation.
+func (mr *MockInventoryRepositoryMockRecorder) FindReservation(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindReservation", reflect.TypeOf((*MockInventoryRepository)(nil).FindReservation), id)
+}
+
+// GetStock mocks base method.
+func (m *MockInventoryRepository) GetStock(productID uint) (*domain.ProductStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStock", productID)
+	ret0, _ := ret[0].(*domain.ProductStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStock indicates an expected call of GetStock.
+func (mr *MockInventoryRepositoryMockRecorder) GetStock(productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStock", reflect.TypeOf((*MockInventoryRepository)(nil).GetStock), productID)
+}
+
+// ListMovements mocks base method.
+func (m *MockInventoryRepository) ListMovements(productID uint, req repository.PageRequest) (repository.PageResult[domain.StockMovement], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMovements", productID, req)
+	ret0, _ := ret[0].(repository.PageResult[domain.StockMovement])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMovements indicates an expected call of ListMovements.
+func (mr *MockInventoryRepositoryMockRecorder) ListMovements(productID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMovements", reflect.TypeOf((*MockInventoryRepository)(nil).ListMovements), productID, req)
+}
+
+// ReleaseExpired mocks base method.
+func (m *MockInventoryRepository) ReleaseExpired(now time.Time, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseExpired", now, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseExpired indicates an expected call of ReleaseExpired.
+func (mr *MockInventoryRepositoryMockRecorder) ReleaseExpired(now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseExpired", reflect.TypeOf((*MockInventoryRepository)(nil).ReleaseExpired), now, limit)
+}
+
+// ReleaseReservation mocks base method.
+func (m *MockInventoryRepository) ReleaseReservation(id uint, actorID *uint, now time.Time) (*domain.StockReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseReservation", id, actorID, now)
+	ret0, _ := ret[0].(*domain.StockReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseReservation indicates an expected call of ReleaseReservation.
+func (mr *MockInventoryRepositoryMockRecorder) ReleaseReservation(id, actorID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseReservation", reflect.TypeOf((*MockInventoryRepository)(nil).ReleaseReservation), id, actorID, now)
+}
+
+// Reserve mocks base method.
+func (m *MockInventoryRepository) Reserve(reservation *domain.StockReservation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", reservation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockInventoryRepositoryMockRecorder) Reserve(reservation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockInventoryRepository)(nil).Reserve), reservation)
+}
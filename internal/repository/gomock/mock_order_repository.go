@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/order_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source internal/repository/order_repository.go -destination internal/repository/gomock/mock_order_repository.go -package gomock
+//
+
+// Package gomock is a generated GoMock package.
+package gomock
+
+import (
+	reflect "reflect"
+
+	domain "github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	repository "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrderRepository is a mock of OrderRepository interface.
+type MockOrderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderRepositoryMockRecorder is the mock recorder for MockOrderRepository.
+type MockOrderRepositoryMockRecorder struct {
+	mock *MockOrderRepository
+}
+
+// NewMockOrderRepository creates a new mock instance.
+func NewMockOrderRepository(ctrl *gomock.Controller) *MockOrderRepository {
+	mock := &MockOrderRepository{ctrl: ctrl}
+	mock.recorder = &MockOrderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderRepository) EXPECT() *MockOrderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateFromCart mocks base method.
+func (m *MockOrderRepository) CreateFromCart(order *domain.Order, cartID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFromCart", order, cartID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFromCart indicates an expected call of CreateFromCart.
+func (mr *MockOrderRepositoryMockRecorder) CreateFromCart(order, cartID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFromCart", reflect.TypeOf((*MockOrderRepository)(nil).CreateFromCart), order, cartID)
+}
+
+// FindByID mocks base method.
+func (m *MockOrderRepository) FindByID(id uint) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockOrderRepositoryMockRecorder) FindByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockOrderRepository)(nil).FindByID), id)
+}
+
+// ListPaged mocks base method.
+func (m *MockOrderRepository) ListPaged(query repository.OrderListQuery) (repository.PageResult[domain.Order], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPaged", query)
+	ret0, _ := ret[0].(repository.PageResult[domain.Order])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPaged indicates an expected call of ListPaged.
+func (mr *MockOrderRepositoryMockRecorder) ListPaged(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaged", reflect.TypeOf((*MockOrderRepository)(nil).ListPaged), query)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockOrderRepository) UpdateStatus(id uint, from, to string, updates map[string]any) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", id, from, to, updates)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockOrderRepositoryMockRecorder) UpdateStatus(id, from, to, updates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockOrderRepository)(nil).UpdateStatus), id, from, to, updates)
+}
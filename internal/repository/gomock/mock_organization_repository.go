@@ -0,0 +1,219 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/organization_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source internal/repository/organization_repository.go -destination internal/repository/gomock/mock_organization_repository.go -package gomock
+//
+
+// Package gomock is a generated GoMock package.
+package gomock
+
+import (
+	reflect "reflect"
+
+	domain "github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	repository "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrganizationRepository is a mock of OrganizationRepository interface.
+type MockOrganizationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOrganizationRepositoryMockRecorder is the mock recorder for MockOrganizationRepository.
+type MockOrganizationRepositoryMockRecorder struct {
+	mock *MockOrganizationRepository
+}
+
+// NewMockOrganizationRepository creates a new mock instance.
+func NewMockOrganizationRepository(ctrl *gomock.Controller) *MockOrganizationRepository {
+	mock := &MockOrganizationRepository{ctrl: ctrl}
+	mock.recorder = &MockOrganizationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationRepository) EXPECT() *MockOrganizationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AcceptMembership mocks base method.
+func (m *MockOrganizationRepository) AcceptMembership(orgID, userID uint) (*domain.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptMembership", orgID, userID)
+	ret0, _ := ret[0].(*domain.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptMembership indicates an expected call of AcceptMembership.
+func (mr *MockOrganizationRepositoryMockRecorder) AcceptMembership(orgID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptMembership", reflect.TypeOf((*MockOrganizationRepository)(nil).AcceptMembership), orgID, userID)
+}
+
+// CountMembersWithPermission mocks base method.
+func (m *MockOrganizationRepository) CountMembersWithPermission(orgID uint, resource, action string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMembersWithPermission", orgID, resource, action)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountMembersWithPermission indicates an expected call of CountMembersWithPermission.
+func (mr *MockOrganizationRepositoryMockRecorder) CountMembersWithPermission(orgID, resource, action any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMembersWithPermission", reflect.TypeOf((*MockOrganizationRepository)(nil).CountMembersWithPermission), orgID, resource, action)
+}
+
+// Create mocks base method.
+func (m *MockOrganizationRepository) Create(org *domain.Organization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", org)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrganizationRepositoryMockRecorder) Create(org any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrganizationRepository)(nil).Create), org)
+}
+
+// DeleteByID mocks base method.
+func (m *MockOrganizationRepository) DeleteByID(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockOrganizationRepositoryMockRecorder) DeleteByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockOrganizationRepository)(nil).DeleteByID), id)
+}
+
+// DeleteMembership mocks base method.
+func (m *MockOrganizationRepository) DeleteMembership(orgID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMembership", orgID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMembership indicates an expected call of DeleteMembership.
+func (mr *MockOrganizationRepositoryMockRecorder) DeleteMembership(orgID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMembership", reflect.TypeOf((*MockOrganizationRepository)(nil).DeleteMembership), orgID, userID)
+}
+
+// FindByID mocks base method.
+func (m *MockOrganizationRepository) FindByID(id uint) (*domain.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*domain.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockOrganizationRepositoryMockRecorder) FindByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockOrganizationRepository)(nil).FindByID), id)
+}
+
+// FindBySlug mocks base method.
+func (m *MockOrganizationRepository) FindBySlug(slug string) (*domain.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySlug", slug)
+	ret0, _ := ret[0].(*domain.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySlug indicates an expected call of FindBySlug.
+func (mr *MockOrganizationRepositoryMockRecorder) FindBySlug(slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySlug", reflect.TypeOf((*MockOrganizationRepository)(nil).FindBySlug), slug)
+}
+
+// FindMembership mocks base method.
+func (m *MockOrganizationRepository) FindMembership(orgID, userID uint) (*domain.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMembership", orgID, userID)
+	ret0, _ := ret[0].(*domain.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMembership indicates an expected call of FindMembership.
+func (mr *MockOrganizationRepositoryMockRecorder) FindMembership(orgID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMembership", reflect.TypeOf((*MockOrganizationRepository)(nil).FindMembership), orgID, userID)
+}
+
+// ListInvitingOrganizations mocks base method.
+func (m *MockOrganizationRepository) ListInvitingOrganizations(userID uint) ([]domain.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvitingOrganizations", userID)
+	ret0, _ := ret[0].([]domain.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInvitingOrganizations indicates an expected call of ListInvitingOrganizations.
+func (mr *MockOrganizationRepositoryMockRecorder) ListInvitingOrganizations(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvitingOrganizations", reflect.TypeOf((*MockOrganizationRepository)(nil).ListInvitingOrganizations), userID)
+}
+
+// ListMemberships mocks base method.
+func (m *MockOrganizationRepository) ListMemberships(orgID uint, req repository.PageRequest) (repository.PageResult[domain.Membership], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMemberships", orgID, req)
+	ret0, _ := ret[0].(repository.PageResult[domain.Membership])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMemberships indicates an expected call of ListMemberships.
+func (mr *MockOrganizationRepositoryMockRecorder) ListMemberships(orgID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMemberships", reflect.TypeOf((*MockOrganizationRepository)(nil).ListMemberships), orgID, req)
+}
+
+// ListPaged mocks base method.
+func (m *MockOrganizationRepository) ListPaged(req repository.PageRequest) (repository.PageResult[domain.Organization], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPaged", req)
+	ret0, _ := ret[0].(repository.PageResult[domain.Organization])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPaged indicates an expected call of ListPaged.
+func (mr *MockOrganizationRepositoryMockRecorder) ListPaged(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaged", reflect.TypeOf((*MockOrganizationRepository)(nil).ListPaged), req)
+}
+
+// UpsertMembership mocks base method.
+func (m *MockOrganizationRepository) UpsertMembership(orgID, userID uint, roleIDs []uint, status string) (*domain.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMembership", orgID, userID, roleIDs, status)
+	ret0, _ := ret[0].(*domain.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertMembership indicates an expected call of UpsertMembership.
+func (mr *MockOrganizationRepositoryMockRecorder) UpsertMembership(orgID, userID, roleIDs, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMembership", reflect.TypeOf((*MockOrganizationRepository)(nil).UpsertMembership), orgID, userID, roleIDs, status)
+}
@@ -129,6 +129,21 @@ func (mr *MockPermissionRepositoryMockRecorder) List() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPermissionRepository)(nil).List))
 }
 
+// ListByCursor mocks base method.
+func (m *MockPermissionRepository) ListByCursor(cursor repository.CursorRequest, sortBy, sortOrder, resource, action string) (repository.CursorResult[domain.Permission], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByCursor", cursor, sortBy, sortOrder, resource, action)
+	ret0, _ := ret[0].(repository.CursorResult[domain.Permission])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByCursor indicates an expected call of ListByCursor.
+func (mr *MockPermissionRepositoryMockRecorder) ListByCursor(cursor, sortBy, sortOrder, resource, action any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByCursor", reflect.TypeOf((*MockPermissionRepository)(nil).ListByCursor), cursor, sortBy, sortOrder, resource, action)
+}
+
 // ListPaged mocks base method.
 func (m *MockPermissionRepository) ListPaged(req repository.PageRequest, sortBy, sortOrder, resource, action string) (repository.PageResult[domain.Permission], error) {
 	m.ctrl.T.Helper()
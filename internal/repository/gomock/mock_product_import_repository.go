@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/product_import_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source internal/repository/product_import_repository.go -destination internal/repository/gomock/mock_product_import_repository.go -package gomock
+//
+
+// Package gomock is a generated GoMock package.
+package gomock
+
+import (
+	reflect "reflect"
+
+	domain "github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProductImportJobRepository is a mock of ProductImportJobRepository interface.
+type MockProductImportJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductImportJobRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockProductImportJobRepositoryMockRecorder is the mock recorder for MockProductImportJobRepository.
+type MockProductImportJobRepositoryMockRecorder struct {
+	mock *MockProductImportJobRepository
+}
+
+// NewMockProductImportJobRepository creates a new mock instance.
+func NewMockProductImportJobRepository(ctrl *gomock.Controller) *MockProductImportJobRepository {
+	mock := &MockProductImportJobRepository{ctrl: ctrl}
+	mock.recorder = &MockProductImportJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductImportJobRepository) EXPECT() *MockProductImportJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockProductImportJobRepository) Create(job *domain.ProductImportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockProductImportJobRepositoryMockRecorder) Create(job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProductImportJobRepository)(nil).Create), job)
+}
+
+// FindByID mocks base method.
+func (m *MockProductImportJobRepository) FindByID(id uint) (*domain.ProductImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*domain.ProductImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockProductImportJobRepositoryMockRecorder) FindByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockProductImportJobRepository)(nil).FindByID), id)
+}
+
+// Update mocks base method.
+func (m *MockProductImportJobRepository) Update(job *domain.ProductImportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockProductImportJobRepositoryMockRecorder) Update(job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProductImportJobRepository)(nil).Update), job)
+}
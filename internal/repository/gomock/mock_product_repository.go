@@ -11,6 +11,7 @@ package gomock
 
 import (
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
 	repository "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
@@ -41,6 +42,20 @@ func (m *MockProductRepository) EXPECT() *MockProductRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddImage mocks base method.
+func (m *MockProductRepository) AddImage(image *domain.ProductImage, limit int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddImage", image, limit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddImage indicates an expected call of AddImage.
+func (mr *MockProductRepositoryMockRecorder) AddImage(image, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddImage", reflect.TypeOf((*MockProductRepository)(nil).AddImage), image, limit)
+}
+
 // Create mocks base method.
 func (m *MockProductRepository) Create(product *domain.Product) error {
 	m.ctrl.T.Helper()
@@ -56,17 +71,46 @@ func (mr *MockProductRepositoryMockRecorder) Create(product any) *gomock.Call {
 }
 
 // DeleteByID mocks base method.
-func (m *MockProductRepository) DeleteByID(id uint) error {
+func (m *MockProductRepository) DeleteByID(id, version uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteByID", id)
+	ret := m.ctrl.Call(m, "DeleteByID", id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteByID indicates an expected call of DeleteByID.
-func (mr *MockProductRepositoryMockRecorder) DeleteByID(id any) *gomock.Call {
+func (mr *MockProductRepositoryMockRecorder) DeleteByID(id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockProductRepository)(nil).DeleteByID), id, version)
+}
+
+// DeleteGrant mocks base method.
+func (m *MockProductRepository) DeleteGrant(productID, grantID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGrant", productID, grantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGrant indicates an expected call of DeleteGrant.
+func (mr *MockProductRepositoryMockRecorder) DeleteGrant(productID, grantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockProductRepository)(nil).DeleteByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGrant", reflect.TypeOf((*MockProductRepository)(nil).DeleteGrant), productID, grantID)
+}
+
+// DeleteImage mocks base method.
+func (m *MockProductRepository) DeleteImage(productID, imageID uint) (*domain.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImage", productID, imageID)
+	ret0, _ := ret[0].(*domain.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteImage indicates an expected call of DeleteImage.
+func (mr *MockProductRepositoryMockRecorder) DeleteImage(productID, imageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImage", reflect.TypeOf((*MockProductRepository)(nil).DeleteImage), productID, imageID)
 }
 
 // FindByID mocks base method.
@@ -84,31 +128,221 @@ func (mr *MockProductRepositoryMockRecorder) FindByID(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockProductRepository)(nil).FindByID), id)
 }
 
+// FindBySKU mocks base method.
+func (m *MockProductRepository) FindBySKU(sku string) (*domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySKU", sku)
+	ret0, _ := ret[0].(*domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySKU indicates an expected call of FindBySKU.
+func (mr *MockProductRepositoryMockRecorder) FindBySKU(sku any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySKU", reflect.TypeOf((*MockProductRepository)(nil).FindBySKU), sku)
+}
+
+// ForOrganization mocks base method.
+func (m *MockProductRepository) ForOrganization(orgID uint) repository.ProductRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForOrganization", orgID)
+	ret0, _ := ret[0].(repository.ProductRepository)
+	return ret0
+}
+
+// ForOrganization indicates an expected call of ForOrganization.
+func (mr *MockProductRepositoryMockRecorder) ForOrganization(orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForOrganization", reflect.TypeOf((*MockProductRepository)(nil).ForOrganization), orgID)
+}
+
+// ForViewer mocks base method.
+func (m *MockProductRepository) ForViewer(userID uint) repository.ProductRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForViewer", userID)
+	ret0, _ := ret[0].(repository.ProductRepository)
+	return ret0
+}
+
+// ForViewer indicates an expected call of ForViewer.
+func (mr *MockProductRepositoryMockRecorder) ForViewer(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForViewer", reflect.TypeOf((*MockProductRepository)(nil).ForViewer), userID)
+}
+
+// HasGrant mocks base method.
+func (m *MockProductRepository) HasGrant(productID, userID uint, access string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasGrant", productID, userID, access)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasGrant indicates an expected call of HasGrant.
+func (mr *MockProductRepositoryMockRecorder) HasGrant(productID, userID, access any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasGrant", reflect.TypeOf((*MockProductRepository)(nil).HasGrant), productID, userID, access)
+}
+
+// ListAfter mocks base method.
+func (m *MockProductRepository) ListAfter(afterID uint, limit int) ([]domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAfter", afterID, limit)
+	ret0, _ := ret[0].([]domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAfter indicates an expected call of ListAfter.
+func (mr *MockProductRepositoryMockRecorder) ListAfter(afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAfter", reflect.TypeOf((*MockProductRepository)(nil).ListAfter), afterID, limit)
+}
+
+// ListByCursor mocks base method.
+func (m *MockProductRepository) ListByCursor(query repository.ProductListQuery, cursor repository.CursorRequest) (repository.CursorResult[domain.Product], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByCursor", query, cursor)
+	ret0, _ := ret[0].(repository.CursorResult[domain.Product])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByCursor indicates an expected call of ListByCursor.
+func (mr *MockProductRepositoryMockRecorder) ListByCursor(query, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByCursor", reflect.TypeOf((*MockProductRepository)(nil).ListByCursor), query, cursor)
+}
+
+// ListDeleted mocks base method.
+func (m *MockProductRepository) ListDeleted(req repository.PageRequest) (repository.PageResult[domain.Product], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeleted", req)
+	ret0, _ := ret[0].(repository.PageResult[domain.Product])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeleted indicates an expected call of ListDeleted.
+func (mr *MockProductRepositoryMockRecorder) ListDeleted(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeleted", reflect.TypeOf((*MockProductRepository)(nil).ListDeleted), req)
+}
+
+// ListGrants mocks base method.
+func (m *MockProductRepository) ListGrants(productID uint) ([]domain.ProductGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGrants", productID)
+	ret0, _ := ret[0].([]domain.ProductGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGrants indicates an expected call of ListGrants.
+func (mr *MockProductRepositoryMockRecorder) ListGrants(productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGrants", reflect.TypeOf((*MockProductRepository)(nil).ListGrants), productID)
+}
+
 // ListPaged mocks base method.
-func (m *MockProductRepository) ListPaged(req repository.PageRequest) (repository.PageResult[domain.Product], error) {
+func (m *MockProductRepository) ListPaged(query repository.ProductListQuery) (repository.PageResult[domain.Product], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPaged", req)
+	ret := m.ctrl.Call(m, "ListPaged", query)
 	ret0, _ := ret[0].(repository.PageResult[domain.Product])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListPaged indicates an expected call of ListPaged.
-func (mr *MockProductRepositoryMockRecorder) ListPaged(req any) *gomock.Call {
+func (mr *MockProductRepositoryMockRecorder) ListPaged(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaged", reflect.TypeOf((*MockProductRepository)(nil).ListPaged), query)
+}
+
+// Purge mocks base method.
+func (m *MockProductRepository) Purge(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockProductRepositoryMockRecorder) Purge(id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaged", reflect.TypeOf((*MockProductRepository)(nil).ListPaged), req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockProductRepository)(nil).Purge), id)
+}
+
+// PurgeDeletedBefore mocks base method.
+func (m *MockProductRepository) PurgeDeletedBefore(cutoff time.Time, limit int) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedBefore", cutoff, limit)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedBefore indicates an expected call of PurgeDeletedBefore.
+func (mr *MockProductRepositoryMockRecorder) PurgeDeletedBefore(cutoff, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedBefore", reflect.TypeOf((*MockProductRepository)(nil).PurgeDeletedBefore), cutoff, limit)
+}
+
+// ReorderImages mocks base method.
+func (m *MockProductRepository) ReorderImages(productID uint, imageIDs []uint) ([]domain.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderImages", productID, imageIDs)
+	ret0, _ := ret[0].([]domain.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderImages indicates an expected call of ReorderImages.
+func (mr *MockProductRepositoryMockRecorder) ReorderImages(productID, imageIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderImages", reflect.TypeOf((*MockProductRepository)(nil).ReorderImages), productID, imageIDs)
+}
+
+// Restore mocks base method.
+func (m *MockProductRepository) Restore(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockProductRepositoryMockRecorder) Restore(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockProductRepository)(nil).Restore), id)
 }
 
 // Update mocks base method.
-func (m *MockProductRepository) Update(id uint, updates map[string]any) error {
+func (m *MockProductRepository) Update(id, version uint, updates map[string]any) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", id, updates)
+	ret := m.ctrl.Call(m, "Update", id, version, updates)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockProductRepositoryMockRecorder) Update(id, updates any) *gomock.Call {
+func (mr *MockProductRepositoryMockRecorder) Update(id, version, updates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProductRepository)(nil).Update), id, version, updates)
+}
+
+// UpsertGrant mocks base method.
+func (m *MockProductRepository) UpsertGrant(grant *domain.ProductGrant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertGrant", grant)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertGrant indicates an expected call of UpsertGrant.
+func (mr *MockProductRepositoryMockRecorder) UpsertGrant(grant any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProductRepository)(nil).Update), id, updates)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertGrant", reflect.TypeOf((*MockProductRepository)(nil).UpsertGrant), grant)
 }
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/role_change_request_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source internal/repository/role_change_request_repository.go -destination internal/repository/gomock/mock_role_change_request_repository.go -package gomock
+//
+
+// Package gomock is a generated GoMock package.
+package gomock
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	repository "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRoleChangeRequestRepository is a mock of RoleChangeRequestRepository interface.
+type MockRoleChangeRequestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleChangeRequestRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRoleChangeRequestRepositoryMockRecorder is the mock recorder for MockRoleChangeRequestRepository.
+type MockRoleChangeRequestRepositoryMockRecorder struct {
+	mock *MockRoleChangeRequestRepository
+}
+
+// NewMockRoleChangeRequestRepository creates a new mock instance.
+func NewMockRoleChangeRequestRepository(ctrl *gomock.Controller) *MockRoleChangeRequestRepository {
+	mock := &MockRoleChangeRequestRepository{ctrl: ctrl}
+	mock.recorder = &MockRoleChangeRequestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoleChangeRequestRepository) EXPECT() *MockRoleChangeRequestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRoleChangeRequestRepository) Create(req *domain.RoleChangeRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRoleChangeRequestRepositoryMockRecorder) Create(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRoleChangeRequestRepository)(nil).Create), req)
+}
+
+// FindByID mocks base method.
+func (m *MockRoleChangeRequestRepository) FindByID(id uint) (*domain.RoleChangeRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*domain.RoleChangeRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockRoleChangeRequestRepositoryMockRecorder) FindByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockRoleChangeRequestRepository)(nil).FindByID), id)
+}
+
+// ListExpiredPending mocks base method.
+func (m *MockRoleChangeRequestRepository) ListExpiredPending(now time.Time, limit int) ([]domain.RoleChangeRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredPending", now, limit)
+	ret0, _ := ret[0].([]domain.RoleChangeRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredPending indicates an expected call of ListExpiredPending.
+func (mr *MockRoleChangeRequestRepositoryMockRecorder) ListExpiredPending(now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredPending", reflect.TypeOf((*MockRoleChangeRequestRepository)(nil).ListExpiredPending), now, limit)
+}
+
+// ListPaged mocks base method.
+func (m *MockRoleChangeRequestRepository) ListPaged(req repository.PageRequest, status string) (repository.PageResult[domain.RoleChangeRequest], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPaged", req, status)
+	ret0, _ := ret[0].(repository.PageResult[domain.RoleChangeRequest])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPaged indicates an expected call of ListPaged.
+func (mr *MockRoleChangeRequestRepositoryMockRecorder) ListPaged(req, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaged", reflect.TypeOf((*MockRoleChangeRequestRepository)(nil).ListPaged), req, status)
+}
+
+// Transition mocks base method.
+func (m *MockRoleChangeRequestRepository) Transition(id uint, from, to string, decidedBy *uint, reason string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transition", id, from, to, decidedBy, reason, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transition indicates an expected call of Transition.
+func (mr *MockRoleChangeRequestRepositoryMockRecorder) Transition(id, from, to, decidedBy, reason, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transition", reflect.TypeOf((*MockRoleChangeRequestRepository)(nil).Transition), id, from, to, decidedBy, reason, at)
+}
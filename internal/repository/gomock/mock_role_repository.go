@@ -114,6 +114,21 @@ func (mr *MockRoleRepositoryMockRecorder) List() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRoleRepository)(nil).List))
 }
 
+// ListByCursor mocks base method.
+func (m *MockRoleRepository) ListByCursor(cursor repository.CursorRequest, sortBy, sortOrder, name string) (repository.CursorResult[domain.Role], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByCursor", cursor, sortBy, sortOrder, name)
+	ret0, _ := ret[0].(repository.CursorResult[domain.Role])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByCursor indicates an expected call of ListByCursor.
+func (mr *MockRoleRepositoryMockRecorder) ListByCursor(cursor, sortBy, sortOrder, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByCursor", reflect.TypeOf((*MockRoleRepository)(nil).ListByCursor), cursor, sortBy, sortOrder, name)
+}
+
 // ListPaged mocks base method.
 func (m *MockRoleRepository) ListPaged(req repository.PageRequest, sortBy, sortOrder, name string) (repository.PageResult[domain.Role], error) {
 	m.ctrl.T.Helper()
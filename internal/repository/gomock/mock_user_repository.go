@@ -11,6 +11,7 @@ package gomock
 
 import (
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
 	repository "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
@@ -69,6 +70,35 @@ func (mr *MockUserRepositoryMockRecorder) Create(user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserRepository)(nil).Create), user)
 }
 
+// DeleteByID mocks base method.
+func (m *MockUserRepository) DeleteByID(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockUserRepositoryMockRecorder) DeleteByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockUserRepository)(nil).DeleteByID), id)
+}
+
+// DeleteExpiredRoleGrant mocks base method.
+func (m *MockUserRepository) DeleteExpiredRoleGrant(userID, roleID uint, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredRoleGrant", userID, roleID, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredRoleGrant indicates an expected call of DeleteExpiredRoleGrant.
+func (mr *MockUserRepositoryMockRecorder) DeleteExpiredRoleGrant(userID, roleID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredRoleGrant", reflect.TypeOf((*MockUserRepository)(nil).DeleteExpiredRoleGrant), userID, roleID, now)
+}
+
 // FindByEmail mocks base method.
 func (m *MockUserRepository) FindByEmail(email string) (*domain.User, error) {
 	m.ctrl.T.Helper()
@@ -99,6 +129,20 @@ func (mr *MockUserRepositoryMockRecorder) FindByID(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUserRepository)(nil).FindByID), id)
 }
 
+// GrantRole mocks base method.
+func (m *MockUserRepository) GrantRole(grant *domain.UserRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantRole", grant)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantRole indicates an expected call of GrantRole.
+func (mr *MockUserRepositoryMockRecorder) GrantRole(grant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRole", reflect.TypeOf((*MockUserRepository)(nil).GrantRole), grant)
+}
+
 // List mocks base method.
 func (m *MockUserRepository) List() ([]domain.User, error) {
 	m.ctrl.T.Helper()
@@ -114,6 +158,51 @@ func (mr *MockUserRepositoryMockRecorder) List() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserRepository)(nil).List))
 }
 
+// ListByCursor mocks base method.
+func (m *MockUserRepository) ListByCursor(query repository.UserListQuery, cursor repository.CursorRequest) (repository.CursorResult[domain.User], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByCursor", query, cursor)
+	ret0, _ := ret[0].(repository.CursorResult[domain.User])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByCursor indicates an expected call of ListByCursor.
+func (mr *MockUserRepositoryMockRecorder) ListByCursor(query, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByCursor", reflect.TypeOf((*MockUserRepository)(nil).ListByCursor), query, cursor)
+}
+
+// ListDeleted mocks base method.
+func (m *MockUserRepository) ListDeleted(req repository.PageRequest) (repository.PageResult[domain.User], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeleted", req)
+	ret0, _ := ret[0].(repository.PageResult[domain.User])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeleted indicates an expected call of ListDeleted.
+func (mr *MockUserRepositoryMockRecorder) ListDeleted(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeleted", reflect.TypeOf((*MockUserRepository)(nil).ListDeleted), req)
+}
+
+// ListExpiredRoleGrants mocks base method.
+func (m *MockUserRepository) ListExpiredRoleGrants(now time.Time, limit int) ([]domain.UserRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredRoleGrants", now, limit)
+	ret0, _ := ret[0].([]domain.UserRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredRoleGrants indicates an expected call of ListExpiredRoleGrants.
+func (mr *MockUserRepositoryMockRecorder) ListExpiredRoleGrants(now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredRoleGrants", reflect.TypeOf((*MockUserRepository)(nil).ListExpiredRoleGrants), now, limit)
+}
+
 // ListPaged mocks base method.
 func (m *MockUserRepository) ListPaged(query repository.UserListQuery) (repository.PageResult[domain.User], error) {
 	m.ctrl.T.Helper()
@@ -129,6 +218,64 @@ func (mr *MockUserRepositoryMockRecorder) ListPaged(query any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaged", reflect.TypeOf((*MockUserRepository)(nil).ListPaged), query)
 }
 
+// ListRoleGrants mocks base method.
+func (m *MockUserRepository) ListRoleGrants(userID uint) ([]domain.UserRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoleGrants", userID)
+	ret0, _ := ret[0].([]domain.UserRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoleGrants indicates an expected call of ListRoleGrants.
+func (mr *MockUserRepositoryMockRecorder) ListRoleGrants(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoleGrants", reflect.TypeOf((*MockUserRepository)(nil).ListRoleGrants), userID)
+}
+
+// Purge mocks base method.
+func (m *MockUserRepository) Purge(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockUserRepositoryMockRecorder) Purge(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockUserRepository)(nil).Purge), id)
+}
+
+// PurgeDeletedBefore mocks base method.
+func (m *MockUserRepository) PurgeDeletedBefore(cutoff time.Time, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedBefore", cutoff, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedBefore indicates an expected call of PurgeDeletedBefore.
+func (mr *MockUserRepositoryMockRecorder) PurgeDeletedBefore(cutoff, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedBefore", reflect.TypeOf((*MockUserRepository)(nil).PurgeDeletedBefore), cutoff, limit)
+}
+
+// Restore mocks base method.
+func (m *MockUserRepository) Restore(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockUserRepositoryMockRecorder) Restore(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockUserRepository)(nil).Restore), id)
+}
+
 // SetRoles mocks base method.
 func (m *MockUserRepository) SetRoles(userID uint, roleIDs []uint) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+)
+
+var (
+	ErrGroupNotFound       = errors.New("group not found")
+	ErrGroupMemberNotFound = errors.New("group member not found")
+	ErrGroupUserNotFound   = errors.New("one or more users not found")
+)
+
+type GroupRepository interface {
+	Create(group *domain.Group, roleIDs []uint) error
+	FindByID(id uint) (*domain.Group, error)
+	ListPaged(req PageRequest, name string) (PageResult[domain.Group], error)
+	Update(group *domain.Group, roleIDs []uint) error
+	DeleteByID(id uint) error
+	ListMemberIDs(groupID uint) ([]uint, error)
+	AddMembers(groupID uint, userIDs []uint) ([]uint, error)
+	RemoveMember(groupID, userID uint) error
+}
+
+type GormGroupRepository struct{ db *gorm.DB }
+
+func NewGroupRepository(db *gorm.DB) GroupRepository { return &GormGroupRepository{db: db} }
+
+func (r *GormGroupRepository) Create(group *domain.Group, roleIDs []uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		roles, err := findRolesByIDs(tx, roleIDs)
+		if err != nil {
+			return err
+		}
+		group.Roles = roles
+		return tx.Create(group).Error
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "group", "create", "error")
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "group", "create", "success")
+	return nil
+}
+
+func (r *GormGroupRepository) FindByID(id uint) (*domain.Group, error) {
+	var group domain.Group
+	if err := r.db.Preload("Roles").First(&group, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			observability.RecordRepositoryOperation(context.Background(), "group", "find_by_id", "not_found")
+			return nil, ErrGroupNotFound
+		}
+		observability.RecordRepositoryOperation(context.Background(), "group", "find_by_id", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "group", "find_by_id", "success")
+	return &group, nil
+}
+
+func (r *GormGroupRepository) ListPaged(req PageRequest, name string) (PageResult[domain.Group], error) {
+	normalized := normalizePageRequest(req)
+	result := PageResult[domain.Group]{
+		Page:     normalized.Page,
+		PageSize: normalized.PageSize,
+	}
+	base := r.db.Model(&domain.Group{})
+	if name = strings.TrimSpace(name); name != "" {
+		base = base.Where("name LIKE ?", name+"%")
+	}
+	if err := base.Session(&gorm.Session{}).Count(&result.Total).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "group", "list_paged", "error")
+		return PageResult[domain.Group]{}, err
+	}
+	offset := (normalized.Page - 1) * normalized.PageSize
+	if err := base.Preload("Roles").Order("name asc").Offset(offset).Limit(normalized.PageSize).Find(&result.Items).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "group", "list_paged", "error")
+		return PageResult[domain.Group]{}, err
+	}
+	result.TotalPages = calcTotalPages(result.Total, normalized.PageSize)
+	observability.RecordRepositoryOperation(context.Background(), "group", "list_paged", "success")
+	return result, nil
+}
+
+func (r *GormGroupRepository) Update(group *domain.Group, roleIDs []uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var existing domain.Group
+		if err := tx.First(&existing, group.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrGroupNotFound
+			}
+			return err
+		}
+		if err := tx.Model(&existing).Updates(map[string]any{
+			"name":        group.Name,
+			"description": group.Description,
+		}).Error; err != nil {
+			return err
+		}
+		roles, err := findRolesByIDs(tx, roleIDs)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&existing).Association("Roles").Replace(roles); err != nil {
+			return err
+		}
+		group.Roles = roles
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrGroupNotFound) {
+			observability.RecordRepositoryOperation(context.Background(), "group", "update", "not_found")
+		} else {
+			observability.RecordRepositoryOperation(context.Background(), "group", "update", "error")
+		}
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "group", "update", "success")
+	return nil
+}
+
+func (r *GormGroupRepository) DeleteByID(id uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		group := domain.Group{ID: id}
+		if err := tx.Model(&group).Association("Roles").Clear(); err != nil {
+			return err
+		}
+		if err := tx.Where("group_id = ?", id).Delete(&domain.GroupMember{}).Error; err != nil {
+			return err
+		}
+		res := tx.Delete(&domain.Group{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrGroupNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrGroupNotFound) {
+			observability.RecordRepositoryOperation(context.Background(), "group", "delete_by_id", "not_found")
+		} else {
+			observability.RecordRepositoryOperation(context.Background(), "group", "delete_by_id", "error")
+		}
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "group", "delete_by_id", "success")
+	return nil
+}
+
+func (r *GormGroupRepository) ListMemberIDs(groupID uint) ([]uint, error) {
+	var ids []uint
+	if err := r.db.Model(&domain.GroupMember{}).Where("group_id = ?", groupID).Order("user_id asc").Pluck("user_id", &ids).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "group", "list_member_ids", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "group", "list_member_ids", "success")
+	return ids, nil
+}
+
+// AddMembers adds users to the group and returns the IDs that were not
+// already members.
+func (r *GormGroupRepository) AddMembers(groupID uint, userIDs []uint) ([]uint, error) {
+	userIDs = uniqueUint(userIDs)
+	var added []uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var groupCount int64
+		if err := tx.Model(&domain.Group{}).Where("id = ?", groupID).Count(&groupCount).Error; err != nil {
+			return err
+		}
+		if groupCount == 0 {
+			return ErrGroupNotFound
+		}
+		if len(userIDs) == 0 {
+			return nil
+		}
+		var userCount int64
+		if err := tx.Model(&domain.User{}).Where("id IN ?", userIDs).Count(&userCount).Error; err != nil {
+			return err
+		}
+		if int(userCount) != len(userIDs) {
+			return ErrGroupUserNotFound
+		}
+		for _, userID := range userIDs {
+			res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&domain.GroupMember{GroupID: groupID, UserID: userID})
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected > 0 {
+				added = append(added, userID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrGroupNotFound) {
+			observability.RecordRepositoryOperation(context.Background(), "group", "add_members", "not_found")
+		} else {
+			observability.RecordRepositoryOperation(context.Background(), "group", "add_members", "error")
+		}
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "group", "add_members", "success")
+	return added, nil
+}
+
+func (r *GormGroupRepository) RemoveMember(groupID, userID uint) error {
+	res := r.db.Where("group_id = ? AND user_id = ?", groupID, userID).Delete(&domain.GroupMember{})
+	if res.Error != nil {
+		observability.RecordRepositoryOperation(context.Background(), "group", "remove_member", "error")
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		observability.RecordRepositoryOperation(context.Background(), "group", "remove_member", "not_found")
+		return ErrGroupMemberNotFound
+	}
+	observability.RecordRepositoryOperation(context.Background(), "group", "remove_member", "success")
+	return nil
+}
+
+func findRolesByIDs(tx *gorm.DB, roleIDs []uint) ([]domain.Role, error) {
+	roleIDs = uniqueUint(roleIDs)
+	roles := make([]domain.Role, 0, len(roleIDs))
+	if len(roleIDs) == 0 {
+		return roles, nil
+	}
+	if err := tx.Where("id IN ?", roleIDs).Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	if len(roles) != len(roleIDs) {
+		return nil, ErrRoleNotFound
+	}
+	return roles, nil
+}
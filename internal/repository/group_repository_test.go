@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+)
+
+func TestGroupRepositoryMembershipAndEffectiveRoles(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	repo := NewGroupRepository(db)
+	userRepo := NewUserRepository(db)
+	roleRepo := NewRoleRepository(db)
+
+	perm := &domain.Permission{Resource: "products", Action: "write"}
+	if err := db.Create(perm).Error; err != nil {
+		t.Fatalf("create permission: %v", err)
+	}
+	engineer := &domain.Role{Name: "engineer"}
+	if err := roleRepo.Create(engineer, []uint{perm.ID}); err != nil {
+		t.Fatalf("create role: %v", err)
+	}
+	alice := &domain.User{Email: "alice@example.com", Name: "Alice", Status: "active"}
+	bob := &domain.User{Email: "bob@example.com", Name: "Bob", Status: "active"}
+	for _, u := range []*domain.User{alice, bob} {
+		if err := userRepo.Create(u); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+
+	group := &domain.Group{Name: "platform", Description: "Platform engineers"}
+	if err := repo.Create(group, []uint{engineer.ID}); err != nil {
+		t.Fatalf("create group: %v", err)
+	}
+	if err := repo.Create(&domain.Group{Name: "bad"}, []uint{999}); !errors.Is(err, ErrRoleNotFound) {
+		t.Fatalf("expected ErrRoleNotFound, got %v", err)
+	}
+
+	added, err := repo.AddMembers(group.ID, []uint{alice.ID, alice.ID})
+	if err != nil {
+		t.Fatalf("add members: %v", err)
+	}
+	if len(added) != 1 || added[0] != alice.ID {
+		t.Fatalf("expected alice added once, got %v", added)
+	}
+	added, err = repo.AddMembers(group.ID, []uint{alice.ID, bob.ID})
+	if err != nil {
+		t.Fatalf("add members again: %v", err)
+	}
+	if len(added) != 1 || added[0] != bob.ID {
+		t.Fatalf("expected only bob newly added, got %v", added)
+	}
+	if _, err := repo.AddMembers(group.ID, []uint{999}); !errors.Is(err, ErrGroupUserNotFound) {
+		t.Fatalf("expected ErrGroupUserNotFound, got %v", err)
+	}
+	if _, err := repo.AddMembers(999, []uint{alice.ID}); !errors.Is(err, ErrGroupNotFound) {
+		t.Fatalf("expected ErrGroupNotFound, got %v", err)
+	}
+
+	found, err := userRepo.FindByID(alice.ID)
+	if err != nil {
+		t.Fatalf("find alice: %v", err)
+	}
+	if len(found.Roles) != 0 || len(found.GroupRoles) != 1 || len(found.GroupRoles[0].Permissions) != 1 {
+		t.Fatalf("expected engineer inherited through group, roles=%+v group_roles=%+v", found.Roles, found.GroupRoles)
+	}
+
+	page, err := userRepo.ListPaged(UserListQuery{PageRequest: PageRequest{Page: 1, PageSize: 10}, GroupID: group.ID})
+	if err != nil {
+		t.Fatalf("list group users: %v", err)
+	}
+	if page.Total != 2 {
+		t.Fatalf("expected 2 group members, got %d", page.Total)
+	}
+
+	if err := repo.RemoveMember(group.ID, alice.ID); err != nil {
+		t.Fatalf("remove member: %v", err)
+	}
+	if err := repo.RemoveMember(group.ID, alice.ID); !errors.Is(err, ErrGroupMemberNotFound) {
+		t.Fatalf("expected ErrGroupMemberNotFound, got %v", err)
+	}
+	found, err = userRepo.FindByID(alice.ID)
+	if err != nil {
+		t.Fatalf("find alice after removal: %v", err)
+	}
+	if len(found.GroupRoles) != 0 {
+		t.Fatalf("expected no group roles after removal, got %+v", found.GroupRoles)
+	}
+
+	group.Name = "platform-eng"
+	if err := repo.Update(group, nil); err != nil {
+		t.Fatalf("update group: %v", err)
+	}
+	reloaded, err := repo.FindByID(group.ID)
+	if err != nil {
+		t.Fatalf("find group: %v", err)
+	}
+	if reloaded.Name != "platform-eng" || len(reloaded.Roles) != 0 {
+		t.Fatalf("unexpected updated group: %+v", reloaded)
+	}
+
+	memberIDs, err := repo.ListMemberIDs(group.ID)
+	if err != nil || len(memberIDs) != 1 || memberIDs[0] != bob.ID {
+		t.Fatalf("list member ids: ids=%v err=%v", memberIDs, err)
+	}
+	if err := repo.DeleteByID(group.ID); err != nil {
+		t.Fatalf("delete group: %v", err)
+	}
+	if memberIDs, _ := repo.ListMemberIDs(group.ID); len(memberIDs) != 0 {
+		t.Fatalf("expected members removed with group, got %v", memberIDs)
+	}
+	if err := repo.DeleteByID(group.ID); !errors.Is(err, ErrGroupNotFound) {
+		t.Fatalf("expected ErrGroupNotFound, got %v", err)
+	}
+}
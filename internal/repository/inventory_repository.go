@@ -0,0 +1,295 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+)
+
+var (
+	ErrInsufficientStock    = errors.New("insufficient stock")
+	ErrReservationNotFound  = errors.New("reservation not found")
+	ErrReservationNotActive = errors.New("reservation is no longer active")
+)
+
+// InventoryRepository keeps product stock levels, reservations and the stock
+// movement ledger. Every change to a stock row is a single conditional
+// UPDATE, so concurrent writers are serialized by the row lock and a check
+// such as "enough units available" can never be bypassed by a racing
+// request. Each change appends a domain.StockMovement in the same
+// transaction.
+type InventoryRepository interface {
+	// GetStock returns the product's stock. Products that never had stock
+	// report zero levels.
+	GetStock(productID uint) (*domain.ProductStock, error)
+	// Adjust adds delta to the on-hand stock. ErrInsufficientStock is
+	// returned when the result would fall below the reserved quantity.
+	Adjust(productID uint, delta int64, actorID *uint, reason string) (*domain.ProductStock, error)
+	// Reserve holds reservation.Quantity units if that many are available and
+	// stores the reservation. ErrInsufficientStock is returned otherwise.
+	Reserve(reservation *domain.StockReservation) error
+	FindReservation(id uint) (*domain.StockReservation, error)
+	// CommitReservation turns held units into a sale by removing them from
+	// on-hand stock. Committing twice is a no-op; reservations that were
+	// released or have passed ExpiresAt fail with ErrReservationNotActive.
+	CommitReservation(id uint, actorID *uint, now time.Time) (*domain.StockReservation, error)
+	// ReleaseReservation returns held units to available stock. Releasing
+	// twice is a no-op; committed reservations fail with
+	// ErrReservationNotActive.
+	ReleaseReservation(id uint, actorID *uint, now time.Time) (*domain.StockReservation, error)
+	// ReleaseExpired marks up to limit active reservations that expired
+	// before now as expired, returns their units and reports how many it
+	// released.
+	ReleaseExpired(now time.Time, limit int) (int, error)
+	// ListMovements returns a page of the product's ledger, newest first.
+	ListMovements(productID uint, req PageRequest) (PageResult[domain.StockMovement], error)
+}
+
+type GormInventoryRepository struct{ db *gorm.DB }
+
+func NewInventoryRepository(db *gorm.DB) InventoryRepository {
+	return &GormInventoryRepository{db: db}
+}
+
+func (r *GormInventoryRepository) GetStock(productID uint) (*domain.ProductStock, error) {
+	stock := domain.ProductStock{ProductID: productID}
+	err := r.db.Where("product_id = ?", productID).Take(&stock).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		observability.RecordRepositoryOperation(context.Background(), "inventory", "get_stock", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "inventory", "get_stock", "success")
+	return &stock, nil
+}
+
+func (r *GormInventoryRepository) Adjust(productID uint, delta int64, actorID *uint, reason string) (*domain.ProductStock, error) {
+	var stock *domain.ProductStock
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		stock, err = applyStockMovement(tx, &domain.StockMovement{
+			ProductID:   productID,
+			Kind:        domain.StockMovementAdjust,
+			OnHandDelta: delta,
+			Reason:      reason,
+			ActorUserID: actorID,
+		}, "on_hand + ? >= reserved", delta)
+		return err
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "inventory", "adjust", inventoryOutcome(err))
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "inventory", "adjust", "success")
+	return stock, nil
+}
+
+func (r *GormInventoryRepository) Reserve(reservation *domain.StockReservation) error {
+	reservation.Status = domain.ReservationActive
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(reservation).Error; err != nil {
+			return err
+		}
+		_, err := applyStockMovement(tx, &domain.StockMovement{
+			ProductID:     reservation.ProductID,
+			ReservationID: &reservation.ID,
+			Kind:          domain.StockMovementReserve,
+			ReservedDelta: reservation.Quantity,
+			ActorUserID:   reservation.CreatedBy,
+		}, "on_hand - reserved >= ?", reservation.Quantity)
+		return err
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "inventory", "reserve", inventoryOutcome(err))
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "inventory", "reserve", "success")
+	return nil
+}
+
+func (r *GormInventoryRepository) FindReservation(id uint) (*domain.StockReservation, error) {
+	var reservation domain.StockReservation
+	if err := r.db.First(&reservation, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			observability.RecordRepositoryOperation(context.Background(), "inventory", "find_reservation", "not_found")
+			return nil, ErrReservationNotFound
+		}
+		observability.RecordRepositoryOperation(context.Background(), "inventory", "find_reservation", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "inventory", "find_reservation", "success")
+	return &reservation, nil
+}
+
+func (r *GormInventoryRepository) CommitReservation(id uint, actorID *uint, now time.Time) (*domain.StockReservation, error) {
+	reservation, err := r.finishReservation(id, domain.ReservationCommitted, actorID, now)
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "inventory", "commit_reservation", inventoryOutcome(err))
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "inventory", "commit_reservation", "success")
+	return reservation, nil
+}
+
+func (r *GormInventoryRepository) ReleaseReservation(id uint, actorID *uint, now time.Time) (*domain.StockReservation, error) {
+	reservation, err := r.finishReservation(id, domain.ReservationReleased, actorID, now)
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "inventory", "release_reservation", inventoryOutcome(err))
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "inventory", "release_reservation", "success")
+	return reservation, nil
+}
+
+func (r *GormInventoryRepository) ReleaseExpired(now time.Time, limit int) (int, error) {
+	var ids []uint
+	err := r.db.Model(&domain.StockReservation{}).
+		Where("status = ? AND expires_at <= ?", domain.ReservationActive, now.UTC()).
+		Order("expires_at asc").Limit(limit).Pluck("id", &ids).Error
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "inventory", "release_expired", "error")
+		return 0, err
+	}
+	released := 0
+	for _, id := range ids {
+		_, err := r.finishReservation(id, domain.ReservationExpired, nil, now)
+		switch {
+		case err == nil:
+			released++
+		case errors.Is(err, ErrReservationNotActive):
+			// Committed or released since it was selected.
+		default:
+			observability.RecordRepositoryOperation(context.Background(), "inventory", "release_expired", "error")
+			return released, err
+		}
+	}
+	observability.RecordRepositoryOperation(context.Background(), "inventory", "release_expired", "success")
+	return released, nil
+}
+
+func (r *GormInventoryRepository) ListMovements(productID uint, req PageRequest) (PageResult[domain.StockMovement], error) {
+	normalized := normalizePageRequest(req)
+	result := PageResult[domain.StockMovement]{
+		Page:     normalized.Page,
+		PageSize: normalized.PageSize,
+	}
+	base := r.db.Model(&domain.StockMovement{}).Where("product_id = ?", productID)
+	if err := base.Session(&gorm.Session{}).Count(&result.Total).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "inventory", "list_movements", "error")
+		return PageResult[domain.StockMovement]{}, err
+	}
+	offset := (normalized.Page - 1) * normalized.PageSize
+	if err := base.Order("created_at desc").Order("id desc").Offset(offset).Limit(normalized.PageSize).Find(&result.Items).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "inventory", "list_movements", "error")
+		return PageResult[domain.StockMovement]{}, err
+	}
+	result.TotalPages = calcTotalPages(result.Total, normalized.PageSize)
+	observability.RecordRepositoryOperation(context.Background(), "inventory", "list_movements", "success")
+	return result, nil
+}
+
+// finishReservation moves an active reservation to status and settles its
+// held units. The status change is conditional on the reservation still
+// being active, so of two racing requests only one settles the stock.
+// Commits additionally require the reservation not to have expired.
+func (r *GormInventoryRepository) finishReservation(id uint, status string, actorID *uint, now time.Time) (*domain.StockReservation, error) {
+	var reservation domain.StockReservation
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		q := tx.Model(&domain.StockReservation{}).Where("id = ? AND status = ?", id, domain.ReservationActive)
+		if status == domain.ReservationCommitted {
+			q = q.Where("expires_at > ?", now.UTC())
+		}
+		res := q.Updates(map[string]any{"status": status, "updated_at": now.UTC()})
+		if res.Error != nil {
+			return res.Error
+		}
+		if err := tx.First(&reservation, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrReservationNotFound
+			}
+			return err
+		}
+		if res.RowsAffected == 0 {
+			if reservation.Status == status {
+				return nil
+			}
+			return ErrReservationNotActive
+		}
+		movement := &domain.StockMovement{
+			ProductID:     reservation.ProductID,
+			ReservationID: &reservation.ID,
+			ReservedDelta: -reservation.Quantity,
+			ActorUserID:   actorID,
+		}
+		switch status {
+		case domain.ReservationCommitted:
+			movement.Kind = domain.StockMovementCommit
+			movement.OnHandDelta = -reservation.Quantity
+		case domain.ReservationExpired:
+			movement.Kind = domain.StockMovementExpire
+		default:
+			movement.Kind = domain.StockMovementRelease
+		}
+		_, err := applyStockMovement(tx, movement, "")
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// ensureStockRow creates an empty stock row for the product if none exists
+// so conditional updates always have a row to match.
+func ensureStockRow(tx *gorm.DB, productID uint) error {
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&domain.ProductStock{ProductID: productID, UpdatedAt: time.Now().UTC()}).Error
+}
+
+// applyStockMovement applies the movement's deltas to the stock row, guarded
+// by the optional condition, and records it in the ledger.
+func applyStockMovement(tx *gorm.DB, movement *domain.StockMovement, guard string, guardArgs ...any) (*domain.ProductStock, error) {
+	if err := ensureStockRow(tx, movement.ProductID); err != nil {
+		return nil, err
+	}
+	q := tx.Model(&domain.ProductStock{}).Where("product_id = ?", movement.ProductID)
+	if guard != "" {
+		q = q.Where(guard, guardArgs...)
+	}
+	res := q.Updates(map[string]any{
+		"on_hand":    gorm.Expr("on_hand + ?", movement.OnHandDelta),
+		"reserved":   gorm.Expr("reserved + ?", movement.ReservedDelta),
+		"updated_at": time.Now().UTC(),
+	})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, ErrInsufficientStock
+	}
+	var stock domain.ProductStock
+	if err := tx.Where("product_id = ?", movement.ProductID).Take(&stock).Error; err != nil {
+		return nil, err
+	}
+	movement.OnHandAfter, movement.ReservedAfter = stock.OnHand, stock.Reserved
+	if err := tx.Create(movement).Error; err != nil {
+		return nil, err
+	}
+	return &stock, nil
+}
+
+func inventoryOutcome(err error) string {
+	switch {
+	case errors.Is(err, ErrReservationNotFound):
+		return "not_found"
+	case errors.Is(err, ErrInsufficientStock), errors.Is(err, ErrReservationNotActive):
+		return "conflict"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+)
+
+func newInventoryDBForTest(t *testing.T) InventoryRepository {
+	t.Helper()
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.ProductStock{}, &domain.StockReservation{}, &domain.StockMovement{}); err != nil {
+		t.Fatalf("migrate inventory: %v", err)
+	}
+	return NewInventoryRepository(db)
+}
+
+func TestInventoryRepositoryReservationLifecycle(t *testing.T) {
+	repo := newInventoryDBForTest(t)
+	actor := uint(7)
+	now := time.Now().UTC()
+
+	empty, err := repo.GetStock(1)
+	if err != nil || empty.OnHand != 0 || empty.Available() != 0 {
+		t.Fatalf("expected empty stock, got %+v err=%v", empty, err)
+	}
+	if _, err := repo.Adjust(1, -1, &actor, "shrinkage"); !errors.Is(err, ErrInsufficientStock) {
+		t.Fatalf("expected negative stock to be rejected, got %v", err)
+	}
+	stock, err := repo.Adjust(1, 10, &actor, "received")
+	if err != nil || stock.OnHand != 10 {
+		t.Fatalf("adjust: %+v err=%v", stock, err)
+	}
+
+	first := &domain.StockReservation{ProductID: 1, Quantity: 6, ExpiresAt: now.Add(time.Minute), CreatedBy: &actor}
+	if err := repo.Reserve(first); err != nil {
+		t.Fatalf("reserve first: %v", err)
+	}
+	if err := repo.Reserve(&domain.StockReservation{ProductID: 1, Quantity: 5, ExpiresAt: now.Add(time.Minute)}); !errors.Is(err, ErrInsufficientStock) {
+		t.Fatalf("expected oversell to be rejected, got %v", err)
+	}
+	if _, err := repo.Adjust(1, -5, &actor, "damaged"); !errors.Is(err, ErrInsufficientStock) {
+		t.Fatalf("expected adjustment below reserved to be rejected, got %v", err)
+	}
+	second := &domain.StockReservation{ProductID: 1, Quantity: 4, ExpiresAt: now.Add(time.Minute)}
+	if err := repo.Reserve(second); err != nil {
+		t.Fatalf("reserve second: %v", err)
+	}
+
+	committed, err := repo.CommitReservation(first.ID, &actor, now)
+	if err != nil || committed.Status != domain.ReservationCommitted {
+		t.Fatalf("commit: %+v err=%v", committed, err)
+	}
+	if _, err := repo.CommitReservation(first.ID, &actor, now); err != nil {
+		t.Fatalf("expected repeated commit to be a no-op, got %v", err)
+	}
+	if _, err := repo.ReleaseReservation(first.ID, &actor, now); !errors.Is(err, ErrReservationNotActive) {
+		t.Fatalf("expected committed reservation not to be released, got %v", err)
+	}
+	if _, err := repo.ReleaseReservation(second.ID, &actor, now); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if _, err := repo.ReleaseReservation(second.ID, &actor, now); err != nil {
+		t.Fatalf("expected repeated release to be a no-op, got %v", err)
+	}
+	if _, err := repo.CommitReservation(second.ID, &actor, now); !errors.Is(err, ErrReservationNotActive) {
+		t.Fatalf("expected released reservation not to be committed, got %v", err)
+	}
+	if _, err := repo.CommitReservation(999, &actor, now); !errors.Is(err, ErrReservationNotFound) {
+		t.Fatalf("expected ErrReservationNotFound, got %v", err)
+	}
+
+	stock, err = repo.GetStock(1)
+	if err != nil || stock.OnHand != 4 || stock.Reserved != 0 {
+		t.Fatalf("expected 4 on hand and nothing reserved, got %+v err=%v", stock, err)
+	}
+	ledger, err := repo.ListMovements(1, PageRequest{Page: 1, PageSize: 20})
+	if err != nil {
+		t.Fatalf("list movements: %v", err)
+	}
+	// adjust, reserve, reserve, commit, release; rejected writes leave no trace.
+	if ledger.Total != 5 || ledger.Items[0].Kind != domain.StockMovementRelease || ledger.Items[0].OnHandAfter != 4 {
+		t.Fatalf("unexpected ledger: %+v", ledger.Items)
+	}
+	commit := ledger.Items[1]
+	if commit.Kind != domain.StockMovementCommit || commit.OnHandDelta != -6 || commit.ReservedDelta != -6 || commit.ReservationID == nil || *commit.ReservationID != first.ID {
+		t.Fatalf("unexpected commit movement: %+v", commit)
+	}
+}
+
+func TestInventoryRepositoryReleasesExpiredReservations(t *testing.T) {
+	repo := newInventoryDBForTest(t)
+	now := time.Now().UTC()
+	if _, err := repo.Adjust(1, 5, nil, "received"); err != nil {
+		t.Fatalf("adjust: %v", err)
+	}
+	stale := &domain.StockReservation{ProductID: 1, Quantity: 2, ExpiresAt: now.Add(-time.Second)}
+	fresh := &domain.StockReservation{ProductID: 1, Quantity: 2, ExpiresAt: now.Add(time.Hour)}
+	for _, r := range []*domain.StockReservation{stale, fresh} {
+		if err := repo.Reserve(r); err != nil {
+			t.Fatalf("reserve: %v", err)
+		}
+	}
+	if _, err := repo.CommitReservation(stale.ID, nil, now); !errors.Is(err, ErrReservationNotActive) {
+		t.Fatalf("expected expired reservation not to be committed, got %v", err)
+	}
+
+	released, err := repo.ReleaseExpired(now, 10)
+	if err != nil || released != 1 {
+		t.Fatalf("expected one expired reservation released, got %d err=%v", released, err)
+	}
+	loaded, err := repo.FindReservation(stale.ID)
+	if err != nil || loaded.Status != domain.ReservationExpired {
+		t.Fatalf("expected expired status, got %+v err=%v", loaded, err)
+	}
+	stock, err := repo.GetStock(1)
+	if err != nil || stock.Reserved != 2 || stock.Available() != 3 {
+		t.Fatalf("expected only the fresh reservation held, got %+v err=%v", stock, err)
+	}
+}
+
+func TestInventoryRepositoryConcurrentReservationsDoNotOversell(t *testing.T) {
+	repo := newInventoryDBForTest(t)
+	if _, err := repo.Adjust(1, 10, nil, "received"); err != nil {
+		t.Fatalf("adjust: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded, rejected := 0, 0
+	for i := 0; i < 25; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := repo.Reserve(&domain.StockReservation{ProductID: 1, Quantity: 1, ExpiresAt: time.Now().Add(time.Minute)})
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				succeeded++
+			case errors.Is(err, ErrInsufficientStock):
+				rejected++
+			default:
+				// SQLite reports lock contention as an error; such attempts
+				// neither reserve nor oversell.
+			}
+		}()
+	}
+	wg.Wait()
+
+	stock, err := repo.GetStock(1)
+	if err != nil {
+		t.Fatalf("get stock: %v", err)
+	}
+	if stock.Reserved != int64(succeeded) || stock.Reserved > 10 || stock.Available() < 0 {
+		t.Fatalf("oversold: %+v after %d successful reservations", stock, succeeded)
+	}
+}
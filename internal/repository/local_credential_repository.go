@@ -42,7 +42,7 @@ func (r *GormLocalCredentialRepository) FindByEmail(email string) (*domain.Local
 	normalized := strings.TrimSpace(strings.ToLower(email))
 	err := r.db.
 		Joins("JOIN users ON users.id = local_credentials.user_id").
-		Where("users.email = ?", normalized).
+		Where("users.email = ? AND users.deleted_at IS NULL", normalized).
 		First(&c).Error
 	if err != nil {
 		return nil, err
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+)
+
+var (
+	ErrOrderNotFound       = errors.New("order not found")
+	ErrOrderStatusConflict = errors.New("order status changed concurrently")
+)
+
+// OrderListQuery filters order lists. A zero UserID lists every user's
+// orders, a nil OrganizationID every organization's and an empty Status
+// every status.
+type OrderListQuery struct {
+	PageRequest
+	UserID         uint
+	OrganizationID *uint
+	Status         string
+}
+
+type OrderRepository interface {
+	// CreateFromCart stores the order and its items and empties cartID in the
+	// same transaction, so a cart is never checked out twice.
+	CreateFromCart(order *domain.Order, cartID uint) error
+	FindByID(id uint) (*domain.Order, error)
+	// ListPaged returns orders newest first.
+	ListPaged(query OrderListQuery) (PageResult[domain.Order], error)
+	// UpdateStatus moves the order from status from to status to and applies
+	// updates in one conditional statement. ErrOrderStatusConflict is
+	// returned when the order is no longer in status from.
+	UpdateStatus(id uint, from, to string, updates map[string]any) (*domain.Order, error)
+}
+
+type GormOrderRepository struct{ db *gorm.DB }
+
+func NewOrderRepository(db *gorm.DB) OrderRepository {
+	return &GormOrderRepository{db: db}
+}
+
+func (r *GormOrderRepository) CreateFromCart(order *domain.Order, cartID uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("cart_id = ?", cartID).Delete(&domain.CartItem{})
+		if res.Error != nil {
+			return res.Error
+		}
+		// A concurrent checkout already emptied the cart.
+		if res.RowsAffected == 0 {
+			return ErrCartNotFound
+		}
+		return tx.Create(order).Error
+	})
+	if err != nil {
+		outcome := "error"
+		if errors.Is(err, ErrCartNotFound) {
+			outcome = "conflict"
+		}
+		observability.RecordRepositoryOperation(context.Background(), "order", "create", outcome)
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "order", "create", "success")
+	return nil
+}
+
+func (r *GormOrderRepository) FindByID(id uint) (*domain.Order, error) {
+	order, err := r.findByID(r.db, id)
+	if err != nil {
+		outcome := "error"
+		if errors.Is(err, ErrOrderNotFound) {
+			outcome = "not_found"
+		}
+		observability.RecordRepositoryOperation(context.Background(), "order", "find_by_id", outcome)
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "order", "find_by_id", "success")
+	return order, nil
+}
+
+func (r *GormOrderRepository) ListPaged(query OrderListQuery) (PageResult[domain.Order], error) {
+	normalized := normalizePageRequest(query.PageRequest)
+	result := PageResult[domain.Order]{
+		Page:     normalized.Page,
+		PageSize: normalized.PageSize,
+	}
+	base := r.db.Model(&domain.Order{})
+	if query.UserID != 0 {
+		base = base.Where("user_id = ?", query.UserID)
+	}
+	if query.OrganizationID != nil {
+		base = base.Where("organization_id = ?", *query.OrganizationID)
+	}
+	if query.Status != "" {
+		base = base.Where("status = ?", query.Status)
+	}
+	if err := base.Session(&gorm.Session{}).Count(&result.Total).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "order", "list_paged", "error")
+		return PageResult[domain.Order]{}, err
+	}
+	offset := (normalized.Page - 1) * normalized.PageSize
+	if err := base.Preload("Items", orderItemsOrder).
+		Order("created_at desc").Order("id desc").
+		Offset(offset).Limit(normalized.PageSize).Find(&result.Items).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "order", "list_paged", "error")
+		return PageResult[domain.Order]{}, err
+	}
+	result.TotalPages = calcTotalPages(result.Total, normalized.PageSize)
+	observability.RecordRepositoryOperation(context.Background(), "order", "list_paged", "success")
+	return result, nil
+}
+
+func (r *GormOrderRepository) UpdateStatus(id uint, from, to string, updates map[string]any) (*domain.Order, error) {
+	var order *domain.Order
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		values := map[string]any{"status": to, "updated_at": time.Now().UTC()}
+		for k, v := range updates {
+			values[k] = v
+		}
+		res := tx.Model(&domain.Order{}).Where("id = ? AND status = ?", id, from).Updates(values)
+		if res.Error != nil {
+			return res.Error
+		}
+		var err error
+		if order, err = r.findByID(tx, id); err != nil {
+			return err
+		}
+		if res.RowsAffected == 0 {
+			return ErrOrderStatusConflict
+		}
+		return nil
+	})
+	if err != nil {
+		outcome := "error"
+		switch {
+		case errors.Is(err, ErrOrderNotFound):
+			outcome = "not_found"
+		case errors.Is(err, ErrOrderStatusConflict):
+			outcome = "conflict"
+		}
+		observability.RecordRepositoryOperation(context.Background(), "order", "update_status", outcome)
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "order", "update_status", "success")
+	return order, nil
+}
+
+func (r *GormOrderRepository) findByID(db *gorm.DB, id uint) (*domain.Order, error) {
+	var order domain.Order
+	if err := db.Preload("Items", orderItemsOrder).First(&order, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return &order, nil
+}
+
+func orderItemsOrder(db *gorm.DB) *gorm.DB { return db.Order("id asc") }
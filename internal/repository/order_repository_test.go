@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+)
+
+func TestOrderRepositoryCheckoutAndStatusTransitions(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.Cart{}, &domain.CartItem{}, &domain.Order{}, &domain.OrderItem{}); err != nil {
+		t.Fatalf("migrate orders: %v", err)
+	}
+	carts := NewCartRepository(db)
+	orders := NewOrderRepository(db)
+
+	userID := uint(1)
+	cart := &domain.Cart{UserID: &userID}
+	if err := carts.Create(cart); err != nil {
+		t.Fatalf("create cart: %v", err)
+	}
+	_ = carts.SetItem(cart.ID, 10, 2)
+
+	order := &domain.Order{
+		UserID:     userID,
+		Status:     domain.OrderPending,
+		Currency:   "USD",
+		TotalMinor: 3000,
+		Items:      []domain.OrderItem{{ProductID: 10, Name: "Widget", UnitPriceMinor: 1500, Quantity: 2, LineTotalMinor: 3000}},
+	}
+	if err := orders.CreateFromCart(order, cart.ID); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if loaded, _ := carts.FindByUser(userID); len(loaded.Items) != 0 {
+		t.Fatalf("expected cart emptied by checkout, got %+v", loaded.Items)
+	}
+	if err := orders.CreateFromCart(&domain.Order{UserID: userID, Status: domain.OrderPending, Currency: "USD"}, cart.ID); !errors.Is(err, ErrCartNotFound) {
+		t.Fatalf("expected second checkout of the same cart to fail, got %v", err)
+	}
+
+	paidAt := time.Now().UTC()
+	paid, err := orders.UpdateStatus(order.ID, domain.OrderPending, domain.OrderPaid, map[string]any{"paid_at": paidAt, "payment_reference": "ch_1"})
+	if err != nil || paid.Status != domain.OrderPaid || paid.PaymentReference != "ch_1" || len(paid.Items) != 1 {
+		t.Fatalf("unexpected paid order: %+v err=%v", paid, err)
+	}
+	stale, err := orders.UpdateStatus(order.ID, domain.OrderPending, domain.OrderCancelled, nil)
+	if !errors.Is(err, ErrOrderStatusConflict) || stale != nil {
+		t.Fatalf("expected ErrOrderStatusConflict, got %+v err=%v", stale, err)
+	}
+	if _, err := orders.UpdateStatus(999, domain.OrderPending, domain.OrderPaid, nil); !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("expected ErrOrderNotFound, got %v", err)
+	}
+
+	list, err := orders.ListPaged(OrderListQuery{PageRequest: PageRequest{Page: 1, PageSize: 10}, UserID: userID, Status: domain.OrderPaid})
+	if err != nil || list.Total != 1 || len(list.Items[0].Items) != 1 {
+		t.Fatalf("unexpected order list: %+v err=%v", list, err)
+	}
+}
@@ -0,0 +1,309 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+)
+
+var (
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrMembershipNotFound   = errors.New("membership not found")
+	ErrOrganizationHasData  = errors.New("organization still owns products, orders or import jobs")
+)
+
+type OrganizationRepository interface {
+	Create(org *domain.Organization) error
+	FindByID(id uint) (*domain.Organization, error)
+	FindBySlug(slug string) (*domain.Organization, error)
+	ListPaged(req PageRequest) (PageResult[domain.Organization], error)
+	// DeleteByID deletes the organization and its memberships. It fails with
+	// ErrOrganizationHasData while products (trashed ones included), orders
+	// or import jobs still belong to it.
+	DeleteByID(id uint) error
+	FindMembership(orgID, userID uint) (*domain.Membership, error)
+	ListMemberships(orgID uint, req PageRequest) (PageResult[domain.Membership], error)
+	// UpsertMembership replaces the user's roles in the organization. A new
+	// membership is created with status; an existing one keeps its own.
+	UpsertMembership(orgID, userID uint, roleIDs []uint, status string) (*domain.Membership, error)
+	// AcceptMembership activates the user's invitation to the organization,
+	// or returns ErrMembershipNotFound when there is none.
+	AcceptMembership(orgID, userID uint) (*domain.Membership, error)
+	// ListInvitingOrganizations lists organizations the user is invited to.
+	ListInvitingOrganizations(userID uint) ([]domain.Organization, error)
+	// CountMembersWithPermission counts active members whose org roles grant
+	// resource:action.
+	CountMembersWithPermission(orgID uint, resource, action string) (int64, error)
+	DeleteMembership(orgID, userID uint) error
+}
+
+type GormOrganizationRepository struct{ db *gorm.DB }
+
+func NewOrganizationRepository(db *gorm.DB) OrganizationRepository {
+	return &GormOrganizationRepository{db: db}
+}
+
+func (r *GormOrganizationRepository) Create(org *domain.Organization) error {
+	if err := r.db.Create(org).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "organization", "create", "error")
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "organization", "create", "success")
+	return nil
+}
+
+func (r *GormOrganizationRepository) FindByID(id uint) (*domain.Organization, error) {
+	var org domain.Organization
+	if err := r.db.First(&org, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			observability.RecordRepositoryOperation(context.Background(), "organization", "find_by_id", "not_found")
+			return nil, ErrOrganizationNotFound
+		}
+		observability.RecordRepositoryOperation(context.Background(), "organization", "find_by_id", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "organization", "find_by_id", "success")
+	return &org, nil
+}
+
+func (r *GormOrganizationRepository) FindBySlug(slug string) (*domain.Organization, error) {
+	var org domain.Organization
+	if err := r.db.Where("slug = ?", slug).First(&org).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			observability.RecordRepositoryOperation(context.Background(), "organization", "find_by_slug", "not_found")
+			return nil, ErrOrganizationNotFound
+		}
+		observability.RecordRepositoryOperation(context.Background(), "organization", "find_by_slug", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "organization", "find_by_slug", "success")
+	return &org, nil
+}
+
+func (r *GormOrganizationRepository) ListPaged(req PageRequest) (PageResult[domain.Organization], error) {
+	normalized := normalizePageRequest(req)
+	result := PageResult[domain.Organization]{
+		Page:     normalized.Page,
+		PageSize: normalized.PageSize,
+	}
+	base := r.db.Model(&domain.Organization{})
+	if err := base.Session(&gorm.Session{}).Count(&result.Total).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "organization", "list_paged", "error")
+		return PageResult[domain.Organization]{}, err
+	}
+	offset := (normalized.Page - 1) * normalized.PageSize
+	if err := base.Order("slug asc").Offset(offset).Limit(normalized.PageSize).Find(&result.Items).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "organization", "list_paged", "error")
+		return PageResult[domain.Organization]{}, err
+	}
+	result.TotalPages = calcTotalPages(result.Total, normalized.PageSize)
+	observability.RecordRepositoryOperation(context.Background(), "organization", "list_paged", "success")
+	return result, nil
+}
+
+func (r *GormOrganizationRepository) DeleteByID(id uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, owned := range []any{&domain.Product{}, &domain.Order{}, &domain.ProductImportJob{}} {
+			var count int64
+			if err := tx.Unscoped().Model(owned).Where("organization_id = ?", id).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return ErrOrganizationHasData
+			}
+		}
+		var memberships []domain.Membership
+		if err := tx.Where("organization_id = ?", id).Find(&memberships).Error; err != nil {
+			return err
+		}
+		for i := range memberships {
+			if err := tx.Model(&memberships[i]).Association("Roles").Clear(); err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("organization_id = ?", id).Delete(&domain.Membership{}).Error; err != nil {
+			return err
+		}
+		res := tx.Delete(&domain.Organization{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrOrganizationNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrOrganizationNotFound) {
+			observability.RecordRepositoryOperation(context.Background(), "organization", "delete_by_id", "not_found")
+		} else {
+			observability.RecordRepositoryOperation(context.Background(), "organization", "delete_by_id", "error")
+		}
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "organization", "delete_by_id", "success")
+	return nil
+}
+
+func (r *GormOrganizationRepository) FindMembership(orgID, userID uint) (*domain.Membership, error) {
+	var membership domain.Membership
+	err := r.db.Preload("Roles.Permissions").
+		Where("organization_id = ? AND user_id = ?", orgID, userID).
+		First(&membership).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			observability.RecordRepositoryOperation(context.Background(), "organization", "find_membership", "not_found")
+			return nil, ErrMembershipNotFound
+		}
+		observability.RecordRepositoryOperation(context.Background(), "organization", "find_membership", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "organization", "find_membership", "success")
+	return &membership, nil
+}
+
+func (r *GormOrganizationRepository) ListMemberships(orgID uint, req PageRequest) (PageResult[domain.Membership], error) {
+	normalized := normalizePageRequest(req)
+	result := PageResult[domain.Membership]{
+		Page:     normalized.Page,
+		PageSize: normalized.PageSize,
+	}
+	base := r.db.Model(&domain.Membership{}).Where("organization_id = ?", orgID)
+	if err := base.Session(&gorm.Session{}).Count(&result.Total).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "organization", "list_memberships", "error")
+		return PageResult[domain.Membership]{}, err
+	}
+	offset := (normalized.Page - 1) * normalized.PageSize
+	if err := base.Preload("Roles").Order("id asc").Offset(offset).Limit(normalized.PageSize).Find(&result.Items).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "organization", "list_memberships", "error")
+		return PageResult[domain.Membership]{}, err
+	}
+	result.TotalPages = calcTotalPages(result.Total, normalized.PageSize)
+	observability.RecordRepositoryOperation(context.Background(), "organization", "list_memberships", "success")
+	return result, nil
+}
+
+func (r *GormOrganizationRepository) UpsertMembership(orgID, userID uint, roleIDs []uint, status string) (*domain.Membership, error) {
+	var membership domain.Membership
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		membership = domain.Membership{OrganizationID: orgID, UserID: userID, Status: status}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&membership).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("organization_id = ? AND user_id = ?", orgID, userID).First(&membership).Error; err != nil {
+			return err
+		}
+		roles := make([]domain.Role, 0, len(roleIDs))
+		if len(roleIDs) > 0 {
+			if err := tx.Where("id IN ?", roleIDs).Find(&roles).Error; err != nil {
+				return err
+			}
+			if len(roles) != len(uniqueUint(roleIDs)) {
+				return ErrRoleNotFound
+			}
+		}
+		if err := tx.Model(&membership).Association("Roles").Replace(roles); err != nil {
+			return err
+		}
+		membership.Roles = roles
+		return nil
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "organization", "upsert_membership", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "organization", "upsert_membership", "success")
+	return &membership, nil
+}
+
+func (r *GormOrganizationRepository) AcceptMembership(orgID, userID uint) (*domain.Membership, error) {
+	res := r.db.Model(&domain.Membership{}).
+		Where("organization_id = ? AND user_id = ? AND status = ?", orgID, userID, domain.MembershipInvited).
+		Update("status", domain.MembershipActive)
+	if res.Error != nil {
+		observability.RecordRepositoryOperation(context.Background(), "organization", "accept_membership", "error")
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		observability.RecordRepositoryOperation(context.Background(), "organization", "accept_membership", "not_found")
+		return nil, ErrMembershipNotFound
+	}
+	observability.RecordRepositoryOperation(context.Background(), "organization", "accept_membership", "success")
+	return r.FindMembership(orgID, userID)
+}
+
+func (r *GormOrganizationRepository) ListInvitingOrganizations(userID uint) ([]domain.Organization, error) {
+	var orgs []domain.Organization
+	err := r.db.Where("id IN (?)", r.db.Model(&domain.Membership{}).
+		Select("organization_id").
+		Where("user_id = ? AND status = ?", userID, domain.MembershipInvited)).
+		Order("id asc").Find(&orgs).Error
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "organization", "list_inviting", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "organization", "list_inviting", "success")
+	return orgs, nil
+}
+
+func (r *GormOrganizationRepository) CountMembersWithPermission(orgID uint, resource, action string) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.Membership{}).
+		Joins("JOIN membership_roles ON membership_roles.membership_id = memberships.id").
+		Joins("JOIN role_permissions ON role_permissions.role_id = membership_roles.role_id").
+		Joins("JOIN permissions ON permissions.id = role_permissions.permission_id").
+		Where("memberships.organization_id = ? AND memberships.status = ?", orgID, domain.MembershipActive).
+		Where("permissions.resource = ? AND permissions.action = ?", resource, action).
+		Distinct("memberships.id").
+		Count(&count).Error
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "organization", "count_members_with_permission", "error")
+		return 0, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "organization", "count_members_with_permission", "success")
+	return count, nil
+}
+
+func (r *GormOrganizationRepository) DeleteMembership(orgID, userID uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var membership domain.Membership
+		if err := tx.Where("organization_id = ? AND user_id = ?", orgID, userID).First(&membership).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrMembershipNotFound
+			}
+			return err
+		}
+		if err := tx.Model(&membership).Association("Roles").Clear(); err != nil {
+			return err
+		}
+		return tx.Delete(&membership).Error
+	})
+	if err != nil {
+		if errors.Is(err, ErrMembershipNotFound) {
+			observability.RecordRepositoryOperation(context.Background(), "organization", "delete_membership", "not_found")
+		} else {
+			observability.RecordRepositoryOperation(context.Background(), "organization", "delete_membership", "error")
+		}
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "organization", "delete_membership", "success")
+	return nil
+}
+
+func uniqueUint(values []uint) []uint {
+	seen := make(map[uint]struct{}, len(values))
+	out := make([]uint, 0, len(values))
+	for _, v := range values {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+)
+
+func TestOrganizationRepositoryMembershipLifecycle(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	repo := NewOrganizationRepository(db)
+	userRepo := NewUserRepository(db)
+
+	perm := domain.Permission{Resource: "members", Action: "read"}
+	if err := db.Create(&perm).Error; err != nil {
+		t.Fatalf("create permission: %v", err)
+	}
+	role := domain.Role{Name: "org_admin", Permissions: []domain.Permission{perm}}
+	if err := db.Create(&role).Error; err != nil {
+		t.Fatalf("create role: %v", err)
+	}
+	alice := &domain.User{Email: "alice@example.com", Name: "Alice", Status: "active"}
+	bob := &domain.User{Email: "bob@example.com", Name: "Bob", Status: "active"}
+	for _, u := range []*domain.User{alice, bob} {
+		if err := userRepo.Create(u); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+
+	acme := &domain.Organization{Slug: "acme", Name: "Acme"}
+	globex := &domain.Organization{Slug: "globex", Name: "Globex"}
+	for _, org := range []*domain.Organization{acme, globex} {
+		if err := repo.Create(org); err != nil {
+			t.Fatalf("create org: %v", err)
+		}
+	}
+	if found, err := repo.FindBySlug("acme"); err != nil || found.ID != acme.ID {
+		t.Fatalf("find by slug: org=%+v err=%v", found, err)
+	}
+
+	if _, err := repo.UpsertMembership(acme.ID, alice.ID, []uint{role.ID}, domain.MembershipActive); err != nil {
+		t.Fatalf("upsert alice: %v", err)
+	}
+	if _, err := repo.UpsertMembership(globex.ID, bob.ID, nil, domain.MembershipActive); err != nil {
+		t.Fatalf("upsert bob: %v", err)
+	}
+	if _, err := repo.UpsertMembership(acme.ID, bob.ID, []uint{role.ID, 999}, domain.MembershipActive); !errors.Is(err, ErrRoleNotFound) {
+		t.Fatalf("expected ErrRoleNotFound for unknown role, got %v", err)
+	}
+
+	membership, err := repo.FindMembership(acme.ID, alice.ID)
+	if err != nil {
+		t.Fatalf("find membership: %v", err)
+	}
+	if len(membership.Roles) != 1 || len(membership.Roles[0].Permissions) != 1 {
+		t.Fatalf("expected membership roles with permissions preloaded, got %+v", membership.Roles)
+	}
+	if _, err := repo.FindMembership(acme.ID, bob.ID); !errors.Is(err, ErrMembershipNotFound) {
+		t.Fatalf("expected ErrMembershipNotFound, got %v", err)
+	}
+
+	// Re-upserting replaces the role set instead of duplicating the membership.
+	if _, err := repo.UpsertMembership(acme.ID, alice.ID, nil, domain.MembershipActive); err != nil {
+		t.Fatalf("re-upsert alice: %v", err)
+	}
+	page, err := repo.ListMemberships(acme.ID, PageRequest{Page: 1, PageSize: 10})
+	if err != nil {
+		t.Fatalf("list memberships: %v", err)
+	}
+	if page.Total != 1 || len(page.Items[0].Roles) != 0 {
+		t.Fatalf("unexpected memberships page: %+v", page)
+	}
+
+	users, err := userRepo.ListPaged(UserListQuery{PageRequest: PageRequest{Page: 1, PageSize: 10}, OrganizationID: acme.ID})
+	if err != nil {
+		t.Fatalf("list tenant users: %v", err)
+	}
+	if users.Total != 1 || users.Items[0].ID != alice.ID {
+		t.Fatalf("expected only alice in acme, got %+v", users.Items)
+	}
+
+	if err := repo.DeleteMembership(acme.ID, alice.ID); err != nil {
+		t.Fatalf("delete membership: %v", err)
+	}
+	if err := repo.DeleteMembership(acme.ID, alice.ID); !errors.Is(err, ErrMembershipNotFound) {
+		t.Fatalf("expected ErrMembershipNotFound on second delete, got %v", err)
+	}
+
+	// An organization that still owns data, even trashed products, stays.
+	if err := db.AutoMigrate(&domain.Product{}, &domain.Order{}, &domain.OrderItem{}, &domain.ProductImportJob{}); err != nil {
+		t.Fatalf("migrate owned tables: %v", err)
+	}
+	orgID := globex.ID
+	owned := &domain.Product{Name: "Widget", Currency: "USD", OrganizationID: &orgID}
+	if err := db.Create(owned).Error; err != nil {
+		t.Fatalf("create org product: %v", err)
+	}
+	if err := db.Delete(owned).Error; err != nil {
+		t.Fatalf("trash org product: %v", err)
+	}
+	if err := repo.DeleteByID(globex.ID); !errors.Is(err, ErrOrganizationHasData) {
+		t.Fatalf("expected ErrOrganizationHasData, got %v", err)
+	}
+	if _, err := repo.FindByID(globex.ID); err != nil {
+		t.Fatalf("expected org kept, got %v", err)
+	}
+	if err := db.Unscoped().Delete(owned).Error; err != nil {
+		t.Fatalf("purge org product: %v", err)
+	}
+
+	if err := repo.DeleteByID(globex.ID); err != nil {
+		t.Fatalf("delete org: %v", err)
+	}
+	if _, err := repo.FindByID(globex.ID); !errors.Is(err, ErrOrganizationNotFound) {
+		t.Fatalf("expected ErrOrganizationNotFound, got %v", err)
+	}
+	var remaining int64
+	if err := db.Model(&domain.Membership{}).Where("organization_id = ?", globex.ID).Count(&remaining).Error; err != nil {
+		t.Fatalf("count memberships: %v", err)
+	}
+	if remaining != 0 {
+		t.Fatalf("expected memberships removed with org, got %d", remaining)
+	}
+	if err := repo.DeleteByID(globex.ID); !errors.Is(err, ErrOrganizationNotFound) {
+		t.Fatalf("expected ErrOrganizationNotFound on second delete, got %v", err)
+	}
+}
+
+func TestOrganizationRepositoryInvitationsAndAdminCount(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	repo := NewOrganizationRepository(db)
+	userRepo := NewUserRepository(db)
+
+	write := domain.Permission{Resource: "members", Action: "write"}
+	if err := db.Create(&write).Error; err != nil {
+		t.Fatalf("create permission: %v", err)
+	}
+	admin := domain.Role{Name: "org_admin", Permissions: []domain.Permission{write}}
+	if err := db.Create(&admin).Error; err != nil {
+		t.Fatalf("create role: %v", err)
+	}
+	alice := &domain.User{Email: "alice@example.com", Name: "Alice", Status: "active"}
+	bob := &domain.User{Email: "bob@example.com", Name: "Bob", Status: "active"}
+	for _, u := range []*domain.User{alice, bob} {
+		if err := userRepo.Create(u); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+	acme := &domain.Organization{Slug: "acme", Name: "Acme"}
+	if err := repo.Create(acme); err != nil {
+		t.Fatalf("create org: %v", err)
+	}
+
+	if _, err := repo.UpsertMembership(acme.ID, alice.ID, []uint{admin.ID}, domain.MembershipActive); err != nil {
+		t.Fatalf("add alice: %v", err)
+	}
+	invited, err := repo.UpsertMembership(acme.ID, bob.ID, []uint{admin.ID}, domain.MembershipInvited)
+	if err != nil || invited.Status != domain.MembershipInvited {
+		t.Fatalf("invite bob: %+v err=%v", invited, err)
+	}
+	if count, err := repo.CountMembersWithPermission(acme.ID, "members", "write"); err != nil || count != 1 {
+		t.Fatalf("expected only alice counted as admin, got %d err=%v", count, err)
+	}
+	users, err := userRepo.ListPaged(UserListQuery{PageRequest: PageRequest{Page: 1, PageSize: 10}, OrganizationID: acme.ID})
+	if err != nil || users.Total != 1 || users.Items[0].ID != alice.ID {
+		t.Fatalf("expected invited users outside the tenant, got %+v err=%v", users.Items, err)
+	}
+	orgs, err := repo.ListInvitingOrganizations(bob.ID)
+	if err != nil || len(orgs) != 1 || orgs[0].ID != acme.ID {
+		t.Fatalf("expected bob invited to acme, got %+v err=%v", orgs, err)
+	}
+
+	// Re-upserting keeps the invitation pending.
+	if again, err := repo.UpsertMembership(acme.ID, bob.ID, nil, domain.MembershipActive); err != nil || again.Status != domain.MembershipInvited {
+		t.Fatalf("expected re-upsert to keep the status, got %+v err=%v", again, err)
+	}
+	if _, err := repo.AcceptMembership(acme.ID, alice.ID); !errors.Is(err, ErrMembershipNotFound) {
+		t.Fatalf("expected nothing to accept for an active member, got %v", err)
+	}
+	accepted, err := repo.AcceptMembership(acme.ID, bob.ID)
+	if err != nil || accepted.Status != domain.MembershipActive {
+		t.Fatalf("accept: %+v err=%v", accepted, err)
+	}
+	if orgs, err := repo.ListInvitingOrganizations(bob.ID); err != nil || len(orgs) != 0 {
+		t.Fatalf("expected no pending invitations, got %+v err=%v", orgs, err)
+	}
+}
@@ -1,5 +1,15 @@
 package repository
 
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
 const (
 	DefaultPage     = 1
 	DefaultPageSize = 20
@@ -49,3 +59,133 @@ func calcTotalPages(total int64, pageSize int) int {
 	}
 	return int(pages)
 }
+
+var (
+	ErrInvalidCursor          = errors.New("invalid cursor")
+	ErrCursorSortNotSupported = errors.New("sort field does not support cursor pagination")
+)
+
+// CursorPosition identifies a row in a keyset ordering by its sort value,
+// encoded as text, and id.
+type CursorPosition struct {
+	Value string
+	ID    uint
+}
+
+// CursorRequest asks for one keyset page. A nil Position starts at the
+// beginning of the ordering. Otherwise the page holds the rows after
+// Position, or the rows before it when Backward is set.
+type CursorRequest struct {
+	PageSize int
+	Position *CursorPosition
+	Backward bool
+}
+
+// CursorResult is one keyset page. Next and Prev are the positions to
+// continue from in either direction and are nil at the ends of the ordering.
+type CursorResult[T any] struct {
+	Items    []T
+	PageSize int
+	Next     *CursorPosition
+	Prev     *CursorPosition
+}
+
+// listByCursor runs q as a keyset page ordered by table.sortBy and then
+// table.id, both in sortOrder. key returns the sort value and id of a row; it
+// must return a nil value for sort fields without keyset support. One extra
+// row is fetched to detect whether the page has a successor, and no total is
+// counted.
+func listByCursor[T any](q *gorm.DB, table, sortBy, sortOrder string, req CursorRequest, key func(T) (any, uint)) (CursorResult[T], error) {
+	pageSize := normalizePageRequest(PageRequest{PageSize: req.PageSize}).PageSize
+	var zero T
+	sample, _ := key(zero)
+	if sample == nil {
+		return CursorResult[T]{}, ErrCursorSortNotSupported
+	}
+
+	// The query walks the ordering in reverse when paging backwards.
+	desc := (sortOrder != "asc") != req.Backward
+	op, dir := ">", "asc"
+	if desc {
+		op, dir = "<", "desc"
+	}
+	col, idCol := table+"."+sortBy, table+".id"
+	if req.Position != nil {
+		if sortBy == "id" {
+			q = q.Where(idCol+" "+op+" ?", req.Position.ID)
+		} else {
+			value, err := decodeCursorValue(req.Position.Value, sample)
+			if err != nil {
+				return CursorResult[T]{}, ErrInvalidCursor
+			}
+			q = q.Where("("+col+" "+op+" ? OR ("+col+" = ? AND "+idCol+" "+op+" ?))", value, value, req.Position.ID)
+		}
+	}
+	if sortBy != "id" {
+		q = q.Order(col + " " + dir)
+	}
+	var items []T
+	if err := q.Order(idCol + " " + dir).Limit(pageSize + 1).Find(&items).Error; err != nil {
+		return CursorResult[T]{}, err
+	}
+	more := len(items) > pageSize
+	if more {
+		items = items[:pageSize]
+	}
+	hasNext, hasPrev := more, req.Position != nil
+	if req.Backward {
+		slices.Reverse(items)
+		hasNext, hasPrev = req.Position != nil, more
+	}
+
+	result := CursorResult[T]{Items: items, PageSize: pageSize}
+	if len(items) == 0 {
+		return result, nil
+	}
+	if hasPrev {
+		value, id := key(items[0])
+		result.Prev = &CursorPosition{Value: encodeCursorValue(value), ID: id}
+	}
+	if hasNext {
+		value, id := key(items[len(items)-1])
+		result.Next = &CursorPosition{Value: encodeCursorValue(value), ID: id}
+	}
+	return result, nil
+}
+
+func encodeCursorValue(value any) string {
+	switch v := value.(type) {
+	case time.Time:
+		// Keep the offset so the value compares equal to the stored one.
+		return v.Format(time.RFC3339Nano)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// decodeCursorValue parses raw into the type of sample.
+func decodeCursorValue(raw string, sample any) (any, error) {
+	switch sample.(type) {
+	case time.Time:
+		return time.Parse(time.RFC3339Nano, raw)
+	case float64:
+		return strconv.ParseFloat(raw, 64)
+	case uint:
+		v, err := strconv.ParseUint(raw, 10, 64)
+		return uint(v), err
+	case int64:
+		return strconv.ParseInt(raw, 10, 64)
+	case string:
+		return raw, nil
+	default:
+		return nil, ErrInvalidCursor
+	}
+}
@@ -1,7 +1,12 @@
 package repository
 
 import (
+	"errors"
+	"fmt"
 	"testing"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
 )
 
 func TestNormalizePageRequestBounds(t *testing.T) {
@@ -99,3 +104,98 @@ func FuzzCalcTotalPagesInvariants(f *testing.F) {
 		}
 	})
 }
+
+func TestListByCursorWalksBothDirections(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.Product{}, &domain.ProductGrant{}, &domain.ProductTag{}, &domain.ProductPrice{}, &domain.ProductImage{}, &domain.ProductStock{}, &domain.StockReservation{}, &domain.StockMovement{}, &domain.CartItem{}); err != nil {
+		t.Fatalf("migrate product: %v", err)
+	}
+	repo := NewProductRepository(db)
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	// Prices repeat so pages have to break ties on id.
+	for i := 0; i < 5; i++ {
+		p := &domain.Product{Name: fmt.Sprintf("P%d", i), PriceMinor: int64(1000 + i/2), CreatedAt: base.Add(time.Duration(i) * time.Hour)}
+		if err := repo.Create(p); err != nil {
+			t.Fatalf("create product %d: %v", i, err)
+		}
+	}
+	names := func(items []domain.Product) string {
+		out := ""
+		for _, item := range items {
+			out += item.Name + " "
+		}
+		return out
+	}
+	query := ProductListQuery{Currency: "USD", SortBy: "price", SortOrder: "asc"}
+
+	first, err := repo.ListByCursor(query, CursorRequest{PageSize: 2})
+	if err != nil || names(first.Items) != "P0 P1 " || first.Prev != nil || first.Next == nil {
+		t.Fatalf("unexpected first page %q prev=%v next=%v err=%v", names(first.Items), first.Prev, first.Next, err)
+	}
+	// A row inserted before the cursor must not shift the next page.
+	if err := repo.Create(&domain.Product{Name: "Early", PriceMinor: 1, CreatedAt: base}); err != nil {
+		t.Fatalf("create early product: %v", err)
+	}
+	second, err := repo.ListByCursor(query, CursorRequest{PageSize: 2, Position: first.Next})
+	if err != nil || names(second.Items) != "P2 P3 " || second.Prev == nil || second.Next == nil {
+		t.Fatalf("unexpected second page %q err=%v", names(second.Items), err)
+	}
+	last, err := repo.ListByCursor(query, CursorRequest{PageSize: 2, Position: second.Next})
+	if err != nil || names(last.Items) != "P4 " || last.Next != nil || last.Prev == nil {
+		t.Fatalf("unexpected last page %q next=%v err=%v", names(last.Items), last.Next, err)
+	}
+
+	back, err := repo.ListByCursor(query, CursorRequest{PageSize: 2, Position: last.Prev, Backward: true})
+	if err != nil || names(back.Items) != "P2 P3 " || back.Next == nil || back.Prev == nil {
+		t.Fatalf("unexpected page walking back %q err=%v", names(back.Items), err)
+	}
+	earlier, err := repo.ListByCursor(query, CursorRequest{PageSize: 2, Position: back.Prev, Backward: true})
+	if err != nil || names(earlier.Items) != "P0 P1 " || earlier.Prev == nil {
+		t.Fatalf("unexpected earlier page %q prev=%v err=%v", names(earlier.Items), earlier.Prev, err)
+	}
+	start, err := repo.ListByCursor(query, CursorRequest{PageSize: 2, Position: earlier.Prev, Backward: true})
+	if err != nil || names(start.Items) != "Early " || start.Prev != nil || start.Next == nil {
+		t.Fatalf("expected to reach the start including the new row, got %q prev=%v err=%v", names(start.Items), start.Prev, err)
+	}
+
+	byTime, err := repo.ListByCursor(ProductListQuery{}, CursorRequest{PageSize: 3})
+	if err != nil || names(byTime.Items) != "P4 P3 P2 " {
+		t.Fatalf("unexpected newest-first page %q err=%v", names(byTime.Items), err)
+	}
+	next, err := repo.ListByCursor(ProductListQuery{}, CursorRequest{PageSize: 3, Position: byTime.Next})
+	if err != nil || names(next.Items) != "P1 Early P0 " {
+		t.Fatalf("unexpected created_at page %q err=%v", names(next.Items), err)
+	}
+
+	if _, err := repo.ListByCursor(query, CursorRequest{Position: &CursorPosition{Value: "cheap", ID: 1}}); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor, got %v", err)
+	}
+	if _, err := repo.ListByCursor(ProductListQuery{SortBy: "price"}, CursorRequest{}); !errors.Is(err, ErrProductPriceSortNeedsCurrency) {
+		t.Fatalf("expected ErrProductPriceSortNeedsCurrency, got %v", err)
+	}
+	if _, err := repo.ListByCursor(ProductListQuery{SortBy: "description"}, CursorRequest{}); !errors.Is(err, ErrCursorSortNotSupported) {
+		t.Fatalf("expected ErrCursorSortNotSupported, got %v", err)
+	}
+}
+
+func TestRoleListByCursorPreloadsPermissions(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	repo := NewRoleRepository(db)
+	perm := &domain.Permission{Resource: "users", Action: "read"}
+	if err := db.Create(perm).Error; err != nil {
+		t.Fatalf("create permission: %v", err)
+	}
+	for _, name := range []string{"admin", "auditor", "viewer"} {
+		if err := repo.Create(&domain.Role{Name: name}, []uint{perm.ID}); err != nil {
+			t.Fatalf("create role %s: %v", name, err)
+		}
+	}
+
+	page, err := repo.ListByCursor(CursorRequest{PageSize: 2}, "name", "asc", "a")
+	if err != nil || len(page.Items) != 2 || page.Next != nil {
+		t.Fatalf("expected both roles matching the filter on one page, got %+v err=%v", page, err)
+	}
+	if page.Items[0].Name != "admin" || len(page.Items[0].Permissions) != 1 {
+		t.Fatalf("expected admin with preloaded permissions, got %+v", page.Items[0])
+	}
+}
@@ -14,6 +14,7 @@ var ErrPermissionNotFound = errors.New("permission not found")
 type PermissionRepository interface {
 	List() ([]domain.Permission, error)
 	ListPaged(req PageRequest, sortBy, sortOrder, resource, action string) (PageResult[domain.Permission], error)
+	ListByCursor(cursor CursorRequest, sortBy, sortOrder, resource, action string) (CursorResult[domain.Permission], error)
 	FindByID(id uint) (*domain.Permission, error)
 	FindByPairs(pairs [][2]string) ([]domain.Permission, error)
 	FindByResourceAction(resource, action string) (*domain.Permission, error)
@@ -46,13 +47,7 @@ func (r *GormPermissionRepository) ListPaged(req PageRequest, sortBy, sortOrder,
 		PageSize: normalized.PageSize,
 	}
 
-	base := r.db.Model(&domain.Permission{})
-	if resource != "" {
-		base = base.Where("permissions.resource LIKE ?", resource+"%")
-	}
-	if action != "" {
-		base = base.Where("permissions.action LIKE ?", action+"%")
-	}
+	base := r.filtered(resource, action)
 	if err := base.Count(&result.Total).Error; err != nil {
 		observability.RecordRepositoryOperation(context.Background(), "permission", "list_paged", "error")
 		return PageResult[domain.Permission]{}, err
@@ -73,6 +68,42 @@ func (r *GormPermissionRepository) ListPaged(req PageRequest, sortBy, sortOrder,
 	return result, nil
 }
 
+func (r *GormPermissionRepository) ListByCursor(cursor CursorRequest, sortBy, sortOrder, resource, action string) (CursorResult[domain.Permission], error) {
+	result, err := listByCursor(r.filtered(resource, action), "permissions", sortBy, sortOrder, cursor, func(p domain.Permission) (any, uint) {
+		switch sortBy {
+		case "id":
+			return p.ID, p.ID
+		case "resource":
+			return p.Resource, p.ID
+		case "action":
+			return p.Action, p.ID
+		case "created_at":
+			return p.CreatedAt, p.ID
+		case "updated_at":
+			return p.UpdatedAt, p.ID
+		default:
+			return nil, p.ID
+		}
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "permission", "list_by_cursor", "error")
+		return CursorResult[domain.Permission]{}, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "permission", "list_by_cursor", "success")
+	return result, nil
+}
+
+func (r *GormPermissionRepository) filtered(resource, action string) *gorm.DB {
+	base := r.db.Model(&domain.Permission{})
+	if resource != "" {
+		base = base.Where("permissions.resource LIKE ?", resource+"%")
+	}
+	if action != "" {
+		base = base.Where("permissions.action LIKE ?", action+"%")
+	}
+	return base
+}
+
 func (r *GormPermissionRepository) FindByID(id uint) (*domain.Permission, error) {
 	var p domain.Permission
 	if err := r.db.First(&p, id).Error; err != nil {
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+)
+
+var ErrProductImportJobNotFound = errors.New("product import job not found")
+
+type ProductImportJobRepository interface {
+	Create(job *domain.ProductImportJob) error
+	FindByID(id uint) (*domain.ProductImportJob, error)
+	// Update saves the job's status, counters, errors and timestamps.
+	Update(job *domain.ProductImportJob) error
+}
+
+type GormProductImportJobRepository struct{ db *gorm.DB }
+
+func NewProductImportJobRepository(db *gorm.DB) ProductImportJobRepository {
+	return &GormProductImportJobRepository{db: db}
+}
+
+func (r *GormProductImportJobRepository) Create(job *domain.ProductImportJob) error {
+	if job.Errors == nil {
+		job.Errors = domain.ProductImportErrors{}
+	}
+	if err := r.db.Create(job).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "product_import_job", "create", "error")
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "product_import_job", "create", "success")
+	return nil
+}
+
+func (r *GormProductImportJobRepository) FindByID(id uint) (*domain.ProductImportJob, error) {
+	var job domain.ProductImportJob
+	if err := r.db.First(&job, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			observability.RecordRepositoryOperation(context.Background(), "product_import_job", "find_by_id", "not_found")
+			return nil, ErrProductImportJobNotFound
+		}
+		observability.RecordRepositoryOperation(context.Background(), "product_import_job", "find_by_id", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "product_import_job", "find_by_id", "success")
+	return &job, nil
+}
+
+func (r *GormProductImportJobRepository) Update(job *domain.ProductImportJob) error {
+	res := r.db.Model(&domain.ProductImportJob{ID: job.ID}).Select(
+		"status", "processed_rows", "created_rows", "updated_rows", "failed_rows",
+		"errors", "failure", "started_at", "finished_at", "updated_at",
+	).Updates(job)
+	if res.Error == nil && res.RowsAffected == 0 {
+		res.Error = ErrProductImportJobNotFound
+	}
+	if res.Error != nil {
+		outcome := "error"
+		if errors.Is(res.Error, ErrProductImportJobNotFound) {
+			outcome = "not_found"
+		}
+		observability.RecordRepositoryOperation(context.Background(), "product_import_job", "update", outcome)
+		return res.Error
+	}
+	observability.RecordRepositoryOperation(context.Background(), "product_import_job", "update", "success")
+	return nil
+}
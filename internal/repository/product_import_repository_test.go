@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+)
+
+func TestProductImportJobRepositoryLifecycle(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.ProductImportJob{}); err != nil {
+		t.Fatalf("migrate product import jobs: %v", err)
+	}
+	repo := NewProductImportJobRepository(db)
+
+	orgID := uint(3)
+	job := &domain.ProductImportJob{OrganizationID: &orgID, Format: "csv", Status: domain.ProductImportPending}
+	if err := repo.Create(job); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	now := time.Now().UTC()
+	job.Status = domain.ProductImportCompleted
+	job.ProcessedRows, job.CreatedRows, job.FailedRows = 3, 2, 1
+	job.Errors = domain.ProductImportErrors{{Row: 2, SKU: "BAD-1", Error: "invalid price"}}
+	job.StartedAt, job.FinishedAt = &now, &now
+	if err := repo.Update(job); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	loaded, err := repo.FindByID(job.ID)
+	if err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	if loaded.Status != domain.ProductImportCompleted || loaded.ProcessedRows != 3 || loaded.CreatedRows != 2 || loaded.FailedRows != 1 {
+		t.Fatalf("unexpected job: %+v", loaded)
+	}
+	if len(loaded.Errors) != 1 || loaded.Errors[0].Row != 2 || loaded.Errors[0].SKU != "BAD-1" || loaded.FinishedAt == nil {
+		t.Fatalf("unexpected job errors: %+v", loaded)
+	}
+
+	if _, err := repo.FindByID(999); !errors.Is(err, ErrProductImportJobNotFound) {
+		t.Fatalf("expected ErrProductImportJobNotFound, got %v", err)
+	}
+	if err := repo.Update(&domain.ProductImportJob{ID: 999, Status: domain.ProductImportFailed}); !errors.Is(err, ErrProductImportJobNotFound) {
+		t.Fatalf("expected ErrProductImportJobNotFound on update, got %v", err)
+	}
+}
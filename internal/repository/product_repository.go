@@ -3,6 +3,9 @@ package repository
 import (
 	"context"
 	"errors"
+	"sort"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -10,34 +13,181 @@ import (
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
 )
 
-var ErrProductNotFound = errors.New("product not found")
+var (
+	ErrProductNotFound               = errors.New("product not found")
+	ErrProductVersionConflict        = errors.New("product was modified by another request")
+	ErrProductGrantNotFound          = errors.New("product grant not found")
+	ErrProductImageNotFound          = errors.New("product image not found")
+	ErrProductImageLimit             = errors.New("product gallery is full")
+	ErrProductImageOrder             = errors.New("image order must list every image of the product exactly once")
+	ErrProductPriceSortNeedsCurrency = errors.New("sorting by price requires a currency")
+)
+
+// ProductListQuery filters and orders a product listing. SortBy must be one
+// of name, price or created_at and is expected to be allow-listed by the
+// caller; an empty value sorts by created_at. Price sorts by the price in
+// Currency, the product's own or its list price, and requires Currency.
+type ProductListQuery struct {
+	PageRequest
+	SortBy    string
+	SortOrder string
+	// Search matches name and description. Postgres uses full-text search
+	// backed by a trigram index on name; other dialects fall back to LIKE.
+	Search string
+	// Currency keeps products priced in that currency, either as their own
+	// currency or through their price list. MinPriceMinor and MaxPriceMinor
+	// bound that price in minor units and require Currency.
+	Currency      string
+	MinPriceMinor *int64
+	MaxPriceMinor *int64
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	// CategoryID keeps products in the category or any of its descendants.
+	// Tag keeps products carrying the tag.
+	CategoryID uint
+	Tag        string
+}
 
 type ProductRepository interface {
 	Create(product *domain.Product) error
 	FindByID(id uint) (*domain.Product, error)
-	ListPaged(req PageRequest) (PageResult[domain.Product], error)
-	Update(id uint, updates map[string]any) error
-	DeleteByID(id uint) error
+	// FindBySKU returns the product carrying sku, or ErrProductNotFound.
+	// Products in the trash still hold their SKU and are returned too.
+	FindBySKU(sku string) (*domain.Product, error)
+	ListPaged(query ProductListQuery) (PageResult[domain.Product], error)
+	// ListByCursor returns a keyset page of the products matching query,
+	// ordered like ListPaged. query.PageRequest is ignored.
+	ListByCursor(query ProductListQuery, cursor CursorRequest) (CursorResult[domain.Product], error)
+	// ListAfter returns up to limit products with an ID greater than afterID
+	// in ID order, so callers can walk every product in bounded batches.
+	ListAfter(afterID uint, limit int) ([]domain.Product, error)
+	// Update applies updates and increments the product version. A non-zero
+	// version must match the stored one, otherwise ErrProductVersionConflict
+	// is returned and nothing changes. DeleteByID checks version the same way.
+	// A "tags" entry holding a []string replaces the product's tags and a
+	// "prices" entry holding a []domain.ProductPrice replaces its price list.
+	Update(id, version uint, updates map[string]any) error
+	// DeleteByID soft-deletes the product. Its grants are kept so Restore
+	// brings the product back exactly as it was shared.
+	DeleteByID(id, version uint) error
+	// ListDeleted returns a page of soft-deleted products, most recently
+	// deleted first.
+	ListDeleted(req PageRequest) (PageResult[domain.Product], error)
+	// Restore brings a soft-deleted product back. ErrProductNotFound is
+	// returned when the product is not in the trash.
+	Restore(id uint) error
+	// Purge permanently removes a soft-deleted product, its grants, tags and
+	// image records. The image files are left to the caller.
+	Purge(id uint) error
+	// PurgeDeletedBefore permanently removes up to limit products, in any
+	// organization, that were soft-deleted before cutoff, and returns their
+	// IDs.
+	PurgeDeletedBefore(cutoff time.Time, limit int) ([]uint, error)
+	// ForOrganization returns a repository limited to products owned by orgID.
+	// The unscoped repository only sees platform products (no organization).
+	ForOrganization(orgID uint) ProductRepository
+	// ForViewer returns a repository limited to products userID owns or has
+	// been granted access to, directly or through a group.
+	ForViewer(userID uint) ProductRepository
+	HasGrant(productID, userID uint, access string) (bool, error)
+	ListGrants(productID uint) ([]domain.ProductGrant, error)
+	UpsertGrant(grant *domain.ProductGrant) error
+	DeleteGrant(productID, grantID uint) error
+	// AddImage appends image to the end of its product's gallery. A gallery
+	// holds at most limit images; ErrProductImageLimit is returned once full.
+	// Gallery changes increment the product version like Update.
+	AddImage(image *domain.ProductImage, limit int) error
+	// ReorderImages puts the gallery in the order of imageIDs, which must
+	// list every image of the product exactly once.
+	ReorderImages(productID uint, imageIDs []uint) ([]domain.ProductImage, error)
+	// DeleteImage removes an image, closes the gap it leaves in the order
+	// and returns the removed image.
+	DeleteImage(productID, imageID uint) (*domain.ProductImage, error)
 }
 
-type GormProductRepository struct{ db *gorm.DB }
+type GormProductRepository struct {
+	db       *gorm.DB
+	orgID    *uint
+	viewerID *uint
+}
 
 func NewProductRepository(db *gorm.DB) ProductRepository {
 	return &GormProductRepository{db: db}
 }
 
+func (r *GormProductRepository) ForOrganization(orgID uint) ProductRepository {
+	return &GormProductRepository{db: r.db, orgID: &orgID, viewerID: r.viewerID}
+}
+
+func (r *GormProductRepository) ForViewer(userID uint) ProductRepository {
+	return &GormProductRepository{db: r.db, orgID: r.orgID, viewerID: &userID}
+}
+
+func (r *GormProductRepository) scoped() *gorm.DB {
+	q := r.db.Where("products.organization_id IS NULL")
+	if r.orgID != nil {
+		q = r.db.Where("products.organization_id = ?", *r.orgID)
+	}
+	if r.viewerID != nil {
+		q = q.Where("(products.owner_id = ? OR products.id IN (?))", *r.viewerID, r.grantedProductIDs(*r.viewerID, domain.ProductAccessRead))
+	}
+	return q
+}
+
+// grantedProductIDs selects products shared with userID directly or through
+// one of the user's groups at the given access level or higher.
+func (r *GormProductRepository) grantedProductIDs(userID uint, access string) *gorm.DB {
+	q := r.db.Model(&domain.ProductGrant{}).
+		Select("product_id").
+		Where("(user_id = ? OR group_id IN (?))", userID, r.db.Model(&domain.GroupMember{}).Select("group_id").Where("user_id = ?", userID))
+	if access == domain.ProductAccessWrite {
+		q = q.Where("access = ?", domain.ProductAccessWrite)
+	}
+	return q
+}
+
 func (r *GormProductRepository) Create(product *domain.Product) error {
-	if err := r.db.Create(product).Error; err != nil {
+	product.OrganizationID = nil
+	if r.orgID != nil {
+		orgID := *r.orgID
+		product.OrganizationID = &orgID
+	}
+	if product.Attributes == nil {
+		product.Attributes = domain.ProductAttributes{}
+	}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(product).Error; err != nil {
+			return err
+		}
+		if err := replaceProductTags(tx, product.ID, product.Tags); err != nil {
+			return err
+		}
+		return replaceProductPrices(tx, product.ID, product.Prices)
+	})
+	if err != nil {
 		observability.RecordRepositoryOperation(context.Background(), "product", "create", "error")
 		return err
 	}
+	if product.Tags == nil {
+		product.Tags = []string{}
+	}
+	if product.Prices == nil {
+		product.Prices = []domain.ProductPrice{}
+	}
+	if product.Images == nil {
+		product.Images = []domain.ProductImage{}
+	}
 	observability.RecordRepositoryOperation(context.Background(), "product", "create", "success")
 	return nil
 }
 
 func (r *GormProductRepository) FindByID(id uint) (*domain.Product, error) {
 	var product domain.Product
-	if err := r.db.First(&product, id).Error; err != nil {
+	err := r.scoped().First(&product, id).Error
+	if err == nil {
+		err = loadProductDetails(r.db, []*domain.Product{&product})
+	}
+	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			observability.RecordRepositoryOperation(context.Background(), "product", "find_by_id", "not_found")
 			return nil, ErrProductNotFound
@@ -49,20 +199,53 @@ func (r *GormProductRepository) FindByID(id uint) (*domain.Product, error) {
 	return &product, nil
 }
 
-func (r *GormProductRepository) ListPaged(req PageRequest) (PageResult[domain.Product], error) {
-	normalized := normalizePageRequest(req)
+func (r *GormProductRepository) FindBySKU(sku string) (*domain.Product, error) {
+	var product domain.Product
+	err := r.scoped().Unscoped().Where("products.sku = ?", sku).First(&product).Error
+	if err == nil {
+		err = loadProductDetails(r.db, []*domain.Product{&product})
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			observability.RecordRepositoryOperation(context.Background(), "product", "find_by_sku", "not_found")
+			return nil, ErrProductNotFound
+		}
+		observability.RecordRepositoryOperation(context.Background(), "product", "find_by_sku", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "product", "find_by_sku", "success")
+	return &product, nil
+}
+
+func (r *GormProductRepository) ListPaged(query ProductListQuery) (PageResult[domain.Product], error) {
+	normalized := normalizePageRequest(query.PageRequest)
 	result := PageResult[domain.Product]{
 		Page:     normalized.Page,
 		PageSize: normalized.PageSize,
 	}
 
-	base := r.db.Model(&domain.Product{})
-	if err := base.Count(&result.Total).Error; err != nil {
+	base := r.filtered(query)
+	if err := base.Session(&gorm.Session{}).Count(&result.Total).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "product", "list_paged", "error")
+		return PageResult[domain.Product]{}, err
+	}
+
+	sortBy, sortOrder, err := productSort(query)
+	if err != nil {
 		observability.RecordRepositoryOperation(context.Background(), "product", "list_paged", "error")
 		return PageResult[domain.Product]{}, err
 	}
+	listQuery := base
+	if sortBy == productPriceSortColumn {
+		listQuery = r.pricedProducts(base, query.Currency)
+	}
+	listQuery = listQuery.Order("products." + sortBy + " " + sortOrder).Order("products.id " + sortOrder)
 	offset := (normalized.Page - 1) * normalized.PageSize
-	if err := base.Order("id desc").Offset(offset).Limit(normalized.PageSize).Find(&result.Items).Error; err != nil {
+	err = listQuery.Offset(offset).Limit(normalized.PageSize).Find(&result.Items).Error
+	if err == nil {
+		err = loadProductDetails(r.db, productPointers(result.Items))
+	}
+	if err != nil {
 		observability.RecordRepositoryOperation(context.Background(), "product", "list_paged", "error")
 		return PageResult[domain.Product]{}, err
 	}
@@ -71,30 +254,643 @@ func (r *GormProductRepository) ListPaged(req PageRequest) (PageResult[domain.Pr
 	return result, nil
 }
 
-func (r *GormProductRepository) Update(id uint, updates map[string]any) error {
-	res := r.db.Model(&domain.Product{}).Where("id = ?", id).Updates(updates)
+func (r *GormProductRepository) ListByCursor(query ProductListQuery, cursor CursorRequest) (CursorResult[domain.Product], error) {
+	sortBy, sortOrder, err := productSort(query)
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "product", "list_by_cursor", "error")
+		return CursorResult[domain.Product]{}, err
+	}
+	if sortBy == productPriceSortColumn {
+		return r.listPricedByCursor(query, sortOrder, cursor)
+	}
+	result, err := listByCursor(r.filtered(query), "products", sortBy, sortOrder, cursor, func(p domain.Product) (any, uint) {
+		switch sortBy {
+		case "name":
+			return p.Name, p.ID
+		case "created_at":
+			return p.CreatedAt, p.ID
+		default:
+			return nil, p.ID
+		}
+	})
+	if err == nil {
+		err = loadProductDetails(r.db, productPointers(result.Items))
+	}
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "product", "list_by_cursor", "error")
+		return CursorResult[domain.Product]{}, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "product", "list_by_cursor", "success")
+	return result, nil
+}
+
+func (r *GormProductRepository) ListAfter(afterID uint, limit int) ([]domain.Product, error) {
+	var items []domain.Product
+	err := r.scoped().Where("products.id > ?", afterID).Order("products.id ASC").Limit(limit).Find(&items).Error
+	if err == nil {
+		err = loadProductDetails(r.db, productPointers(items))
+	}
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "product", "list_after", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "product", "list_after", "success")
+	return items, nil
+}
+
+func (r *GormProductRepository) filtered(query ProductListQuery) *gorm.DB {
+	base := r.scoped().Model(&domain.Product{})
+	if search := strings.TrimSpace(query.Search); search != "" {
+		base = r.searchProducts(base, search)
+	}
+	if currency := strings.ToUpper(strings.TrimSpace(query.Currency)); currency != "" {
+		own := priceBounds(r.db.Where("products.currency = ?", currency), "products.price_minor", query)
+		listed := priceBounds(r.db.Model(&domain.ProductPrice{}).Select("product_id").Where("currency = ?", currency), "amount_minor", query)
+		base = base.Where(r.db.Where(own).Or("products.id IN (?)", listed))
+	}
+	if query.CreatedAfter != nil {
+		base = base.Where("products.created_at >= ?", query.CreatedAfter.UTC())
+	}
+	if query.CreatedBefore != nil {
+		base = base.Where("products.created_at < ?", query.CreatedBefore.UTC())
+	}
+	if query.CategoryID != 0 {
+		base = base.Where("products.category_id IN (?)", categorySubtree(r.db, query.CategoryID))
+	}
+	if tag := strings.TrimSpace(query.Tag); tag != "" {
+		base = base.Where("products.id IN (?)", r.db.Model(&domain.ProductTag{}).Select("product_id").Where("tag = ?", strings.ToLower(tag)))
+	}
+	return base
+}
+
+// priceBounds applies the query's minor-unit price bounds to column.
+func priceBounds(q *gorm.DB, column string, query ProductListQuery) *gorm.DB {
+	if query.MinPriceMinor != nil {
+		q = q.Where(column+" >= ?", *query.MinPriceMinor)
+	}
+	if query.MaxPriceMinor != nil {
+		q = q.Where(column+" <= ?", *query.MaxPriceMinor)
+	}
+	return q
+}
+
+// categorySubtree selects the IDs of categoryID and all of its descendants.
+func categorySubtree(db *gorm.DB, categoryID uint) *gorm.DB {
+	return db.Raw(`WITH RECURSIVE subtree(id) AS (
+		SELECT id FROM categories WHERE id = ?
+		UNION
+		SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+	) SELECT id FROM subtree`, categoryID)
+}
+
+// productPriceSortColumn is the column pricedProducts adds for sorting by
+// price.
+const productPriceSortColumn = "sort_price_minor"
+
+func productSort(query ProductListQuery) (string, string, error) {
+	sortBy := query.SortBy
+	switch sortBy {
+	case "":
+		sortBy = "created_at"
+	case "price":
+		if strings.TrimSpace(query.Currency) == "" {
+			return "", "", ErrProductPriceSortNeedsCurrency
+		}
+		sortBy = productPriceSortColumn
+	}
+	sortOrder := "desc"
+	if query.SortOrder == "asc" {
+		sortOrder = "asc"
+	}
+	return sortBy, sortOrder, nil
+}
+
+// pricedProduct is a product row carrying its price in the listing currency.
+type pricedProduct struct {
+	domain.Product
+	SortPriceMinor int64
+}
+
+// pricedProducts wraps the filtered products in a derived table named
+// products that adds sort_price_minor: the product's own price when it is
+// in currency, otherwise its list price in currency. The currency filter
+// guarantees one of them exists.
+func (r *GormProductRepository) pricedProducts(filtered *gorm.DB, currency string) *gorm.DB {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	listed := r.db.Model(&domain.ProductPrice{}).Select("product_prices.amount_minor").
+		Where("product_prices.product_id = products.id AND product_prices.currency = ?", currency)
+	inner := filtered.Select("products.*, CASE WHEN products.currency = ? THEN products.price_minor ELSE (?) END AS "+productPriceSortColumn, currency, listed)
+	return r.db.Table("(?) AS products", inner)
+}
+
+func (r *GormProductRepository) listPricedByCursor(query ProductListQuery, sortOrder string, cursor CursorRequest) (CursorResult[domain.Product], error) {
+	priced, err := listByCursor(r.pricedProducts(r.filtered(query), query.Currency), "products", productPriceSortColumn, sortOrder, cursor, func(p pricedProduct) (any, uint) {
+		return p.SortPriceMinor, p.ID
+	})
+	result := CursorResult[domain.Product]{PageSize: priced.PageSize, Next: priced.Next, Prev: priced.Prev}
+	if err == nil {
+		result.Items = make([]domain.Product, 0, len(priced.Items))
+		for _, item := range priced.Items {
+			result.Items = append(result.Items, item.Product)
+		}
+		err = loadProductDetails(r.db, productPointers(result.Items))
+	}
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "product", "list_by_cursor", "error")
+		return CursorResult[domain.Product]{}, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "product", "list_by_cursor", "success")
+	return result, nil
+}
+
+// searchProducts matches search against name and description. On Postgres
+// whole words go through the products_search_idx full-text index and
+// partial names through the products_name_trgm_idx trigram index, both
+// created by database.Migrate. Other dialects use a case-insensitive LIKE.
+func (r *GormProductRepository) searchProducts(q *gorm.DB, search string) *gorm.DB {
+	pattern := "%" + escapeLikePattern(strings.ToLower(search)) + "%"
+	if r.db.Dialector.Name() == "postgres" {
+		return q.Where(
+			"(to_tsvector('simple', products.name || ' ' || products.description) @@ plainto_tsquery('simple', ?) OR products.name ILIKE ? ESCAPE '\\')",
+			search, pattern,
+		)
+	}
+	return q.Where(
+		"(LOWER(products.name) LIKE ? ESCAPE '\\' OR LOWER(products.description) LIKE ? ESCAPE '\\')",
+		pattern, pattern,
+	)
+}
+
+func escapeLikePattern(value string) string {
+	return strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_").Replace(value)
+}
+
+func (r *GormProductRepository) Update(id, version uint, updates map[string]any) error {
+	changes := make(map[string]any, len(updates)+1)
+	var tags []string
+	var prices []domain.ProductPrice
+	replaceTags, replacePrices := false, false
+	for column, value := range updates {
+		switch column {
+		case "tags":
+			tags, replaceTags = value.([]string)
+		case "prices":
+			prices, replacePrices = value.([]domain.ProductPrice)
+		default:
+			changes[column] = value
+		}
+	}
+	changes["version"] = gorm.Expr("version + 1")
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		scoped := &GormProductRepository{db: tx, orgID: r.orgID, viewerID: r.viewerID}
+		q := scoped.scoped().Model(&domain.Product{}).Where("id = ?", id)
+		if version != 0 {
+			q = q.Where("version = ?", version)
+		}
+		res := q.Updates(changes)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return scoped.missingOrConflict(tx, id, version)
+		}
+		if replaceTags {
+			if err := tx.Where("product_id = ?", id).Delete(&domain.ProductTag{}).Error; err != nil {
+				return err
+			}
+			if err := replaceProductTags(tx, id, tags); err != nil {
+				return err
+			}
+		}
+		if replacePrices {
+			if err := tx.Where("product_id = ?", id).Delete(&domain.ProductPrice{}).Error; err != nil {
+				return err
+			}
+			return replaceProductPrices(tx, id, prices)
+		}
+		return nil
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "product", "update", productWriteOutcome(err))
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "product", "update", "success")
+	return nil
+}
+
+func (r *GormProductRepository) DeleteByID(id, version uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		scoped := &GormProductRepository{db: tx, orgID: r.orgID, viewerID: r.viewerID}
+		q := scoped.scoped()
+		if version != 0 {
+			q = q.Where("version = ?", version)
+		}
+		res := q.Delete(&domain.Product{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return scoped.missingOrConflict(tx, id, version)
+		}
+		return nil
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "product", "delete_by_id", productWriteOutcome(err))
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "product", "delete_by_id", "success")
+	return nil
+}
+
+// trashed selects soft-deleted products within the repository's organization.
+func (r *GormProductRepository) trashed() *gorm.DB {
+	return r.scoped().Unscoped().Model(&domain.Product{}).Where("products.deleted_at IS NOT NULL")
+}
+
+func (r *GormProductRepository) ListDeleted(req PageRequest) (PageResult[domain.Product], error) {
+	normalized := normalizePageRequest(req)
+	result := PageResult[domain.Product]{
+		Page:     normalized.Page,
+		PageSize: normalized.PageSize,
+	}
+	if err := r.trashed().Count(&result.Total).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "product", "list_deleted", "error")
+		return PageResult[domain.Product]{}, err
+	}
+	offset := (normalized.Page - 1) * normalized.PageSize
+	err := r.trashed().Order("products.deleted_at desc").Order("products.id desc").
+		Offset(offset).Limit(normalized.PageSize).Find(&result.Items).Error
+	if err == nil {
+		err = loadProductDetails(r.db, productPointers(result.Items))
+	}
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "product", "list_deleted", "error")
+		return PageResult[domain.Product]{}, err
+	}
+	result.TotalPages = calcTotalPages(result.Total, normalized.PageSize)
+	observability.RecordRepositoryOperation(context.Background(), "product", "list_deleted", "success")
+	return result, nil
+}
+
+func (r *GormProductRepository) Restore(id uint) error {
+	res := r.trashed().Where("products.id = ?", id).Update("deleted_at", nil)
 	if res.Error != nil {
-		observability.RecordRepositoryOperation(context.Background(), "product", "update", "error")
+		observability.RecordRepositoryOperation(context.Background(), "product", "restore", "error")
 		return res.Error
 	}
 	if res.RowsAffected == 0 {
-		observability.RecordRepositoryOperation(context.Background(), "product", "update", "not_found")
+		observability.RecordRepositoryOperation(context.Background(), "product", "restore", "not_found")
 		return ErrProductNotFound
 	}
-	observability.RecordRepositoryOperation(context.Background(), "product", "update", "success")
+	observability.RecordRepositoryOperation(context.Background(), "product", "restore", "success")
+	return nil
+}
+
+func (r *GormProductRepository) Purge(id uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		scoped := &GormProductRepository{db: tx, orgID: r.orgID}
+		res := scoped.trashed().Where("products.id = ?", id).Delete(&domain.Product{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrProductNotFound
+		}
+		return purgeProductDependents(tx, []uint{id})
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "product", "purge", productWriteOutcome(err))
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "product", "purge", "success")
 	return nil
 }
 
-func (r *GormProductRepository) DeleteByID(id uint) error {
-	res := r.db.Delete(&domain.Product{}, id)
+func (r *GormProductRepository) PurgeDeletedBefore(cutoff time.Time, limit int) ([]uint, error) {
+	var ids []uint
+	err := r.db.Unscoped().Model(&domain.Product{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff.UTC()).
+		Order("deleted_at asc").Limit(limit).Pluck("id", &ids).Error
+	if err == nil && len(ids) > 0 {
+		err = r.db.Transaction(func(tx *gorm.DB) error {
+			if err := purgeProductDependents(tx, ids); err != nil {
+				return err
+			}
+			return tx.Unscoped().Where("id IN ? AND deleted_at IS NOT NULL", ids).Delete(&domain.Product{}).Error
+		})
+	}
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "product", "purge_deleted_before", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "product", "purge_deleted_before", "success")
+	return ids, nil
+}
+
+// purgeProductDependents removes the rows that belong to purged products,
+// including their stock, reservations, stock history and cart lines.
+func purgeProductDependents(tx *gorm.DB, ids []uint) error {
+	dependents := []any{
+		&domain.ProductGrant{},
+		&domain.ProductTag{},
+		&domain.ProductPrice{},
+		&domain.ProductImage{},
+		&domain.ProductStock{},
+		&domain.StockReservation{},
+		&domain.StockMovement{},
+		&domain.CartItem{},
+	}
+	for _, model := range dependents {
+		if err := tx.Where("product_id IN ?", ids).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceProductTags inserts the product's tags. Existing tags must already
+// have been removed.
+func replaceProductTags(tx *gorm.DB, productID uint, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	rows := make([]domain.ProductTag, 0, len(tags))
+	for _, tag := range tags {
+		rows = append(rows, domain.ProductTag{ProductID: productID, Tag: tag})
+	}
+	return tx.Create(&rows).Error
+}
+
+// replaceProductPrices inserts the product's price list. Existing prices
+// must already have been removed.
+func replaceProductPrices(tx *gorm.DB, productID uint, prices []domain.ProductPrice) error {
+	if len(prices) == 0 {
+		return nil
+	}
+	rows := make([]domain.ProductPrice, 0, len(prices))
+	for _, price := range prices {
+		price.ProductID = productID
+		rows = append(rows, price)
+	}
+	return tx.Create(&rows).Error
+}
+
+// loadProductDetails fills Tags and Prices on every product with one query
+// per table.
+func loadProductDetails(db *gorm.DB, products []*domain.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+	ids := make([]uint, 0, len(products))
+	byID := make(map[uint]*domain.Product, len(products))
+	for _, p := range products {
+		ids = append(ids, p.ID)
+		byID[p.ID] = p
+		p.Tags = []string{}
+		p.Prices = []domain.ProductPrice{}
+		p.Images = []domain.ProductImage{}
+	}
+	var tags []domain.ProductTag
+	if err := db.Where("product_id IN ?", ids).Order("tag asc").Find(&tags).Error; err != nil {
+		return err
+	}
+	for _, row := range tags {
+		if p, ok := byID[row.ProductID]; ok {
+			p.Tags = append(p.Tags, row.Tag)
+		}
+	}
+	var prices []domain.ProductPrice
+	if err := db.Where("product_id IN ?", ids).Order("currency asc").Find(&prices).Error; err != nil {
+		return err
+	}
+	for _, row := range prices {
+		if p, ok := byID[row.ProductID]; ok {
+			p.Prices = append(p.Prices, row)
+		}
+	}
+	var images []domain.ProductImage
+	if err := db.Where("product_id IN ?", ids).Order("position asc").Order("id asc").Find(&images).Error; err != nil {
+		return err
+	}
+	for _, row := range images {
+		if p, ok := byID[row.ProductID]; ok {
+			p.Images = append(p.Images, row)
+		}
+	}
+	return nil
+}
+
+func productPointers(items []domain.Product) []*domain.Product {
+	out := make([]*domain.Product, len(items))
+	for i := range items {
+		out[i] = &items[i]
+	}
+	return out
+}
+
+// missingOrConflict explains a conditional write that matched no row: the
+// product is either out of scope or gone, or it exists at another version.
+func (r *GormProductRepository) missingOrConflict(db *gorm.DB, id, version uint) error {
+	if version == 0 {
+		return ErrProductNotFound
+	}
+	var count int64
+	scoped := &GormProductRepository{db: db, orgID: r.orgID, viewerID: r.viewerID}
+	if err := scoped.scoped().Model(&domain.Product{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrProductNotFound
+	}
+	return ErrProductVersionConflict
+}
+
+func productWriteOutcome(err error) string {
+	switch {
+	case errors.Is(err, ErrProductNotFound), errors.Is(err, ErrProductImageNotFound):
+		return "not_found"
+	case errors.Is(err, ErrProductVersionConflict), errors.Is(err, ErrProductImageLimit):
+		return "conflict"
+	case errors.Is(err, ErrProductImageOrder):
+		return "bad_request"
+	default:
+		return "error"
+	}
+}
+
+func (r *GormProductRepository) HasGrant(productID, userID uint, access string) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.ProductGrant{}).
+		Where("product_id = ? AND product_id IN (?)", productID, r.grantedProductIDs(userID, access)).
+		Count(&count).Error
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "product", "has_grant", "error")
+		return false, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "product", "has_grant", "success")
+	return count > 0, nil
+}
+
+func (r *GormProductRepository) ListGrants(productID uint) ([]domain.ProductGrant, error) {
+	var grants []domain.ProductGrant
+	if err := r.db.Where("product_id = ?", productID).Order("id asc").Find(&grants).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "product", "list_grants", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "product", "list_grants", "success")
+	return grants, nil
+}
+
+// UpsertGrant creates the grant or, when the product is already shared with
+// the same user or group, updates its access level in place.
+func (r *GormProductRepository) UpsertGrant(grant *domain.ProductGrant) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var existing domain.ProductGrant
+		q := tx.Where("product_id = ?", grant.ProductID)
+		if grant.UserID != nil {
+			q = q.Where("user_id = ?", *grant.UserID)
+		} else {
+			q = q.Where("group_id = ?", grant.GroupID)
+		}
+		err := q.First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(grant).Error
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&existing).Update("access", grant.Access).Error; err != nil {
+			return err
+		}
+		existing.Access = grant.Access
+		*grant = existing
+		return nil
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "product", "upsert_grant", "error")
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "product", "upsert_grant", "success")
+	return nil
+}
+
+func (r *GormProductRepository) DeleteGrant(productID, grantID uint) error {
+	res := r.db.Where("product_id = ?", productID).Delete(&domain.ProductGrant{}, grantID)
+	if res.Error != nil {
+		observability.RecordRepositoryOperation(context.Background(), "product", "delete_grant", "error")
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		observability.RecordRepositoryOperation(context.Background(), "product", "delete_grant", "not_found")
+		return ErrProductGrantNotFound
+	}
+	observability.RecordRepositoryOperation(context.Background(), "product", "delete_grant", "success")
+	return nil
+}
+
+// touchForGallery bumps the version of the product, when it is in scope,
+// since its gallery is part of its representation. The update also locks the
+// row, so gallery changes of one product apply one at a time.
+func (r *GormProductRepository) touchForGallery(tx *gorm.DB, productID uint) error {
+	scoped := &GormProductRepository{db: tx, orgID: r.orgID}
+	res := scoped.scoped().Model(&domain.Product{}).Where("id = ?", productID).
+		Updates(map[string]any{"version": gorm.Expr("version + 1")})
 	if res.Error != nil {
-		observability.RecordRepositoryOperation(context.Background(), "product", "delete_by_id", "error")
 		return res.Error
 	}
 	if res.RowsAffected == 0 {
-		observability.RecordRepositoryOperation(context.Background(), "product", "delete_by_id", "not_found")
 		return ErrProductNotFound
 	}
-	observability.RecordRepositoryOperation(context.Background(), "product", "delete_by_id", "success")
 	return nil
 }
+
+func (r *GormProductRepository) AddImage(image *domain.ProductImage, limit int) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.touchForGallery(tx, image.ProductID); err != nil {
+			return err
+		}
+		var count int64
+		if err := tx.Model(&domain.ProductImage{}).Where("product_id = ?", image.ProductID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= int64(limit) {
+			return ErrProductImageLimit
+		}
+		image.Position = int(count)
+		return tx.Create(image).Error
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "product", "add_image", productWriteOutcome(err))
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "product", "add_image", "success")
+	return nil
+}
+
+func (r *GormProductRepository) ReorderImages(productID uint, imageIDs []uint) ([]domain.ProductImage, error) {
+	var images []domain.ProductImage
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.touchForGallery(tx, productID); err != nil {
+			return err
+		}
+		if err := tx.Where("product_id = ?", productID).Find(&images).Error; err != nil {
+			return err
+		}
+		byID := make(map[uint]*domain.ProductImage, len(images))
+		for i := range images {
+			byID[images[i].ID] = &images[i]
+		}
+		if len(imageIDs) != len(images) {
+			return ErrProductImageOrder
+		}
+		for position, id := range imageIDs {
+			image, ok := byID[id]
+			if !ok {
+				return ErrProductImageOrder
+			}
+			delete(byID, id)
+			if image.Position == position {
+				continue
+			}
+			if err := tx.Model(&domain.ProductImage{}).Where("id = ?", id).Update("position", position).Error; err != nil {
+				return err
+			}
+			image.Position = position
+		}
+		return nil
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "product", "reorder_images", productWriteOutcome(err))
+		return nil, err
+	}
+	sort.Slice(images, func(i, j int) bool { return images[i].Position < images[j].Position })
+	observability.RecordRepositoryOperation(context.Background(), "product", "reorder_images", "success")
+	return images, nil
+}
+
+func (r *GormProductRepository) DeleteImage(productID, imageID uint) (*domain.ProductImage, error) {
+	var image domain.ProductImage
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.touchForGallery(tx, productID); err != nil {
+			return err
+		}
+		if err := tx.Where("product_id = ?", productID).First(&image, imageID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrProductImageNotFound
+			}
+			return err
+		}
+		if err := tx.Delete(&domain.ProductImage{}, image.ID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domain.ProductImage{}).
+			Where("product_id = ? AND position > ?", productID, image.Position).
+			Update("position", gorm.Expr("position - 1")).Error
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "product", "delete_image", productWriteOutcome(err))
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "product", "delete_image", "success")
+	return &image, nil
+}
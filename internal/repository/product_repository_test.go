@@ -4,27 +4,28 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
 )
 
 func TestProductRepositoryCRUDAndPagination(t *testing.T) {
 	db := newRepositoryDBForTest(t)
-	if err := db.AutoMigrate(&domain.Product{}); err != nil {
+	if err := db.AutoMigrate(&domain.Product{}, &domain.ProductGrant{}, &domain.ProductTag{}, &domain.ProductPrice{}, &domain.ProductImage{}, &domain.ProductStock{}, &domain.StockReservation{}, &domain.StockMovement{}, &domain.CartItem{}); err != nil {
 		t.Fatalf("migrate product: %v", err)
 	}
 	repo := NewProductRepository(db)
 
 	created := make([]*domain.Product, 0, 3)
 	for i := 0; i < 3; i++ {
-		p := &domain.Product{Name: fmt.Sprintf("Product %c", 'A'+i), Description: "desc", Price: float64(10 + i)}
+		p := &domain.Product{Name: fmt.Sprintf("Product %c", 'A'+i), Description: "desc", PriceMinor: int64(1000 + i), Currency: "USD"}
 		if err := repo.Create(p); err != nil {
 			t.Fatalf("create product %d: %v", i, err)
 		}
 		created = append(created, p)
 	}
 
-	page, err := repo.ListPaged(PageRequest{Page: 1, PageSize: 2})
+	page, err := repo.ListPaged(ProductListQuery{PageRequest: PageRequest{Page: 1, PageSize: 2}})
 	if err != nil {
 		t.Fatalf("list paged: %v", err)
 	}
@@ -43,18 +44,18 @@ func TestProductRepositoryCRUDAndPagination(t *testing.T) {
 		t.Fatalf("name mismatch: got %q want %q", loaded.Name, created[0].Name)
 	}
 
-	if err := repo.Update(created[0].ID, map[string]any{"name": "Renamed", "price": 99.5}); err != nil {
+	if err := repo.Update(created[0].ID, 0, map[string]any{"name": "Renamed", "price_minor": int64(9950)}); err != nil {
 		t.Fatalf("update: %v", err)
 	}
 	updated, err := repo.FindByID(created[0].ID)
 	if err != nil {
 		t.Fatalf("find updated: %v", err)
 	}
-	if updated.Name != "Renamed" || updated.Price != 99.5 {
+	if updated.Name != "Renamed" || updated.PriceMinor != 9950 {
 		t.Fatalf("unexpected updated product: %+v", updated)
 	}
 
-	if err := repo.DeleteByID(created[1].ID); err != nil {
+	if err := repo.DeleteByID(created[1].ID, 0); err != nil {
 		t.Fatalf("delete by id: %v", err)
 	}
 	if _, err := repo.FindByID(created[1].ID); !errors.Is(err, ErrProductNotFound) {
@@ -62,9 +63,128 @@ func TestProductRepositoryCRUDAndPagination(t *testing.T) {
 	}
 }
 
+func TestProductRepositoryListFiltersAndSort(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.Product{}, &domain.ProductGrant{}, &domain.ProductTag{}, &domain.ProductPrice{}, &domain.ProductImage{}, &domain.ProductStock{}, &domain.StockReservation{}, &domain.StockMovement{}, &domain.CartItem{}); err != nil {
+		t.Fatalf("migrate product: %v", err)
+	}
+	repo := NewProductRepository(db)
+
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	seed := []domain.Product{
+		{Name: "Blue Mug", Description: "ceramic", PriceMinor: 1200, Currency: "USD", CreatedAt: base},
+		{Name: "Red Mug", Description: "enamel", PriceMinor: 800, Currency: "USD", CreatedAt: base.Add(24 * time.Hour)},
+		{Name: "Teapot", Description: "fits a blue mug set", PriceMinor: 3000, Currency: "USD", Prices: []domain.ProductPrice{{Currency: "EUR", AmountMinor: 900}}, CreatedAt: base.Add(48 * time.Hour)},
+		{Name: "100%_Cotton Towel", Description: "bath", PriceMinor: 1500, Currency: "USD", CreatedAt: base.Add(72 * time.Hour)},
+		{Name: "Saucer", Description: "porcelain", PriceMinor: 400, Currency: "EUR", Prices: []domain.ProductPrice{{Currency: "USD", AmountMinor: 2000}}, CreatedAt: base.Add(96 * time.Hour)},
+	}
+	for i := range seed {
+		if err := repo.Create(&seed[i]); err != nil {
+			t.Fatalf("create %s: %v", seed[i].Name, err)
+		}
+	}
+	names := func(items []domain.Product) []string {
+		out := make([]string, 0, len(items))
+		for _, item := range items {
+			out = append(out, item.Name)
+		}
+		return out
+	}
+	price := func(v int64) *int64 { return &v }
+	at := func(v time.Time) *time.Time { return &v }
+
+	cases := []struct {
+		name  string
+		query ProductListQuery
+		want  []string
+	}{
+		{name: "default newest first", query: ProductListQuery{}, want: []string{"Saucer", "100%_Cotton Towel", "Teapot", "Red Mug", "Blue Mug"}},
+		{name: "search name and description", query: ProductListQuery{Search: "BLUE", SortBy: "name", SortOrder: "asc"}, want: []string{"Blue Mug", "Teapot"}},
+		{name: "search escapes wildcards", query: ProductListQuery{Search: "0%_c"}, want: []string{"100%_Cotton Towel"}},
+		{name: "wildcards are literal", query: ProductListQuery{Search: "_"}, want: []string{"100%_Cotton Towel"}},
+		{name: "price range by price", query: ProductListQuery{Currency: "USD", MinPriceMinor: price(800), MaxPriceMinor: price(1500), SortBy: "price", SortOrder: "desc"}, want: []string{"100%_Cotton Towel", "Blue Mug", "Red Mug"}},
+		{name: "price list currency", query: ProductListQuery{Currency: "eur", MinPriceMinor: price(800), MaxPriceMinor: price(1500)}, want: []string{"Teapot"}},
+		// The Saucer's own EUR 4.00 must not rank it below USD prices; its
+		// USD list price of 20.00 does.
+		{name: "price sort uses the filtered currency", query: ProductListQuery{Currency: "USD", SortBy: "price", SortOrder: "asc"}, want: []string{"Red Mug", "Blue Mug", "100%_Cotton Towel", "Saucer", "Teapot"}},
+		{name: "price sort across own and list prices", query: ProductListQuery{Currency: "EUR", SortBy: "price", SortOrder: "desc"}, want: []string{"Teapot", "Saucer"}},
+		{name: "created range", query: ProductListQuery{CreatedAfter: at(base.Add(24 * time.Hour)), CreatedBefore: at(base.Add(72 * time.Hour)), SortOrder: "asc"}, want: []string{"Red Mug", "Teapot"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			page, err := repo.ListPaged(tc.query)
+			if err != nil {
+				t.Fatalf("list paged: %v", err)
+			}
+			got := names(page.Items)
+			if fmt.Sprint(got) != fmt.Sprint(tc.want) || page.Total != int64(len(tc.want)) {
+				t.Fatalf("expected %v (total %d), got %v (total %d)", tc.want, len(tc.want), got, page.Total)
+			}
+		})
+	}
+
+	// Cursor pages continue from the list price, not the own price.
+	usdByPrice := ProductListQuery{Currency: "USD", SortBy: "price", SortOrder: "asc"}
+	var walked []string
+	cursor := CursorRequest{PageSize: 2}
+	for {
+		page, err := repo.ListByCursor(usdByPrice, cursor)
+		if err != nil {
+			t.Fatalf("list by cursor: %v", err)
+		}
+		walked = append(walked, names(page.Items)...)
+		if page.Next == nil {
+			break
+		}
+		cursor.Position = page.Next
+	}
+	if want := []string{"Red Mug", "Blue Mug", "100%_Cotton Towel", "Saucer", "Teapot"}; fmt.Sprint(walked) != fmt.Sprint(want) {
+		t.Fatalf("expected cursor walk %v, got %v", want, walked)
+	}
+
+	if _, err := repo.ListPaged(ProductListQuery{SortBy: "price"}); !errors.Is(err, ErrProductPriceSortNeedsCurrency) {
+		t.Fatalf("expected ErrProductPriceSortNeedsCurrency, got %v", err)
+	}
+}
+
+func TestProductRepositoryVersionChecks(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.Product{}, &domain.ProductGrant{}, &domain.ProductTag{}, &domain.ProductPrice{}, &domain.ProductImage{}, &domain.ProductStock{}, &domain.StockReservation{}, &domain.StockMovement{}, &domain.CartItem{}); err != nil {
+		t.Fatalf("migrate product: %v", err)
+	}
+	repo := NewProductRepository(db)
+	product := &domain.Product{Name: "Mug", PriceMinor: 5}
+	if err := repo.Create(product); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if product.Version != 1 {
+		t.Fatalf("expected new products at version 1, got %d", product.Version)
+	}
+
+	if err := repo.Update(product.ID, 1, map[string]any{"name": "First"}); err != nil {
+		t.Fatalf("update at current version: %v", err)
+	}
+	if err := repo.Update(product.ID, 1, map[string]any{"name": "Second"}); !errors.Is(err, ErrProductVersionConflict) {
+		t.Fatalf("expected ErrProductVersionConflict for a stale version, got %v", err)
+	}
+	if err := repo.DeleteByID(product.ID, 1); !errors.Is(err, ErrProductVersionConflict) {
+		t.Fatalf("expected ErrProductVersionConflict on delete, got %v", err)
+	}
+	loaded, err := repo.FindByID(product.ID)
+	if err != nil || loaded.Name != "First" || loaded.Version != 2 {
+		t.Fatalf("expected the first write to win at version 2, got %+v err=%v", loaded, err)
+	}
+	if err := repo.Update(999, 1, map[string]any{"name": "x"}); !errors.Is(err, ErrProductNotFound) {
+		t.Fatalf("expected ErrProductNotFound for a missing product, got %v", err)
+	}
+	if err := repo.DeleteByID(product.ID, 2); err != nil {
+		t.Fatalf("delete at current version: %v", err)
+	}
+}
+
 func TestProductRepositoryNotFoundCases(t *testing.T) {
 	db := newRepositoryDBForTest(t)
-	if err := db.AutoMigrate(&domain.Product{}); err != nil {
+	if err := db.AutoMigrate(&domain.Product{}, &domain.ProductGrant{}, &domain.ProductTag{}, &domain.ProductPrice{}, &domain.ProductImage{}, &domain.ProductStock{}, &domain.StockReservation{}, &domain.StockMovement{}, &domain.CartItem{}); err != nil {
 		t.Fatalf("migrate product: %v", err)
 	}
 	repo := NewProductRepository(db)
@@ -72,10 +192,374 @@ func TestProductRepositoryNotFoundCases(t *testing.T) {
 	if _, err := repo.FindByID(999); !errors.Is(err, ErrProductNotFound) {
 		t.Fatalf("expected ErrProductNotFound, got %v", err)
 	}
-	if err := repo.Update(999, map[string]any{"name": "x"}); !errors.Is(err, ErrProductNotFound) {
+	if err := repo.Update(999, 0, map[string]any{"name": "x"}); !errors.Is(err, ErrProductNotFound) {
 		t.Fatalf("expected ErrProductNotFound on update, got %v", err)
 	}
-	if err := repo.DeleteByID(999); !errors.Is(err, ErrProductNotFound) {
+	if err := repo.DeleteByID(999, 0); !errors.Is(err, ErrProductNotFound) {
 		t.Fatalf("expected ErrProductNotFound on delete, got %v", err)
 	}
 }
+
+func TestProductRepositoryOrganizationScoping(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.Product{}, &domain.ProductGrant{}, &domain.ProductTag{}, &domain.ProductPrice{}, &domain.ProductImage{}, &domain.ProductStock{}, &domain.StockReservation{}, &domain.StockMovement{}, &domain.CartItem{}); err != nil {
+		t.Fatalf("migrate product: %v", err)
+	}
+	global := NewProductRepository(db)
+	acme := global.ForOrganization(1)
+	globex := global.ForOrganization(2)
+
+	shared := &domain.Product{Name: "Shared", PriceMinor: 1}
+	if err := global.Create(shared); err != nil {
+		t.Fatalf("create global product: %v", err)
+	}
+	owned := &domain.Product{Name: "Acme Widget", PriceMinor: 2}
+	if err := acme.Create(owned); err != nil {
+		t.Fatalf("create acme product: %v", err)
+	}
+	if owned.OrganizationID == nil || *owned.OrganizationID != 1 {
+		t.Fatalf("expected product stamped with organization 1, got %v", owned.OrganizationID)
+	}
+
+	page, err := acme.ListPaged(ProductListQuery{PageRequest: PageRequest{Page: 1, PageSize: 10}})
+	if err != nil {
+		t.Fatalf("list acme: %v", err)
+	}
+	if page.Total != 1 || page.Items[0].ID != owned.ID {
+		t.Fatalf("expected only acme product, got %+v", page.Items)
+	}
+	page, err = global.ListPaged(ProductListQuery{PageRequest: PageRequest{Page: 1, PageSize: 10}})
+	if err != nil {
+		t.Fatalf("list global: %v", err)
+	}
+	if page.Total != 1 || page.Items[0].ID != shared.ID {
+		t.Fatalf("expected only unscoped product, got %+v", page.Items)
+	}
+
+	if _, err := globex.FindByID(owned.ID); !errors.Is(err, ErrProductNotFound) {
+		t.Fatalf("expected cross-tenant lookup to miss, got %v", err)
+	}
+	if err := globex.Update(owned.ID, 0, map[string]any{"name": "Stolen"}); !errors.Is(err, ErrProductNotFound) {
+		t.Fatalf("expected cross-tenant update to miss, got %v", err)
+	}
+	if err := globex.DeleteByID(owned.ID, 0); !errors.Is(err, ErrProductNotFound) {
+		t.Fatalf("expected cross-tenant delete to miss, got %v", err)
+	}
+	if err := acme.DeleteByID(owned.ID, 0); err != nil {
+		t.Fatalf("delete in own tenant: %v", err)
+	}
+}
+
+func TestProductRepositoryViewerScopingAndGrants(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.Product{}, &domain.ProductGrant{}, &domain.ProductTag{}, &domain.ProductPrice{}, &domain.ProductImage{}, &domain.ProductStock{}, &domain.StockReservation{}, &domain.StockMovement{}, &domain.CartItem{}); err != nil {
+		t.Fatalf("migrate product: %v", err)
+	}
+	repo := NewProductRepository(db)
+	ownerID, viewerID, groupID := uint(1), uint(2), uint(7)
+
+	owned := &domain.Product{Name: "Owned", PriceMinor: 1, OwnerID: &ownerID}
+	other := &domain.Product{Name: "Other", PriceMinor: 2, OwnerID: &ownerID}
+	for _, p := range []*domain.Product{owned, other} {
+		if err := repo.Create(p); err != nil {
+			t.Fatalf("create product: %v", err)
+		}
+	}
+
+	viewer := repo.ForViewer(viewerID)
+	page, err := viewer.ListPaged(ProductListQuery{PageRequest: PageRequest{Page: 1, PageSize: 10}})
+	if err != nil {
+		t.Fatalf("list as viewer: %v", err)
+	}
+	if page.Total != 0 {
+		t.Fatalf("expected no visible products before sharing, got %+v", page.Items)
+	}
+	page, err = repo.ForViewer(ownerID).ListPaged(ProductListQuery{PageRequest: PageRequest{Page: 1, PageSize: 10}})
+	if err != nil {
+		t.Fatalf("list as owner: %v", err)
+	}
+	if page.Total != 2 {
+		t.Fatalf("expected owner to see both products, got %d", page.Total)
+	}
+
+	grant := &domain.ProductGrant{ProductID: owned.ID, UserID: &viewerID, Access: domain.ProductAccessRead}
+	if err := repo.UpsertGrant(grant); err != nil {
+		t.Fatalf("upsert grant: %v", err)
+	}
+	if _, err := viewer.FindByID(owned.ID); err != nil {
+		t.Fatalf("expected shared product to be visible: %v", err)
+	}
+	if _, err := viewer.FindByID(other.ID); !errors.Is(err, ErrProductNotFound) {
+		t.Fatalf("expected unshared product to miss, got %v", err)
+	}
+	canWrite, err := repo.HasGrant(owned.ID, viewerID, domain.ProductAccessWrite)
+	if err != nil || canWrite {
+		t.Fatalf("expected read grant to not imply write, got %v err=%v", canWrite, err)
+	}
+
+	upgraded := &domain.ProductGrant{ProductID: owned.ID, UserID: &viewerID, Access: domain.ProductAccessWrite}
+	if err := repo.UpsertGrant(upgraded); err != nil {
+		t.Fatalf("upgrade grant: %v", err)
+	}
+	if upgraded.ID != grant.ID || upgraded.Access != domain.ProductAccessWrite {
+		t.Fatalf("expected grant %d upgraded in place, got %+v", grant.ID, upgraded)
+	}
+	canWrite, err = repo.HasGrant(owned.ID, viewerID, domain.ProductAccessWrite)
+	if err != nil || !canWrite {
+		t.Fatalf("expected write grant, got %v err=%v", canWrite, err)
+	}
+
+	if err := db.Create(&domain.GroupMember{GroupID: groupID, UserID: viewerID}).Error; err != nil {
+		t.Fatalf("add group member: %v", err)
+	}
+	if err := repo.UpsertGrant(&domain.ProductGrant{ProductID: other.ID, GroupID: &groupID, Access: domain.ProductAccessRead}); err != nil {
+		t.Fatalf("upsert group grant: %v", err)
+	}
+	if _, err := viewer.FindByID(other.ID); err != nil {
+		t.Fatalf("expected group-shared product to be visible: %v", err)
+	}
+
+	grants, err := repo.ListGrants(owned.ID)
+	if err != nil || len(grants) != 1 {
+		t.Fatalf("expected one grant, got %+v err=%v", grants, err)
+	}
+	if err := repo.DeleteGrant(other.ID, grant.ID); !errors.Is(err, ErrProductGrantNotFound) {
+		t.Fatalf("expected grant lookup scoped to product, got %v", err)
+	}
+	if err := repo.DeleteGrant(owned.ID, grant.ID); err != nil {
+		t.Fatalf("delete grant: %v", err)
+	}
+	if _, err := viewer.FindByID(owned.ID); !errors.Is(err, ErrProductNotFound) {
+		t.Fatalf("expected revoked product to miss, got %v", err)
+	}
+
+	if err := repo.DeleteByID(other.ID, 0); err != nil {
+		t.Fatalf("delete product: %v", err)
+	}
+	if grants, _ := repo.ListGrants(other.ID); len(grants) != 1 {
+		t.Fatalf("expected grants kept while the product is in the trash, got %+v", grants)
+	}
+	if err := repo.Purge(other.ID); err != nil {
+		t.Fatalf("purge product: %v", err)
+	}
+	if grants, _ := repo.ListGrants(other.ID); len(grants) != 0 {
+		t.Fatalf("expected grants removed with the purged product, got %+v", grants)
+	}
+}
+
+func TestProductRepositoryTrash(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.Product{}, &domain.ProductGrant{}, &domain.ProductTag{}, &domain.ProductPrice{}, &domain.ProductImage{}, &domain.ProductStock{}, &domain.StockReservation{}, &domain.StockMovement{}, &domain.CartItem{}); err != nil {
+		t.Fatalf("migrate product: %v", err)
+	}
+	global := NewProductRepository(db)
+	acme := global.ForOrganization(1)
+
+	kept := &domain.Product{Name: "Kept", PriceMinor: 1}
+	old := &domain.Product{Name: "Old", PriceMinor: 1}
+	tenant := &domain.Product{Name: "Tenant", PriceMinor: 1}
+	for _, p := range []*domain.Product{kept, old} {
+		if err := global.Create(p); err != nil {
+			t.Fatalf("create product: %v", err)
+		}
+	}
+	if err := acme.Create(tenant); err != nil {
+		t.Fatalf("create tenant product: %v", err)
+	}
+	for _, id := range []uint{kept.ID, old.ID} {
+		stock := []any{
+			&domain.ProductStock{ProductID: id, OnHand: 5, Reserved: 1},
+			&domain.StockReservation{ProductID: id, Quantity: 1, Status: domain.ReservationActive, ExpiresAt: time.Now().Add(time.Hour)},
+			&domain.StockMovement{ProductID: id, Kind: domain.StockMovementAdjust, OnHandDelta: 5, OnHandAfter: 5},
+			&domain.CartItem{CartID: 1, ProductID: id, Quantity: 1},
+		}
+		for _, row := range stock {
+			if err := db.Create(row).Error; err != nil {
+				t.Fatalf("create %T: %v", row, err)
+			}
+		}
+		if err := global.DeleteByID(id, 0); err != nil {
+			t.Fatalf("delete product %d: %v", id, err)
+		}
+	}
+	if err := acme.DeleteByID(tenant.ID, 0); err != nil {
+		t.Fatalf("delete tenant product: %v", err)
+	}
+
+	page, err := global.ListPaged(ProductListQuery{PageRequest: PageRequest{Page: 1, PageSize: 10}})
+	if err != nil || page.Total != 0 {
+		t.Fatalf("expected deleted products hidden from listings, got %+v err=%v", page, err)
+	}
+	trash, err := global.ListDeleted(PageRequest{Page: 1, PageSize: 10})
+	if err != nil || trash.Total != 2 {
+		t.Fatalf("expected two platform products in the trash, got %+v err=%v", trash, err)
+	}
+	if err := acme.Restore(kept.ID); !errors.Is(err, ErrProductNotFound) {
+		t.Fatalf("expected restore scoped to the organization, got %v", err)
+	}
+	if err := global.Purge(kept.ID); err != nil {
+		t.Fatalf("purge product: %v", err)
+	}
+	if err := global.Restore(kept.ID); !errors.Is(err, ErrProductNotFound) {
+		t.Fatalf("expected purged product to be gone, got %v", err)
+	}
+
+	past := time.Now().UTC().Add(-48 * time.Hour)
+	if err := db.Unscoped().Model(&domain.Product{}).Where("id = ?", old.ID).Update("deleted_at", past).Error; err != nil {
+		t.Fatalf("backdate deletion: %v", err)
+	}
+	purged, err := global.PurgeDeletedBefore(time.Now().UTC().Add(-24*time.Hour), 10)
+	if err != nil || len(purged) != 1 || purged[0] != old.ID {
+		t.Fatalf("expected only the expired product purged, got %v err=%v", purged, err)
+	}
+	for _, model := range []any{&domain.ProductStock{}, &domain.StockReservation{}, &domain.StockMovement{}, &domain.CartItem{}} {
+		var count int64
+		if err := db.Model(model).Count(&count).Error; err != nil || count != 0 {
+			t.Fatalf("expected %T rows purged with their products, got %d err=%v", model, count, err)
+		}
+	}
+	if err := acme.Restore(tenant.ID); err != nil {
+		t.Fatalf("restore tenant product: %v", err)
+	}
+	restored, err := acme.FindByID(tenant.ID)
+	if err != nil || restored.DeletedAt.Valid {
+		t.Fatalf("expected restored product visible again, got %+v err=%v", restored, err)
+	}
+	if err := acme.Purge(tenant.ID); !errors.Is(err, ErrProductNotFound) {
+		t.Fatalf("expected purge to require the product in the trash, got %v", err)
+	}
+}
+
+func TestProductRepositorySKUAndBatches(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.Product{}, &domain.ProductGrant{}, &domain.ProductTag{}, &domain.ProductPrice{}, &domain.ProductImage{}, &domain.ProductStock{}, &domain.StockReservation{}, &domain.StockMovement{}, &domain.CartItem{}); err != nil {
+		t.Fatalf("migrate product: %v", err)
+	}
+	acme := NewProductRepository(db).ForOrganization(1)
+	globex := NewProductRepository(db).ForOrganization(2)
+
+	sku := "WID-1"
+	created := make([]*domain.Product, 0, 5)
+	for i := 0; i < 5; i++ {
+		p := &domain.Product{Name: fmt.Sprintf("Widget %d", i), PriceMinor: 100, Currency: "USD", Tags: []string{"bulk"}}
+		if i == 0 {
+			p.SKU = &sku
+		}
+		if err := acme.Create(p); err != nil {
+			t.Fatalf("create product %d: %v", i, err)
+		}
+		created = append(created, p)
+	}
+	if err := acme.Create(&domain.Product{Name: "Duplicate", PriceMinor: 1, SKU: &sku}); err == nil {
+		t.Fatal("expected a duplicate sku in one organization to be rejected")
+	}
+	if err := globex.Create(&domain.Product{Name: "Other tenant", PriceMinor: 1, SKU: &sku}); err != nil {
+		t.Fatalf("expected the sku to be free in another organization, got %v", err)
+	}
+
+	found, err := acme.FindBySKU(sku)
+	if err != nil || found.ID != created[0].ID || len(found.Tags) != 1 {
+		t.Fatalf("find by sku: %+v err=%v", found, err)
+	}
+	if _, err := acme.FindBySKU("missing"); !errors.Is(err, ErrProductNotFound) {
+		t.Fatalf("expected ErrProductNotFound, got %v", err)
+	}
+
+	var seen []uint
+	var after uint
+	for {
+		batch, err := acme.ListAfter(after, 2)
+		if err != nil {
+			t.Fatalf("list after %d: %v", after, err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+		for _, p := range batch {
+			if len(p.Tags) != 1 {
+				t.Fatalf("expected tags loaded, got %+v", p)
+			}
+			seen = append(seen, p.ID)
+		}
+		after = batch[len(batch)-1].ID
+	}
+	if len(seen) != len(created) || seen[0] != created[0].ID || seen[4] != created[4].ID {
+		t.Fatalf("expected every acme product in id order, got %v", seen)
+	}
+
+	if err := acme.DeleteByID(created[0].ID, 0); err != nil {
+		t.Fatalf("delete sku holder: %v", err)
+	}
+	trashed, err := acme.FindBySKU(sku)
+	if err != nil || trashed.ID != created[0].ID || !trashed.DeletedAt.Valid {
+		t.Fatalf("expected the trashed product to keep its sku, got %+v err=%v", trashed, err)
+	}
+}
+
+func TestProductRepositoryImages(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.Product{}, &domain.ProductGrant{}, &domain.ProductTag{}, &domain.ProductPrice{}, &domain.ProductImage{}, &domain.ProductStock{}, &domain.StockReservation{}, &domain.StockMovement{}, &domain.CartItem{}); err != nil {
+		t.Fatalf("migrate product: %v", err)
+	}
+	acme := NewProductRepository(db).ForOrganization(1)
+	globex := NewProductRepository(db).ForOrganization(2)
+
+	product := &domain.Product{Name: "Gallery", PriceMinor: 100, Currency: "USD"}
+	if err := acme.Create(product); err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	images := make([]*domain.ProductImage, 0, 3)
+	for i := 0; i < 3; i++ {
+		image := &domain.ProductImage{ProductID: product.ID, ObjectKey: fmt.Sprintf("products/%d/%d.png", product.ID, i), ContentType: "image/png", Size: 10}
+		if err := acme.AddImage(image, 3); err != nil {
+			t.Fatalf("add image %d: %v", i, err)
+		}
+		if image.Position != i {
+			t.Fatalf("expected image %d at position %d, got %d", i, i, image.Position)
+		}
+		images = append(images, image)
+	}
+	if err := acme.AddImage(&domain.ProductImage{ProductID: product.ID, ObjectKey: "x", ContentType: "image/png"}, 3); !errors.Is(err, ErrProductImageLimit) {
+		t.Fatalf("expected ErrProductImageLimit, got %v", err)
+	}
+	if err := globex.AddImage(&domain.ProductImage{ProductID: product.ID, ObjectKey: "x", ContentType: "image/png"}, 3); !errors.Is(err, ErrProductNotFound) {
+		t.Fatalf("expected another tenant's product to be missing, got %v", err)
+	}
+
+	if _, err := acme.ReorderImages(product.ID, []uint{images[0].ID, images[1].ID}); !errors.Is(err, ErrProductImageOrder) {
+		t.Fatalf("expected ErrProductImageOrder for a partial order, got %v", err)
+	}
+	if _, err := acme.ReorderImages(product.ID, []uint{images[0].ID, images[0].ID, images[1].ID}); !errors.Is(err, ErrProductImageOrder) {
+		t.Fatalf("expected ErrProductImageOrder for a repeated id, got %v", err)
+	}
+	reordered, err := acme.ReorderImages(product.ID, []uint{images[2].ID, images[0].ID, images[1].ID})
+	if err != nil || len(reordered) != 3 || reordered[0].ID != images[2].ID || reordered[2].ID != images[1].ID {
+		t.Fatalf("reorder images: %+v err=%v", reordered, err)
+	}
+
+	removed, err := acme.DeleteImage(product.ID, images[2].ID)
+	if err != nil || removed.ObjectKey != images[2].ObjectKey {
+		t.Fatalf("delete image: %+v err=%v", removed, err)
+	}
+	if _, err := acme.DeleteImage(product.ID, images[2].ID); !errors.Is(err, ErrProductImageNotFound) {
+		t.Fatalf("expected ErrProductImageNotFound, got %v", err)
+	}
+	found, err := acme.FindByID(product.ID)
+	if err != nil || len(found.Images) != 2 || found.Images[0].ID != images[0].ID || found.Images[0].Position != 0 || found.Images[1].Position != 1 {
+		t.Fatalf("expected the gap closed after delete, got %+v err=%v", found, err)
+	}
+	if found.Version != product.Version+5 {
+		t.Fatalf("expected every gallery change to bump the version, got %d", found.Version)
+	}
+
+	if err := acme.DeleteByID(product.ID, 0); err != nil {
+		t.Fatalf("delete product: %v", err)
+	}
+	if err := acme.Purge(product.ID); err != nil {
+		t.Fatalf("purge product: %v", err)
+	}
+	var count int64
+	if err := db.Model(&domain.ProductImage{}).Where("product_id = ?", product.ID).Count(&count).Error; err != nil || count != 0 {
+		t.Fatalf("expected purge to remove image records, got %d err=%v", count, err)
+	}
+}
@@ -24,6 +24,12 @@ func newRepositoryDBForTest(t *testing.T) *gorm.DB {
 		&domain.Permission{},
 		&domain.Role{},
 		&domain.User{},
+		&domain.UserRole{},
+		&domain.RoleChangeRequest{},
+		&domain.Group{},
+		&domain.GroupMember{},
+		&domain.Organization{},
+		&domain.Membership{},
 		&domain.LocalCredential{},
 		&domain.VerificationToken{},
 		&domain.OAuthAccount{},
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+)
+
+var (
+	ErrRoleChangeRequestNotFound   = errors.New("role change request not found")
+	ErrRoleChangeRequestNotPending = errors.New("role change request is not pending")
+)
+
+type RoleChangeRequestRepository interface {
+	Create(req *domain.RoleChangeRequest) error
+	FindByID(id uint) (*domain.RoleChangeRequest, error)
+	ListPaged(req PageRequest, status string) (PageResult[domain.RoleChangeRequest], error)
+	// Transition moves a request from one status to another. It fails with
+	// ErrRoleChangeRequestNotPending if the request is no longer in from, which
+	// makes concurrent decisions on the same request safe.
+	Transition(id uint, from, to string, decidedBy *uint, reason string, at time.Time) error
+	ListExpiredPending(now time.Time, limit int) ([]domain.RoleChangeRequest, error)
+}
+
+type GormRoleChangeRequestRepository struct{ db *gorm.DB }
+
+func NewRoleChangeRequestRepository(db *gorm.DB) RoleChangeRequestRepository {
+	return &GormRoleChangeRequestRepository{db: db}
+}
+
+func (r *GormRoleChangeRequestRepository) Create(req *domain.RoleChangeRequest) error {
+	if err := r.db.Create(req).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "role_change_request", "create", "error")
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "role_change_request", "create", "success")
+	return nil
+}
+
+func (r *GormRoleChangeRequestRepository) FindByID(id uint) (*domain.RoleChangeRequest, error) {
+	var req domain.RoleChangeRequest
+	if err := r.db.First(&req, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			observability.RecordRepositoryOperation(context.Background(), "role_change_request", "find_by_id", "not_found")
+			return nil, ErrRoleChangeRequestNotFound
+		}
+		observability.RecordRepositoryOperation(context.Background(), "role_change_request", "find_by_id", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "role_change_request", "find_by_id", "success")
+	return &req, nil
+}
+
+func (r *GormRoleChangeRequestRepository) ListPaged(req PageRequest, status string) (PageResult[domain.RoleChangeRequest], error) {
+	pageReq := normalizePageRequest(req)
+	result := PageResult[domain.RoleChangeRequest]{
+		Page:     pageReq.Page,
+		PageSize: pageReq.PageSize,
+	}
+	base := r.db.Model(&domain.RoleChangeRequest{})
+	if status != "" {
+		base = base.Where("status = ?", status)
+	}
+	if err := base.Session(&gorm.Session{}).Count(&result.Total).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "role_change_request", "list_paged", "error")
+		return PageResult[domain.RoleChangeRequest]{}, err
+	}
+	offset := (pageReq.Page - 1) * pageReq.PageSize
+	if err := base.Order("id desc").Offset(offset).Limit(pageReq.PageSize).Find(&result.Items).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "role_change_request", "list_paged", "error")
+		return PageResult[domain.RoleChangeRequest]{}, err
+	}
+	result.TotalPages = calcTotalPages(result.Total, pageReq.PageSize)
+	observability.RecordRepositoryOperation(context.Background(), "role_change_request", "list_paged", "success")
+	return result, nil
+}
+
+func (r *GormRoleChangeRequestRepository) Transition(id uint, from, to string, decidedBy *uint, reason string, at time.Time) error {
+	res := r.db.Model(&domain.RoleChangeRequest{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(map[string]any{
+			"status":          to,
+			"decided_by":      decidedBy,
+			"decision_reason": reason,
+			"decided_at":      at,
+		})
+	if res.Error != nil {
+		observability.RecordRepositoryOperation(context.Background(), "role_change_request", "transition", "error")
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		observability.RecordRepositoryOperation(context.Background(), "role_change_request", "transition", "not_found")
+		return ErrRoleChangeRequestNotPending
+	}
+	observability.RecordRepositoryOperation(context.Background(), "role_change_request", "transition", "success")
+	return nil
+}
+
+func (r *GormRoleChangeRequestRepository) ListExpiredPending(now time.Time, limit int) ([]domain.RoleChangeRequest, error) {
+	if limit <= 0 {
+		limit = 500
+	}
+	var reqs []domain.RoleChangeRequest
+	err := r.db.Where("status = ? AND expires_at <= ?", domain.RoleChangeRequestStatusPending, now).
+		Order("id asc").
+		Limit(limit).
+		Find(&reqs).Error
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "role_change_request", "list_expired_pending", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "role_change_request", "list_expired_pending", "success")
+	return reqs, nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+)
+
+func TestRoleChangeRequestRepositoryLifecycle(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	repo := NewRoleChangeRequestRepository(db)
+	now := time.Now().UTC()
+
+	pending := &domain.RoleChangeRequest{
+		TargetUserID: 5,
+		RequestedBy:  1,
+		RoleIDs:      []uint{1, 2},
+		Status:       domain.RoleChangeRequestStatusPending,
+		ExpiresAt:    now.Add(time.Hour),
+	}
+	stale := &domain.RoleChangeRequest{
+		TargetUserID: 6,
+		RequestedBy:  1,
+		RoleIDs:      []uint{},
+		Status:       domain.RoleChangeRequestStatusPending,
+		ExpiresAt:    now.Add(-time.Minute),
+	}
+	for _, req := range []*domain.RoleChangeRequest{pending, stale} {
+		if err := repo.Create(req); err != nil {
+			t.Fatalf("create request: %v", err)
+		}
+	}
+
+	found, err := repo.FindByID(pending.ID)
+	if err != nil {
+		t.Fatalf("find request: %v", err)
+	}
+	if len(found.RoleIDs) != 2 || found.RoleIDs[0] != 1 || found.RoleIDs[1] != 2 {
+		t.Fatalf("expected role ids round-trip, got %+v", found.RoleIDs)
+	}
+	if _, err := repo.FindByID(999); !errors.Is(err, ErrRoleChangeRequestNotFound) {
+		t.Fatalf("expected ErrRoleChangeRequestNotFound, got %v", err)
+	}
+
+	expired, err := repo.ListExpiredPending(now, 10)
+	if err != nil {
+		t.Fatalf("list expired pending: %v", err)
+	}
+	if len(expired) != 1 || expired[0].ID != stale.ID {
+		t.Fatalf("expected only stale request, got %+v", expired)
+	}
+
+	approver := uint(2)
+	if err := repo.Transition(pending.ID, domain.RoleChangeRequestStatusPending, domain.RoleChangeRequestStatusApproved, &approver, "ok", now); err != nil {
+		t.Fatalf("approve request: %v", err)
+	}
+	if err := repo.Transition(pending.ID, domain.RoleChangeRequestStatusPending, domain.RoleChangeRequestStatusRejected, &approver, "late", now); !errors.Is(err, ErrRoleChangeRequestNotPending) {
+		t.Fatalf("expected second decision to fail with ErrRoleChangeRequestNotPending, got %v", err)
+	}
+
+	page, err := repo.ListPaged(PageRequest{Page: 1, PageSize: 10}, domain.RoleChangeRequestStatusApproved)
+	if err != nil {
+		t.Fatalf("list paged: %v", err)
+	}
+	if page.Total != 1 || len(page.Items) != 1 || page.Items[0].DecidedBy == nil || *page.Items[0].DecidedBy != approver {
+		t.Fatalf("unexpected approved page: %+v", page)
+	}
+}
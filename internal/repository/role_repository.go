@@ -16,6 +16,7 @@ type RoleRepository interface {
 	FindByName(name string) (*domain.Role, error)
 	List() ([]domain.Role, error)
 	ListPaged(req PageRequest, sortBy, sortOrder, name string) (PageResult[domain.Role], error)
+	ListByCursor(cursor CursorRequest, sortBy, sortOrder, name string) (CursorResult[domain.Role], error)
 	Create(role *domain.Role, permissionIDs []uint) error
 	Update(role *domain.Role, permissionIDs []uint) error
 	DeleteByID(id uint) error
@@ -73,10 +74,7 @@ func (r *GormRoleRepository) ListPaged(req PageRequest, sortBy, sortOrder, name
 		PageSize: normalized.PageSize,
 	}
 
-	base := r.db.Model(&domain.Role{})
-	if name != "" {
-		base = base.Where("roles.name LIKE ?", name+"%")
-	}
+	base := r.filtered(name)
 	if err := base.Count(&result.Total).Error; err != nil {
 		observability.RecordRepositoryOperation(context.Background(), "role", "list_paged", "error")
 		return PageResult[domain.Role]{}, err
@@ -97,6 +95,37 @@ func (r *GormRoleRepository) ListPaged(req PageRequest, sortBy, sortOrder, name
 	return result, nil
 }
 
+func (r *GormRoleRepository) ListByCursor(cursor CursorRequest, sortBy, sortOrder, name string) (CursorResult[domain.Role], error) {
+	result, err := listByCursor(r.filtered(name).Preload("Permissions"), "roles", sortBy, sortOrder, cursor, func(role domain.Role) (any, uint) {
+		switch sortBy {
+		case "id":
+			return role.ID, role.ID
+		case "name":
+			return role.Name, role.ID
+		case "created_at":
+			return role.CreatedAt, role.ID
+		case "updated_at":
+			return role.UpdatedAt, role.ID
+		default:
+			return nil, role.ID
+		}
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "role", "list_by_cursor", "error")
+		return CursorResult[domain.Role]{}, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "role", "list_by_cursor", "success")
+	return result, nil
+}
+
+func (r *GormRoleRepository) filtered(name string) *gorm.DB {
+	base := r.db.Model(&domain.Role{})
+	if name != "" {
+		base = base.Where("roles.name LIKE ?", name+"%")
+	}
+	return base
+}
+
 func (r *GormRoleRepository) Create(role *domain.Role, permissionIDs []uint) error {
 	if err := r.db.Create(role).Error; err != nil {
 		observability.RecordRepositoryOperation(context.Background(), "role", "create", "error")
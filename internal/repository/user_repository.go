@@ -3,13 +3,23 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrUserEmailReserved is returned by Create when the email belongs to a
+// soft-deleted user. The address stays taken until that user is purged.
+var ErrUserEmailReserved = errors.New("email belongs to a deleted user")
+
+// ErrRoleGrantWindowConflict is returned by GrantRole when the user still
+// holds the role for a window that neither overlaps nor touches the new one.
+var ErrRoleGrantWindowConflict = errors.New("role grant window does not overlap the current grant")
+
 type UserListQuery struct {
 	PageRequest
 	SortBy    string
@@ -17,6 +27,10 @@ type UserListQuery struct {
 	Email     string
 	Status    string
 	Role      string
+	// OrganizationID limits results to members of that organization when set.
+	OrganizationID uint
+	// GroupID limits results to members of that group when set.
+	GroupID uint
 }
 
 type UserRepository interface {
@@ -26,8 +40,30 @@ type UserRepository interface {
 	Update(user *domain.User) error
 	List() ([]domain.User, error)
 	ListPaged(query UserListQuery) (PageResult[domain.User], error)
+	// ListByCursor returns a keyset page of the users matching query, ordered
+	// by query.SortBy (created_at when empty) and id. query.PageRequest is
+	// ignored.
+	ListByCursor(query UserListQuery, cursor CursorRequest) (CursorResult[domain.User], error)
 	SetRoles(userID uint, roleIDs []uint) error
 	AddRole(userID, roleID uint) error
+	GrantRole(grant *domain.UserRole) error
+	ListRoleGrants(userID uint) ([]domain.UserRole, error)
+	ListExpiredRoleGrants(now time.Time, limit int) ([]domain.UserRole, error)
+	DeleteExpiredRoleGrant(userID, roleID uint, now time.Time) (bool, error)
+	// DeleteByID soft-deletes the user and revokes their sessions.
+	// gorm.ErrRecordNotFound is returned for missing or already deleted users.
+	DeleteByID(id uint) error
+	// ListDeleted returns a page of soft-deleted users, most recently deleted
+	// first.
+	ListDeleted(req PageRequest) (PageResult[domain.User], error)
+	// Restore brings a soft-deleted user back. Revoked sessions stay revoked.
+	Restore(id uint) error
+	// Purge permanently removes a soft-deleted user together with their
+	// credentials, sessions, role grants and memberships. Products they own
+	// are kept without an owner.
+	Purge(id uint) error
+	// PurgeDeletedBefore purges up to limit users soft-deleted before cutoff.
+	PurgeDeletedBefore(cutoff time.Time, limit int) (int, error)
 }
 
 type GormUserRepository struct{ db *gorm.DB }
@@ -36,7 +72,10 @@ func NewUserRepository(db *gorm.DB) UserRepository { return &GormUserRepository{
 
 func (r *GormUserRepository) FindByID(id uint) (*domain.User, error) {
 	var u domain.User
-	err := r.db.Preload("Roles.Permissions").First(&u, id).Error
+	err := r.db.First(&u, id).Error
+	if err == nil {
+		err = r.loadActiveRoles(&u, time.Now().UTC())
+	}
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			observability.RecordRepositoryOperation(context.Background(), "user", "find_by_id", "not_found")
@@ -51,7 +90,10 @@ func (r *GormUserRepository) FindByID(id uint) (*domain.User, error) {
 
 func (r *GormUserRepository) FindByEmail(email string) (*domain.User, error) {
 	var u domain.User
-	err := r.db.Preload("Roles.Permissions").Where("email = ?", email).First(&u).Error
+	err := r.db.Where("email = ?", email).First(&u).Error
+	if err == nil {
+		err = r.loadActiveRoles(&u, time.Now().UTC())
+	}
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			observability.RecordRepositoryOperation(context.Background(), "user", "find_by_email", "not_found")
@@ -67,6 +109,13 @@ func (r *GormUserRepository) FindByEmail(email string) (*domain.User, error) {
 func (r *GormUserRepository) Create(user *domain.User) error {
 	err := r.db.Create(user).Error
 	if err != nil {
+		var deleted int64
+		if countErr := r.db.Unscoped().Model(&domain.User{}).
+			Where("email = ? AND deleted_at IS NOT NULL", user.Email).
+			Count(&deleted).Error; countErr == nil && deleted > 0 {
+			observability.RecordRepositoryOperation(context.Background(), "user", "create", "conflict")
+			return ErrUserEmailReserved
+		}
 		observability.RecordRepositoryOperation(context.Background(), "user", "create", "error")
 		return err
 	}
@@ -101,19 +150,7 @@ func (r *GormUserRepository) ListPaged(query UserListQuery) (PageResult[domain.U
 		PageSize: req.PageSize,
 	}
 
-	base := r.db.Model(&domain.User{})
-	if query.Email != "" {
-		base = base.Where("users.email LIKE ?", query.Email+"%")
-	}
-	if query.Status != "" {
-		base = base.Where("users.status = ?", query.Status)
-	}
-	if query.Role != "" {
-		base = base.Joins("JOIN user_roles ur ON ur.user_id = users.id").
-			Joins("JOIN roles r ON r.id = ur.role_id").
-			Where("r.name = ?", query.Role)
-	}
-
+	base := r.filtered(query)
 	countQuery := base.Session(&gorm.Session{})
 	if query.Role != "" {
 		countQuery = countQuery.Distinct("users.id")
@@ -142,16 +179,96 @@ func (r *GormUserRepository) ListPaged(query UserListQuery) (PageResult[domain.U
 	return result, nil
 }
 
+func (r *GormUserRepository) ListByCursor(query UserListQuery, cursor CursorRequest) (CursorResult[domain.User], error) {
+	sortBy := query.SortBy
+	if sortBy == "" {
+		sortBy = "created_at"
+	}
+	listQuery := r.filtered(query).Preload("Roles")
+	if query.Role != "" {
+		listQuery = listQuery.Distinct("users.*")
+	}
+	result, err := listByCursor(listQuery, "users", sortBy, query.SortOrder, cursor, func(u domain.User) (any, uint) {
+		switch sortBy {
+		case "id":
+			return u.ID, u.ID
+		case "email":
+			return u.Email, u.ID
+		case "name":
+			return u.Name, u.ID
+		case "status":
+			return u.Status, u.ID
+		case "last_login_at":
+			return u.LastLoginAt, u.ID
+		case "created_at":
+			return u.CreatedAt, u.ID
+		case "updated_at":
+			return u.UpdatedAt, u.ID
+		default:
+			return nil, u.ID
+		}
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "user", "list_by_cursor", "error")
+		return CursorResult[domain.User]{}, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "user", "list_by_cursor", "success")
+	return result, nil
+}
+
+func (r *GormUserRepository) filtered(query UserListQuery) *gorm.DB {
+	base := r.db.Model(&domain.User{})
+	if query.Email != "" {
+		base = base.Where("users.email LIKE ?", query.Email+"%")
+	}
+	if query.Status != "" {
+		base = base.Where("users.status = ?", query.Status)
+	}
+	if query.OrganizationID != 0 {
+		base = base.Where("users.id IN (?)", r.db.Model(&domain.Membership{}).
+			Select("user_id").
+			Where("organization_id = ? AND status = ?", query.OrganizationID, domain.MembershipActive))
+	}
+	if query.GroupID != 0 {
+		base = base.Where("users.id IN (?)", r.db.Model(&domain.GroupMember{}).
+			Select("user_id").
+			Where("group_id = ?", query.GroupID))
+	}
+	if query.Role != "" {
+		base = base.Joins("JOIN user_roles ur ON ur.user_id = users.id").
+			Joins("JOIN roles r ON r.id = ur.role_id").
+			Where("r.name = ?", query.Role)
+	}
+	return base
+}
+
+// SetRoles makes roleIDs the user's direct roles. Grants for roles the user
+// keeps are left untouched, so their validity windows survive.
 func (r *GormUserRepository) SetRoles(userID uint, roleIDs []uint) error {
-	var roles []domain.Role
-	if len(roleIDs) > 0 {
-		if err := r.db.Where("id IN ?", roleIDs).Find(&roles).Error; err != nil {
-			observability.RecordRepositoryOperation(context.Background(), "user", "set_roles", "error")
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var keep []uint
+		if len(roleIDs) > 0 {
+			if err := tx.Model(&domain.Role{}).Where("id IN ?", roleIDs).Pluck("id", &keep).Error; err != nil {
+				return err
+			}
+		}
+		stale := tx.Where("user_id = ?", userID)
+		if len(keep) > 0 {
+			stale = stale.Where("role_id NOT IN ?", keep)
+		}
+		if err := stale.Delete(&domain.UserRole{}).Error; err != nil {
 			return err
 		}
-	}
-	u := domain.User{ID: userID}
-	if err := r.db.Model(&u).Association("Roles").Replace(roles); err != nil {
+		if len(keep) == 0 {
+			return nil
+		}
+		grants := make([]domain.UserRole, 0, len(keep))
+		for _, roleID := range keep {
+			grants = append(grants, domain.UserRole{UserID: userID, RoleID: roleID})
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&grants).Error
+	})
+	if err != nil {
 		observability.RecordRepositoryOperation(context.Background(), "user", "set_roles", "error")
 		return err
 	}
@@ -169,3 +286,281 @@ func (r *GormUserRepository) AddRole(userID, roleID uint) error {
 	observability.RecordRepositoryOperation(context.Background(), "user", "add_role", "success")
 	return nil
 }
+
+// loadActiveRoles populates u.Roles (with permissions) from grants whose
+// validity window contains now, so expired or not-yet-valid grants never
+// contribute permissions even before the reaper removes them. Roles inherited
+// from group membership are loaded into u.GroupRoles.
+func (r *GormUserRepository) loadActiveRoles(u *domain.User, now time.Time) error {
+	activeRoleIDs := r.db.Model(&domain.UserRole{}).
+		Select("role_id").
+		Where("user_id = ?", u.ID).
+		Where("valid_from IS NULL OR valid_from <= ?", now).
+		Where("valid_until IS NULL OR valid_until > ?", now)
+	roles := make([]domain.Role, 0)
+	if err := r.db.Preload("Permissions").Where("id IN (?)", activeRoleIDs).Order("id asc").Find(&roles).Error; err != nil {
+		return err
+	}
+	u.Roles = roles
+
+	groupIDs := r.db.Model(&domain.GroupMember{}).Select("group_id").Where("user_id = ?", u.ID)
+	groupRoleIDs := r.db.Table("group_roles").Select("role_id").Where("group_id IN (?)", groupIDs)
+	groupRoles := make([]domain.Role, 0)
+	if err := r.db.Preload("Permissions").Where("id IN (?)", groupRoleIDs).Order("id asc").Find(&groupRoles).Error; err != nil {
+		return err
+	}
+	u.GroupRoles = groupRoles
+	return nil
+}
+
+// GrantRole creates the grant or, when the user already holds the role for
+// a window that overlaps or touches the new one, widens it to cover both so
+// a re-grant never shortens access. An existing grant that has already
+// expired is replaced; a live grant for a disjoint window is left alone and
+// ErrRoleGrantWindowConflict is returned.
+func (r *GormUserRepository) GrantRole(grant *domain.UserRole) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var existing domain.UserRole
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND role_id = ?", grant.UserID, grant.RoleID).
+			First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(grant).Error
+		}
+		if err != nil {
+			return err
+		}
+		if existing.ValidUntil == nil || existing.ValidUntil.After(time.Now()) {
+			if !grantWindowsMeet(existing, *grant) {
+				return ErrRoleGrantWindowConflict
+			}
+			grant.ValidFrom = earlierGrantBound(existing.ValidFrom, grant.ValidFrom)
+			grant.ValidUntil = laterGrantBound(existing.ValidUntil, grant.ValidUntil)
+		}
+		grant.CreatedAt = existing.CreatedAt
+		return tx.Model(&domain.UserRole{}).
+			Where("user_id = ? AND role_id = ?", grant.UserID, grant.RoleID).
+			Updates(map[string]any{"valid_from": grant.ValidFrom, "valid_until": grant.ValidUntil, "justification": grant.Justification}).Error
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "user", "grant_role", "error")
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "user", "grant_role", "success")
+	return nil
+}
+
+// grantWindowsMeet reports whether two grant windows overlap or touch, so
+// their union is a single window.
+func grantWindowsMeet(a, b domain.UserRole) bool {
+	startsInTime := func(from, until *time.Time) bool {
+		return from == nil || until == nil || !from.After(*until)
+	}
+	return startsInTime(b.ValidFrom, a.ValidUntil) && startsInTime(a.ValidFrom, b.ValidUntil)
+}
+
+// earlierGrantBound returns the earlier start; nil means unbounded.
+func earlierGrantBound(a, b *time.Time) *time.Time {
+	if a == nil || b == nil {
+		return nil
+	}
+	if a.Before(*b) {
+		return a
+	}
+	return b
+}
+
+// laterGrantBound returns the later end; nil means unbounded.
+func laterGrantBound(a, b *time.Time) *time.Time {
+	if a == nil || b == nil {
+		return nil
+	}
+	if a.After(*b) {
+		return a
+	}
+	return b
+}
+
+func (r *GormUserRepository) ListRoleGrants(userID uint) ([]domain.UserRole, error) {
+	var grants []domain.UserRole
+	err := r.db.Where("user_id = ?", userID).Order("role_id asc").Find(&grants).Error
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "user", "list_role_grants", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "user", "list_role_grants", "success")
+	return grants, nil
+}
+
+func (r *GormUserRepository) ListExpiredRoleGrants(now time.Time, limit int) ([]domain.UserRole, error) {
+	if limit <= 0 {
+		limit = 500
+	}
+	var grants []domain.UserRole
+	err := r.db.Where("valid_until IS NOT NULL AND valid_until <= ?", now).
+		Order("valid_until asc").
+		Limit(limit).
+		Find(&grants).Error
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "user", "list_expired_role_grants", "error")
+		return nil, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "user", "list_expired_role_grants", "success")
+	return grants, nil
+}
+
+// DeleteExpiredRoleGrant removes the grant only if it is still expired at now,
+// so a grant extended concurrently by an admin is left in place.
+func (r *GormUserRepository) DeleteExpiredRoleGrant(userID, roleID uint, now time.Time) (bool, error) {
+	res := r.db.Where("user_id = ? AND role_id = ? AND valid_until IS NOT NULL AND valid_until <= ?", userID, roleID, now).
+		Delete(&domain.UserRole{})
+	if res.Error != nil {
+		observability.RecordRepositoryOperation(context.Background(), "user", "delete_expired_role_grant", "error")
+		return false, res.Error
+	}
+	if res.RowsAffected == 0 {
+		observability.RecordRepositoryOperation(context.Background(), "user", "delete_expired_role_grant", "not_found")
+		return false, nil
+	}
+	observability.RecordRepositoryOperation(context.Background(), "user", "delete_expired_role_grant", "success")
+	return true, nil
+}
+
+func (r *GormUserRepository) DeleteByID(id uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Delete(&domain.User{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Model(&domain.Session{}).
+			Where("user_id = ? AND revoked_at IS NULL", id).
+			Updates(map[string]any{"revoked_at": time.Now(), "revoked_reason": "user_deleted"}).Error
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "user", "delete_by_id", userWriteOutcome(err))
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "user", "delete_by_id", "success")
+	return nil
+}
+
+// trashed selects soft-deleted users.
+func (r *GormUserRepository) trashed() *gorm.DB {
+	return r.db.Unscoped().Model(&domain.User{}).Where("users.deleted_at IS NOT NULL")
+}
+
+func (r *GormUserRepository) ListDeleted(req PageRequest) (PageResult[domain.User], error) {
+	normalized := normalizePageRequest(req)
+	result := PageResult[domain.User]{
+		Page:     normalized.Page,
+		PageSize: normalized.PageSize,
+	}
+	if err := r.trashed().Count(&result.Total).Error; err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "user", "list_deleted", "error")
+		return PageResult[domain.User]{}, err
+	}
+	offset := (normalized.Page - 1) * normalized.PageSize
+	err := r.trashed().Order("users.deleted_at desc").Order("users.id desc").
+		Offset(offset).Limit(normalized.PageSize).Find(&result.Items).Error
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "user", "list_deleted", "error")
+		return PageResult[domain.User]{}, err
+	}
+	result.TotalPages = calcTotalPages(result.Total, normalized.PageSize)
+	observability.RecordRepositoryOperation(context.Background(), "user", "list_deleted", "success")
+	return result, nil
+}
+
+func (r *GormUserRepository) Restore(id uint) error {
+	res := r.trashed().Where("users.id = ?", id).Update("deleted_at", nil)
+	if res.Error != nil {
+		observability.RecordRepositoryOperation(context.Background(), "user", "restore", "error")
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		observability.RecordRepositoryOperation(context.Background(), "user", "restore", "not_found")
+		return gorm.ErrRecordNotFound
+	}
+	observability.RecordRepositoryOperation(context.Background(), "user", "restore", "success")
+	return nil
+}
+
+func (r *GormUserRepository) Purge(id uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return purgeUsers(tx, []uint{id}, true)
+	})
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "user", "purge", userWriteOutcome(err))
+		return err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "user", "purge", "success")
+	return nil
+}
+
+func (r *GormUserRepository) PurgeDeletedBefore(cutoff time.Time, limit int) (int, error) {
+	var ids []uint
+	err := r.trashed().Where("users.deleted_at < ?", cutoff.UTC()).
+		Order("users.deleted_at asc").Limit(limit).Pluck("users.id", &ids).Error
+	if err == nil && len(ids) > 0 {
+		err = r.db.Transaction(func(tx *gorm.DB) error {
+			return purgeUsers(tx, ids, false)
+		})
+	}
+	if err != nil {
+		observability.RecordRepositoryOperation(context.Background(), "user", "purge_deleted_before", "error")
+		return 0, err
+	}
+	observability.RecordRepositoryOperation(context.Background(), "user", "purge_deleted_before", "success")
+	return len(ids), nil
+}
+
+// purgeUsers hard-deletes soft-deleted users and every row that only makes
+// sense while they exist. Owned products are detached rather than deleted.
+// With requireAll set, any id not in the trash aborts with
+// gorm.ErrRecordNotFound.
+func purgeUsers(tx *gorm.DB, ids []uint, requireAll bool) error {
+	res := tx.Unscoped().Where("id IN ? AND deleted_at IS NOT NULL", ids).Delete(&domain.User{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if requireAll && res.RowsAffected != int64(len(ids)) {
+		return gorm.ErrRecordNotFound
+	}
+	dependents := []any{
+		&domain.UserRole{},
+		&domain.LocalCredential{},
+		&domain.OAuthAccount{},
+		&domain.Session{},
+		&domain.VerificationToken{},
+		&domain.GroupMember{},
+		&domain.Membership{},
+		&domain.ProductGrant{},
+		&domain.FeatureFlagExposure{},
+	}
+	for _, model := range dependents {
+		if err := tx.Where("user_id IN ?", ids).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	carts := tx.Model(&domain.Cart{}).Select("id").Where("user_id IN ?", ids)
+	if err := tx.Where("cart_id IN (?)", carts).Delete(&domain.CartItem{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("user_id IN ?", ids).Delete(&domain.Cart{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("target_user_id IN ? OR requested_by IN ?", ids, ids).Delete(&domain.RoleChangeRequest{}).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Model(&domain.Product{}).Where("owner_id IN ?", ids).Update("owner_id", nil).Error
+}
+
+func userWriteOutcome(err error) string {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "not_found"
+	}
+	return "error"
+}
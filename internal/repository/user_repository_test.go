@@ -1,9 +1,13 @@
 package repository
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+
+	"gorm.io/gorm"
 )
 
 func TestUserRepositoryListPagedFiltersSortAndRoleAssociations(t *testing.T) {
@@ -69,6 +73,29 @@ func TestUserRepositoryListPagedFiltersSortAndRoleAssociations(t *testing.T) {
 		t.Fatalf("expected 2 users with role=user, got total=%d items=%d", rolePage.Total, len(rolePage.Items))
 	}
 
+	org := &domain.Organization{Slug: "acme", Name: "Acme"}
+	if err := db.Create(org).Error; err != nil {
+		t.Fatalf("create organization: %v", err)
+	}
+	for _, m := range []domain.Membership{
+		{OrganizationID: org.ID, UserID: u1.ID, Status: domain.MembershipActive},
+		{OrganizationID: org.ID, UserID: u2.ID, Status: domain.MembershipInvited},
+	} {
+		if err := db.Create(&m).Error; err != nil {
+			t.Fatalf("create membership: %v", err)
+		}
+	}
+	orgPage, err := userRepo.ListPaged(UserListQuery{
+		PageRequest:    PageRequest{Page: 1, PageSize: 10},
+		OrganizationID: org.ID,
+	})
+	if err != nil {
+		t.Fatalf("list paged by organization: %v", err)
+	}
+	if orgPage.Total != 1 || len(orgPage.Items) != 1 || orgPage.Items[0].ID != u1.ID {
+		t.Fatalf("expected only the active member, got %+v", orgPage)
+	}
+
 	if err := userRepo.SetRoles(u1.ID, []uint{userRole.ID}); err != nil {
 		t.Fatalf("set roles replace: %v", err)
 	}
@@ -80,3 +107,269 @@ func TestUserRepositoryListPagedFiltersSortAndRoleAssociations(t *testing.T) {
 		t.Fatalf("expected roles replaced to [user], got %+v", updated.Roles)
 	}
 }
+
+func TestUserRepositoryRoleGrantsRespectValidityWindow(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	userRepo := NewUserRepository(db)
+	roleRepo := NewRoleRepository(db)
+
+	permWrite := &domain.Permission{Resource: "users", Action: "write"}
+	if err := db.Create(permWrite).Error; err != nil {
+		t.Fatalf("create permission: %v", err)
+	}
+	baseRole := &domain.Role{Name: "user"}
+	oncallRole := &domain.Role{Name: "oncall"}
+	futureRole := &domain.Role{Name: "future"}
+	if err := roleRepo.Create(baseRole, nil); err != nil {
+		t.Fatalf("create base role: %v", err)
+	}
+	if err := roleRepo.Create(oncallRole, []uint{permWrite.ID}); err != nil {
+		t.Fatalf("create oncall role: %v", err)
+	}
+	if err := roleRepo.Create(futureRole, nil); err != nil {
+		t.Fatalf("create future role: %v", err)
+	}
+	u := &domain.User{Email: "oncall@example.com", Name: "Oncall", Status: "active"}
+	if err := userRepo.Create(u); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	if err := userRepo.AddRole(u.ID, baseRole.ID); err != nil {
+		t.Fatalf("add base role: %v", err)
+	}
+
+	now := time.Now().UTC()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Hour)
+	if err := userRepo.GrantRole(&domain.UserRole{UserID: u.ID, RoleID: oncallRole.ID, ValidUntil: &past, Justification: "INC-1"}); err != nil {
+		t.Fatalf("grant expired oncall role: %v", err)
+	}
+	if err := userRepo.GrantRole(&domain.UserRole{UserID: u.ID, RoleID: futureRole.ID, ValidFrom: &future}); err != nil {
+		t.Fatalf("grant future role: %v", err)
+	}
+
+	found, err := userRepo.FindByEmail(u.Email)
+	if err != nil {
+		t.Fatalf("find by email: %v", err)
+	}
+	if len(found.Roles) != 1 || found.Roles[0].Name != "user" {
+		t.Fatalf("expected only base role active, got %+v", found.Roles)
+	}
+
+	// Re-granting upserts the window; the extended grant becomes active.
+	if err := userRepo.GrantRole(&domain.UserRole{UserID: u.ID, RoleID: oncallRole.ID, ValidUntil: &future, Justification: "INC-1 extended"}); err != nil {
+		t.Fatalf("extend oncall grant: %v", err)
+	}
+	found, err = userRepo.FindByID(u.ID)
+	if err != nil {
+		t.Fatalf("find by id: %v", err)
+	}
+	if len(found.Roles) != 2 || found.Roles[1].Name != "oncall" || len(found.Roles[1].Permissions) != 1 {
+		t.Fatalf("expected base + oncall roles with permissions, got %+v", found.Roles)
+	}
+
+	// A shorter re-grant keeps the longer window it overlaps.
+	sooner := now.Add(10 * time.Minute)
+	if err := userRepo.GrantRole(&domain.UserRole{UserID: u.ID, RoleID: oncallRole.ID, ValidFrom: &now, ValidUntil: &sooner, Justification: "INC-1 extended"}); err != nil {
+		t.Fatalf("re-grant oncall role: %v", err)
+	}
+	// SetRoles keeps the windows of roles the user retains.
+	if err := userRepo.SetRoles(u.ID, []uint{baseRole.ID, oncallRole.ID, futureRole.ID}); err != nil {
+		t.Fatalf("set roles: %v", err)
+	}
+
+	grants, err := userRepo.ListRoleGrants(u.ID)
+	if err != nil {
+		t.Fatalf("list role grants: %v", err)
+	}
+	if len(grants) != 3 {
+		t.Fatalf("expected 3 grants, got %d", len(grants))
+	}
+	for _, grant := range grants {
+		switch grant.RoleID {
+		case oncallRole.ID:
+			if grant.ValidFrom != nil || grant.ValidUntil == nil || !grant.ValidUntil.Equal(future) {
+				t.Fatalf("expected the oncall window kept open until %v, got %+v", future, grant)
+			}
+		case futureRole.ID:
+			if grant.ValidFrom == nil || !grant.ValidFrom.Equal(future) {
+				t.Fatalf("expected set roles to keep the future start, got %+v", grant)
+			}
+		}
+	}
+
+	later := future.Add(time.Minute)
+	expired, err := userRepo.ListExpiredRoleGrants(later, 10)
+	if err != nil {
+		t.Fatalf("list expired grants: %v", err)
+	}
+	if len(expired) != 1 || expired[0].RoleID != oncallRole.ID || expired[0].Justification != "INC-1 extended" {
+		t.Fatalf("unexpected expired grants: %+v", expired)
+	}
+	deleted, err := userRepo.DeleteExpiredRoleGrant(u.ID, oncallRole.ID, now)
+	if err != nil || deleted {
+		t.Fatalf("expected unexpired grant to be kept, deleted=%v err=%v", deleted, err)
+	}
+	deleted, err = userRepo.DeleteExpiredRoleGrant(u.ID, oncallRole.ID, later)
+	if err != nil || !deleted {
+		t.Fatalf("expected expired grant deleted, deleted=%v err=%v", deleted, err)
+	}
+}
+
+func TestUserRepositoryGrantRoleMergesOnlyMeetingWindows(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	userRepo := NewUserRepository(db)
+	roleRepo := NewRoleRepository(db)
+
+	role := &domain.Role{Name: "oncall"}
+	if err := roleRepo.Create(role, nil); err != nil {
+		t.Fatalf("create role: %v", err)
+	}
+	u := &domain.User{Email: "oncall@example.com", Name: "Oncall", Status: "active"}
+	if err := userRepo.Create(u); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	day := func(n int) *time.Time {
+		at := time.Now().UTC().Truncate(time.Second).AddDate(0, 0, n)
+		return &at
+	}
+	window := func() domain.UserRole {
+		t.Helper()
+		grants, err := userRepo.ListRoleGrants(u.ID)
+		if err != nil || len(grants) != 1 {
+			t.Fatalf("expected one grant, got %+v err=%v", grants, err)
+		}
+		return grants[0]
+	}
+
+	if err := userRepo.GrantRole(&domain.UserRole{UserID: u.ID, RoleID: role.ID, ValidFrom: day(1), ValidUntil: day(10)}); err != nil {
+		t.Fatalf("grant first window: %v", err)
+	}
+
+	// A disjoint window would otherwise merge into one spanning the gap.
+	err := userRepo.GrantRole(&domain.UserRole{UserID: u.ID, RoleID: role.ID, ValidFrom: day(31), ValidUntil: day(40)})
+	if !errors.Is(err, ErrRoleGrantWindowConflict) {
+		t.Fatalf("expected ErrRoleGrantWindowConflict for a disjoint window, got %v", err)
+	}
+	if got := window(); !got.ValidFrom.Equal(*day(1)) || !got.ValidUntil.Equal(*day(10)) {
+		t.Fatalf("expected the first window kept, got %+v", got)
+	}
+
+	// A window that starts where the current one ends extends it.
+	if err := userRepo.GrantRole(&domain.UserRole{UserID: u.ID, RoleID: role.ID, ValidFrom: day(10), ValidUntil: day(20)}); err != nil {
+		t.Fatalf("grant touching window: %v", err)
+	}
+	if got := window(); !got.ValidFrom.Equal(*day(1)) || !got.ValidUntil.Equal(*day(20)) {
+		t.Fatalf("expected the windows joined, got %+v", got)
+	}
+
+	// An overlapping shorter window never shortens the current one.
+	if err := userRepo.GrantRole(&domain.UserRole{UserID: u.ID, RoleID: role.ID, ValidFrom: day(5), ValidUntil: day(6)}); err != nil {
+		t.Fatalf("grant overlapping window: %v", err)
+	}
+	if got := window(); !got.ValidFrom.Equal(*day(1)) || !got.ValidUntil.Equal(*day(20)) {
+		t.Fatalf("expected the window unchanged, got %+v", got)
+	}
+}
+
+func TestUserRepositorySoftDeleteRestoreAndPurge(t *testing.T) {
+	db := newRepositoryDBForTest(t)
+	if err := db.AutoMigrate(&domain.Product{}, &domain.ProductGrant{}, &domain.ProductTag{}, &domain.ProductPrice{}, &domain.ProductImage{}, &domain.FeatureFlagExposure{}, &domain.Cart{}, &domain.CartItem{}, &domain.RoleChangeRequest{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	userRepo := NewUserRepository(db)
+
+	alice := &domain.User{Email: "alice@example.com", Name: "Alice", Status: "active"}
+	bob := &domain.User{Email: "bob@example.com", Name: "Bob", Status: "active"}
+	for _, u := range []*domain.User{alice, bob} {
+		if err := userRepo.Create(u); err != nil {
+			t.Fatalf("create user %s: %v", u.Email, err)
+		}
+	}
+	session := &domain.Session{UserID: alice.ID, RefreshTokenHash: "hash-alice", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := db.Create(session).Error; err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	if err := db.Create(&domain.LocalCredential{UserID: alice.ID, PasswordHash: "x"}).Error; err != nil {
+		t.Fatalf("create credential: %v", err)
+	}
+	owned := &domain.Product{Name: "Owned", PriceMinor: 1, OwnerID: &alice.ID}
+	if err := db.Create(owned).Error; err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	cart := &domain.Cart{UserID: &alice.ID, Items: []domain.CartItem{{ProductID: owned.ID, Quantity: 1}}}
+	if err := db.Create(cart).Error; err != nil {
+		t.Fatalf("create cart: %v", err)
+	}
+	request := &domain.RoleChangeRequest{TargetUserID: alice.ID, RequestedBy: bob.ID, RoleIDs: []uint{1}, Status: domain.RoleChangeRequestStatusPending, ExpiresAt: time.Now().Add(time.Hour)}
+	if err := db.Create(request).Error; err != nil {
+		t.Fatalf("create role change request: %v", err)
+	}
+
+	if err := userRepo.DeleteByID(alice.ID); err != nil {
+		t.Fatalf("delete user: %v", err)
+	}
+	if err := userRepo.DeleteByID(alice.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected second delete to miss, got %v", err)
+	}
+	if _, err := userRepo.FindByEmail(alice.Email); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected deleted user hidden from lookups, got %v", err)
+	}
+	if _, err := NewLocalCredentialRepository(db).FindByEmail(alice.Email); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected deleted user's credential hidden from login, got %v", err)
+	}
+	var revoked domain.Session
+	if err := db.First(&revoked, session.ID).Error; err != nil || revoked.RevokedAt == nil {
+		t.Fatalf("expected session revoked on delete, got %+v err=%v", revoked, err)
+	}
+	if err := userRepo.Create(&domain.User{Email: alice.Email, Name: "Again", Status: "active"}); !errors.Is(err, ErrUserEmailReserved) {
+		t.Fatalf("expected email reserved by the deleted user, got %v", err)
+	}
+	trash, err := userRepo.ListDeleted(PageRequest{Page: 1, PageSize: 10})
+	if err != nil || trash.Total != 1 || trash.Items[0].ID != alice.ID {
+		t.Fatalf("expected alice in the trash, got %+v err=%v", trash, err)
+	}
+
+	if err := userRepo.Restore(alice.ID); err != nil {
+		t.Fatalf("restore user: %v", err)
+	}
+	if _, err := userRepo.FindByID(alice.ID); err != nil {
+		t.Fatalf("expected restored user visible, got %v", err)
+	}
+	if err := userRepo.Purge(alice.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected purge to require the user in the trash, got %v", err)
+	}
+
+	if err := userRepo.DeleteByID(alice.ID); err != nil {
+		t.Fatalf("delete user again: %v", err)
+	}
+	if err := userRepo.DeleteByID(bob.ID); err != nil {
+		t.Fatalf("delete bob: %v", err)
+	}
+	past := time.Now().UTC().Add(-48 * time.Hour)
+	if err := db.Unscoped().Model(&domain.User{}).Where("id = ?", alice.ID).Update("deleted_at", past).Error; err != nil {
+		t.Fatalf("backdate deletion: %v", err)
+	}
+	purged, err := userRepo.PurgeDeletedBefore(time.Now().UTC().Add(-24*time.Hour), 10)
+	if err != nil || purged != 1 {
+		t.Fatalf("expected only alice purged, got %d err=%v", purged, err)
+	}
+	var remaining int64
+	db.Model(&domain.LocalCredential{}).Where("user_id = ?", alice.ID).Count(&remaining)
+	if remaining != 0 {
+		t.Fatalf("expected credentials purged with the user, got %d", remaining)
+	}
+	for _, model := range []any{&domain.Cart{}, &domain.CartItem{}, &domain.RoleChangeRequest{}} {
+		var count int64
+		if err := db.Model(model).Count(&count).Error; err != nil || count != 0 {
+			t.Fatalf("expected %T rows purged with the user, got %d err=%v", model, count, err)
+		}
+	}
+	var product domain.Product
+	if err := db.First(&product, owned.ID).Error; err != nil || product.OwnerID != nil {
+		t.Fatalf("expected owned product kept without owner, got %+v err=%v", product, err)
+	}
+	if err := userRepo.Create(&domain.User{Email: alice.Email, Name: "Again", Status: "active"}); err != nil {
+		t.Fatalf("expected email free after purge, got %v", err)
+	}
+}
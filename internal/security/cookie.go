@@ -6,6 +6,10 @@ import (
 	"time"
 )
 
+// CartCookieName holds a guest's cart token. Its path covers both the cart
+// and auth routes so the guest cart can be merged at sign-in.
+const CartCookieName = "cart_token"
+
 type CookieManager struct {
 	Domain   string
 	Secure   bool
@@ -39,6 +43,14 @@ func (c *CookieManager) ClearTokenCookies(w http.ResponseWriter) {
 	clear("oauth_state", "/api/v1/auth/google", true)
 }
 
+func (c *CookieManager) SetCartCookie(w http.ResponseWriter, token string, ttl time.Duration) {
+	http.SetCookie(w, &http.Cookie{Name: CartCookieName, Value: token, Path: "/api/v1", HttpOnly: true, Secure: c.Secure, SameSite: c.SameSite, Domain: c.Domain, MaxAge: int(ttl.Seconds())})
+}
+
+func (c *CookieManager) ClearCartCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{Name: CartCookieName, Path: "/api/v1", Value: "", MaxAge: -1, HttpOnly: true, Secure: c.Secure, SameSite: c.SameSite, Domain: c.Domain})
+}
+
 func GetCookie(r *http.Request, name string) string {
 	cookie, err := r.Cookie(name)
 	if err != nil {
@@ -0,0 +1,33 @@
+package security
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCursorSignerRoundTripAndTamper(t *testing.T) {
+	type position struct {
+		Value string `json:"v"`
+		ID    uint   `json:"id"`
+	}
+	signer := NewCursorSigner("cursor-secret-123456")
+	token, err := signer.Encode(position{Value: "2026-03-01T00:00:00Z", ID: 7})
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	var got position
+	if err := signer.Decode(token, &got); err != nil || got.ID != 7 || got.Value != "2026-03-01T00:00:00Z" {
+		t.Fatalf("unexpected decode %+v err=%v", got, err)
+	}
+	if err := NewCursorSigner("other-secret-123456").Decode(token, &got); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor for a different secret, got %v", err)
+	}
+	tampered := "x" + token[1:]
+	if err := signer.Decode(tampered, &got); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor for a tampered token, got %v", err)
+	}
+	if _, ok := VerifySignedState(token, "cursor-secret-123456"); ok {
+		t.Fatal("cursor must not verify as signed state under the raw secret")
+	}
+}
@@ -134,6 +134,9 @@ func (s *AuthService) RegisterLocal(email, name, password, ua, ip string) (*Logi
 
 	user := &domain.User{Email: email, Name: name, Status: "active"}
 	if err := s.userSvc.userRepo.Create(user); err != nil {
+		if errors.Is(err, repository.ErrUserEmailReserved) {
+			return nil, fmt.Errorf("email already registered")
+		}
 		return nil, err
 	}
 	userRole, err := s.roleRepo.FindByName("user")
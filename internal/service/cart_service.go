@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/security"
+)
+
+const (
+	maxCartItems        = 100
+	maxCartItemQuantity = 1000
+)
+
+var (
+	ErrCartInvalidQuantity = fmt.Errorf("quantity must be between 0 and %d", maxCartItemQuantity)
+	ErrCartTooManyItems    = fmt.Errorf("a cart holds at most %d different products", maxCartItems)
+)
+
+// CartOwner identifies a cart: the signed-in user's, or otherwise a guest's
+// by the token issued with the guest's first cart change.
+type CartOwner struct {
+	UserID     uint
+	GuestToken string
+}
+
+type DefaultCartService struct {
+	repo     repository.CartRepository
+	products repository.ProductRepository
+}
+
+func NewCartService(repo repository.CartRepository, products repository.ProductRepository) *DefaultCartService {
+	return &DefaultCartService{repo: repo, products: products}
+}
+
+// GetCart returns the owner's cart, or an empty one if there is none yet.
+func (s *DefaultCartService) GetCart(ctx context.Context, owner CartOwner) (*domain.Cart, error) {
+	cart, err := s.find(owner)
+	if errors.Is(err, repository.ErrCartNotFound) {
+		return &domain.Cart{Items: []domain.CartItem{}}, nil
+	}
+	return cart, err
+}
+
+// SetItem sets the quantity of a product in the owner's cart; 0 removes it.
+// A guest without a valid token gets a new cart whose token is returned in
+// the cart's GuestToken field.
+func (s *DefaultCartService) SetItem(ctx context.Context, owner CartOwner, productID uint, quantity int64) (*domain.Cart, error) {
+	if quantity < 0 || quantity > maxCartItemQuantity {
+		return nil, ErrCartInvalidQuantity
+	}
+	cart, err := s.find(owner)
+	if errors.Is(err, repository.ErrCartNotFound) {
+		if quantity == 0 {
+			return &domain.Cart{Items: []domain.CartItem{}}, nil
+		}
+		cart, err = s.create(owner)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if quantity > 0 {
+		if err := s.checkProduct(ctx, productID); err != nil {
+			return nil, err
+		}
+		if len(cart.Items) >= maxCartItems && !cartHasProduct(cart, productID) {
+			return nil, ErrCartTooManyItems
+		}
+	}
+	if err := s.repo.SetItem(cart.ID, productID, quantity); err != nil {
+		return nil, err
+	}
+	updated, err := s.find(CartOwner{UserID: owner.UserID, GuestToken: cart.GuestToken})
+	if err != nil {
+		return nil, err
+	}
+	updated.GuestToken = cart.GuestToken
+	if updated.GuestToken == owner.GuestToken {
+		updated.GuestToken = ""
+	}
+	return updated, nil
+}
+
+func (s *DefaultCartService) Clear(ctx context.Context, owner CartOwner) error {
+	cart, err := s.find(owner)
+	if errors.Is(err, repository.ErrCartNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return s.repo.Clear(cart.ID)
+}
+
+// MergeGuestCart moves the guest cart identified by guestToken into the
+// user's cart. Unknown or already merged tokens are ignored. The merged cart
+// stays within the same item and quantity limits as SetItem.
+func (s *DefaultCartService) MergeGuestCart(ctx context.Context, guestToken string, userID uint) error {
+	if guestToken == "" || userID == 0 {
+		return nil
+	}
+	guest, err := s.repo.FindByGuestTokenHash(hashGuestCartToken(guestToken))
+	if errors.Is(err, repository.ErrCartNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.repo.MergeGuest(guest.ID, userID, maxCartItems, maxCartItemQuantity); err != nil && !errors.Is(err, repository.ErrCartNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (s *DefaultCartService) find(owner CartOwner) (*domain.Cart, error) {
+	switch {
+	case owner.UserID != 0:
+		return s.repo.FindByUser(owner.UserID)
+	case owner.GuestToken != "":
+		cart, err := s.repo.FindByGuestTokenHash(hashGuestCartToken(owner.GuestToken))
+		if err != nil {
+			return nil, err
+		}
+		cart.GuestToken = owner.GuestToken
+		return cart, nil
+	default:
+		return nil, repository.ErrCartNotFound
+	}
+}
+
+func (s *DefaultCartService) create(owner CartOwner) (*domain.Cart, error) {
+	if owner.UserID != 0 {
+		userID := owner.UserID
+		if err := s.repo.Create(&domain.Cart{UserID: &userID}); err != nil {
+			// A concurrent request created the user's cart first.
+			if cart, findErr := s.repo.FindByUser(userID); findErr == nil {
+				return cart, nil
+			}
+			return nil, err
+		}
+		return s.repo.FindByUser(userID)
+	}
+	token, err := security.NewRandomString(32)
+	if err != nil {
+		return nil, err
+	}
+	hash := hashGuestCartToken(token)
+	cart := &domain.Cart{GuestTokenHash: &hash}
+	if err := s.repo.Create(cart); err != nil {
+		return nil, err
+	}
+	cart.GuestToken = token
+	return cart, nil
+}
+
+// checkProduct confirms the product exists in the caller's tenant.
+func (s *DefaultCartService) checkProduct(ctx context.Context, productID uint) error {
+	products := s.products
+	if tenant, ok := TenantFromContext(ctx); ok {
+		products = products.ForOrganization(tenant.OrganizationID)
+	}
+	_, err := products.FindByID(productID)
+	return err
+}
+
+func cartHasProduct(cart *domain.Cart, productID uint) bool {
+	for _, item := range cart.Items {
+		if item.ProductID == productID {
+			return true
+		}
+	}
+	return false
+}
+
+func hashGuestCartToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
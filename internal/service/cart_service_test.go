@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	repogomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCartServiceGuestCartIssuesToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	carts := repogomock.NewMockCartRepository(ctrl)
+	products := repogomock.NewMockProductRepository(ctrl)
+	svc := NewCartService(carts, products)
+	ctx := context.Background()
+
+	var storedHash string
+	carts.EXPECT().Create(gomock.AssignableToTypeOf(&domain.Cart{})).DoAndReturn(func(c *domain.Cart) error {
+		if c.UserID != nil || c.GuestTokenHash == nil {
+			t.Fatalf("expected a guest cart, got %+v", c)
+		}
+		storedHash = *c.GuestTokenHash
+		c.ID = 5
+		return nil
+	})
+	products.EXPECT().FindByID(uint(3)).Return(&domain.Product{ID: 3}, nil)
+	carts.EXPECT().SetItem(uint(5), uint(3), int64(2)).Return(nil)
+	carts.EXPECT().FindByGuestTokenHash(gomock.Any()).DoAndReturn(func(hash string) (*domain.Cart, error) {
+		if hash != storedHash {
+			t.Fatalf("looked up %q, stored %q", hash, storedHash)
+		}
+		return &domain.Cart{ID: 5, Items: []domain.CartItem{{CartID: 5, ProductID: 3, Quantity: 2}}}, nil
+	})
+
+	cart, err := svc.SetItem(ctx, CartOwner{}, 3, 2)
+	if err != nil {
+		t.Fatalf("set item: %v", err)
+	}
+	if cart.GuestToken == "" || hashGuestCartToken(cart.GuestToken) != storedHash || len(cart.Items) != 1 {
+		t.Fatalf("expected the new guest token to be returned once, got %+v", cart)
+	}
+}
+
+func TestCartServiceValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	carts := repogomock.NewMockCartRepository(ctrl)
+	products := repogomock.NewMockProductRepository(ctrl)
+	svc := NewCartService(carts, products)
+	ctx := context.Background()
+	owner := CartOwner{UserID: 7}
+
+	if _, err := svc.SetItem(ctx, owner, 1, maxCartItemQuantity+1); !errors.Is(err, ErrCartInvalidQuantity) {
+		t.Fatalf("expected ErrCartInvalidQuantity, got %v", err)
+	}
+
+	full := &domain.Cart{ID: 1}
+	for i := 0; i < maxCartItems; i++ {
+		full.Items = append(full.Items, domain.CartItem{ProductID: uint(i + 1), Quantity: 1})
+	}
+	carts.EXPECT().FindByUser(uint(7)).Return(full, nil).Times(2)
+	products.EXPECT().FindByID(gomock.Any()).Return(&domain.Product{}, nil).Times(1)
+	if _, err := svc.SetItem(ctx, owner, maxCartItems+1, 1); !errors.Is(err, ErrCartTooManyItems) {
+		t.Fatalf("expected ErrCartTooManyItems, got %v", err)
+	}
+
+	products.EXPECT().FindByID(uint(999)).Return(nil, repository.ErrProductNotFound)
+	if _, err := svc.SetItem(ctx, owner, 999, 1); !errors.Is(err, repository.ErrProductNotFound) {
+		t.Fatalf("expected ErrProductNotFound, got %v", err)
+	}
+}
+
+func TestCartServiceMergeGuestCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	carts := repogomock.NewMockCartRepository(ctrl)
+	svc := NewCartService(carts, repogomock.NewMockProductRepository(ctrl))
+	ctx := context.Background()
+
+	carts.EXPECT().FindByGuestTokenHash(hashGuestCartToken("guest")).Return(&domain.Cart{ID: 4}, nil)
+	carts.EXPECT().MergeGuest(uint(4), uint(9), maxCartItems, int64(maxCartItemQuantity)).Return(nil)
+	if err := svc.MergeGuestCart(ctx, "guest", 9); err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+
+	carts.EXPECT().FindByGuestTokenHash(hashGuestCartToken("stale")).Return(nil, repository.ErrCartNotFound)
+	if err := svc.MergeGuestCart(ctx, "stale", 9); err != nil {
+		t.Fatalf("expected unknown token to be ignored, got %v", err)
+	}
+	if err := svc.MergeGuestCart(ctx, "", 9); err != nil {
+		t.Fatalf("expected empty token to be ignored, got %v", err)
+	}
+}
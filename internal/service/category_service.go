@@ -0,0 +1,344 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+)
+
+var (
+	ErrCategoryInvalidName       = errors.New("name must be between 2 and 100 characters")
+	ErrCategoryInvalidSlug       = errors.New("slug must be 2-100 lower-case letters, digits or single hyphens")
+	ErrCategoryInvalidDesc       = errors.New("description must be at most 500 characters")
+	ErrCategoryInvalidParent     = errors.New("parent category does not exist")
+	ErrCategoryCycle             = errors.New("category cannot be moved under itself or its descendants")
+	ErrCategoryTooDeep           = errors.New("category tree is too deep")
+	ErrCategoryInvalidAttributes = errors.New("invalid attribute schema")
+	ErrProductInvalidAttributes  = errors.New("invalid product attributes")
+)
+
+const (
+	maxCategoryDepth              = 8
+	maxCategoryAttributes         = 50
+	maxCategoryAttributeOptions   = 100
+	maxProductAttributeStringSize = 500
+)
+
+var (
+	categorySlugPattern         = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	categoryAttributeKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+)
+
+type CreateCategoryInput struct {
+	Name        string
+	Slug        string
+	Description string
+	ParentID    *uint
+	Attributes  []domain.CategoryAttribute
+}
+
+// UpdateCategoryInput changes only the fields that are set. A ParentID of 0
+// moves the category to the root.
+type UpdateCategoryInput struct {
+	Name        *string
+	Slug        *string
+	Description *string
+	ParentID    *uint
+	Attributes  *[]domain.CategoryAttribute
+}
+
+type DefaultCategoryService struct {
+	repo repository.CategoryRepository
+}
+
+func NewCategoryService(repo repository.CategoryRepository) *DefaultCategoryService {
+	return &DefaultCategoryService{repo: repo}
+}
+
+func (s *DefaultCategoryService) CreateCategory(ctx context.Context, input CreateCategoryInput) (*domain.Category, error) {
+	category := &domain.Category{
+		Name:        input.Name,
+		Slug:        input.Slug,
+		Description: input.Description,
+		ParentID:    input.ParentID,
+		Attributes:  input.Attributes,
+	}
+	if category.ParentID != nil && *category.ParentID == 0 {
+		category.ParentID = nil
+	}
+	if err := s.validate(category); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (s *DefaultCategoryService) ListCategories(ctx context.Context) ([]domain.Category, error) {
+	return s.repo.List()
+}
+
+func (s *DefaultCategoryService) GetCategory(ctx context.Context, id uint) (*domain.Category, error) {
+	return s.repo.FindByID(id)
+}
+
+func (s *DefaultCategoryService) UpdateCategory(ctx context.Context, id uint, input UpdateCategoryInput) (*domain.Category, error) {
+	category, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if input.Name != nil {
+		category.Name = *input.Name
+	}
+	if input.Slug != nil {
+		category.Slug = *input.Slug
+	}
+	if input.Description != nil {
+		category.Description = *input.Description
+	}
+	if input.ParentID != nil {
+		category.ParentID = nil
+		if *input.ParentID != 0 {
+			parentID := *input.ParentID
+			category.ParentID = &parentID
+		}
+	}
+	if input.Attributes != nil {
+		category.Attributes = *input.Attributes
+	}
+	if err := s.validate(category); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (s *DefaultCategoryService) DeleteCategory(ctx context.Context, id uint) error {
+	return s.repo.DeleteByID(id)
+}
+
+// validate normalizes the category fields and checks them against the rest
+// of the tree: the parent must exist, the category cannot move below itself,
+// the tree stays within maxCategoryDepth levels and no attribute key is
+// defined twice along any root-to-leaf path.
+func (s *DefaultCategoryService) validate(category *domain.Category) error {
+	category.Name = strings.TrimSpace(category.Name)
+	category.Slug = strings.ToLower(strings.TrimSpace(category.Slug))
+	category.Description = strings.TrimSpace(category.Description)
+	if len(category.Name) < 2 || len(category.Name) > 100 {
+		return ErrCategoryInvalidName
+	}
+	if len(category.Slug) < 2 || len(category.Slug) > 100 || !categorySlugPattern.MatchString(category.Slug) {
+		return ErrCategoryInvalidSlug
+	}
+	if len(category.Description) > 500 {
+		return ErrCategoryInvalidDesc
+	}
+
+	all, err := s.repo.List()
+	if err != nil {
+		return err
+	}
+	byID := make(map[uint]domain.Category, len(all))
+	children := map[uint][]uint{}
+	for _, c := range all {
+		byID[c.ID] = c
+		if c.ParentID != nil {
+			children[*c.ParentID] = append(children[*c.ParentID], c.ID)
+		}
+	}
+
+	var inherited []domain.CategoryAttribute
+	depth := 1
+	for next := category.ParentID; next != nil; {
+		if category.ID != 0 && *next == category.ID {
+			return ErrCategoryCycle
+		}
+		parent, ok := byID[*next]
+		if !ok {
+			return ErrCategoryInvalidParent
+		}
+		if depth++; depth > maxCategoryDepth {
+			return ErrCategoryTooDeep
+		}
+		inherited = append(inherited, parent.Attributes...)
+		next = parent.ParentID
+	}
+
+	// Descendants move with the category and inherit its attributes.
+	descendantKeys := map[string]struct{}{}
+	var walk func(id uint, level int) error
+	walk = func(id uint, level int) error {
+		if level > maxCategoryDepth {
+			return ErrCategoryTooDeep
+		}
+		for _, childID := range children[id] {
+			for _, attr := range byID[childID].Attributes {
+				descendantKeys[attr.Key] = struct{}{}
+			}
+			if err := walk(childID, level+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if category.ID != 0 {
+		if err := walk(category.ID, depth); err != nil {
+			return err
+		}
+	}
+
+	attributes, err := normalizeCategoryAttributes(category.Attributes, inherited)
+	if err != nil {
+		return err
+	}
+	for _, attr := range append(attributes, inherited...) {
+		if _, ok := descendantKeys[attr.Key]; ok {
+			return fmt.Errorf("%w: key %q is already defined by a subcategory", ErrCategoryInvalidAttributes, attr.Key)
+		}
+	}
+	category.Attributes = attributes
+	return nil
+}
+
+// categoryChain returns the category with the given id followed by its
+// ancestors, nearest first.
+func categoryChain(repo repository.CategoryRepository, id uint) ([]domain.Category, error) {
+	var chain []domain.Category
+	for next := &id; next != nil; {
+		if len(chain) >= maxCategoryDepth {
+			return nil, ErrCategoryTooDeep
+		}
+		category, err := repo.FindByID(*next)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, *category)
+		next = category.ParentID
+	}
+	return chain, nil
+}
+
+func normalizeCategoryAttributes(attributes, inherited []domain.CategoryAttribute) ([]domain.CategoryAttribute, error) {
+	if len(attributes) > maxCategoryAttributes {
+		return nil, fmt.Errorf("%w: at most %d attributes are allowed", ErrCategoryInvalidAttributes, maxCategoryAttributes)
+	}
+	keys := make(map[string]struct{}, len(attributes)+len(inherited))
+	for _, attr := range inherited {
+		keys[attr.Key] = struct{}{}
+	}
+	out := make([]domain.CategoryAttribute, 0, len(attributes))
+	for _, attr := range attributes {
+		attr.Key = strings.TrimSpace(attr.Key)
+		attr.Type = strings.ToLower(strings.TrimSpace(attr.Type))
+		if !categoryAttributeKeyPattern.MatchString(attr.Key) {
+			return nil, fmt.Errorf("%w: key %q must be lower-case snake_case", ErrCategoryInvalidAttributes, attr.Key)
+		}
+		if _, ok := keys[attr.Key]; ok {
+			return nil, fmt.Errorf("%w: key %q is already defined", ErrCategoryInvalidAttributes, attr.Key)
+		}
+		keys[attr.Key] = struct{}{}
+		switch attr.Type {
+		case domain.CategoryAttributeString, domain.CategoryAttributeNumber, domain.CategoryAttributeBoolean:
+			if len(attr.Options) > 0 {
+				return nil, fmt.Errorf("%w: options are only allowed on enum attributes", ErrCategoryInvalidAttributes)
+			}
+			attr.Options = nil
+		case domain.CategoryAttributeEnum:
+			options, err := normalizeEnumOptions(attr.Key, attr.Options)
+			if err != nil {
+				return nil, err
+			}
+			attr.Options = options
+		default:
+			return nil, fmt.Errorf("%w: type of %q must be string, number, boolean or enum", ErrCategoryInvalidAttributes, attr.Key)
+		}
+		out = append(out, attr)
+	}
+	return out, nil
+}
+
+func normalizeEnumOptions(key string, options []string) ([]string, error) {
+	if len(options) == 0 || len(options) > maxCategoryAttributeOptions {
+		return nil, fmt.Errorf("%w: enum %q needs between 1 and %d options", ErrCategoryInvalidAttributes, key, maxCategoryAttributeOptions)
+	}
+	seen := make(map[string]struct{}, len(options))
+	out := make([]string, 0, len(options))
+	for _, option := range options {
+		option = strings.TrimSpace(option)
+		if option == "" || len(option) > 100 {
+			return nil, fmt.Errorf("%w: enum %q has an empty or too long option", ErrCategoryInvalidAttributes, key)
+		}
+		if _, ok := seen[option]; ok {
+			return nil, fmt.Errorf("%w: enum %q repeats option %q", ErrCategoryInvalidAttributes, key, option)
+		}
+		seen[option] = struct{}{}
+		out = append(out, option)
+	}
+	return out, nil
+}
+
+// validateProductAttributes checks attrs against the merged schema of
+// chain, the product's category and its ancestors. Unknown keys, missing
+// required keys and values of the wrong type are rejected.
+func validateProductAttributes(chain []domain.Category, attrs domain.ProductAttributes) error {
+	schema := map[string]domain.CategoryAttribute{}
+	for _, category := range chain {
+		for _, attr := range category.Attributes {
+			schema[attr.Key] = attr
+		}
+	}
+	for key, value := range attrs {
+		attr, ok := schema[key]
+		if !ok {
+			return fmt.Errorf("%w: unknown attribute %q", ErrProductInvalidAttributes, key)
+		}
+		if !attributeValueMatches(attr, value) {
+			return fmt.Errorf("%w: attribute %q must be a valid %s", ErrProductInvalidAttributes, key, attr.Type)
+		}
+	}
+	for key, attr := range schema {
+		if _, ok := attrs[key]; attr.Required && !ok {
+			return fmt.Errorf("%w: attribute %q is required", ErrProductInvalidAttributes, key)
+		}
+	}
+	return nil
+}
+
+func attributeValueMatches(attr domain.CategoryAttribute, value any) bool {
+	switch attr.Type {
+	case domain.CategoryAttributeString:
+		v, ok := value.(string)
+		return ok && len(v) <= maxProductAttributeStringSize
+	case domain.CategoryAttributeNumber:
+		switch value.(type) {
+		case float64, float32, int, int64, int32, uint, uint64, uint32:
+			return true
+		}
+		return false
+	case domain.CategoryAttributeBoolean:
+		_, ok := value.(bool)
+		return ok
+	case domain.CategoryAttributeEnum:
+		v, ok := value.(string)
+		if !ok {
+			return false
+		}
+		for _, option := range attr.Options {
+			if option == v {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
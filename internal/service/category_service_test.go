@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	repogomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func categoryTreeForTest() []domain.Category {
+	root, mid := uint(1), uint(2)
+	return []domain.Category{
+		{ID: 1, Name: "Electronics", Slug: "electronics", Attributes: []domain.CategoryAttribute{
+			{Key: "brand", Type: domain.CategoryAttributeString, Required: true},
+		}},
+		{ID: 2, ParentID: &root, Name: "Computers", Slug: "computers", Attributes: []domain.CategoryAttribute{
+			{Key: "ram_gb", Type: domain.CategoryAttributeNumber},
+		}},
+		{ID: 3, ParentID: &mid, Name: "Laptops", Slug: "laptops", Attributes: []domain.CategoryAttribute{
+			{Key: "touchscreen", Type: domain.CategoryAttributeBoolean},
+			{Key: "condition", Type: domain.CategoryAttributeEnum, Options: []string{"new", "refurbished"}},
+		}},
+	}
+}
+
+func expectCategoryTree(repo *repogomock.MockCategoryRepository, tree []domain.Category) {
+	repo.EXPECT().List().Return(tree, nil).AnyTimes()
+	repo.EXPECT().FindByID(gomock.Any()).DoAndReturn(func(id uint) (*domain.Category, error) {
+		for _, c := range tree {
+			if c.ID == id {
+				cp := c
+				return &cp, nil
+			}
+		}
+		return nil, repository.ErrCategoryNotFound
+	}).AnyTimes()
+}
+
+func TestCategoryServiceValidatesTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockCategoryRepository(ctrl)
+	svc := NewCategoryService(repo)
+	expectCategoryTree(repo, categoryTreeForTest())
+
+	parent := uint(3)
+	cases := []struct {
+		name  string
+		input CreateCategoryInput
+		want  error
+	}{
+		{"bad slug", CreateCategoryInput{Name: "Phones", Slug: "Phones & Tablets"}, ErrCategoryInvalidSlug},
+		{"missing parent", CreateCategoryInput{Name: "Phones", Slug: "phones", ParentID: uintPtr(99)}, ErrCategoryInvalidParent},
+		{"inherited key", CreateCategoryInput{Name: "Gaming", Slug: "gaming", ParentID: &parent, Attributes: []domain.CategoryAttribute{{Key: "brand", Type: "string"}}}, ErrCategoryInvalidAttributes},
+		{"unknown type", CreateCategoryInput{Name: "Gaming", Slug: "gaming", Attributes: []domain.CategoryAttribute{{Key: "fps", Type: "float"}}}, ErrCategoryInvalidAttributes},
+		{"enum without options", CreateCategoryInput{Name: "Gaming", Slug: "gaming", Attributes: []domain.CategoryAttribute{{Key: "tier", Type: "enum"}}}, ErrCategoryInvalidAttributes},
+	}
+	for _, tc := range cases {
+		if _, err := svc.CreateCategory(context.Background(), tc.input); !errors.Is(err, tc.want) {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+
+	repo.EXPECT().Create(gomock.Any()).Return(nil)
+	created, err := svc.CreateCategory(context.Background(), CreateCategoryInput{
+		Name: " Gaming Laptops ", Slug: "Gaming-Laptops", ParentID: &parent,
+		Attributes: []domain.CategoryAttribute{{Key: "gpu", Type: " ENUM ", Options: []string{" rtx ", "radeon"}}},
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if created.Name != "Gaming Laptops" || created.Slug != "gaming-laptops" || created.Attributes[0].Type != "enum" || created.Attributes[0].Options[0] != "rtx" {
+		t.Fatalf("expected normalized category, got %+v", created)
+	}
+
+	moveUnderChild := uint(3)
+	if _, err := svc.UpdateCategory(context.Background(), 1, UpdateCategoryInput{ParentID: &moveUnderChild}); !errors.Is(err, ErrCategoryCycle) {
+		t.Fatalf("expected ErrCategoryCycle, got %v", err)
+	}
+	clash := []domain.CategoryAttribute{{Key: "ram_gb", Type: "number"}}
+	if _, err := svc.UpdateCategory(context.Background(), 1, UpdateCategoryInput{Attributes: &clash}); !errors.Is(err, ErrCategoryInvalidAttributes) {
+		t.Fatalf("expected subcategory key clash, got %v", err)
+	}
+
+	toRoot := uint(0)
+	repo.EXPECT().Update(gomock.Any()).Return(nil)
+	moved, err := svc.UpdateCategory(context.Background(), 3, UpdateCategoryInput{ParentID: &toRoot})
+	if err != nil {
+		t.Fatalf("move to root: %v", err)
+	}
+	if moved.ParentID != nil {
+		t.Fatalf("expected root category, got parent %v", *moved.ParentID)
+	}
+}
+
+func TestProductServiceValidatesCategoryAttributesAndTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockProductRepository(ctrl)
+	categories := repogomock.NewMockCategoryRepository(ctrl)
+	svc := NewProductService(repo, categories, nil)
+	ctx := WithPrincipal(context.Background(), SystemPrincipal())
+	expectCategoryTree(categories, categoryTreeForTest())
+
+	laptops := uint(3)
+	cases := []struct {
+		name  string
+		input CreateProductInput
+		want  error
+	}{
+		{"unknown category", CreateProductInput{Name: "Laptop", Price: PriceInput{Amount: "1"}, CategoryID: uintPtr(99)}, ErrProductInvalidCategory},
+		{"attributes without category", CreateProductInput{Name: "Laptop", Price: PriceInput{Amount: "1"}, Attributes: map[string]any{"brand": "Acme"}}, ErrProductInvalidAttributes},
+		{"missing required", CreateProductInput{Name: "Laptop", Price: PriceInput{Amount: "1"}, CategoryID: &laptops}, ErrProductInvalidAttributes},
+		{"unknown key", CreateProductInput{Name: "Laptop", Price: PriceInput{Amount: "1"}, CategoryID: &laptops, Attributes: map[string]any{"brand": "Acme", "color": "red"}}, ErrProductInvalidAttributes},
+		{"wrong type", CreateProductInput{Name: "Laptop", Price: PriceInput{Amount: "1"}, CategoryID: &laptops, Attributes: map[string]any{"brand": "Acme", "ram_gb": "16"}}, ErrProductInvalidAttributes},
+		{"bad enum", CreateProductInput{Name: "Laptop", Price: PriceInput{Amount: "1"}, CategoryID: &laptops, Attributes: map[string]any{"brand": "Acme", "condition": "used"}}, ErrProductInvalidAttributes},
+		{"empty tag", CreateProductInput{Name: "Laptop", Price: PriceInput{Amount: "1"}, Tags: []string{"ok", " "}}, ErrProductInvalidTags},
+	}
+	for _, tc := range cases {
+		if _, err := svc.Create(ctx, tc.input); !errors.Is(err, tc.want) {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+
+	repo.EXPECT().Create(gomock.AssignableToTypeOf(&domain.Product{})).Return(nil)
+	created, err := svc.Create(ctx, CreateProductInput{
+		Name: "Laptop", Price: PriceInput{Amount: "1"}, CategoryID: &laptops, Tags: []string{"Sale", "new", "sale"},
+		Attributes: map[string]any{"brand": "Acme", "ram_gb": 16.0, "touchscreen": true, "condition": "refurbished"},
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if len(created.Tags) != 2 || created.Tags[0] != "new" || created.Tags[1] != "sale" {
+		t.Fatalf("expected normalized tags, got %v", created.Tags)
+	}
+
+	// Moving the product to a category without a brand attribute leaves its
+	// stored attributes invalid, so the move is rejected.
+	current := &domain.Product{ID: 7, CategoryID: &laptops, Attributes: domain.ProductAttributes{"brand": "Acme", "ram_gb": 16.0}}
+	repo.EXPECT().FindByID(uint(7)).Return(current, nil).AnyTimes()
+	root := uint(1)
+	if _, err := svc.Update(ctx, 7, 0, UpdateProductInput{CategoryID: &root}); !errors.Is(err, ErrProductInvalidAttributes) {
+		t.Fatalf("expected stored attributes to be checked against the new category, got %v", err)
+	}
+
+	repo.EXPECT().Update(uint(7), uint(0), gomock.Any()).DoAndReturn(func(_, _ uint, updates map[string]any) error {
+		if updates["category_id"] != (*uint)(nil) {
+			t.Fatalf("expected category to be cleared, got %v", updates["category_id"])
+		}
+		if attrs, ok := updates["attributes"].(domain.ProductAttributes); !ok || len(attrs) != 0 {
+			t.Fatalf("expected attributes to be cleared, got %v", updates["attributes"])
+		}
+		return nil
+	})
+	noCategory, empty := uint(0), map[string]any{}
+	if _, err := svc.Update(ctx, 7, 0, UpdateProductInput{CategoryID: &noCategory, Attributes: &empty}); err != nil {
+		t.Fatalf("clear category: %v", err)
+	}
+}
+
+func uintPtr(v uint) *uint { return &v }
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	FeatureFlagChangeFlagCreated = "flag.created"
+	FeatureFlagChangeFlagUpdated = "flag.updated"
+	FeatureFlagChangeFlagDeleted = "flag.deleted"
+	FeatureFlagChangeRuleCreated = "rule.created"
+	FeatureFlagChangeRuleUpdated = "rule.updated"
+	FeatureFlagChangeRuleDeleted = "rule.deleted"
+
+	FeatureFlagChangeFlagRolledBack = "flag.rolled_back"
+
+	defaultFeatureFlagChangeLogSize    = 256
+	defaultFeatureFlagChangeBufferSize = 32
+)
+
+// FeatureFlagChangeEvent describes a committed flag definition change. IDs are
+// assigned by the publishing replica and are opaque to subscribers.
+type FeatureFlagChangeEvent struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	FlagID     uint      `json:"flag_id"`
+	FlagKey    string    `json:"flag_key,omitempty"`
+	RuleID     uint      `json:"rule_id,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// FeatureFlagChangeSubscription is a live feed of change events. Replay holds
+// events published after the requested Last-Event-ID; Resumed is false when
+// that ID is no longer in the replay log and the subscriber must resync.
+// Events is closed when the subscriber falls too far behind.
+type FeatureFlagChangeSubscription struct {
+	Events  <-chan FeatureFlagChangeEvent
+	Replay  []FeatureFlagChangeEvent
+	Resumed bool
+	Close   func()
+}
+
+type FeatureFlagChangeBroker interface {
+	Publish(ctx context.Context, event FeatureFlagChangeEvent) error
+	Subscribe(ctx context.Context, lastEventID string) (*FeatureFlagChangeSubscription, error)
+}
+
+type featureFlagChangeSubscriber struct {
+	ch chan FeatureFlagChangeEvent
+}
+
+// InProcessFeatureFlagChangeBroker fans change events out to subscribers in
+// this process and keeps a bounded log of recent events for resume.
+type InProcessFeatureFlagChangeBroker struct {
+	mu          sync.Mutex
+	log         []FeatureFlagChangeEvent
+	logSize     int
+	subscribers map[*featureFlagChangeSubscriber]struct{}
+}
+
+func NewInProcessFeatureFlagChangeBroker() *InProcessFeatureFlagChangeBroker {
+	return &InProcessFeatureFlagChangeBroker{
+		logSize:     defaultFeatureFlagChangeLogSize,
+		subscribers: map[*featureFlagChangeSubscriber]struct{}{},
+	}
+}
+
+func (b *InProcessFeatureFlagChangeBroker) Publish(_ context.Context, event FeatureFlagChangeEvent) error {
+	b.deliver(prepareFeatureFlagChangeEvent(event))
+	return nil
+}
+
+func (b *InProcessFeatureFlagChangeBroker) Subscribe(_ context.Context, lastEventID string) (*FeatureFlagChangeSubscription, error) {
+	sub := &featureFlagChangeSubscriber{ch: make(chan FeatureFlagChangeEvent, defaultFeatureFlagChangeBufferSize)}
+
+	b.mu.Lock()
+	replay, resumed := b.since(lastEventID)
+	b.subscribers[sub] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return &FeatureFlagChangeSubscription{
+		Events:  sub.ch,
+		Replay:  replay,
+		Resumed: resumed,
+		Close: func() {
+			once.Do(func() { b.unsubscribe(sub) })
+		},
+	}, nil
+}
+
+// deliver records the event and hands it to every subscriber. A subscriber
+// whose buffer is full is dropped rather than blocking the publisher; its
+// client reconnects and resumes from the replay log.
+func (b *InProcessFeatureFlagChangeBroker) deliver(event FeatureFlagChangeEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.log = append(b.log, event)
+	if over := len(b.log) - b.logSize; over > 0 {
+		b.log = append([]FeatureFlagChangeEvent(nil), b.log[over:]...)
+	}
+	for sub := range b.subscribers {
+		select {
+		case sub.ch <- event:
+		default:
+			delete(b.subscribers, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+func (b *InProcessFeatureFlagChangeBroker) unsubscribe(sub *featureFlagChangeSubscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+// since returns the logged events after lastEventID. Callers must hold b.mu.
+func (b *InProcessFeatureFlagChangeBroker) since(lastEventID string) ([]FeatureFlagChangeEvent, bool) {
+	if lastEventID == "" {
+		return nil, false
+	}
+	for i := len(b.log) - 1; i >= 0; i-- {
+		if b.log[i].ID == lastEventID {
+			return append([]FeatureFlagChangeEvent(nil), b.log[i+1:]...), true
+		}
+	}
+	return nil, false
+}
+
+func prepareFeatureFlagChangeEvent(event FeatureFlagChangeEvent) FeatureFlagChangeEvent {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+	if event.ID == "" {
+		event.ID = newFeatureFlagChangeEventID(event.OccurredAt)
+	}
+	return event
+}
+
+func newFeatureFlagChangeEventID(at time.Time) string {
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return strconv.FormatInt(at.UnixMilli(), 10) + "-" + hex.EncodeToString(suffix)
+}
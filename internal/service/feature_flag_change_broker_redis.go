@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisFeatureFlagChangeBroker publishes change events on a Redis pub/sub
+// channel so every API replica can push them to its own SSE subscribers.
+// Subscribers are served by an in-process broker fed by Run.
+type RedisFeatureFlagChangeBroker struct {
+	client  redis.UniversalClient
+	channel string
+	local   *InProcessFeatureFlagChangeBroker
+}
+
+func NewRedisFeatureFlagChangeBroker(client redis.UniversalClient, channel string) *RedisFeatureFlagChangeBroker {
+	if channel == "" {
+		channel = "feature_flag_changes"
+	}
+	return &RedisFeatureFlagChangeBroker{client: client, channel: channel, local: NewInProcessFeatureFlagChangeBroker()}
+}
+
+// Publish sends the event to Redis. When Redis is unavailable the event is
+// still delivered to this replica's subscribers and the error is returned.
+func (b *RedisFeatureFlagChangeBroker) Publish(ctx context.Context, event FeatureFlagChangeEvent) error {
+	event = prepareFeatureFlagChangeEvent(event)
+	if b.client == nil {
+		b.local.deliver(event)
+		return nil
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if err := b.client.Publish(ctx, b.channel, payload).Err(); err != nil {
+		b.local.deliver(event)
+		return err
+	}
+	return nil
+}
+
+func (b *RedisFeatureFlagChangeBroker) Subscribe(ctx context.Context, lastEventID string) (*FeatureFlagChangeSubscription, error) {
+	return b.local.Subscribe(ctx, lastEventID)
+}
+
+// Run relays events from the Redis channel to local subscribers until ctx is
+// cancelled. The go-redis client reconnects the subscription on its own.
+func (b *RedisFeatureFlagChangeBroker) Run(ctx context.Context, logger *slog.Logger) {
+	if b.client == nil {
+		return
+	}
+	pubsub := b.client.Subscribe(ctx, b.channel)
+	defer pubsub.Close()
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			var event FeatureFlagChangeEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil || event.ID == "" {
+				if logger != nil {
+					logger.Warn("feature flag change relay dropped malformed event", "error", err)
+				}
+				continue
+			}
+			b.local.deliver(event)
+		}
+	}
+}
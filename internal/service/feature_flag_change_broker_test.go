@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestInProcessFeatureFlagChangeBrokerReplayAndResume(t *testing.T) {
+	ctx := context.Background()
+	broker := NewInProcessFeatureFlagChangeBroker()
+
+	for _, flagID := range []uint{1, 2, 3} {
+		if err := broker.Publish(ctx, FeatureFlagChangeEvent{Type: FeatureFlagChangeFlagUpdated, FlagID: flagID}); err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+	}
+	first := broker.log[0]
+
+	sub, err := broker.Subscribe(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	defer sub.Close()
+	if !sub.Resumed || len(sub.Replay) != 2 || sub.Replay[0].FlagID != 2 || sub.Replay[1].FlagID != 3 {
+		t.Fatalf("unexpected replay: resumed=%v events=%+v", sub.Resumed, sub.Replay)
+	}
+
+	unknown, err := broker.Subscribe(ctx, "0-deadbeef")
+	if err != nil {
+		t.Fatalf("subscribe unknown: %v", err)
+	}
+	defer unknown.Close()
+	if unknown.Resumed || len(unknown.Replay) != 0 {
+		t.Fatalf("expected resync for unknown event id, got resumed=%v replay=%d", unknown.Resumed, len(unknown.Replay))
+	}
+
+	if err := broker.Publish(ctx, FeatureFlagChangeEvent{Type: FeatureFlagChangeRuleCreated, FlagID: 4, RuleID: 9}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	select {
+	case event := <-sub.Events:
+		if event.FlagID != 4 || event.RuleID != 9 || event.ID == "" || event.OccurredAt.IsZero() {
+			t.Fatalf("unexpected live event: %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected live event")
+	}
+}
+
+func TestInProcessFeatureFlagChangeBrokerDropsSlowSubscriber(t *testing.T) {
+	ctx := context.Background()
+	broker := NewInProcessFeatureFlagChangeBroker()
+	sub, err := broker.Subscribe(ctx, "")
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	defer sub.Close()
+
+	for i := 0; i <= defaultFeatureFlagChangeBufferSize; i++ {
+		_ = broker.Publish(ctx, FeatureFlagChangeEvent{Type: FeatureFlagChangeFlagUpdated, FlagID: uint(i + 1)})
+	}
+	received := 0
+	for range sub.Events {
+		received++
+	}
+	if received != defaultFeatureFlagChangeBufferSize {
+		t.Fatalf("expected %d buffered events before drop, got %d", defaultFeatureFlagChangeBufferSize, received)
+	}
+}
+
+func TestRedisFeatureFlagChangeBrokerFansOutAcrossReplicas(t *testing.T) {
+	_, client := newRedisClientForTest(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	publisher := NewRedisFeatureFlagChangeBroker(client, "ff_changes_test")
+	replica := NewRedisFeatureFlagChangeBroker(client, "ff_changes_test")
+	go publisher.Run(ctx, nil)
+	go replica.Run(ctx, nil)
+
+	sub, err := replica.Subscribe(ctx, "")
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	defer sub.Close()
+
+	// Wait for both relays to attach before publishing.
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		counts, err := client.PubSubNumSub(ctx, "ff_changes_test").Result()
+		if err != nil {
+			t.Fatalf("pubsub numsub: %v", err)
+		}
+		if counts["ff_changes_test"] >= 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("relays did not subscribe in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := publisher.Publish(ctx, FeatureFlagChangeEvent{Type: FeatureFlagChangeFlagCreated, FlagID: 7, FlagKey: "new_checkout"}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	select {
+	case event := <-sub.Events:
+		if event.FlagKey != "new_checkout" || event.ID == "" {
+			t.Fatalf("unexpected relayed event: %+v", event)
+		}
+		resumed, err := replica.Subscribe(ctx, event.ID)
+		if err != nil {
+			t.Fatalf("resume subscribe: %v", err)
+		}
+		defer resumed.Close()
+		if !resumed.Resumed {
+			t.Fatal("expected relayed event id to be resumable on the receiving replica")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected event relayed through redis")
+	}
+}
+
+func TestRedisFeatureFlagChangeBrokerDeliversLocallyWhenPublishFails(t *testing.T) {
+	server, client := newRedisClientForTest(t)
+	broker := NewRedisFeatureFlagChangeBroker(client, "ff_changes_test")
+	sub, err := broker.Subscribe(context.Background(), "")
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	defer sub.Close()
+
+	server.Close()
+	if err := broker.Publish(context.Background(), FeatureFlagChangeEvent{Type: FeatureFlagChangeFlagDeleted, FlagID: 3}); err == nil {
+		t.Fatal("expected publish error with redis down")
+	}
+	select {
+	case event := <-sub.Events:
+		if event.FlagID != 3 {
+			t.Fatalf("unexpected fallback event: %+v", event)
+		}
+	default:
+		t.Fatal("expected local delivery when redis publish fails")
+	}
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	repogomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+// TestFeatureFlagServiceConformance runs the fixtures shared with the Go SDK
+// (pkg/flagsclient) against the server evaluator so both stay in lockstep.
+func TestFeatureFlagServiceConformance(t *testing.T) {
+	raw, err := os.ReadFile("../../pkg/flagsclient/testdata/conformance.json")
+	if err != nil {
+		t.Fatalf("read fixtures: %v", err)
+	}
+	var fixtures struct {
+		Flags []domain.FeatureFlag `json:"flags"`
+		Cases []struct {
+			Name    string `json:"name"`
+			Context struct {
+				UserID      uint              `json:"user_id"`
+				Roles       []string          `json:"roles"`
+				Org         string            `json:"org"`
+				Environment string            `json:"environment"`
+				Attributes  map[string]string `json:"attributes"`
+			} `json:"context"`
+			Expect map[string]struct {
+				Enabled bool            `json:"enabled"`
+				Source  string          `json:"source"`
+				Variant string          `json:"variant"`
+				Value   json.RawMessage `json:"value"`
+			} `json:"expect"`
+		} `json:"cases"`
+	}
+	if err := json.Unmarshal(raw, &fixtures); err != nil {
+		t.Fatalf("decode fixtures: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockFeatureFlagRepository(ctrl)
+	repo.EXPECT().ListFlags().Return(fixtures.Flags, nil).AnyTimes()
+	svc := NewFeatureFlagService(repo, NewNoopFeatureFlagEvaluationCacheStore(), nil, nil)
+
+	for _, tc := range fixtures.Cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			results, err := svc.EvaluateAll(context.Background(), FeatureFlagEvaluationContext{
+				UserID:      tc.Context.UserID,
+				Roles:       tc.Context.Roles,
+				Org:         tc.Context.Org,
+				Environment: tc.Context.Environment,
+				Attributes:  tc.Context.Attributes,
+			})
+			if err != nil {
+				t.Fatalf("evaluate all: %v", err)
+			}
+			if len(results) != len(tc.Expect) {
+				t.Fatalf("expected %d results, got %d", len(tc.Expect), len(results))
+			}
+			for _, result := range results {
+				want, ok := tc.Expect[result.Key]
+				if !ok {
+					t.Fatalf("unexpected flag %q", result.Key)
+				}
+				if result.Enabled != want.Enabled || result.Source != want.Source || result.Variant != want.Variant || !equalCompactJSON(t, result.Value, want.Value) {
+					t.Fatalf("%s: expected %+v, got %+v", result.Key, want, result)
+				}
+			}
+		})
+	}
+}
+
+func equalCompactJSON(t *testing.T, a, b json.RawMessage) bool {
+	t.Helper()
+	var ca, cb bytes.Buffer
+	if err := json.Compact(&ca, a); err != nil {
+		t.Fatalf("compact %s: %v", a, err)
+	}
+	if err := json.Compact(&cb, b); err != nil {
+		t.Fatalf("compact %s: %v", b, err)
+	}
+	return ca.String() == cb.String()
+}
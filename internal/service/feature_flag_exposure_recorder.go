@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+)
+
+// FeatureFlagExposureRecorder observes evaluation results served to callers.
+// Record runs on the evaluation path and must not block on I/O.
+type FeatureFlagExposureRecorder interface {
+	Record(ctx context.Context, evalCtx FeatureFlagEvaluationContext, results []FeatureFlagEvaluationResult)
+}
+
+type NoopFeatureFlagExposureRecorder struct{}
+
+func NewNoopFeatureFlagExposureRecorder() *NoopFeatureFlagExposureRecorder {
+	return &NoopFeatureFlagExposureRecorder{}
+}
+
+func (r *NoopFeatureFlagExposureRecorder) Record(context.Context, FeatureFlagEvaluationContext, []FeatureFlagEvaluationResult) {
+}
+
+type featureFlagStatKey struct {
+	flagKey string
+	variant string
+	source  string
+}
+
+type featureFlagExposureKey struct {
+	flagKey string
+	userID  uint
+	variant string
+	enabled bool
+}
+
+// BufferedFeatureFlagExposureRecorder aggregates evaluations in memory and
+// writes them to the database on Flush. Counts are summed per flag, variant
+// and source; exposures are kept for identified users only, once per user
+// and outcome per flush window, and beyond maxExposures they are dropped.
+type BufferedFeatureFlagExposureRecorder struct {
+	repo         repository.FeatureFlagExposureRepository
+	maxExposures int
+	now          func() time.Time
+
+	mu        sync.Mutex
+	stats     map[featureFlagStatKey]*domain.FeatureFlagEvaluationStat
+	exposures map[featureFlagExposureKey]domain.FeatureFlagExposure
+}
+
+func NewBufferedFeatureFlagExposureRecorder(repo repository.FeatureFlagExposureRepository, maxExposures int) *BufferedFeatureFlagExposureRecorder {
+	if maxExposures <= 0 {
+		maxExposures = 10000
+	}
+	return &BufferedFeatureFlagExposureRecorder{
+		repo:         repo,
+		maxExposures: maxExposures,
+		now:          time.Now,
+		stats:        map[featureFlagStatKey]*domain.FeatureFlagEvaluationStat{},
+		exposures:    map[featureFlagExposureKey]domain.FeatureFlagExposure{},
+	}
+}
+
+func (r *BufferedFeatureFlagExposureRecorder) Record(ctx context.Context, evalCtx FeatureFlagEvaluationContext, results []FeatureFlagEvaluationResult) {
+	if len(results) == 0 {
+		return
+	}
+	at := r.now().UTC()
+	dropped := 0
+	r.mu.Lock()
+	for _, result := range results {
+		r.addStat(domain.FeatureFlagEvaluationStat{FlagKey: result.Key, Variant: result.Variant, Source: result.Source, Count: 1, LastEvaluatedAt: at})
+		if evalCtx.UserID == 0 {
+			continue
+		}
+		exposure := domain.FeatureFlagExposure{
+			FlagKey:     result.Key,
+			UserID:      evalCtx.UserID,
+			Variant:     result.Variant,
+			Enabled:     result.Enabled,
+			Source:      result.Source,
+			EvaluatedAt: at,
+		}
+		if !r.addExposure(exposure) {
+			dropped++
+		}
+	}
+	r.mu.Unlock()
+	observability.RecordFeatureFlagExposureRecords(ctx, "exposure", "dropped", dropped)
+}
+
+// addStat and addExposure must be called with mu held.
+func (r *BufferedFeatureFlagExposureRecorder) addStat(stat domain.FeatureFlagEvaluationStat) {
+	key := featureFlagStatKey{flagKey: stat.FlagKey, variant: stat.Variant, source: stat.Source}
+	current, ok := r.stats[key]
+	if !ok {
+		r.stats[key] = &stat
+		return
+	}
+	current.Count += stat.Count
+	if stat.LastEvaluatedAt.After(current.LastEvaluatedAt) {
+		current.LastEvaluatedAt = stat.LastEvaluatedAt
+	}
+}
+
+func (r *BufferedFeatureFlagExposureRecorder) addExposure(exposure domain.FeatureFlagExposure) bool {
+	key := featureFlagExposureKey{flagKey: exposure.FlagKey, userID: exposure.UserID, variant: exposure.Variant, enabled: exposure.Enabled}
+	if _, ok := r.exposures[key]; ok {
+		return true
+	}
+	if len(r.exposures) >= r.maxExposures {
+		return false
+	}
+	r.exposures[key] = exposure
+	return true
+}
+
+// Flush writes everything buffered since the previous flush. On failure the
+// records are put back so the next flush retries them.
+func (r *BufferedFeatureFlagExposureRecorder) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	stats := make([]domain.FeatureFlagEvaluationStat, 0, len(r.stats))
+	for _, stat := range r.stats {
+		stats = append(stats, *stat)
+	}
+	exposures := make([]domain.FeatureFlagExposure, 0, len(r.exposures))
+	for _, exposure := range r.exposures {
+		exposures = append(exposures, exposure)
+	}
+	r.stats = map[featureFlagStatKey]*domain.FeatureFlagEvaluationStat{}
+	r.exposures = map[featureFlagExposureKey]domain.FeatureFlagExposure{}
+	r.mu.Unlock()
+
+	if len(stats) == 0 && len(exposures) == 0 {
+		return nil
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].FlagKey != stats[j].FlagKey {
+			return stats[i].FlagKey < stats[j].FlagKey
+		}
+		if stats[i].Variant != stats[j].Variant {
+			return stats[i].Variant < stats[j].Variant
+		}
+		return stats[i].Source < stats[j].Source
+	})
+	sort.Slice(exposures, func(i, j int) bool {
+		if !exposures[i].EvaluatedAt.Equal(exposures[j].EvaluatedAt) {
+			return exposures[i].EvaluatedAt.Before(exposures[j].EvaluatedAt)
+		}
+		if exposures[i].FlagKey != exposures[j].FlagKey {
+			return exposures[i].FlagKey < exposures[j].FlagKey
+		}
+		return exposures[i].UserID < exposures[j].UserID
+	})
+	if err := r.repo.RecordEvaluations(stats, exposures); err != nil {
+		observability.RecordFeatureFlagExposureRecords(ctx, "stat", "error", len(stats))
+		observability.RecordFeatureFlagExposureRecords(ctx, "exposure", "error", len(exposures))
+		dropped := 0
+		r.mu.Lock()
+		for _, stat := range stats {
+			r.addStat(stat)
+		}
+		for _, exposure := range exposures {
+			if !r.addExposure(exposure) {
+				dropped++
+			}
+		}
+		r.mu.Unlock()
+		observability.RecordFeatureFlagExposureRecords(ctx, "exposure", "dropped", dropped)
+		return err
+	}
+	observability.RecordFeatureFlagExposureRecords(ctx, "stat", "flushed", len(stats))
+	observability.RecordFeatureFlagExposureRecords(ctx, "exposure", "flushed", len(exposures))
+	return nil
+}
+
+// RunLoop flushes every interval and prunes exposures older than retention.
+// It flushes once more when ctx is cancelled so a clean shutdown keeps the
+// last window.
+func (r *BufferedFeatureFlagExposureRecorder) RunLoop(ctx context.Context, interval, retention time.Duration, logger *slog.Logger) {
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if err := r.Flush(context.Background()); err != nil && logger != nil {
+				logger.Warn("feature flag exposure final flush failed", "error", err)
+			}
+			return
+		case <-ticker.C:
+			if err := r.Flush(ctx); err != nil {
+				if logger != nil {
+					logger.Warn("feature flag exposure flush failed", "error", err)
+				}
+				continue
+			}
+			if retention <= 0 {
+				continue
+			}
+			deleted, err := r.repo.DeleteExposuresBefore(r.now().UTC().Add(-retention), 1000)
+			if err != nil {
+				if logger != nil {
+					logger.Warn("feature flag exposure cleanup failed", "error", err)
+				}
+				continue
+			}
+			if deleted > 0 && logger != nil {
+				logger.Info("feature flag exposure cleanup removed expired records", "deleted", deleted)
+			}
+		}
+	}
+}
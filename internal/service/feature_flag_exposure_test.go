@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	repogomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository/gomock"
+)
+
+func TestFeatureFlagExposureRecorderFlushesEvaluations(t *testing.T) {
+	db := newFeatureFlagExposureDBForTest(t)
+	exposureRepo := repository.NewFeatureFlagExposureRepository(db)
+	recorder := NewBufferedFeatureFlagExposureRecorder(exposureRepo, 100)
+	svc := NewFeatureFlagService(repository.NewFeatureFlagRepository(db), NewInMemoryFeatureFlagEvaluationCacheStore(), nil, recorder)
+	ctx := context.Background()
+
+	flag := &domain.FeatureFlag{Key: "checkout", Enabled: false}
+	if err := svc.CreateFlag(ctx, flag); err != nil {
+		t.Fatalf("create flag: %v", err)
+	}
+	if err := svc.CreateRule(ctx, &domain.FeatureFlagRule{FeatureFlagID: flag.ID, Type: FeatureFlagRuleTypeUser, MatchValue: "42", Enabled: true}); err != nil {
+		t.Fatalf("create rule: %v", err)
+	}
+
+	user := FeatureFlagEvaluationContext{UserID: 42}
+	for i := 0; i < 2; i++ {
+		// The second call is served from the evaluation cache and still counts.
+		if _, err := svc.EvaluateAll(ctx, user); err != nil {
+			t.Fatalf("evaluate all: %v", err)
+		}
+	}
+	if _, err := svc.EvaluateByKey(ctx, "checkout", FeatureFlagEvaluationContext{}); err != nil {
+		t.Fatalf("evaluate anonymous: %v", err)
+	}
+	// Stream pushes and OFREP revalidations evaluate without counting.
+	if _, err := svc.EvaluateAllUnrecorded(ctx, FeatureFlagEvaluationContext{UserID: 7}); err != nil {
+		t.Fatalf("evaluate unrecorded: %v", err)
+	}
+	if stats, _ := exposureRepo.ListEvaluationStats("checkout"); len(stats) != 0 {
+		t.Fatalf("expected nothing written before a flush, got %+v", stats)
+	}
+	if err := recorder.Flush(ctx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	stats, err := exposureRepo.ListEvaluationStats("checkout")
+	if err != nil || len(stats) != 2 {
+		t.Fatalf("expected default and rule stats, got %+v err=%v", stats, err)
+	}
+	if stats[0].Source != "default" || stats[0].Count != 1 || stats[1].Source != "rule:user" || stats[1].Count != 2 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	exposures, err := exposureRepo.ListExposures("checkout", 0, 10)
+	if err != nil || len(exposures) != 1 {
+		t.Fatalf("expected one deduplicated exposure for the identified user, got %+v err=%v", exposures, err)
+	}
+	if exposures[0].UserID != 42 || !exposures[0].Enabled || exposures[0].Source != "rule:user" {
+		t.Fatalf("unexpected exposure %+v", exposures[0])
+	}
+	if err := recorder.Flush(ctx); err != nil {
+		t.Fatalf("empty flush: %v", err)
+	}
+	if stats, _ := exposureRepo.ListEvaluationStats("checkout"); stats[1].Count != 2 {
+		t.Fatalf("expected an empty flush to leave counts unchanged, got %+v", stats)
+	}
+}
+
+func TestFeatureFlagExposureRecorderRetriesFailedFlush(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockFeatureFlagExposureRepository(ctrl)
+	recorder := NewBufferedFeatureFlagExposureRecorder(repo, 1)
+	ctx := context.Background()
+	results := []FeatureFlagEvaluationResult{{Key: "checkout", Enabled: true, Source: "default"}}
+
+	recorder.Record(ctx, FeatureFlagEvaluationContext{UserID: 1}, results)
+	recorder.Record(ctx, FeatureFlagEvaluationContext{UserID: 2}, results)
+
+	repo.EXPECT().RecordEvaluations(gomock.Any(), gomock.Any()).Return(errors.New("db down"))
+	if err := recorder.Flush(ctx); err == nil {
+		t.Fatal("expected flush error")
+	}
+	repo.EXPECT().RecordEvaluations(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(stats []domain.FeatureFlagEvaluationStat, exposures []domain.FeatureFlagExposure) error {
+			if len(stats) != 1 || stats[0].Count != 2 {
+				t.Fatalf("expected the failed counts to be retried, got %+v", stats)
+			}
+			if len(exposures) != 1 || exposures[0].UserID != 1 {
+				t.Fatalf("expected exposures beyond the buffer size to be dropped, got %+v", exposures)
+			}
+			return nil
+		})
+	if err := recorder.Flush(ctx); err != nil {
+		t.Fatalf("retry flush: %v", err)
+	}
+}
+
+func TestFeatureFlagUsageServiceStaleFlags(t *testing.T) {
+	db := newFeatureFlagExposureDBForTest(t)
+	flagRepo := repository.NewFeatureFlagRepository(db)
+	exposureRepo := repository.NewFeatureFlagExposureRepository(db)
+	flags := NewFeatureFlagService(flagRepo, NewNoopFeatureFlagEvaluationCacheStore(), nil, nil)
+	usage := NewFeatureFlagUsageService(flagRepo, exposureRepo)
+	ctx := context.Background()
+
+	rolledOut := &domain.FeatureFlag{Key: "rolled_out", Enabled: true}
+	partial := &domain.FeatureFlag{Key: "partial", Enabled: true}
+	active := &domain.FeatureFlag{Key: "active", Enabled: false}
+	for _, flag := range []*domain.FeatureFlag{rolledOut, partial, active} {
+		if err := flags.CreateFlag(ctx, flag); err != nil {
+			t.Fatalf("create %s: %v", flag.Key, err)
+		}
+	}
+	if err := flags.CreateRule(ctx, &domain.FeatureFlagRule{FeatureFlagID: partial.ID, Type: FeatureFlagRuleTypeOrg, MatchValue: "acme", Enabled: false}); err != nil {
+		t.Fatalf("create rule: %v", err)
+	}
+
+	now := time.Now().UTC().AddDate(0, 0, 40)
+	usage.now = func() time.Time { return now }
+	if err := exposureRepo.RecordEvaluations([]domain.FeatureFlagEvaluationStat{
+		{FlagKey: "active", Source: "default", Count: 9, LastEvaluatedAt: now.Add(-time.Hour)},
+		{FlagKey: "rolled_out", Source: "default", Count: 3, LastEvaluatedAt: now.AddDate(0, 0, -35)},
+	}, nil); err != nil {
+		t.Fatalf("seed stats: %v", err)
+	}
+
+	stale, err := usage.StaleFlags(ctx, 30)
+	if err != nil {
+		t.Fatalf("stale flags: %v", err)
+	}
+	if len(stale) != 2 {
+		t.Fatalf("expected partial and rolled_out, got %+v", stale)
+	}
+	if stale[0].Key != "partial" || strings.Join(stale[0].Reasons, ",") != FeatureFlagStaleNotEvaluated || stale[0].LastEvaluatedAt != nil {
+		t.Fatalf("expected partial to be reported as never evaluated, got %+v", stale[0])
+	}
+	if stale[1].Key != "rolled_out" || strings.Join(stale[1].Reasons, ",") != "not_evaluated,fully_rolled_out" || stale[1].Evaluations != 3 {
+		t.Fatalf("expected rolled_out to be unused and fully rolled out, got %+v", stale[1])
+	}
+
+	if stale, _ := usage.StaleFlags(ctx, 60); len(stale) != 0 {
+		t.Fatalf("expected nothing stale over a 60 day window, got %+v", stale)
+	}
+	if _, err := usage.StaleFlags(ctx, 0); !errors.Is(err, ErrFeatureFlagInvalidStaleWindow) {
+		t.Fatalf("expected ErrFeatureFlagInvalidStaleWindow, got %v", err)
+	}
+
+	report, err := usage.Usage(ctx, active.ID)
+	if err != nil || report.Evaluations != 9 || report.LastEvaluatedAt == nil || len(report.Breakdown) != 1 {
+		t.Fatalf("unexpected usage %+v err=%v", report, err)
+	}
+	if _, err := usage.ListExposures(ctx, 999, 0, 10); !errors.Is(err, repository.ErrFeatureFlagNotFound) {
+		t.Fatalf("expected ErrFeatureFlagNotFound, got %v", err)
+	}
+}
+
+func TestFeatureFlagFullyRolledOut(t *testing.T) {
+	variants := []domain.FeatureFlagVariant{
+		{Key: "control", Value: []byte(`"a"`)},
+		{Key: "treatment", Value: []byte(`"b"`)},
+	}
+	cases := []struct {
+		name string
+		flag domain.FeatureFlag
+		want bool
+	}{
+		{name: "disabled", flag: domain.FeatureFlag{Enabled: false}, want: false},
+		{name: "boolean on", flag: domain.FeatureFlag{Enabled: true, Rules: []domain.FeatureFlagRule{{Type: FeatureFlagRuleTypePercent, Percentage: 10, Enabled: true}}}, want: true},
+		{name: "rule turns off", flag: domain.FeatureFlag{Enabled: true, Rules: []domain.FeatureFlagRule{{Type: FeatureFlagRuleTypeUser, MatchValue: "1", Enabled: false}}}, want: false},
+		{name: "prerequisite", flag: domain.FeatureFlag{Enabled: true, Prerequisites: []domain.FeatureFlagPrerequisite{{PrerequisiteFlagID: 2}}}, want: false},
+		{name: "single variant", flag: domain.FeatureFlag{Enabled: true, Type: domain.FeatureFlagTypeString, DefaultVariant: "treatment", Variants: variants}, want: true},
+		{
+			name: "weighted split",
+			flag: domain.FeatureFlag{Enabled: true, Type: domain.FeatureFlagTypeString, DefaultVariant: "control", Variants: []domain.FeatureFlagVariant{
+				{Key: "control", Weight: 0}, {Key: "treatment", Weight: 100},
+			}},
+			want: false,
+		},
+		{
+			name: "rule pins other variant",
+			flag: domain.FeatureFlag{Enabled: true, Type: domain.FeatureFlagTypeString, DefaultVariant: "treatment", Variants: variants,
+				Rules: []domain.FeatureFlagRule{{Type: FeatureFlagRuleTypeRole, MatchValue: "qa", Enabled: true, Variant: "control"}}},
+			want: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := featureFlagFullyRolledOut(tc.flag); got != tc.want {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func newFeatureFlagExposureDBForTest(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&domain.FeatureFlag{},
+		&domain.FeatureFlagRule{},
+		&domain.FeatureFlagVariant{},
+		&domain.FeatureFlagPrerequisite{},
+		&domain.FeatureFlagVersion{},
+		&domain.FeatureFlagEvaluationStat{},
+		&domain.FeatureFlagExposure{},
+	); err != nil {
+		t.Fatalf("migrate feature flags: %v", err)
+	}
+	return db
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// FeatureFlagGuardrailHTTPErrorRate is the share of HTTP responses with a
+	// 5xx status over the tracker window, between 0 and 1.
+	FeatureFlagGuardrailHTTPErrorRate = "http_error_rate"
+
+	httpErrorRateBucketCount = 30
+)
+
+var ErrFeatureFlagUnknownGuardrail = errors.New("unknown feature flag guardrail metric")
+
+// FeatureFlagGuardrailSource reports the current value of a guardrail
+// metric. ok is false when too little data has been observed to judge it.
+type FeatureFlagGuardrailSource interface {
+	GuardrailValue(ctx context.Context, metric string) (value float64, ok bool, err error)
+}
+
+// HTTPErrorRateStore shares response counts between replicas, one entry per
+// tracker bucket slot. Slots are derived from wall-clock time, so replicas
+// with the same window agree on them.
+type HTTPErrorRateStore interface {
+	AddCounts(ctx context.Context, slot int64, total, errors int, ttl time.Duration) error
+	SumCounts(ctx context.Context, slots []int64) (total, errors int, err error)
+}
+
+// HTTPErrorRateTracker counts responses served by this replica over a sliding
+// window. It backs the http_error_rate guardrail. Without a shared store the
+// value reflects only the replica running the scheduler; with one, every
+// replica publishes its counts and the guardrail judges the whole fleet.
+type HTTPErrorRateTracker struct {
+	mu          sync.Mutex
+	bucketWidth time.Duration
+	buckets     [httpErrorRateBucketCount]httpErrorRateBucket
+	minRequests int
+	now         func() time.Time
+
+	// shared, when set, receives the counts of buckets; published holds
+	// what has already been sent for each of them.
+	shared    HTTPErrorRateStore
+	published [httpErrorRateBucketCount]httpErrorRateBucket
+}
+
+type httpErrorRateBucket struct {
+	slot   int64
+	total  int
+	errors int
+}
+
+func NewHTTPErrorRateTracker(window time.Duration, minRequests int) *HTTPErrorRateTracker {
+	if window <= 0 {
+		window = 5 * time.Minute
+	}
+	if minRequests < 1 {
+		minRequests = 1
+	}
+	width := window / httpErrorRateBucketCount
+	if width < time.Second {
+		width = time.Second
+	}
+	return &HTTPErrorRateTracker{bucketWidth: width, minRequests: minRequests, now: time.Now}
+}
+
+// NewSharedHTTPErrorRateTracker returns a tracker that publishes its counts
+// to store and reads the guardrail from the counts of every replica.
+func NewSharedHTTPErrorRateTracker(window time.Duration, minRequests int, store HTTPErrorRateStore) *HTTPErrorRateTracker {
+	t := NewHTTPErrorRateTracker(window, minRequests)
+	t.shared = store
+	return t
+}
+
+// Shared reports whether the tracker publishes to a shared store.
+func (t *HTTPErrorRateTracker) Shared() bool {
+	return t.shared != nil
+}
+
+// RecordStatus counts one response with the given status code.
+func (t *HTTPErrorRateTracker) RecordStatus(status int) {
+	slot := t.now().UnixNano() / int64(t.bucketWidth)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	bucket := &t.buckets[slot%httpErrorRateBucketCount]
+	if bucket.slot != slot {
+		*bucket = httpErrorRateBucket{slot: slot}
+	}
+	bucket.total++
+	if status >= http.StatusInternalServerError {
+		bucket.errors++
+	}
+}
+
+func (t *HTTPErrorRateTracker) GuardrailValue(ctx context.Context, metric string) (float64, bool, error) {
+	if metric != FeatureFlagGuardrailHTTPErrorRate {
+		return 0, false, ErrFeatureFlagUnknownGuardrail
+	}
+	current := t.now().UnixNano() / int64(t.bucketWidth)
+	total, errs := 0, 0
+	if t.shared != nil {
+		if err := t.Flush(ctx); err != nil {
+			return 0, false, err
+		}
+		slots := make([]int64, 0, httpErrorRateBucketCount)
+		for slot := current - httpErrorRateBucketCount + 1; slot <= current; slot++ {
+			slots = append(slots, slot)
+		}
+		var err error
+		if total, errs, err = t.shared.SumCounts(ctx, slots); err != nil {
+			return 0, false, err
+		}
+	} else {
+		t.mu.Lock()
+		for _, bucket := range t.buckets {
+			if current-bucket.slot < httpErrorRateBucketCount {
+				total += bucket.total
+				errs += bucket.errors
+			}
+		}
+		t.mu.Unlock()
+	}
+	if total < t.minRequests {
+		return 0, false, nil
+	}
+	return float64(errs) / float64(total), true, nil
+}
+
+// Flush publishes the counts recorded since the previous flush to the shared
+// store. Counts that fail to publish are retried on the next flush.
+func (t *HTTPErrorRateTracker) Flush(ctx context.Context) error {
+	if t.shared == nil {
+		return nil
+	}
+	current := t.now().UnixNano() / int64(t.bucketWidth)
+	pending := make([]httpErrorRateBucket, 0, httpErrorRateBucketCount)
+	t.mu.Lock()
+	for i := range t.buckets {
+		bucket, sent := t.buckets[i], &t.published[i]
+		if current-bucket.slot >= httpErrorRateBucketCount {
+			continue
+		}
+		if sent.slot != bucket.slot {
+			*sent = httpErrorRateBucket{slot: bucket.slot}
+		}
+		if bucket.total == sent.total {
+			continue
+		}
+		pending = append(pending, httpErrorRateBucket{slot: bucket.slot, total: bucket.total - sent.total, errors: bucket.errors - sent.errors})
+		*sent = bucket
+	}
+	t.mu.Unlock()
+
+	// Keep each slot for one window past its end so every replica can still
+	// read it.
+	ttl := 2 * httpErrorRateBucketCount * t.bucketWidth
+	for i, delta := range pending {
+		if err := t.shared.AddCounts(ctx, delta.slot, delta.total, delta.errors, ttl); err != nil {
+			t.unpublish(pending[i:])
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *HTTPErrorRateTracker) unpublish(deltas []httpErrorRateBucket) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, delta := range deltas {
+		sent := &t.published[delta.slot%httpErrorRateBucketCount]
+		if sent.slot == delta.slot {
+			sent.total -= delta.total
+			sent.errors -= delta.errors
+		}
+	}
+}
+
+// RunFlushLoop publishes counts once per bucket until ctx is cancelled, so
+// the guardrail sees every replica's responses within one bucket width.
+func (t *HTTPErrorRateTracker) RunFlushLoop(ctx context.Context, logger *slog.Logger) {
+	ticker := time.NewTicker(t.bucketWidth)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			_ = t.Flush(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			if err := t.Flush(ctx); err != nil && logger != nil {
+				logger.Warn("feature flag guardrail flush failed", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisHTTPErrorRateStore keeps the fleet's response counts in one Redis
+// hash per tracker bucket slot.
+type RedisHTTPErrorRateStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+func NewRedisHTTPErrorRateStore(client redis.UniversalClient, prefix string) *RedisHTTPErrorRateStore {
+	if prefix == "" {
+		prefix = "feature_flag_guardrail"
+	}
+	return &RedisHTTPErrorRateStore{client: client, prefix: prefix}
+}
+
+func (s *RedisHTTPErrorRateStore) AddCounts(ctx context.Context, slot int64, total, errors int, ttl time.Duration) error {
+	key := s.slotKey(slot)
+	pipe := s.client.TxPipeline()
+	pipe.HIncrBy(ctx, key, "total", int64(total))
+	if errors > 0 {
+		pipe.HIncrBy(ctx, key, "errors", int64(errors))
+	}
+	pipe.Expire(ctx, key, ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (s *RedisHTTPErrorRateStore) SumCounts(ctx context.Context, slots []int64) (int, int, error) {
+	pipe := s.client.Pipeline()
+	cmds := make([]*redis.SliceCmd, 0, len(slots))
+	for _, slot := range slots {
+		cmds = append(cmds, pipe.HMGet(ctx, s.slotKey(slot), "total", "errors"))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, 0, err
+	}
+	total, errs := 0, 0
+	for _, cmd := range cmds {
+		values := cmd.Val()
+		total += redisCount(values, 0)
+		errs += redisCount(values, 1)
+	}
+	return total, errs, nil
+}
+
+func (s *RedisHTTPErrorRateStore) slotKey(slot int64) string {
+	return s.prefix + ":http_error_rate:" + strconv.FormatInt(slot, 10)
+}
+
+// redisCount reads the integer at index of an HMGET reply; missing fields
+// count as zero.
+func redisCount(values []any, index int) int {
+	if index >= len(values) {
+		return 0
+	}
+	raw, ok := values[index].(string)
+	if !ok {
+		return 0
+	}
+	n, _ := strconv.Atoi(raw)
+	return n
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSharedHTTPErrorRateTrackerCoversEveryReplica(t *testing.T) {
+	server, client := newRedisClientForTest(t)
+	store := NewRedisHTTPErrorRateStore(client, "test")
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	scheduler := NewSharedHTTPErrorRateTracker(time.Minute, 4, store)
+	scheduler.now = clock
+	other := NewSharedHTTPErrorRateTracker(time.Minute, 4, store)
+	other.now = clock
+	ctx := context.Background()
+
+	// The replica running the scheduler is healthy; the other one is not.
+	scheduler.RecordStatus(200)
+	scheduler.RecordStatus(200)
+	other.RecordStatus(500)
+	other.RecordStatus(503)
+	if _, ok, err := scheduler.GuardrailValue(ctx, FeatureFlagGuardrailHTTPErrorRate); err != nil || ok {
+		t.Fatalf("expected unflushed replicas to be unseen, ok=%v err=%v", ok, err)
+	}
+	if err := other.Flush(ctx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	value, ok, err := scheduler.GuardrailValue(ctx, FeatureFlagGuardrailHTTPErrorRate)
+	if err != nil || !ok || value != 0.5 {
+		t.Fatalf("expected fleet error rate 0.5, got %v ok=%v err=%v", value, ok, err)
+	}
+
+	// Flushing again publishes only new responses.
+	other.RecordStatus(200)
+	if err := other.Flush(ctx); err != nil {
+		t.Fatalf("second flush: %v", err)
+	}
+	if value, _, _ := scheduler.GuardrailValue(ctx, FeatureFlagGuardrailHTTPErrorRate); value != 0.4 {
+		t.Fatalf("expected error rate 0.4 after one more response, got %v", value)
+	}
+
+	// Counts that fail to publish are sent on the next flush.
+	other.RecordStatus(500)
+	server.SetError("unavailable")
+	if err := other.Flush(ctx); err == nil {
+		t.Fatal("expected flush to fail while redis is down")
+	}
+	if _, _, err := scheduler.GuardrailValue(ctx, FeatureFlagGuardrailHTTPErrorRate); err == nil {
+		t.Fatal("expected an unreadable shared metric to report an error")
+	}
+	server.SetError("")
+	if err := other.Flush(ctx); err != nil {
+		t.Fatalf("retry flush: %v", err)
+	}
+	if value, _, _ := scheduler.GuardrailValue(ctx, FeatureFlagGuardrailHTTPErrorRate); value != 0.5 {
+		t.Fatalf("expected error rate 0.5 after the retried flush, got %v", value)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok, _ := scheduler.GuardrailValue(ctx, FeatureFlagGuardrailHTTPErrorRate); ok {
+		t.Fatal("expected responses outside the window to be dropped")
+	}
+}
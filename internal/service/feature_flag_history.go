@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+)
+
+type featureFlagVariantState struct {
+	Value  json.RawMessage `json:"value"`
+	Weight int             `json:"weight"`
+}
+
+// featureFlagRuleState is the part of a rule that affects evaluation; IDs and
+// timestamps are left out so diffs only report meaningful edits.
+type featureFlagRuleState struct {
+	Type           string                     `json:"type"`
+	MatchValue     string                     `json:"match_value"`
+	Percentage     int                        `json:"percentage"`
+	Enabled        bool                       `json:"enabled"`
+	Priority       int                        `json:"priority"`
+	Variant        string                     `json:"variant,omitempty"`
+	ClauseOperator string                     `json:"clause_operator,omitempty"`
+	Clauses        []domain.FeatureFlagClause `json:"clauses,omitempty"`
+}
+
+type featureFlagPrerequisiteState struct {
+	Variant string `json:"variant,omitempty"`
+	Enabled *bool  `json:"enabled,omitempty"`
+}
+
+// newFeatureFlagVersion builds the history record for a mutation. The
+// snapshot is the flag after the change, or before it for deletions.
+func newFeatureFlagVersion(ctx context.Context, action string, before, after *domain.FeatureFlag) (*domain.FeatureFlagVersion, error) {
+	state := after
+	if state == nil {
+		state = before
+	}
+	snapshot, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	diff, err := diffFeatureFlags(before, after)
+	if err != nil {
+		return nil, err
+	}
+	version := &domain.FeatureFlagVersion{
+		FeatureFlagID: state.ID,
+		Action:        action,
+		Snapshot:      snapshot,
+		Diff:          diff,
+	}
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		version.ActorUserID = principal.UserID
+	}
+	return version, nil
+}
+
+// diffFeatureFlags lists the fields that differ between before and after,
+// either of which may be nil. Variants are keyed by variant key, rules by
+// rule ID and prerequisites by the required flag's ID; the result is ordered
+// by field name.
+func diffFeatureFlags(before, after *domain.FeatureFlag) ([]domain.FeatureFlagFieldChange, error) {
+	b, a := featureFlagFields(before), featureFlagFields(after)
+	names := make([]string, 0, len(b)+len(a))
+	for name := range b {
+		names = append(names, name)
+	}
+	for name := range a {
+		if _, ok := b[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	changes := make([]domain.FeatureFlagFieldChange, 0)
+	for _, name := range names {
+		beforeValue, err := marshalFeatureFlagField(b, name)
+		if err != nil {
+			return nil, err
+		}
+		afterValue, err := marshalFeatureFlagField(a, name)
+		if err != nil {
+			return nil, err
+		}
+		if string(beforeValue) == string(afterValue) {
+			continue
+		}
+		changes = append(changes, domain.FeatureFlagFieldChange{Field: name, Before: beforeValue, After: afterValue})
+	}
+	return changes, nil
+}
+
+func featureFlagFields(flag *domain.FeatureFlag) map[string]any {
+	if flag == nil {
+		return map[string]any{}
+	}
+	fields := map[string]any{
+		"key":             flag.Key,
+		"description":     flag.Description,
+		"enabled":         flag.Enabled,
+		"type":            flag.Type,
+		"default_variant": flag.DefaultVariant,
+	}
+	for _, variant := range flag.Variants {
+		fields["variants["+variant.Key+"]"] = featureFlagVariantState{Value: variant.Value, Weight: variant.Weight}
+	}
+	for _, rule := range flag.Rules {
+		fields["rules["+strconv.FormatUint(uint64(rule.ID), 10)+"]"] = featureFlagRuleState{
+			Type:           rule.Type,
+			MatchValue:     rule.MatchValue,
+			Percentage:     rule.Percentage,
+			Enabled:        rule.Enabled,
+			Priority:       rule.Priority,
+			Variant:        rule.Variant,
+			ClauseOperator: rule.ClauseOperator,
+			Clauses:        rule.Clauses,
+		}
+	}
+	for _, prerequisite := range flag.Prerequisites {
+		fields["prerequisites["+strconv.FormatUint(uint64(prerequisite.PrerequisiteFlagID), 10)+"]"] = featureFlagPrerequisiteState{
+			Variant: prerequisite.Variant,
+			Enabled: prerequisite.Enabled,
+		}
+	}
+	return fields
+}
+
+func marshalFeatureFlagField(fields map[string]any, name string) (json.RawMessage, error) {
+	value, ok := fields[name]
+	if !ok {
+		return nil, nil
+	}
+	return json.Marshal(value)
+}
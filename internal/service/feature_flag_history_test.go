@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	repogomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDiffFeatureFlagsReportsChangedFields(t *testing.T) {
+	before := &domain.FeatureFlag{
+		ID:             1,
+		Key:            "checkout_copy",
+		Enabled:        true,
+		Type:           domain.FeatureFlagTypeString,
+		DefaultVariant: "control",
+		Variants: []domain.FeatureFlagVariant{
+			{ID: 1, Key: "control", Value: json.RawMessage(`"Buy now"`), Weight: 50},
+			{ID: 2, Key: "urgent", Value: json.RawMessage(`"Hurry"`), Weight: 50},
+		},
+		Rules: []domain.FeatureFlagRule{{ID: 7, Type: FeatureFlagRuleTypePercent, Percentage: 10, Enabled: true, Priority: 50}},
+	}
+	after := *before
+	after.Enabled = false
+	after.Variants = []domain.FeatureFlagVariant{
+		{ID: 3, Key: "control", Value: json.RawMessage(`"Buy now"`), Weight: 50},
+		{ID: 4, Key: "urgent", Value: json.RawMessage(`"Hurry"`), Weight: 50},
+	}
+	after.Rules = []domain.FeatureFlagRule{{ID: 7, Type: FeatureFlagRuleTypePercent, Percentage: 100, Enabled: true, Priority: 50}}
+
+	diff, err := diffFeatureFlags(before, &after)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if len(diff) != 2 || diff[0].Field != "enabled" || diff[1].Field != "rules[7]" {
+		t.Fatalf("expected enabled and rules[7] changes only, got %+v", diff)
+	}
+	if string(diff[0].Before) != "true" || string(diff[0].After) != "false" {
+		t.Fatalf("unexpected enabled change: %+v", diff[0])
+	}
+
+	created, err := diffFeatureFlags(nil, before)
+	if err != nil {
+		t.Fatalf("diff create: %v", err)
+	}
+	if len(created) != 8 || created[0].Before != nil {
+		t.Fatalf("expected every field added on create, got %+v", created)
+	}
+}
+
+func TestFeatureFlagServiceRecordsActorInHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockFeatureFlagRepository(ctrl)
+	svc := NewFeatureFlagService(repo, NewNoopFeatureFlagEvaluationCacheStore(), nil, nil)
+
+	before := &domain.FeatureFlag{ID: 3, Key: "beta", Enabled: false, Type: domain.FeatureFlagTypeBoolean}
+	after := &domain.FeatureFlag{ID: 3, Key: "beta", Enabled: true, Type: domain.FeatureFlagTypeBoolean}
+	var recorded *domain.FeatureFlagVersion
+	gomock.InOrder(
+		repo.EXPECT().FindFlagByID(uint(3)).Return(before, nil),
+		repo.EXPECT().UpdateFlag(gomock.Any(), gomock.Any()).DoAndReturn(func(_ *domain.FeatureFlag, version repository.FeatureFlagVersionFunc) error {
+			var err error
+			recorded, err = version(after)
+			return err
+		}),
+	)
+
+	ctx := WithPrincipal(context.Background(), Principal{UserID: 42})
+	if err := svc.UpdateFlag(ctx, &domain.FeatureFlag{ID: 3, Key: "beta", Enabled: true}); err != nil {
+		t.Fatalf("update flag: %v", err)
+	}
+	if recorded == nil || recorded.ActorUserID != 42 || recorded.Action != FeatureFlagChangeFlagUpdated || recorded.FeatureFlagID != 3 {
+		t.Fatalf("unexpected history record: %+v", recorded)
+	}
+	if len(recorded.Diff) != 1 || recorded.Diff[0].Field != "enabled" {
+		t.Fatalf("expected enabled diff, got %+v", recorded.Diff)
+	}
+	var snapshot domain.FeatureFlag
+	if err := json.Unmarshal(recorded.Snapshot, &snapshot); err != nil || !snapshot.Enabled {
+		t.Fatalf("expected snapshot of the updated flag, got %s err=%v", recorded.Snapshot, err)
+	}
+}
+
+func TestFeatureFlagServiceRollbackRestoresSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockFeatureFlagRepository(ctrl)
+	cache := NewInMemoryFeatureFlagEvaluationCacheStore()
+	svc := NewFeatureFlagService(repo, cache, nil, nil)
+
+	good := domain.FeatureFlag{
+		ID:    9,
+		Key:   "new_checkout",
+		Type:  domain.FeatureFlagTypeBoolean,
+		Rules: []domain.FeatureFlagRule{{ID: 4, FeatureFlagID: 9, Type: FeatureFlagRuleTypePercent, Percentage: 5, Enabled: true, Priority: 50}},
+	}
+	bad := good
+	bad.Rules = []domain.FeatureFlagRule{{ID: 4, FeatureFlagID: 9, Type: FeatureFlagRuleTypePercent, Percentage: 100, Enabled: true, Priority: 50}}
+	snapshot, _ := json.Marshal(good)
+
+	sub, err := svc.SubscribeChanges(context.Background(), "")
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	defer sub.Close()
+	if err := cache.Set(context.Background(), "ff:eval", []byte("stale"), time.Minute); err != nil {
+		t.Fatalf("seed cache: %v", err)
+	}
+
+	repo.EXPECT().FindVersion(uint(9), 2).Return(&domain.FeatureFlagVersion{FeatureFlagID: 9, Version: 2, Snapshot: snapshot}, nil)
+	gomock.InOrder(
+		repo.EXPECT().FindFlagByID(uint(9)).Return(&bad, nil),
+		repo.EXPECT().RestoreFlag(gomock.Any(), gomock.Any()).DoAndReturn(func(flag *domain.FeatureFlag, version *domain.FeatureFlagVersion) error {
+			if flag.ID != 9 || len(flag.Rules) != 1 || flag.Rules[0].Percentage != 5 {
+				t.Fatalf("unexpected restored flag: %+v", flag)
+			}
+			if version.Action != FeatureFlagChangeFlagRolledBack || version.SourceVersion != 2 || len(version.Diff) != 1 || version.Diff[0].Field != "rules[4]" {
+				t.Fatalf("unexpected rollback record: %+v", version)
+			}
+			return nil
+		}),
+		repo.EXPECT().FindFlagByID(uint(9)).Return(&good, nil),
+	)
+
+	restored, err := svc.Rollback(context.Background(), 9, 2)
+	if err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+	if restored.Rules[0].Percentage != 5 {
+		t.Fatalf("expected restored flag, got %+v", restored)
+	}
+	if _, ok, _ := cache.Get(context.Background(), "ff:eval"); ok {
+		t.Fatal("expected rollback to invalidate cached evaluations")
+	}
+	if event := <-sub.Events; event.Type != FeatureFlagChangeFlagRolledBack || event.FlagID != 9 {
+		t.Fatalf("unexpected rollback event: %+v", event)
+	}
+
+	repo.EXPECT().FindVersion(uint(9), 7).Return(nil, repository.ErrFeatureFlagVersionNotFound)
+	if _, err := svc.Rollback(context.Background(), 9, 7); !errors.Is(err, repository.ErrFeatureFlagVersionNotFound) {
+		t.Fatalf("expected version not found, got %v", err)
+	}
+}
+
+func TestFeatureFlagServiceListHistoryClampsLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockFeatureFlagRepository(ctrl)
+	svc := NewFeatureFlagService(repo, NewNoopFeatureFlagEvaluationCacheStore(), nil, nil)
+
+	repo.EXPECT().ListVersions(uint(1), defaultFeatureFlagHistoryLimit).Return(nil, nil)
+	repo.EXPECT().ListVersions(uint(1), maxFeatureFlagHistoryLimit).Return(nil, nil)
+	if _, err := svc.ListHistory(context.Background(), 1, 0); err != nil {
+		t.Fatalf("list default: %v", err)
+	}
+	if _, err := svc.ListHistory(context.Background(), 1, 5000); err != nil {
+		t.Fatalf("list clamped: %v", err)
+	}
+}
+
+func TestDiffFeatureFlagsReportsPrerequisiteChanges(t *testing.T) {
+	on, off := true, false
+	before := &domain.FeatureFlag{ID: 2, Key: "new_checkout_v2", Prerequisites: []domain.FeatureFlagPrerequisite{{ID: 5, PrerequisiteFlagID: 1, Enabled: &on}}}
+	after := &domain.FeatureFlag{ID: 2, Key: "new_checkout_v2", Prerequisites: []domain.FeatureFlagPrerequisite{{ID: 9, PrerequisiteFlagID: 1, Enabled: &off}}}
+
+	diff, err := diffFeatureFlags(before, after)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if len(diff) != 1 || diff[0].Field != "prerequisites[1]" || string(diff[0].After) != `{"enabled":false}` {
+		t.Fatalf("expected prerequisites[1] change only, got %+v", diff)
+	}
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+)
+
+const maxFeatureFlagPrerequisites = 10
+
+var (
+	ErrFeatureFlagInvalidPrerequisite = errors.New("invalid feature flag prerequisite")
+	ErrFeatureFlagPrerequisiteCycle   = errors.New("feature flag prerequisites form a cycle")
+)
+
+// FeatureFlagDependentsError lists the flags that still require a flag that
+// was about to be deleted.
+type FeatureFlagDependentsError struct {
+	Dependents []string
+}
+
+func (e *FeatureFlagDependentsError) Error() string {
+	return "feature flag is a prerequisite of " + strings.Join(e.Dependents, ", ")
+}
+
+func (e *FeatureFlagDependentsError) Unwrap() error {
+	return repository.ErrFeatureFlagHasDependents
+}
+
+// FeatureFlagDependencyGraph has one node per flag and one edge per
+// prerequisite, pointing from the dependent flag to the flag it requires.
+type FeatureFlagDependencyGraph struct {
+	Nodes []FeatureFlagGraphNode `json:"nodes"`
+	Edges []FeatureFlagGraphEdge `json:"edges"`
+}
+
+type FeatureFlagGraphNode struct {
+	ID      uint   `json:"id"`
+	Key     string `json:"key"`
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
+
+type FeatureFlagGraphEdge struct {
+	From    uint   `json:"from"`
+	To      uint   `json:"to"`
+	Variant string `json:"variant,omitempty"`
+	Enabled *bool  `json:"enabled,omitempty"`
+}
+
+func buildFeatureFlagDependencyGraph(flags []domain.FeatureFlag) *FeatureFlagDependencyGraph {
+	graph := &FeatureFlagDependencyGraph{
+		Nodes: make([]FeatureFlagGraphNode, 0, len(flags)),
+		Edges: make([]FeatureFlagGraphEdge, 0),
+	}
+	for _, flag := range flags {
+		flagType := flag.Type
+		if flagType == "" {
+			flagType = domain.FeatureFlagTypeBoolean
+		}
+		graph.Nodes = append(graph.Nodes, FeatureFlagGraphNode{ID: flag.ID, Key: flag.Key, Type: flagType, Enabled: flag.Enabled})
+		for _, prerequisite := range flag.Prerequisites {
+			graph.Edges = append(graph.Edges, FeatureFlagGraphEdge{
+				From:    flag.ID,
+				To:      prerequisite.PrerequisiteFlagID,
+				Variant: prerequisite.Variant,
+				Enabled: prerequisite.Enabled,
+			})
+		}
+	}
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		if graph.Edges[i].From == graph.Edges[j].From {
+			return graph.Edges[i].To < graph.Edges[j].To
+		}
+		return graph.Edges[i].From < graph.Edges[j].From
+	})
+	return graph
+}
+
+// validateFeatureFlagPrerequisites normalizes flag's prerequisites and checks
+// them against the other flags: each must reference an existing flag once,
+// name a variant only for multivariate flags, and must not close a cycle.
+// Boolean requirements default to enabled.
+func validateFeatureFlagPrerequisites(flag *domain.FeatureFlag, flags []domain.FeatureFlag) error {
+	if len(flag.Prerequisites) > maxFeatureFlagPrerequisites {
+		return fmt.Errorf("%w: at most %d prerequisites are allowed", ErrFeatureFlagInvalidPrerequisite, maxFeatureFlagPrerequisites)
+	}
+	byID := make(map[uint]domain.FeatureFlag, len(flags))
+	for _, other := range flags {
+		byID[other.ID] = other
+	}
+	seen := make(map[uint]struct{}, len(flag.Prerequisites))
+	for i := range flag.Prerequisites {
+		prerequisite := &flag.Prerequisites[i]
+		prerequisite.Variant = strings.TrimSpace(strings.ToLower(prerequisite.Variant))
+		if flag.ID != 0 && prerequisite.PrerequisiteFlagID == flag.ID {
+			return fmt.Errorf("%w: a flag cannot require itself", ErrFeatureFlagInvalidPrerequisite)
+		}
+		required, ok := byID[prerequisite.PrerequisiteFlagID]
+		if !ok {
+			return fmt.Errorf("%w: prerequisite flag %d not found", ErrFeatureFlagInvalidPrerequisite, prerequisite.PrerequisiteFlagID)
+		}
+		if _, dup := seen[prerequisite.PrerequisiteFlagID]; dup {
+			return fmt.Errorf("%w: %s is listed more than once", ErrFeatureFlagInvalidPrerequisite, required.Key)
+		}
+		seen[prerequisite.PrerequisiteFlagID] = struct{}{}
+		if prerequisite.Variant == "" {
+			if prerequisite.Enabled == nil {
+				enabled := true
+				prerequisite.Enabled = &enabled
+			}
+			continue
+		}
+		if prerequisite.Enabled != nil {
+			return fmt.Errorf("%w: %s must set variant or enabled, not both", ErrFeatureFlagInvalidPrerequisite, required.Key)
+		}
+		if _, ok := required.FindVariant(prerequisite.Variant); !ok {
+			return fmt.Errorf("%w: %s has no variant %q", ErrFeatureFlagInvalidPrerequisite, required.Key, prerequisite.Variant)
+		}
+	}
+	if flag.ID == 0 {
+		// A new flag has no dependents yet, so it cannot close a cycle.
+		return nil
+	}
+	byID[flag.ID] = *flag
+	if path := findFeatureFlagPrerequisiteCycle(flag.ID, byID); path != nil {
+		keys := make([]string, 0, len(path))
+		for _, id := range path {
+			keys = append(keys, byID[id].Key)
+		}
+		return fmt.Errorf("%w: %s", ErrFeatureFlagPrerequisiteCycle, strings.Join(keys, " -> "))
+	}
+	return nil
+}
+
+// findFeatureFlagPrerequisiteCycle returns the path from start back to
+// itself through prerequisite edges, or nil when start is not on a cycle.
+func findFeatureFlagPrerequisiteCycle(start uint, byID map[uint]domain.FeatureFlag) []uint {
+	visited := map[uint]bool{}
+	var walk func(id uint, path []uint) []uint
+	walk = func(id uint, path []uint) []uint {
+		for _, prerequisite := range byID[id].Prerequisites {
+			next := prerequisite.PrerequisiteFlagID
+			if next == start {
+				return append(path, next)
+			}
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+			if cycle := walk(next, append(path, next)); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+	return walk(start, []uint{start})
+}
+
+// dependentFeatureFlagKeys returns the keys of flags that require id.
+func dependentFeatureFlagKeys(id uint, flags []domain.FeatureFlag) []string {
+	keys := make([]string, 0)
+	for _, flag := range flags {
+		for _, prerequisite := range flag.Prerequisites {
+			if prerequisite.PrerequisiteFlagID == id {
+				keys = append(keys, flag.Key)
+				break
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// featureFlagEvaluator evaluates flags for one context. Results are memoised
+// so a prerequisite shared by many flags is evaluated once, and a flag found
+// on its own prerequisite path is treated as unmet rather than recursing.
+type featureFlagEvaluator struct {
+	ctx      FeatureFlagEvaluationContext
+	flags    map[uint]domain.FeatureFlag
+	results  map[uint]FeatureFlagEvaluationResult
+	visiting map[uint]bool
+}
+
+func newFeatureFlagEvaluator(flags []domain.FeatureFlag, ctx FeatureFlagEvaluationContext) *featureFlagEvaluator {
+	byID := make(map[uint]domain.FeatureFlag, len(flags))
+	for _, flag := range flags {
+		byID[flag.ID] = flag
+	}
+	return &featureFlagEvaluator{
+		ctx:      ctx,
+		flags:    byID,
+		results:  make(map[uint]FeatureFlagEvaluationResult, len(flags)),
+		visiting: map[uint]bool{},
+	}
+}
+
+func (e *featureFlagEvaluator) evaluate(flag domain.FeatureFlag) FeatureFlagEvaluationResult {
+	if result, ok := e.results[flag.ID]; ok {
+		return result
+	}
+	e.visiting[flag.ID] = true
+	defer delete(e.visiting, flag.ID)
+
+	for _, prerequisite := range flag.Prerequisites {
+		if !e.prerequisiteMet(prerequisite) {
+			source := "prerequisite:" + strconv.FormatUint(uint64(prerequisite.PrerequisiteFlagID), 10)
+			if required, ok := e.flags[prerequisite.PrerequisiteFlagID]; ok {
+				source = "prerequisite:" + required.Key
+			}
+			result := buildFeatureFlagResult(flag, false, source, nil, e.ctx)
+			e.results[flag.ID] = result
+			return result
+		}
+	}
+	enabled, source, rule := evaluateFeatureFlag(flag, e.ctx)
+	result := buildFeatureFlagResult(flag, enabled, source, rule, e.ctx)
+	e.results[flag.ID] = result
+	return result
+}
+
+func (e *featureFlagEvaluator) prerequisiteMet(prerequisite domain.FeatureFlagPrerequisite) bool {
+	required, ok := e.flags[prerequisite.PrerequisiteFlagID]
+	if !ok || e.visiting[required.ID] {
+		return false
+	}
+	result := e.evaluate(required)
+	if prerequisite.Variant != "" {
+		return result.Variant == prerequisite.Variant
+	}
+	want := true
+	if prerequisite.Enabled != nil {
+		want = *prerequisite.Enabled
+	}
+	return result.Enabled == want
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	repogomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestValidateFeatureFlagPrerequisites(t *testing.T) {
+	flags := []domain.FeatureFlag{
+		{ID: 1, Key: "new_checkout", Type: domain.FeatureFlagTypeBoolean},
+		{ID: 2, Key: "new_checkout_v2", Type: domain.FeatureFlagTypeBoolean, Prerequisites: []domain.FeatureFlagPrerequisite{{PrerequisiteFlagID: 1}}},
+		{ID: 3, Key: "checkout_theme", Type: domain.FeatureFlagTypeString, Variants: []domain.FeatureFlagVariant{{Key: "control"}, {Key: "treatment"}}},
+	}
+
+	flag := &domain.FeatureFlag{Key: "promo", Prerequisites: []domain.FeatureFlagPrerequisite{
+		{PrerequisiteFlagID: 1},
+		{PrerequisiteFlagID: 3, Variant: " Treatment "},
+	}}
+	if err := validateFeatureFlagPrerequisites(flag, flags); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if flag.Prerequisites[0].Enabled == nil || !*flag.Prerequisites[0].Enabled || flag.Prerequisites[1].Variant != "treatment" {
+		t.Fatalf("expected normalized prerequisites, got %+v", flag.Prerequisites)
+	}
+
+	enabled := true
+	invalid := map[string][]domain.FeatureFlagPrerequisite{
+		"unknown flag":         {{PrerequisiteFlagID: 99}},
+		"duplicate":            {{PrerequisiteFlagID: 1}, {PrerequisiteFlagID: 1}},
+		"unknown variant":      {{PrerequisiteFlagID: 3, Variant: "missing"}},
+		"variant on boolean":   {{PrerequisiteFlagID: 1, Variant: "on"}},
+		"variant and enabled":  {{PrerequisiteFlagID: 3, Variant: "control", Enabled: &enabled}},
+		"requires itself (id)": {{PrerequisiteFlagID: 4}},
+	}
+	for name, prerequisites := range invalid {
+		candidate := &domain.FeatureFlag{ID: 4, Key: "promo", Prerequisites: prerequisites}
+		if err := validateFeatureFlagPrerequisites(candidate, flags); !errors.Is(err, ErrFeatureFlagInvalidPrerequisite) {
+			t.Fatalf("%s: expected ErrFeatureFlagInvalidPrerequisite, got %v", name, err)
+		}
+	}
+
+	cyclic := &domain.FeatureFlag{ID: 1, Key: "new_checkout", Prerequisites: []domain.FeatureFlagPrerequisite{{PrerequisiteFlagID: 2}}}
+	err := validateFeatureFlagPrerequisites(cyclic, flags)
+	if !errors.Is(err, ErrFeatureFlagPrerequisiteCycle) || !strings.Contains(err.Error(), "new_checkout -> new_checkout_v2 -> new_checkout") {
+		t.Fatalf("expected cycle through new_checkout_v2, got %v", err)
+	}
+}
+
+func TestFeatureFlagServicePrerequisiteEvaluation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockFeatureFlagRepository(ctrl)
+	svc := NewFeatureFlagService(repo, NewNoopFeatureFlagEvaluationCacheStore(), nil, nil)
+
+	off := false
+	parent := domain.FeatureFlag{ID: 1, Key: "new_checkout", Enabled: false, Rules: []domain.FeatureFlagRule{
+		{ID: 11, FeatureFlagID: 1, Type: FeatureFlagRuleTypeUser, MatchValue: "7", Enabled: true, Priority: 10},
+	}}
+	child := domain.FeatureFlag{ID: 2, Key: "new_checkout_v2", Enabled: true, Prerequisites: []domain.FeatureFlagPrerequisite{{FeatureFlagID: 2, PrerequisiteFlagID: 1}}}
+	legacy := domain.FeatureFlag{ID: 3, Key: "legacy_checkout", Enabled: true, Prerequisites: []domain.FeatureFlagPrerequisite{{FeatureFlagID: 3, PrerequisiteFlagID: 1, Enabled: &off}}}
+	repo.EXPECT().FindFlagByKey("new_checkout_v2").Return(&child, nil).Times(2)
+	repo.EXPECT().ListFlags().Return([]domain.FeatureFlag{parent, child, legacy}, nil).AnyTimes()
+
+	res, err := svc.EvaluateByKey(context.Background(), "new_checkout_v2", FeatureFlagEvaluationContext{UserID: 8})
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if res.Enabled || res.Source != "prerequisite:new_checkout" || string(res.Value) != "false" {
+		t.Fatalf("expected unmet prerequisite to switch the flag off, got %+v", res)
+	}
+	res, err = svc.EvaluateByKey(context.Background(), "new_checkout_v2", FeatureFlagEvaluationContext{UserID: 7})
+	if err != nil || !res.Enabled || res.Source != "default" {
+		t.Fatalf("expected met prerequisite to fall through to the flag, got %+v err=%v", res, err)
+	}
+
+	results, err := svc.EvaluateAll(context.Background(), FeatureFlagEvaluationContext{UserID: 8})
+	if err != nil {
+		t.Fatalf("evaluate all: %v", err)
+	}
+	for _, result := range results {
+		if result.Key == "legacy_checkout" && (!result.Enabled || result.Source != "default") {
+			t.Fatalf("expected enabled=false requirement to be met while new_checkout is off, got %+v", result)
+		}
+	}
+}
+
+func TestFeatureFlagServiceDeleteReportsDependents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockFeatureFlagRepository(ctrl)
+	svc := NewFeatureFlagService(repo, NewNoopFeatureFlagEvaluationCacheStore(), nil, nil)
+
+	flags := []domain.FeatureFlag{
+		{ID: 1, Key: "new_checkout"},
+		{ID: 2, Key: "new_checkout_v2", Prerequisites: []domain.FeatureFlagPrerequisite{{PrerequisiteFlagID: 1}}},
+		{ID: 3, Key: "express_pay", Prerequisites: []domain.FeatureFlagPrerequisite{{PrerequisiteFlagID: 1}}},
+	}
+	repo.EXPECT().FindFlagByID(uint(1)).Return(&flags[0], nil)
+	repo.EXPECT().DeleteFlag(uint(1), gomock.Any()).Return(repository.ErrFeatureFlagHasDependents)
+	repo.EXPECT().ListFlags().Return(flags, nil)
+
+	err := svc.DeleteFlag(context.Background(), 1)
+	var dependentsErr *FeatureFlagDependentsError
+	if !errors.Is(err, repository.ErrFeatureFlagHasDependents) || !errors.As(err, &dependentsErr) {
+		t.Fatalf("expected dependents error, got %v", err)
+	}
+	if len(dependentsErr.Dependents) != 2 || dependentsErr.Dependents[0] != "express_pay" || dependentsErr.Dependents[1] != "new_checkout_v2" {
+		t.Fatalf("unexpected dependents: %v", dependentsErr.Dependents)
+	}
+
+	graph := buildFeatureFlagDependencyGraph(flags)
+	if len(graph.Nodes) != 3 || len(graph.Edges) != 2 || graph.Edges[0].From != 2 || graph.Edges[0].To != 1 || graph.Nodes[0].Type != domain.FeatureFlagTypeBoolean {
+		t.Fatalf("unexpected graph: %+v", graph)
+	}
+}
+
+func TestFeatureFlagServiceRejectsRemovingRequiredVariant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockFeatureFlagRepository(ctrl)
+	svc := NewFeatureFlagService(repo, NewNoopFeatureFlagEvaluationCacheStore(), nil, nil)
+
+	theme := domain.FeatureFlag{ID: 3, Key: "checkout_theme", Type: domain.FeatureFlagTypeString, DefaultVariant: "control", Variants: []domain.FeatureFlagVariant{
+		{Key: "control", Value: []byte(`"blue"`)},
+		{Key: "treatment", Value: []byte(`"green"`)},
+	}}
+	promo := domain.FeatureFlag{ID: 4, Key: "theme_promo", Prerequisites: []domain.FeatureFlagPrerequisite{{PrerequisiteFlagID: 3, Variant: "treatment"}}}
+	repo.EXPECT().FindFlagByID(uint(3)).Return(&theme, nil)
+	repo.EXPECT().ListFlags().Return([]domain.FeatureFlag{theme, promo}, nil)
+
+	update := &domain.FeatureFlag{ID: 3, Key: "checkout_theme", Type: domain.FeatureFlagTypeString, DefaultVariant: "control", Variants: []domain.FeatureFlagVariant{
+		{Key: "control", Value: []byte(`"blue"`)},
+	}}
+	if err := svc.UpdateFlag(context.Background(), update); !errors.Is(err, ErrFeatureFlagInvalidVariant) || !strings.Contains(err.Error(), "theme_promo") {
+		t.Fatalf("expected required variant to block the update, got %v", err)
+	}
+}
+
+func TestFeatureFlagServiceRollbackRejectsRemovingRequiredVariant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockFeatureFlagRepository(ctrl)
+	svc := NewFeatureFlagService(repo, NewNoopFeatureFlagEvaluationCacheStore(), nil, nil)
+
+	theme := domain.FeatureFlag{ID: 3, Key: "checkout_theme", Type: domain.FeatureFlagTypeString, DefaultVariant: "control", Variants: []domain.FeatureFlagVariant{
+		{Key: "control", Value: []byte(`"blue"`)},
+		{Key: "treatment", Value: []byte(`"green"`)},
+	}}
+	promo := domain.FeatureFlag{ID: 4, Key: "theme_promo", Prerequisites: []domain.FeatureFlagPrerequisite{{PrerequisiteFlagID: 3, Variant: "treatment"}}}
+	old := domain.FeatureFlag{ID: 3, Key: "checkout_theme", Type: domain.FeatureFlagTypeString, DefaultVariant: "control", Variants: []domain.FeatureFlagVariant{
+		{Key: "control", Value: []byte(`"blue"`)},
+	}}
+	snapshot, _ := json.Marshal(old)
+	repo.EXPECT().FindVersion(uint(3), 1).Return(&domain.FeatureFlagVersion{FeatureFlagID: 3, Version: 1, Snapshot: snapshot}, nil)
+	repo.EXPECT().FindFlagByID(uint(3)).Return(&theme, nil)
+	repo.EXPECT().ListFlags().Return([]domain.FeatureFlag{theme, promo}, nil)
+
+	if _, err := svc.Rollback(context.Background(), 3, 1); !errors.Is(err, ErrFeatureFlagInvalidVariant) || !strings.Contains(err.Error(), "theme_promo") {
+		t.Fatalf("expected required variant to block the rollback, got %v", err)
+	}
+}
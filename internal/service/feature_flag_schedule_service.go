@@ -0,0 +1,337 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+)
+
+const (
+	maxFeatureFlagScheduleSteps = 20
+
+	// featureFlagScheduleClaimLease is how long a replica may hold a step in
+	// "applying" before another replica treats the claim as abandoned.
+	featureFlagScheduleClaimLease = 5 * time.Minute
+)
+
+var ErrFeatureFlagScheduleInvalid = errors.New("invalid feature flag schedule")
+
+type DefaultFeatureFlagScheduleService struct {
+	repo       repository.FeatureFlagScheduleRepository
+	flags      FeatureFlagService
+	guardrails FeatureFlagGuardrailSource
+	now        func() time.Time
+}
+
+func NewFeatureFlagScheduleService(repo repository.FeatureFlagScheduleRepository, flags FeatureFlagService, guardrails FeatureFlagGuardrailSource) *DefaultFeatureFlagScheduleService {
+	return &DefaultFeatureFlagScheduleService{repo: repo, flags: flags, guardrails: guardrails, now: time.Now}
+}
+
+// CreateSchedule validates the steps against the flag and stores the
+// schedule as active. Steps are renumbered in the order given.
+func (s *DefaultFeatureFlagScheduleService) CreateSchedule(ctx context.Context, schedule *domain.FeatureFlagSchedule) error {
+	flag, err := s.flags.GetFlagByID(ctx, schedule.FeatureFlagID)
+	if err != nil {
+		return err
+	}
+	if err := validateFeatureFlagSchedule(flag, schedule, s.now().UTC()); err != nil {
+		return err
+	}
+	schedule.ID = 0
+	schedule.Status = domain.FeatureFlagScheduleStatusActive
+	schedule.StatusReason = ""
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		schedule.CreatedBy = principal.UserID
+	}
+	for i := range schedule.Steps {
+		schedule.Steps[i].ID = 0
+		schedule.Steps[i].Position = i + 1
+		schedule.Steps[i].RunAt = schedule.Steps[i].RunAt.UTC()
+		schedule.Steps[i].Status = domain.FeatureFlagScheduleStepPending
+		schedule.Steps[i].ClaimedAt = nil
+		schedule.Steps[i].AppliedAt = nil
+		schedule.Steps[i].Error = ""
+	}
+	if err := s.repo.CreateSchedule(schedule); err != nil {
+		return err
+	}
+	setFeatureFlagScheduleNextRun(schedule)
+	return nil
+}
+
+// ListSchedules returns the flag's schedules newest first, with NextRunAt set
+// on active schedules that still have pending steps.
+func (s *DefaultFeatureFlagScheduleService) ListSchedules(ctx context.Context, flagID uint) ([]domain.FeatureFlagSchedule, error) {
+	schedules, err := s.repo.ListSchedules(flagID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range schedules {
+		setFeatureFlagScheduleNextRun(&schedules[i])
+	}
+	return schedules, nil
+}
+
+func (s *DefaultFeatureFlagScheduleService) CancelSchedule(ctx context.Context, flagID, scheduleID uint) (*domain.FeatureFlagSchedule, error) {
+	return s.transition(flagID, scheduleID, []string{domain.FeatureFlagScheduleStatusActive, domain.FeatureFlagScheduleStatusPaused}, domain.FeatureFlagScheduleStatusCancelled, "cancelled")
+}
+
+// ResumeSchedule reactivates a paused schedule. The guardrail is checked
+// again before the next step, so a metric still over its threshold pauses
+// the schedule again.
+func (s *DefaultFeatureFlagScheduleService) ResumeSchedule(ctx context.Context, flagID, scheduleID uint) (*domain.FeatureFlagSchedule, error) {
+	return s.transition(flagID, scheduleID, []string{domain.FeatureFlagScheduleStatusPaused}, domain.FeatureFlagScheduleStatusActive, "")
+}
+
+func (s *DefaultFeatureFlagScheduleService) transition(flagID, scheduleID uint, from []string, to, reason string) (*domain.FeatureFlagSchedule, error) {
+	schedule, err := s.repo.FindSchedule(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.FeatureFlagID != flagID {
+		return nil, repository.ErrFeatureFlagScheduleNotFound
+	}
+	if err := s.repo.TransitionSchedule(scheduleID, from, to, reason); err != nil {
+		return nil, err
+	}
+	schedule, err = s.repo.FindSchedule(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	setFeatureFlagScheduleNextRun(schedule)
+	return schedule, nil
+}
+
+// RunDue applies steps that are due at now, at most one per schedule per
+// call. Any number of replicas may run it: each step is claimed with a
+// conditional update before it is applied, and changes go through the flag
+// service so history, cache invalidation and change events stay consistent.
+func (s *DefaultFeatureFlagScheduleService) RunDue(ctx context.Context, now time.Time, batchSize int) (int, error) {
+	now = now.UTC()
+	staleBefore := now.Add(-featureFlagScheduleClaimLease)
+	steps, err := s.repo.ListDueSteps(now, staleBefore, batchSize)
+	if err != nil {
+		return 0, err
+	}
+	applied := 0
+	for i := range steps {
+		step := steps[i]
+		schedule, err := s.repo.FindSchedule(step.ScheduleID)
+		if err != nil {
+			return applied, err
+		}
+		if held, err := s.checkGuardrail(ctx, schedule); err != nil {
+			return applied, err
+		} else if held {
+			continue
+		}
+		claimed, err := s.repo.ClaimStep(step.ID, now, staleBefore)
+		if err != nil {
+			return applied, err
+		}
+		if !claimed {
+			continue
+		}
+		applyErr := s.applyStep(ctx, schedule, step)
+		failure := ""
+		if applyErr != nil {
+			failure = applyErr.Error()
+		}
+		if err := s.repo.FinishStep(&step, now, failure); err != nil {
+			return applied, err
+		}
+		if applyErr != nil {
+			observability.RecordFeatureFlagScheduleStep(ctx, "failed")
+			emitFeatureFlagScheduleAudit(ctx, "feature_flag.schedule.fail", "fail", "failure", "step_apply_failed", schedule, step)
+			continue
+		}
+		applied++
+		observability.RecordFeatureFlagScheduleStep(ctx, "applied")
+		emitFeatureFlagScheduleAudit(ctx, "feature_flag.schedule.apply", "apply", "success", "step_due", schedule, step)
+	}
+	return applied, nil
+}
+
+func (s *DefaultFeatureFlagScheduleService) RunLoop(ctx context.Context, interval time.Duration, batchSize int, logger *slog.Logger) {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			applied, err := s.RunDue(ctx, time.Now().UTC(), batchSize)
+			if err != nil {
+				if logger != nil {
+					logger.Warn("feature flag scheduler failed", "error", err)
+				}
+				continue
+			}
+			if applied > 0 && logger != nil {
+				logger.Info("feature flag scheduler applied steps", "applied", applied)
+			}
+		}
+	}
+}
+
+// checkGuardrail reports whether the schedule's next step must wait. It
+// pauses the schedule when the guardrail metric is over the threshold or
+// cannot be read. Too little data holds the step without pausing, so it is
+// retried once enough requests have been observed.
+func (s *DefaultFeatureFlagScheduleService) checkGuardrail(ctx context.Context, schedule *domain.FeatureFlagSchedule) (bool, error) {
+	if schedule.GuardrailMetric == "" {
+		return false, nil
+	}
+	reason := ""
+	if s.guardrails == nil {
+		reason = "guardrail " + schedule.GuardrailMetric + " unavailable"
+	} else {
+		value, ok, err := s.guardrails.GuardrailValue(ctx, schedule.GuardrailMetric)
+		switch {
+		case err != nil:
+			reason = "guardrail " + schedule.GuardrailMetric + " unavailable: " + err.Error()
+		case !ok:
+			observability.RecordFeatureFlagScheduleStep(ctx, "held")
+			return true, nil
+		case value > schedule.GuardrailThreshold:
+			reason = fmt.Sprintf("guardrail %s=%.4f exceeded %.4f", schedule.GuardrailMetric, value, schedule.GuardrailThreshold)
+		}
+	}
+	if reason == "" {
+		return false, nil
+	}
+	err := s.repo.TransitionSchedule(schedule.ID, []string{domain.FeatureFlagScheduleStatusActive}, domain.FeatureFlagScheduleStatusPaused, reason)
+	if errors.Is(err, repository.ErrFeatureFlagScheduleStatusConflict) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	observability.RecordFeatureFlagScheduleStep(ctx, "paused")
+	observability.EmitSystemAudit(ctx, observability.AuditInput{
+		EventName:  "feature_flag.schedule.pause",
+		TargetType: "feature_flag_schedule",
+		TargetID:   strconv.FormatUint(uint64(schedule.ID), 10),
+		Action:     "pause",
+		Outcome:    "success",
+		Reason:     "guardrail_exceeded",
+	}, "feature_flag_id", schedule.FeatureFlagID, "guardrail", schedule.GuardrailMetric, "detail", reason)
+	return true, nil
+}
+
+func (s *DefaultFeatureFlagScheduleService) applyStep(ctx context.Context, schedule *domain.FeatureFlagSchedule, step domain.FeatureFlagScheduleStep) error {
+	flag, err := s.flags.GetFlagByID(ctx, schedule.FeatureFlagID)
+	if err != nil {
+		return err
+	}
+	if schedule.RuleID == 0 {
+		if step.Enabled == nil {
+			return ErrFeatureFlagScheduleInvalid
+		}
+		flag.Enabled = *step.Enabled
+		return s.flags.UpdateFlag(ctx, flag)
+	}
+	if step.Percentage == nil {
+		return ErrFeatureFlagScheduleInvalid
+	}
+	for _, rule := range flag.Rules {
+		if rule.ID == schedule.RuleID {
+			rule.Percentage = *step.Percentage
+			return s.flags.UpdateRule(ctx, &rule)
+		}
+	}
+	return repository.ErrFeatureFlagRuleNotFound
+}
+
+func validateFeatureFlagSchedule(flag *domain.FeatureFlag, schedule *domain.FeatureFlagSchedule, now time.Time) error {
+	if len(schedule.Steps) == 0 || len(schedule.Steps) > maxFeatureFlagScheduleSteps {
+		return fmt.Errorf("%w: between 1 and %d steps are required", ErrFeatureFlagScheduleInvalid, maxFeatureFlagScheduleSteps)
+	}
+	if schedule.RuleID != 0 {
+		found := false
+		for _, rule := range flag.Rules {
+			if rule.ID == schedule.RuleID {
+				found = rule.Type == FeatureFlagRuleTypePercent
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: rule_id must reference a percent rule of this flag", ErrFeatureFlagScheduleInvalid)
+		}
+	}
+	var previous time.Time
+	for i, step := range schedule.Steps {
+		if !step.RunAt.After(now) {
+			return fmt.Errorf("%w: step %d run_at must be in the future", ErrFeatureFlagScheduleInvalid, i+1)
+		}
+		if i > 0 && !step.RunAt.After(previous) {
+			return fmt.Errorf("%w: step %d run_at must be after the previous step", ErrFeatureFlagScheduleInvalid, i+1)
+		}
+		previous = step.RunAt
+		if schedule.RuleID == 0 {
+			if step.Enabled == nil || step.Percentage != nil {
+				return fmt.Errorf("%w: step %d must set enabled only", ErrFeatureFlagScheduleInvalid, i+1)
+			}
+			continue
+		}
+		if step.Percentage == nil || step.Enabled != nil || *step.Percentage < 0 || *step.Percentage > 100 {
+			return fmt.Errorf("%w: step %d must set percentage between 0 and 100 only", ErrFeatureFlagScheduleInvalid, i+1)
+		}
+	}
+	if schedule.GuardrailMetric == "" {
+		if schedule.GuardrailThreshold != 0 {
+			return fmt.Errorf("%w: guardrail_threshold requires guardrail_metric", ErrFeatureFlagScheduleInvalid)
+		}
+		return nil
+	}
+	if schedule.GuardrailMetric != FeatureFlagGuardrailHTTPErrorRate {
+		return fmt.Errorf("%w: guardrail_metric must be %s", ErrFeatureFlagScheduleInvalid, FeatureFlagGuardrailHTTPErrorRate)
+	}
+	if schedule.GuardrailThreshold <= 0 || schedule.GuardrailThreshold > 1 {
+		return fmt.Errorf("%w: guardrail_threshold must be greater than 0 and at most 1", ErrFeatureFlagScheduleInvalid)
+	}
+	return nil
+}
+
+func setFeatureFlagScheduleNextRun(schedule *domain.FeatureFlagSchedule) {
+	schedule.NextRunAt = nil
+	if schedule.Status != domain.FeatureFlagScheduleStatusActive {
+		return
+	}
+	for _, step := range schedule.Steps {
+		if step.Status != domain.FeatureFlagScheduleStepApplied {
+			runAt := step.RunAt
+			schedule.NextRunAt = &runAt
+			return
+		}
+	}
+}
+
+func emitFeatureFlagScheduleAudit(ctx context.Context, eventName, action, outcome, reason string, schedule *domain.FeatureFlagSchedule, step domain.FeatureFlagScheduleStep) {
+	kv := []any{"feature_flag_id", schedule.FeatureFlagID, "step", step.Position}
+	if step.Enabled != nil {
+		kv = append(kv, "enabled", *step.Enabled)
+	}
+	if step.Percentage != nil {
+		kv = append(kv, "rule_id", schedule.RuleID, "percentage", *step.Percentage)
+	}
+	observability.EmitSystemAudit(ctx, observability.AuditInput{
+		EventName:  eventName,
+		TargetType: "feature_flag_schedule",
+		TargetID:   strconv.FormatUint(uint64(schedule.ID), 10),
+		Action:     action,
+		Outcome:    outcome,
+		Reason:     reason,
+	}, kv...)
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type fakeFeatureFlagGuardrail struct {
+	value float64
+	ok    bool
+	err   error
+}
+
+func (f *fakeFeatureFlagGuardrail) GuardrailValue(context.Context, string) (float64, bool, error) {
+	return f.value, f.ok, f.err
+}
+
+func TestFeatureFlagScheduleServiceRampsPercentRule(t *testing.T) {
+	svc, flags, scheduleRepo := newFeatureFlagScheduleServiceForTest(t, nil)
+	ctx := context.Background()
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return base }
+
+	flag := &domain.FeatureFlag{Key: "new_checkout", Type: domain.FeatureFlagTypeBoolean, Enabled: true}
+	if err := flags.CreateFlag(ctx, flag); err != nil {
+		t.Fatalf("create flag: %v", err)
+	}
+	rule := &domain.FeatureFlagRule{FeatureFlagID: flag.ID, Type: FeatureFlagRuleTypePercent, Percentage: 5, Enabled: true}
+	if err := flags.CreateRule(ctx, rule); err != nil {
+		t.Fatalf("create rule: %v", err)
+	}
+
+	schedule := &domain.FeatureFlagSchedule{
+		FeatureFlagID: flag.ID,
+		RuleID:        rule.ID,
+		Steps: []domain.FeatureFlagScheduleStep{
+			{RunAt: base.Add(time.Hour), Percentage: intPtr(25)},
+			{RunAt: base.Add(2 * time.Hour), Percentage: intPtr(100)},
+		},
+	}
+	if err := svc.CreateSchedule(ctx, schedule); err != nil {
+		t.Fatalf("create schedule: %v", err)
+	}
+	if schedule.NextRunAt == nil || !schedule.NextRunAt.Equal(base.Add(time.Hour)) {
+		t.Fatalf("expected next run at first step, got %v", schedule.NextRunAt)
+	}
+
+	if applied, err := svc.RunDue(ctx, base.Add(30*time.Minute), 10); err != nil || applied != 0 {
+		t.Fatalf("expected nothing due yet, applied=%d err=%v", applied, err)
+	}
+
+	// Another replica holds a live claim on the first step, so this replica
+	// must leave it alone until the lease runs out.
+	due, err := scheduleRepo.ListDueSteps(base.Add(time.Hour), base, 10)
+	if err != nil || len(due) != 1 {
+		t.Fatalf("list due: %+v err=%v", due, err)
+	}
+	if claimed, err := scheduleRepo.ClaimStep(due[0].ID, base.Add(time.Hour), base); err != nil || !claimed {
+		t.Fatalf("claim: claimed=%v err=%v", claimed, err)
+	}
+	if applied, err := svc.RunDue(ctx, base.Add(time.Hour+time.Minute), 10); err != nil || applied != 0 {
+		t.Fatalf("expected claimed step to be skipped, applied=%d err=%v", applied, err)
+	}
+	if applied, err := svc.RunDue(ctx, base.Add(time.Hour+featureFlagScheduleClaimLease), 10); err != nil || applied != 1 {
+		t.Fatalf("expected abandoned step to be applied, applied=%d err=%v", applied, err)
+	}
+	assertFeatureFlagRulePercentage(t, flags, flag.ID, rule.ID, 25)
+
+	// The second step is due too, but only one step per schedule runs per tick.
+	if applied, err := svc.RunDue(ctx, base.Add(3*time.Hour), 10); err != nil || applied != 1 {
+		t.Fatalf("expected second step applied, applied=%d err=%v", applied, err)
+	}
+	assertFeatureFlagRulePercentage(t, flags, flag.ID, rule.ID, 100)
+
+	schedules, err := svc.ListSchedules(ctx, flag.ID)
+	if err != nil || len(schedules) != 1 {
+		t.Fatalf("list schedules: %+v err=%v", schedules, err)
+	}
+	if schedules[0].Status != domain.FeatureFlagScheduleStatusCompleted || schedules[0].NextRunAt != nil {
+		t.Fatalf("expected completed schedule, got %+v", schedules[0])
+	}
+	history, err := flags.ListHistory(ctx, flag.ID, 0)
+	if err != nil {
+		t.Fatalf("list history: %v", err)
+	}
+	if len(history) != 4 {
+		t.Fatalf("expected scheduled changes in flag history, got %d versions", len(history))
+	}
+}
+
+func TestFeatureFlagScheduleServiceGuardrailPausesUntilResumed(t *testing.T) {
+	guardrail := &fakeFeatureFlagGuardrail{value: 0.2, ok: true}
+	svc, flags, _ := newFeatureFlagScheduleServiceForTest(t, guardrail)
+	ctx := context.Background()
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return base }
+
+	flag := &domain.FeatureFlag{Key: "risky", Type: domain.FeatureFlagTypeBoolean}
+	if err := flags.CreateFlag(ctx, flag); err != nil {
+		t.Fatalf("create flag: %v", err)
+	}
+	enabled := true
+	schedule := &domain.FeatureFlagSchedule{
+		FeatureFlagID:      flag.ID,
+		GuardrailMetric:    FeatureFlagGuardrailHTTPErrorRate,
+		GuardrailThreshold: 0.05,
+		Steps:              []domain.FeatureFlagScheduleStep{{RunAt: base.Add(time.Minute), Enabled: &enabled}},
+	}
+	if err := svc.CreateSchedule(ctx, schedule); err != nil {
+		t.Fatalf("create schedule: %v", err)
+	}
+
+	if applied, err := svc.RunDue(ctx, base.Add(time.Hour), 10); err != nil || applied != 0 {
+		t.Fatalf("expected guardrail to block the step, applied=%d err=%v", applied, err)
+	}
+	schedules, _ := svc.ListSchedules(ctx, flag.ID)
+	if schedules[0].Status != domain.FeatureFlagScheduleStatusPaused || !strings.Contains(schedules[0].StatusReason, "exceeded") {
+		t.Fatalf("expected paused schedule, got %+v", schedules[0])
+	}
+	if _, err := svc.ResumeSchedule(ctx, flag.ID+1, schedule.ID); !errors.Is(err, repository.ErrFeatureFlagScheduleNotFound) {
+		t.Fatalf("expected schedule of another flag to be not found, got %v", err)
+	}
+
+	guardrail.value = 0.01
+	resumed, err := svc.ResumeSchedule(ctx, flag.ID, schedule.ID)
+	if err != nil || resumed.Status != domain.FeatureFlagScheduleStatusActive {
+		t.Fatalf("resume: %+v err=%v", resumed, err)
+	}
+	if _, err := svc.ResumeSchedule(ctx, flag.ID, schedule.ID); !errors.Is(err, repository.ErrFeatureFlagScheduleStatusConflict) {
+		t.Fatalf("expected conflict resuming an active schedule, got %v", err)
+	}
+
+	guardrail.ok = false
+	if applied, err := svc.RunDue(ctx, base.Add(time.Hour), 10); err != nil || applied != 0 {
+		t.Fatalf("expected too little guardrail data to hold the step, applied=%d err=%v", applied, err)
+	}
+	schedules, _ = svc.ListSchedules(ctx, flag.ID)
+	if schedules[0].Status != domain.FeatureFlagScheduleStatusActive {
+		t.Fatalf("expected held schedule to stay active, got %+v", schedules[0])
+	}
+
+	guardrail.ok = true
+	if applied, err := svc.RunDue(ctx, base.Add(time.Hour), 10); err != nil || applied != 1 {
+		t.Fatalf("expected step applied after resume, applied=%d err=%v", applied, err)
+	}
+	loaded, err := flags.GetFlagByID(ctx, flag.ID)
+	if err != nil || !loaded.Enabled {
+		t.Fatalf("expected flag enabled, got %+v err=%v", loaded, err)
+	}
+	if _, err := svc.CancelSchedule(ctx, flag.ID, schedule.ID); !errors.Is(err, repository.ErrFeatureFlagScheduleStatusConflict) {
+		t.Fatalf("expected conflict cancelling a completed schedule, got %v", err)
+	}
+}
+
+func TestFeatureFlagScheduleServiceRejectsInvalidSchedules(t *testing.T) {
+	svc, flags, _ := newFeatureFlagScheduleServiceForTest(t, nil)
+	ctx := context.Background()
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return base }
+
+	flag := &domain.FeatureFlag{Key: "validated", Type: domain.FeatureFlagTypeBoolean}
+	if err := flags.CreateFlag(ctx, flag); err != nil {
+		t.Fatalf("create flag: %v", err)
+	}
+	userRule := &domain.FeatureFlagRule{FeatureFlagID: flag.ID, Type: FeatureFlagRuleTypeUser, MatchValue: "7", Enabled: true}
+	if err := flags.CreateRule(ctx, userRule); err != nil {
+		t.Fatalf("create rule: %v", err)
+	}
+	enabled := true
+
+	cases := map[string]*domain.FeatureFlagSchedule{
+		"no steps":                {},
+		"past run_at":             {Steps: []domain.FeatureFlagScheduleStep{{RunAt: base.Add(-time.Minute), Enabled: &enabled}}},
+		"out of order":            {Steps: []domain.FeatureFlagScheduleStep{{RunAt: base.Add(2 * time.Hour), Enabled: &enabled}, {RunAt: base.Add(time.Hour), Enabled: &enabled}}},
+		"percentage without rule": {Steps: []domain.FeatureFlagScheduleStep{{RunAt: base.Add(time.Hour), Percentage: intPtr(50)}}},
+		"non-percent rule":        {RuleID: userRule.ID, Steps: []domain.FeatureFlagScheduleStep{{RunAt: base.Add(time.Hour), Percentage: intPtr(50)}}},
+		"unknown guardrail":       {GuardrailMetric: "latency", GuardrailThreshold: 0.5, Steps: []domain.FeatureFlagScheduleStep{{RunAt: base.Add(time.Hour), Enabled: &enabled}}},
+		"threshold out of range":  {GuardrailMetric: FeatureFlagGuardrailHTTPErrorRate, GuardrailThreshold: 2, Steps: []domain.FeatureFlagScheduleStep{{RunAt: base.Add(time.Hour), Enabled: &enabled}}},
+	}
+	for name, schedule := range cases {
+		schedule.FeatureFlagID = flag.ID
+		if err := svc.CreateSchedule(ctx, schedule); !errors.Is(err, ErrFeatureFlagScheduleInvalid) {
+			t.Fatalf("%s: expected ErrFeatureFlagScheduleInvalid, got %v", name, err)
+		}
+	}
+	missing := &domain.FeatureFlagSchedule{FeatureFlagID: 999, Steps: []domain.FeatureFlagScheduleStep{{RunAt: base.Add(time.Hour), Enabled: &enabled}}}
+	if err := svc.CreateSchedule(ctx, missing); !errors.Is(err, repository.ErrFeatureFlagNotFound) {
+		t.Fatalf("expected ErrFeatureFlagNotFound, got %v", err)
+	}
+}
+
+func TestHTTPErrorRateTrackerSlidingWindow(t *testing.T) {
+	tracker := NewHTTPErrorRateTracker(time.Minute, 4)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	tracker.RecordStatus(200)
+	tracker.RecordStatus(500)
+	tracker.RecordStatus(503)
+	if _, ok, err := tracker.GuardrailValue(ctx, FeatureFlagGuardrailHTTPErrorRate); err != nil || ok {
+		t.Fatalf("expected too few requests to report no value, ok=%v err=%v", ok, err)
+	}
+	tracker.RecordStatus(404)
+	value, ok, err := tracker.GuardrailValue(ctx, FeatureFlagGuardrailHTTPErrorRate)
+	if err != nil || !ok || value != 0.5 {
+		t.Fatalf("expected error rate 0.5, got %v ok=%v err=%v", value, ok, err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok, _ := tracker.GuardrailValue(ctx, FeatureFlagGuardrailHTTPErrorRate); ok {
+		t.Fatal("expected responses outside the window to be dropped")
+	}
+	if _, _, err := tracker.GuardrailValue(ctx, "latency"); !errors.Is(err, ErrFeatureFlagUnknownGuardrail) {
+		t.Fatalf("expected ErrFeatureFlagUnknownGuardrail, got %v", err)
+	}
+}
+
+func newFeatureFlagScheduleServiceForTest(t *testing.T, guardrails FeatureFlagGuardrailSource) (*DefaultFeatureFlagScheduleService, *DefaultFeatureFlagService, repository.FeatureFlagScheduleRepository) {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&domain.FeatureFlag{},
+		&domain.FeatureFlagRule{},
+		&domain.FeatureFlagVariant{},
+		&domain.FeatureFlagPrerequisite{},
+		&domain.FeatureFlagVersion{},
+		&domain.FeatureFlagSchedule{},
+		&domain.FeatureFlagScheduleStep{},
+	); err != nil {
+		t.Fatalf("migrate feature flags: %v", err)
+	}
+	flags := NewFeatureFlagService(repository.NewFeatureFlagRepository(db), NewNoopFeatureFlagEvaluationCacheStore(), nil, nil)
+	scheduleRepo := repository.NewFeatureFlagScheduleRepository(db)
+	return NewFeatureFlagScheduleService(scheduleRepo, flags, guardrails), flags, scheduleRepo
+}
+
+func assertFeatureFlagRulePercentage(t *testing.T, flags *DefaultFeatureFlagService, flagID, ruleID uint, want int) {
+	t.Helper()
+	rules, err := flags.ListRules(context.Background(), flagID)
+	if err != nil {
+		t.Fatalf("list rules: %v", err)
+	}
+	for _, rule := range rules {
+		if rule.ID == ruleID {
+			if rule.Percentage != want {
+				t.Fatalf("expected rule percentage %d, got %d", want, rule.Percentage)
+			}
+			return
+		}
+	}
+	t.Fatalf("rule %d not found", ruleID)
+}
+
+func intPtr(v int) *int { return &v }
@@ -4,9 +4,11 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -23,25 +25,48 @@ const (
 	FeatureFlagRuleTypeOrg         = "org"
 	FeatureFlagRuleTypeEnvironment = "environment"
 	FeatureFlagRuleTypePercent     = "percent"
+	FeatureFlagRuleTypeAttribute   = "attribute"
 )
 
 var (
 	ErrFeatureFlagInvalidRuleType  = errors.New("invalid feature flag rule type")
 	ErrFeatureFlagInvalidRuleValue = errors.New("invalid feature flag rule value")
+	ErrFeatureFlagInvalidType      = errors.New("feature flag type must be boolean, string, number or json")
+	ErrFeatureFlagInvalidVariant   = errors.New("invalid feature flag variant")
 )
 
+const (
+	defaultFeatureFlagHistoryLimit = 50
+	maxFeatureFlagHistoryLimit     = 200
+)
+
+var featureFlagVariantKeyRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_\-]{0,63}$`)
+
 type FeatureFlagEvaluationContext struct {
 	UserID      uint
 	Roles       []string
 	Org         string
 	Environment string
+	// Attributes carries custom targeting attributes such as plan, country
+	// or app_version. Keys are lower-case.
+	Attributes map[string]string
 }
 
 type FeatureFlagEvaluationResult struct {
-	Key         string `json:"key"`
-	Enabled     bool   `json:"enabled"`
-	Source      string `json:"source"`
-	Description string `json:"description"`
+	Key         string          `json:"key"`
+	Enabled     bool            `json:"enabled"`
+	Source      string          `json:"source"`
+	Description string          `json:"description"`
+	Type        string          `json:"type"`
+	Variant     string          `json:"variant,omitempty"`
+	Value       json.RawMessage `json:"value"`
+}
+
+// FeatureFlagSnapshot is the full flag, variant and rule set used by SDKs for
+// local evaluation. Version changes whenever any definition changes.
+type FeatureFlagSnapshot struct {
+	Version string               `json:"version"`
+	Flags   []domain.FeatureFlag `json:"flags"`
 }
 
 type featureFlagCachedPayload struct {
@@ -49,23 +74,89 @@ type featureFlagCachedPayload struct {
 }
 
 type DefaultFeatureFlagService struct {
-	repo     repository.FeatureFlagRepository
-	cache    FeatureFlagEvaluationCacheStore
-	cacheTTL time.Duration
+	repo      repository.FeatureFlagRepository
+	cache     FeatureFlagEvaluationCacheStore
+	changes   FeatureFlagChangeBroker
+	exposures FeatureFlagExposureRecorder
+	cacheTTL  time.Duration
 }
 
-func NewFeatureFlagService(repo repository.FeatureFlagRepository, cache FeatureFlagEvaluationCacheStore) *DefaultFeatureFlagService {
+func NewFeatureFlagService(
+	repo repository.FeatureFlagRepository,
+	cache FeatureFlagEvaluationCacheStore,
+	changes FeatureFlagChangeBroker,
+	exposures FeatureFlagExposureRecorder,
+) *DefaultFeatureFlagService {
 	if cache == nil {
 		cache = NewNoopFeatureFlagEvaluationCacheStore()
 	}
-	return &DefaultFeatureFlagService{repo: repo, cache: cache, cacheTTL: 30 * time.Second}
+	if changes == nil {
+		changes = NewInProcessFeatureFlagChangeBroker()
+	}
+	if exposures == nil {
+		exposures = NewNoopFeatureFlagExposureRecorder()
+	}
+	return &DefaultFeatureFlagService{repo: repo, cache: cache, changes: changes, exposures: exposures, cacheTTL: 30 * time.Second}
+}
+
+// SubscribeChanges opens a feed of committed flag and rule changes, replaying
+// events after lastEventID when they are still retained.
+func (s *DefaultFeatureFlagService) SubscribeChanges(ctx context.Context, lastEventID string) (*FeatureFlagChangeSubscription, error) {
+	return s.changes.Subscribe(ctx, lastEventID)
 }
 
 func (s *DefaultFeatureFlagService) EvaluateAll(ctx context.Context, evalCtx FeatureFlagEvaluationContext) ([]FeatureFlagEvaluationResult, error) {
+	results, err := s.evaluateAll(ctx, "all", evalCtx)
+	if err != nil {
+		return nil, err
+	}
+	s.exposures.Record(ctx, evalCtx, results)
+	return results, nil
+}
+
+// EvaluateAllUnrecorded evaluates every flag like EvaluateAll without
+// recording exposures, for callers that may not deliver the values to the
+// user. Pair it with RecordExposures once they are delivered.
+func (s *DefaultFeatureFlagService) EvaluateAllUnrecorded(ctx context.Context, evalCtx FeatureFlagEvaluationContext) ([]FeatureFlagEvaluationResult, error) {
+	return s.evaluateAll(ctx, "all", evalCtx)
+}
+
+// RecordExposures records that results were served for evalCtx.
+func (s *DefaultFeatureFlagService) RecordExposures(ctx context.Context, evalCtx FeatureFlagEvaluationContext, results []FeatureFlagEvaluationResult) {
+	s.exposures.Record(ctx, evalCtx, results)
+}
+
+// EvaluateKeys evaluates the given keys for an explicit context, sharing the
+// per-context evaluation cache with EvaluateAll. Unknown keys are omitted from
+// the result; an empty key list returns every flag.
+func (s *DefaultFeatureFlagService) EvaluateKeys(ctx context.Context, keys []string, evalCtx FeatureFlagEvaluationContext) ([]FeatureFlagEvaluationResult, error) {
+	results, err := s.evaluateAll(ctx, "bulk", evalCtx)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		s.exposures.Record(ctx, evalCtx, results)
+		return results, nil
+	}
+	wanted := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		wanted[strings.TrimSpace(strings.ToLower(key))] = struct{}{}
+	}
+	filtered := make([]FeatureFlagEvaluationResult, 0, len(wanted))
+	for _, result := range results {
+		if _, ok := wanted[result.Key]; ok {
+			filtered = append(filtered, result)
+		}
+	}
+	s.exposures.Record(ctx, evalCtx, filtered)
+	return filtered, nil
+}
+
+func (s *DefaultFeatureFlagService) evaluateAll(ctx context.Context, scope string, evalCtx FeatureFlagEvaluationContext) ([]FeatureFlagEvaluationResult, error) {
 	start := time.Now()
 	status := "success"
 	defer func() {
-		observability.RecordFeatureFlagEvaluation(ctx, "all", status, time.Since(start))
+		observability.RecordFeatureFlagEvaluation(ctx, scope, status, time.Since(start))
 	}()
 
 	cacheKey := buildFeatureFlagCacheKey(evalCtx)
@@ -81,15 +172,10 @@ func (s *DefaultFeatureFlagService) EvaluateAll(ctx context.Context, evalCtx Fea
 		return nil, err
 	}
 
+	evaluator := newFeatureFlagEvaluator(flags, evalCtx)
 	results := make([]FeatureFlagEvaluationResult, 0, len(flags))
 	for _, flag := range flags {
-		enabled, source := evaluateFeatureFlag(flag, evalCtx)
-		results = append(results, FeatureFlagEvaluationResult{
-			Key:         flag.Key,
-			Enabled:     enabled,
-			Source:      source,
-			Description: flag.Description,
-		})
+		results = append(results, evaluator.evaluate(flag))
 	}
 	sort.Slice(results, func(i, j int) bool { return results[i].Key < results[j].Key })
 	_ = s.writeCachedEvaluation(ctx, cacheKey, featureFlagCachedPayload{Values: results})
@@ -108,19 +194,51 @@ func (s *DefaultFeatureFlagService) EvaluateByKey(ctx context.Context, key strin
 		status = "error"
 		return nil, err
 	}
-	enabled, source := evaluateFeatureFlag(*flag, evalCtx)
-	return &FeatureFlagEvaluationResult{
-		Key:         flag.Key,
-		Enabled:     enabled,
-		Source:      source,
-		Description: flag.Description,
-	}, nil
+	flags := []domain.FeatureFlag{*flag}
+	if len(flag.Prerequisites) > 0 {
+		// Prerequisites may chain through any flag, so resolve them against
+		// the full set.
+		if flags, err = s.repo.ListFlags(); err != nil {
+			status = "error"
+			return nil, err
+		}
+	}
+	result := newFeatureFlagEvaluator(flags, evalCtx).evaluate(*flag)
+	s.exposures.Record(ctx, evalCtx, []FeatureFlagEvaluationResult{result})
+	return &result, nil
 }
 
 func (s *DefaultFeatureFlagService) ListFlags(ctx context.Context) ([]domain.FeatureFlag, error) {
 	return s.repo.ListFlags()
 }
 
+// Snapshot returns every flag definition with a content-derived version that
+// callers use as an ETag.
+func (s *DefaultFeatureFlagService) Snapshot(ctx context.Context) (*FeatureFlagSnapshot, error) {
+	flags, err := s.repo.ListFlags()
+	if err != nil {
+		return nil, err
+	}
+	if flags == nil {
+		flags = []domain.FeatureFlag{}
+	}
+	encoded, err := json.Marshal(flags)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(encoded)
+	return &FeatureFlagSnapshot{Version: hex.EncodeToString(sum[:16]), Flags: flags}, nil
+}
+
+// DependencyGraph returns every flag with its prerequisite edges.
+func (s *DefaultFeatureFlagService) DependencyGraph(ctx context.Context) (*FeatureFlagDependencyGraph, error) {
+	flags, err := s.repo.ListFlags()
+	if err != nil {
+		return nil, err
+	}
+	return buildFeatureFlagDependencyGraph(flags), nil
+}
+
 func (s *DefaultFeatureFlagService) GetFlagByID(ctx context.Context, id uint) (*domain.FeatureFlag, error) {
 	return s.repo.FindFlagByID(id)
 }
@@ -130,10 +248,16 @@ func (s *DefaultFeatureFlagService) CreateFlag(ctx context.Context, flag *domain
 	if flag.Key == "" {
 		return ErrFeatureFlagInvalidRuleValue
 	}
-	if err := s.repo.CreateFlag(flag); err != nil {
+	if err := normalizeAndValidateVariants(flag); err != nil {
 		return err
 	}
-	return s.cache.InvalidateAll(ctx)
+	if err := s.validatePrerequisites(flag); err != nil {
+		return err
+	}
+	if err := s.repo.CreateFlag(flag, s.versionFunc(ctx, FeatureFlagChangeFlagCreated, nil)); err != nil {
+		return err
+	}
+	return s.notifyChange(ctx, FeatureFlagChangeEvent{Type: FeatureFlagChangeFlagCreated, FlagID: flag.ID, FlagKey: flag.Key})
 }
 
 func (s *DefaultFeatureFlagService) UpdateFlag(ctx context.Context, flag *domain.FeatureFlag) error {
@@ -141,17 +265,42 @@ func (s *DefaultFeatureFlagService) UpdateFlag(ctx context.Context, flag *domain
 	if flag.Key == "" {
 		return ErrFeatureFlagInvalidRuleValue
 	}
-	if err := s.repo.UpdateFlag(flag); err != nil {
+	if err := normalizeAndValidateVariants(flag); err != nil {
+		return err
+	}
+	before, err := s.repo.FindFlagByID(flag.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.validatePrerequisites(flag); err != nil {
+		return err
+	}
+	if err := checkRuleVariants(before.Rules, flag); err != nil {
+		return err
+	}
+	if err := s.checkRemovedVariants(before, flag); err != nil {
+		return err
+	}
+	if err := s.repo.UpdateFlag(flag, s.versionFunc(ctx, FeatureFlagChangeFlagUpdated, before)); err != nil {
 		return err
 	}
-	return s.cache.InvalidateAll(ctx)
+	return s.notifyChange(ctx, FeatureFlagChangeEvent{Type: FeatureFlagChangeFlagUpdated, FlagID: flag.ID, FlagKey: flag.Key})
 }
 
 func (s *DefaultFeatureFlagService) DeleteFlag(ctx context.Context, id uint) error {
-	if err := s.repo.DeleteFlag(id); err != nil {
+	before, err := s.repo.FindFlagByID(id)
+	if err != nil {
 		return err
 	}
-	return s.cache.InvalidateAll(ctx)
+	if err := s.repo.DeleteFlag(id, s.versionFunc(ctx, FeatureFlagChangeFlagDeleted, before)); err != nil {
+		if errors.Is(err, repository.ErrFeatureFlagHasDependents) {
+			if flags, listErr := s.repo.ListFlags(); listErr == nil {
+				return &FeatureFlagDependentsError{Dependents: dependentFeatureFlagKeys(id, flags)}
+			}
+		}
+		return err
+	}
+	return s.notifyChange(ctx, FeatureFlagChangeEvent{Type: FeatureFlagChangeFlagDeleted, FlagID: id, FlagKey: before.Key})
 }
 
 func (s *DefaultFeatureFlagService) ListRules(ctx context.Context, flagID uint) ([]domain.FeatureFlagRule, error) {
@@ -162,30 +311,228 @@ func (s *DefaultFeatureFlagService) CreateRule(ctx context.Context, rule *domain
 	if err := normalizeAndValidateRule(rule); err != nil {
 		return err
 	}
-	if err := s.repo.CreateRule(rule); err != nil {
+	before, err := s.repo.FindFlagByID(rule.FeatureFlagID)
+	if err != nil {
 		return err
 	}
-	return s.cache.InvalidateAll(ctx)
+	if err := validateRuleVariant(before, rule); err != nil {
+		return err
+	}
+	if err := s.repo.CreateRule(rule, s.versionFunc(ctx, FeatureFlagChangeRuleCreated, before)); err != nil {
+		return err
+	}
+	return s.notifyChange(ctx, FeatureFlagChangeEvent{Type: FeatureFlagChangeRuleCreated, FlagID: rule.FeatureFlagID, FlagKey: before.Key, RuleID: rule.ID})
 }
 
 func (s *DefaultFeatureFlagService) UpdateRule(ctx context.Context, rule *domain.FeatureFlagRule) error {
 	if err := normalizeAndValidateRule(rule); err != nil {
 		return err
 	}
-	if err := s.repo.UpdateRule(rule); err != nil {
+	before, err := s.repo.FindFlagByID(rule.FeatureFlagID)
+	if err != nil {
 		return err
 	}
-	return s.cache.InvalidateAll(ctx)
+	if err := validateRuleVariant(before, rule); err != nil {
+		return err
+	}
+	if err := s.repo.UpdateRule(rule, s.versionFunc(ctx, FeatureFlagChangeRuleUpdated, before)); err != nil {
+		return err
+	}
+	return s.notifyChange(ctx, FeatureFlagChangeEvent{Type: FeatureFlagChangeRuleUpdated, FlagID: rule.FeatureFlagID, FlagKey: before.Key, RuleID: rule.ID})
 }
 
 func (s *DefaultFeatureFlagService) DeleteRule(ctx context.Context, flagID, ruleID uint) error {
-	if err := s.repo.DeleteRule(flagID, ruleID); err != nil {
+	before, err := s.repo.FindFlagByID(flagID)
+	if err != nil {
 		return err
 	}
-	return s.cache.InvalidateAll(ctx)
+	if err := s.repo.DeleteRule(flagID, ruleID, s.versionFunc(ctx, FeatureFlagChangeRuleDeleted, before)); err != nil {
+		return err
+	}
+	return s.notifyChange(ctx, FeatureFlagChangeEvent{Type: FeatureFlagChangeRuleDeleted, FlagID: flagID, FlagKey: before.Key, RuleID: ruleID})
 }
 
-func evaluateFeatureFlag(flag domain.FeatureFlag, ctx FeatureFlagEvaluationContext) (bool, string) {
+// ListHistory returns the flag's version history newest first. History
+// outlives the flag, so deleted flags still report their versions.
+func (s *DefaultFeatureFlagService) ListHistory(ctx context.Context, flagID uint, limit int) ([]domain.FeatureFlagVersion, error) {
+	if limit <= 0 {
+		limit = defaultFeatureFlagHistoryLimit
+	}
+	if limit > maxFeatureFlagHistoryLimit {
+		limit = maxFeatureFlagHistoryLimit
+	}
+	return s.repo.ListVersions(flagID, limit)
+}
+
+// Rollback restores the flag, its variants and rules to the snapshot stored
+// in version. The restore and its own history entry commit atomically.
+func (s *DefaultFeatureFlagService) Rollback(ctx context.Context, flagID uint, version int) (*domain.FeatureFlag, error) {
+	target, err := s.repo.FindVersion(flagID, version)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.repo.FindFlagByID(flagID)
+	if err != nil {
+		return nil, err
+	}
+	var restored domain.FeatureFlag
+	if err := json.Unmarshal(target.Snapshot, &restored); err != nil {
+		return nil, err
+	}
+	restored.ID = flagID
+	if err := s.validatePrerequisites(&restored); err != nil {
+		return nil, err
+	}
+	if err := s.checkRemovedVariants(current, &restored); err != nil {
+		return nil, err
+	}
+	record, err := newFeatureFlagVersion(ctx, FeatureFlagChangeFlagRolledBack, current, &restored)
+	if err != nil {
+		return nil, err
+	}
+	record.SourceVersion = target.Version
+	if err := s.repo.RestoreFlag(&restored, record); err != nil {
+		return nil, err
+	}
+	if err := s.notifyChange(ctx, FeatureFlagChangeEvent{Type: FeatureFlagChangeFlagRolledBack, FlagID: flagID, FlagKey: restored.Key}); err != nil {
+		return nil, err
+	}
+	return s.repo.FindFlagByID(flagID)
+}
+
+// notifyChange invalidates cached evaluations and notifies stream
+// subscribers. The change is already committed, so a failed publish is only
+// recorded; subscribers still converge on their next reconnect.
+func (s *DefaultFeatureFlagService) notifyChange(ctx context.Context, event FeatureFlagChangeEvent) error {
+	err := s.cache.InvalidateAll(ctx)
+	status := "success"
+	if pubErr := s.changes.Publish(ctx, event); pubErr != nil {
+		status = "error"
+	}
+	observability.RecordFeatureFlagChangePublish(ctx, event.Type, status)
+	return err
+}
+
+// versionFunc builds the history entry the repository stores with a
+// mutation. before is the flag as it stood prior to the mutation, nil for
+// creations.
+func (s *DefaultFeatureFlagService) versionFunc(ctx context.Context, action string, before *domain.FeatureFlag) repository.FeatureFlagVersionFunc {
+	return func(after *domain.FeatureFlag) (*domain.FeatureFlagVersion, error) {
+		return newFeatureFlagVersion(ctx, action, before, after)
+	}
+}
+
+// validatePrerequisites checks flag's prerequisites against the stored
+// flags. Flags without prerequisites skip the lookup.
+func (s *DefaultFeatureFlagService) validatePrerequisites(flag *domain.FeatureFlag) error {
+	if len(flag.Prerequisites) == 0 {
+		return nil
+	}
+	flags, err := s.repo.ListFlags()
+	if err != nil {
+		return err
+	}
+	return validateFeatureFlagPrerequisites(flag, flags)
+}
+
+// checkRemovedVariants rejects an update that drops a variant another flag
+// requires as a prerequisite.
+func (s *DefaultFeatureFlagService) checkRemovedVariants(before, after *domain.FeatureFlag) error {
+	removed := map[string]struct{}{}
+	for _, variant := range before.Variants {
+		if _, ok := after.FindVariant(variant.Key); !ok {
+			removed[variant.Key] = struct{}{}
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+	flags, err := s.repo.ListFlags()
+	if err != nil {
+		return err
+	}
+	for _, flag := range flags {
+		for _, prerequisite := range flag.Prerequisites {
+			if prerequisite.PrerequisiteFlagID != before.ID {
+				continue
+			}
+			if _, ok := removed[prerequisite.Variant]; ok {
+				return fmt.Errorf("%w: variant %q is required by %s", ErrFeatureFlagInvalidVariant, prerequisite.Variant, flag.Key)
+			}
+		}
+	}
+	return nil
+}
+
+// checkRuleVariants rejects an update that drops a variant one of the flag's
+// own rules still targets.
+func checkRuleVariants(rules []domain.FeatureFlagRule, flag *domain.FeatureFlag) error {
+	for i := range rules {
+		if err := validateRuleVariant(flag, &rules[i]); err != nil {
+			return fmt.Errorf("%w: variant %q is targeted by rule %d", err, rules[i].Variant, rules[i].ID)
+		}
+	}
+	return nil
+}
+
+// validateRuleVariant checks that a rule's target variant exists on its flag.
+func validateRuleVariant(flag *domain.FeatureFlag, rule *domain.FeatureFlagRule) error {
+	if rule.Variant == "" {
+		return nil
+	}
+	if _, ok := flag.FindVariant(rule.Variant); !ok {
+		return ErrFeatureFlagInvalidVariant
+	}
+	return nil
+}
+
+// buildFeatureFlagResult renders the outcome of evaluating flag: the boolean
+// value, or the variant selected for enabled and the matched rule.
+func buildFeatureFlagResult(flag domain.FeatureFlag, enabled bool, source string, rule *domain.FeatureFlagRule, ctx FeatureFlagEvaluationContext) FeatureFlagEvaluationResult {
+	result := FeatureFlagEvaluationResult{
+		Key:         flag.Key,
+		Enabled:     enabled,
+		Source:      source,
+		Description: flag.Description,
+		Type:        flag.Type,
+	}
+	if result.Type == "" || result.Type == domain.FeatureFlagTypeBoolean {
+		result.Type = domain.FeatureFlagTypeBoolean
+		result.Value = json.RawMessage(strconv.FormatBool(enabled))
+		return result
+	}
+	if variant, ok := selectFeatureFlagVariant(flag, enabled, rule, ctx); ok {
+		result.Variant = variant.Key
+		result.Value = variant.Value
+	}
+	return result
+}
+
+// selectFeatureFlagVariant picks the variant served to ctx. Disabled flags and
+// anonymous callers get the default variant; a matched rule may pin a
+// variant; otherwise weighted variants split traffic by stable user bucket.
+func selectFeatureFlagVariant(flag domain.FeatureFlag, enabled bool, rule *domain.FeatureFlagRule, ctx FeatureFlagEvaluationContext) (domain.FeatureFlagVariant, bool) {
+	if enabled {
+		if rule != nil && rule.Variant != "" {
+			if variant, ok := flag.FindVariant(rule.Variant); ok {
+				return variant, true
+			}
+		}
+		if ctx.UserID != 0 {
+			bucket := stableVariantBucket(flag.ID, ctx.UserID)
+			cumulative := 0
+			for _, variant := range flag.Variants {
+				cumulative += variant.Weight
+				if bucket < cumulative {
+					return variant, true
+				}
+			}
+		}
+	}
+	return flag.FindVariant(flag.DefaultVariant)
+}
+
+func evaluateFeatureFlag(flag domain.FeatureFlag, ctx FeatureFlagEvaluationContext) (bool, string, *domain.FeatureFlagRule) {
 	rules := append([]domain.FeatureFlagRule(nil), flag.Rules...)
 	sort.SliceStable(rules, func(i, j int) bool {
 		if rules[i].Priority == rules[j].Priority {
@@ -194,34 +541,31 @@ func evaluateFeatureFlag(flag domain.FeatureFlag, ctx FeatureFlagEvaluationConte
 		return rules[i].Priority < rules[j].Priority
 	})
 
-	if matched, value := evaluateRuleByType(rules, FeatureFlagRuleTypeUser, ctx); matched {
-		return value, "rule:user"
-	}
-	if matched, value := evaluateRuleByType(rules, FeatureFlagRuleTypeRole, ctx); matched {
-		return value, "rule:role"
-	}
-	if matched, value := evaluateRuleByType(rules, FeatureFlagRuleTypeOrg, ctx); matched {
-		return value, "rule:org"
-	}
-	if matched, value := evaluateRuleByType(rules, FeatureFlagRuleTypeEnvironment, ctx); matched {
-		return value, "rule:environment"
-	}
-	if matched, value := evaluateRuleByType(rules, FeatureFlagRuleTypePercent, ctx); matched {
-		return value, "rule:percent"
+	for _, ruleType := range []string{
+		FeatureFlagRuleTypeUser,
+		FeatureFlagRuleTypeRole,
+		FeatureFlagRuleTypeOrg,
+		FeatureFlagRuleTypeEnvironment,
+		FeatureFlagRuleTypeAttribute,
+		FeatureFlagRuleTypePercent,
+	} {
+		if rule, matched := evaluateRuleByType(rules, ruleType, ctx); matched {
+			return rule.Enabled, "rule:" + ruleType, rule
+		}
 	}
-	return flag.Enabled, "default"
+	return flag.Enabled, "default", nil
 }
 
-func evaluateRuleByType(rules []domain.FeatureFlagRule, ruleType string, ctx FeatureFlagEvaluationContext) (bool, bool) {
-	for _, rule := range rules {
-		if rule.Type != ruleType {
+func evaluateRuleByType(rules []domain.FeatureFlagRule, ruleType string, ctx FeatureFlagEvaluationContext) (*domain.FeatureFlagRule, bool) {
+	for i := range rules {
+		if rules[i].Type != ruleType {
 			continue
 		}
-		if matchesRule(rule, ctx) {
-			return true, rule.Enabled
+		if matchesRule(rules[i], ctx) {
+			return &rules[i], true
 		}
 	}
-	return false, false
+	return nil, false
 }
 
 func matchesRule(rule domain.FeatureFlagRule, ctx FeatureFlagEvaluationContext) bool {
@@ -240,6 +584,8 @@ func matchesRule(rule domain.FeatureFlagRule, ctx FeatureFlagEvaluationContext)
 		return strings.EqualFold(strings.TrimSpace(rule.MatchValue), strings.TrimSpace(ctx.Org))
 	case FeatureFlagRuleTypeEnvironment:
 		return strings.EqualFold(strings.TrimSpace(rule.MatchValue), strings.TrimSpace(ctx.Environment))
+	case FeatureFlagRuleTypeAttribute:
+		return matchesClauses(rule, ctx)
 	case FeatureFlagRuleTypePercent:
 		if rule.Percentage <= 0 || ctx.UserID == 0 {
 			return false
@@ -255,7 +601,17 @@ func matchesRule(rule domain.FeatureFlagRule, ctx FeatureFlagEvaluationContext)
 }
 
 func stablePercentBucket(flagID, userID uint) int {
-	src := fmt.Sprintf("%d:%d", flagID, userID)
+	return stableBucket(fmt.Sprintf("%d:%d", flagID, userID))
+}
+
+// stableVariantBucket hashes with a separate salt so variant allocation is
+// independent of percent-rule inclusion; raising a rollout percentage does
+// not reshuffle users who were already assigned a variant.
+func stableVariantBucket(flagID, userID uint) int {
+	return stableBucket(fmt.Sprintf("%d:%d:variant", flagID, userID))
+}
+
+func stableBucket(src string) int {
 	sum := sha256.Sum256([]byte(src))
 	const bucketCount uint16 = 100
 	const maxUint16 = ^uint16(0)
@@ -274,33 +630,123 @@ func stablePercentBucket(flagID, userID uint) int {
 func normalizeAndValidateRule(rule *domain.FeatureFlagRule) error {
 	rule.Type = strings.TrimSpace(strings.ToLower(rule.Type))
 	rule.MatchValue = strings.TrimSpace(strings.ToLower(rule.MatchValue))
+	rule.Variant = strings.TrimSpace(strings.ToLower(rule.Variant))
 	if rule.Priority == 0 {
 		rule.Priority = 100
 	}
 	switch rule.Type {
 	case FeatureFlagRuleTypeUser, FeatureFlagRuleTypeRole, FeatureFlagRuleTypeOrg, FeatureFlagRuleTypeEnvironment:
 		if rule.MatchValue == "" {
-			return ErrFeatureFlagInvalidRuleValue
+			return ruleError("match_value", "is required for %s rules", rule.Type)
 		}
 		rule.Percentage = 0
+		rule.ClauseOperator = ""
+		rule.Clauses = nil
 	case FeatureFlagRuleTypePercent:
 		if rule.Percentage < 0 || rule.Percentage > 100 {
-			return ErrFeatureFlagInvalidRuleValue
+			return ruleError("percentage", "must be between 0 and 100")
 		}
 		rule.MatchValue = ""
+		rule.ClauseOperator = ""
+		rule.Clauses = nil
+	case FeatureFlagRuleTypeAttribute:
+		if err := normalizeAndValidateClauses(rule); err != nil {
+			return err
+		}
+		rule.MatchValue = ""
+		rule.Percentage = 0
 	default:
 		return ErrFeatureFlagInvalidRuleType
 	}
 	return nil
 }
 
+// normalizeAndValidateVariants checks the flag type and that every variant
+// key is unique, every value matches the type, weights are either all zero or
+// sum to 100, and the default variant exists. Boolean flags carry no variants.
+func normalizeAndValidateVariants(flag *domain.FeatureFlag) error {
+	flag.Type = strings.TrimSpace(strings.ToLower(flag.Type))
+	flag.DefaultVariant = strings.TrimSpace(strings.ToLower(flag.DefaultVariant))
+	switch flag.Type {
+	case "", domain.FeatureFlagTypeBoolean:
+		flag.Type = domain.FeatureFlagTypeBoolean
+		if len(flag.Variants) > 0 || flag.DefaultVariant != "" {
+			return ErrFeatureFlagInvalidVariant
+		}
+		return nil
+	case domain.FeatureFlagTypeString, domain.FeatureFlagTypeNumber, domain.FeatureFlagTypeJSON:
+	default:
+		return ErrFeatureFlagInvalidType
+	}
+
+	if len(flag.Variants) == 0 {
+		return ErrFeatureFlagInvalidVariant
+	}
+	seen := make(map[string]struct{}, len(flag.Variants))
+	totalWeight := 0
+	for i := range flag.Variants {
+		variant := &flag.Variants[i]
+		variant.Key = strings.TrimSpace(strings.ToLower(variant.Key))
+		if !featureFlagVariantKeyRe.MatchString(variant.Key) {
+			return ErrFeatureFlagInvalidVariant
+		}
+		if _, dup := seen[variant.Key]; dup {
+			return ErrFeatureFlagInvalidVariant
+		}
+		seen[variant.Key] = struct{}{}
+		if !variantValueMatchesType(flag.Type, variant.Value) {
+			return ErrFeatureFlagInvalidVariant
+		}
+		if variant.Weight < 0 || variant.Weight > 100 {
+			return ErrFeatureFlagInvalidVariant
+		}
+		totalWeight += variant.Weight
+	}
+	if totalWeight != 0 && totalWeight != 100 {
+		return ErrFeatureFlagInvalidVariant
+	}
+	if flag.DefaultVariant == "" {
+		flag.DefaultVariant = flag.Variants[0].Key
+	}
+	if _, ok := seen[flag.DefaultVariant]; !ok {
+		return ErrFeatureFlagInvalidVariant
+	}
+	return nil
+}
+
+func variantValueMatchesType(flagType string, raw json.RawMessage) bool {
+	var decoded any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return false
+	}
+	switch flagType {
+	case domain.FeatureFlagTypeString:
+		_, ok := decoded.(string)
+		return ok
+	case domain.FeatureFlagTypeNumber:
+		_, ok := decoded.(float64)
+		return ok
+	default:
+		return decoded != nil
+	}
+}
+
 func buildFeatureFlagCacheKey(evalCtx FeatureFlagEvaluationContext) string {
 	normalizedRoles := append([]string(nil), evalCtx.Roles...)
 	for i := range normalizedRoles {
 		normalizedRoles[i] = strings.TrimSpace(strings.ToLower(normalizedRoles[i]))
 	}
 	sort.Strings(normalizedRoles)
-	return fmt.Sprintf("u:%d|roles:%s|org:%s|env:%s", evalCtx.UserID, strings.Join(normalizedRoles, ","), strings.TrimSpace(strings.ToLower(evalCtx.Org)), strings.TrimSpace(strings.ToLower(evalCtx.Environment)))
+	key := fmt.Sprintf("u:%d|roles:%s|org:%s|env:%s", evalCtx.UserID, strings.Join(normalizedRoles, ","), strings.TrimSpace(strings.ToLower(evalCtx.Org)), strings.TrimSpace(strings.ToLower(evalCtx.Environment)))
+	if len(evalCtx.Attributes) == 0 {
+		return key
+	}
+	attrs := make([]string, 0, len(evalCtx.Attributes))
+	for name, value := range evalCtx.Attributes {
+		attrs = append(attrs, strconv.Quote(name)+"="+strconv.Quote(value))
+	}
+	sort.Strings(attrs)
+	return key + "|attrs:" + strings.Join(attrs, ",")
 }
 
 func (s *DefaultFeatureFlagService) readCachedEvaluation(ctx context.Context, key string) (featureFlagCachedPayload, bool) {
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -25,7 +26,7 @@ func TestFeatureFlagServiceEvaluatePrecedence(t *testing.T) {
 			{ID: 4, FeatureFlagID: 1, Type: FeatureFlagRuleTypeUser, MatchValue: "42", Enabled: false, Priority: 20},
 		},
 	}, nil)
-	svc := NewFeatureFlagService(repo, NewInMemoryFeatureFlagEvaluationCacheStore())
+	svc := NewFeatureFlagService(repo, NewInMemoryFeatureFlagEvaluationCacheStore(), nil, nil)
 
 	res, err := svc.EvaluateByKey(context.Background(), "new_checkout", FeatureFlagEvaluationContext{
 		UserID:      42,
@@ -68,7 +69,7 @@ func TestFeatureFlagServiceRuleValidation(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	repo := repogomock.NewMockFeatureFlagRepository(ctrl)
 	repo.EXPECT().FindFlagByID(uint(1)).AnyTimes().Return(&domain.FeatureFlag{ID: 1, Key: "k"}, nil)
-	svc := NewFeatureFlagService(repo, NewNoopFeatureFlagEvaluationCacheStore())
+	svc := NewFeatureFlagService(repo, NewNoopFeatureFlagEvaluationCacheStore(), nil, nil)
 
 	err := svc.CreateRule(context.Background(), &domain.FeatureFlagRule{FeatureFlagID: 1, Type: "percent", Percentage: 120})
 	if !errors.Is(err, ErrFeatureFlagInvalidRuleValue) {
@@ -96,21 +97,58 @@ func TestStablePercentBucketDistribution(t *testing.T) {
 	}
 }
 
+func TestWeightedVariantsSplitPartialRollout(t *testing.T) {
+	const sampleSize = 10000
+	flag := domain.FeatureFlag{
+		ID: 7, Type: domain.FeatureFlagTypeString, DefaultVariant: "off",
+		Variants: []domain.FeatureFlagVariant{
+			{Key: "off", Value: json.RawMessage(`"none"`)},
+			{Key: "a", Value: json.RawMessage(`"a"`), Weight: 50},
+			{Key: "b", Value: json.RawMessage(`"b"`), Weight: 50},
+		},
+		Rules: []domain.FeatureFlagRule{{ID: 1, FeatureFlagID: 7, Type: FeatureFlagRuleTypePercent, Percentage: 10, Enabled: true}},
+	}
+	served := map[string]int{}
+	for userID := uint(1); userID <= sampleSize; userID++ {
+		ctx := FeatureFlagEvaluationContext{UserID: userID}
+		enabled, source, rule := evaluateFeatureFlag(flag, ctx)
+		if result := buildFeatureFlagResult(flag, enabled, source, rule, ctx); enabled {
+			served[result.Variant]++
+		}
+	}
+	total := served["a"] + served["b"]
+	if total < 900 || total > 1100 || served["off"] != 0 {
+		t.Fatalf("expected ~10%% of users enabled on a weighted variant, got %v", served)
+	}
+	if served["a"] < total*4/10 || served["b"] < total*4/10 {
+		t.Fatalf("expected enabled users split ~50/50, got %v", served)
+	}
+}
+
 func TestFeatureFlagServiceCRUDWithGeneratedMocks(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	repo := repogomock.NewMockFeatureFlagRepository(ctrl)
-	svc := NewFeatureFlagService(repo, NewNoopFeatureFlagEvaluationCacheStore())
+	svc := NewFeatureFlagService(repo, NewNoopFeatureFlagEvaluationCacheStore(), nil, nil)
 
 	flags := map[uint]domain.FeatureFlag{}
 	nextID := uint(1)
+	var actions []string
+	recordVersion := func(version repository.FeatureFlagVersionFunc, after *domain.FeatureFlag) error {
+		record, err := version(after)
+		if err != nil {
+			return err
+		}
+		actions = append(actions, record.Action)
+		return nil
+	}
 
-	repo.EXPECT().CreateFlag(gomock.AssignableToTypeOf(&domain.FeatureFlag{})).DoAndReturn(func(flag *domain.FeatureFlag) error {
+	repo.EXPECT().CreateFlag(gomock.AssignableToTypeOf(&domain.FeatureFlag{}), gomock.Any()).DoAndReturn(func(flag *domain.FeatureFlag, version repository.FeatureFlagVersionFunc) error {
 		if flag.ID == 0 {
 			flag.ID = nextID
 			nextID++
 		}
 		flags[flag.ID] = *flag
-		return nil
+		return recordVersion(version, flag)
 	})
 	repo.EXPECT().FindFlagByID(gomock.Any()).DoAndReturn(func(id uint) (*domain.FeatureFlag, error) {
 		f, ok := flags[id]
@@ -120,19 +158,19 @@ func TestFeatureFlagServiceCRUDWithGeneratedMocks(t *testing.T) {
 		cp := f
 		return &cp, nil
 	}).AnyTimes()
-	repo.EXPECT().UpdateFlag(gomock.AssignableToTypeOf(&domain.FeatureFlag{})).DoAndReturn(func(flag *domain.FeatureFlag) error {
+	repo.EXPECT().UpdateFlag(gomock.AssignableToTypeOf(&domain.FeatureFlag{}), gomock.Any()).DoAndReturn(func(flag *domain.FeatureFlag, version repository.FeatureFlagVersionFunc) error {
 		if _, ok := flags[flag.ID]; !ok {
 			return repository.ErrFeatureFlagNotFound
 		}
 		flags[flag.ID] = *flag
-		return nil
+		return recordVersion(version, flag)
 	})
-	repo.EXPECT().DeleteFlag(gomock.Any()).DoAndReturn(func(id uint) error {
+	repo.EXPECT().DeleteFlag(gomock.Any(), gomock.Any()).DoAndReturn(func(id uint, version repository.FeatureFlagVersionFunc) error {
 		if _, ok := flags[id]; !ok {
 			return repository.ErrFeatureFlagNotFound
 		}
 		delete(flags, id)
-		return nil
+		return recordVersion(version, nil)
 	})
 
 	flag := &domain.FeatureFlag{Key: "k1", Description: "flag one", Enabled: true}
@@ -156,4 +194,219 @@ func TestFeatureFlagServiceCRUDWithGeneratedMocks(t *testing.T) {
 	if err := svc.DeleteFlag(context.Background(), loaded.ID); err != nil {
 		t.Fatalf("DeleteFlag: %v", err)
 	}
+	want := []string{FeatureFlagChangeFlagCreated, FeatureFlagChangeFlagUpdated, FeatureFlagChangeFlagDeleted}
+	if len(actions) != len(want) || actions[0] != want[0] || actions[1] != want[1] || actions[2] != want[2] {
+		t.Fatalf("expected history actions %v, got %v", want, actions)
+	}
+}
+
+func TestFeatureFlagServiceMultivariateEvaluation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockFeatureFlagRepository(ctrl)
+	repo.EXPECT().FindFlagByKey("checkout_copy").AnyTimes().Return(&domain.FeatureFlag{
+		ID:             3,
+		Key:            "checkout_copy",
+		Enabled:        true,
+		Type:           domain.FeatureFlagTypeString,
+		DefaultVariant: "control",
+		Variants: []domain.FeatureFlagVariant{
+			{Key: "control", Value: json.RawMessage(`"Buy now"`), Weight: 50},
+			{Key: "urgent", Value: json.RawMessage(`"Only a few left"`), Weight: 50},
+		},
+		Rules: []domain.FeatureFlagRule{
+			{ID: 1, FeatureFlagID: 3, Type: FeatureFlagRuleTypeRole, MatchValue: "admin", Enabled: true, Priority: 10, Variant: "urgent"},
+			{ID: 2, FeatureFlagID: 3, Type: FeatureFlagRuleTypeEnvironment, MatchValue: "staging", Enabled: false, Priority: 20},
+		},
+	}, nil)
+	svc := NewFeatureFlagService(repo, NewNoopFeatureFlagEvaluationCacheStore(), nil, nil)
+
+	res, err := svc.EvaluateByKey(context.Background(), "checkout_copy", FeatureFlagEvaluationContext{UserID: 5, Roles: []string{"admin"}})
+	if err != nil {
+		t.Fatalf("evaluate role rule: %v", err)
+	}
+	if res.Variant != "urgent" || string(res.Value) != `"Only a few left"` || res.Source != "rule:role" {
+		t.Fatalf("expected pinned urgent variant, got %+v", res)
+	}
+
+	res, err = svc.EvaluateByKey(context.Background(), "checkout_copy", FeatureFlagEvaluationContext{UserID: 5, Environment: "staging"})
+	if err != nil {
+		t.Fatalf("evaluate disabled rule: %v", err)
+	}
+	if res.Enabled || res.Variant != "control" {
+		t.Fatalf("expected disabled evaluation to serve default variant, got %+v", res)
+	}
+
+	counts := map[string]int{}
+	for userID := uint(1); userID <= 2000; userID++ {
+		res, err := svc.EvaluateByKey(context.Background(), "checkout_copy", FeatureFlagEvaluationContext{UserID: userID})
+		if err != nil {
+			t.Fatalf("evaluate split: %v", err)
+		}
+		counts[res.Variant]++
+	}
+	if counts["control"] < 900 || counts["urgent"] < 900 {
+		t.Fatalf("expected ~50/50 weighted split, got %v", counts)
+	}
+}
+
+func TestFeatureFlagServiceBooleanResultCarriesValue(t *testing.T) {
+	flag := domain.FeatureFlag{ID: 1, Key: "beta", Enabled: true}
+	res := newFeatureFlagEvaluator([]domain.FeatureFlag{flag}, FeatureFlagEvaluationContext{UserID: 1}).evaluate(flag)
+	if res.Type != domain.FeatureFlagTypeBoolean || string(res.Value) != "true" || res.Variant != "" {
+		t.Fatalf("unexpected boolean evaluation: %+v", res)
+	}
+}
+
+func TestFeatureFlagServiceVariantValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockFeatureFlagRepository(ctrl)
+	svc := NewFeatureFlagService(repo, NewNoopFeatureFlagEvaluationCacheStore(), nil, nil)
+
+	cases := []struct {
+		name string
+		flag domain.FeatureFlag
+		want error
+	}{
+		{"unknown type", domain.FeatureFlag{Key: "a", Type: "enum"}, ErrFeatureFlagInvalidType},
+		{"boolean with variants", domain.FeatureFlag{Key: "a", Variants: []domain.FeatureFlagVariant{{Key: "x", Value: json.RawMessage(`true`)}}}, ErrFeatureFlagInvalidVariant},
+		{"typed without variants", domain.FeatureFlag{Key: "a", Type: "string"}, ErrFeatureFlagInvalidVariant},
+		{"value type mismatch", domain.FeatureFlag{Key: "a", Type: "number", Variants: []domain.FeatureFlagVariant{{Key: "x", Value: json.RawMessage(`"1"`)}}}, ErrFeatureFlagInvalidVariant},
+		{"duplicate keys", domain.FeatureFlag{Key: "a", Type: "json", Variants: []domain.FeatureFlagVariant{{Key: "x", Value: json.RawMessage(`{}`)}, {Key: "X", Value: json.RawMessage(`[]`)}}}, ErrFeatureFlagInvalidVariant},
+		{"weights not 100", domain.FeatureFlag{Key: "a", Type: "number", Variants: []domain.FeatureFlagVariant{{Key: "x", Value: json.RawMessage(`1`), Weight: 30}, {Key: "y", Value: json.RawMessage(`2`), Weight: 30}}}, ErrFeatureFlagInvalidVariant},
+		{"unknown default", domain.FeatureFlag{Key: "a", Type: "number", DefaultVariant: "z", Variants: []domain.FeatureFlagVariant{{Key: "x", Value: json.RawMessage(`1`)}}}, ErrFeatureFlagInvalidVariant},
+	}
+	for _, tc := range cases {
+		flag := tc.flag
+		if err := svc.CreateFlag(context.Background(), &flag); !errors.Is(err, tc.want) {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+
+	repo.EXPECT().CreateFlag(gomock.Any(), gomock.Any()).DoAndReturn(func(flag *domain.FeatureFlag, _ repository.FeatureFlagVersionFunc) error {
+		flag.ID = 4
+		return nil
+	})
+	flag := &domain.FeatureFlag{Key: "limits", Type: "JSON", Variants: []domain.FeatureFlagVariant{{Key: " Small ", Value: json.RawMessage(`{"max":5}`)}}}
+	repo.EXPECT().FindFlagByID(uint(4)).Return(flag, nil)
+	if err := svc.CreateFlag(context.Background(), flag); err != nil {
+		t.Fatalf("create json flag: %v", err)
+	}
+	if flag.Type != domain.FeatureFlagTypeJSON || flag.DefaultVariant != "small" {
+		t.Fatalf("expected normalized type and default variant, got %+v", flag)
+	}
+
+	err := svc.CreateRule(context.Background(), &domain.FeatureFlagRule{FeatureFlagID: 4, Type: "user", MatchValue: "1", Variant: "large"})
+	if !errors.Is(err, ErrFeatureFlagInvalidVariant) {
+		t.Fatalf("expected rule with unknown variant to be rejected, got %v", err)
+	}
+}
+
+func TestFeatureFlagServiceUpdateRejectsRemovingRuleVariant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockFeatureFlagRepository(ctrl)
+	svc := NewFeatureFlagService(repo, NewNoopFeatureFlagEvaluationCacheStore(), nil, nil)
+
+	repo.EXPECT().FindFlagByID(uint(3)).Return(&domain.FeatureFlag{ID: 3, Key: "checkout_theme", Type: domain.FeatureFlagTypeString, DefaultVariant: "control",
+		Variants: []domain.FeatureFlagVariant{{Key: "control", Value: json.RawMessage(`"blue"`)}, {Key: "treatment", Value: json.RawMessage(`"green"`)}},
+		Rules:    []domain.FeatureFlagRule{{ID: 21, FeatureFlagID: 3, Type: "role", MatchValue: "beta", Enabled: true, Variant: "treatment"}},
+	}, nil)
+	update := &domain.FeatureFlag{ID: 3, Key: "checkout_theme", Type: domain.FeatureFlagTypeString, DefaultVariant: "control",
+		Variants: []domain.FeatureFlagVariant{{Key: "control", Value: json.RawMessage(`"blue"`)}},
+	}
+	if err := svc.UpdateFlag(context.Background(), update); !errors.Is(err, ErrFeatureFlagInvalidVariant) {
+		t.Fatalf("expected a variant targeted by a rule to block the update, got %v", err)
+	}
+}
+
+func TestFeatureFlagServiceEvaluateKeysUsesSharedCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockFeatureFlagRepository(ctrl)
+	repo.EXPECT().ListFlags().Times(1).Return([]domain.FeatureFlag{
+		{ID: 1, Key: "alpha", Enabled: true},
+		{ID: 2, Key: "beta", Enabled: false},
+		{ID: 3, Key: "gamma", Enabled: true},
+	}, nil)
+	svc := NewFeatureFlagService(repo, NewInMemoryFeatureFlagEvaluationCacheStore(), nil, nil)
+	evalCtx := FeatureFlagEvaluationContext{UserID: 9, Attributes: map[string]string{"plan": "pro"}}
+
+	if _, err := svc.EvaluateAll(context.Background(), evalCtx); err != nil {
+		t.Fatalf("evaluate all: %v", err)
+	}
+	results, err := svc.EvaluateKeys(context.Background(), []string{"Gamma", "alpha", "missing"}, evalCtx)
+	if err != nil {
+		t.Fatalf("evaluate keys: %v", err)
+	}
+	if len(results) != 2 || results[0].Key != "alpha" || results[1].Key != "gamma" {
+		t.Fatalf("unexpected bulk results: %+v", results)
+	}
+	all, err := svc.EvaluateKeys(context.Background(), nil, evalCtx)
+	if err != nil || len(all) != 3 {
+		t.Fatalf("expected all flags for empty key list, got %+v err=%v", all, err)
+	}
+}
+
+func TestFeatureFlagServicePublishesCommittedChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockFeatureFlagRepository(ctrl)
+	svc := NewFeatureFlagService(repo, NewNoopFeatureFlagEvaluationCacheStore(), nil, nil)
+
+	sub, err := svc.SubscribeChanges(context.Background(), "")
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	defer sub.Close()
+
+	repo.EXPECT().CreateFlag(gomock.Any(), gomock.Any()).DoAndReturn(func(flag *domain.FeatureFlag, _ repository.FeatureFlagVersionFunc) error {
+		flag.ID = 5
+		return nil
+	})
+	repo.EXPECT().FindFlagByID(uint(5)).Return(&domain.FeatureFlag{ID: 5, Key: "new_checkout"}, nil)
+	if err := svc.CreateFlag(context.Background(), &domain.FeatureFlag{Key: " New_Checkout "}); err != nil {
+		t.Fatalf("create flag: %v", err)
+	}
+	repo.EXPECT().DeleteRule(uint(5), uint(8), gomock.Any()).Return(nil)
+	if err := svc.DeleteRule(context.Background(), 5, 8); err != nil {
+		t.Fatalf("delete rule: %v", err)
+	}
+	repo.EXPECT().FindFlagByID(uint(6)).Return(nil, repository.ErrFeatureFlagNotFound)
+	if err := svc.UpdateFlag(context.Background(), &domain.FeatureFlag{ID: 6, Key: "missing"}); !errors.Is(err, repository.ErrFeatureFlagNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+
+	created := <-sub.Events
+	if created.Type != FeatureFlagChangeFlagCreated || created.FlagID != 5 || created.FlagKey != "new_checkout" {
+		t.Fatalf("unexpected create event: %+v", created)
+	}
+	deleted := <-sub.Events
+	if deleted.Type != FeatureFlagChangeRuleDeleted || deleted.FlagID != 5 || deleted.RuleID != 8 {
+		t.Fatalf("unexpected rule delete event: %+v", deleted)
+	}
+	select {
+	case event := <-sub.Events:
+		t.Fatalf("expected no event for failed update, got %+v", event)
+	default:
+	}
+}
+
+func TestFeatureFlagServiceSnapshotVersionTracksDefinitions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockFeatureFlagRepository(ctrl)
+	svc := NewFeatureFlagService(repo, NewNoopFeatureFlagEvaluationCacheStore(), nil, nil)
+
+	flags := []domain.FeatureFlag{{ID: 1, Key: "new_checkout", Type: domain.FeatureFlagTypeBoolean}}
+	repo.EXPECT().ListFlags().DoAndReturn(func() ([]domain.FeatureFlag, error) { return flags, nil }).Times(3)
+
+	first, err := svc.Snapshot(context.Background())
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	again, _ := svc.Snapshot(context.Background())
+	if first.Version == "" || first.Version != again.Version {
+		t.Fatalf("expected stable version, got %q and %q", first.Version, again.Version)
+	}
+	flags[0].Enabled = true
+	changed, _ := svc.Snapshot(context.Background())
+	if changed.Version == first.Version {
+		t.Fatal("expected version to change with flag definitions")
+	}
 }
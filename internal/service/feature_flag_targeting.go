@@ -0,0 +1,378 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+)
+
+const (
+	FeatureFlagClauseOpIn         = "in"
+	FeatureFlagClauseOpStartsWith = "starts_with"
+	FeatureFlagClauseOpEndsWith   = "ends_with"
+	FeatureFlagClauseOpRegex      = "regex"
+	FeatureFlagClauseOpSemverEq   = "semver_eq"
+	FeatureFlagClauseOpSemverGt   = "semver_gt"
+	FeatureFlagClauseOpSemverGte  = "semver_gte"
+	FeatureFlagClauseOpSemverLt   = "semver_lt"
+	FeatureFlagClauseOpSemverLte  = "semver_lte"
+	FeatureFlagClauseOpNumGt      = "num_gt"
+	FeatureFlagClauseOpNumGte     = "num_gte"
+	FeatureFlagClauseOpNumLt      = "num_lt"
+	FeatureFlagClauseOpNumLte     = "num_lte"
+	FeatureFlagClauseOpNumBetween = "num_between"
+
+	FeatureFlagClauseCombineAnd = "and"
+	FeatureFlagClauseCombineOr  = "or"
+
+	maxFeatureFlagClauses      = 20
+	maxFeatureFlagClauseValues = 100
+)
+
+var featureFlagAttributeRe = regexp.MustCompile(`^[a-z][a-z0-9_.]{0,63}$`)
+
+// featureFlagRegexCache memoizes compiled clause patterns; patterns come from
+// stored rules, so the set is bounded by rule definitions.
+var featureFlagRegexCache sync.Map
+
+// FeatureFlagRuleError reports which part of a rule definition is invalid.
+// It unwraps to ErrFeatureFlagInvalidRuleValue.
+type FeatureFlagRuleError struct {
+	Field  string
+	Reason string
+}
+
+func (e *FeatureFlagRuleError) Error() string {
+	return fmt.Sprintf("invalid feature flag rule: %s %s", e.Field, e.Reason)
+}
+
+func (e *FeatureFlagRuleError) Unwrap() error {
+	return ErrFeatureFlagInvalidRuleValue
+}
+
+func ruleError(field, format string, args ...any) error {
+	return &FeatureFlagRuleError{Field: field, Reason: fmt.Sprintf(format, args...)}
+}
+
+// attributeValues resolves a clause attribute from the evaluation context.
+// Built-in attributes take precedence over custom ones of the same name.
+func (c FeatureFlagEvaluationContext) attributeValues(name string) []string {
+	switch name {
+	case "user_id":
+		if c.UserID == 0 {
+			return nil
+		}
+		return []string{strconv.FormatUint(uint64(c.UserID), 10)}
+	case "role":
+		return c.Roles
+	case "org":
+		if c.Org == "" {
+			return nil
+		}
+		return []string{c.Org}
+	case "environment":
+		if c.Environment == "" {
+			return nil
+		}
+		return []string{c.Environment}
+	}
+	if value, ok := c.Attributes[name]; ok {
+		return []string{value}
+	}
+	return nil
+}
+
+func matchesClauses(rule domain.FeatureFlagRule, ctx FeatureFlagEvaluationContext) bool {
+	if len(rule.Clauses) == 0 {
+		return false
+	}
+	anyMode := rule.ClauseOperator == FeatureFlagClauseCombineOr
+	for _, clause := range rule.Clauses {
+		matched := matchesClause(clause, ctx)
+		if anyMode && matched {
+			return true
+		}
+		if !anyMode && !matched {
+			return false
+		}
+	}
+	return !anyMode
+}
+
+// matchesClause reports whether any of the attribute's values satisfies the
+// clause. A missing attribute never matches, even when the clause is negated.
+func matchesClause(clause domain.FeatureFlagClause, ctx FeatureFlagEvaluationContext) bool {
+	values := ctx.attributeValues(clause.Attribute)
+	if len(values) == 0 {
+		return false
+	}
+	matched := false
+	for _, value := range values {
+		if matchesClauseValue(clause, strings.TrimSpace(value)) {
+			matched = true
+			break
+		}
+	}
+	return matched != clause.Negate
+}
+
+func matchesClauseValue(clause domain.FeatureFlagClause, value string) bool {
+	switch clause.Operator {
+	case FeatureFlagClauseOpIn:
+		for _, candidate := range clause.Values {
+			if strings.EqualFold(candidate, value) {
+				return true
+			}
+		}
+		return false
+	case FeatureFlagClauseOpStartsWith:
+		lower := strings.ToLower(value)
+		for _, candidate := range clause.Values {
+			if strings.HasPrefix(lower, candidate) {
+				return true
+			}
+		}
+		return false
+	case FeatureFlagClauseOpEndsWith:
+		lower := strings.ToLower(value)
+		for _, candidate := range clause.Values {
+			if strings.HasSuffix(lower, candidate) {
+				return true
+			}
+		}
+		return false
+	case FeatureFlagClauseOpRegex:
+		for _, pattern := range clause.Values {
+			re, err := compileClauseRegex(pattern)
+			if err == nil && re.MatchString(value) {
+				return true
+			}
+		}
+		return false
+	case FeatureFlagClauseOpSemverEq, FeatureFlagClauseOpSemverGt, FeatureFlagClauseOpSemverGte, FeatureFlagClauseOpSemverLt, FeatureFlagClauseOpSemverLte:
+		actual, ok := parseSemver(value)
+		if !ok || len(clause.Values) != 1 {
+			return false
+		}
+		target, ok := parseSemver(clause.Values[0])
+		if !ok {
+			return false
+		}
+		return compareWith(clause.Operator, compareSemver(actual, target))
+	case FeatureFlagClauseOpNumGt, FeatureFlagClauseOpNumGte, FeatureFlagClauseOpNumLt, FeatureFlagClauseOpNumLte:
+		actual, err := strconv.ParseFloat(value, 64)
+		if err != nil || len(clause.Values) != 1 {
+			return false
+		}
+		target, err := strconv.ParseFloat(clause.Values[0], 64)
+		if err != nil {
+			return false
+		}
+		switch {
+		case actual < target:
+			return compareWith(clause.Operator, -1)
+		case actual > target:
+			return compareWith(clause.Operator, 1)
+		default:
+			return compareWith(clause.Operator, 0)
+		}
+	case FeatureFlagClauseOpNumBetween:
+		actual, err := strconv.ParseFloat(value, 64)
+		if err != nil || len(clause.Values) != 2 {
+			return false
+		}
+		lo, errLo := strconv.ParseFloat(clause.Values[0], 64)
+		hi, errHi := strconv.ParseFloat(clause.Values[1], 64)
+		return errLo == nil && errHi == nil && actual >= lo && actual <= hi
+	default:
+		return false
+	}
+}
+
+func compareWith(operator string, cmp int) bool {
+	switch operator {
+	case FeatureFlagClauseOpSemverEq:
+		return cmp == 0
+	case FeatureFlagClauseOpSemverGt, FeatureFlagClauseOpNumGt:
+		return cmp > 0
+	case FeatureFlagClauseOpSemverGte, FeatureFlagClauseOpNumGte:
+		return cmp >= 0
+	case FeatureFlagClauseOpSemverLt, FeatureFlagClauseOpNumLt:
+		return cmp < 0
+	case FeatureFlagClauseOpSemverLte, FeatureFlagClauseOpNumLte:
+		return cmp <= 0
+	default:
+		return false
+	}
+}
+
+func compileClauseRegex(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := featureFlagRegexCache.Load(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	featureFlagRegexCache.Store(pattern, re)
+	return re, nil
+}
+
+type semver struct {
+	major, minor, patch uint64
+	prerelease          string
+}
+
+// parseSemver accepts MAJOR[.MINOR[.PATCH]][-PRERELEASE][+BUILD] with an
+// optional leading "v"; missing minor/patch parts are treated as zero.
+func parseSemver(raw string) (semver, bool) {
+	raw = strings.TrimPrefix(strings.TrimSpace(raw), "v")
+	if i := strings.IndexByte(raw, '+'); i >= 0 {
+		raw = raw[:i]
+	}
+	var v semver
+	if i := strings.IndexByte(raw, '-'); i >= 0 {
+		v.prerelease = raw[i+1:]
+		raw = raw[:i]
+		if v.prerelease == "" {
+			return semver{}, false
+		}
+	}
+	parts := strings.Split(raw, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return semver{}, false
+	}
+	nums := [3]uint64{}
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return semver{}, false
+		}
+		nums[i] = n
+	}
+	v.major, v.minor, v.patch = nums[0], nums[1], nums[2]
+	return v, true
+}
+
+// compareSemver orders versions by core numbers, then ranks a prerelease
+// below its release and compares prerelease strings lexically.
+func compareSemver(a, b semver) int {
+	for _, pair := range [][2]uint64{{a.major, b.major}, {a.minor, b.minor}, {a.patch, b.patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case a.prerelease == b.prerelease:
+		return 0
+	case a.prerelease == "":
+		return 1
+	case b.prerelease == "":
+		return -1
+	case a.prerelease < b.prerelease:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func normalizeAndValidateClauses(rule *domain.FeatureFlagRule) error {
+	rule.ClauseOperator = strings.TrimSpace(strings.ToLower(rule.ClauseOperator))
+	if rule.ClauseOperator == "" {
+		rule.ClauseOperator = FeatureFlagClauseCombineAnd
+	}
+	if rule.ClauseOperator != FeatureFlagClauseCombineAnd && rule.ClauseOperator != FeatureFlagClauseCombineOr {
+		return ruleError("clause_operator", "must be %q or %q", FeatureFlagClauseCombineAnd, FeatureFlagClauseCombineOr)
+	}
+	if len(rule.Clauses) == 0 {
+		return ruleError("clauses", "must contain at least one clause")
+	}
+	if len(rule.Clauses) > maxFeatureFlagClauses {
+		return ruleError("clauses", "must contain at most %d clauses", maxFeatureFlagClauses)
+	}
+	for i := range rule.Clauses {
+		if err := normalizeAndValidateClause(&rule.Clauses[i], fmt.Sprintf("clauses[%d]", i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func normalizeAndValidateClause(clause *domain.FeatureFlagClause, field string) error {
+	clause.Attribute = strings.TrimSpace(strings.ToLower(clause.Attribute))
+	clause.Operator = strings.TrimSpace(strings.ToLower(clause.Operator))
+	if !featureFlagAttributeRe.MatchString(clause.Attribute) {
+		return ruleError(field+".attribute", "must match %s", featureFlagAttributeRe.String())
+	}
+	if len(clause.Values) == 0 {
+		return ruleError(field+".values", "must not be empty")
+	}
+	if len(clause.Values) > maxFeatureFlagClauseValues {
+		return ruleError(field+".values", "must contain at most %d values", maxFeatureFlagClauseValues)
+	}
+	for i := range clause.Values {
+		clause.Values[i] = strings.TrimSpace(clause.Values[i])
+	}
+
+	switch clause.Operator {
+	case FeatureFlagClauseOpIn, FeatureFlagClauseOpStartsWith, FeatureFlagClauseOpEndsWith:
+		for i := range clause.Values {
+			clause.Values[i] = strings.ToLower(clause.Values[i])
+		}
+		clause.Values = dedupeSorted(clause.Values)
+	case FeatureFlagClauseOpRegex:
+		for i, pattern := range clause.Values {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return ruleError(fmt.Sprintf("%s.values[%d]", field, i), "is not a valid regular expression: %v", err)
+			}
+		}
+	case FeatureFlagClauseOpSemverEq, FeatureFlagClauseOpSemverGt, FeatureFlagClauseOpSemverGte, FeatureFlagClauseOpSemverLt, FeatureFlagClauseOpSemverLte:
+		if len(clause.Values) != 1 {
+			return ruleError(field+".values", "must contain exactly one version for %s", clause.Operator)
+		}
+		if _, ok := parseSemver(clause.Values[0]); !ok {
+			return ruleError(field+".values[0]", "is not a valid semantic version")
+		}
+	case FeatureFlagClauseOpNumGt, FeatureFlagClauseOpNumGte, FeatureFlagClauseOpNumLt, FeatureFlagClauseOpNumLte:
+		if len(clause.Values) != 1 {
+			return ruleError(field+".values", "must contain exactly one number for %s", clause.Operator)
+		}
+		if _, err := strconv.ParseFloat(clause.Values[0], 64); err != nil {
+			return ruleError(field+".values[0]", "is not a number")
+		}
+	case FeatureFlagClauseOpNumBetween:
+		if len(clause.Values) != 2 {
+			return ruleError(field+".values", "must contain [min, max] for %s", clause.Operator)
+		}
+		lo, errLo := strconv.ParseFloat(clause.Values[0], 64)
+		hi, errHi := strconv.ParseFloat(clause.Values[1], 64)
+		if errLo != nil || errHi != nil {
+			return ruleError(field+".values", "must be numbers")
+		}
+		if lo > hi {
+			return ruleError(field+".values", "min must not exceed max")
+		}
+	default:
+		return ruleError(field+".operator", "unsupported operator %q", clause.Operator)
+	}
+	return nil
+}
+
+func dedupeSorted(values []string) []string {
+	sort.Strings(values)
+	out := values[:0]
+	for _, v := range values {
+		if len(out) == 0 || out[len(out)-1] != v {
+			out = append(out, v)
+		}
+	}
+	return out
+}
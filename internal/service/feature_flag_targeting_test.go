@@ -0,0 +1,117 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+)
+
+func TestMatchesClauseOperators(t *testing.T) {
+	ctx := FeatureFlagEvaluationContext{
+		UserID: 7,
+		Roles:  []string{"member", "beta"},
+		Attributes: map[string]string{
+			"plan":         "Pro",
+			"email_domain": "corp.example.com",
+			"app_version":  "2.4.1",
+			"seats":        "25",
+		},
+	}
+	cases := []struct {
+		name   string
+		clause domain.FeatureFlagClause
+		want   bool
+	}{
+		{"in list", domain.FeatureFlagClause{Attribute: "plan", Operator: "in", Values: []string{"pro", "team"}}, true},
+		{"in list negated", domain.FeatureFlagClause{Attribute: "plan", Operator: "in", Values: []string{"pro"}, Negate: true}, false},
+		{"multi-valued role", domain.FeatureFlagClause{Attribute: "role", Operator: "in", Values: []string{"beta"}}, true},
+		{"suffix", domain.FeatureFlagClause{Attribute: "email_domain", Operator: "ends_with", Values: []string{".example.com"}}, true},
+		{"prefix", domain.FeatureFlagClause{Attribute: "email_domain", Operator: "starts_with", Values: []string{"mail."}}, false},
+		{"regex", domain.FeatureFlagClause{Attribute: "email_domain", Operator: "regex", Values: []string{`^corp\.`}}, true},
+		{"semver gte", domain.FeatureFlagClause{Attribute: "app_version", Operator: "semver_gte", Values: []string{"2.4.0"}}, true},
+		{"semver lt", domain.FeatureFlagClause{Attribute: "app_version", Operator: "semver_lt", Values: []string{"v2.4.1"}}, false},
+		{"numeric range", domain.FeatureFlagClause{Attribute: "seats", Operator: "num_between", Values: []string{"10", "50"}}, true},
+		{"numeric gt", domain.FeatureFlagClause{Attribute: "seats", Operator: "num_gt", Values: []string{"25"}}, false},
+		{"builtin user id", domain.FeatureFlagClause{Attribute: "user_id", Operator: "in", Values: []string{"7"}}, true},
+		{"missing attribute negated", domain.FeatureFlagClause{Attribute: "country", Operator: "in", Values: []string{"us"}, Negate: true}, false},
+	}
+	for _, tc := range cases {
+		if got := matchesClause(tc.clause, ctx); got != tc.want {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestMatchesClausesCombinesWithAndOr(t *testing.T) {
+	ctx := FeatureFlagEvaluationContext{Attributes: map[string]string{"plan": "pro", "country": "de"}}
+	clauses := []domain.FeatureFlagClause{
+		{Attribute: "plan", Operator: "in", Values: []string{"pro"}},
+		{Attribute: "country", Operator: "in", Values: []string{"us"}},
+	}
+	if matchesRule(domain.FeatureFlagRule{Type: FeatureFlagRuleTypeAttribute, ClauseOperator: "and", Clauses: clauses}, ctx) {
+		t.Fatal("expected and-combined clauses to fail")
+	}
+	if !matchesRule(domain.FeatureFlagRule{Type: FeatureFlagRuleTypeAttribute, ClauseOperator: "or", Clauses: clauses}, ctx) {
+		t.Fatal("expected or-combined clauses to match")
+	}
+}
+
+func TestCompareSemverPrerelease(t *testing.T) {
+	release, _ := parseSemver("1.2.0")
+	pre, _ := parseSemver("1.2.0-rc.1")
+	short, ok := parseSemver("1.2")
+	if !ok {
+		t.Fatal("expected short version to parse")
+	}
+	if compareSemver(pre, release) >= 0 || compareSemver(short, release) != 0 {
+		t.Fatalf("unexpected semver ordering")
+	}
+	if _, ok := parseSemver("1.x"); ok {
+		t.Fatal("expected invalid version to be rejected")
+	}
+}
+
+func TestNormalizeAndValidateRuleReportsClauseDetails(t *testing.T) {
+	cases := []struct {
+		name  string
+		rule  domain.FeatureFlagRule
+		field string
+	}{
+		{"missing match value", domain.FeatureFlagRule{Type: "role"}, "match_value"},
+		{"no clauses", domain.FeatureFlagRule{Type: "attribute"}, "clauses"},
+		{"bad combinator", domain.FeatureFlagRule{Type: "attribute", ClauseOperator: "xor", Clauses: []domain.FeatureFlagClause{{Attribute: "plan", Operator: "in", Values: []string{"pro"}}}}, "clause_operator"},
+		{"bad operator", domain.FeatureFlagRule{Type: "attribute", Clauses: []domain.FeatureFlagClause{{Attribute: "plan", Operator: "like", Values: []string{"pro"}}}}, "clauses[0].operator"},
+		{"bad attribute", domain.FeatureFlagRule{Type: "attribute", Clauses: []domain.FeatureFlagClause{{Attribute: "9lives", Operator: "in", Values: []string{"x"}}}}, "clauses[0].attribute"},
+		{"bad regex", domain.FeatureFlagRule{Type: "attribute", Clauses: []domain.FeatureFlagClause{{Attribute: "plan", Operator: "in", Values: []string{"a"}}, {Attribute: "email", Operator: "regex", Values: []string{"("}}}}, "clauses[1].values[0]"},
+		{"bad semver", domain.FeatureFlagRule{Type: "attribute", Clauses: []domain.FeatureFlagClause{{Attribute: "app_version", Operator: "semver_gt", Values: []string{"latest"}}}}, "clauses[0].values[0]"},
+		{"inverted range", domain.FeatureFlagRule{Type: "attribute", Clauses: []domain.FeatureFlagClause{{Attribute: "seats", Operator: "num_between", Values: []string{"9", "1"}}}}, "clauses[0].values"},
+	}
+	for _, tc := range cases {
+		rule := tc.rule
+		err := normalizeAndValidateRule(&rule)
+		var ruleErr *FeatureFlagRuleError
+		if !errors.As(err, &ruleErr) || !errors.Is(err, ErrFeatureFlagInvalidRuleValue) {
+			t.Fatalf("%s: expected FeatureFlagRuleError, got %v", tc.name, err)
+		}
+		if ruleErr.Field != tc.field {
+			t.Fatalf("%s: expected field %q, got %q (%s)", tc.name, tc.field, ruleErr.Field, ruleErr.Reason)
+		}
+	}
+
+	rule := domain.FeatureFlagRule{Type: " Attribute ", MatchValue: "ignored", Clauses: []domain.FeatureFlagClause{{Attribute: " Plan ", Operator: "IN", Values: []string{"Team", " pro", "team"}}}}
+	if err := normalizeAndValidateRule(&rule); err != nil {
+		t.Fatalf("valid attribute rule: %v", err)
+	}
+	if rule.ClauseOperator != "and" || rule.MatchValue != "" || rule.Clauses[0].Attribute != "plan" || len(rule.Clauses[0].Values) != 2 {
+		t.Fatalf("unexpected normalized rule: %+v", rule)
+	}
+}
+
+func TestBuildFeatureFlagCacheKeyIncludesAttributes(t *testing.T) {
+	base := FeatureFlagEvaluationContext{UserID: 1}
+	withAttrs := FeatureFlagEvaluationContext{UserID: 1, Attributes: map[string]string{"plan": "pro"}}
+	if buildFeatureFlagCacheKey(base) == buildFeatureFlagCacheKey(withAttrs) {
+		t.Fatal("expected attributes to change the cache key")
+	}
+}
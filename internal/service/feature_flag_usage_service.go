@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+)
+
+const (
+	FeatureFlagStaleNotEvaluated   = "not_evaluated"
+	FeatureFlagStaleFullyRolledOut = "fully_rolled_out"
+
+	maxFeatureFlagStaleDays         = 365
+	defaultFeatureFlagExposureLimit = 100
+	maxFeatureFlagExposureLimit     = 1000
+)
+
+var ErrFeatureFlagInvalidStaleWindow = errors.New("stale window must be between 1 and 365 days")
+
+// FeatureFlagUsage is the recorded evaluation telemetry of one flag, broken
+// down by served variant and result source.
+type FeatureFlagUsage struct {
+	FlagID          uint                               `json:"flag_id"`
+	FlagKey         string                             `json:"flag_key"`
+	Evaluations     int64                              `json:"evaluations"`
+	LastEvaluatedAt *time.Time                         `json:"last_evaluated_at,omitempty"`
+	Breakdown       []domain.FeatureFlagEvaluationStat `json:"breakdown"`
+}
+
+// StaleFeatureFlag is a cleanup candidate. Reasons holds not_evaluated when
+// the flag has not been evaluated within the window, and fully_rolled_out
+// when it has served one outcome to everyone since UnchangedSince.
+type StaleFeatureFlag struct {
+	ID              uint       `json:"id"`
+	Key             string     `json:"key"`
+	Enabled         bool       `json:"enabled"`
+	Reasons         []string   `json:"reasons"`
+	Evaluations     int64      `json:"evaluations"`
+	LastEvaluatedAt *time.Time `json:"last_evaluated_at,omitempty"`
+	UnchangedSince  time.Time  `json:"unchanged_since"`
+}
+
+type DefaultFeatureFlagUsageService struct {
+	flags     repository.FeatureFlagRepository
+	exposures repository.FeatureFlagExposureRepository
+	now       func() time.Time
+}
+
+func NewFeatureFlagUsageService(flags repository.FeatureFlagRepository, exposures repository.FeatureFlagExposureRepository) *DefaultFeatureFlagUsageService {
+	return &DefaultFeatureFlagUsageService{flags: flags, exposures: exposures, now: time.Now}
+}
+
+func (s *DefaultFeatureFlagUsageService) Usage(ctx context.Context, flagID uint) (*FeatureFlagUsage, error) {
+	flag, err := s.flags.FindFlagByID(flagID)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.exposures.ListEvaluationStats(flag.Key)
+	if err != nil {
+		return nil, err
+	}
+	if stats == nil {
+		stats = []domain.FeatureFlagEvaluationStat{}
+	}
+	usage := &FeatureFlagUsage{FlagID: flag.ID, FlagKey: flag.Key, Breakdown: stats}
+	usage.Evaluations, usage.LastEvaluatedAt = summarizeFeatureFlagStats(stats)
+	return usage, nil
+}
+
+// StaleFlags lists flags that have not been evaluated, or have been fully
+// rolled out without changes, for at least days.
+func (s *DefaultFeatureFlagUsageService) StaleFlags(ctx context.Context, days int) ([]StaleFeatureFlag, error) {
+	if days < 1 || days > maxFeatureFlagStaleDays {
+		return nil, ErrFeatureFlagInvalidStaleWindow
+	}
+	cutoff := s.now().UTC().AddDate(0, 0, -days)
+	flags, err := s.flags.ListFlags()
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.exposures.ListEvaluationStats("")
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string][]domain.FeatureFlagEvaluationStat)
+	for _, stat := range stats {
+		byKey[stat.FlagKey] = append(byKey[stat.FlagKey], stat)
+	}
+
+	stale := make([]StaleFeatureFlag, 0)
+	for _, flag := range flags {
+		entry := StaleFeatureFlag{ID: flag.ID, Key: flag.Key, Enabled: flag.Enabled, Reasons: []string{}, UnchangedSince: flag.UpdatedAt.UTC()}
+		entry.Evaluations, entry.LastEvaluatedAt = summarizeFeatureFlagStats(byKey[flag.Key])
+		if entry.LastEvaluatedAt == nil {
+			if flag.CreatedAt.Before(cutoff) {
+				entry.Reasons = append(entry.Reasons, FeatureFlagStaleNotEvaluated)
+			}
+		} else if entry.LastEvaluatedAt.Before(cutoff) {
+			entry.Reasons = append(entry.Reasons, FeatureFlagStaleNotEvaluated)
+		}
+		if featureFlagFullyRolledOut(flag) {
+			versions, err := s.flags.ListVersions(flag.ID, 1)
+			if err != nil {
+				return nil, err
+			}
+			if len(versions) > 0 {
+				entry.UnchangedSince = versions[0].CreatedAt.UTC()
+			}
+			if entry.UnchangedSince.Before(cutoff) {
+				entry.Reasons = append(entry.Reasons, FeatureFlagStaleFullyRolledOut)
+			}
+		}
+		if len(entry.Reasons) > 0 {
+			stale = append(stale, entry)
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i].Key < stale[j].Key })
+	return stale, nil
+}
+
+// ListExposures returns the flag's recorded exposures newest first. Pass the
+// smallest id of a page as beforeID to fetch the next one.
+func (s *DefaultFeatureFlagUsageService) ListExposures(ctx context.Context, flagID, beforeID uint, limit int) ([]domain.FeatureFlagExposure, error) {
+	if limit <= 0 {
+		limit = defaultFeatureFlagExposureLimit
+	}
+	if limit > maxFeatureFlagExposureLimit {
+		limit = maxFeatureFlagExposureLimit
+	}
+	flag, err := s.flags.FindFlagByID(flagID)
+	if err != nil {
+		return nil, err
+	}
+	exposures, err := s.exposures.ListExposures(flag.Key, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+	if exposures == nil {
+		exposures = []domain.FeatureFlagExposure{}
+	}
+	return exposures, nil
+}
+
+func summarizeFeatureFlagStats(stats []domain.FeatureFlagEvaluationStat) (int64, *time.Time) {
+	var total int64
+	var last *time.Time
+	for _, stat := range stats {
+		total += stat.Count
+		at := stat.LastEvaluatedAt.UTC()
+		if last == nil || at.After(*last) {
+			last = &at
+		}
+	}
+	return total, last
+}
+
+// featureFlagFullyRolledOut reports whether flag serves the same outcome to
+// every context: it is enabled with no prerequisites, no rule turns it off,
+// and, for multivariate flags, rules, weights and the default all resolve to
+// a single variant.
+func featureFlagFullyRolledOut(flag domain.FeatureFlag) bool {
+	if !flag.Enabled || len(flag.Prerequisites) > 0 {
+		return false
+	}
+	for _, rule := range flag.Rules {
+		if !rule.Enabled {
+			return false
+		}
+	}
+	if flag.Type == "" || flag.Type == domain.FeatureFlagTypeBoolean {
+		return true
+	}
+	// Anonymous callers and unallocated weight always get the default.
+	served := map[string]struct{}{flag.DefaultVariant: {}}
+	for _, variant := range flag.Variants {
+		if variant.Weight > 0 {
+			served[variant.Key] = struct{}{}
+		}
+	}
+	for _, rule := range flag.Rules {
+		if rule.Variant != "" {
+			served[rule.Variant] = struct{}{}
+		}
+	}
+	return len(served) == 1
+}
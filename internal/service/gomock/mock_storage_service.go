@@ -55,6 +55,34 @@ func (mr *MockStorageServiceMockRecorder) DeleteAvatar(ctx, userID, objectKey an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAvatar", reflect.TypeOf((*MockStorageService)(nil).DeleteAvatar), ctx, userID, objectKey)
 }
 
+// DeleteObject mocks base method.
+func (m *MockStorageService) DeleteObject(ctx context.Context, prefix, objectKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteObject", ctx, prefix, objectKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteObject indicates an expected call of DeleteObject.
+func (mr *MockStorageServiceMockRecorder) DeleteObject(ctx, prefix, objectKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteObject", reflect.TypeOf((*MockStorageService)(nil).DeleteObject), ctx, prefix, objectKey)
+}
+
+// DeletePrefix mocks base method.
+func (m *MockStorageService) DeletePrefix(ctx context.Context, prefix string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrefix", ctx, prefix)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePrefix indicates an expected call of DeletePrefix.
+func (mr *MockStorageServiceMockRecorder) DeletePrefix(ctx, prefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrefix", reflect.TypeOf((*MockStorageService)(nil).DeletePrefix), ctx, prefix)
+}
+
 // GenerateAvatarURL mocks base method.
 func (m *MockStorageService) GenerateAvatarURL(ctx context.Context, objectKey string) (string, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +98,21 @@ func (mr *MockStorageServiceMockRecorder) GenerateAvatarURL(ctx, objectKey any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateAvatarURL", reflect.TypeOf((*MockStorageService)(nil).GenerateAvatarURL), ctx, objectKey)
 }
 
+// GenerateURL mocks base method.
+func (m *MockStorageService) GenerateURL(ctx context.Context, objectKey string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateURL", ctx, objectKey)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateURL indicates an expected call of GenerateURL.
+func (mr *MockStorageServiceMockRecorder) GenerateURL(ctx, objectKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateURL", reflect.TypeOf((*MockStorageService)(nil).GenerateURL), ctx, objectKey)
+}
+
 // UploadAvatar mocks base method.
 func (m *MockStorageService) UploadAvatar(ctx context.Context, userID uint, file io.Reader, fileSize int64, contentType string) (string, error) {
 	m.ctrl.T.Helper()
@@ -84,3 +127,19 @@ func (mr *MockStorageServiceMockRecorder) UploadAvatar(ctx, userID, file, fileSi
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAvatar", reflect.TypeOf((*MockStorageService)(nil).UploadAvatar), ctx, userID, file, fileSize, contentType)
 }
+
+// UploadImage mocks base method.
+func (m *MockStorageService) UploadImage(ctx context.Context, prefix string, file io.Reader, fileSize int64, metadata map[string]string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadImage", ctx, prefix, file, fileSize, metadata)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UploadImage indicates an expected call of UploadImage.
+func (mr *MockStorageServiceMockRecorder) UploadImage(ctx, prefix, file, fileSize, metadata any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadImage", reflect.TypeOf((*MockStorageService)(nil).UploadImage), ctx, prefix, file, fileSize, metadata)
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+)
+
+var (
+	ErrGroupInvalidName    = errors.New("name must be between 2 and 100 characters")
+	ErrGroupInvalidDesc    = errors.New("description must be at most 255 characters")
+	ErrGroupProtectedRole  = errors.New("protected roles cannot be assigned to groups")
+	ErrGroupTooManyMembers = errors.New("too many users in a single request")
+)
+
+const maxGroupMembersPerChange = 500
+
+type CreateGroupInput struct {
+	Name        string
+	Description string
+	RoleIDs     []uint
+}
+
+type UpdateGroupInput struct {
+	Name        *string
+	Description *string
+	RoleIDs     *[]uint
+}
+
+// DefaultGroupService manages groups and keeps the permission cache of every
+// affected member consistent with group membership and group roles.
+type DefaultGroupService struct {
+	repo           repository.GroupRepository
+	roleRepo       repository.RoleRepository
+	userRepo       repository.UserRepository
+	resolver       PermissionResolver
+	protectedRoles map[string]struct{}
+}
+
+// NewGroupService builds the group service. Roles named in protectedRoles
+// cannot be assigned to groups, so protected grants keep going through the
+// per-user approval flow.
+func NewGroupService(repo repository.GroupRepository, roleRepo repository.RoleRepository, userRepo repository.UserRepository, resolver PermissionResolver, protectedRoles []string) *DefaultGroupService {
+	protected := make(map[string]struct{}, len(protectedRoles))
+	for _, role := range protectedRoles {
+		if trimmed := strings.ToLower(strings.TrimSpace(role)); trimmed != "" {
+			protected[trimmed] = struct{}{}
+		}
+	}
+	return &DefaultGroupService{repo: repo, roleRepo: roleRepo, userRepo: userRepo, resolver: resolver, protectedRoles: protected}
+}
+
+func (s *DefaultGroupService) CreateGroup(ctx context.Context, input CreateGroupInput) (*domain.Group, error) {
+	name, desc, err := validateGroupFields(input.Name, input.Description)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkRoles(input.RoleIDs); err != nil {
+		return nil, err
+	}
+	group := &domain.Group{Name: name, Description: desc}
+	if err := s.repo.Create(group, input.RoleIDs); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+func (s *DefaultGroupService) ListGroups(ctx context.Context, req repository.PageRequest, name string) (repository.PageResult[domain.Group], error) {
+	return s.repo.ListPaged(req, name)
+}
+
+func (s *DefaultGroupService) GetGroup(ctx context.Context, id uint) (*domain.Group, error) {
+	return s.repo.FindByID(id)
+}
+
+func (s *DefaultGroupService) UpdateGroup(ctx context.Context, id uint, input UpdateGroupInput) (*domain.Group, error) {
+	group, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	name, desc := group.Name, group.Description
+	if input.Name != nil {
+		name = *input.Name
+	}
+	if input.Description != nil {
+		desc = *input.Description
+	}
+	name, desc, err = validateGroupFields(name, desc)
+	if err != nil {
+		return nil, err
+	}
+	roleIDs := make([]uint, 0, len(group.Roles))
+	for _, role := range group.Roles {
+		roleIDs = append(roleIDs, role.ID)
+	}
+	rolesChanged := false
+	if input.RoleIDs != nil {
+		if err := s.checkRoles(*input.RoleIDs); err != nil {
+			return nil, err
+		}
+		rolesChanged = !sameUintSet(roleIDs, *input.RoleIDs)
+		roleIDs = *input.RoleIDs
+	}
+	group.Name, group.Description = name, desc
+	if err := s.repo.Update(group, roleIDs); err != nil {
+		return nil, err
+	}
+	if rolesChanged {
+		s.invalidateMembers(ctx, id)
+	}
+	return group, nil
+}
+
+func (s *DefaultGroupService) DeleteGroup(ctx context.Context, id uint) error {
+	memberIDs, err := s.repo.ListMemberIDs(id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteByID(id); err != nil {
+		return err
+	}
+	s.invalidateUsers(ctx, memberIDs)
+	return nil
+}
+
+func (s *DefaultGroupService) ListMembers(ctx context.Context, groupID uint, req repository.PageRequest) (repository.PageResult[domain.User], error) {
+	if _, err := s.repo.FindByID(groupID); err != nil {
+		return repository.PageResult[domain.User]{}, err
+	}
+	return s.userRepo.ListPaged(repository.UserListQuery{PageRequest: req, GroupID: groupID})
+}
+
+func (s *DefaultGroupService) AddMembers(ctx context.Context, groupID uint, userIDs []uint) ([]uint, error) {
+	if len(userIDs) > maxGroupMembersPerChange {
+		return nil, ErrGroupTooManyMembers
+	}
+	added, err := s.repo.AddMembers(groupID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateUsers(ctx, added)
+	return added, nil
+}
+
+func (s *DefaultGroupService) RemoveMember(ctx context.Context, groupID, userID uint) error {
+	if err := s.repo.RemoveMember(groupID, userID); err != nil {
+		return err
+	}
+	s.invalidateUsers(ctx, []uint{userID})
+	return nil
+}
+
+func (s *DefaultGroupService) checkRoles(roleIDs []uint) error {
+	for _, roleID := range roleIDs {
+		role, err := s.roleRepo.FindByID(roleID)
+		if err != nil {
+			return err
+		}
+		if _, ok := s.protectedRoles[strings.ToLower(role.Name)]; ok {
+			return ErrGroupProtectedRole
+		}
+	}
+	return nil
+}
+
+func (s *DefaultGroupService) invalidateMembers(ctx context.Context, groupID uint) {
+	memberIDs, err := s.repo.ListMemberIDs(groupID)
+	if err != nil {
+		// Member lookup failed, so fall back to dropping every cached entry.
+		if s.resolver != nil {
+			if err := s.resolver.InvalidateAll(ctx); err != nil {
+				slog.Warn("rbac permission cache global invalidation failed", "group_id", groupID, "error", err)
+			}
+		}
+		return
+	}
+	s.invalidateUsers(ctx, memberIDs)
+}
+
+func (s *DefaultGroupService) invalidateUsers(ctx context.Context, userIDs []uint) {
+	if s.resolver == nil {
+		return
+	}
+	for _, userID := range userIDs {
+		if err := s.resolver.InvalidateUser(ctx, userID); err != nil {
+			slog.Warn("rbac permission cache user invalidation failed", "user_id", userID, "error", err)
+		}
+	}
+}
+
+func validateGroupFields(name, desc string) (string, string, error) {
+	name = strings.TrimSpace(name)
+	desc = strings.TrimSpace(desc)
+	if len(name) < 2 || len(name) > 100 {
+		return "", "", ErrGroupInvalidName
+	}
+	if len(desc) > 255 {
+		return "", "", ErrGroupInvalidDesc
+	}
+	return name, desc, nil
+}
+
+func sameUintSet(a, b []uint) bool {
+	set := make(map[uint]struct{}, len(a))
+	for _, v := range a {
+		set[v] = struct{}{}
+	}
+	other := make(map[uint]struct{}, len(b))
+	for _, v := range b {
+		if _, ok := set[v]; !ok {
+			return false
+		}
+		other[v] = struct{}{}
+	}
+	return len(set) == len(other)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	repogomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGroupServiceMembershipChangesInvalidatePermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockGroupRepository(ctrl)
+	resolver := NewMockPermissionResolver(ctrl)
+	svc := NewGroupService(repo, nil, nil, resolver, nil)
+
+	repo.EXPECT().AddMembers(uint(5), []uint{1, 2, 3}).Return([]uint{2, 3}, nil)
+	resolver.EXPECT().InvalidateUser(gomock.Any(), uint(2)).Return(nil)
+	resolver.EXPECT().InvalidateUser(gomock.Any(), uint(3)).Return(nil)
+	added, err := svc.AddMembers(context.Background(), 5, []uint{1, 2, 3})
+	if err != nil {
+		t.Fatalf("add members: %v", err)
+	}
+	if len(added) != 2 {
+		t.Fatalf("expected 2 added, got %v", added)
+	}
+
+	repo.EXPECT().RemoveMember(uint(5), uint(2)).Return(nil)
+	resolver.EXPECT().InvalidateUser(gomock.Any(), uint(2)).Return(nil)
+	if err := svc.RemoveMember(context.Background(), 5, 2); err != nil {
+		t.Fatalf("remove member: %v", err)
+	}
+
+	repo.EXPECT().ListMemberIDs(uint(5)).Return([]uint{3}, nil)
+	repo.EXPECT().DeleteByID(uint(5)).Return(nil)
+	resolver.EXPECT().InvalidateUser(gomock.Any(), uint(3)).Return(nil)
+	if err := svc.DeleteGroup(context.Background(), 5); err != nil {
+		t.Fatalf("delete group: %v", err)
+	}
+}
+
+func TestGroupServiceUpdateRolesInvalidatesMembersOnlyWhenChanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockGroupRepository(ctrl)
+	roleRepo := repogomock.NewMockRoleRepository(ctrl)
+	resolver := NewMockPermissionResolver(ctrl)
+	svc := NewGroupService(repo, roleRepo, nil, resolver, []string{"admin"})
+
+	repo.EXPECT().FindByID(uint(5)).Return(&domain.Group{ID: 5, Name: "platform", Roles: []domain.Role{{ID: 7}}}, nil).Times(3)
+	roleRepo.EXPECT().FindByID(uint(7)).Return(&domain.Role{ID: 7, Name: "engineer"}, nil).Times(2)
+	roleRepo.EXPECT().FindByID(uint(8)).Return(&domain.Role{ID: 8, Name: "oncall"}, nil)
+	roleRepo.EXPECT().FindByID(uint(1)).Return(&domain.Role{ID: 1, Name: "admin"}, nil)
+
+	unchanged := []uint{7}
+	repo.EXPECT().Update(gomock.Any(), []uint{7}).Return(nil)
+	if _, err := svc.UpdateGroup(context.Background(), 5, UpdateGroupInput{RoleIDs: &unchanged}); err != nil {
+		t.Fatalf("update unchanged roles: %v", err)
+	}
+
+	changed := []uint{7, 8}
+	repo.EXPECT().Update(gomock.Any(), []uint{7, 8}).Return(nil)
+	repo.EXPECT().ListMemberIDs(uint(5)).Return([]uint{11, 12}, nil)
+	resolver.EXPECT().InvalidateUser(gomock.Any(), uint(11)).Return(nil)
+	resolver.EXPECT().InvalidateUser(gomock.Any(), uint(12)).Return(nil)
+	if _, err := svc.UpdateGroup(context.Background(), 5, UpdateGroupInput{RoleIDs: &changed}); err != nil {
+		t.Fatalf("update changed roles: %v", err)
+	}
+
+	protected := []uint{1}
+	if _, err := svc.UpdateGroup(context.Background(), 5, UpdateGroupInput{RoleIDs: &protected}); !errors.Is(err, ErrGroupProtectedRole) {
+		t.Fatalf("expected ErrGroupProtectedRole, got %v", err)
+	}
+}
+
+func TestGroupServiceValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := NewGroupService(repogomock.NewMockGroupRepository(ctrl), nil, nil, nil, nil)
+
+	if _, err := svc.CreateGroup(context.Background(), CreateGroupInput{Name: " x "}); !errors.Is(err, ErrGroupInvalidName) {
+		t.Fatalf("expected ErrGroupInvalidName, got %v", err)
+	}
+	if _, err := svc.AddMembers(context.Background(), 1, make([]uint, maxGroupMembersPerChange+1)); !errors.Is(err, ErrGroupTooManyMembers) {
+		t.Fatalf("expected ErrGroupTooManyMembers, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"io"
 	"net/http"
 
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
@@ -49,8 +50,13 @@ type SessionServiceInterface interface {
 
 type FeatureFlagService interface {
 	EvaluateAll(ctx context.Context, evalCtx FeatureFlagEvaluationContext) ([]FeatureFlagEvaluationResult, error)
+	EvaluateAllUnrecorded(ctx context.Context, evalCtx FeatureFlagEvaluationContext) ([]FeatureFlagEvaluationResult, error)
+	RecordExposures(ctx context.Context, evalCtx FeatureFlagEvaluationContext, results []FeatureFlagEvaluationResult)
 	EvaluateByKey(ctx context.Context, key string, evalCtx FeatureFlagEvaluationContext) (*FeatureFlagEvaluationResult, error)
+	EvaluateKeys(ctx context.Context, keys []string, evalCtx FeatureFlagEvaluationContext) ([]FeatureFlagEvaluationResult, error)
 	ListFlags(ctx context.Context) ([]domain.FeatureFlag, error)
+	Snapshot(ctx context.Context) (*FeatureFlagSnapshot, error)
+	DependencyGraph(ctx context.Context) (*FeatureFlagDependencyGraph, error)
 	GetFlagByID(ctx context.Context, id uint) (*domain.FeatureFlag, error)
 	CreateFlag(ctx context.Context, flag *domain.FeatureFlag) error
 	UpdateFlag(ctx context.Context, flag *domain.FeatureFlag) error
@@ -59,12 +65,120 @@ type FeatureFlagService interface {
 	CreateRule(ctx context.Context, rule *domain.FeatureFlagRule) error
 	UpdateRule(ctx context.Context, rule *domain.FeatureFlagRule) error
 	DeleteRule(ctx context.Context, flagID, ruleID uint) error
+	ListHistory(ctx context.Context, flagID uint, limit int) ([]domain.FeatureFlagVersion, error)
+	Rollback(ctx context.Context, flagID uint, version int) (*domain.FeatureFlag, error)
+	SubscribeChanges(ctx context.Context, lastEventID string) (*FeatureFlagChangeSubscription, error)
+}
+
+type FeatureFlagScheduleService interface {
+	CreateSchedule(ctx context.Context, schedule *domain.FeatureFlagSchedule) error
+	ListSchedules(ctx context.Context, flagID uint) ([]domain.FeatureFlagSchedule, error)
+	CancelSchedule(ctx context.Context, flagID, scheduleID uint) (*domain.FeatureFlagSchedule, error)
+	ResumeSchedule(ctx context.Context, flagID, scheduleID uint) (*domain.FeatureFlagSchedule, error)
+}
+
+type FeatureFlagUsageService interface {
+	Usage(ctx context.Context, flagID uint) (*FeatureFlagUsage, error)
+	StaleFlags(ctx context.Context, days int) ([]StaleFeatureFlag, error)
+	ListExposures(ctx context.Context, flagID, beforeID uint, limit int) ([]domain.FeatureFlagExposure, error)
 }
 
 type ProductService interface {
 	Create(ctx context.Context, input CreateProductInput) (*domain.Product, error)
-	ListPaged(ctx context.Context, req repository.PageRequest) (repository.PageResult[domain.Product], error)
+	ValidateCreate(ctx context.Context, input CreateProductInput) error
+	ListPaged(ctx context.Context, query repository.ProductListQuery) (repository.PageResult[domain.Product], error)
+	ListByCursor(ctx context.Context, query repository.ProductListQuery, cursor repository.CursorRequest) (repository.CursorResult[domain.Product], error)
 	GetByID(ctx context.Context, id uint) (*domain.Product, error)
-	Update(ctx context.Context, id uint, input UpdateProductInput) (*domain.Product, error)
-	DeleteByID(ctx context.Context, id uint) error
+	Update(ctx context.Context, id, version uint, input UpdateProductInput) (*domain.Product, error)
+	DeleteByID(ctx context.Context, id, version uint) error
+	ListDeleted(ctx context.Context, req repository.PageRequest) (repository.PageResult[domain.Product], error)
+	Restore(ctx context.Context, id uint) (*domain.Product, error)
+	Purge(ctx context.Context, id uint) error
+	ListGrants(ctx context.Context, productID uint) ([]domain.ProductGrant, error)
+	ShareProduct(ctx context.Context, productID uint, input ShareProductInput) (*domain.ProductGrant, error)
+	RevokeShare(ctx context.Context, productID, grantID uint) error
+	UploadImage(ctx context.Context, productID uint, file io.Reader, fileSize int64) (*domain.ProductImage, error)
+	ReorderImages(ctx context.Context, productID uint, imageIDs []uint) ([]domain.ProductImage, error)
+	DeleteImage(ctx context.Context, productID, imageID uint) error
+	ImageURL(ctx context.Context, productID, imageID uint) (string, error)
+}
+
+type ProductImportService interface {
+	StartImport(ctx context.Context, input ProductImportInput) (*domain.ProductImportJob, error)
+	GetImportJob(ctx context.Context, id uint) (*domain.ProductImportJob, error)
+	Export(ctx context.Context, format string, w io.Writer) error
+}
+
+type CategoryService interface {
+	CreateCategory(ctx context.Context, input CreateCategoryInput) (*domain.Category, error)
+	ListCategories(ctx context.Context) ([]domain.Category, error)
+	GetCategory(ctx context.Context, id uint) (*domain.Category, error)
+	UpdateCategory(ctx context.Context, id uint, input UpdateCategoryInput) (*domain.Category, error)
+	DeleteCategory(ctx context.Context, id uint) error
+}
+
+type InventoryService interface {
+	GetStock(ctx context.Context, productID uint) (*domain.ProductStock, error)
+	AdjustStock(ctx context.Context, productID uint, input AdjustStockInput) (*domain.ProductStock, error)
+	ListMovements(ctx context.Context, productID uint, req repository.PageRequest) (repository.PageResult[domain.StockMovement], error)
+	Reserve(ctx context.Context, input ReserveStockInput) (*domain.StockReservation, error)
+	GetReservation(ctx context.Context, id uint) (*domain.StockReservation, error)
+	CommitReservation(ctx context.Context, id uint) (*domain.StockReservation, error)
+	ReleaseReservation(ctx context.Context, id uint) (*domain.StockReservation, error)
+}
+
+type CartService interface {
+	GetCart(ctx context.Context, owner CartOwner) (*domain.Cart, error)
+	SetItem(ctx context.Context, owner CartOwner, productID uint, quantity int64) (*domain.Cart, error)
+	Clear(ctx context.Context, owner CartOwner) error
+	CartMerger
+}
+
+// CartMerger moves a guest's cart into the user's cart when the guest signs
+// in.
+type CartMerger interface {
+	MergeGuestCart(ctx context.Context, guestToken string, userID uint) error
+}
+
+type OrderService interface {
+	Checkout(ctx context.Context, userID uint, input CheckoutInput) (*domain.Order, error)
+	ListOrders(ctx context.Context, query repository.OrderListQuery) (repository.PageResult[domain.Order], error)
+	GetOrder(ctx context.Context, id, ownerID uint) (*domain.Order, error)
+	Pay(ctx context.Context, id, ownerID uint) (*domain.Order, error)
+	Fulfill(ctx context.Context, id uint) (*domain.Order, error)
+	Cancel(ctx context.Context, id, ownerID uint) (*domain.Order, error)
+	Refund(ctx context.Context, id uint) (*domain.Order, error)
+}
+
+type RoleChangeRequestService interface {
+	Submit(ctx context.Context, targetUserID, requestedBy uint, roleIDs []uint) (*domain.RoleChangeRequest, error)
+	Approve(ctx context.Context, id, approverID uint, reason string) (*domain.RoleChangeRequest, error)
+	Reject(ctx context.Context, id, approverID uint, reason string) (*domain.RoleChangeRequest, error)
+	List(ctx context.Context, req repository.PageRequest, status string) (repository.PageResult[domain.RoleChangeRequest], error)
+}
+
+type OrganizationService interface {
+	CreateOrganization(ctx context.Context, slug, name string) (*domain.Organization, error)
+	ListOrganizations(ctx context.Context, req repository.PageRequest) (repository.PageResult[domain.Organization], error)
+	GetOrganization(ctx context.Context, id uint) (*domain.Organization, error)
+	DeleteOrganization(ctx context.Context, id uint) error
+	ResolveTenant(ctx context.Context, selector string, userID uint) (*Tenant, error)
+	ListMembers(ctx context.Context, orgID uint, req repository.PageRequest) (repository.PageResult[domain.Membership], error)
+	SetMemberRoles(ctx context.Context, orgID, userID uint, roleIDs []uint) (*domain.Membership, error)
+	SetOrganizationMember(ctx context.Context, orgID, userID uint, roleIDs []uint) (*domain.Membership, error)
+	RemoveMember(ctx context.Context, orgID, userID uint) error
+	ListInvitations(ctx context.Context, userID uint) ([]domain.Organization, error)
+	AcceptInvitation(ctx context.Context, orgID, userID uint) (*domain.Membership, error)
+	DeclineInvitation(ctx context.Context, orgID, userID uint) error
+}
+
+type GroupService interface {
+	CreateGroup(ctx context.Context, input CreateGroupInput) (*domain.Group, error)
+	ListGroups(ctx context.Context, req repository.PageRequest, name string) (repository.PageResult[domain.Group], error)
+	GetGroup(ctx context.Context, id uint) (*domain.Group, error)
+	UpdateGroup(ctx context.Context, id uint, input UpdateGroupInput) (*domain.Group, error)
+	DeleteGroup(ctx context.Context, id uint) error
+	ListMembers(ctx context.Context, groupID uint, req repository.PageRequest) (repository.PageResult[domain.User], error)
+	AddMembers(ctx context.Context, groupID uint, userIDs []uint) ([]uint, error)
+	RemoveMember(ctx context.Context, groupID, userID uint) error
 }
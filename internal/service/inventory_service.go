@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+)
+
+const (
+	maxStockQuantity   = 1_000_000
+	maxStockReasonSize = 200
+)
+
+var (
+	ErrInventoryInvalidQuantity = fmt.Errorf("quantity must be between 1 and %d", maxStockQuantity)
+	ErrInventoryInvalidDelta    = fmt.Errorf("delta must be non-zero and at most %d in either direction", maxStockQuantity)
+	ErrInventoryInvalidReason   = fmt.Errorf("reason must be <= %d characters", maxStockReasonSize)
+	ErrInventoryInvalidTTL      = errors.New("ttl must be positive and within the configured maximum")
+)
+
+type AdjustStockInput struct {
+	Delta  int64
+	Reason string
+}
+
+// ReserveStockInput holds Quantity units of a product. A zero TTL uses the
+// configured default.
+type ReserveStockInput struct {
+	ProductID uint
+	Quantity  int64
+	TTL       time.Duration
+}
+
+type DefaultInventoryService struct {
+	repo       repository.InventoryRepository
+	products   repository.ProductRepository
+	defaultTTL time.Duration
+	maxTTL     time.Duration
+	now        func() time.Time
+}
+
+func NewInventoryService(repo repository.InventoryRepository, products repository.ProductRepository, defaultTTL, maxTTL time.Duration) *DefaultInventoryService {
+	return &DefaultInventoryService{repo: repo, products: products, defaultTTL: defaultTTL, maxTTL: maxTTL, now: time.Now}
+}
+
+func (s *DefaultInventoryService) GetStock(ctx context.Context, productID uint) (*domain.ProductStock, error) {
+	start := time.Now()
+	outcome := "success"
+	defer func() { observability.RecordProductOperation(ctx, "stock_get", outcome, time.Since(start)) }()
+
+	if err := s.checkProduct(ctx, productID); err != nil {
+		outcome = inventoryOperationOutcome(err)
+		return nil, err
+	}
+	stock, err := s.repo.GetStock(productID)
+	if err != nil {
+		outcome = "error"
+		return nil, err
+	}
+	return stock, nil
+}
+
+func (s *DefaultInventoryService) AdjustStock(ctx context.Context, productID uint, input AdjustStockInput) (*domain.ProductStock, error) {
+	start := time.Now()
+	outcome := "success"
+	defer func() { observability.RecordProductOperation(ctx, "stock_adjust", outcome, time.Since(start)) }()
+
+	if input.Delta == 0 || input.Delta > maxStockQuantity || input.Delta < -maxStockQuantity {
+		outcome = "bad_request"
+		return nil, ErrInventoryInvalidDelta
+	}
+	reason := strings.TrimSpace(input.Reason)
+	if len(reason) > maxStockReasonSize {
+		outcome = "bad_request"
+		return nil, ErrInventoryInvalidReason
+	}
+	if err := s.checkProduct(ctx, productID); err != nil {
+		outcome = inventoryOperationOutcome(err)
+		return nil, err
+	}
+	stock, err := s.repo.Adjust(productID, input.Delta, actorFromContext(ctx), reason)
+	if err != nil {
+		outcome = inventoryOperationOutcome(err)
+		return nil, err
+	}
+	return stock, nil
+}
+
+func (s *DefaultInventoryService) ListMovements(ctx context.Context, productID uint, req repository.PageRequest) (repository.PageResult[domain.StockMovement], error) {
+	start := time.Now()
+	outcome := "success"
+	defer func() { observability.RecordProductOperation(ctx, "stock_movements", outcome, time.Since(start)) }()
+
+	if err := s.checkProduct(ctx, productID); err != nil {
+		outcome = inventoryOperationOutcome(err)
+		return repository.PageResult[domain.StockMovement]{}, err
+	}
+	res, err := s.repo.ListMovements(productID, req)
+	if err != nil {
+		outcome = "error"
+		return repository.PageResult[domain.StockMovement]{}, err
+	}
+	return res, nil
+}
+
+func (s *DefaultInventoryService) Reserve(ctx context.Context, input ReserveStockInput) (*domain.StockReservation, error) {
+	start := time.Now()
+	outcome := "success"
+	defer func() { observability.RecordProductOperation(ctx, "reserve", outcome, time.Since(start)) }()
+
+	if input.Quantity < 1 || input.Quantity > maxStockQuantity {
+		outcome = "bad_request"
+		return nil, ErrInventoryInvalidQuantity
+	}
+	ttl := input.TTL
+	if ttl == 0 {
+		ttl = s.defaultTTL
+	}
+	if ttl <= 0 || ttl > s.maxTTL {
+		outcome = "bad_request"
+		return nil, ErrInventoryInvalidTTL
+	}
+	if err := s.checkProduct(ctx, input.ProductID); err != nil {
+		outcome = inventoryOperationOutcome(err)
+		return nil, err
+	}
+	reservation := &domain.StockReservation{
+		ProductID: input.ProductID,
+		Quantity:  input.Quantity,
+		ExpiresAt: s.now().UTC().Add(ttl),
+		CreatedBy: actorFromContext(ctx),
+	}
+	if err := s.repo.Reserve(reservation); err != nil {
+		outcome = inventoryOperationOutcome(err)
+		return nil, err
+	}
+	return reservation, nil
+}
+
+func (s *DefaultInventoryService) GetReservation(ctx context.Context, id uint) (*domain.StockReservation, error) {
+	start := time.Now()
+	outcome := "success"
+	defer func() { observability.RecordProductOperation(ctx, "reservation_get", outcome, time.Since(start)) }()
+
+	reservation, err := s.findReservation(ctx, id)
+	if err != nil {
+		outcome = inventoryOperationOutcome(err)
+		return nil, err
+	}
+	return reservation, nil
+}
+
+func (s *DefaultInventoryService) CommitReservation(ctx context.Context, id uint) (*domain.StockReservation, error) {
+	start := time.Now()
+	outcome := "success"
+	defer func() { observability.RecordProductOperation(ctx, "reservation_commit", outcome, time.Since(start)) }()
+
+	if _, err := s.findReservation(ctx, id); err != nil {
+		outcome = inventoryOperationOutcome(err)
+		return nil, err
+	}
+	reservation, err := s.repo.CommitReservation(id, actorFromContext(ctx), s.now())
+	if err != nil {
+		outcome = inventoryOperationOutcome(err)
+		return nil, err
+	}
+	return reservation, nil
+}
+
+func (s *DefaultInventoryService) ReleaseReservation(ctx context.Context, id uint) (*domain.StockReservation, error) {
+	start := time.Now()
+	outcome := "success"
+	defer func() { observability.RecordProductOperation(ctx, "reservation_release", outcome, time.Since(start)) }()
+
+	if _, err := s.findReservation(ctx, id); err != nil {
+		outcome = inventoryOperationOutcome(err)
+		return nil, err
+	}
+	reservation, err := s.repo.ReleaseReservation(id, actorFromContext(ctx), s.now())
+	if err != nil {
+		outcome = inventoryOperationOutcome(err)
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// checkProduct confirms the product exists in the caller's tenant.
+func (s *DefaultInventoryService) checkProduct(ctx context.Context, productID uint) error {
+	products := s.products
+	if tenant, ok := TenantFromContext(ctx); ok {
+		products = products.ForOrganization(tenant.OrganizationID)
+	}
+	_, err := products.FindByID(productID)
+	return err
+}
+
+// findReservation loads a reservation whose product is in the caller's
+// tenant; reservations of other tenants look missing.
+func (s *DefaultInventoryService) findReservation(ctx context.Context, id uint) (*domain.StockReservation, error) {
+	reservation, err := s.repo.FindReservation(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkProduct(ctx, reservation.ProductID); err != nil {
+		if errors.Is(err, repository.ErrProductNotFound) {
+			return nil, repository.ErrReservationNotFound
+		}
+		return nil, err
+	}
+	return reservation, nil
+}
+
+func actorFromContext(ctx context.Context) *uint {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.System {
+		return nil
+	}
+	id := principal.UserID
+	return &id
+}
+
+func inventoryOperationOutcome(err error) string {
+	switch {
+	case errors.Is(err, repository.ErrProductNotFound), errors.Is(err, repository.ErrReservationNotFound):
+		return "not_found"
+	case errors.Is(err, repository.ErrInsufficientStock), errors.Is(err, repository.ErrReservationNotActive):
+		return "conflict"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	repogomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInventoryServiceValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := NewInventoryService(repogomock.NewMockInventoryRepository(ctrl), repogomock.NewMockProductRepository(ctrl), 15*time.Minute, time.Hour)
+	ctx := context.Background()
+
+	if _, err := svc.AdjustStock(ctx, 1, AdjustStockInput{}); !errors.Is(err, ErrInventoryInvalidDelta) {
+		t.Fatalf("expected ErrInventoryInvalidDelta, got %v", err)
+	}
+	if _, err := svc.AdjustStock(ctx, 1, AdjustStockInput{Delta: 1, Reason: string(make([]byte, 201))}); !errors.Is(err, ErrInventoryInvalidReason) {
+		t.Fatalf("expected ErrInventoryInvalidReason, got %v", err)
+	}
+	if _, err := svc.Reserve(ctx, ReserveStockInput{ProductID: 1}); !errors.Is(err, ErrInventoryInvalidQuantity) {
+		t.Fatalf("expected ErrInventoryInvalidQuantity, got %v", err)
+	}
+	if _, err := svc.Reserve(ctx, ReserveStockInput{ProductID: 1, Quantity: 1, TTL: 2 * time.Hour}); !errors.Is(err, ErrInventoryInvalidTTL) {
+		t.Fatalf("expected ErrInventoryInvalidTTL, got %v", err)
+	}
+}
+
+func TestInventoryServiceReserveUsesDefaultTTLAndActor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockInventoryRepository(ctrl)
+	products := repogomock.NewMockProductRepository(ctrl)
+	svc := NewInventoryService(repo, products, 15*time.Minute, time.Hour)
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	products.EXPECT().FindByID(uint(3)).Return(&domain.Product{ID: 3}, nil)
+	repo.EXPECT().Reserve(gomock.AssignableToTypeOf(&domain.StockReservation{})).DoAndReturn(func(r *domain.StockReservation) error {
+		if !r.ExpiresAt.Equal(now.Add(15*time.Minute)) || r.CreatedBy == nil || *r.CreatedBy != 9 || r.Quantity != 2 {
+			t.Fatalf("unexpected reservation: %+v", r)
+		}
+		r.ID = 11
+		return nil
+	})
+
+	ctx := WithPrincipal(context.Background(), Principal{UserID: 9})
+	reservation, err := svc.Reserve(ctx, ReserveStockInput{ProductID: 3, Quantity: 2})
+	if err != nil || reservation.ID != 11 {
+		t.Fatalf("reserve: %+v err=%v", reservation, err)
+	}
+}
+
+func TestInventoryServiceHidesOtherTenantsReservations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockInventoryRepository(ctrl)
+	products := repogomock.NewMockProductRepository(ctrl)
+	scoped := repogomock.NewMockProductRepository(ctrl)
+	svc := NewInventoryService(repo, products, 15*time.Minute, time.Hour)
+
+	repo.EXPECT().FindReservation(uint(5)).Return(&domain.StockReservation{ID: 5, ProductID: 3, Status: domain.ReservationActive}, nil)
+	products.EXPECT().ForOrganization(uint(4)).Return(scoped)
+	scoped.EXPECT().FindByID(uint(3)).Return(nil, repository.ErrProductNotFound)
+
+	ctx := WithTenant(context.Background(), Tenant{OrganizationID: 4, Slug: "acme"})
+	if _, err := svc.CommitReservation(ctx, 5); !errors.Is(err, repository.ErrReservationNotFound) {
+		t.Fatalf("expected ErrReservationNotFound, got %v", err)
+	}
+}
@@ -11,6 +11,7 @@ package service
 
 import (
 	context "context"
+	io "io"
 	http "net/http"
 	reflect "reflect"
 
@@ -555,6 +556,21 @@ func (mr *MockFeatureFlagServiceMockRecorder) DeleteRule(ctx, flagID, ruleID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockFeatureFlagService)(nil).DeleteRule), ctx, flagID, ruleID)
 }
 
+// DependencyGraph mocks base method.
+func (m *MockFeatureFlagService) DependencyGraph(ctx context.Context) (*FeatureFlagDependencyGraph, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DependencyGraph", ctx)
+	ret0, _ := ret[0].(*FeatureFlagDependencyGraph)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DependencyGraph indicates an expected call of DependencyGraph.
+func (mr *MockFeatureFlagServiceMockRecorder) DependencyGraph(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DependencyGraph", reflect.TypeOf((*MockFeatureFlagService)(nil).DependencyGraph), ctx)
+}
+
 // EvaluateAll mocks base method.
 func (m *MockFeatureFlagService) EvaluateAll(ctx context.Context, evalCtx FeatureFlagEvaluationContext) ([]FeatureFlagEvaluationResult, error) {
 	m.ctrl.T.Helper()
@@ -570,6 +586,21 @@ func (mr *MockFeatureFlagServiceMockRecorder) EvaluateAll(ctx, evalCtx any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluateAll", reflect.TypeOf((*MockFeatureFlagService)(nil).EvaluateAll), ctx, evalCtx)
 }
 
+// EvaluateAllUnrecorded mocks base method.
+func (m *MockFeatureFlagService) EvaluateAllUnrecorded(ctx context.Context, evalCtx FeatureFlagEvaluationContext) ([]FeatureFlagEvaluationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EvaluateAllUnrecorded", ctx, evalCtx)
+	ret0, _ := ret[0].([]FeatureFlagEvaluationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EvaluateAllUnrecorded indicates an expected call of EvaluateAllUnrecorded.
+func (mr *MockFeatureFlagServiceMockRecorder) EvaluateAllUnrecorded(ctx, evalCtx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluateAllUnrecorded", reflect.TypeOf((*MockFeatureFlagService)(nil).EvaluateAllUnrecorded), ctx, evalCtx)
+}
+
 // EvaluateByKey mocks base method.
 func (m *MockFeatureFlagService) EvaluateByKey(ctx context.Context, key string, evalCtx FeatureFlagEvaluationContext) (*FeatureFlagEvaluationResult, error) {
 	m.ctrl.T.Helper()
@@ -585,6 +616,21 @@ func (mr *MockFeatureFlagServiceMockRecorder) EvaluateByKey(ctx, key, evalCtx an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluateByKey", reflect.TypeOf((*MockFeatureFlagService)(nil).EvaluateByKey), ctx, key, evalCtx)
 }
 
+// EvaluateKeys mocks base method.
+func (m *MockFeatureFlagService) EvaluateKeys(ctx context.Context, keys []string, evalCtx FeatureFlagEvaluationContext) ([]FeatureFlagEvaluationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EvaluateKeys", ctx, keys, evalCtx)
+	ret0, _ := ret[0].([]FeatureFlagEvaluationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EvaluateKeys indicates an expected call of EvaluateKeys.
+func (mr *MockFeatureFlagServiceMockRecorder) EvaluateKeys(ctx, keys, evalCtx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluateKeys", reflect.TypeOf((*MockFeatureFlagService)(nil).EvaluateKeys), ctx, keys, evalCtx)
+}
+
 // GetFlagByID mocks base method.
 func (m *MockFeatureFlagService) GetFlagByID(ctx context.Context, id uint) (*domain.FeatureFlag, error) {
 	m.ctrl.T.Helper()
@@ -615,6 +661,21 @@ func (mr *MockFeatureFlagServiceMockRecorder) ListFlags(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFlags", reflect.TypeOf((*MockFeatureFlagService)(nil).ListFlags), ctx)
 }
 
+// ListHistory mocks base method.
+func (m *MockFeatureFlagService) ListHistory(ctx context.Context, flagID uint, limit int) ([]domain.FeatureFlagVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHistory", ctx, flagID, limit)
+	ret0, _ := ret[0].([]domain.FeatureFlagVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHistory indicates an expected call of ListHistory.
+func (mr *MockFeatureFlagServiceMockRecorder) ListHistory(ctx, flagID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHistory", reflect.TypeOf((*MockFeatureFlagService)(nil).ListHistory), ctx, flagID, limit)
+}
+
 // ListRules mocks base method.
 func (m *MockFeatureFlagService) ListRules(ctx context.Context, flagID uint) ([]domain.FeatureFlagRule, error) {
 	m.ctrl.T.Helper()
@@ -630,6 +691,63 @@ func (mr *MockFeatureFlagServiceMockRecorder) ListRules(ctx, flagID any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRules", reflect.TypeOf((*MockFeatureFlagService)(nil).ListRules), ctx, flagID)
 }
 
+// RecordExposures mocks base method.
+func (m *MockFeatureFlagService) RecordExposures(ctx context.Context, evalCtx FeatureFlagEvaluationContext, results []FeatureFlagEvaluationResult) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordExposures", ctx, evalCtx, results)
+}
+
+// RecordExposures indicates an expected call of RecordExposures.
+func (mr *MockFeatureFlagServiceMockRecorder) RecordExposures(ctx, evalCtx, results any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordExposures", reflect.TypeOf((*MockFeatureFlagService)(nil).RecordExposures), ctx, evalCtx, results)
+}
+
+// Rollback mocks base method.
+func (m *MockFeatureFlagService) Rollback(ctx context.Context, flagID uint, version int) (*domain.FeatureFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", ctx, flagID, version)
+	ret0, _ := ret[0].(*domain.FeatureFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockFeatureFlagServiceMockRecorder) Rollback(ctx, flagID, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockFeatureFlagService)(nil).Rollback), ctx, flagID, version)
+}
+
+// Snapshot mocks base method.
+func (m *MockFeatureFlagService) Snapshot(ctx context.Context) (*FeatureFlagSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot", ctx)
+	ret0, _ := ret[0].(*FeatureFlagSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockFeatureFlagServiceMockRecorder) Snapshot(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockFeatureFlagService)(nil).Snapshot), ctx)
+}
+
+// SubscribeChanges mocks base method.
+func (m *MockFeatureFlagService) SubscribeChanges(ctx context.Context, lastEventID string) (*FeatureFlagChangeSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeChanges", ctx, lastEventID)
+	ret0, _ := ret[0].(*FeatureFlagChangeSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeChanges indicates an expected call of SubscribeChanges.
+func (mr *MockFeatureFlagServiceMockRecorder) SubscribeChanges(ctx, lastEventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeChanges", reflect.TypeOf((*MockFeatureFlagService)(nil).SubscribeChanges), ctx, lastEventID)
+}
+
 // UpdateFlag mocks base method.
 func (m *MockFeatureFlagService) UpdateFlag(ctx context.Context, flag *domain.FeatureFlag) error {
 	m.ctrl.T.Helper()
@@ -658,6 +776,158 @@ func (mr *MockFeatureFlagServiceMockRecorder) UpdateRule(ctx, rule any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRule", reflect.TypeOf((*MockFeatureFlagService)(nil).UpdateRule), ctx, rule)
 }
 
+// MockFeatureFlagScheduleService is a mock of FeatureFlagScheduleService interface.
+type MockFeatureFlagScheduleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeatureFlagScheduleServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockFeatureFlagScheduleServiceMockRecorder is the mock recorder for MockFeatureFlagScheduleService.
+type MockFeatureFlagScheduleServiceMockRecorder struct {
+	mock *MockFeatureFlagScheduleService
+}
+
+// NewMockFeatureFlagScheduleService creates a new mock instance.
+func NewMockFeatureFlagScheduleService(ctrl *gomock.Controller) *MockFeatureFlagScheduleService {
+	mock := &MockFeatureFlagScheduleService{ctrl: ctrl}
+	mock.recorder = &MockFeatureFlagScheduleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeatureFlagScheduleService) EXPECT() *MockFeatureFlagScheduleServiceMockRecorder {
+	return m.recorder
+}
+
+// CancelSchedule mocks base method.
+func (m *MockFeatureFlagScheduleService) CancelSchedule(ctx context.Context, flagID, scheduleID uint) (*domain.FeatureFlagSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSchedule", ctx, flagID, scheduleID)
+	ret0, _ := ret[0].(*domain.FeatureFlagSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelSchedule indicates an expected call of CancelSchedule.
+func (mr *MockFeatureFlagScheduleServiceMockRecorder) CancelSchedule(ctx, flagID, scheduleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSchedule", reflect.TypeOf((*MockFeatureFlagScheduleService)(nil).CancelSchedule), ctx, flagID, scheduleID)
+}
+
+// CreateSchedule mocks base method.
+func (m *MockFeatureFlagScheduleService) CreateSchedule(ctx context.Context, schedule *domain.FeatureFlagSchedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSchedule", ctx, schedule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSchedule indicates an expected call of CreateSchedule.
+func (mr *MockFeatureFlagScheduleServiceMockRecorder) CreateSchedule(ctx, schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchedule", reflect.TypeOf((*MockFeatureFlagScheduleService)(nil).CreateSchedule), ctx, schedule)
+}
+
+// ListSchedules mocks base method.
+func (m *MockFeatureFlagScheduleService) ListSchedules(ctx context.Context, flagID uint) ([]domain.FeatureFlagSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSchedules", ctx, flagID)
+	ret0, _ := ret[0].([]domain.FeatureFlagSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSchedules indicates an expected call of ListSchedules.
+func (mr *MockFeatureFlagScheduleServiceMockRecorder) ListSchedules(ctx, flagID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSchedules", reflect.TypeOf((*MockFeatureFlagScheduleService)(nil).ListSchedules), ctx, flagID)
+}
+
+// ResumeSchedule mocks base method.
+func (m *MockFeatureFlagScheduleService) ResumeSchedule(ctx context.Context, flagID, scheduleID uint) (*domain.FeatureFlagSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeSchedule", ctx, flagID, scheduleID)
+	ret0, _ := ret[0].(*domain.FeatureFlagSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumeSchedule indicates an expected call of ResumeSchedule.
+func (mr *MockFeatureFlagScheduleServiceMockRecorder) ResumeSchedule(ctx, flagID, scheduleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeSchedule", reflect.TypeOf((*MockFeatureFlagScheduleService)(nil).ResumeSchedule), ctx, flagID, scheduleID)
+}
+
+// MockFeatureFlagUsageService is a mock of FeatureFlagUsageService interface.
+type MockFeatureFlagUsageService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeatureFlagUsageServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockFeatureFlagUsageServiceMockRecorder is the mock recorder for MockFeatureFlagUsageService.
+type MockFeatureFlagUsageServiceMockRecorder struct {
+	mock *MockFeatureFlagUsageService
+}
+
+// NewMockFeatureFlagUsageService creates a new mock instance.
+func NewMockFeatureFlagUsageService(ctrl *gomock.Controller) *MockFeatureFlagUsageService {
+	mock := &MockFeatureFlagUsageService{ctrl: ctrl}
+	mock.recorder = &MockFeatureFlagUsageServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeatureFlagUsageService) EXPECT() *MockFeatureFlagUsageServiceMockRecorder {
+	return m.recorder
+}
+
+// ListExposures mocks base method.
+func (m *MockFeatureFlagUsageService) ListExposures(ctx context.Context, flagID, beforeID uint, limit int) ([]domain.FeatureFlagExposure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExposures", ctx, flagID, beforeID, limit)
+	ret0, _ := ret[0].([]domain.FeatureFlagExposure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExposures indicates an expected call of ListExposures.
+func (mr *MockFeatureFlagUsageServiceMockRecorder) ListExposures(ctx, flagID, beforeID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExposures", reflect.TypeOf((*MockFeatureFlagUsageService)(nil).ListExposures), ctx, flagID, beforeID, limit)
+}
+
+// StaleFlags mocks base method.
+func (m *MockFeatureFlagUsageService) StaleFlags(ctx context.Context, days int) ([]StaleFeatureFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StaleFlags", ctx, days)
+	ret0, _ := ret[0].([]StaleFeatureFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StaleFlags indicates an expected call of StaleFlags.
+func (mr *MockFeatureFlagUsageServiceMockRecorder) StaleFlags(ctx, days any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StaleFlags", reflect.TypeOf((*MockFeatureFlagUsageService)(nil).StaleFlags), ctx, days)
+}
+
+// Usage mocks base method.
+func (m *MockFeatureFlagUsageService) Usage(ctx context.Context, flagID uint) (*FeatureFlagUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Usage", ctx, flagID)
+	ret0, _ := ret[0].(*FeatureFlagUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Usage indicates an expected call of Usage.
+func (mr *MockFeatureFlagUsageServiceMockRecorder) Usage(ctx, flagID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Usage", reflect.TypeOf((*MockFeatureFlagUsageService)(nil).Usage), ctx, flagID)
+}
+
 // MockProductService is a mock of ProductService interface.
 type MockProductService struct {
 	ctrl     *gomock.Controller
@@ -698,17 +968,31 @@ func (mr *MockProductServiceMockRecorder) Create(ctx, input any) *gomock.Call {
 }
 
 // DeleteByID mocks base method.
-func (m *MockProductService) DeleteByID(ctx context.Context, id uint) error {
+func (m *MockProductService) DeleteByID(ctx context.Context, id, version uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteByID indicates an expected call of DeleteByID.
-func (mr *MockProductServiceMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+func (mr *MockProductServiceMockRecorder) DeleteByID(ctx, id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockProductService)(nil).DeleteByID), ctx, id, version)
+}
+
+// DeleteImage mocks base method.
+func (m *MockProductService) DeleteImage(ctx context.Context, productID, imageID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImage", ctx, productID, imageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteImage indicates an expected call of DeleteImage.
+func (mr *MockProductServiceMockRecorder) DeleteImage(ctx, productID, imageID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockProductService)(nil).DeleteByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImage", reflect.TypeOf((*MockProductService)(nil).DeleteImage), ctx, productID, imageID)
 }
 
 // GetByID mocks base method.
@@ -726,32 +1010,1165 @@ func (mr *MockProductServiceMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockProductService)(nil).GetByID), ctx, id)
 }
 
+// ImageURL mocks base method.
+func (m *MockProductService) ImageURL(ctx context.Context, productID, imageID uint) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImageURL", ctx, productID, imageID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImageURL indicates an expected call of ImageURL.
+func (mr *MockProductServiceMockRecorder) ImageURL(ctx, productID, imageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImageURL", reflect.TypeOf((*MockProductService)(nil).ImageURL), ctx, productID, imageID)
+}
+
+// ListByCursor mocks base method.
+func (m *MockProductService) ListByCursor(ctx context.Context, query repository.ProductListQuery, cursor repository.CursorRequest) (repository.CursorResult[domain.Product], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByCursor", ctx, query, cursor)
+	ret0, _ := ret[0].(repository.CursorResult[domain.Product])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByCursor indicates an expected call of ListByCursor.
+func (mr *MockProductServiceMockRecorder) ListByCursor(ctx, query, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByCursor", reflect.TypeOf((*MockProductService)(nil).ListByCursor), ctx, query, cursor)
+}
+
+// ListDeleted mocks base method.
+func (m *MockProductService) ListDeleted(ctx context.Context, req repository.PageRequest) (repository.PageResult[domain.Product], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeleted", ctx, req)
+	ret0, _ := ret[0].(repository.PageResult[domain.Product])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeleted indicates an expected call of ListDeleted.
+func (mr *MockProductServiceMockRecorder) ListDeleted(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeleted", reflect.TypeOf((*MockProductService)(nil).ListDeleted), ctx, req)
+}
+
+// ListGrants mocks base method.
+func (m *MockProductService) ListGrants(ctx context.Context, productID uint) ([]domain.ProductGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGrants", ctx, productID)
+	ret0, _ := ret[0].([]domain.ProductGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGrants indicates an expected call of ListGrants.
+func (mr *MockProductServiceMockRecorder) ListGrants(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGrants", reflect.TypeOf((*MockProductService)(nil).ListGrants), ctx, productID)
+}
+
 // ListPaged mocks base method.
-func (m *MockProductService) ListPaged(ctx context.Context, req repository.PageRequest) (repository.PageResult[domain.Product], error) {
+func (m *MockProductService) ListPaged(ctx context.Context, query repository.ProductListQuery) (repository.PageResult[domain.Product], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPaged", ctx, req)
+	ret := m.ctrl.Call(m, "ListPaged", ctx, query)
 	ret0, _ := ret[0].(repository.PageResult[domain.Product])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListPaged indicates an expected call of ListPaged.
-func (mr *MockProductServiceMockRecorder) ListPaged(ctx, req any) *gomock.Call {
+func (mr *MockProductServiceMockRecorder) ListPaged(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaged", reflect.TypeOf((*MockProductService)(nil).ListPaged), ctx, query)
+}
+
+// Purge mocks base method.
+func (m *MockProductService) Purge(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockProductServiceMockRecorder) Purge(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockProductService)(nil).Purge), ctx, id)
+}
+
+// ReorderImages mocks base method.
+func (m *MockProductService) ReorderImages(ctx context.Context, productID uint, imageIDs []uint) ([]domain.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderImages", ctx, productID, imageIDs)
+	ret0, _ := ret[0].([]domain.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderImages indicates an expected call of ReorderImages.
+func (mr *MockProductServiceMockRecorder) ReorderImages(ctx, productID, imageIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderImages", reflect.TypeOf((*MockProductService)(nil).ReorderImages), ctx, productID, imageIDs)
+}
+
+// Restore mocks base method.
+func (m *MockProductService) Restore(ctx context.Context, id uint) (*domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(*domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockProductServiceMockRecorder) Restore(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockProductService)(nil).Restore), ctx, id)
+}
+
+// RevokeShare mocks base method.
+func (m *MockProductService) RevokeShare(ctx context.Context, productID, grantID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeShare", ctx, productID, grantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeShare indicates an expected call of RevokeShare.
+func (mr *MockProductServiceMockRecorder) RevokeShare(ctx, productID, grantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShare", reflect.TypeOf((*MockProductService)(nil).RevokeShare), ctx, productID, grantID)
+}
+
+// ShareProduct mocks base method.
+func (m *MockProductService) ShareProduct(ctx context.Context, productID uint, input ShareProductInput) (*domain.ProductGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareProduct", ctx, productID, input)
+	ret0, _ := ret[0].(*domain.ProductGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShareProduct indicates an expected call of ShareProduct.
+func (mr *MockProductServiceMockRecorder) ShareProduct(ctx, productID, input any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaged", reflect.TypeOf((*MockProductService)(nil).ListPaged), ctx, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareProduct", reflect.TypeOf((*MockProductService)(nil).ShareProduct), ctx, productID, input)
 }
 
 // Update mocks base method.
-func (m *MockProductService) Update(ctx context.Context, id uint, input UpdateProductInput) (*domain.Product, error) {
+func (m *MockProductService) Update(ctx context.Context, id, version uint, input UpdateProductInput) (*domain.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, id, input)
+	ret := m.ctrl.Call(m, "Update", ctx, id, version, input)
 	ret0, _ := ret[0].(*domain.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockProductServiceMockRecorder) Update(ctx, id, input any) *gomock.Call {
+func (mr *MockProductServiceMockRecorder) Update(ctx, id, version, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProductService)(nil).Update), ctx, id, version, input)
+}
+
+// UploadImage mocks base method.
+func (m *MockProductService) UploadImage(ctx context.Context, productID uint, file io.Reader, fileSize int64) (*domain.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadImage", ctx, productID, file, fileSize)
+	ret0, _ := ret[0].(*domain.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadImage indicates an expected call of UploadImage.
+func (mr *MockProductServiceMockRecorder) UploadImage(ctx, productID, file, fileSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadImage", reflect.TypeOf((*MockProductService)(nil).UploadImage), ctx, productID, file, fileSize)
+}
+
+// ValidateCreate mocks base method.
+func (m *MockProductService) ValidateCreate(ctx context.Context, input CreateProductInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateCreate", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateCreate indicates an expected call of ValidateCreate.
+func (mr *MockProductServiceMockRecorder) ValidateCreate(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateCreate", reflect.TypeOf((*MockProductService)(nil).ValidateCreate), ctx, input)
+}
+
+// MockProductImportService is a mock of ProductImportService interface.
+type MockProductImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductImportServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockProductImportServiceMockRecorder is the mock recorder for MockProductImportService.
+type MockProductImportServiceMockRecorder struct {
+	mock *MockProductImportService
+}
+
+// NewMockProductImportService creates a new mock instance.
+func NewMockProductImportService(ctrl *gomock.Controller) *MockProductImportService {
+	mock := &MockProductImportService{ctrl: ctrl}
+	mock.recorder = &MockProductImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductImportService) EXPECT() *MockProductImportServiceMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockProductImportService) Export(ctx context.Context, format string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, format, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockProductImportServiceMockRecorder) Export(ctx, format, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockProductImportService)(nil).Export), ctx, format, w)
+}
+
+// GetImportJob mocks base method.
+func (m *MockProductImportService) GetImportJob(ctx context.Context, id uint) (*domain.ProductImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportJob", ctx, id)
+	ret0, _ := ret[0].(*domain.ProductImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportJob indicates an expected call of GetImportJob.
+func (mr *MockProductImportServiceMockRecorder) GetImportJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportJob", reflect.TypeOf((*MockProductImportService)(nil).GetImportJob), ctx, id)
+}
+
+// StartImport mocks base method.
+func (m *MockProductImportService) StartImport(ctx context.Context, input ProductImportInput) (*domain.ProductImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartImport", ctx, input)
+	ret0, _ := ret[0].(*domain.ProductImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartImport indicates an expected call of StartImport.
+func (mr *MockProductImportServiceMockRecorder) StartImport(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartImport", reflect.TypeOf((*MockProductImportService)(nil).StartImport), ctx, input)
+}
+
+// MockCategoryService is a mock of CategoryService interface.
+type MockCategoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCategoryServiceMockRecorder is the mock recorder for MockCategoryService.
+type MockCategoryServiceMockRecorder struct {
+	mock *MockCategoryService
+}
+
+// NewMockCategoryService creates a new mock instance.
+func NewMockCategoryService(ctrl *gomock.Controller) *MockCategoryService {
+	mock := &MockCategoryService{ctrl: ctrl}
+	mock.recorder = &MockCategoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryService) EXPECT() *MockCategoryServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateCategory mocks base method.
+func (m *MockCategoryService) CreateCategory(ctx context.Context, input CreateCategoryInput) (*domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", ctx, input)
+	ret0, _ := ret[0].(*domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockCategoryServiceMockRecorder) CreateCategory(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockCategoryService)(nil).CreateCategory), ctx, input)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockCategoryService) DeleteCategory(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockCategoryServiceMockRecorder) DeleteCategory(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryService)(nil).DeleteCategory), ctx, id)
+}
+
+// GetCategory mocks base method.
+func (m *MockCategoryService) GetCategory(ctx context.Context, id uint) (*domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategory", ctx, id)
+	ret0, _ := ret[0].(*domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategory indicates an expected call of GetCategory.
+func (mr *MockCategoryServiceMockRecorder) GetCategory(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockCategoryService)(nil).GetCategory), ctx, id)
+}
+
+// ListCategories mocks base method.
+func (m *MockCategoryService) ListCategories(ctx context.Context) ([]domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategories", ctx)
+	ret0, _ := ret[0].([]domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockCategoryServiceMockRecorder) ListCategories(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockCategoryService)(nil).ListCategories), ctx)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockCategoryService) UpdateCategory(ctx context.Context, id uint, input UpdateCategoryInput) (*domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", ctx, id, input)
+	ret0, _ := ret[0].(*domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockCategoryServiceMockRecorder) UpdateCategory(ctx, id, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategoryService)(nil).UpdateCategory), ctx, id, input)
+}
+
+// MockInventoryService is a mock of InventoryService interface.
+type MockInventoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockInventoryServiceMockRecorder is the mock recorder for MockInventoryService.
+type MockInventoryServiceMockRecorder struct {
+	mock *MockInventoryService
+}
+
+// NewMockInventoryService creates a new mock instance.
+func NewMockInventoryService(ctrl *gomock.Controller) *MockInventoryService {
+	mock := &MockInventoryService{ctrl: ctrl}
+	mock.recorder = &MockInventoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryService) EXPECT() *MockInventoryServiceMockRecorder {
+	return m.recorder
+}
+
+// AdjustStock mocks base method.
+func (m *MockInventoryService) AdjustStock(ctx context.Context, productID uint, input AdjustStockInput) (*domain.ProductStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustStock", ctx, productID, input)
+	ret0, _ := ret[0].(*domain.ProductStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustStock indicates an expected call of AdjustStock.
+func (mr *MockInventoryServiceMockRecorder) AdjustStock(ctx, productID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStock", reflect.TypeOf((*MockInventoryService)(nil).AdjustStock), ctx, productID, input)
+}
+
+// CommitReservation mocks base method.
+func (m *MockInventoryService) CommitReservation(ctx context.Context, id uint) (*domain.StockReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitReservation", ctx, id)
+	ret0, _ := ret[0].(*domain.StockReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitReservation indicates an expected call of CommitReservation.
+func (mr *MockInventoryServiceMockRecorder) CommitReservation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitReservation", reflect.TypeOf((*MockInventoryService)(nil).CommitReservation), ctx, id)
+}
+
+// GetReservation mocks base method.
+func (m *MockInventoryService) GetReservation(ctx context.Context, id uint) (*domain.StockReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservation", ctx, id)
+	ret0, _ := ret[0].(*domain.StockReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservation indicates an expected call of GetReservation.
+func (mr *MockInventoryServiceMockRecorder) GetReservation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservation", reflect.TypeOf((*MockInventoryService)(nil).GetReservation), ctx, id)
+}
+
+// GetStock mocks base method.
+func (m *MockInventoryService) GetStock(ctx context.Context, productID uint) (*domain.ProductStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStock", ctx, productID)
+	ret0, _ := ret[0].(*domain.ProductStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStock indicates an expected call of GetStock.
+func (mr *MockInventoryServiceMockRecorder) GetStock(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStock", reflect.TypeOf((*MockInventoryService)(nil).GetStock), ctx, productID)
+}
+
+// ListMovements mocks base method.
+func (m *MockInventoryService) ListMovements(ctx context.Context, productID uint, req repository.PageRequest) (repository.PageResult[domain.StockMovement], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMovements", ctx, productID, req)
+	ret0, _ := ret[0].(repository.PageResult[domain.StockMovement])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMovements indicates an expected call of ListMovements.
+func (mr *MockInventoryServiceMockRecorder) ListMovements(ctx, productID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMovements", reflect.TypeOf((*MockInventoryService)(nil).ListMovements), ctx, productID, req)
+}
+
+// ReleaseReservation mocks base method.
+func (m *MockInventoryService) ReleaseReservation(ctx context.Context, id uint) (*domain.StockReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseReservation", ctx, id)
+	ret0, _ := ret[0].(*domain.StockReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseReservation indicates an expected call of ReleaseReservation.
+func (mr *MockInventoryServiceMockRecorder) ReleaseReservation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseReservation", reflect.TypeOf((*MockInventoryService)(nil).ReleaseReservation), ctx, id)
+}
+
+// Reserve mocks base method.
+func (m *MockInventoryService) Reserve(ctx context.Context, input ReserveStockInput) (*domain.StockReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, input)
+	ret0, _ := ret[0].(*domain.StockReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockInventoryServiceMockRecorder) Reserve(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockInventoryService)(nil).Reserve), ctx, input)
+}
+
+// MockCartService is a mock of CartService interface.
+type MockCartService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCartServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCartServiceMockRecorder is the mock recorder for MockCartService.
+type MockCartServiceMockRecorder struct {
+	mock *MockCartService
+}
+
+// NewMockCartService creates a new mock instance.
+func NewMockCartService(ctrl *gomock.Controller) *MockCartService {
+	mock := &MockCartService{ctrl: ctrl}
+	mock.recorder = &MockCartServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCartService) EXPECT() *MockCartServiceMockRecorder {
+	return m.recorder
+}
+
+// Clear mocks base method.
+func (m *MockCartService) Clear(ctx context.Context, owner CartOwner) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", ctx, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockCartServiceMockRecorder) Clear(ctx, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockCartService)(nil).Clear), ctx, owner)
+}
+
+// GetCart mocks base method.
+func (m *MockCartService) GetCart(ctx context.Context, owner CartOwner) (*domain.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCart", ctx, owner)
+	ret0, _ := ret[0].(*domain.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCart indicates an expected call of GetCart.
+func (mr *MockCartServiceMockRecorder) GetCart(ctx, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCart", reflect.TypeOf((*MockCartService)(nil).GetCart), ctx, owner)
+}
+
+// MergeGuestCart mocks base method.
+func (m *MockCartService) MergeGuestCart(ctx context.Context, guestToken string, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeGuestCart", ctx, guestToken, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeGuestCart indicates an expected call of MergeGuestCart.
+func (mr *MockCartServiceMockRecorder) MergeGuestCart(ctx, guestToken, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeGuestCart", reflect.TypeOf((*MockCartService)(nil).MergeGuestCart), ctx, guestToken, userID)
+}
+
+// SetItem mocks base method.
+func (m *MockCartService) SetItem(ctx context.Context, owner CartOwner, productID uint, quantity int64) (*domain.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItem", ctx, owner, productID, quantity)
+	ret0, _ := ret[0].(*domain.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetItem indicates an expected call of SetItem.
+func (mr *MockCartServiceMockRecorder) SetItem(ctx, owner, productID, quantity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItem", reflect.TypeOf((*MockCartService)(nil).SetItem), ctx, owner, productID, quantity)
+}
+
+// MockCartMerger is a mock of CartMerger interface.
+type MockCartMerger struct {
+	ctrl     *gomock.Controller
+	recorder *MockCartMergerMockRecorder
+	isgomock struct{}
+}
+
+// MockCartMergerMockRecorder is the mock recorder for MockCartMerger.
+type MockCartMergerMockRecorder struct {
+	mock *MockCartMerger
+}
+
+// NewMockCartMerger creates a new mock instance.
+func NewMockCartMerger(ctrl *gomock.Controller) *MockCartMerger {
+	mock := &MockCartMerger{ctrl: ctrl}
+	mock.recorder = &MockCartMergerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCartMerger) EXPECT() *MockCartMergerMockRecorder {
+	return m.recorder
+}
+
+// MergeGuestCart mocks base method.
+func (m *MockCartMerger) MergeGuestCart(ctx context.Context, guestToken string, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeGuestCart", ctx, guestToken, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeGuestCart indicates an expected call of MergeGuestCart.
+func (mr *MockCartMergerMockRecorder) MergeGuestCart(ctx, guestToken, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeGuestCart", reflect.TypeOf((*MockCartMerger)(nil).MergeGuestCart), ctx, guestToken, userID)
+}
+
+// MockOrderService is a mock of OrderService interface.
+type MockOrderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderServiceMockRecorder is the mock recorder for MockOrderService.
+type MockOrderServiceMockRecorder struct {
+	mock *MockOrderService
+}
+
+// NewMockOrderService creates a new mock instance.
+func NewMockOrderService(ctrl *gomock.Controller) *MockOrderService {
+	mock := &MockOrderService{ctrl: ctrl}
+	mock.recorder = &MockOrderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderService) EXPECT() *MockOrderServiceMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockOrderService) Cancel(ctx context.Context, id, ownerID uint) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, id, ownerID)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockOrderServiceMockRecorder) Cancel(ctx, id, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockOrderService)(nil).Cancel), ctx, id, ownerID)
+}
+
+// Checkout mocks base method.
+func (m *MockOrderService) Checkout(ctx context.Context, userID uint, input CheckoutInput) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", ctx, userID, input)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockOrderServiceMockRecorder) Checkout(ctx, userID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockOrderService)(nil).Checkout), ctx, userID, input)
+}
+
+// Fulfill mocks base method.
+func (m *MockOrderService) Fulfill(ctx context.Context, id uint) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fulfill", ctx, id)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Fulfill indicates an expected call of Fulfill.
+func (mr *MockOrderServiceMockRecorder) Fulfill(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fulfill", reflect.TypeOf((*MockOrderService)(nil).Fulfill), ctx, id)
+}
+
+// GetOrder mocks base method.
+func (m *MockOrderService) GetOrder(ctx context.Context, id, ownerID uint) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", ctx, id, ownerID)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockOrderServiceMockRecorder) GetOrder(ctx, id, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrderService)(nil).GetOrder), ctx, id, ownerID)
+}
+
+// ListOrders mocks base method.
+func (m *MockOrderService) ListOrders(ctx context.Context, query repository.OrderListQuery) (repository.PageResult[domain.Order], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrders", ctx, query)
+	ret0, _ := ret[0].(repository.PageResult[domain.Order])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrders indicates an expected call of ListOrders.
+func (mr *MockOrderServiceMockRecorder) ListOrders(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockOrderService)(nil).ListOrders), ctx, query)
+}
+
+// Pay mocks base method.
+func (m *MockOrderService) Pay(ctx context.Context, id, ownerID uint) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pay", ctx, id, ownerID)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pay indicates an expected call of Pay.
+func (mr *MockOrderServiceMockRecorder) Pay(ctx, id, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pay", reflect.TypeOf((*MockOrderService)(nil).Pay), ctx, id, ownerID)
+}
+
+// Refund mocks base method.
+func (m *MockOrderService) Refund(ctx context.Context, id uint) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", ctx, id)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockOrderServiceMockRecorder) Refund(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockOrderService)(nil).Refund), ctx, id)
+}
+
+// MockRoleChangeRequestService is a mock of RoleChangeRequestService interface.
+type MockRoleChangeRequestService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleChangeRequestServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockRoleChangeRequestServiceMockRecorder is the mock recorder for MockRoleChangeRequestService.
+type MockRoleChangeRequestServiceMockRecorder struct {
+	mock *MockRoleChangeRequestService
+}
+
+// NewMockRoleChangeRequestService creates a new mock instance.
+func NewMockRoleChangeRequestService(ctrl *gomock.Controller) *MockRoleChangeRequestService {
+	mock := &MockRoleChangeRequestService{ctrl: ctrl}
+	mock.recorder = &MockRoleChangeRequestServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoleChangeRequestService) EXPECT() *MockRoleChangeRequestServiceMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockRoleChangeRequestService) Approve(ctx context.Context, id, approverID uint, reason string) (*domain.RoleChangeRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, id, approverID, reason)
+	ret0, _ := ret[0].(*domain.RoleChangeRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockRoleChangeRequestServiceMockRecorder) Approve(ctx, id, approverID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockRoleChangeRequestService)(nil).Approve), ctx, id, approverID, reason)
+}
+
+// List mocks base method.
+func (m *MockRoleChangeRequestService) List(ctx context.Context, req repository.PageRequest, status string) (repository.PageResult[domain.RoleChangeRequest], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, req, status)
+	ret0, _ := ret[0].(repository.PageResult[domain.RoleChangeRequest])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRoleChangeRequestServiceMockRecorder) List(ctx, req, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRoleChangeRequestService)(nil).List), ctx, req, status)
+}
+
+// Reject mocks base method.
+func (m *MockRoleChangeRequestService) Reject(ctx context.Context, id, approverID uint, reason string) (*domain.RoleChangeRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, id, approverID, reason)
+	ret0, _ := ret[0].(*domain.RoleChangeRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockRoleChangeRequestServiceMockRecorder) Reject(ctx, id, approverID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockRoleChangeRequestService)(nil).Reject), ctx, id, approverID, reason)
+}
+
+// Submit mocks base method.
+func (m *MockRoleChangeRequestService) Submit(ctx context.Context, targetUserID, requestedBy uint, roleIDs []uint) (*domain.RoleChangeRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Submit", ctx, targetUserID, requestedBy, roleIDs)
+	ret0, _ := ret[0].(*domain.RoleChangeRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Submit indicates an expected call of Submit.
+func (mr *MockRoleChangeRequestServiceMockRecorder) Submit(ctx, targetUserID, requestedBy, roleIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockRoleChangeRequestService)(nil).Submit), ctx, targetUserID, requestedBy, roleIDs)
+}
+
+// MockOrganizationService is a mock of OrganizationService interface.
+type MockOrganizationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockOrganizationServiceMockRecorder is the mock recorder for MockOrganizationService.
+type MockOrganizationServiceMockRecorder struct {
+	mock *MockOrganizationService
+}
+
+// NewMockOrganizationService creates a new mock instance.
+func NewMockOrganizationService(ctrl *gomock.Controller) *MockOrganizationService {
+	mock := &MockOrganizationService{ctrl: ctrl}
+	mock.recorder = &MockOrganizationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationService) EXPECT() *MockOrganizationServiceMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockOrganizationService) AcceptInvitation(ctx context.Context, orgID, userID uint) (*domain.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, orgID, userID)
+	ret0, _ := ret[0].(*domain.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockOrganizationServiceMockRecorder) AcceptInvitation(ctx, orgID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockOrganizationService)(nil).AcceptInvitation), ctx, orgID, userID)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockOrganizationService) CreateOrganization(ctx context.Context, slug, name string) (*domain.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", ctx, slug, name)
+	ret0, _ := ret[0].(*domain.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockOrganizationServiceMockRecorder) CreateOrganization(ctx, slug, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockOrganizationService)(nil).CreateOrganization), ctx, slug, name)
+}
+
+// DeclineInvitation mocks base method.
+func (m *MockOrganizationService) DeclineInvitation(ctx context.Context, orgID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineInvitation", ctx, orgID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineInvitation indicates an expected call of DeclineInvitation.
+func (mr *MockOrganizationServiceMockRecorder) DeclineInvitation(ctx, orgID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineInvitation", reflect.TypeOf((*MockOrganizationService)(nil).DeclineInvitation), ctx, orgID, userID)
+}
+
+// DeleteOrganization mocks base method.
+func (m *MockOrganizationService) DeleteOrganization(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganization", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrganization indicates an expected call of DeleteOrganization.
+func (mr *MockOrganizationServiceMockRecorder) DeleteOrganization(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganization", reflect.TypeOf((*MockOrganizationService)(nil).DeleteOrganization), ctx, id)
+}
+
+// GetOrganization mocks base method.
+func (m *MockOrganizationService) GetOrganization(ctx context.Context, id uint) (*domain.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganization", ctx, id)
+	ret0, _ := ret[0].(*domain.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganization indicates an expected call of GetOrganization.
+func (mr *MockOrganizationServiceMockRecorder) GetOrganization(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganization", reflect.TypeOf((*MockOrganizationService)(nil).GetOrganization), ctx, id)
+}
+
+// ListInvitations mocks base method.
+func (m *MockOrganizationService) ListInvitations(ctx context.Context, userID uint) ([]domain.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvitations", ctx, userID)
+	ret0, _ := ret[0].([]domain.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInvitations indicates an expected call of ListInvitations.
+func (mr *MockOrganizationServiceMockRecorder) ListInvitations(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvitations", reflect.TypeOf((*MockOrganizationService)(nil).ListInvitations), ctx, userID)
+}
+
+// ListMembers mocks base method.
+func (m *MockOrganizationService) ListMembers(ctx context.Context, orgID uint, req repository.PageRequest) (repository.PageResult[domain.Membership], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers", ctx, orgID, req)
+	ret0, _ := ret[0].(repository.PageResult[domain.Membership])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockOrganizationServiceMockRecorder) ListMembers(ctx, orgID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockOrganizationService)(nil).ListMembers), ctx, orgID, req)
+}
+
+// ListOrganizations mocks base method.
+func (m *MockOrganizationService) ListOrganizations(ctx context.Context, req repository.PageRequest) (repository.PageResult[domain.Organization], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganizations", ctx, req)
+	ret0, _ := ret[0].(repository.PageResult[domain.Organization])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganizations indicates an expected call of ListOrganizations.
+func (mr *MockOrganizationServiceMockRecorder) ListOrganizations(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizations", reflect.TypeOf((*MockOrganizationService)(nil).ListOrganizations), ctx, req)
+}
+
+// RemoveMember mocks base method.
+func (m *MockOrganizationService) RemoveMember(ctx context.Context, orgID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, orgID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockOrganizationServiceMockRecorder) RemoveMember(ctx, orgID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockOrganizationService)(nil).RemoveMember), ctx, orgID, userID)
+}
+
+// ResolveTenant mocks base method.
+func (m *MockOrganizationService) ResolveTenant(ctx context.Context, selector string, userID uint) (*Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveTenant", ctx, selector, userID)
+	ret0, _ := ret[0].(*Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveTenant indicates an expected call of ResolveTenant.
+func (mr *MockOrganizationServiceMockRecorder) ResolveTenant(ctx, selector, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveTenant", reflect.TypeOf((*MockOrganizationService)(nil).ResolveTenant), ctx, selector, userID)
+}
+
+// SetMemberRoles mocks base method.
+func (m *MockOrganizationService) SetMemberRoles(ctx context.Context, orgID, userID uint, roleIDs []uint) (*domain.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMemberRoles", ctx, orgID, userID, roleIDs)
+	ret0, _ := ret[0].(*domain.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetMemberRoles indicates an expected call of SetMemberRoles.
+func (mr *MockOrganizationServiceMockRecorder) SetMemberRoles(ctx, orgID, userID, roleIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMemberRoles", reflect.TypeOf((*MockOrganizationService)(nil).SetMemberRoles), ctx, orgID, userID, roleIDs)
+}
+
+// SetOrganizationMember mocks base method.
+func (m *MockOrganizationService) SetOrganizationMember(ctx context.Context, orgID, userID uint, roleIDs []uint) (*domain.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOrganizationMember", ctx, orgID, userID, roleIDs)
+	ret0, _ := ret[0].(*domain.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOrganizationMember indicates an expected call of SetOrganizationMember.
+func (mr *MockOrganizationServiceMockRecorder) SetOrganizationMember(ctx, orgID, userID, roleIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOrganizationMember", reflect.TypeOf((*MockOrganizationService)(nil).SetOrganizationMember), ctx, orgID, userID, roleIDs)
+}
+
+// MockGroupService is a mock of GroupService interface.
+type MockGroupService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockGroupServiceMockRecorder is the mock recorder for MockGroupService.
+type MockGroupServiceMockRecorder struct {
+	mock *MockGroupService
+}
+
+// NewMockGroupService creates a new mock instance.
+func NewMockGroupService(ctrl *gomock.Controller) *MockGroupService {
+	mock := &MockGroupService{ctrl: ctrl}
+	mock.recorder = &MockGroupServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupService) EXPECT() *MockGroupServiceMockRecorder {
+	return m.recorder
+}
+
+// AddMembers mocks base method.
+func (m *MockGroupService) AddMembers(ctx context.Context, groupID uint, userIDs []uint) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMembers", ctx, groupID, userIDs)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMembers indicates an expected call of AddMembers.
+func (mr *MockGroupServiceMockRecorder) AddMembers(ctx, groupID, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMembers", reflect.TypeOf((*MockGroupService)(nil).AddMembers), ctx, groupID, userIDs)
+}
+
+// CreateGroup mocks base method.
+func (m *MockGroupService) CreateGroup(ctx context.Context, input CreateGroupInput) (*domain.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroup", ctx, input)
+	ret0, _ := ret[0].(*domain.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGroup indicates an expected call of CreateGroup.
+func (mr *MockGroupServiceMockRecorder) CreateGroup(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockGroupService)(nil).CreateGroup), ctx, input)
+}
+
+// DeleteGroup mocks base method.
+func (m *MockGroupService) DeleteGroup(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroup", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGroup indicates an expected call of DeleteGroup.
+func (mr *MockGroupServiceMockRecorder) DeleteGroup(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockGroupService)(nil).DeleteGroup), ctx, id)
+}
+
+// GetGroup mocks base method.
+func (m *MockGroupService) GetGroup(ctx context.Context, id uint) (*domain.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroup", ctx, id)
+	ret0, _ := ret[0].(*domain.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroup indicates an expected call of GetGroup.
+func (mr *MockGroupServiceMockRecorder) GetGroup(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroup", reflect.TypeOf((*MockGroupService)(nil).GetGroup), ctx, id)
+}
+
+// ListGroups mocks base method.
+func (m *MockGroupService) ListGroups(ctx context.Context, req repository.PageRequest, name string) (repository.PageResult[domain.Group], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroups", ctx, req, name)
+	ret0, _ := ret[0].(repository.PageResult[domain.Group])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroups indicates an expected call of ListGroups.
+func (mr *MockGroupServiceMockRecorder) ListGroups(ctx, req, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroups", reflect.TypeOf((*MockGroupService)(nil).ListGroups), ctx, req, name)
+}
+
+// ListMembers mocks base method.
+func (m *MockGroupService) ListMembers(ctx context.Context, groupID uint, req repository.PageRequest) (repository.PageResult[domain.User], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers", ctx, groupID, req)
+	ret0, _ := ret[0].(repository.PageResult[domain.User])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockGroupServiceMockRecorder) ListMembers(ctx, groupID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockGroupService)(nil).ListMembers), ctx, groupID, req)
+}
+
+// RemoveMember mocks base method.
+func (m *MockGroupService) RemoveMember(ctx context.Context, groupID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, groupID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockGroupServiceMockRecorder) RemoveMember(ctx, groupID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockGroupService)(nil).RemoveMember), ctx, groupID, userID)
+}
+
+// UpdateGroup mocks base method.
+func (m *MockGroupService) UpdateGroup(ctx context.Context, id uint, input UpdateGroupInput) (*domain.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroup", ctx, id, input)
+	ret0, _ := ret[0].(*domain.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGroup indicates an expected call of UpdateGroup.
+func (mr *MockGroupServiceMockRecorder) UpdateGroup(ctx, id, input any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProductService)(nil).Update), ctx, id, input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroup", reflect.TypeOf((*MockGroupService)(nil).UpdateGroup), ctx, id, input)
 }
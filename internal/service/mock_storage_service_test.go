@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/storage_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source internal/service/storage_service.go -destination internal/service/mock_storage_service_test.go -package service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStorageService is a mock of StorageService interface.
+type MockStorageService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockStorageServiceMockRecorder is the mock recorder for MockStorageService.
+type MockStorageServiceMockRecorder struct {
+	mock *MockStorageService
+}
+
+// NewMockStorageService creates a new mock instance.
+func NewMockStorageService(ctrl *gomock.Controller) *MockStorageService {
+	mock := &MockStorageService{ctrl: ctrl}
+	mock.recorder = &MockStorageServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorageService) EXPECT() *MockStorageServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteAvatar mocks base method.
+func (m *MockStorageService) DeleteAvatar(ctx context.Context, userID uint, objectKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAvatar", ctx, userID, objectKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAvatar indicates an expected call of DeleteAvatar.
+func (mr *MockStorageServiceMockRecorder) DeleteAvatar(ctx, userID, objectKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAvatar", reflect.TypeOf((*MockStorageService)(nil).DeleteAvatar), ctx, userID, objectKey)
+}
+
+// DeleteObject mocks base method.
+func (m *MockStorageService) DeleteObject(ctx context.Context, prefix, objectKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteObject", ctx, prefix, objectKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteObject indicates an expected call of DeleteObject.
+func (mr *MockStorageServiceMockRecorder) DeleteObject(ctx, prefix, objectKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteObject", reflect.TypeOf((*MockStorageService)(nil).DeleteObject), ctx, prefix, objectKey)
+}
+
+// DeletePrefix mocks base method.
+func (m *MockStorageService) DeletePrefix(ctx context.Context, prefix string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrefix", ctx, prefix)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePrefix indicates an expected call of DeletePrefix.
+func (mr *MockStorageServiceMockRecorder) DeletePrefix(ctx, prefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrefix", reflect.TypeOf((*MockStorageService)(nil).DeletePrefix), ctx, prefix)
+}
+
+// GenerateAvatarURL mocks base method.
+func (m *MockStorageService) GenerateAvatarURL(ctx context.Context, objectKey string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateAvatarURL", ctx, objectKey)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateAvatarURL indicates an expected call of GenerateAvatarURL.
+func (mr *MockStorageServiceMockRecorder) GenerateAvatarURL(ctx, objectKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateAvatarURL", reflect.TypeOf((*MockStorageService)(nil).GenerateAvatarURL), ctx, objectKey)
+}
+
+// GenerateURL mocks base method.
+func (m *MockStorageService) GenerateURL(ctx context.Context, objectKey string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateURL", ctx, objectKey)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateURL indicates an expected call of GenerateURL.
+func (mr *MockStorageServiceMockRecorder) GenerateURL(ctx, objectKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateURL", reflect.TypeOf((*MockStorageService)(nil).GenerateURL), ctx, objectKey)
+}
+
+// UploadAvatar mocks base method.
+func (m *MockStorageService) UploadAvatar(ctx context.Context, userID uint, file io.Reader, fileSize int64, contentType string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadAvatar", ctx, userID, file, fileSize, contentType)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadAvatar indicates an expected call of UploadAvatar.
+func (mr *MockStorageServiceMockRecorder) UploadAvatar(ctx, userID, file, fileSize, contentType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAvatar", reflect.TypeOf((*MockStorageService)(nil).UploadAvatar), ctx, userID, file, fileSize, contentType)
+}
+
+// UploadImage mocks base method.
+func (m *MockStorageService) UploadImage(ctx context.Context, prefix string, file io.Reader, fileSize int64, metadata map[string]string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadImage", ctx, prefix, file, fileSize, metadata)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UploadImage indicates an expected call of UploadImage.
+func (mr *MockStorageServiceMockRecorder) UploadImage(ctx, prefix, file, fileSize, metadata any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadImage", reflect.TypeOf((*MockStorageService)(nil).UploadImage), ctx, prefix, file, fileSize, metadata)
+}
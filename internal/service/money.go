@@ -0,0 +1,125 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+)
+
+var ErrProductInvalidCurrency = errors.New("currency must be a supported ISO 4217 code")
+
+const maxProductPrices = 20
+
+// PriceInput is an amount in a currency, given either as a decimal string
+// such as "19.99" or directly in minor units. Exactly one of Amount and
+// AmountMinor must be set. An empty Currency means the product's currency.
+type PriceInput struct {
+	Currency    string
+	Amount      string
+	AmountMinor *int64
+}
+
+// NormalizeCurrency upper-cases code and checks it against the supported
+// ISO 4217 currencies.
+func NormalizeCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if _, ok := domain.CurrencyExponent(code); !ok {
+		return "", ErrProductInvalidCurrency
+	}
+	return code, nil
+}
+
+// ParseMinorUnits converts a non-negative decimal amount into the minor units
+// of currency. Amounts with more fractional digits than the currency's
+// exponent are rejected rather than rounded.
+func ParseMinorUnits(amount, currency string) (int64, error) {
+	exp, ok := domain.CurrencyExponent(currency)
+	if !ok {
+		return 0, ErrProductInvalidCurrency
+	}
+	amount = strings.TrimSpace(amount)
+	whole, frac, hasPoint := strings.Cut(amount, ".")
+	if whole == "" || (hasPoint && frac == "") || !isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("%w: %q is not a decimal amount", ErrProductInvalidPrice, amount)
+	}
+	if len(frac) > exp {
+		return 0, fmt.Errorf("%w: %s allows at most %d decimal places", ErrProductInvalidPrice, currency, exp)
+	}
+	minor, err := strconv.ParseInt(whole+frac+strings.Repeat("0", exp-len(frac)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrProductInvalidPrice, amount)
+	}
+	return minor, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// resolvePrice validates input and returns its currency, falling back to
+// defaultCurrency, and its positive amount in minor units.
+func resolvePrice(input PriceInput, defaultCurrency string) (string, int64, error) {
+	currency := defaultCurrency
+	if strings.TrimSpace(input.Currency) != "" {
+		var err error
+		if currency, err = NormalizeCurrency(input.Currency); err != nil {
+			return "", 0, err
+		}
+	}
+	var minor int64
+	switch {
+	case input.Amount != "" && input.AmountMinor != nil:
+		return "", 0, fmt.Errorf("%w: set either an amount or minor units, not both", ErrProductInvalidPrice)
+	case input.AmountMinor != nil:
+		minor = *input.AmountMinor
+	case input.Amount != "":
+		var err error
+		if minor, err = ParseMinorUnits(input.Amount, currency); err != nil {
+			return "", 0, err
+		}
+	default:
+		return "", 0, fmt.Errorf("%w: an amount is required", ErrProductInvalidPrice)
+	}
+	if minor <= 0 {
+		return "", 0, fmt.Errorf("%w: amount must be greater than 0", ErrProductInvalidPrice)
+	}
+	return currency, minor, nil
+}
+
+// resolvePriceList validates a product's additional prices. Each needs an
+// explicit currency different from the product's own and from each other.
+func resolvePriceList(inputs []PriceInput, productCurrency string) ([]domain.ProductPrice, error) {
+	if len(inputs) > maxProductPrices {
+		return nil, fmt.Errorf("%w: at most %d additional prices are allowed", ErrProductInvalidPrice, maxProductPrices)
+	}
+	prices := make([]domain.ProductPrice, 0, len(inputs))
+	seen := make(map[string]struct{}, len(inputs))
+	for _, input := range inputs {
+		if strings.TrimSpace(input.Currency) == "" {
+			return nil, ErrProductInvalidCurrency
+		}
+		currency, minor, err := resolvePrice(input, "")
+		if err != nil {
+			return nil, err
+		}
+		if currency == productCurrency {
+			return nil, fmt.Errorf("%w: %s is already the product's currency", ErrProductInvalidPrice, currency)
+		}
+		if _, dup := seen[currency]; dup {
+			return nil, fmt.Errorf("%w: %s is listed more than once", ErrProductInvalidPrice, currency)
+		}
+		seen[currency] = struct{}{}
+		prices = append(prices, domain.ProductPrice{Currency: currency, AmountMinor: minor})
+	}
+	sort.Slice(prices, func(i, j int) bool { return prices[i].Currency < prices[j].Currency })
+	return prices, nil
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	repogomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestParseMinorUnitsEnforcesCurrencyExponent(t *testing.T) {
+	cases := []struct {
+		amount, currency string
+		want             int64
+		wantErr          error
+	}{
+		{"19.99", "USD", 1999, nil},
+		{"19.9", "USD", 1990, nil},
+		{"19", "USD", 1900, nil},
+		{"1999", "JPY", 1999, nil},
+		{"1.234", "KWD", 1234, nil},
+		{"19.999", "USD", 0, ErrProductInvalidPrice},
+		{"19.5", "JPY", 0, ErrProductInvalidPrice},
+		{"-1", "USD", 0, ErrProductInvalidPrice},
+		{"1e3", "USD", 0, ErrProductInvalidPrice},
+		{"19.", "USD", 0, ErrProductInvalidPrice},
+		{"99999999999999999999", "USD", 0, ErrProductInvalidPrice},
+		{"1", "XYZ", 0, ErrProductInvalidCurrency},
+	}
+	for _, tc := range cases {
+		got, err := ParseMinorUnits(tc.amount, tc.currency)
+		if !errors.Is(err, tc.wantErr) || got != tc.want {
+			t.Fatalf("ParseMinorUnits(%q, %q) = %d, %v; want %d, %v", tc.amount, tc.currency, got, err, tc.want, tc.wantErr)
+		}
+	}
+	for _, tc := range []struct {
+		minor    int64
+		currency string
+		want     string
+	}{{1999, "USD", "19.99"}, {5, "USD", "0.05"}, {1999, "JPY", "1999"}, {1234, "KWD", "1.234"}, {-250, "EUR", "-2.50"}} {
+		if got := domain.FormatMinorUnits(tc.minor, tc.currency); got != tc.want {
+			t.Fatalf("FormatMinorUnits(%d, %q) = %q, want %q", tc.minor, tc.currency, got, tc.want)
+		}
+	}
+}
+
+func TestProductServiceValidatesPriceList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockProductRepository(ctrl)
+	svc := NewProductService(repo, repogomock.NewMockCategoryRepository(ctrl), nil)
+	ctx := WithPrincipal(context.Background(), SystemPrincipal())
+
+	minor := int64(1500)
+	cases := []struct {
+		name  string
+		input CreateProductInput
+		want  error
+	}{
+		{"unknown currency", CreateProductInput{Name: "Mug", Price: PriceInput{Currency: "ABC", Amount: "1"}}, ErrProductInvalidCurrency},
+		{"amount and minor units", CreateProductInput{Name: "Mug", Price: PriceInput{Amount: "15", AmountMinor: &minor}}, ErrProductInvalidPrice},
+		{"too precise", CreateProductInput{Name: "Mug", Price: PriceInput{Currency: "JPY", Amount: "15.5"}}, ErrProductInvalidPrice},
+		{"list repeats own currency", CreateProductInput{Name: "Mug", Price: PriceInput{Amount: "15"}, Prices: []PriceInput{{Currency: "usd", Amount: "14"}}}, ErrProductInvalidPrice},
+		{"list repeats currency", CreateProductInput{Name: "Mug", Price: PriceInput{Amount: "15"}, Prices: []PriceInput{{Currency: "EUR", Amount: "14"}, {Currency: "eur", Amount: "13"}}}, ErrProductInvalidPrice},
+		{"list without currency", CreateProductInput{Name: "Mug", Price: PriceInput{Amount: "15"}, Prices: []PriceInput{{Amount: "14"}}}, ErrProductInvalidCurrency},
+	}
+	for _, tc := range cases {
+		if _, err := svc.Create(ctx, tc.input); !errors.Is(err, tc.want) {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+
+	repo.EXPECT().Create(gomock.AssignableToTypeOf(&domain.Product{})).Return(nil)
+	created, err := svc.Create(ctx, CreateProductInput{
+		Name: "Mug", Price: PriceInput{Currency: "gbp", AmountMinor: &minor},
+		Prices: []PriceInput{{Currency: "JPY", Amount: "2800"}, {Currency: "EUR", Amount: "17.5"}},
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if created.Currency != "GBP" || created.PriceMinor != 1500 || len(created.Prices) != 2 ||
+		created.Prices[0] != (domain.ProductPrice{Currency: "EUR", AmountMinor: 1750}) || created.Prices[1].AmountMinor != 2800 {
+		t.Fatalf("unexpected pricing: %+v", created)
+	}
+
+	// Switching to a currency that is already in the price list must replace
+	// the list in the same request.
+	current := &domain.Product{ID: 7, PriceMinor: 1500, Currency: "GBP", Prices: []domain.ProductPrice{{Currency: "EUR", AmountMinor: 1750}}}
+	repo.EXPECT().FindByID(uint(7)).Return(current, nil).AnyTimes()
+	if _, err := svc.Update(ctx, 7, 0, UpdateProductInput{Price: &PriceInput{Currency: "EUR", Amount: "18"}}); !errors.Is(err, ErrProductInvalidPrice) {
+		t.Fatalf("expected price list clash, got %v", err)
+	}
+	repo.EXPECT().Update(uint(7), uint(0), gomock.Any()).DoAndReturn(func(_, _ uint, updates map[string]any) error {
+		if updates["currency"] != "EUR" || updates["price_minor"] != int64(1800) {
+			t.Fatalf("unexpected price updates: %v", updates)
+		}
+		if prices, ok := updates["prices"].([]domain.ProductPrice); !ok || len(prices) != 1 || prices[0].Currency != "GBP" {
+			t.Fatalf("unexpected price list update: %v", updates["prices"])
+		}
+		return nil
+	})
+	prices := []PriceInput{{Currency: "GBP", Amount: "15"}}
+	if _, err := svc.Update(ctx, 7, 0, UpdateProductInput{Price: &PriceInput{Currency: "EUR", Amount: "18"}, Prices: &prices}); err != nil {
+		t.Fatalf("switch currency: %v", err)
+	}
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+)
+
+var (
+	ErrCartEmpty               = errors.New("cart is empty")
+	ErrOrderItemUnavailable    = errors.New("product in cart is no longer available")
+	ErrOrderCurrencyMismatch   = errors.New("product has no price in the order currency")
+	ErrOrderTotalTooLarge      = errors.New("order total is out of range")
+	ErrInvalidOrderTransition  = errors.New("order status does not allow this change")
+	ErrOrderPaymentNotRecorded = errors.New("order has no payment to refund")
+)
+
+// CheckoutInput selects the order currency. An empty Currency uses the
+// currency of the first product in the cart.
+type CheckoutInput struct {
+	Currency string
+}
+
+// DefaultOrderService turns carts into orders and moves orders through the
+// status machine in domain.CanTransitionOrder. Totals are always computed
+// from current product prices; clients never supply amounts.
+type DefaultOrderService struct {
+	orders   repository.OrderRepository
+	carts    repository.CartRepository
+	products repository.ProductRepository
+	payments PaymentProvider
+	now      func() time.Time
+}
+
+func NewOrderService(orders repository.OrderRepository, carts repository.CartRepository, products repository.ProductRepository, payments PaymentProvider) *DefaultOrderService {
+	return &DefaultOrderService{orders: orders, carts: carts, products: products, payments: payments, now: time.Now}
+}
+
+// Checkout creates a pending order from the user's cart and empties the cart.
+func (s *DefaultOrderService) Checkout(ctx context.Context, userID uint, input CheckoutInput) (*domain.Order, error) {
+	cart, err := s.carts.FindByUser(userID)
+	if errors.Is(err, repository.ErrCartNotFound) {
+		return nil, ErrCartEmpty
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(cart.Items) == 0 {
+		return nil, ErrCartEmpty
+	}
+	currency := ""
+	if strings.TrimSpace(input.Currency) != "" {
+		if currency, err = NormalizeCurrency(input.Currency); err != nil {
+			return nil, err
+		}
+	}
+
+	products := s.products
+	order := &domain.Order{UserID: userID, Status: domain.OrderPending}
+	if tenant, ok := TenantFromContext(ctx); ok {
+		products = products.ForOrganization(tenant.OrganizationID)
+		orgID := tenant.OrganizationID
+		order.OrganizationID = &orgID
+	}
+	items := append([]domain.CartItem(nil), cart.Items...)
+	sort.Slice(items, func(i, j int) bool { return items[i].ProductID < items[j].ProductID })
+	for _, item := range items {
+		product, err := products.FindByID(item.ProductID)
+		if errors.Is(err, repository.ErrProductNotFound) {
+			return nil, fmt.Errorf("%w: product %d", ErrOrderItemUnavailable, item.ProductID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if currency == "" {
+			currency = product.Currency
+		}
+		unit, ok := productPriceIn(product, currency)
+		if !ok {
+			return nil, fmt.Errorf("%w: product %d has no %s price", ErrOrderCurrencyMismatch, product.ID, currency)
+		}
+		if unit > math.MaxInt64/item.Quantity {
+			return nil, ErrOrderTotalTooLarge
+		}
+		line := unit * item.Quantity
+		if order.TotalMinor > math.MaxInt64-line {
+			return nil, ErrOrderTotalTooLarge
+		}
+		order.TotalMinor += line
+		order.Items = append(order.Items, domain.OrderItem{
+			ProductID:      product.ID,
+			Name:           product.Name,
+			UnitPriceMinor: unit,
+			Quantity:       item.Quantity,
+			LineTotalMinor: line,
+		})
+	}
+	order.Currency = currency
+
+	if err := s.orders.CreateFromCart(order, cart.ID); err != nil {
+		if errors.Is(err, repository.ErrCartNotFound) {
+			return nil, ErrCartEmpty
+		}
+		return nil, err
+	}
+	return order, nil
+}
+
+// ListOrders lists orders in the caller's tenant.
+func (s *DefaultOrderService) ListOrders(ctx context.Context, query repository.OrderListQuery) (repository.PageResult[domain.Order], error) {
+	if tenant, ok := TenantFromContext(ctx); ok {
+		orgID := tenant.OrganizationID
+		query.OrganizationID = &orgID
+	}
+	return s.orders.ListPaged(query)
+}
+
+// GetOrder loads an order. A non-zero ownerID restricts the lookup to that
+// user's orders; orders of other users and other tenants look missing.
+func (s *DefaultOrderService) GetOrder(ctx context.Context, id, ownerID uint) (*domain.Order, error) {
+	order, err := s.orders.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if ownerID != 0 && order.UserID != ownerID {
+		return nil, repository.ErrOrderNotFound
+	}
+	if tenant, ok := TenantFromContext(ctx); ok {
+		if order.OrganizationID == nil || *order.OrganizationID != tenant.OrganizationID {
+			return nil, repository.ErrOrderNotFound
+		}
+	}
+	return order, nil
+}
+
+// Pay charges the order total and marks the order paid. Paying a paid order
+// returns it unchanged. The charge uses a key derived from the order, so a
+// retried payment never bills twice.
+func (s *DefaultOrderService) Pay(ctx context.Context, id, ownerID uint) (*domain.Order, error) {
+	order, err := s.GetOrder(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status == domain.OrderPaid {
+		return order, nil
+	}
+	if !domain.CanTransitionOrder(order.Status, domain.OrderPaid) {
+		return nil, invalidOrderTransition(order.Status, domain.OrderPaid)
+	}
+	charge, err := s.payments.Charge(ctx, ChargeRequest{
+		OrderID:        order.ID,
+		AmountMinor:    order.TotalMinor,
+		Currency:       order.Currency,
+		IdempotencyKey: fmt.Sprintf("order-%d", order.ID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	paid, err := s.orders.UpdateStatus(order.ID, domain.OrderPending, domain.OrderPaid, map[string]any{
+		"payment_provider":  s.payments.Name(),
+		"payment_reference": charge.Reference,
+		"paid_at":           s.now().UTC(),
+	})
+	if errors.Is(err, repository.ErrOrderStatusConflict) {
+		current, findErr := s.orders.FindByID(order.ID)
+		if findErr != nil {
+			return nil, findErr
+		}
+		if current.Status == domain.OrderPaid {
+			return current, nil
+		}
+		// Cancelled while the charge was in flight: give the money back.
+		if _, refundErr := s.payments.Refund(ctx, RefundRequest{OrderID: order.ID, Reference: charge.Reference, AmountMinor: order.TotalMinor, Currency: order.Currency}); refundErr != nil {
+			return nil, refundErr
+		}
+		return nil, invalidOrderTransition(current.Status, domain.OrderPaid)
+	}
+	return paid, err
+}
+
+// Fulfill marks a paid order as shipped.
+func (s *DefaultOrderService) Fulfill(ctx context.Context, id uint) (*domain.Order, error) {
+	order, err := s.GetOrder(ctx, id, 0)
+	if err != nil {
+		return nil, err
+	}
+	return s.transition(ctx, order, domain.OrderFulfilled, "fulfilled_at")
+}
+
+// Cancel cancels a pending or paid order; paid orders are refunded. A
+// non-zero ownerID restricts cancellation to that user's orders.
+func (s *DefaultOrderService) Cancel(ctx context.Context, id, ownerID uint) (*domain.Order, error) {
+	order, err := s.GetOrder(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.transition(ctx, order, domain.OrderCancelled, "cancelled_at")
+}
+
+// Refund refunds a paid or fulfilled order.
+func (s *DefaultOrderService) Refund(ctx context.Context, id uint) (*domain.Order, error) {
+	order, err := s.GetOrder(ctx, id, 0)
+	if err != nil {
+		return nil, err
+	}
+	return s.transition(ctx, order, domain.OrderRefunded, "refunded_at")
+}
+
+// transition moves order to status to and stamps timestampColumn. Moving to
+// the current status is a no-op. Cancelling or refunding a paid order
+// refunds the payment after the status change: both statuses are final, so
+// no other request can move the order on in between, and a failed refund
+// restores the previous status.
+func (s *DefaultOrderService) transition(ctx context.Context, order *domain.Order, to, timestampColumn string) (*domain.Order, error) {
+	if order.Status == to {
+		return order, nil
+	}
+	if !domain.CanTransitionOrder(order.Status, to) {
+		return nil, invalidOrderTransition(order.Status, to)
+	}
+	from := order.Status
+	refund := from != domain.OrderPending && (to == domain.OrderCancelled || to == domain.OrderRefunded)
+	if refund && order.PaymentReference == "" {
+		return nil, ErrOrderPaymentNotRecorded
+	}
+	updated, err := s.orders.UpdateStatus(order.ID, from, to, map[string]any{timestampColumn: s.now().UTC()})
+	if errors.Is(err, repository.ErrOrderStatusConflict) {
+		current, findErr := s.orders.FindByID(order.ID)
+		if findErr != nil {
+			return nil, findErr
+		}
+		if current.Status == to {
+			return current, nil
+		}
+		return nil, invalidOrderTransition(current.Status, to)
+	}
+	if err != nil || !refund {
+		return updated, err
+	}
+	if _, err := s.payments.Refund(ctx, RefundRequest{
+		OrderID:     order.ID,
+		Reference:   order.PaymentReference,
+		AmountMinor: order.TotalMinor,
+		Currency:    order.Currency,
+	}); err != nil {
+		if _, rollbackErr := s.orders.UpdateStatus(order.ID, to, from, map[string]any{timestampColumn: nil}); rollbackErr != nil {
+			return nil, errors.Join(err, rollbackErr)
+		}
+		return nil, err
+	}
+	return updated, nil
+}
+
+// productPriceIn returns the product's price in currency: its own price or
+// one of its additional list prices. A zero price is a free product, not a
+// missing one.
+func productPriceIn(product *domain.Product, currency string) (int64, bool) {
+	if product.Currency == currency {
+		return product.PriceMinor, true
+	}
+	for _, price := range product.Prices {
+		if price.Currency == currency {
+			return price.AmountMinor, true
+		}
+	}
+	return 0, false
+}
+
+func invalidOrderTransition(from, to string) error {
+	return fmt.Errorf("%w: %s to %s", ErrInvalidOrderTransition, from, to)
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	repogomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOrderServiceCheckoutComputesTotals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orders := repogomock.NewMockOrderRepository(ctrl)
+	carts := repogomock.NewMockCartRepository(ctrl)
+	products := repogomock.NewMockProductRepository(ctrl)
+	svc := NewOrderService(orders, carts, products, NewFakePaymentProvider())
+	ctx := context.Background()
+
+	cart := &domain.Cart{ID: 4, Items: []domain.CartItem{{ProductID: 2, Quantity: 1}, {ProductID: 1, Quantity: 2}}}
+	carts.EXPECT().FindByUser(uint(9)).Return(cart, nil).Times(2)
+	products.EXPECT().FindByID(uint(1)).Return(&domain.Product{ID: 1, Name: "Mug", PriceMinor: 1999, Currency: "USD",
+		Prices: []domain.ProductPrice{{ProductID: 1, Currency: "EUR", AmountMinor: 1850}}}, nil).Times(2)
+	products.EXPECT().FindByID(uint(2)).Return(&domain.Product{ID: 2, Name: "Pen", PriceMinor: 500, Currency: "USD"}, nil).Times(2)
+
+	if _, err := svc.Checkout(ctx, 9, CheckoutInput{Currency: "eur"}); !errors.Is(err, ErrOrderCurrencyMismatch) {
+		t.Fatalf("expected ErrOrderCurrencyMismatch, got %v", err)
+	}
+
+	orders.EXPECT().CreateFromCart(gomock.AssignableToTypeOf(&domain.Order{}), uint(4)).Return(nil)
+	order, err := svc.Checkout(ctx, 9, CheckoutInput{})
+	if err != nil {
+		t.Fatalf("checkout: %v", err)
+	}
+	if order.Status != domain.OrderPending || order.Currency != "USD" || order.TotalMinor != 4498 || len(order.Items) != 2 {
+		t.Fatalf("unexpected order: %+v", order)
+	}
+	if first := order.Items[0]; first.ProductID != 1 || first.UnitPriceMinor != 1999 || first.LineTotalMinor != 3998 {
+		t.Fatalf("unexpected first item: %+v", first)
+	}
+
+	carts.EXPECT().FindByUser(uint(10)).Return(nil, repository.ErrCartNotFound)
+	if _, err := svc.Checkout(ctx, 10, CheckoutInput{}); !errors.Is(err, ErrCartEmpty) {
+		t.Fatalf("expected ErrCartEmpty, got %v", err)
+	}
+}
+
+func TestOrderServiceCheckoutAllowsFreeProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orders := repogomock.NewMockOrderRepository(ctrl)
+	carts := repogomock.NewMockCartRepository(ctrl)
+	products := repogomock.NewMockProductRepository(ctrl)
+	svc := NewOrderService(orders, carts, products, NewFakePaymentProvider())
+
+	carts.EXPECT().FindByUser(uint(9)).Return(&domain.Cart{ID: 4, Items: []domain.CartItem{{ProductID: 1, Quantity: 3}}}, nil)
+	products.EXPECT().FindByID(uint(1)).Return(&domain.Product{ID: 1, Name: "Sticker", Currency: "USD"}, nil)
+	orders.EXPECT().CreateFromCart(gomock.AssignableToTypeOf(&domain.Order{}), uint(4)).Return(nil)
+
+	order, err := svc.Checkout(context.Background(), 9, CheckoutInput{})
+	if err != nil {
+		t.Fatalf("checkout: %v", err)
+	}
+	if order.TotalMinor != 0 || len(order.Items) != 1 || order.Items[0].Quantity != 3 {
+		t.Fatalf("unexpected order: %+v", order)
+	}
+}
+
+func TestOrderServiceLifecycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orders := repogomock.NewMockOrderRepository(ctrl)
+	payments := NewFakePaymentProvider()
+	svc := NewOrderService(orders, repogomock.NewMockCartRepository(ctrl), repogomock.NewMockProductRepository(ctrl), payments)
+	ctx := context.Background()
+
+	pending := &domain.Order{ID: 3, UserID: 9, Status: domain.OrderPending, Currency: "USD", TotalMinor: 1000}
+	orders.EXPECT().FindByID(uint(3)).Return(pending, nil).Times(3)
+
+	if _, err := svc.GetOrder(ctx, 3, 8); !errors.Is(err, repository.ErrOrderNotFound) {
+		t.Fatalf("expected other users' orders to look missing, got %v", err)
+	}
+	if _, err := svc.Fulfill(ctx, 3); !errors.Is(err, ErrInvalidOrderTransition) {
+		t.Fatalf("expected unpaid order not to be fulfilled, got %v", err)
+	}
+
+	var paid *domain.Order
+	orders.EXPECT().UpdateStatus(uint(3), domain.OrderPending, domain.OrderPaid, gomock.Any()).
+		DoAndReturn(func(_ uint, _, _ string, updates map[string]any) (*domain.Order, error) {
+			if updates["payment_provider"] != "fake" || updates["payment_reference"] == "" || updates["paid_at"] == nil {
+				t.Fatalf("unexpected payment updates: %+v", updates)
+			}
+			paid = &domain.Order{ID: 3, UserID: 9, Status: domain.OrderPaid, Currency: "USD", TotalMinor: 1000,
+				PaymentProvider: "fake", PaymentReference: updates["payment_reference"].(string)}
+			return paid, nil
+		})
+	if _, err := svc.Pay(ctx, 3, 9); err != nil {
+		t.Fatalf("pay: %v", err)
+	}
+	if payments.Charges() != 1 {
+		t.Fatalf("expected one charge, got %d", payments.Charges())
+	}
+
+	orders.EXPECT().FindByID(uint(3)).DoAndReturn(func(uint) (*domain.Order, error) { return paid, nil })
+	orders.EXPECT().UpdateStatus(uint(3), domain.OrderPaid, domain.OrderCancelled, gomock.Any()).
+		Return(&domain.Order{ID: 3, UserID: 9, Status: domain.OrderCancelled}, nil)
+	cancelled, err := svc.Cancel(ctx, 3, 9)
+	if err != nil || cancelled.Status != domain.OrderCancelled {
+		t.Fatalf("cancel: %+v err=%v", cancelled, err)
+	}
+	if payments.Refunds() != 1 {
+		t.Fatalf("expected cancelling a paid order to refund it, got %d refunds", payments.Refunds())
+	}
+}
+
+func TestOrderServiceRestoresStatusWhenRefundFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orders := repogomock.NewMockOrderRepository(ctrl)
+	svc := NewOrderService(orders, repogomock.NewMockCartRepository(ctrl), repogomock.NewMockProductRepository(ctrl), NewFakePaymentProvider())
+	ctx := context.Background()
+
+	fulfilled := &domain.Order{ID: 3, Status: domain.OrderFulfilled, PaymentReference: "unknown_charge"}
+	orders.EXPECT().FindByID(uint(3)).Return(fulfilled, nil)
+	gomock.InOrder(
+		orders.EXPECT().UpdateStatus(uint(3), domain.OrderFulfilled, domain.OrderRefunded, gomock.Any()).
+			Return(&domain.Order{ID: 3, Status: domain.OrderRefunded}, nil),
+		orders.EXPECT().UpdateStatus(uint(3), domain.OrderRefunded, domain.OrderFulfilled, map[string]any{"refunded_at": nil}).
+			Return(fulfilled, nil),
+	)
+	if _, err := svc.Refund(ctx, 3); err == nil {
+		t.Fatal("expected refund error")
+	}
+}
+
+func TestFakePaymentProviderDeduplicates(t *testing.T) {
+	p := NewFakePaymentProvider()
+	ctx := context.Background()
+	first, err := p.Charge(ctx, ChargeRequest{OrderID: 1, AmountMinor: 100, Currency: "USD", IdempotencyKey: "order-1"})
+	if err != nil {
+		t.Fatalf("charge: %v", err)
+	}
+	second, err := p.Charge(ctx, ChargeRequest{OrderID: 1, AmountMinor: 100, Currency: "USD", IdempotencyKey: "order-1"})
+	if err != nil || second.Reference != first.Reference || p.Charges() != 1 {
+		t.Fatalf("expected retried charge to be deduplicated, got %+v err=%v charges=%d", second, err, p.Charges())
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := p.Refund(ctx, RefundRequest{OrderID: 1, Reference: first.Reference}); err != nil {
+			t.Fatalf("refund: %v", err)
+		}
+	}
+	if p.Refunds() != 1 {
+		t.Fatalf("expected one refund, got %d", p.Refunds())
+	}
+
+	p.Decline = func(req ChargeRequest) bool { return req.AmountMinor > 1000 }
+	if _, err := p.Charge(ctx, ChargeRequest{AmountMinor: 5000, IdempotencyKey: "order-2"}); !errors.Is(err, ErrPaymentDeclined) {
+		t.Fatalf("expected ErrPaymentDeclined, got %v", err)
+	}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+)
+
+var (
+	ErrOrganizationInvalidSlug   = errors.New("slug must be 3-64 lowercase letters, digits, or hyphens")
+	ErrOrganizationInvalidName   = errors.New("name must be between 1 and 120 characters")
+	ErrOrganizationNotMember     = errors.New("user is not a member of the organization")
+	ErrOrganizationProtectedRole = errors.New("protected roles cannot be granted within an organization")
+	ErrOrganizationLastAdmin     = errors.New("organization must keep at least one member admin")
+)
+
+// organizationAdminPermission marks the members who administer an
+// organization; every organization keeps at least one.
+const organizationAdminPermission = "members:write"
+
+var organizationSlugRe = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,62}[a-z0-9]$`)
+
+type DefaultOrganizationService struct {
+	repo           repository.OrganizationRepository
+	roleRepo       repository.RoleRepository
+	rbac           *RBACService
+	protectedRoles map[string]struct{}
+}
+
+func NewOrganizationService(repo repository.OrganizationRepository, roleRepo repository.RoleRepository, rbac *RBACService, protectedRoles []string) *DefaultOrganizationService {
+	protected := make(map[string]struct{}, len(protectedRoles))
+	for _, role := range protectedRoles {
+		if trimmed := strings.ToLower(strings.TrimSpace(role)); trimmed != "" {
+			protected[trimmed] = struct{}{}
+		}
+	}
+	return &DefaultOrganizationService{repo: repo, roleRepo: roleRepo, rbac: rbac, protectedRoles: protected}
+}
+
+func (s *DefaultOrganizationService) CreateOrganization(ctx context.Context, slug, name string) (*domain.Organization, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	name = strings.TrimSpace(name)
+	if !organizationSlugRe.MatchString(slug) {
+		return nil, ErrOrganizationInvalidSlug
+	}
+	// All-digit slugs would be indistinguishable from IDs in tenant selectors.
+	if _, err := strconv.ParseUint(slug, 10, 64); err == nil {
+		return nil, ErrOrganizationInvalidSlug
+	}
+	if name == "" || len(name) > 120 {
+		return nil, ErrOrganizationInvalidName
+	}
+	org := &domain.Organization{Slug: slug, Name: name}
+	if err := s.repo.Create(org); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+func (s *DefaultOrganizationService) ListOrganizations(ctx context.Context, req repository.PageRequest) (repository.PageResult[domain.Organization], error) {
+	return s.repo.ListPaged(req)
+}
+
+func (s *DefaultOrganizationService) GetOrganization(ctx context.Context, id uint) (*domain.Organization, error) {
+	return s.repo.FindByID(id)
+}
+
+func (s *DefaultOrganizationService) DeleteOrganization(ctx context.Context, id uint) error {
+	return s.repo.DeleteByID(id)
+}
+
+// ResolveTenant looks up the organization named by selector (numeric ID or
+// slug) and the caller's membership in it.
+func (s *DefaultOrganizationService) ResolveTenant(ctx context.Context, selector string, userID uint) (*Tenant, error) {
+	org, err := s.findOrganization(selector)
+	if err != nil {
+		return nil, err
+	}
+	membership, err := s.repo.FindMembership(org.ID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrMembershipNotFound) {
+			return nil, ErrOrganizationNotMember
+		}
+		return nil, err
+	}
+	if membership.Status == domain.MembershipInvited {
+		return nil, ErrOrganizationNotMember
+	}
+	return &Tenant{
+		OrganizationID: org.ID,
+		Slug:           org.Slug,
+		Permissions:    s.rbac.PermissionsFromRoles(membership.Roles),
+	}, nil
+}
+
+func (s *DefaultOrganizationService) ListMembers(ctx context.Context, orgID uint, req repository.PageRequest) (repository.PageResult[domain.Membership], error) {
+	if _, err := s.repo.FindByID(orgID); err != nil {
+		return repository.PageResult[domain.Membership]{}, err
+	}
+	return s.repo.ListMemberships(orgID, req)
+}
+
+// SetMemberRoles lets an org admin change a member's roles. A user who is
+// not yet a member is invited and joins only after accepting.
+func (s *DefaultOrganizationService) SetMemberRoles(ctx context.Context, orgID, userID uint, roleIDs []uint) (*domain.Membership, error) {
+	return s.setMemberRoles(orgID, userID, roleIDs, domain.MembershipInvited)
+}
+
+// SetOrganizationMember lets a platform admin add a member directly, e.g.
+// to bootstrap an organization's first org admin.
+func (s *DefaultOrganizationService) SetOrganizationMember(ctx context.Context, orgID, userID uint, roleIDs []uint) (*domain.Membership, error) {
+	return s.setMemberRoles(orgID, userID, roleIDs, domain.MembershipActive)
+}
+
+func (s *DefaultOrganizationService) RemoveMember(ctx context.Context, orgID, userID uint) error {
+	membership, err := s.repo.FindMembership(orgID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.keepAnotherAdmin(orgID, membership, nil); err != nil {
+		return err
+	}
+	return s.repo.DeleteMembership(orgID, userID)
+}
+
+func (s *DefaultOrganizationService) ListInvitations(ctx context.Context, userID uint) ([]domain.Organization, error) {
+	return s.repo.ListInvitingOrganizations(userID)
+}
+
+func (s *DefaultOrganizationService) AcceptInvitation(ctx context.Context, orgID, userID uint) (*domain.Membership, error) {
+	return s.repo.AcceptMembership(orgID, userID)
+}
+
+func (s *DefaultOrganizationService) DeclineInvitation(ctx context.Context, orgID, userID uint) error {
+	membership, err := s.repo.FindMembership(orgID, userID)
+	if err != nil {
+		return err
+	}
+	if membership.Status != domain.MembershipInvited {
+		return repository.ErrMembershipNotFound
+	}
+	return s.repo.DeleteMembership(orgID, userID)
+}
+
+func (s *DefaultOrganizationService) setMemberRoles(orgID, userID uint, roleIDs []uint, status string) (*domain.Membership, error) {
+	if _, err := s.repo.FindByID(orgID); err != nil {
+		return nil, err
+	}
+	roles := make([]domain.Role, 0, len(roleIDs))
+	for _, roleID := range roleIDs {
+		role, err := s.roleRepo.FindByID(roleID)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := s.protectedRoles[strings.ToLower(role.Name)]; ok {
+			return nil, ErrOrganizationProtectedRole
+		}
+		roles = append(roles, *role)
+	}
+	current, err := s.repo.FindMembership(orgID, userID)
+	switch {
+	case errors.Is(err, repository.ErrMembershipNotFound):
+	case err != nil:
+		return nil, err
+	default:
+		if err := s.keepAnotherAdmin(orgID, current, roles); err != nil {
+			return nil, err
+		}
+	}
+	return s.repo.UpsertMembership(orgID, userID, roleIDs, status)
+}
+
+// keepAnotherAdmin refuses to take the admin permission away from
+// membership, by replacing its roles with roles or removing it when roles
+// is nil, if no other active member holds it.
+func (s *DefaultOrganizationService) keepAnotherAdmin(orgID uint, membership *domain.Membership, roles []domain.Role) error {
+	if membership.Status == domain.MembershipInvited || !s.isOrganizationAdmin(membership.Roles) || s.isOrganizationAdmin(roles) {
+		return nil
+	}
+	resource, action, _ := strings.Cut(organizationAdminPermission, ":")
+	admins, err := s.repo.CountMembersWithPermission(orgID, resource, action)
+	if err != nil {
+		return err
+	}
+	if admins <= 1 {
+		return ErrOrganizationLastAdmin
+	}
+	return nil
+}
+
+func (s *DefaultOrganizationService) isOrganizationAdmin(roles []domain.Role) bool {
+	return s.rbac.HasPermission(s.rbac.PermissionsFromRoles(roles), organizationAdminPermission)
+}
+
+func (s *DefaultOrganizationService) findOrganization(selector string) (*domain.Organization, error) {
+	selector = strings.ToLower(strings.TrimSpace(selector))
+	if selector == "" {
+		return nil, repository.ErrOrganizationNotFound
+	}
+	if id, err := strconv.ParseUint(selector, 10, 64); err == nil {
+		return s.repo.FindByID(uint(id))
+	}
+	return s.repo.FindBySlug(selector)
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	repogomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOrganizationServiceCreateValidatesSlug(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockOrganizationRepository(ctrl)
+	svc := NewOrganizationService(repo, nil, NewRBACService(), nil)
+
+	for _, slug := range []string{"", "ab", "-acme", "acme-", "Acme Corp", "12345"} {
+		if _, err := svc.CreateOrganization(context.Background(), slug, "Acme"); !errors.Is(err, ErrOrganizationInvalidSlug) {
+			t.Fatalf("slug %q: expected ErrOrganizationInvalidSlug, got %v", slug, err)
+		}
+	}
+	if _, err := svc.CreateOrganization(context.Background(), "acme", "  "); !errors.Is(err, ErrOrganizationInvalidName) {
+		t.Fatalf("expected ErrOrganizationInvalidName, got %v", err)
+	}
+
+	repo.EXPECT().Create(gomock.Any()).Return(nil)
+	org, err := svc.CreateOrganization(context.Background(), " ACME-corp ", "Acme Corp")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if org.Slug != "acme-corp" {
+		t.Fatalf("expected normalized slug, got %q", org.Slug)
+	}
+}
+
+func TestOrganizationServiceResolveTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockOrganizationRepository(ctrl)
+	svc := NewOrganizationService(repo, nil, NewRBACService(), nil)
+
+	org := &domain.Organization{ID: 4, Slug: "acme"}
+	repo.EXPECT().FindBySlug("acme").Return(org, nil)
+	repo.EXPECT().FindMembership(uint(4), uint(7)).Return(&domain.Membership{
+		OrganizationID: 4,
+		UserID:         7,
+		Roles: []domain.Role{{Name: "org_admin", Permissions: []domain.Permission{
+			{Resource: "members", Action: "read"},
+		}}},
+	}, nil)
+	tenant, err := svc.ResolveTenant(context.Background(), "acme", 7)
+	if err != nil {
+		t.Fatalf("resolve by slug: %v", err)
+	}
+	if tenant.OrganizationID != 4 || len(tenant.Permissions) != 1 || tenant.Permissions[0] != "members:read" {
+		t.Fatalf("unexpected tenant: %+v", tenant)
+	}
+
+	repo.EXPECT().FindByID(uint(4)).Return(org, nil)
+	repo.EXPECT().FindMembership(uint(4), uint(8)).Return(nil, repository.ErrMembershipNotFound)
+	if _, err := svc.ResolveTenant(context.Background(), "4", 8); !errors.Is(err, ErrOrganizationNotMember) {
+		t.Fatalf("expected ErrOrganizationNotMember, got %v", err)
+	}
+
+	if _, err := svc.ResolveTenant(context.Background(), " ", 7); !errors.Is(err, repository.ErrOrganizationNotFound) {
+		t.Fatalf("expected ErrOrganizationNotFound for empty selector, got %v", err)
+	}
+}
+
+func TestOrganizationServiceSetMemberRolesRejectsProtectedRoles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockOrganizationRepository(ctrl)
+	roleRepo := repogomock.NewMockRoleRepository(ctrl)
+	svc := NewOrganizationService(repo, roleRepo, NewRBACService(), []string{"admin", "user"})
+
+	repo.EXPECT().FindByID(uint(4)).Return(&domain.Organization{ID: 4}, nil).Times(2)
+	roleRepo.EXPECT().FindByID(uint(1)).Return(&domain.Role{ID: 1, Name: "Admin"}, nil)
+	if _, err := svc.SetMemberRoles(context.Background(), 4, 7, []uint{1}); !errors.Is(err, ErrOrganizationProtectedRole) {
+		t.Fatalf("expected ErrOrganizationProtectedRole, got %v", err)
+	}
+
+	roleRepo.EXPECT().FindByID(uint(3)).Return(&domain.Role{ID: 3, Name: "org_admin"}, nil)
+	repo.EXPECT().FindMembership(uint(4), uint(7)).Return(nil, repository.ErrMembershipNotFound)
+	repo.EXPECT().UpsertMembership(uint(4), uint(7), []uint{3}, domain.MembershipInvited).Return(&domain.Membership{OrganizationID: 4, UserID: 7, Status: domain.MembershipInvited}, nil)
+	if _, err := svc.SetMemberRoles(context.Background(), 4, 7, []uint{3}); err != nil {
+		t.Fatalf("set member roles: %v", err)
+	}
+}
+
+func TestOrganizationServiceInvitesNewMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockOrganizationRepository(ctrl)
+	roleRepo := repogomock.NewMockRoleRepository(ctrl)
+	svc := NewOrganizationService(repo, roleRepo, NewRBACService(), nil)
+
+	repo.EXPECT().FindByID(uint(4)).Return(&domain.Organization{ID: 4, Slug: "acme"}, nil).AnyTimes()
+	repo.EXPECT().FindMembership(uint(4), uint(7)).Return(nil, repository.ErrMembershipNotFound)
+	repo.EXPECT().UpsertMembership(uint(4), uint(7), []uint{}, domain.MembershipActive).Return(&domain.Membership{OrganizationID: 4, UserID: 7, Status: domain.MembershipActive}, nil)
+	if _, err := svc.SetOrganizationMember(context.Background(), 4, 7, []uint{}); err != nil {
+		t.Fatalf("platform admins add members directly: %v", err)
+	}
+
+	repo.EXPECT().FindMembership(uint(4), uint(8)).Return(&domain.Membership{OrganizationID: 4, UserID: 8, Status: domain.MembershipInvited}, nil).Times(2)
+	if _, err := svc.ResolveTenant(context.Background(), "4", 8); !errors.Is(err, ErrOrganizationNotMember) {
+		t.Fatalf("expected a pending invitation to grant no tenant access, got %v", err)
+	}
+	repo.EXPECT().DeleteMembership(uint(4), uint(8)).Return(nil)
+	if err := svc.DeclineInvitation(context.Background(), 4, 8); err != nil {
+		t.Fatalf("decline: %v", err)
+	}
+	repo.EXPECT().FindMembership(uint(4), uint(9)).Return(&domain.Membership{OrganizationID: 4, UserID: 9, Status: domain.MembershipActive}, nil)
+	if err := svc.DeclineInvitation(context.Background(), 4, 9); !errors.Is(err, repository.ErrMembershipNotFound) {
+		t.Fatalf("expected declining to leave active memberships alone, got %v", err)
+	}
+}
+
+func TestOrganizationServiceKeepsLastAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockOrganizationRepository(ctrl)
+	roleRepo := repogomock.NewMockRoleRepository(ctrl)
+	svc := NewOrganizationService(repo, roleRepo, NewRBACService(), nil)
+
+	adminRole := domain.Role{ID: 3, Name: "org_admin", Permissions: []domain.Permission{{Resource: "members", Action: "write"}}}
+	viewerRole := domain.Role{ID: 5, Name: "org_viewer", Permissions: []domain.Permission{{Resource: "members", Action: "read"}}}
+	admin := &domain.Membership{OrganizationID: 4, UserID: 7, Status: domain.MembershipActive, Roles: []domain.Role{adminRole}}
+	repo.EXPECT().FindByID(uint(4)).Return(&domain.Organization{ID: 4}, nil).AnyTimes()
+	repo.EXPECT().FindMembership(uint(4), uint(7)).Return(admin, nil).AnyTimes()
+	roleRepo.EXPECT().FindByID(uint(5)).Return(&viewerRole, nil).AnyTimes()
+	roleRepo.EXPECT().FindByID(uint(3)).Return(&adminRole, nil).AnyTimes()
+
+	repo.EXPECT().CountMembersWithPermission(uint(4), "members", "write").Return(int64(1), nil).Times(2)
+	if err := svc.RemoveMember(context.Background(), 4, 7); !errors.Is(err, ErrOrganizationLastAdmin) {
+		t.Fatalf("expected the last admin to stay, got %v", err)
+	}
+	if _, err := svc.SetMemberRoles(context.Background(), 4, 7, []uint{5}); !errors.Is(err, ErrOrganizationLastAdmin) {
+		t.Fatalf("expected the last admin to keep the admin permission, got %v", err)
+	}
+
+	repo.EXPECT().UpsertMembership(uint(4), uint(7), []uint{3, 5}, domain.MembershipInvited).Return(admin, nil)
+	if _, err := svc.SetMemberRoles(context.Background(), 4, 7, []uint{3, 5}); err != nil {
+		t.Fatalf("expected roles that keep the admin permission to pass, got %v", err)
+	}
+	repo.EXPECT().CountMembersWithPermission(uint(4), "members", "write").Return(int64(2), nil)
+	repo.EXPECT().DeleteMembership(uint(4), uint(7)).Return(nil)
+	if err := svc.RemoveMember(context.Background(), 4, 7); err != nil {
+		t.Fatalf("expected removal with another admin left, got %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var ErrPaymentDeclined = errors.New("payment declined")
+
+type ChargeRequest struct {
+	OrderID     uint
+	AmountMinor int64
+	Currency    string
+	// IdempotencyKey identifies the charge; retrying with the same key must
+	// not bill the customer twice.
+	IdempotencyKey string
+}
+
+type RefundRequest struct {
+	OrderID     uint
+	Reference   string
+	AmountMinor int64
+	Currency    string
+}
+
+type PaymentResult struct {
+	Reference string
+}
+
+// PaymentProvider charges and refunds order payments. Implementations wrap a
+// payment gateway; FakePaymentProvider is the in-process default used for
+// development and tests.
+type PaymentProvider interface {
+	Name() string
+	Charge(ctx context.Context, req ChargeRequest) (*PaymentResult, error)
+	Refund(ctx context.Context, req RefundRequest) (*PaymentResult, error)
+}
+
+// FakePaymentProvider accepts every charge unless Decline says otherwise and
+// keeps charges in memory. Charges are deduplicated by IdempotencyKey and
+// refunds by reference, like a real gateway would.
+type FakePaymentProvider struct {
+	// Decline, when set, rejects the charges it returns true for with
+	// ErrPaymentDeclined.
+	Decline func(ChargeRequest) bool
+
+	mu       sync.Mutex
+	next     int
+	charges  map[string]string
+	refunded map[string]string
+}
+
+func NewFakePaymentProvider() *FakePaymentProvider {
+	return &FakePaymentProvider{charges: map[string]string{}, refunded: map[string]string{}}
+}
+
+func (p *FakePaymentProvider) Name() string { return "fake" }
+
+func (p *FakePaymentProvider) Charge(_ context.Context, req ChargeRequest) (*PaymentResult, error) {
+	if p.Decline != nil && p.Decline(req) {
+		return nil, ErrPaymentDeclined
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if ref, ok := p.charges[req.IdempotencyKey]; ok {
+		return &PaymentResult{Reference: ref}, nil
+	}
+	p.next++
+	ref := fmt.Sprintf("fake_ch_%d", p.next)
+	p.charges[req.IdempotencyKey] = ref
+	return &PaymentResult{Reference: ref}, nil
+}
+
+func (p *FakePaymentProvider) Refund(_ context.Context, req RefundRequest) (*PaymentResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if ref, ok := p.refunded[req.Reference]; ok {
+		return &PaymentResult{Reference: ref}, nil
+	}
+	known := false
+	for _, ref := range p.charges {
+		if ref == req.Reference {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return nil, fmt.Errorf("unknown charge %q", req.Reference)
+	}
+	p.next++
+	ref := fmt.Sprintf("fake_re_%d", p.next)
+	p.refunded[req.Reference] = ref
+	return &PaymentResult{Reference: ref}, nil
+}
+
+// Charges reports how many distinct charges were made.
+func (p *FakePaymentProvider) Charges() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.charges)
+}
+
+// Refunds reports how many distinct charges were refunded.
+func (p *FakePaymentProvider) Refunds() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.refunded)
+}
@@ -0,0 +1,44 @@
+package service
+
+import "context"
+
+// Principal is the authenticated caller and the effective permissions that
+// authorized the current request. Services use it for record-level checks
+// such as "own" permission scopes.
+type Principal struct {
+	UserID      uint
+	Permissions []string
+	// System marks work the application does on its own behalf, such as
+	// background jobs. It holds every permission and has no user.
+	System bool
+}
+
+// SystemPrincipal is the principal for internal callers that act without a
+// signed-in user. Services refuse record-level work when no principal is
+// present, so such callers must set this one explicitly.
+func SystemPrincipal() Principal {
+	return Principal{System: true}
+}
+
+type principalContextKey struct{}
+
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(Principal)
+	return principal, ok
+}
+
+func (p Principal) Has(permission string) bool {
+	if p.System {
+		return true
+	}
+	for _, perm := range p.Permissions {
+		if perm == permission {
+			return true
+		}
+	}
+	return false
+}
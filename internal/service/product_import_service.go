@@ -0,0 +1,365 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+)
+
+var (
+	ErrProductImportInvalidFormat = errors.New("format must be csv or ndjson")
+	ErrProductImportEmpty         = errors.New("import file is empty")
+	ErrProductImportSKURequired   = errors.New("sku is required to match existing products")
+
+	// errProductImportFile marks job failures caused by the file itself;
+	// their message is shown to the caller.
+	errProductImportFile        = errors.New("invalid import file")
+	errProductImportInterrupted = errors.New("import was interrupted by a server shutdown; rows before the interruption were kept")
+)
+
+const (
+	// maxProductImportErrors caps the per-row errors stored on a job.
+	maxProductImportErrors      = 1000
+	productImportProgressEvery  = 100
+	productExportBatchSize      = 500
+	maxConcurrentProductImports = 2
+)
+
+// ProductImportInput starts an import. Body is read to the end before
+// StartImport returns.
+type ProductImportInput struct {
+	Format string
+	DryRun bool
+	Body   io.Reader
+}
+
+// DefaultProductImportService runs bulk product imports in the background
+// and streams exports. Every imported row goes through ProductService, so it
+// is validated exactly like a product created or updated through the API.
+// Rows are matched to existing products by SKU: a match is updated with the
+// row's values, anything else is created.
+type DefaultProductImportService struct {
+	jobs     repository.ProductImportJobRepository
+	repo     repository.ProductRepository
+	products ProductService
+	maxRows  int
+	logger   *slog.Logger
+	now      func() time.Time
+
+	slots  chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewProductImportService(jobs repository.ProductImportJobRepository, repo repository.ProductRepository, products ProductService, maxRows int, logger *slog.Logger) *DefaultProductImportService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &DefaultProductImportService{
+		jobs:     jobs,
+		repo:     repo,
+		products: products,
+		maxRows:  maxRows,
+		logger:   logger,
+		now:      time.Now,
+		slots:    make(chan struct{}, maxConcurrentProductImports),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// StartImport stores the uploaded file, records a pending job and processes
+// the file in the background. The job keeps the caller's tenant and
+// principal, so rows are checked against the caller's permissions.
+func (s *DefaultProductImportService) StartImport(ctx context.Context, input ProductImportInput) (*domain.ProductImportJob, error) {
+	if input.Format != ProductTransferCSV && input.Format != ProductTransferNDJSON {
+		return nil, ErrProductImportInvalidFormat
+	}
+	spool, err := os.CreateTemp("", "product-import-*")
+	if err != nil {
+		return nil, err
+	}
+	started := false
+	defer func() {
+		if !started {
+			_ = spool.Close()
+			_ = os.Remove(spool.Name())
+		}
+	}()
+	size, err := io.Copy(spool, input.Body)
+	if err != nil {
+		return nil, err
+	}
+	if size == 0 {
+		return nil, ErrProductImportEmpty
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	job := &domain.ProductImportJob{Format: input.Format, DryRun: input.DryRun, Status: domain.ProductImportPending, Errors: domain.ProductImportErrors{}, CreatedBy: actorFromContext(ctx)}
+	if tenant, ok := TenantFromContext(ctx); ok {
+		orgID := tenant.OrganizationID
+		job.OrganizationID = &orgID
+	}
+	if err := s.jobs.Create(job); err != nil {
+		return nil, err
+	}
+	started = true
+	snapshot := *job
+	s.wg.Add(1)
+	go s.run(context.WithoutCancel(ctx), job, spool)
+	return &snapshot, nil
+}
+
+// GetImportJob loads a job started in the caller's tenant; jobs of other
+// tenants look missing.
+func (s *DefaultProductImportService) GetImportJob(ctx context.Context, id uint) (*domain.ProductImportJob, error) {
+	job, err := s.jobs.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	tenant, ok := TenantFromContext(ctx)
+	switch {
+	case ok && (job.OrganizationID == nil || *job.OrganizationID != tenant.OrganizationID):
+		return nil, repository.ErrProductImportJobNotFound
+	case !ok && job.OrganizationID != nil:
+		return nil, repository.ErrProductImportJobNotFound
+	}
+	return job, nil
+}
+
+// Export writes every product in the caller's tenant to w in ID order. It
+// reads products in batches, so memory use does not grow with the catalog.
+func (s *DefaultProductImportService) Export(ctx context.Context, format string, w io.Writer) error {
+	start := time.Now()
+	outcome := "success"
+	defer func() { observability.RecordProductOperation(ctx, "export", outcome, time.Since(start)) }()
+
+	writer, err := newProductRecordWriter(format, w)
+	if err != nil {
+		outcome = "bad_request"
+		return err
+	}
+	repo := s.repo
+	if tenant, ok := TenantFromContext(ctx); ok {
+		repo = repo.ForOrganization(tenant.OrganizationID)
+	}
+	var after uint
+	for {
+		batch, err := repo.ListAfter(after, productExportBatchSize)
+		if err != nil {
+			outcome = "error"
+			return err
+		}
+		for _, product := range batch {
+			if err := writer.Write(product); err != nil {
+				outcome = "error"
+				return err
+			}
+		}
+		if err := writer.Flush(); err != nil {
+			outcome = "error"
+			return err
+		}
+		if len(batch) < productExportBatchSize {
+			return nil
+		}
+		after = batch[len(batch)-1].ID
+	}
+}
+
+// Close stops running imports, marking them failed, and waits for them to
+// record their final state.
+func (s *DefaultProductImportService) Close() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *DefaultProductImportService) run(ctx context.Context, job *domain.ProductImportJob, spool *os.File) {
+	defer s.wg.Done()
+	defer func() {
+		_ = spool.Close()
+		_ = os.Remove(spool.Name())
+	}()
+
+	select {
+	case s.slots <- struct{}{}:
+		defer func() { <-s.slots }()
+	case <-s.ctx.Done():
+		s.finish(job, errProductImportInterrupted)
+		return
+	}
+
+	start := time.Now()
+	startedAt := s.now().UTC()
+	job.Status = domain.ProductImportRunning
+	job.StartedAt = &startedAt
+	s.save(job)
+	err := s.process(ctx, job, spool)
+	s.finish(job, err)
+
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	observability.RecordProductOperation(ctx, "import", outcome, time.Since(start))
+}
+
+func (s *DefaultProductImportService) process(ctx context.Context, job *domain.ProductImportJob, file io.Reader) error {
+	records, err := newProductRecordReader(job.Format, file)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errProductImportFile, err)
+	}
+	repo := s.repo
+	if tenant, ok := TenantFromContext(ctx); ok {
+		repo = repo.ForOrganization(tenant.OrganizationID)
+	}
+	// seen remembers SKUs accepted earlier in a dry run, where nothing is
+	// stored, so a repeated SKU is reported as an update like in a real run.
+	seen := map[string]struct{}{}
+	for {
+		if s.ctx.Err() != nil {
+			return errProductImportInterrupted
+		}
+		rec, err := records.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil && !errors.Is(err, errProductRecord) {
+			if errors.Is(err, errProductImportFile) {
+				return err
+			}
+			return fmt.Errorf("read import file: %w", err)
+		}
+		if job.ProcessedRows >= s.maxRows {
+			return fmt.Errorf("%w: more than %d rows", errProductImportFile, s.maxRows)
+		}
+		job.ProcessedRows++
+
+		created := false
+		if err == nil {
+			created, err = s.importRecord(ctx, repo, job.DryRun, rec, seen)
+		}
+		switch {
+		case err != nil:
+			job.FailedRows++
+			if len(job.Errors) < maxProductImportErrors {
+				job.Errors = append(job.Errors, domain.ProductImportError{Row: job.ProcessedRows, SKU: rec.SKU, Error: productImportRowError(err)})
+			}
+		case created:
+			job.CreatedRows++
+		default:
+			job.UpdatedRows++
+		}
+		if job.ProcessedRows%productImportProgressEvery == 0 {
+			s.save(job)
+		}
+	}
+}
+
+// importRecord creates or updates the product described by rec and reports
+// whether it was created. In a dry run the record is only validated.
+func (s *DefaultProductImportService) importRecord(ctx context.Context, repo repository.ProductRepository, dryRun bool, rec productRecord, seen map[string]struct{}) (bool, error) {
+	input := rec.input()
+	sku, err := normalizeProductSKU(input.SKU)
+	if err != nil {
+		return false, err
+	}
+	if sku == nil {
+		return false, ErrProductImportSKURequired
+	}
+	input.SKU = *sku
+	existing, err := repo.FindBySKU(*sku)
+	if err != nil && !errors.Is(err, repository.ErrProductNotFound) {
+		return false, err
+	}
+	if existing != nil && existing.DeletedAt.Valid {
+		return false, ErrProductSKUTaken
+	}
+
+	if dryRun {
+		if err := s.products.ValidateCreate(ctx, input); err != nil {
+			return false, err
+		}
+		_, repeated := seen[*sku]
+		seen[*sku] = struct{}{}
+		return existing == nil && !repeated, nil
+	}
+	if existing == nil {
+		_, err := s.products.Create(ctx, input)
+		return err == nil, err
+	}
+	// A row describes the whole product, so fields it leaves empty are
+	// cleared rather than kept.
+	var categoryID uint
+	if input.CategoryID != nil {
+		categoryID = *input.CategoryID
+	}
+	_, err = s.products.Update(ctx, existing.ID, 0, UpdateProductInput{
+		Name:        &input.Name,
+		Description: &input.Description,
+		Price:       &input.Price,
+		Prices:      &input.Prices,
+		CategoryID:  &categoryID,
+		Tags:        &input.Tags,
+		Attributes:  &input.Attributes,
+	})
+	return false, err
+}
+
+func (s *DefaultProductImportService) finish(job *domain.ProductImportJob, err error) {
+	finishedAt := s.now().UTC()
+	job.FinishedAt = &finishedAt
+	job.Status = domain.ProductImportCompleted
+	if err != nil {
+		job.Status = domain.ProductImportFailed
+		switch {
+		case errors.Is(err, errProductImportFile), errors.Is(err, errProductImportInterrupted):
+			job.Failure = truncateProductImportMessage(err.Error())
+		default:
+			job.Failure = "import failed due to an internal error"
+			if s.logger != nil {
+				s.logger.Error("product import failed", "job_id", job.ID, "error", err)
+			}
+		}
+	}
+	s.save(job)
+}
+
+func (s *DefaultProductImportService) save(job *domain.ProductImportJob) {
+	if err := s.jobs.Update(job); err != nil && s.logger != nil {
+		s.logger.Warn("product import progress update failed", "job_id", job.ID, "status", job.Status, "error", err)
+	}
+}
+
+// productImportRowError describes a rejected row. Validation errors are
+// reported as they are; anything else would expose internals.
+func productImportRowError(err error) string {
+	switch {
+	case errors.Is(err, errProductRecord), errors.Is(err, ErrProductImportSKURequired),
+		errors.Is(err, ErrProductForbidden), errors.Is(err, repository.ErrProductNotFound):
+		return truncateProductImportMessage(err.Error())
+	}
+	switch productOutcome(err) {
+	case "bad_request", "conflict":
+		return truncateProductImportMessage(err.Error())
+	default:
+		return "internal error"
+	}
+}
+
+func truncateProductImportMessage(message string) string {
+	if len(message) > 500 {
+		return message[:500]
+	}
+	return message
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	repogomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+// recordImportJobs stores jobs created and saved through jobs and returns a
+// function yielding the last saved state of a job.
+func recordImportJobs(jobs *repogomock.MockProductImportJobRepository) func(id uint) domain.ProductImportJob {
+	var mu sync.Mutex
+	saved := map[uint]domain.ProductImportJob{}
+	jobs.EXPECT().Create(gomock.Any()).DoAndReturn(func(job *domain.ProductImportJob) error {
+		mu.Lock()
+		defer mu.Unlock()
+		job.ID = uint(len(saved) + 1)
+		job.Errors = domain.ProductImportErrors{}
+		saved[job.ID] = *job
+		return nil
+	}).AnyTimes()
+	jobs.EXPECT().Update(gomock.Any()).DoAndReturn(func(job *domain.ProductImportJob) error {
+		mu.Lock()
+		defer mu.Unlock()
+		copied := *job
+		copied.Errors = append(domain.ProductImportErrors{}, job.Errors...)
+		saved[job.ID] = copied
+		return nil
+	}).AnyTimes()
+	return func(id uint) domain.ProductImportJob {
+		mu.Lock()
+		defer mu.Unlock()
+		return saved[id]
+	}
+}
+
+func TestProductImportServiceUpsertsBySKU(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	jobs := repogomock.NewMockProductImportJobRepository(ctrl)
+	repo := repogomock.NewMockProductRepository(ctrl)
+	tenantRepo := repogomock.NewMockProductRepository(ctrl)
+	products := NewMockProductService(ctrl)
+	svc := NewProductImportService(jobs, repo, products, 100, nil)
+	savedJob := recordImportJobs(jobs)
+
+	ctx := WithPrincipal(WithTenant(context.Background(), Tenant{OrganizationID: 4}), Principal{UserID: 9})
+	repo.EXPECT().ForOrganization(uint(4)).Return(tenantRepo)
+	tenantRepo.EXPECT().FindBySKU("NEW-1").Return(nil, repository.ErrProductNotFound)
+	tenantRepo.EXPECT().FindBySKU("OLD-1").Return(&domain.Product{ID: 12}, nil)
+	tenantRepo.EXPECT().FindBySKU("BAD-1").Return(nil, repository.ErrProductNotFound)
+	products.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, input CreateProductInput) (*domain.Product, error) {
+		if input.SKU != "NEW-1" || input.Price.Amount != "10.50" || input.Price.Currency != "USD" || len(input.Tags) != 2 || len(input.Prices) != 1 {
+			t.Errorf("unexpected create input: %+v", input)
+		}
+		return &domain.Product{ID: 13}, nil
+	})
+	products.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, ErrProductInvalidName)
+	products.EXPECT().Update(gomock.Any(), uint(12), uint(0), gomock.Any()).DoAndReturn(func(_ context.Context, _, _ uint, input UpdateProductInput) (*domain.Product, error) {
+		if *input.Name != "Old Widget" || input.CategoryID == nil || *input.CategoryID != 0 || input.Tags == nil || len(*input.Tags) != 0 {
+			t.Errorf("expected a full replacement update, got %+v", input)
+		}
+		return &domain.Product{ID: 12}, nil
+	})
+
+	file := "sku,name,price,currency,prices,tags\n" +
+		"NEW-1,New Widget,10.50,USD,EUR:9.75,a|b\n" +
+		"OLD-1,Old Widget,3,USD,,\n" +
+		",No SKU,1,USD,,\n" +
+		"BAD-1,x,1,USD,,\n" +
+		"ODD-1,too,many,fields,,,\n"
+	job, err := svc.StartImport(ctx, ProductImportInput{Format: ProductTransferCSV, Body: strings.NewReader(file)})
+	if err != nil {
+		t.Fatalf("start import: %v", err)
+	}
+	if job.Status != domain.ProductImportPending || job.OrganizationID == nil || *job.OrganizationID != 4 || job.CreatedBy == nil || *job.CreatedBy != 9 {
+		t.Fatalf("unexpected started job: %+v", job)
+	}
+	svc.wg.Wait()
+
+	final := savedJob(job.ID)
+	if final.Status != domain.ProductImportCompleted || final.StartedAt == nil || final.FinishedAt == nil {
+		t.Fatalf("expected completed job, got %+v", final)
+	}
+	if final.ProcessedRows != 5 || final.CreatedRows != 1 || final.UpdatedRows != 1 || final.FailedRows != 3 {
+		t.Fatalf("unexpected counters: %+v", final)
+	}
+	if len(final.Errors) != 3 || final.Errors[0].Row != 3 || final.Errors[0].Error != ErrProductImportSKURequired.Error() ||
+		final.Errors[1].SKU != "BAD-1" || final.Errors[1].Error != ErrProductInvalidName.Error() || final.Errors[2].Row != 5 {
+		t.Fatalf("unexpected row errors: %+v", final.Errors)
+	}
+}
+
+func TestProductImportServiceDryRunWritesNothing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	jobs := repogomock.NewMockProductImportJobRepository(ctrl)
+	repo := repogomock.NewMockProductRepository(ctrl)
+	products := NewMockProductService(ctrl)
+	svc := NewProductImportService(jobs, repo, products, 100, nil)
+	savedJob := recordImportJobs(jobs)
+
+	repo.EXPECT().FindBySKU("A-1").Return(nil, repository.ErrProductNotFound).Times(2)
+	repo.EXPECT().FindBySKU("B-1").Return(&domain.Product{ID: 3}, nil)
+	repo.EXPECT().FindBySKU("C-1").Return(nil, repository.ErrProductNotFound)
+	products.EXPECT().ValidateCreate(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, input CreateProductInput) error {
+		if input.SKU == "C-1" {
+			return ErrProductInvalidPrice
+		}
+		return nil
+	}).Times(4)
+
+	file := `{"sku":"A-1","name":"Alpha","price":"1.00"}` + "\n\n" +
+		`{"sku":"A-1","name":"Alpha again","price_minor":100}` + "\n" +
+		`{"sku":"B-1","name":"Beta","price":2}` + "\n" +
+		`{"sku":"C-1","name":"Gamma","price":"0"}` + "\n" +
+		`{"sku":"D-1","nmae":"typo"}` + "\n"
+	job, err := svc.StartImport(context.Background(), ProductImportInput{Format: ProductTransferNDJSON, DryRun: true, Body: strings.NewReader(file)})
+	if err != nil {
+		t.Fatalf("start import: %v", err)
+	}
+	svc.wg.Wait()
+
+	final := savedJob(job.ID)
+	if final.Status != domain.ProductImportCompleted || !final.DryRun || final.OrganizationID != nil {
+		t.Fatalf("expected completed dry run, got %+v", final)
+	}
+	if final.ProcessedRows != 5 || final.CreatedRows != 1 || final.UpdatedRows != 2 || final.FailedRows != 2 {
+		t.Fatalf("unexpected counters: %+v", final)
+	}
+	if final.Errors[0].Row != 4 || final.Errors[1].Row != 5 || !strings.Contains(final.Errors[1].Error, "nmae") {
+		t.Fatalf("unexpected row errors: %+v", final.Errors)
+	}
+}
+
+func TestProductImportServiceRejectsBadFiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	jobs := repogomock.NewMockProductImportJobRepository(ctrl)
+	repo := repogomock.NewMockProductRepository(ctrl)
+	products := NewMockProductService(ctrl)
+	svc := NewProductImportService(jobs, repo, products, 1, nil)
+	savedJob := recordImportJobs(jobs)
+
+	if _, err := svc.StartImport(context.Background(), ProductImportInput{Format: "xlsx", Body: strings.NewReader("x")}); !errors.Is(err, ErrProductImportInvalidFormat) {
+		t.Fatalf("expected ErrProductImportInvalidFormat, got %v", err)
+	}
+	if _, err := svc.StartImport(context.Background(), ProductImportInput{Format: ProductTransferCSV, Body: strings.NewReader("")}); !errors.Is(err, ErrProductImportEmpty) {
+		t.Fatalf("expected ErrProductImportEmpty, got %v", err)
+	}
+
+	header, err := svc.StartImport(context.Background(), ProductImportInput{Format: ProductTransferCSV, Body: strings.NewReader("sku,colour\nA,red\n")})
+	if err != nil {
+		t.Fatalf("start import: %v", err)
+	}
+	// The second row exceeds the limit of one row and fails the job.
+	repo.EXPECT().FindBySKU("A-1").Return(nil, repository.ErrProductNotFound)
+	products.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, ErrProductInvalidName)
+	tooMany, err := svc.StartImport(context.Background(), ProductImportInput{Format: ProductTransferCSV, Body: strings.NewReader("sku,name\nA-1,\nA-2,\n")})
+	if err != nil {
+		t.Fatalf("start import: %v", err)
+	}
+	svc.wg.Wait()
+
+	if job := savedJob(header.ID); job.Status != domain.ProductImportFailed || !strings.Contains(job.Failure, `unknown csv column "colour"`) {
+		t.Fatalf("expected unknown column failure, got %+v", job)
+	}
+	if job := savedJob(tooMany.ID); job.Status != domain.ProductImportFailed || job.ProcessedRows != 1 || !strings.Contains(job.Failure, "more than 1 rows") {
+		t.Fatalf("expected row limit failure, got %+v", job)
+	}
+}
+
+func TestProductImportServiceGetImportJobIsTenantScoped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	jobs := repogomock.NewMockProductImportJobRepository(ctrl)
+	svc := NewProductImportService(jobs, repogomock.NewMockProductRepository(ctrl), NewMockProductService(ctrl), 10, nil)
+
+	orgID := uint(4)
+	jobs.EXPECT().FindByID(uint(1)).Return(&domain.ProductImportJob{ID: 1, OrganizationID: &orgID}, nil).Times(3)
+	acme := WithTenant(context.Background(), Tenant{OrganizationID: 4})
+	globex := WithTenant(context.Background(), Tenant{OrganizationID: 5})
+
+	if job, err := svc.GetImportJob(acme, 1); err != nil || job.ID != 1 {
+		t.Fatalf("expected job in own tenant, got %+v err=%v", job, err)
+	}
+	if _, err := svc.GetImportJob(globex, 1); !errors.Is(err, repository.ErrProductImportJobNotFound) {
+		t.Fatalf("expected other tenant's job to look missing, got %v", err)
+	}
+	if _, err := svc.GetImportJob(context.Background(), 1); !errors.Is(err, repository.ErrProductImportJobNotFound) {
+		t.Fatalf("expected tenant job to be hidden without a tenant, got %v", err)
+	}
+}
+
+func TestProductImportServiceExportRoundTrips(t *testing.T) {
+	sku := "WID-1"
+	categoryID := uint(2)
+	product := domain.Product{
+		ID: 1, SKU: &sku, Name: "Widget, large", Description: `say "hi"`, PriceMinor: 1050, Currency: "USD",
+		Prices: []domain.ProductPrice{{Currency: "EUR", AmountMinor: 975}}, CategoryID: &categoryID,
+		Tags: []string{"a", "b"}, Attributes: domain.ProductAttributes{"colour": "red"},
+	}
+
+	for _, format := range []string{ProductTransferCSV, ProductTransferNDJSON} {
+		t.Run(format, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := repogomock.NewMockProductRepository(ctrl)
+			svc := NewProductImportService(repogomock.NewMockProductImportJobRepository(ctrl), repo, NewMockProductService(ctrl), 10, nil)
+			repo.EXPECT().ListAfter(uint(0), productExportBatchSize).Return([]domain.Product{product}, nil)
+
+			var out bytes.Buffer
+			if err := svc.Export(context.Background(), format, &out); err != nil {
+				t.Fatalf("export: %v", err)
+			}
+			records, err := newProductRecordReader(format, &out)
+			if err != nil {
+				t.Fatalf("read export: %v", err)
+			}
+			rec, err := records.Next()
+			if err != nil {
+				t.Fatalf("read record: %v", err)
+			}
+			input := rec.input()
+			if input.SKU != sku || input.Name != product.Name || input.Description != product.Description ||
+				input.Price.Amount != "10.50" || input.Price.Currency != "USD" || input.CategoryID == nil || *input.CategoryID != 2 {
+				t.Fatalf("unexpected round-tripped input: %+v", input)
+			}
+			if len(input.Prices) != 1 || input.Prices[0].Currency != "EUR" || input.Prices[0].Amount != "9.75" ||
+				len(input.Tags) != 2 || input.Attributes["colour"] != "red" {
+				t.Fatalf("unexpected round-tripped details: %+v", input)
+			}
+			if _, err := records.Next(); !errors.Is(err, io.EOF) {
+				t.Fatalf("expected a single record, got %v", err)
+			}
+		})
+	}
+}
@@ -3,6 +3,10 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
 	"strings"
 	"time"
 
@@ -14,28 +18,116 @@ import (
 var (
 	ErrProductInvalidName        = errors.New("name must be between 3 and 120 characters")
 	ErrProductInvalidDescription = errors.New("description must be <= 500 characters")
-	ErrProductInvalidPrice       = errors.New("price must be greater than 0")
+	ErrProductInvalidPrice       = errors.New("invalid price")
 	ErrProductNoUpdates          = errors.New("no updates provided")
+	ErrProductForbidden          = errors.New("not allowed to modify this product")
+	ErrProductInvalidGrant       = errors.New("grant must target exactly one of user_id or group_id with access read or write")
+	ErrProductInvalidCategory    = errors.New("category does not exist")
+	ErrProductInvalidTags        = errors.New("tags must be at most 20 values of 1 to 50 characters")
+	ErrProductInvalidSKU         = errors.New("sku must be 1 to 64 letters, digits, '.', '_' or '-' and start with a letter or digit")
+	ErrProductSKUTaken           = errors.New("sku is already used by another product")
 )
 
+const (
+	maxProductTags   = 20
+	maxProductSKU    = 64
+	maxProductImages = 20
+)
+
+// CreateProductInput describes a new product. An empty SKU leaves the
+// product without one.
 type CreateProductInput struct {
+	SKU         string
 	Name        string
 	Description string
-	Price       float64
+	Price       PriceInput
+	Prices      []PriceInput
+	CategoryID  *uint
+	Tags        []string
+	Attributes  map[string]any
 }
 
+// UpdateProductInput changes only the fields that are set. A CategoryID of 0
+// removes the product from its category; Prices, Tags and Attributes replace
+// the stored values as a whole. An empty SKU removes it.
 type UpdateProductInput struct {
+	SKU         *string
 	Name        *string
 	Description *string
-	Price       *float64
+	Price       *PriceInput
+	Prices      *[]PriceInput
+	CategoryID  *uint
+	Tags        *[]string
+	Attributes  *map[string]any
+}
+
+type ShareProductInput struct {
+	UserID  *uint
+	GroupID *uint
+	Access  string
 }
 
 type ProductServiceImpl struct {
-	repo repository.ProductRepository
+	repo       repository.ProductRepository
+	categories repository.CategoryRepository
+	storage    StorageService
+}
+
+func NewProductService(repo repository.ProductRepository, categories repository.CategoryRepository, storage StorageService) *ProductServiceImpl {
+	return &ProductServiceImpl{repo: repo, categories: categories, storage: storage}
+}
+
+// repoFor scopes product queries to the active tenant, if any.
+func (s *ProductServiceImpl) repoFor(ctx context.Context) repository.ProductRepository {
+	if tenant, ok := TenantFromContext(ctx); ok {
+		return s.repo.ForOrganization(tenant.OrganizationID)
+	}
+	return s.repo
 }
 
-func NewProductService(repo repository.ProductRepository) *ProductServiceImpl {
-	return &ProductServiceImpl{repo: repo}
+// visibleRepo narrows the tenant repository to products the caller owns or
+// has been granted when the caller holds neither products:read nor the given
+// global permission, so records outside their reach look missing.
+func (s *ProductServiceImpl) visibleRepo(ctx context.Context, global string) repository.ProductRepository {
+	repo := s.repoFor(ctx)
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.Has("products:read") || principal.Has(global) {
+		return repo
+	}
+	return repo.ForViewer(principal.UserID)
+}
+
+// authorizeOwn applies the ":own" scope for callers lacking the global
+// permission. Owners always qualify; write-level grants also qualify when
+// allowGrant is set. A context without a principal is refused.
+func (s *ProductServiceImpl) authorizeOwn(ctx context.Context, repo repository.ProductRepository, id uint, global string, allowGrant bool) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return ErrProductForbidden
+	}
+	if principal.Has(global) {
+		return nil
+	}
+	if !principal.Has(global + ":own") {
+		return ErrProductForbidden
+	}
+	product, err := repo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if product.OwnerID != nil && *product.OwnerID == principal.UserID {
+		return nil
+	}
+	if allowGrant {
+		granted, err := repo.HasGrant(id, principal.UserID, domain.ProductAccessWrite)
+		if err != nil {
+			return err
+		}
+		if granted {
+			return nil
+		}
+	}
+	return ErrProductForbidden
 }
 
 func (s *ProductServiceImpl) Create(ctx context.Context, input CreateProductInput) (*domain.Product, error) {
@@ -43,39 +135,106 @@ func (s *ProductServiceImpl) Create(ctx context.Context, input CreateProductInpu
 	outcome := "success"
 	defer func() { observability.RecordProductOperation(ctx, "create", outcome, time.Since(start)) }()
 
+	product, err := s.newProduct(input)
+	if err != nil {
+		outcome = productOutcome(err)
+		return nil, err
+	}
+	repo := s.repoFor(ctx)
+	if product.SKU != nil {
+		if err := checkSKUFree(repo, *product.SKU, 0); err != nil {
+			outcome = productOutcome(err)
+			return nil, err
+		}
+	}
+	if principal, ok := PrincipalFromContext(ctx); ok && !principal.System {
+		owner := principal.UserID
+		product.OwnerID = &owner
+		product.CreatedBy = &owner
+	}
+	if err := repo.Create(product); err != nil {
+		outcome = "error"
+		return nil, err
+	}
+	return product, nil
+}
+
+// ValidateCreate applies the validation rules of Create without storing
+// anything. SKU uniqueness is not checked.
+func (s *ProductServiceImpl) ValidateCreate(ctx context.Context, input CreateProductInput) error {
+	_, err := s.newProduct(input)
+	return err
+}
+
+// newProduct validates input and builds the product it describes.
+func (s *ProductServiceImpl) newProduct(input CreateProductInput) (*domain.Product, error) {
 	name := strings.TrimSpace(input.Name)
 	description := strings.TrimSpace(input.Description)
 	if len(name) < 3 || len(name) > 120 {
-		outcome = "bad_request"
 		return nil, ErrProductInvalidName
 	}
 	if len(description) > 500 {
-		outcome = "bad_request"
 		return nil, ErrProductInvalidDescription
 	}
-	if input.Price <= 0 {
-		outcome = "bad_request"
-		return nil, ErrProductInvalidPrice
+	sku, err := normalizeProductSKU(input.SKU)
+	if err != nil {
+		return nil, err
 	}
-
-	product := &domain.Product{Name: name, Description: description, Price: input.Price}
-	if err := s.repo.Create(product); err != nil {
-		outcome = "error"
+	currency, priceMinor, err := resolvePrice(input.Price, domain.DefaultCurrency)
+	if err != nil {
 		return nil, err
 	}
-	return product, nil
+	prices, err := resolvePriceList(input.Prices, currency)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := normalizeProductTags(input.Tags)
+	if err != nil {
+		return nil, err
+	}
+	categoryID := input.CategoryID
+	if categoryID != nil && *categoryID == 0 {
+		categoryID = nil
+	}
+	attributes := domain.ProductAttributes(input.Attributes)
+	if err := s.checkAttributes(categoryID, attributes); err != nil {
+		return nil, err
+	}
+	return &domain.Product{
+		SKU: sku, Name: name, Description: description, PriceMinor: priceMinor, Currency: currency, Prices: prices,
+		CategoryID: categoryID, Tags: tags, Attributes: attributes,
+	}, nil
 }
 
-func (s *ProductServiceImpl) ListPaged(ctx context.Context, req repository.PageRequest) (repository.PageResult[domain.Product], error) {
+func (s *ProductServiceImpl) ListPaged(ctx context.Context, query repository.ProductListQuery) (repository.PageResult[domain.Product], error) {
 	start := time.Now()
 	outcome := "success"
 	defer func() { observability.RecordProductOperation(ctx, "list", outcome, time.Since(start)) }()
 
-	res, err := s.repo.ListPaged(req)
+	res, err := s.visibleRepo(ctx, "products:read").ListPaged(query)
 	if err != nil {
 		outcome = "error"
 		return repository.PageResult[domain.Product]{}, err
 	}
+	for i := range res.Items {
+		linkProductImages(res.Items[i].Images)
+	}
+	return res, nil
+}
+
+func (s *ProductServiceImpl) ListByCursor(ctx context.Context, query repository.ProductListQuery, cursor repository.CursorRequest) (repository.CursorResult[domain.Product], error) {
+	start := time.Now()
+	outcome := "success"
+	defer func() { observability.RecordProductOperation(ctx, "list", outcome, time.Since(start)) }()
+
+	res, err := s.visibleRepo(ctx, "products:read").ListByCursor(query, cursor)
+	if err != nil {
+		outcome = "error"
+		return repository.CursorResult[domain.Product]{}, err
+	}
+	for i := range res.Items {
+		linkProductImages(res.Items[i].Images)
+	}
 	return res, nil
 }
 
@@ -84,7 +243,7 @@ func (s *ProductServiceImpl) GetByID(ctx context.Context, id uint) (*domain.Prod
 	outcome := "success"
 	defer func() { observability.RecordProductOperation(ctx, "get", outcome, time.Since(start)) }()
 
-	product, err := s.repo.FindByID(id)
+	product, err := s.visibleRepo(ctx, "products:read").FindByID(id)
 	if err != nil {
 		if errors.Is(err, repository.ErrProductNotFound) {
 			outcome = "not_found"
@@ -93,10 +252,13 @@ func (s *ProductServiceImpl) GetByID(ctx context.Context, id uint) (*domain.Prod
 		}
 		return nil, err
 	}
+	linkProductImages(product.Images)
 	return product, nil
 }
 
-func (s *ProductServiceImpl) Update(ctx context.Context, id uint, input UpdateProductInput) (*domain.Product, error) {
+// Update applies input when the product is still at version; zero skips the
+// check. A stale version fails with repository.ErrProductVersionConflict.
+func (s *ProductServiceImpl) Update(ctx context.Context, id, version uint, input UpdateProductInput) (*domain.Product, error) {
 	start := time.Now()
 	outcome := "success"
 	defer func() { observability.RecordProductOperation(ctx, "update", outcome, time.Since(start)) }()
@@ -118,46 +280,477 @@ func (s *ProductServiceImpl) Update(ctx context.Context, id uint, input UpdatePr
 		}
 		updates["description"] = description
 	}
-	if input.Price != nil {
-		if *input.Price <= 0 {
+	if input.SKU != nil {
+		sku, err := normalizeProductSKU(*input.SKU)
+		if err != nil {
 			outcome = "bad_request"
-			return nil, ErrProductInvalidPrice
+			return nil, err
 		}
-		updates["price"] = *input.Price
+		updates["sku"] = sku
 	}
-	if len(updates) == 0 {
+	if input.Tags != nil {
+		tags, err := normalizeProductTags(*input.Tags)
+		if err != nil {
+			outcome = "bad_request"
+			return nil, err
+		}
+		updates["tags"] = tags
+	}
+	if len(updates) == 0 && input.Price == nil && input.Prices == nil && input.CategoryID == nil && input.Attributes == nil {
 		outcome = "bad_request"
 		return nil, ErrProductNoUpdates
 	}
 
-	if err := s.repo.Update(id, updates); err != nil {
-		if errors.Is(err, repository.ErrProductNotFound) {
-			outcome = "not_found"
-		} else {
-			outcome = "error"
+	repo := s.repoFor(ctx)
+	if err := s.authorizeOwn(ctx, s.visibleRepo(ctx, "products:write"), id, "products:write", true); err != nil {
+		outcome = productOutcome(err)
+		return nil, err
+	}
+	if sku, ok := updates["sku"].(*string); ok && sku != nil {
+		if err := checkSKUFree(repo, *sku, id); err != nil {
+			outcome = productOutcome(err)
+			return nil, err
 		}
+	}
+	if input.Price != nil || input.Prices != nil {
+		// The price list may not repeat the product's currency, so a change
+		// to either is checked against the current value of the other.
+		current, err := repo.FindByID(id)
+		if err != nil {
+			outcome = productOutcome(err)
+			return nil, err
+		}
+		if err := pricingUpdates(current, input, updates); err != nil {
+			outcome = productOutcome(err)
+			return nil, err
+		}
+	}
+	if input.CategoryID != nil || input.Attributes != nil {
+		// Attributes are validated against the category the product ends up
+		// in, so either change needs the other's current value.
+		current, err := repo.FindByID(id)
+		if err != nil {
+			outcome = productOutcome(err)
+			return nil, err
+		}
+		categoryID, attributes := current.CategoryID, current.Attributes
+		if input.CategoryID != nil {
+			categoryID = nil
+			if *input.CategoryID != 0 {
+				next := *input.CategoryID
+				categoryID = &next
+			}
+			updates["category_id"] = categoryID
+		}
+		if input.Attributes != nil {
+			attributes = domain.ProductAttributes(*input.Attributes)
+		}
+		if err := s.checkAttributes(categoryID, attributes); err != nil {
+			outcome = productOutcome(err)
+			return nil, err
+		}
+		if attributes == nil {
+			attributes = domain.ProductAttributes{}
+		}
+		updates["attributes"] = attributes
+	}
+	if err := repo.Update(id, version, updates); err != nil {
+		outcome = productOutcome(err)
 		return nil, err
 	}
-	product, err := s.repo.FindByID(id)
+	product, err := repo.FindByID(id)
 	if err != nil {
 		outcome = "error"
 		return nil, err
 	}
+	linkProductImages(product.Images)
 	return product, nil
 }
 
-func (s *ProductServiceImpl) DeleteByID(ctx context.Context, id uint) error {
+func (s *ProductServiceImpl) DeleteByID(ctx context.Context, id, version uint) error {
 	start := time.Now()
 	outcome := "success"
 	defer func() { observability.RecordProductOperation(ctx, "delete", outcome, time.Since(start)) }()
 
-	if err := s.repo.DeleteByID(id); err != nil {
-		if errors.Is(err, repository.ErrProductNotFound) {
-			outcome = "not_found"
-		} else {
-			outcome = "error"
+	if err := s.authorizeOwn(ctx, s.visibleRepo(ctx, "products:delete"), id, "products:delete", false); err != nil {
+		outcome = productOutcome(err)
+		return err
+	}
+	if err := s.repoFor(ctx).DeleteByID(id, version); err != nil {
+		outcome = productOutcome(err)
+		return err
+	}
+	return nil
+}
+
+// ListDeleted, Restore and Purge manage the tenant's product trash. Routes
+// gate them on the global products:delete permission.
+func (s *ProductServiceImpl) ListDeleted(ctx context.Context, req repository.PageRequest) (repository.PageResult[domain.Product], error) {
+	start := time.Now()
+	outcome := "success"
+	defer func() { observability.RecordProductOperation(ctx, "list_deleted", outcome, time.Since(start)) }()
+
+	res, err := s.repoFor(ctx).ListDeleted(req)
+	if err != nil {
+		outcome = "error"
+		return repository.PageResult[domain.Product]{}, err
+	}
+	return res, nil
+}
+
+func (s *ProductServiceImpl) Restore(ctx context.Context, id uint) (*domain.Product, error) {
+	start := time.Now()
+	outcome := "success"
+	defer func() { observability.RecordProductOperation(ctx, "restore", outcome, time.Since(start)) }()
+
+	repo := s.repoFor(ctx)
+	if err := repo.Restore(id); err != nil {
+		outcome = productOutcome(err)
+		return nil, err
+	}
+	product, err := repo.FindByID(id)
+	if err != nil {
+		outcome = productOutcome(err)
+		return nil, err
+	}
+	linkProductImages(product.Images)
+	return product, nil
+}
+
+// Purge permanently removes a product from the trash together with its
+// image files.
+func (s *ProductServiceImpl) Purge(ctx context.Context, id uint) error {
+	start := time.Now()
+	outcome := "success"
+	defer func() { observability.RecordProductOperation(ctx, "purge", outcome, time.Since(start)) }()
+
+	if err := s.repoFor(ctx).Purge(id); err != nil {
+		outcome = productOutcome(err)
+		return err
+	}
+	removeProductImageFiles(ctx, s.storage, id)
+	return nil
+}
+
+func (s *ProductServiceImpl) ListGrants(ctx context.Context, productID uint) ([]domain.ProductGrant, error) {
+	repo := s.visibleRepo(ctx, "products:write")
+	if _, err := repo.FindByID(productID); err != nil {
+		return nil, err
+	}
+	return repo.ListGrants(productID)
+}
+
+// ShareProduct grants a user or group access to one product. Only the owner
+// or a holder of the global products:write permission may share.
+func (s *ProductServiceImpl) ShareProduct(ctx context.Context, productID uint, input ShareProductInput) (*domain.ProductGrant, error) {
+	access := strings.ToLower(strings.TrimSpace(input.Access))
+	if (input.UserID == nil) == (input.GroupID == nil) ||
+		(access != domain.ProductAccessRead && access != domain.ProductAccessWrite) {
+		return nil, ErrProductInvalidGrant
+	}
+	repo := s.visibleRepo(ctx, "products:write")
+	if err := s.authorizeOwn(ctx, repo, productID, "products:write", false); err != nil {
+		return nil, err
+	}
+	if _, err := repo.FindByID(productID); err != nil {
+		return nil, err
+	}
+	grant := &domain.ProductGrant{ProductID: productID, UserID: input.UserID, GroupID: input.GroupID, Access: access}
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		grant.CreatedBy = principal.UserID
+	}
+	if err := repo.UpsertGrant(grant); err != nil {
+		return nil, err
+	}
+	return grant, nil
+}
+
+func (s *ProductServiceImpl) RevokeShare(ctx context.Context, productID, grantID uint) error {
+	repo := s.visibleRepo(ctx, "products:write")
+	if err := s.authorizeOwn(ctx, repo, productID, "products:write", false); err != nil {
+		return err
+	}
+	if _, err := repo.FindByID(productID); err != nil {
+		return err
+	}
+	return repo.DeleteGrant(productID, grantID)
+}
+
+// UploadImage appends an image to the product's gallery. Like Update, it
+// needs products:write, or products:write:own as owner or write grantee.
+func (s *ProductServiceImpl) UploadImage(ctx context.Context, productID uint, file io.Reader, fileSize int64) (*domain.ProductImage, error) {
+	start := time.Now()
+	outcome := "success"
+	defer func() { observability.RecordProductOperation(ctx, "upload_image", outcome, time.Since(start)) }()
+
+	repo := s.visibleRepo(ctx, "products:write")
+	if err := s.authorizeOwn(ctx, repo, productID, "products:write", true); err != nil {
+		outcome = productOutcome(err)
+		return nil, err
+	}
+	product, err := repo.FindByID(productID)
+	if err != nil {
+		outcome = productOutcome(err)
+		return nil, err
+	}
+	// Checked up front so a full gallery does not cost an upload; AddImage
+	// enforces the limit against concurrent uploads.
+	if len(product.Images) >= maxProductImages {
+		outcome = "conflict"
+		return nil, repository.ErrProductImageLimit
+	}
+	prefix := ProductImagePrefix(productID)
+	objectKey, contentType, err := s.storage.UploadImage(ctx, prefix, file, fileSize, map[string]string{
+		"Product-ID": fmt.Sprintf("%d", productID),
+	})
+	if err != nil {
+		outcome = productOutcome(err)
+		return nil, err
+	}
+	image := &domain.ProductImage{ProductID: productID, ObjectKey: objectKey, ContentType: contentType, Size: fileSize, CreatedBy: actorFromContext(ctx)}
+	if err := repo.AddImage(image, maxProductImages); err != nil {
+		outcome = productOutcome(err)
+		// Without its record the file would never be shown or removed.
+		if cleanupErr := s.storage.DeleteObject(ctx, prefix, objectKey); cleanupErr != nil {
+			slog.Warn("product image cleanup failed", "product_id", productID, "object_key", objectKey, "error", cleanupErr)
 		}
+		return nil, err
+	}
+	signed := []domain.ProductImage{*image}
+	linkProductImages(signed)
+	return &signed[0], nil
+}
+
+// ReorderImages sets the gallery order; imageIDs must list every image of
+// the product exactly once.
+func (s *ProductServiceImpl) ReorderImages(ctx context.Context, productID uint, imageIDs []uint) ([]domain.ProductImage, error) {
+	start := time.Now()
+	outcome := "success"
+	defer func() { observability.RecordProductOperation(ctx, "reorder_images", outcome, time.Since(start)) }()
+
+	repo := s.visibleRepo(ctx, "products:write")
+	if err := s.authorizeOwn(ctx, repo, productID, "products:write", true); err != nil {
+		outcome = productOutcome(err)
+		return nil, err
+	}
+	if _, err := repo.FindByID(productID); err != nil {
+		outcome = productOutcome(err)
+		return nil, err
+	}
+	images, err := repo.ReorderImages(productID, imageIDs)
+	if err != nil {
+		outcome = productOutcome(err)
+		return nil, err
+	}
+	linkProductImages(images)
+	return images, nil
+}
+
+// DeleteImage removes an image from the gallery and deletes its file.
+func (s *ProductServiceImpl) DeleteImage(ctx context.Context, productID, imageID uint) error {
+	start := time.Now()
+	outcome := "success"
+	defer func() { observability.RecordProductOperation(ctx, "delete_image", outcome, time.Since(start)) }()
+
+	repo := s.visibleRepo(ctx, "products:write")
+	if err := s.authorizeOwn(ctx, repo, productID, "products:write", true); err != nil {
+		outcome = productOutcome(err)
+		return err
+	}
+	if _, err := repo.FindByID(productID); err != nil {
+		outcome = productOutcome(err)
+		return err
+	}
+	image, err := repo.DeleteImage(productID, imageID)
+	if err != nil {
+		outcome = productOutcome(err)
 		return err
 	}
+	// The record is gone, so a file left behind is only removed with the
+	// rest of the product's files when the product is purged.
+	if err := s.storage.DeleteObject(ctx, ProductImagePrefix(productID), image.ObjectKey); err != nil {
+		slog.Warn("product image file delete failed", "product_id", productID, "object_key", image.ObjectKey, "error", err)
+	}
+	return nil
+}
+
+// productImagePath is the API path that redirects to an image's file.
+func productImagePath(productID, imageID uint) string {
+	return fmt.Sprintf("/api/v1/products/%d/images/%d", productID, imageID)
+}
+
+// linkProductImages fills in stable image URLs pointing at the API, which
+// redirects to a freshly presigned link. Product bodies therefore stay the
+// same between reads, so their ETag validates the whole representation.
+func linkProductImages(images []domain.ProductImage) {
+	for i := range images {
+		images[i].URL = productImagePath(images[i].ProductID, images[i].ID)
+	}
+}
+
+// ImageURL returns a short-lived presigned link to an image of a product the
+// caller can read.
+func (s *ProductServiceImpl) ImageURL(ctx context.Context, productID, imageID uint) (string, error) {
+	start := time.Now()
+	outcome := "success"
+	defer func() { observability.RecordProductOperation(ctx, "image_url", outcome, time.Since(start)) }()
+
+	product, err := s.visibleRepo(ctx, "products:read").FindByID(productID)
+	if err != nil {
+		outcome = productOutcome(err)
+		return "", err
+	}
+	for _, image := range product.Images {
+		if image.ID == imageID {
+			url, err := s.storage.GenerateURL(ctx, image.ObjectKey)
+			if err != nil {
+				outcome = productOutcome(err)
+			}
+			return url, err
+		}
+	}
+	outcome = productOutcome(repository.ErrProductImageNotFound)
+	return "", repository.ErrProductImageNotFound
+}
+
+// removeProductImageFiles deletes the image files of a purged product. The
+// product's records are already gone, so a failure is only logged.
+func removeProductImageFiles(ctx context.Context, storage StorageService, productID uint) {
+	if storage == nil {
+		return
+	}
+	if err := storage.DeletePrefix(ctx, ProductImagePrefix(productID)); err != nil {
+		slog.Warn("product image files cleanup failed", "product_id", productID, "error", err)
+	}
+}
+
+// checkAttributes validates attributes against the schema of categoryID and
+// its ancestors. Products without a category cannot carry attributes.
+func (s *ProductServiceImpl) checkAttributes(categoryID *uint, attributes domain.ProductAttributes) error {
+	if categoryID == nil {
+		if len(attributes) > 0 {
+			return fmt.Errorf("%w: attributes require a category", ErrProductInvalidAttributes)
+		}
+		return nil
+	}
+	chain, err := categoryChain(s.categories, *categoryID)
+	if errors.Is(err, repository.ErrCategoryNotFound) {
+		return ErrProductInvalidCategory
+	}
+	if err != nil {
+		return err
+	}
+	return validateProductAttributes(chain, attributes)
+}
+
+// normalizeProductTags trims, lower-cases and de-duplicates tags and returns
+// them sorted.
+func normalizeProductTags(tags []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(tags))
+	out := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || len(tag) > 50 {
+			return nil, ErrProductInvalidTags
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		out = append(out, tag)
+	}
+	if len(out) > maxProductTags {
+		return nil, ErrProductInvalidTags
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// normalizeProductSKU trims sku and checks its format. An empty SKU yields
+// nil.
+func normalizeProductSKU(sku string) (*string, error) {
+	sku = strings.TrimSpace(sku)
+	if sku == "" {
+		return nil, nil
+	}
+	if len(sku) > maxProductSKU {
+		return nil, ErrProductInvalidSKU
+	}
+	for i, r := range sku {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case i > 0 && (r == '.' || r == '_' || r == '-'):
+		default:
+			return nil, ErrProductInvalidSKU
+		}
+	}
+	return &sku, nil
+}
+
+// checkSKUFree fails with ErrProductSKUTaken when a product other than
+// productID carries sku, including a product in the trash. The database
+// enforces the same rule per organization; this check also covers platform
+// products and gives a clearer error.
+func checkSKUFree(repo repository.ProductRepository, sku string, productID uint) error {
+	existing, err := repo.FindBySKU(sku)
+	if errors.Is(err, repository.ErrProductNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != productID {
+		return ErrProductSKUTaken
+	}
 	return nil
 }
+
+// pricingUpdates validates the price changes in input against current and
+// records them in updates.
+func pricingUpdates(current *domain.Product, input UpdateProductInput, updates map[string]any) error {
+	currency := current.Currency
+	if input.Price != nil {
+		next, minor, err := resolvePrice(*input.Price, current.Currency)
+		if err != nil {
+			return err
+		}
+		currency = next
+		updates["currency"] = currency
+		updates["price_minor"] = minor
+	}
+	if input.Prices != nil {
+		prices, err := resolvePriceList(*input.Prices, currency)
+		if err != nil {
+			return err
+		}
+		updates["prices"] = prices
+		return nil
+	}
+	for _, price := range current.Prices {
+		if price.Currency == currency {
+			return fmt.Errorf("%w: %s is already in the price list; replace prices in the same request", ErrProductInvalidPrice, currency)
+		}
+	}
+	return nil
+}
+
+func productOutcome(err error) string {
+	switch {
+	case errors.Is(err, repository.ErrProductNotFound), errors.Is(err, repository.ErrProductImageNotFound):
+		return "not_found"
+	case errors.Is(err, ErrProductForbidden):
+		return "forbidden"
+	case errors.Is(err, repository.ErrProductVersionConflict), errors.Is(err, ErrProductSKUTaken),
+		errors.Is(err, repository.ErrProductImageLimit):
+		return "conflict"
+	case errors.Is(err, ErrProductInvalidName), errors.Is(err, ErrProductInvalidDescription),
+		errors.Is(err, ErrProductInvalidSKU), errors.Is(err, ErrProductInvalidTags),
+		errors.Is(err, ErrProductInvalidCategory), errors.Is(err, ErrProductInvalidAttributes),
+		errors.Is(err, ErrProductInvalidPrice), errors.Is(err, ErrProductInvalidCurrency),
+		errors.Is(err, ErrFileTooBig), errors.Is(err, ErrInvalidFileType),
+		errors.Is(err, repository.ErrProductImageOrder):
+		return "bad_request"
+	default:
+		return "error"
+	}
+}
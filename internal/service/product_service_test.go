@@ -3,25 +3,28 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
 	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
 	repogomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository/gomock"
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 )
 
 func TestProductServiceValidation(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	repo := repogomock.NewMockProductRepository(ctrl)
-	svc := NewProductService(repo)
+	svc := NewProductService(repo, repogomock.NewMockCategoryRepository(ctrl), nil)
 
-	_, err := svc.Create(context.Background(), CreateProductInput{Name: "ab", Price: 10})
+	_, err := svc.Create(context.Background(), CreateProductInput{Name: "ab", Price: PriceInput{Amount: "10"}})
 	if !errors.Is(err, ErrProductInvalidName) {
 		t.Fatalf("expected ErrProductInvalidName, got %v", err)
 	}
 
-	_, err = svc.Create(context.Background(), CreateProductInput{Name: "Valid Name", Price: 0})
+	_, err = svc.Create(context.Background(), CreateProductInput{Name: "Valid Name", Price: PriceInput{Amount: "0"}})
 	if !errors.Is(err, ErrProductInvalidPrice) {
 		t.Fatalf("expected ErrProductInvalidPrice, got %v", err)
 	}
@@ -30,18 +33,18 @@ func TestProductServiceValidation(t *testing.T) {
 	for i := range longDescription {
 		longDescription[i] = 'a'
 	}
-	_, err = svc.Create(context.Background(), CreateProductInput{Name: "Valid Name", Description: string(longDescription), Price: 10})
+	_, err = svc.Create(context.Background(), CreateProductInput{Name: "Valid Name", Description: string(longDescription), Price: PriceInput{Amount: "10"}})
 	if !errors.Is(err, ErrProductInvalidDescription) {
 		t.Fatalf("expected ErrProductInvalidDescription, got %v", err)
 	}
 
 	name := "ok"
-	_, err = svc.Update(context.Background(), 1, UpdateProductInput{Name: &name})
+	_, err = svc.Update(context.Background(), 1, 0, UpdateProductInput{Name: &name})
 	if !errors.Is(err, ErrProductInvalidName) {
 		t.Fatalf("expected ErrProductInvalidName on update, got %v", err)
 	}
 
-	_, err = svc.Update(context.Background(), 1, UpdateProductInput{})
+	_, err = svc.Update(context.Background(), 1, 0, UpdateProductInput{})
 	if !errors.Is(err, ErrProductNoUpdates) {
 		t.Fatalf("expected ErrProductNoUpdates, got %v", err)
 	}
@@ -50,7 +53,7 @@ func TestProductServiceValidation(t *testing.T) {
 func TestProductServiceCRUDFlow(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	repo := repogomock.NewMockProductRepository(ctrl)
-	svc := NewProductService(repo)
+	svc := NewProductService(repo, repogomock.NewMockCategoryRepository(ctrl), nil)
 
 	items := map[uint]domain.Product{}
 	nextID := uint(1)
@@ -68,8 +71,8 @@ func TestProductServiceCRUDFlow(t *testing.T) {
 		}
 		cp := product
 		return &cp, nil
-	}).Times(3)
-	repo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(id uint, updates map[string]any) error {
+	}).Times(4)
+	repo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(id, version uint, updates map[string]any) error {
 		product, ok := items[id]
 		if !ok {
 			return repository.ErrProductNotFound
@@ -80,13 +83,13 @@ func TestProductServiceCRUDFlow(t *testing.T) {
 		if v, ok := updates["description"].(string); ok {
 			product.Description = v
 		}
-		if v, ok := updates["price"].(float64); ok {
-			product.Price = v
+		if v, ok := updates["price_minor"].(int64); ok {
+			product.PriceMinor = v
 		}
 		items[id] = product
 		return nil
 	})
-	repo.EXPECT().ListPaged(gomock.Any()).DoAndReturn(func(req repository.PageRequest) (repository.PageResult[domain.Product], error) {
+	repo.EXPECT().ListPaged(gomock.Any()).DoAndReturn(func(req repository.ProductListQuery) (repository.PageResult[domain.Product], error) {
 		normalized := repository.PageRequest{Page: req.Page, PageSize: req.PageSize}
 		if normalized.Page < 1 {
 			normalized.Page = repository.DefaultPage
@@ -106,7 +109,7 @@ func TestProductServiceCRUDFlow(t *testing.T) {
 			TotalPages: 1,
 		}, nil
 	})
-	repo.EXPECT().DeleteByID(gomock.Any()).DoAndReturn(func(id uint) error {
+	repo.EXPECT().DeleteByID(gomock.Any(), gomock.Any()).DoAndReturn(func(id, version uint) error {
 		if _, ok := items[id]; !ok {
 			return repository.ErrProductNotFound
 		}
@@ -114,12 +117,13 @@ func TestProductServiceCRUDFlow(t *testing.T) {
 		return nil
 	})
 
-	created, err := svc.Create(context.Background(), CreateProductInput{Name: "Sample Product", Description: "desc", Price: 12.5})
+	ctx := WithPrincipal(context.Background(), SystemPrincipal())
+	created, err := svc.Create(ctx, CreateProductInput{Name: "Sample Product", Description: "desc", Price: PriceInput{Amount: "12.50"}})
 	if err != nil {
 		t.Fatalf("create: %v", err)
 	}
 
-	loaded, err := svc.GetByID(context.Background(), created.ID)
+	loaded, err := svc.GetByID(ctx, created.ID)
 	if err != nil {
 		t.Fatalf("get by id: %v", err)
 	}
@@ -128,16 +132,16 @@ func TestProductServiceCRUDFlow(t *testing.T) {
 	}
 
 	name := "Updated Product"
-	price := 18.75
-	updated, err := svc.Update(context.Background(), created.ID, UpdateProductInput{Name: &name, Price: &price})
+	price := PriceInput{Amount: "18.75"}
+	updated, err := svc.Update(ctx, created.ID, 0, UpdateProductInput{Name: &name, Price: &price})
 	if err != nil {
 		t.Fatalf("update: %v", err)
 	}
-	if updated.Name != "Updated Product" || updated.Price != 18.75 {
+	if updated.Name != "Updated Product" || updated.PriceMinor != 1875 {
 		t.Fatalf("unexpected updated product: %+v", updated)
 	}
 
-	page, err := svc.ListPaged(context.Background(), repository.PageRequest{})
+	page, err := svc.ListPaged(ctx, repository.ProductListQuery{})
 	if err != nil {
 		t.Fatalf("list paged: %v", err)
 	}
@@ -145,10 +149,301 @@ func TestProductServiceCRUDFlow(t *testing.T) {
 		t.Fatalf("expected total 1, got %d", page.Total)
 	}
 
-	if err := svc.DeleteByID(context.Background(), created.ID); err != nil {
+	if err := svc.DeleteByID(ctx, created.ID, 0); err != nil {
 		t.Fatalf("delete: %v", err)
 	}
-	if _, err := svc.GetByID(context.Background(), created.ID); !errors.Is(err, repository.ErrProductNotFound) {
+	if _, err := svc.GetByID(ctx, created.ID); !errors.Is(err, repository.ErrProductNotFound) {
 		t.Fatalf("expected not found after delete, got %v", err)
 	}
 }
+
+func TestProductServiceScopesRepositoryToTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockProductRepository(ctrl)
+	scoped := repogomock.NewMockProductRepository(ctrl)
+	svc := NewProductService(repo, repogomock.NewMockCategoryRepository(ctrl), nil)
+
+	repo.EXPECT().ForOrganization(uint(4)).Return(scoped)
+	scoped.EXPECT().FindByID(uint(9)).Return(nil, repository.ErrProductNotFound)
+
+	ctx := WithTenant(context.Background(), Tenant{OrganizationID: 4, Slug: "acme"})
+	if _, err := svc.GetByID(ctx, 9); !errors.Is(err, repository.ErrProductNotFound) {
+		t.Fatalf("expected ErrProductNotFound from scoped repository, got %v", err)
+	}
+}
+
+func TestProductServiceRequiresPrincipalForRecordWrites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockProductRepository(ctrl)
+	svc := NewProductService(repo, repogomock.NewMockCategoryRepository(ctrl), nil)
+
+	name := "Renamed"
+	if _, err := svc.Update(context.Background(), 4, 0, UpdateProductInput{Name: &name}); !errors.Is(err, ErrProductForbidden) {
+		t.Fatalf("expected update without a principal to be refused, got %v", err)
+	}
+	if err := svc.DeleteByID(context.Background(), 4, 0); !errors.Is(err, ErrProductForbidden) {
+		t.Fatalf("expected delete without a principal to be refused, got %v", err)
+	}
+
+	ctx := WithPrincipal(context.Background(), SystemPrincipal())
+	repo.EXPECT().DeleteByID(uint(4), uint(0)).Return(nil)
+	if err := svc.DeleteByID(ctx, 4, 0); err != nil {
+		t.Fatalf("expected the system principal to delete, got %v", err)
+	}
+	repo.EXPECT().Create(gomock.Any()).DoAndReturn(func(p *domain.Product) error {
+		if p.OwnerID != nil || p.CreatedBy != nil {
+			t.Fatalf("expected no owner for system-created products, got %+v", p)
+		}
+		return nil
+	})
+	if _, err := svc.Create(ctx, CreateProductInput{Name: "Widget", Price: PriceInput{Amount: "5"}}); err != nil {
+		t.Fatalf("create as system: %v", err)
+	}
+}
+
+func TestProductServiceOwnScopeChecksOwnerAndGrants(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockProductRepository(ctrl)
+	viewer := repogomock.NewMockProductRepository(ctrl)
+	svc := NewProductService(repo, repogomock.NewMockCategoryRepository(ctrl), nil)
+
+	ownerID, otherID := uint(5), uint(6)
+	ctx := WithPrincipal(context.Background(), Principal{UserID: ownerID, Permissions: []string{"products:write:own", "products:delete:own"}})
+	repo.EXPECT().ForViewer(ownerID).Return(viewer).AnyTimes()
+	viewer.EXPECT().FindByID(uint(1)).Return(&domain.Product{ID: 1, Name: "Mine", OwnerID: &ownerID}, nil).AnyTimes()
+	viewer.EXPECT().FindByID(uint(2)).Return(&domain.Product{ID: 2, Name: "Shared", OwnerID: &otherID}, nil).AnyTimes()
+
+	name := "Renamed"
+	repo.EXPECT().FindByID(gomock.Any()).Return(&domain.Product{Name: name}, nil).Times(2)
+	repo.EXPECT().Update(uint(1), uint(0), gomock.Any()).Return(nil)
+	if _, err := svc.Update(ctx, 1, 0, UpdateProductInput{Name: &name}); err != nil {
+		t.Fatalf("expected owner update to succeed, got %v", err)
+	}
+
+	viewer.EXPECT().HasGrant(uint(2), ownerID, domain.ProductAccessWrite).Return(false, nil)
+	if _, err := svc.Update(ctx, 2, 0, UpdateProductInput{Name: &name}); !errors.Is(err, ErrProductForbidden) {
+		t.Fatalf("expected ErrProductForbidden without write grant, got %v", err)
+	}
+	viewer.EXPECT().HasGrant(uint(2), ownerID, domain.ProductAccessWrite).Return(true, nil)
+	repo.EXPECT().Update(uint(2), uint(0), gomock.Any()).Return(nil)
+	if _, err := svc.Update(ctx, 2, 0, UpdateProductInput{Name: &name}); err != nil {
+		t.Fatalf("expected write grant to allow update, got %v", err)
+	}
+
+	if err := svc.DeleteByID(ctx, 2, 0); !errors.Is(err, ErrProductForbidden) {
+		t.Fatalf("expected non-owner delete to be forbidden, got %v", err)
+	}
+	repo.EXPECT().DeleteByID(uint(1), uint(0)).Return(nil)
+	if err := svc.DeleteByID(ctx, 1, 0); err != nil {
+		t.Fatalf("expected owner delete to succeed, got %v", err)
+	}
+
+	readOnly := WithPrincipal(context.Background(), Principal{UserID: ownerID, Permissions: []string{"products:read"}})
+	if err := svc.DeleteByID(readOnly, 1, 0); !errors.Is(err, ErrProductForbidden) {
+		t.Fatalf("expected delete without any delete scope to be forbidden, got %v", err)
+	}
+}
+
+func TestProductServiceCreateStampsOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockProductRepository(ctrl)
+	svc := NewProductService(repo, repogomock.NewMockCategoryRepository(ctrl), nil)
+
+	repo.EXPECT().Create(gomock.AssignableToTypeOf(&domain.Product{})).Return(nil)
+	ctx := WithPrincipal(context.Background(), Principal{UserID: 3, Permissions: []string{"products:write:own"}})
+	created, err := svc.Create(ctx, CreateProductInput{Name: "Owned Product", Price: PriceInput{Amount: "5"}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if created.OwnerID == nil || *created.OwnerID != 3 || created.CreatedBy == nil || *created.CreatedBy != 3 {
+		t.Fatalf("expected owner and creator stamped, got %+v", created)
+	}
+}
+
+func TestProductServiceSKU(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockProductRepository(ctrl)
+	svc := NewProductService(repo, repogomock.NewMockCategoryRepository(ctrl), nil)
+	ctx := WithPrincipal(context.Background(), SystemPrincipal())
+
+	for _, sku := range []string{"-lead", "has space", strings.Repeat("A", 65)} {
+		if _, err := svc.Create(ctx, CreateProductInput{SKU: sku, Name: "Widget", Price: PriceInput{Amount: "5"}}); !errors.Is(err, ErrProductInvalidSKU) {
+			t.Fatalf("expected ErrProductInvalidSKU for %q, got %v", sku, err)
+		}
+	}
+	if err := svc.ValidateCreate(ctx, CreateProductInput{SKU: "WID-1", Name: "Widget", Price: PriceInput{Amount: "5"}}); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	repo.EXPECT().FindBySKU("WID-1").Return(&domain.Product{ID: 1}, nil).Times(3)
+	if _, err := svc.Create(ctx, CreateProductInput{SKU: " WID-1 ", Name: "Widget", Price: PriceInput{Amount: "5"}}); !errors.Is(err, ErrProductSKUTaken) {
+		t.Fatalf("expected ErrProductSKUTaken, got %v", err)
+	}
+	sku := "WID-1"
+	if _, err := svc.Update(ctx, 2, 0, UpdateProductInput{SKU: &sku}); !errors.Is(err, ErrProductSKUTaken) {
+		t.Fatalf("expected ErrProductSKUTaken on update, got %v", err)
+	}
+	repo.EXPECT().Update(uint(1), uint(0), map[string]any{"sku": &sku}).Return(nil)
+	repo.EXPECT().FindByID(uint(1)).Return(&domain.Product{ID: 1, SKU: &sku}, nil)
+	if _, err := svc.Update(ctx, 1, 0, UpdateProductInput{SKU: &sku}); err != nil {
+		t.Fatalf("expected a product to keep its own sku, got %v", err)
+	}
+
+	trashedSKU := "OLD-1"
+	repo.EXPECT().FindBySKU(trashedSKU).Return(&domain.Product{ID: 3, SKU: &trashedSKU, DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}, nil)
+	if _, err := svc.Create(ctx, CreateProductInput{SKU: trashedSKU, Name: "Widget", Price: PriceInput{Amount: "5"}}); !errors.Is(err, ErrProductSKUTaken) {
+		t.Fatalf("expected a trashed product to keep its sku, got %v", err)
+	}
+
+	var cleared *string
+	empty := ""
+	repo.EXPECT().Update(uint(1), uint(0), map[string]any{"sku": cleared}).Return(nil)
+	repo.EXPECT().FindByID(uint(1)).Return(&domain.Product{ID: 1}, nil)
+	if _, err := svc.Update(ctx, 1, 0, UpdateProductInput{SKU: &empty}); err != nil {
+		t.Fatalf("clear sku: %v", err)
+	}
+}
+
+func TestProductServiceShareValidationAndOwnership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockProductRepository(ctrl)
+	viewer := repogomock.NewMockProductRepository(ctrl)
+	svc := NewProductService(repo, repogomock.NewMockCategoryRepository(ctrl), nil)
+
+	ownerID, otherID, targetID, groupID := uint(5), uint(6), uint(8), uint(2)
+	ctx := WithPrincipal(context.Background(), Principal{UserID: ownerID, Permissions: []string{"products:write:own"}})
+
+	if _, err := svc.ShareProduct(ctx, 1, ShareProductInput{Access: "read"}); !errors.Is(err, ErrProductInvalidGrant) {
+		t.Fatalf("expected ErrProductInvalidGrant without target, got %v", err)
+	}
+	if _, err := svc.ShareProduct(ctx, 1, ShareProductInput{UserID: &targetID, GroupID: &groupID, Access: "read"}); !errors.Is(err, ErrProductInvalidGrant) {
+		t.Fatalf("expected ErrProductInvalidGrant with two targets, got %v", err)
+	}
+	if _, err := svc.ShareProduct(ctx, 1, ShareProductInput{UserID: &targetID, Access: "admin"}); !errors.Is(err, ErrProductInvalidGrant) {
+		t.Fatalf("expected ErrProductInvalidGrant with unknown access, got %v", err)
+	}
+
+	repo.EXPECT().ForViewer(ownerID).Return(viewer).AnyTimes()
+	viewer.EXPECT().FindByID(uint(1)).Return(&domain.Product{ID: 1, OwnerID: &ownerID}, nil).AnyTimes()
+	viewer.EXPECT().FindByID(uint(2)).Return(&domain.Product{ID: 2, OwnerID: &otherID}, nil).AnyTimes()
+
+	if _, err := svc.ShareProduct(ctx, 2, ShareProductInput{UserID: &targetID, Access: "read"}); !errors.Is(err, ErrProductForbidden) {
+		t.Fatalf("expected non-owner share to be forbidden, got %v", err)
+	}
+	viewer.EXPECT().UpsertGrant(gomock.AssignableToTypeOf(&domain.ProductGrant{})).Return(nil)
+	grant, err := svc.ShareProduct(ctx, 1, ShareProductInput{GroupID: &groupID, Access: " Write "})
+	if err != nil {
+		t.Fatalf("share: %v", err)
+	}
+	if grant.Access != domain.ProductAccessWrite || grant.CreatedBy != ownerID || grant.GroupID == nil {
+		t.Fatalf("unexpected grant: %+v", grant)
+	}
+
+	if err := svc.RevokeShare(ctx, 2, 10); !errors.Is(err, ErrProductForbidden) {
+		t.Fatalf("expected non-owner revoke to be forbidden, got %v", err)
+	}
+	viewer.EXPECT().DeleteGrant(uint(1), uint(10)).Return(repository.ErrProductGrantNotFound)
+	if err := svc.RevokeShare(ctx, 1, 10); !errors.Is(err, repository.ErrProductGrantNotFound) {
+		t.Fatalf("expected ErrProductGrantNotFound, got %v", err)
+	}
+}
+
+func TestProductServiceImages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockProductRepository(ctrl)
+	storage := NewMockStorageService(ctrl)
+	svc := NewProductService(repo, repogomock.NewMockCategoryRepository(ctrl), storage)
+	ctx := WithPrincipal(context.Background(), SystemPrincipal())
+
+	t.Run("upload stores the file under the product prefix", func(t *testing.T) {
+		repo.EXPECT().FindByID(uint(4)).Return(&domain.Product{ID: 4}, nil)
+		storage.EXPECT().UploadImage(ctx, "products/4", gomock.Any(), int64(8), gomock.Any()).Return("products/4/a.png", "image/png", nil)
+		repo.EXPECT().AddImage(gomock.Any(), maxProductImages).DoAndReturn(func(image *domain.ProductImage, _ int) error {
+			if image.ProductID != 4 || image.ObjectKey != "products/4/a.png" || image.ContentType != "image/png" || image.Size != 8 {
+				t.Fatalf("unexpected image record %+v", image)
+			}
+			image.ID = 9
+			return nil
+		})
+
+		image, err := svc.UploadImage(ctx, 4, strings.NewReader("png"), 8)
+		if err != nil || image.ID != 9 || image.URL != "/api/v1/products/4/images/9" {
+			t.Fatalf("upload image: %+v err=%v", image, err)
+		}
+	})
+
+	t.Run("upload removes the file when the record cannot be added", func(t *testing.T) {
+		repo.EXPECT().FindByID(uint(4)).Return(&domain.Product{ID: 4}, nil)
+		storage.EXPECT().UploadImage(ctx, "products/4", gomock.Any(), int64(8), gomock.Any()).Return("products/4/b.png", "image/png", nil)
+		repo.EXPECT().AddImage(gomock.Any(), maxProductImages).Return(repository.ErrProductImageLimit)
+		storage.EXPECT().DeleteObject(ctx, "products/4", "products/4/b.png").Return(nil)
+
+		if _, err := svc.UploadImage(ctx, 4, strings.NewReader("png"), 8); !errors.Is(err, repository.ErrProductImageLimit) {
+			t.Fatalf("expected ErrProductImageLimit, got %v", err)
+		}
+	})
+
+	t.Run("a full gallery is rejected before uploading", func(t *testing.T) {
+		repo.EXPECT().FindByID(uint(4)).Return(&domain.Product{ID: 4, Images: make([]domain.ProductImage, maxProductImages)}, nil)
+
+		if _, err := svc.UploadImage(ctx, 4, strings.NewReader("png"), 8); !errors.Is(err, repository.ErrProductImageLimit) {
+			t.Fatalf("expected ErrProductImageLimit, got %v", err)
+		}
+	})
+
+	t.Run("delete removes the record and the file", func(t *testing.T) {
+		repo.EXPECT().FindByID(uint(4)).Return(&domain.Product{ID: 4}, nil)
+		repo.EXPECT().DeleteImage(uint(4), uint(9)).Return(&domain.ProductImage{ID: 9, ProductID: 4, ObjectKey: "products/4/a.png"}, nil)
+		storage.EXPECT().DeleteObject(ctx, "products/4", "products/4/a.png").Return(errors.New("storage down"))
+
+		if err := svc.DeleteImage(ctx, 4, 9); err != nil {
+			t.Fatalf("expected a failed file delete not to fail the request, got %v", err)
+		}
+	})
+
+	t.Run("reads link images through the api", func(t *testing.T) {
+		repo.EXPECT().FindByID(uint(4)).Return(&domain.Product{ID: 4, Images: []domain.ProductImage{
+			{ID: 1, ProductID: 4, ObjectKey: "products/4/a.png"},
+			{ID: 2, ProductID: 4, ObjectKey: "products/4/b.png", Position: 1},
+		}}, nil)
+
+		product, err := svc.GetByID(ctx, 4)
+		if err != nil || product.Images[0].URL != "/api/v1/products/4/images/1" || product.Images[1].URL != "/api/v1/products/4/images/2" {
+			t.Fatalf("expected stable image links, got %+v err=%v", product, err)
+		}
+	})
+
+	t.Run("image url presigns the requested image", func(t *testing.T) {
+		product := &domain.Product{ID: 4, Images: []domain.ProductImage{{ID: 1, ProductID: 4, ObjectKey: "products/4/a.png"}}}
+		repo.EXPECT().FindByID(uint(4)).Return(product, nil).Times(2)
+		storage.EXPECT().GenerateURL(ctx, "products/4/a.png").Return("https://storage.local/a", nil)
+
+		if url, err := svc.ImageURL(ctx, 4, 1); err != nil || url != "https://storage.local/a" {
+			t.Fatalf("expected presigned url, got %q err=%v", url, err)
+		}
+		if _, err := svc.ImageURL(ctx, 4, 2); !errors.Is(err, repository.ErrProductImageNotFound) {
+			t.Fatalf("expected ErrProductImageNotFound, got %v", err)
+		}
+	})
+
+	t.Run("purge removes every file of the product", func(t *testing.T) {
+		repo.EXPECT().Purge(uint(4)).Return(nil)
+		storage.EXPECT().DeletePrefix(ctx, "products/4").Return(nil)
+
+		if err := svc.Purge(ctx, 4); err != nil {
+			t.Fatalf("purge: %v", err)
+		}
+	})
+
+	t.Run("write:own callers need ownership or a write grant", func(t *testing.T) {
+		owner := uint(7)
+		ctx := WithPrincipal(ctx, Principal{UserID: 8, Permissions: []string{"products:write:own"}})
+		repo.EXPECT().ForViewer(uint(8)).Return(repo)
+		repo.EXPECT().FindByID(uint(4)).Return(&domain.Product{ID: 4, OwnerID: &owner}, nil)
+		repo.EXPECT().HasGrant(uint(4), uint(8), domain.ProductAccessWrite).Return(false, nil)
+
+		if _, err := svc.ReorderImages(ctx, 4, []uint{1}); !errors.Is(err, ErrProductForbidden) {
+			t.Fatalf("expected ErrProductForbidden, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,293 @@
+package service
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+)
+
+const (
+	ProductTransferCSV    = "csv"
+	ProductTransferNDJSON = "ndjson"
+
+	// maxProductTransferLine bounds one NDJSON line so a file without line
+	// breaks cannot be buffered whole.
+	maxProductTransferLine = 1 << 20
+)
+
+// productTransferColumns are the CSV columns of imports and exports. Tags
+// and additional prices are "|"-separated, prices as CURRENCY:amount, and
+// attributes are a JSON object.
+var productTransferColumns = []string{"sku", "name", "description", "price", "currency", "prices", "category_id", "tags", "attributes"}
+
+// productRecord is one product in an import or export file. NDJSON lines
+// use these field names; amounts may be given as decimal strings or, as in
+// the product API, in minor units.
+type productRecord struct {
+	SKU         string               `json:"sku"`
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Price       json.Number          `json:"price,omitempty"`
+	PriceMinor  *int64               `json:"price_minor,omitempty"`
+	Currency    string               `json:"currency,omitempty"`
+	Prices      []productRecordPrice `json:"prices,omitempty"`
+	CategoryID  *uint                `json:"category_id,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Attributes  map[string]any       `json:"attributes,omitempty"`
+}
+
+type productRecordPrice struct {
+	Currency    string      `json:"currency"`
+	Amount      json.Number `json:"amount,omitempty"`
+	AmountMinor *int64      `json:"amount_minor,omitempty"`
+}
+
+func (rec productRecord) input() CreateProductInput {
+	prices := make([]PriceInput, 0, len(rec.Prices))
+	for _, p := range rec.Prices {
+		prices = append(prices, PriceInput{Currency: p.Currency, Amount: p.Amount.String(), AmountMinor: p.AmountMinor})
+	}
+	return CreateProductInput{
+		SKU:         rec.SKU,
+		Name:        rec.Name,
+		Description: rec.Description,
+		Price:       PriceInput{Currency: rec.Currency, Amount: rec.Price.String(), AmountMinor: rec.PriceMinor},
+		Prices:      prices,
+		CategoryID:  rec.CategoryID,
+		Tags:        rec.Tags,
+		Attributes:  rec.Attributes,
+	}
+}
+
+func productRecordFrom(p domain.Product) productRecord {
+	rec := productRecord{
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       json.Number(domain.FormatMinorUnits(p.PriceMinor, p.Currency)),
+		Currency:    p.Currency,
+		CategoryID:  p.CategoryID,
+		Tags:        p.Tags,
+		Attributes:  p.Attributes,
+	}
+	if p.SKU != nil {
+		rec.SKU = *p.SKU
+	}
+	for _, price := range p.Prices {
+		rec.Prices = append(rec.Prices, productRecordPrice{
+			Currency: price.Currency,
+			Amount:   json.Number(domain.FormatMinorUnits(price.AmountMinor, price.Currency)),
+		})
+	}
+	return rec
+}
+
+// errProductRecord marks a record that could not be decoded. The reader can
+// continue with the next record.
+var errProductRecord = errors.New("invalid record")
+
+// productRecordReader reads records one at a time. Next returns io.EOF at
+// the end of the input, an error wrapping errProductRecord for a malformed
+// record, and any other error when the input cannot be read further.
+type productRecordReader interface {
+	Next() (productRecord, error)
+}
+
+func newProductRecordReader(format string, r io.Reader) (productRecordReader, error) {
+	switch format {
+	case ProductTransferCSV:
+		reader := csv.NewReader(r)
+		reader.ReuseRecord = true
+		header, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, errors.New("csv header row is missing")
+			}
+			return nil, fmt.Errorf("read csv header: %w", err)
+		}
+		columns := make(map[string]int, len(header))
+		for i, name := range header {
+			name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+			if !isProductTransferColumn(name) {
+				return nil, fmt.Errorf("unknown csv column %q", name)
+			}
+			if _, dup := columns[name]; dup {
+				return nil, fmt.Errorf("csv column %q appears more than once", name)
+			}
+			columns[name] = i
+		}
+		return &csvProductRecordReader{reader: reader, columns: columns}, nil
+	case ProductTransferNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64<<10), maxProductTransferLine)
+		return &ndjsonProductRecordReader{scanner: scanner}, nil
+	default:
+		return nil, ErrProductImportInvalidFormat
+	}
+}
+
+func isProductTransferColumn(name string) bool {
+	for _, column := range productTransferColumns {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
+
+type csvProductRecordReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func (r *csvProductRecordReader) Next() (productRecord, error) {
+	fields, err := r.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return productRecord{}, fmt.Errorf("%w: %v", errProductRecord, parseErr.Err)
+		}
+		return productRecord{}, err
+	}
+	get := func(name string) string {
+		if i, ok := r.columns[name]; ok {
+			return strings.TrimSpace(fields[i])
+		}
+		return ""
+	}
+	rec := productRecord{
+		SKU:         get("sku"),
+		Name:        get("name"),
+		Description: get("description"),
+		Price:       json.Number(get("price")),
+		Currency:    get("currency"),
+	}
+	if raw := get("prices"); raw != "" {
+		for _, entry := range strings.Split(raw, "|") {
+			currency, amount, ok := strings.Cut(strings.TrimSpace(entry), ":")
+			if !ok {
+				return productRecord{}, fmt.Errorf("%w: prices must be CURRENCY:amount entries separated by |", errProductRecord)
+			}
+			rec.Prices = append(rec.Prices, productRecordPrice{Currency: currency, Amount: json.Number(strings.TrimSpace(amount))})
+		}
+	}
+	if raw := get("category_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return productRecord{}, fmt.Errorf("%w: category_id must be a positive integer", errProductRecord)
+		}
+		categoryID := uint(id)
+		rec.CategoryID = &categoryID
+	}
+	if raw := get("tags"); raw != "" {
+		rec.Tags = strings.Split(raw, "|")
+	}
+	if raw := get("attributes"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &rec.Attributes); err != nil {
+			return productRecord{}, fmt.Errorf("%w: attributes must be a JSON object", errProductRecord)
+		}
+	}
+	return rec, nil
+}
+
+type ndjsonProductRecordReader struct {
+	scanner *bufio.Scanner
+}
+
+func (r *ndjsonProductRecordReader) Next() (productRecord, error) {
+	for r.scanner.Scan() {
+		line := strings.TrimSpace(r.scanner.Text())
+		if line == "" {
+			continue
+		}
+		var rec productRecord
+		decoder := json.NewDecoder(strings.NewReader(line))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&rec); err != nil {
+			return productRecord{}, fmt.Errorf("%w: %v", errProductRecord, err)
+		}
+		return rec, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return productRecord{}, fmt.Errorf("%w: ndjson line exceeds %d bytes", errProductImportFile, maxProductTransferLine)
+		}
+		return productRecord{}, err
+	}
+	return productRecord{}, io.EOF
+}
+
+// productRecordWriter writes export records. Flush pushes buffered output
+// to the underlying writer.
+type productRecordWriter interface {
+	Write(p domain.Product) error
+	Flush() error
+}
+
+func newProductRecordWriter(format string, w io.Writer) (productRecordWriter, error) {
+	switch format {
+	case ProductTransferCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(productTransferColumns); err != nil {
+			return nil, err
+		}
+		return &csvProductRecordWriter{writer: writer}, nil
+	case ProductTransferNDJSON:
+		buffered := bufio.NewWriter(w)
+		return &ndjsonProductRecordWriter{buffered: buffered, encoder: json.NewEncoder(buffered)}, nil
+	default:
+		return nil, ErrProductImportInvalidFormat
+	}
+}
+
+type csvProductRecordWriter struct {
+	writer *csv.Writer
+}
+
+func (w *csvProductRecordWriter) Write(p domain.Product) error {
+	rec := productRecordFrom(p)
+	prices := make([]string, 0, len(rec.Prices))
+	for _, price := range rec.Prices {
+		prices = append(prices, price.Currency+":"+price.Amount.String())
+	}
+	attributes := ""
+	if len(rec.Attributes) > 0 {
+		raw, err := json.Marshal(rec.Attributes)
+		if err != nil {
+			return err
+		}
+		attributes = string(raw)
+	}
+	categoryID := ""
+	if rec.CategoryID != nil {
+		categoryID = strconv.FormatUint(uint64(*rec.CategoryID), 10)
+	}
+	return w.writer.Write([]string{
+		rec.SKU, rec.Name, rec.Description, rec.Price.String(), rec.Currency,
+		strings.Join(prices, "|"), categoryID, strings.Join(rec.Tags, "|"), attributes,
+	})
+}
+
+func (w *csvProductRecordWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type ndjsonProductRecordWriter struct {
+	buffered *bufio.Writer
+	encoder  *json.Encoder
+}
+
+func (w *ndjsonProductRecordWriter) Write(p domain.Product) error {
+	return w.encoder.Encode(productRecordFrom(p))
+}
+
+func (w *ndjsonProductRecordWriter) Flush() error {
+	return w.buffered.Flush()
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+)
+
+// ReservationReaper releases the stock held by reservations that were neither
+// committed nor released before their TTL elapsed. Each release is recorded
+// in the stock movement ledger as an "expire" movement.
+type ReservationReaper struct {
+	repo repository.InventoryRepository
+}
+
+func NewReservationReaper(repo repository.InventoryRepository) *ReservationReaper {
+	return &ReservationReaper{repo: repo}
+}
+
+func (r *ReservationReaper) ReleaseExpired(now time.Time, batchSize int) (int, error) {
+	return r.repo.ReleaseExpired(now.UTC(), batchSize)
+}
+
+func (r *ReservationReaper) RunCleanupLoop(ctx context.Context, interval time.Duration, batchSize int, logger *slog.Logger) {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			released, err := r.ReleaseExpired(time.Now().UTC(), batchSize)
+			if err != nil {
+				if logger != nil {
+					logger.Warn("reservation reaper failed", "error", err)
+				}
+				continue
+			}
+			if released > 0 && logger != nil {
+				logger.Info("reservation reaper released expired reservations", "released", released)
+			}
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	repogomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReservationReaperReleaseExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockInventoryRepository(ctrl)
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	repo.EXPECT().ReleaseExpired(now, 50).Return(3, nil)
+
+	released, err := NewReservationReaper(repo).ReleaseExpired(now, 50)
+	if err != nil || released != 3 {
+		t.Fatalf("expected 3 released, got %d err=%v", released, err)
+	}
+}
+
+func TestReservationReaperReleaseExpiredPropagatesError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockInventoryRepository(ctrl)
+	expected := errors.New("db down")
+	repo.EXPECT().ReleaseExpired(gomock.Any(), 10).Return(0, expected)
+
+	if _, err := NewReservationReaper(repo).ReleaseExpired(time.Now(), 10); !errors.Is(err, expected) {
+		t.Fatalf("expected propagated error, got %v", err)
+	}
+}
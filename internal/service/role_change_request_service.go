@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+)
+
+var (
+	ErrRoleChangeSelfApproval = errors.New("role change request cannot be decided by its requester or target user")
+	ErrRoleChangeExpired      = errors.New("role change request has expired")
+	ErrRoleChangeStale        = errors.New("target user's roles changed since the request was submitted")
+)
+
+const defaultRoleChangeRequestTTL = 24 * time.Hour
+
+// DefaultRoleChangeRequestService implements the two-person rule for role
+// changes that touch protected roles: one admin submits, a different admin
+// approves, and only then are the roles applied through UserServiceInterface.
+type DefaultRoleChangeRequestService struct {
+	repo    repository.RoleChangeRequestRepository
+	userSvc UserServiceInterface
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+func NewRoleChangeRequestService(repo repository.RoleChangeRequestRepository, userSvc UserServiceInterface, ttl time.Duration) *DefaultRoleChangeRequestService {
+	if ttl <= 0 {
+		ttl = defaultRoleChangeRequestTTL
+	}
+	return &DefaultRoleChangeRequestService{
+		repo:    repo,
+		userSvc: userSvc,
+		ttl:     ttl,
+		now:     func() time.Time { return time.Now().UTC() },
+	}
+}
+
+func (s *DefaultRoleChangeRequestService) Submit(ctx context.Context, targetUserID, requestedBy uint, roleIDs []uint) (*domain.RoleChangeRequest, error) {
+	if roleIDs == nil {
+		roleIDs = []uint{}
+	}
+	baseRoleIDs, err := s.currentRoleIDs(targetUserID)
+	if err != nil {
+		return nil, err
+	}
+	req := &domain.RoleChangeRequest{
+		TargetUserID: targetUserID,
+		RequestedBy:  requestedBy,
+		RoleIDs:      roleIDs,
+		BaseRoleIDs:  baseRoleIDs,
+		Status:       domain.RoleChangeRequestStatusPending,
+		ExpiresAt:    s.now().Add(s.ttl),
+	}
+	if err := s.repo.Create(req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (s *DefaultRoleChangeRequestService) Approve(ctx context.Context, id, approverID uint, reason string) (*domain.RoleChangeRequest, error) {
+	req, err := s.loadDecidable(ctx, id, approverID)
+	if err != nil {
+		return nil, err
+	}
+	// The request replaces the whole role set, so applying it over roles
+	// changed since submit would silently undo those changes.
+	if req.BaseRoleIDs != nil {
+		current, err := s.currentRoleIDs(req.TargetUserID)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Equal(current, sortedRoleIDs(req.BaseRoleIDs)) {
+			return nil, ErrRoleChangeStale
+		}
+	}
+	now := s.now()
+	if err := s.repo.Transition(id, domain.RoleChangeRequestStatusPending, domain.RoleChangeRequestStatusApproved, &approverID, reason, now); err != nil {
+		return nil, err
+	}
+	req.Status = domain.RoleChangeRequestStatusApproved
+	req.DecidedBy = &approverID
+	req.DecisionReason = reason
+	req.DecidedAt = &now
+	if err := s.userSvc.SetRoles(req.TargetUserID, req.RoleIDs); err != nil {
+		// The request is already claimed as approved; record that applying it
+		// failed so it is not mistaken for a completed change.
+		req.Status = domain.RoleChangeRequestStatusFailed
+		_ = s.repo.Transition(id, domain.RoleChangeRequestStatusApproved, domain.RoleChangeRequestStatusFailed, &approverID, reason, now)
+		return req, err
+	}
+	return req, nil
+}
+
+func (s *DefaultRoleChangeRequestService) Reject(ctx context.Context, id, approverID uint, reason string) (*domain.RoleChangeRequest, error) {
+	req, err := s.loadDecidable(ctx, id, approverID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	if err := s.repo.Transition(id, domain.RoleChangeRequestStatusPending, domain.RoleChangeRequestStatusRejected, &approverID, reason, now); err != nil {
+		return nil, err
+	}
+	req.Status = domain.RoleChangeRequestStatusRejected
+	req.DecidedBy = &approverID
+	req.DecisionReason = reason
+	req.DecidedAt = &now
+	return req, nil
+}
+
+func (s *DefaultRoleChangeRequestService) List(ctx context.Context, req repository.PageRequest, status string) (repository.PageResult[domain.RoleChangeRequest], error) {
+	if _, err := s.ExpireStale(ctx, 0); err != nil {
+		return repository.PageResult[domain.RoleChangeRequest]{}, err
+	}
+	return s.repo.ListPaged(req, status)
+}
+
+// ExpireStale marks pending requests past their TTL as expired and audits each
+// one. It returns the number of requests expired by this call.
+func (s *DefaultRoleChangeRequestService) ExpireStale(ctx context.Context, limit int) (int, error) {
+	now := s.now()
+	stale, err := s.repo.ListExpiredPending(now, limit)
+	if err != nil {
+		return 0, err
+	}
+	expired := 0
+	for _, req := range stale {
+		if err := s.expire(ctx, req, now); err != nil {
+			if errors.Is(err, repository.ErrRoleChangeRequestNotPending) {
+				continue
+			}
+			return expired, err
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+// RunExpiryLoop expires stale pending requests on every tick so they are
+// closed and audited on time rather than on the next List call.
+func (s *DefaultRoleChangeRequestService) RunExpiryLoop(ctx context.Context, interval time.Duration, batchSize int, logger *slog.Logger) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := s.ExpireStale(ctx, batchSize)
+			if err != nil {
+				if logger != nil {
+					logger.Warn("role change request expiry failed", "error", err)
+				}
+				continue
+			}
+			if expired > 0 && logger != nil {
+				logger.Info("role change request expiry closed stale requests", "expired", expired)
+			}
+		}
+	}
+}
+
+// currentRoleIDs returns the sorted IDs of the roles userID currently holds.
+func (s *DefaultRoleChangeRequestService) currentRoleIDs(userID uint) ([]uint, error) {
+	user, _, err := s.userSvc.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, 0, len(user.Roles))
+	for _, role := range user.Roles {
+		ids = append(ids, role.ID)
+	}
+	return sortedRoleIDs(ids), nil
+}
+
+func sortedRoleIDs(ids []uint) []uint {
+	sorted := slices.Clone(ids)
+	slices.Sort(sorted)
+	return slices.Compact(sorted)
+}
+
+func (s *DefaultRoleChangeRequestService) loadDecidable(ctx context.Context, id, approverID uint) (*domain.RoleChangeRequest, error) {
+	req, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Status != domain.RoleChangeRequestStatusPending {
+		return nil, repository.ErrRoleChangeRequestNotPending
+	}
+	if approverID == req.RequestedBy || approverID == req.TargetUserID {
+		return nil, ErrRoleChangeSelfApproval
+	}
+	now := s.now()
+	if !req.ExpiresAt.After(now) {
+		if err := s.expire(ctx, *req, now); err != nil && !errors.Is(err, repository.ErrRoleChangeRequestNotPending) {
+			return nil, err
+		}
+		return nil, ErrRoleChangeExpired
+	}
+	return req, nil
+}
+
+func (s *DefaultRoleChangeRequestService) expire(ctx context.Context, req domain.RoleChangeRequest, now time.Time) error {
+	if err := s.repo.Transition(req.ID, domain.RoleChangeRequestStatusPending, domain.RoleChangeRequestStatusExpired, nil, "ttl_elapsed", now); err != nil {
+		return err
+	}
+	observability.EmitSystemAudit(ctx, observability.AuditInput{
+		EventName:  "rbac.role_change_request.expire",
+		TargetType: "role_change_request",
+		TargetID:   strconv.FormatUint(uint64(req.ID), 10),
+		Action:     "expire",
+		Outcome:    "success",
+		Reason:     "ttl_elapsed",
+	}, "target_user_id", req.TargetUserID, "requested_by", req.RequestedBy, "role_ids", req.RoleIDs)
+	return nil
+}
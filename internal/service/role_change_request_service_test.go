@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+	repogomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRoleChangeRequestServiceApproveAppliesRoles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockRoleChangeRequestRepository(ctrl)
+	userRepo := repogomock.NewMockUserRepository(ctrl)
+	svc := NewRoleChangeRequestService(repo, NewUserService(userRepo, NewRBACService()), time.Hour)
+
+	repo.EXPECT().FindByID(uint(3)).Return(&domain.RoleChangeRequest{
+		ID:           3,
+		TargetUserID: 9,
+		RequestedBy:  1,
+		RoleIDs:      []uint{1, 2},
+		Status:       domain.RoleChangeRequestStatusPending,
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}, nil)
+	repo.EXPECT().Transition(uint(3), domain.RoleChangeRequestStatusPending, domain.RoleChangeRequestStatusApproved, gomock.Any(), "looks good", gomock.Any()).Return(nil)
+	userRepo.EXPECT().SetRoles(uint(9), []uint{1, 2}).Return(nil)
+
+	req, err := svc.Approve(context.Background(), 3, 2, "looks good")
+	if err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if req.Status != domain.RoleChangeRequestStatusApproved || req.DecidedBy == nil || *req.DecidedBy != 2 {
+		t.Fatalf("unexpected approved request: %+v", req)
+	}
+}
+
+func TestRoleChangeRequestServiceRejectsSelfApproval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockRoleChangeRequestRepository(ctrl)
+	svc := NewRoleChangeRequestService(repo, nil, time.Hour)
+
+	repo.EXPECT().FindByID(uint(3)).Return(&domain.RoleChangeRequest{
+		ID:           3,
+		TargetUserID: 9,
+		RequestedBy:  1,
+		Status:       domain.RoleChangeRequestStatusPending,
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}, nil).Times(2)
+
+	if _, err := svc.Approve(context.Background(), 3, 1, ""); !errors.Is(err, ErrRoleChangeSelfApproval) {
+		t.Fatalf("expected requester self-approval rejected, got %v", err)
+	}
+	if _, err := svc.Reject(context.Background(), 3, 9, ""); !errors.Is(err, ErrRoleChangeSelfApproval) {
+		t.Fatalf("expected target self-decision rejected, got %v", err)
+	}
+}
+
+func TestRoleChangeRequestServiceExpiredRequestCannotBeApproved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockRoleChangeRequestRepository(ctrl)
+	svc := NewRoleChangeRequestService(repo, nil, time.Hour)
+
+	repo.EXPECT().FindByID(uint(4)).Return(&domain.RoleChangeRequest{
+		ID:           4,
+		TargetUserID: 9,
+		RequestedBy:  1,
+		Status:       domain.RoleChangeRequestStatusPending,
+		ExpiresAt:    time.Now().Add(-time.Minute),
+	}, nil)
+	repo.EXPECT().Transition(uint(4), domain.RoleChangeRequestStatusPending, domain.RoleChangeRequestStatusExpired, nil, "ttl_elapsed", gomock.Any()).Return(nil)
+
+	if _, err := svc.Approve(context.Background(), 4, 2, ""); !errors.Is(err, ErrRoleChangeExpired) {
+		t.Fatalf("expected ErrRoleChangeExpired, got %v", err)
+	}
+}
+
+func TestRoleChangeRequestServiceApproveMarksFailedWhenApplyFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockRoleChangeRequestRepository(ctrl)
+	userRepo := repogomock.NewMockUserRepository(ctrl)
+	svc := NewRoleChangeRequestService(repo, NewUserService(userRepo, NewRBACService()), time.Hour)
+	applyErr := errors.New("db down")
+
+	repo.EXPECT().FindByID(uint(5)).Return(&domain.RoleChangeRequest{
+		ID:           5,
+		TargetUserID: 9,
+		RequestedBy:  1,
+		RoleIDs:      []uint{1},
+		Status:       domain.RoleChangeRequestStatusPending,
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}, nil)
+	gomock.InOrder(
+		repo.EXPECT().Transition(uint(5), domain.RoleChangeRequestStatusPending, domain.RoleChangeRequestStatusApproved, gomock.Any(), "", gomock.Any()).Return(nil),
+		repo.EXPECT().Transition(uint(5), domain.RoleChangeRequestStatusApproved, domain.RoleChangeRequestStatusFailed, gomock.Any(), "", gomock.Any()).Return(nil),
+	)
+	userRepo.EXPECT().SetRoles(uint(9), []uint{1}).Return(applyErr)
+
+	req, err := svc.Approve(context.Background(), 5, 2, "")
+	if !errors.Is(err, applyErr) || req == nil || req.Status != domain.RoleChangeRequestStatusFailed {
+		t.Fatalf("expected failed status with apply error, got req=%+v err=%v", req, err)
+	}
+}
+
+func TestRoleChangeRequestServiceListExpiresStaleFirst(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockRoleChangeRequestRepository(ctrl)
+	svc := NewRoleChangeRequestService(repo, nil, time.Hour)
+
+	gomock.InOrder(
+		repo.EXPECT().ListExpiredPending(gomock.Any(), 0).Return([]domain.RoleChangeRequest{{ID: 8, Status: domain.RoleChangeRequestStatusPending}}, nil),
+		repo.EXPECT().Transition(uint(8), domain.RoleChangeRequestStatusPending, domain.RoleChangeRequestStatusExpired, nil, "ttl_elapsed", gomock.Any()).Return(nil),
+		repo.EXPECT().ListPaged(repository.PageRequest{Page: 1, PageSize: 20}, "").Return(repository.PageResult[domain.RoleChangeRequest]{Total: 1}, nil),
+	)
+
+	page, err := svc.List(context.Background(), repository.PageRequest{Page: 1, PageSize: 20}, "")
+	if err != nil || page.Total != 1 {
+		t.Fatalf("unexpected list result page=%+v err=%v", page, err)
+	}
+}
+
+func TestRoleChangeRequestServiceSubmitRecordsCurrentRoles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockRoleChangeRequestRepository(ctrl)
+	userRepo := repogomock.NewMockUserRepository(ctrl)
+	svc := NewRoleChangeRequestService(repo, NewUserService(userRepo, NewRBACService()), time.Hour)
+
+	userRepo.EXPECT().FindByID(uint(9)).Return(&domain.User{ID: 9, Roles: []domain.Role{{ID: 4}, {ID: 2}}}, nil)
+	repo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	req, err := svc.Submit(context.Background(), 9, 1, []uint{1, 2, 4})
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	if len(req.BaseRoleIDs) != 2 || req.BaseRoleIDs[0] != 2 || req.BaseRoleIDs[1] != 4 {
+		t.Fatalf("expected base roles [2 4], got %v", req.BaseRoleIDs)
+	}
+}
+
+func TestRoleChangeRequestServiceApproveRejectsStaleRoles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockRoleChangeRequestRepository(ctrl)
+	userRepo := repogomock.NewMockUserRepository(ctrl)
+	svc := NewRoleChangeRequestService(repo, NewUserService(userRepo, NewRBACService()), time.Hour)
+
+	pending := func() *domain.RoleChangeRequest {
+		return &domain.RoleChangeRequest{
+			ID:           6,
+			TargetUserID: 9,
+			RequestedBy:  1,
+			RoleIDs:      []uint{1, 2},
+			BaseRoleIDs:  []uint{2},
+			Status:       domain.RoleChangeRequestStatusPending,
+			ExpiresAt:    time.Now().Add(time.Hour),
+		}
+	}
+
+	// Another admin granted role 5 after the request was submitted; applying
+	// the stored set would revoke it.
+	repo.EXPECT().FindByID(uint(6)).Return(pending(), nil)
+	userRepo.EXPECT().FindByID(uint(9)).Return(&domain.User{ID: 9, Roles: []domain.Role{{ID: 2}, {ID: 5}}}, nil)
+	if _, err := svc.Approve(context.Background(), 6, 2, ""); !errors.Is(err, ErrRoleChangeStale) {
+		t.Fatalf("expected ErrRoleChangeStale, got %v", err)
+	}
+
+	repo.EXPECT().FindByID(uint(6)).Return(pending(), nil)
+	userRepo.EXPECT().FindByID(uint(9)).Return(&domain.User{ID: 9, Roles: []domain.Role{{ID: 2}}}, nil)
+	repo.EXPECT().Transition(uint(6), domain.RoleChangeRequestStatusPending, domain.RoleChangeRequestStatusApproved, gomock.Any(), "", gomock.Any()).Return(nil)
+	userRepo.EXPECT().SetRoles(uint(9), []uint{1, 2}).Return(nil)
+	if _, err := svc.Approve(context.Background(), 6, 2, ""); err != nil {
+		t.Fatalf("expected approval with unchanged roles, got %v", err)
+	}
+}
+
+func TestRoleChangeRequestServiceRunExpiryLoopExpiresStaleRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockRoleChangeRequestRepository(ctrl)
+	svc := NewRoleChangeRequestService(repo, nil, time.Hour)
+
+	expired := make(chan struct{})
+	repo.EXPECT().ListExpiredPending(gomock.Any(), 50).Return([]domain.RoleChangeRequest{{ID: 8, Status: domain.RoleChangeRequestStatusPending}}, nil)
+	repo.EXPECT().Transition(uint(8), domain.RoleChangeRequestStatusPending, domain.RoleChangeRequestStatusExpired, nil, "ttl_elapsed", gomock.Any()).DoAndReturn(
+		func(uint, string, string, *uint, string, time.Time) error {
+			close(expired)
+			return nil
+		})
+	repo.EXPECT().ListExpiredPending(gomock.Any(), 50).Return(nil, nil).AnyTimes()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.RunExpiryLoop(ctx, 5*time.Millisecond, 50, nil)
+		close(done)
+	}()
+	select {
+	case <-expired:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the loop to expire the stale request")
+	}
+	cancel()
+	<-done
+}
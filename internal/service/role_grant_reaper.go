@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/observability"
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+)
+
+// RoleGrantReaper removes time-bound role grants whose valid_until has
+// elapsed, invalidates the affected users' cached permissions, and emits an
+// audit event per expired grant.
+type RoleGrantReaper struct {
+	userRepo  repository.UserRepository
+	permCache RBACPermissionCacheStore
+}
+
+func NewRoleGrantReaper(userRepo repository.UserRepository, permCache RBACPermissionCacheStore) *RoleGrantReaper {
+	if permCache == nil {
+		permCache = NewNoopRBACPermissionCacheStore()
+	}
+	return &RoleGrantReaper{userRepo: userRepo, permCache: permCache}
+}
+
+func (r *RoleGrantReaper) ReapExpired(ctx context.Context, now time.Time, batchSize int) (int, error) {
+	grants, err := r.userRepo.ListExpiredRoleGrants(now.UTC(), batchSize)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, grant := range grants {
+		deleted, err := r.userRepo.DeleteExpiredRoleGrant(grant.UserID, grant.RoleID, now.UTC())
+		if err != nil {
+			return removed, err
+		}
+		if !deleted {
+			continue
+		}
+		removed++
+		_ = r.permCache.InvalidateUser(ctx, grant.UserID)
+		validUntil := ""
+		if grant.ValidUntil != nil {
+			validUntil = grant.ValidUntil.UTC().Format(time.RFC3339)
+		}
+		observability.EmitSystemAudit(ctx, observability.AuditInput{
+			EventName:  "rbac.role_grant.expire",
+			TargetType: "user",
+			TargetID:   strconv.FormatUint(uint64(grant.UserID), 10),
+			Action:     "expire",
+			Outcome:    "success",
+			Reason:     "valid_until_elapsed",
+		}, "role_id", grant.RoleID, "valid_until", validUntil)
+	}
+	return removed, nil
+}
+
+func (r *RoleGrantReaper) RunCleanupLoop(ctx context.Context, interval time.Duration, batchSize int, logger *slog.Logger) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			removed, err := r.ReapExpired(ctx, time.Now().UTC(), batchSize)
+			if err != nil {
+				if logger != nil {
+					logger.Warn("role grant reaper failed", "error", err)
+				}
+				continue
+			}
+			if removed > 0 && logger != nil {
+				logger.Info("role grant reaper removed expired grants", "removed", removed)
+			}
+		}
+	}
+}
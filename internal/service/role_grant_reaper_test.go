@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/domain"
+	repogomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRoleGrantReaperReapExpiredInvalidatesPermissionCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockUserRepository(ctrl)
+	cache := NewInMemoryRBACPermissionCacheStore()
+	ctx := context.Background()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	expiredAt := now.Add(-time.Minute)
+
+	if err := cache.Set(ctx, 7, "jti-7", []string{"users:write"}, time.Hour); err != nil {
+		t.Fatalf("seed cache user 7: %v", err)
+	}
+	if err := cache.Set(ctx, 8, "jti-8", []string{"users:write"}, time.Hour); err != nil {
+		t.Fatalf("seed cache user 8: %v", err)
+	}
+
+	repo.EXPECT().ListExpiredRoleGrants(now, 50).Return([]domain.UserRole{
+		{UserID: 7, RoleID: 1, ValidUntil: &expiredAt},
+		{UserID: 8, RoleID: 1, ValidUntil: &expiredAt},
+	}, nil)
+	repo.EXPECT().DeleteExpiredRoleGrant(uint(7), uint(1), now).Return(true, nil)
+	// User 8's grant was extended concurrently, so it must survive.
+	repo.EXPECT().DeleteExpiredRoleGrant(uint(8), uint(1), now).Return(false, nil)
+
+	reaper := NewRoleGrantReaper(repo, cache)
+	removed, err := reaper.ReapExpired(ctx, now, 50)
+	if err != nil {
+		t.Fatalf("reap expired: %v", err)
+	}
+	if removed != 1 {
+		t.Fatalf("expected 1 removed grant, got %d", removed)
+	}
+	if _, ok, _ := cache.Get(ctx, 7, "jti-7"); ok {
+		t.Fatal("expected user 7 permission cache invalidated")
+	}
+	if _, ok, _ := cache.Get(ctx, 8, "jti-8"); !ok {
+		t.Fatal("expected user 8 permission cache retained")
+	}
+}
+
+func TestRoleGrantReaperReapExpiredPropagatesListError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repogomock.NewMockUserRepository(ctrl)
+	expected := errors.New("db down")
+	repo.EXPECT().ListExpiredRoleGrants(gomock.Any(), 10).Return(nil, expected)
+
+	removed, err := NewRoleGrantReaper(repo, nil).ReapExpired(context.Background(), time.Now(), 10)
+	if !errors.Is(err, expected) || removed != 0 {
+		t.Fatalf("expected list error and 0 removed, got removed=%d err=%v", removed, err)
+	}
+}
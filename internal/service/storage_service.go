@@ -18,10 +18,11 @@ import (
 )
 
 const (
-	maxAvatarSize    = 5 * 1024 * 1024 // 5 MB
-	avatarObjectTTL  = 7 * 24 * time.Hour
-	presignedURLTTL  = 15 * time.Minute
-	avatarPathPrefix = "avatars"
+	maxImageSize       = 5 * 1024 * 1024 // 5 MB
+	avatarObjectTTL    = 7 * 24 * time.Hour
+	presignedURLTTL    = 15 * time.Minute
+	avatarPathPrefix   = "avatars"
+	productImagePrefix = "products"
 )
 
 var (
@@ -50,6 +51,28 @@ type StorageService interface {
 
 	// GenerateAvatarURL generates a presigned URL for avatar access.
 	GenerateAvatarURL(ctx context.Context, objectKey string) (string, error)
+
+	// UploadImage stores a JPEG or PNG image under prefix and returns the
+	// object key and the content type detected from the image bytes.
+	UploadImage(ctx context.Context, prefix string, file io.Reader, fileSize int64, metadata map[string]string) (objectKey, contentType string, err error)
+
+	// DeleteObject deletes one object, which must live under prefix.
+	DeleteObject(ctx context.Context, prefix, objectKey string) error
+
+	// DeletePrefix deletes every object under prefix.
+	DeletePrefix(ctx context.Context, prefix string) error
+
+	// GenerateURL generates a presigned GET URL for an object.
+	GenerateURL(ctx context.Context, objectKey string) (string, error)
+}
+
+// ProductImagePrefix is the storage prefix holding a product's gallery.
+func ProductImagePrefix(productID uint) string {
+	return fmt.Sprintf("%s/%d", productImagePrefix, productID)
+}
+
+func avatarPrefix(userID uint) string {
+	return fmt.Sprintf("%s/user-%d", avatarPathPrefix, userID)
 }
 
 // MinIOStorageService implements StorageService using MinIO/S3-compatible storage.
@@ -104,9 +127,33 @@ func (s *MinIOStorageService) ensureBucketExists(ctx context.Context) error {
 // UploadAvatar uploads a user's avatar with validation.
 // Detects content type from actual bytes to prevent spoofing.
 func (s *MinIOStorageService) UploadAvatar(ctx context.Context, userID uint, file io.Reader, fileSize int64, contentType string) (string, error) {
+	objectKey, _, err := s.UploadImage(ctx, avatarPrefix(userID), file, fileSize, map[string]string{
+		"User-ID": fmt.Sprintf("%d", userID),
+	})
+	return objectKey, err
+}
+
+// DeleteAvatar deletes an avatar object after validating ownership.
+// Enforces that the objectKey belongs to the specified userID.
+func (s *MinIOStorageService) DeleteAvatar(ctx context.Context, userID uint, objectKey string) error {
+	return s.DeleteObject(ctx, avatarPrefix(userID), objectKey)
+}
+
+// GenerateAvatarURL generates a presigned GET URL for avatar access.
+func (s *MinIOStorageService) GenerateAvatarURL(ctx context.Context, objectKey string) (string, error) {
+	return s.GenerateURL(ctx, objectKey)
+}
+
+// UploadImage uploads an image under prefix with validation.
+// Detects content type from actual bytes to prevent spoofing.
+func (s *MinIOStorageService) UploadImage(ctx context.Context, prefix string, file io.Reader, fileSize int64, metadata map[string]string) (string, string, error) {
 	// Validate file size BEFORE connecting to MinIO
-	if fileSize > maxAvatarSize {
-		return "", ErrFileTooBig
+	if fileSize > maxImageSize {
+		return "", "", ErrFileTooBig
+	}
+	dir, err := objectPrefix(prefix)
+	if err != nil {
+		return "", "", err
 	}
 
 	// Read first 512 bytes to detect actual content type
@@ -114,7 +161,7 @@ func (s *MinIOStorageService) UploadAvatar(ctx context.Context, userID uint, fil
 	buf := make([]byte, 512)
 	n, err := io.ReadFull(file, buf)
 	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
-		return "", fmt.Errorf("%w: read file for content detection: %v", ErrUploadFailed, err)
+		return "", "", fmt.Errorf("%w: read file for content detection: %v", ErrUploadFailed, err)
 	}
 	buf = buf[:n]
 
@@ -124,43 +171,44 @@ func (s *MinIOStorageService) UploadAvatar(ctx context.Context, userID uint, fil
 
 	// Validate detected content type BEFORE connecting to MinIO (not client-provided one)
 	if _, allowed := allowedContentTypes[normalizedDetectedType]; !allowed {
-		return "", ErrInvalidFileType
+		return "", "", ErrInvalidFileType
 	}
 
 	// Lazy init AFTER validation passes (defers MinIO connection until necessary)
 	if err := s.lazyInit(ctx); err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	// Combine sniffed bytes with remaining file content
 	fullFile := io.MultiReader(bytes.NewReader(buf), file)
 
-	// Generate unique object key with user namespace
+	// Generate unique object key within the prefix
 	fileExt := contentTypeToExtension(normalizedDetectedType)
-	objectKey := fmt.Sprintf("%s/user-%d/%s%s", avatarPathPrefix, userID, uuid.New().String(), fileExt)
+	objectKey := dir + uuid.New().String() + fileExt
 
 	// Prepare metadata
-	metadata := map[string]string{
+	userMetadata := map[string]string{
 		"Detected-Content-Type": normalizedDetectedType,
-		"User-ID":               fmt.Sprintf("%d", userID),
 		"Uploaded-At":           time.Now().UTC().Format(time.RFC3339),
 	}
+	for key, value := range metadata {
+		userMetadata[key] = value
+	}
 
 	// Upload file
 	_, err = s.client.PutObject(ctx, s.bucketName, objectKey, fullFile, fileSize, minio.PutObjectOptions{
 		ContentType:  normalizedDetectedType,
-		UserMetadata: metadata,
+		UserMetadata: userMetadata,
 	})
 	if err != nil {
-		return "", fmt.Errorf("%w: %v", ErrUploadFailed, err)
+		return "", "", fmt.Errorf("%w: %v", ErrUploadFailed, err)
 	}
 
-	return objectKey, nil
+	return objectKey, normalizedDetectedType, nil
 }
 
-// DeleteAvatar deletes an avatar object after validating ownership.
-// Enforces that the objectKey belongs to the specified userID.
-func (s *MinIOStorageService) DeleteAvatar(ctx context.Context, userID uint, objectKey string) error {
+// DeleteObject deletes an object after validating it lives under prefix.
+func (s *MinIOStorageService) DeleteObject(ctx context.Context, prefix, objectKey string) error {
 	// No-op for empty keys (fast path, no MinIO connection needed)
 	if strings.TrimSpace(objectKey) == "" {
 		return nil
@@ -171,9 +219,12 @@ func (s *MinIOStorageService) DeleteAvatar(ctx context.Context, userID uint, obj
 		return ErrUnauthorizedAccess
 	}
 
-	// Validate ownership BEFORE connecting to MinIO: objectKey must match pattern avatars/user-{userID}/...
-	expectedPrefix := fmt.Sprintf("%s/user-%d/", avatarPathPrefix, userID)
-	if !strings.HasPrefix(objectKey, expectedPrefix) {
+	// Validate ownership BEFORE connecting to MinIO: objectKey must start with prefix/
+	dir, err := objectPrefix(prefix)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(objectKey, dir) {
 		return ErrUnauthorizedAccess
 	}
 
@@ -182,7 +233,7 @@ func (s *MinIOStorageService) DeleteAvatar(ctx context.Context, userID uint, obj
 		return err
 	}
 
-	err := s.client.RemoveObject(ctx, s.bucketName, objectKey, minio.RemoveObjectOptions{})
+	err = s.client.RemoveObject(ctx, s.bucketName, objectKey, minio.RemoveObjectOptions{})
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrDeleteFailed, err)
 	}
@@ -190,8 +241,62 @@ func (s *MinIOStorageService) DeleteAvatar(ctx context.Context, userID uint, obj
 	return nil
 }
 
-// GenerateAvatarURL generates a presigned GET URL for avatar access.
-func (s *MinIOStorageService) GenerateAvatarURL(ctx context.Context, objectKey string) (string, error) {
+// DeletePrefix deletes every object under prefix, e.g. a purged product's
+// gallery. It keeps going past failed objects and reports the first failure.
+func (s *MinIOStorageService) DeletePrefix(ctx context.Context, prefix string) error {
+	dir, err := objectPrefix(prefix)
+	if err != nil {
+		return err
+	}
+
+	// Lazy init AFTER validation passes (defers MinIO connection until necessary)
+	if err := s.lazyInit(ctx); err != nil {
+		return err
+	}
+
+	// ListObjects reports failures inline, which RemoveObjects would try to
+	// delete, so only successfully listed objects are passed on.
+	var listErr error
+	listed := make(chan struct{})
+	objects := make(chan minio.ObjectInfo)
+	go func() {
+		defer close(listed)
+		defer close(objects)
+		for object := range s.client.ListObjects(ctx, s.bucketName, minio.ListObjectsOptions{Prefix: dir, Recursive: true}) {
+			if object.Err != nil {
+				listErr = object.Err
+				return
+			}
+			select {
+			case objects <- object:
+			case <-ctx.Done():
+				listErr = ctx.Err()
+				return
+			}
+		}
+	}()
+
+	// RemoveObjects stops only once objects is closed, so every result is
+	// drained to avoid leaking its goroutine.
+	var removeErr error
+	for result := range s.client.RemoveObjects(ctx, s.bucketName, objects, minio.RemoveObjectsOptions{}) {
+		if result.Err != nil && removeErr == nil {
+			removeErr = result.Err
+		}
+	}
+	<-listed
+	if listErr != nil {
+		return fmt.Errorf("%w: list objects: %v", ErrDeleteFailed, listErr)
+	}
+	if removeErr != nil {
+		return fmt.Errorf("%w: %v", ErrDeleteFailed, removeErr)
+	}
+
+	return nil
+}
+
+// GenerateURL generates a presigned GET URL for object access.
+func (s *MinIOStorageService) GenerateURL(ctx context.Context, objectKey string) (string, error) {
 	// Validate input BEFORE connecting to MinIO
 	if strings.TrimSpace(objectKey) == "" {
 		return "", fmt.Errorf("%w: empty object key", ErrURLGenerationFailed)
@@ -210,6 +315,16 @@ func (s *MinIOStorageService) GenerateAvatarURL(ctx context.Context, objectKey s
 	return presignedURL.String(), nil
 }
 
+// objectPrefix validates a storage prefix and returns it with a trailing
+// slash, so "products/1" never matches keys of "products/12".
+func objectPrefix(prefix string) (string, error) {
+	prefix = strings.Trim(strings.TrimSpace(prefix), "/")
+	if prefix == "" || strings.Contains(prefix, "..") {
+		return "", ErrUnauthorizedAccess
+	}
+	return prefix + "/", nil
+}
+
 // contentTypeToExtension maps content type to file extension.
 func contentTypeToExtension(contentType string) string {
 	switch contentType {
@@ -249,3 +249,68 @@ func TestUploadAvatarReadError(t *testing.T) {
 		t.Fatalf("expected ErrUploadFailed, got: %v", err)
 	}
 }
+
+// TestDeleteObjectEnforcesPrefix verifies that objects can only be deleted
+// within the given prefix, and that a prefix never matches a longer sibling.
+func TestDeleteObjectEnforcesPrefix(t *testing.T) {
+	svc, err := NewMinIOStorageService("localhost:9999", "key", "secret", "bucket", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		prefix    string
+		objectKey string
+	}{
+		{name: "sibling product", prefix: ProductImagePrefix(1), objectKey: "products/12/file.png"},
+		{name: "other product", prefix: ProductImagePrefix(1), objectKey: "products/2/file.png"},
+		{name: "path traversal", prefix: ProductImagePrefix(1), objectKey: "products/1/../2/file.png"},
+		{name: "empty prefix", prefix: "", objectKey: "products/1/file.png"},
+		{name: "traversal in prefix", prefix: "products/..", objectKey: "products/../file.png"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.DeleteObject(context.Background(), tt.prefix, tt.objectKey)
+			if !errors.Is(err, ErrUnauthorizedAccess) {
+				t.Fatalf("expected ErrUnauthorizedAccess, got %v", err)
+			}
+		})
+	}
+}
+
+// TestUploadImageValidatesBeforeConnecting verifies that size, prefix and
+// content checks run before any MinIO call.
+func TestUploadImageValidatesBeforeConnecting(t *testing.T) {
+	svc, err := NewMinIOStorageService("localhost:9999", "key", "secret", "bucket", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	png := []byte("\x89PNG\r\n\x1a\n")
+
+	if _, _, err := svc.UploadImage(context.Background(), ProductImagePrefix(1), bytes.NewReader(png), 6*1024*1024, nil); !errors.Is(err, ErrFileTooBig) {
+		t.Fatalf("expected ErrFileTooBig, got %v", err)
+	}
+	if _, _, err := svc.UploadImage(context.Background(), "", bytes.NewReader(png), int64(len(png)), nil); !errors.Is(err, ErrUnauthorizedAccess) {
+		t.Fatalf("expected ErrUnauthorizedAccess for an empty prefix, got %v", err)
+	}
+	html := []byte("<html><body>Not an image</body></html>")
+	if _, _, err := svc.UploadImage(context.Background(), ProductImagePrefix(1), bytes.NewReader(html), int64(len(html)), nil); !errors.Is(err, ErrInvalidFileType) {
+		t.Fatalf("expected ErrInvalidFileType, got %v", err)
+	}
+}
+
+// TestDeletePrefixRejectsInvalidPrefix verifies that a bulk delete can never
+// target the whole bucket.
+func TestDeletePrefixRejectsInvalidPrefix(t *testing.T) {
+	svc, err := NewMinIOStorageService("localhost:9999", "key", "secret", "bucket", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, prefix := range []string{"", "/", " ", "products/../avatars"} {
+		if err := svc.DeletePrefix(context.Background(), prefix); !errors.Is(err, ErrUnauthorizedAccess) {
+			t.Fatalf("expected ErrUnauthorizedAccess for %q, got %v", prefix, err)
+		}
+	}
+}
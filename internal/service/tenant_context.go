@@ -0,0 +1,22 @@
+package service
+
+import "context"
+
+// Tenant is the organization selected for the current request along with the
+// permissions granted by the caller's org-scoped roles.
+type Tenant struct {
+	OrganizationID uint
+	Slug           string
+	Permissions    []string
+}
+
+type tenantContextKey struct{}
+
+func WithTenant(ctx context.Context, tenant Tenant) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+func TenantFromContext(ctx context.Context) (Tenant, bool) {
+	tenant, ok := ctx.Value(tenantContextKey{}).(Tenant)
+	return tenant, ok
+}
@@ -149,8 +149,9 @@ func (s *TokenService) RevokeAll(userID uint, reason string) error {
 }
 
 func (s *TokenService) mintTokenPair(user *domain.User, permissions []string) (access string, refresh string, refreshClaims *security.Claims, csrf string, err error) {
-	roles := make([]string, 0, len(user.Roles))
-	for _, r := range user.Roles {
+	effective := user.EffectiveRoles()
+	roles := make([]string, 0, len(effective))
+	for _, r := range effective {
 		roles = append(roles, r.Name)
 	}
 	refresh, err = s.jwtMgr.SignRefreshToken(user.ID, s.refreshTTL)
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/repository"
+)
+
+// TrashPurger permanently removes products and users that have been in the
+// trash for longer than the retention period, along with the products'
+// image files.
+type TrashPurger struct {
+	productRepo repository.ProductRepository
+	userRepo    repository.UserRepository
+	storage     StorageService
+}
+
+func NewTrashPurger(productRepo repository.ProductRepository, userRepo repository.UserRepository, storage StorageService) *TrashPurger {
+	return &TrashPurger{productRepo: productRepo, userRepo: userRepo, storage: storage}
+}
+
+// PurgeExpired removes up to batchSize products and batchSize users deleted
+// before cutoff and reports how many of each were purged.
+func (p *TrashPurger) PurgeExpired(ctx context.Context, cutoff time.Time, batchSize int) (products, users int, err error) {
+	productIDs, err := p.productRepo.PurgeDeletedBefore(cutoff.UTC(), batchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, id := range productIDs {
+		removeProductImageFiles(ctx, p.storage, id)
+	}
+	products = len(productIDs)
+	users, err = p.userRepo.PurgeDeletedBefore(cutoff.UTC(), batchSize)
+	if err != nil {
+		return products, 0, err
+	}
+	return products, users, nil
+}
+
+func (p *TrashPurger) RunCleanupLoop(ctx context.Context, interval, retention time.Duration, batchSize int, logger *slog.Logger) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			products, users, err := p.PurgeExpired(ctx, time.Now().UTC().Add(-retention), batchSize)
+			if err != nil {
+				if logger != nil {
+					logger.Warn("trash purge failed", "error", err)
+				}
+				continue
+			}
+			if products+users > 0 && logger != nil {
+				logger.Info("trash purge removed expired records", "products", products, "users", users)
+			}
+		}
+	}
+}
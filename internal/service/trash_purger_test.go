@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	repogomock "github.com/sandeepkv93/everything-backend-starter-kit/internal/repository/gomock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTrashPurgerPurgeExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	products := repogomock.NewMockProductRepository(ctrl)
+	users := repogomock.NewMockUserRepository(ctrl)
+	storage := NewMockStorageService(ctrl)
+	cutoff := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	products.EXPECT().PurgeDeletedBefore(cutoff, 50).Return([]uint{4, 5, 6}, nil)
+	users.EXPECT().PurgeDeletedBefore(cutoff, 50).Return(1, nil)
+	storage.EXPECT().DeletePrefix(gomock.Any(), "products/4").Return(nil)
+	storage.EXPECT().DeletePrefix(gomock.Any(), "products/5").Return(errors.New("storage down"))
+	storage.EXPECT().DeletePrefix(gomock.Any(), "products/6").Return(nil)
+
+	purgedProducts, purgedUsers, err := NewTrashPurger(products, users, storage).PurgeExpired(context.Background(), cutoff, 50)
+	if err != nil {
+		t.Fatalf("purge expired: %v", err)
+	}
+	if purgedProducts != 3 || purgedUsers != 1 {
+		t.Fatalf("expected 3 products and 1 user purged, got %d and %d", purgedProducts, purgedUsers)
+	}
+}
+
+func TestTrashPurgerPurgeExpiredStopsOnProductError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	products := repogomock.NewMockProductRepository(ctrl)
+	users := repogomock.NewMockUserRepository(ctrl)
+	expected := errors.New("db down")
+	products.EXPECT().PurgeDeletedBefore(gomock.Any(), 10).Return(nil, expected)
+
+	_, _, err := NewTrashPurger(products, users, nil).PurgeExpired(context.Background(), time.Now(), 10)
+	if !errors.Is(err, expected) {
+		t.Fatalf("expected product purge error, got %v", err)
+	}
+}
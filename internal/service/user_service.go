@@ -19,7 +19,7 @@ func (s *UserService) GetByID(id uint) (*domain.User, []string, error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	return u, s.rbac.PermissionsFromRoles(u.Roles), nil
+	return u, s.rbac.PermissionsFromRoles(u.EffectiveRoles()), nil
 }
 
 func (s *UserService) List() ([]domain.User, error) {
@@ -51,6 +51,23 @@ func TestUserServiceGetByID(t *testing.T) {
 		}
 		assertPermissionSet(t, perms, []string{"users:read", "roles:read", "roles:write"})
 	})
+
+	t.Run("includes permissions inherited from groups", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repogomock.NewMockUserRepository(ctrl)
+		repo.EXPECT().FindByID(uint(8)).Return(&domain.User{
+			ID:         8,
+			Roles:      []domain.Role{{ID: 1, Name: "user", Permissions: []domain.Permission{{Resource: "users", Action: "read"}}}},
+			GroupRoles: []domain.Role{{ID: 2, Name: "engineer", Permissions: []domain.Permission{{Resource: "products", Action: "write"}}}},
+		}, nil)
+		svc := NewUserService(repo, NewRBACService())
+
+		_, perms, err := svc.GetByID(8)
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		assertPermissionSet(t, perms, []string{"users:read", "products:write"})
+	})
 }
 
 func TestUserServiceListDelegatesToRepo(t *testing.T) {
@@ -36,6 +36,7 @@ func NewRootCommand() *cobra.Command {
 		newUpCommand(opts),
 		newStatusCommand(opts),
 		newPlanCommand(opts),
+		newDropLegacyPriceCommand(opts),
 	)
 	return cmd
 }
@@ -69,6 +70,36 @@ func newUpCommand(opts *options) *cobra.Command {
 	}
 }
 
+func newDropLegacyPriceCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "drop-legacy-price",
+		Short: "Drop the legacy float products.price column",
+		Long:  "Drops the legacy products.price column that up keeps in sync for the previous release. Run it once no instance of that release is left to roll back to.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			details, err := run(opts, "migrate drop-legacy-price", "drop-legacy-price", func(ctx context.Context) ([]string, error) {
+				_, db, err := loadConfigDB(opts.envFile)
+				if err != nil {
+					return nil, err
+				}
+				sqlDB, _ := db.DB()
+				defer func() { _ = sqlDB.Close() }()
+
+				if err := database.DropLegacyProductPrice(db); err != nil {
+					return nil, err
+				}
+				return []string{"legacy products.price column dropped", "database: connected"}, nil
+			})
+			if opts.ci {
+				common.PrintCIResult(err == nil, "migrate drop-legacy-price", details, err)
+			}
+			if err != nil {
+				os.Exit(3)
+			}
+			return nil
+		},
+	}
+}
+
 func newStatusCommand(opts *options) *cobra.Command {
 	return &cobra.Command{
 		Use:   "status",
@@ -13,10 +13,10 @@ func TestNewRootCommandStructure(t *testing.T) {
 	if cmd.Use != "migrate" {
 		t.Fatalf("unexpected root use: %s", cmd.Use)
 	}
-	if len(cmd.Commands()) != 3 {
-		t.Fatalf("expected 3 subcommands, got %d", len(cmd.Commands()))
+	if len(cmd.Commands()) != 4 {
+		t.Fatalf("expected 4 subcommands, got %d", len(cmd.Commands()))
 	}
-	for _, name := range []string{"up", "status", "plan"} {
+	for _, name := range []string{"up", "status", "plan", "drop-legacy-price"} {
 		if c, _, err := cmd.Find([]string{name}); err != nil || c == nil {
 			t.Fatalf("expected subcommand %q: err=%v", name, err)
 		}
@@ -0,0 +1,336 @@
+// Package flagsclient evaluates feature flags inside other Go services. It
+// downloads the full flag snapshot from the API, evaluates locally with the
+// server's semantics, and refreshes by polling or by listening to the
+// Server-Sent Events stream. The last good snapshot is kept while the API is
+// unreachable.
+package flagsclient
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	snapshotPath = "/api/v1/feature-flags/snapshot"
+	streamPath   = "/api/v1/feature-flags/stream"
+
+	defaultPollInterval = 30 * time.Second
+)
+
+var (
+	ErrNoSnapshot   = errors.New("flagsclient: no snapshot loaded")
+	ErrFlagNotFound = errors.New("flagsclient: flag not found")
+)
+
+// RefreshMode selects how the client keeps its snapshot current.
+type RefreshMode int
+
+const (
+	// RefreshPoll re-downloads the snapshot every PollInterval using ETags.
+	RefreshPoll RefreshMode = iota
+	// RefreshStream re-downloads the snapshot whenever the SSE stream
+	// reports a change, falling back to polling while disconnected.
+	RefreshStream
+)
+
+type Options struct {
+	// BaseURL is the API origin, e.g. https://api.example.com.
+	BaseURL string
+	// Token returns the bearer access token. The token needs the
+	// feature_flags:evaluate permission.
+	Token func(ctx context.Context) (string, error)
+	// HTTPClient defaults to a client without a timeout so streams stay open.
+	HTTPClient   *http.Client
+	Mode         RefreshMode
+	PollInterval time.Duration
+	Logger       *slog.Logger
+}
+
+type Client struct {
+	opts Options
+
+	mu          sync.RWMutex
+	version     string
+	flags       map[string]Flag
+	flagsByID   map[uint]Flag
+	lastRefresh time.Time
+	lastErr     error
+
+	stopMu sync.Mutex
+	stop   context.CancelFunc
+	done   chan struct{}
+}
+
+func New(opts Options) (*Client, error) {
+	opts.BaseURL = strings.TrimRight(strings.TrimSpace(opts.BaseURL), "/")
+	if opts.BaseURL == "" {
+		return nil, errors.New("flagsclient: BaseURL is required")
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{}
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultPollInterval
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.New(slog.DiscardHandler)
+	}
+	return &Client{opts: opts}, nil
+}
+
+// Start loads the first snapshot and begins background refreshing until ctx
+// is cancelled or Close is called. The returned error reports the initial
+// load only; the client keeps retrying in the background either way.
+func (c *Client) Start(ctx context.Context) error {
+	err := c.Refresh(ctx)
+
+	c.stopMu.Lock()
+	defer c.stopMu.Unlock()
+	if c.stop != nil {
+		return err
+	}
+	runCtx, cancel := context.WithCancel(ctx)
+	c.stop = cancel
+	c.done = make(chan struct{})
+	go func() {
+		defer close(c.done)
+		if c.opts.Mode == RefreshStream {
+			c.runStream(runCtx)
+			return
+		}
+		c.runPoll(runCtx)
+	}()
+	return err
+}
+
+// Close stops background refreshing and waits for it to exit.
+func (c *Client) Close() {
+	c.stopMu.Lock()
+	stop, done := c.stop, c.done
+	c.stop, c.done = nil, nil
+	c.stopMu.Unlock()
+	if stop != nil {
+		stop()
+		<-done
+	}
+}
+
+// Refresh downloads the snapshot if it changed since the last successful
+// load. On failure the current snapshot stays in place.
+func (c *Client) Refresh(ctx context.Context) error {
+	err := c.fetchSnapshot(ctx)
+	c.mu.Lock()
+	c.lastErr = err
+	if err == nil {
+		c.lastRefresh = time.Now()
+	}
+	c.mu.Unlock()
+	if err != nil {
+		c.opts.Logger.Warn("feature flag snapshot refresh failed", "error", err)
+	}
+	return err
+}
+
+// LoadSnapshot installs a snapshot directly, for example one persisted from
+// a previous run so evaluation works before the API is reachable.
+func (c *Client) LoadSnapshot(snapshot Snapshot) {
+	flags, flagsByID := snapshot.index()
+	c.mu.Lock()
+	c.version = snapshot.Version
+	c.flags = flags
+	c.flagsByID = flagsByID
+	c.mu.Unlock()
+}
+
+// Version returns the loaded snapshot version, or "" before the first load.
+func (c *Client) Version() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.version
+}
+
+// Status reports when the snapshot was last confirmed current and the error
+// from the most recent refresh attempt.
+func (c *Client) Status() (time.Time, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastRefresh, c.lastErr
+}
+
+func (c *Client) Evaluate(key string, ctx Context) (Result, error) {
+	c.mu.RLock()
+	flags, flagsByID := c.flags, c.flagsByID
+	c.mu.RUnlock()
+	if flags == nil {
+		return Result{}, ErrNoSnapshot
+	}
+	flag, ok := flags[strings.TrimSpace(strings.ToLower(key))]
+	if !ok {
+		return Result{}, ErrFlagNotFound
+	}
+	return newEvaluator(flagsByID, ctx).evaluate(flag), nil
+}
+
+// EvaluateAll evaluates every flag in the snapshot, ordered by key.
+func (c *Client) EvaluateAll(ctx Context) ([]Result, error) {
+	c.mu.RLock()
+	flags, flagsByID := c.flags, c.flagsByID
+	c.mu.RUnlock()
+	if flags == nil {
+		return nil, ErrNoSnapshot
+	}
+	evaluator := newEvaluator(flagsByID, ctx)
+	results := make([]Result, 0, len(flags))
+	for _, flag := range flags {
+		results = append(results, evaluator.evaluate(flag))
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Key < results[j].Key })
+	return results, nil
+}
+
+// Enabled reports whether the flag is on for ctx, or fallback when the flag
+// cannot be evaluated.
+func (c *Client) Enabled(key string, ctx Context, fallback bool) bool {
+	result, err := c.Evaluate(key, ctx)
+	if err != nil {
+		return fallback
+	}
+	return result.Enabled
+}
+
+// Variant returns the served variant key, or fallback when the flag cannot
+// be evaluated or serves no variant.
+func (c *Client) Variant(key string, ctx Context, fallback string) string {
+	result, err := c.Evaluate(key, ctx)
+	if err != nil || result.Variant == "" {
+		return fallback
+	}
+	return result.Variant
+}
+
+func (c *Client) fetchSnapshot(ctx context.Context) error {
+	req, err := c.newRequest(ctx, snapshotPath)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if version := c.Version(); version != "" {
+		req.Header.Set("If-None-Match", `"`+version+`"`)
+	}
+	resp, err := c.opts.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return nil
+	case http.StatusOK:
+	default:
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return fmt.Errorf("flagsclient: snapshot request returned %s", resp.Status)
+	}
+	var envelope struct {
+		Data Snapshot `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("flagsclient: decode snapshot: %w", err)
+	}
+	c.LoadSnapshot(envelope.Data)
+	return nil
+}
+
+func (c *Client) newRequest(ctx context.Context, path string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.opts.BaseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.opts.Token != nil {
+		token, err := c.opts.Token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("flagsclient: token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req, nil
+}
+
+func (c *Client) runPoll(ctx context.Context) {
+	ticker := time.NewTicker(c.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = c.Refresh(ctx)
+		}
+	}
+}
+
+// runStream listens for change events and refreshes on each one. While the
+// stream is down it polls once per PollInterval before reconnecting.
+func (c *Client) runStream(ctx context.Context) {
+	for {
+		err := c.consumeStream(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		c.opts.Logger.Warn("feature flag stream disconnected", "error", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.opts.PollInterval):
+		}
+		_ = c.Refresh(ctx)
+	}
+}
+
+func (c *Client) consumeStream(ctx context.Context) error {
+	req, err := c.newRequest(ctx, streamPath)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := c.opts.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("flagsclient: stream request returned %s", resp.Status)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	event := ""
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "":
+			if event == "snapshot" || event == "change" {
+				_ = c.Refresh(ctx)
+			}
+			event = ""
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package flagsclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeFlagServer struct {
+	mu       sync.Mutex
+	snapshot Snapshot
+	down     bool
+	notMod   atomic.Int32
+	changes  chan struct{}
+}
+
+func (s *fakeFlagServer) setSnapshot(snapshot Snapshot) {
+	s.mu.Lock()
+	s.snapshot = snapshot
+	s.mu.Unlock()
+}
+
+func (s *fakeFlagServer) setDown(down bool) {
+	s.mu.Lock()
+	s.down = down
+	s.mu.Unlock()
+}
+
+func (s *fakeFlagServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer svc-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	switch r.URL.Path {
+	case snapshotPath:
+		s.mu.Lock()
+		snapshot, down := s.snapshot, s.down
+		s.mu.Unlock()
+		if down {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		etag := `"` + snapshot.Version + `"`
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			s.notMod.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"success": true, "data": snapshot})
+	case streamPath:
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher := w.(http.Flusher)
+		_, _ = fmt.Fprint(w, "retry: 3000\n\nevent: snapshot\ndata: {}\n\n")
+		flusher.Flush()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-s.changes:
+				_, _ = fmt.Fprint(w, ": heartbeat\n\nid: 1-a\nevent: change\ndata: {}\n\n")
+				flusher.Flush()
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newFakeFlagServer(t *testing.T, enabled bool) (*fakeFlagServer, *httptest.Server) {
+	t.Helper()
+	fake := &fakeFlagServer{changes: make(chan struct{})}
+	fake.setSnapshot(snapshotWithCheckout("v1", enabled))
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func snapshotWithCheckout(version string, enabled bool) Snapshot {
+	return Snapshot{Version: version, Flags: []Flag{{ID: 1, Key: "new_checkout", Enabled: enabled, Type: TypeBoolean}}}
+}
+
+func staticToken(context.Context) (string, error) { return "svc-token", nil }
+
+func TestClientRefreshUsesETagAndKeepsLastGoodSnapshot(t *testing.T) {
+	fake, server := newFakeFlagServer(t, true)
+	client, err := New(Options{BaseURL: server.URL + "/", Token: staticToken})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	if _, err := client.Evaluate("new_checkout", Context{}); !errors.Is(err, ErrNoSnapshot) {
+		t.Fatalf("expected ErrNoSnapshot before first load, got %v", err)
+	}
+
+	ctx := context.Background()
+	if err := client.Refresh(ctx); err != nil {
+		t.Fatalf("initial refresh: %v", err)
+	}
+	if !client.Enabled("New_Checkout", Context{UserID: 1}, false) || client.Version() != "v1" {
+		t.Fatalf("expected enabled flag from v1 snapshot, version=%q", client.Version())
+	}
+	if err := client.Refresh(ctx); err != nil {
+		t.Fatalf("conditional refresh: %v", err)
+	}
+	if fake.notMod.Load() != 1 {
+		t.Fatalf("expected 304 on unchanged snapshot, got %d", fake.notMod.Load())
+	}
+
+	fake.setDown(true)
+	if err := client.Refresh(ctx); err == nil {
+		t.Fatal("expected refresh error while server is down")
+	}
+	if !client.Enabled("new_checkout", Context{UserID: 1}, false) {
+		t.Fatal("expected last good snapshot to keep serving while server is down")
+	}
+	if _, lastErr := client.Status(); lastErr == nil {
+		t.Fatal("expected status to report refresh error")
+	}
+
+	fake.setDown(false)
+	fake.setSnapshot(snapshotWithCheckout("v2", false))
+	if err := client.Refresh(ctx); err != nil {
+		t.Fatalf("refresh after recovery: %v", err)
+	}
+	if client.Enabled("new_checkout", Context{UserID: 1}, true) || client.Version() != "v2" {
+		t.Fatal("expected v2 snapshot after recovery")
+	}
+	if _, err := client.Evaluate("missing", Context{}); !errors.Is(err, ErrFlagNotFound) {
+		t.Fatalf("expected ErrFlagNotFound, got %v", err)
+	}
+}
+
+func TestClientPollingRefresh(t *testing.T) {
+	fake, server := newFakeFlagServer(t, false)
+	client, _ := New(Options{BaseURL: server.URL, Token: staticToken, PollInterval: 10 * time.Millisecond})
+	if err := client.Start(context.Background()); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer client.Close()
+
+	fake.setSnapshot(snapshotWithCheckout("v2", true))
+	waitFor(t, func() bool { return client.Enabled("new_checkout", Context{}, false) })
+}
+
+func TestClientStreamRefresh(t *testing.T) {
+	fake, server := newFakeFlagServer(t, false)
+	client, _ := New(Options{BaseURL: server.URL, Token: staticToken, Mode: RefreshStream, PollInterval: time.Hour})
+	if err := client.Start(context.Background()); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer client.Close()
+
+	// The snapshot event on connect triggers a conditional refresh.
+	waitFor(t, func() bool { return fake.notMod.Load() >= 1 })
+
+	fake.setSnapshot(snapshotWithCheckout("v2", true))
+	fake.changes <- struct{}{}
+	waitFor(t, func() bool { return client.Enabled("new_checkout", Context{}, false) })
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before deadline")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
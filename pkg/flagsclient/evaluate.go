@@ -0,0 +1,180 @@
+package flagsclient
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ruleTypeOrder is the precedence in which rule types are consulted; within a
+// type, rules run by ascending priority then ID.
+var ruleTypeOrder = []string{
+	RuleTypeUser,
+	RuleTypeRole,
+	RuleTypeOrg,
+	RuleTypeEnvironment,
+	RuleTypeAttribute,
+	RuleTypePercent,
+}
+
+// Evaluate resolves flag for ctx with the same semantics as the server.
+// Prerequisite flags are not available here, so a flag with prerequisites
+// serves its off state; use Client.Evaluate to resolve them.
+func Evaluate(flag Flag, ctx Context) Result {
+	flag.Rules = sortedRules(flag.Rules)
+	return newEvaluator(nil, ctx).evaluate(flag)
+}
+
+// evaluator evaluates flags for one context, memoising results so shared
+// prerequisites are evaluated once. A flag met again on its own prerequisite
+// path counts as unmet.
+type evaluator struct {
+	ctx      Context
+	flags    map[uint]Flag
+	results  map[uint]Result
+	visiting map[uint]bool
+}
+
+func newEvaluator(flags map[uint]Flag, ctx Context) *evaluator {
+	return &evaluator{ctx: ctx, flags: flags, results: map[uint]Result{}, visiting: map[uint]bool{}}
+}
+
+// evaluate expects flag.Rules to already be in evaluation order.
+func (e *evaluator) evaluate(flag Flag) Result {
+	if result, ok := e.results[flag.ID]; ok {
+		return result
+	}
+	e.visiting[flag.ID] = true
+	defer delete(e.visiting, flag.ID)
+
+	for _, prerequisite := range flag.Prerequisites {
+		if !e.prerequisiteMet(prerequisite) {
+			source := "prerequisite:" + strconv.FormatUint(uint64(prerequisite.PrerequisiteFlagID), 10)
+			if required, ok := e.flags[prerequisite.PrerequisiteFlagID]; ok {
+				source = "prerequisite:" + required.Key
+			}
+			result := buildResult(flag, false, source, nil, e.ctx)
+			e.results[flag.ID] = result
+			return result
+		}
+	}
+	enabled, source, rule := evaluateEnabled(flag, e.ctx)
+	result := buildResult(flag, enabled, source, rule, e.ctx)
+	e.results[flag.ID] = result
+	return result
+}
+
+func (e *evaluator) prerequisiteMet(prerequisite Prerequisite) bool {
+	required, ok := e.flags[prerequisite.PrerequisiteFlagID]
+	if !ok || e.visiting[required.ID] {
+		return false
+	}
+	result := e.evaluate(required)
+	if prerequisite.Variant != "" {
+		return result.Variant == prerequisite.Variant
+	}
+	return result.Enabled == (prerequisite.Enabled == nil || *prerequisite.Enabled)
+}
+
+func buildResult(flag Flag, enabled bool, source string, rule *Rule, ctx Context) Result {
+	result := Result{
+		Key:         flag.Key,
+		Enabled:     enabled,
+		Source:      source,
+		Description: flag.Description,
+		Type:        flag.Type,
+	}
+	if result.Type == "" || result.Type == TypeBoolean {
+		result.Type = TypeBoolean
+		result.Value = json.RawMessage(strconv.FormatBool(enabled))
+		return result
+	}
+	if variant, ok := selectVariant(flag, enabled, rule, ctx); ok {
+		result.Variant = variant.Key
+		result.Value = variant.Value
+	}
+	return result
+}
+
+func evaluateEnabled(flag Flag, ctx Context) (bool, string, *Rule) {
+	for _, ruleType := range ruleTypeOrder {
+		for i := range flag.Rules {
+			rule := &flag.Rules[i]
+			if rule.Type == ruleType && matchesRule(*rule, ctx) {
+				return rule.Enabled, "rule:" + ruleType, rule
+			}
+		}
+	}
+	return flag.Enabled, "default", nil
+}
+
+func selectVariant(flag Flag, enabled bool, rule *Rule, ctx Context) (Variant, bool) {
+	if enabled {
+		if rule != nil && rule.Variant != "" {
+			if variant, ok := flag.findVariant(rule.Variant); ok {
+				return variant, true
+			}
+		}
+		if ctx.UserID != 0 {
+			bucket := stableBucket(fmt.Sprintf("%d:%d:variant", flag.ID, ctx.UserID))
+			cumulative := 0
+			for _, variant := range flag.Variants {
+				cumulative += variant.Weight
+				if bucket < cumulative {
+					return variant, true
+				}
+			}
+		}
+	}
+	return flag.findVariant(flag.DefaultVariant)
+}
+
+func matchesRule(rule Rule, ctx Context) bool {
+	switch rule.Type {
+	case RuleTypeUser:
+		return rule.MatchValue == strconv.FormatUint(uint64(ctx.UserID), 10)
+	case RuleTypeRole:
+		target := strings.ToLower(strings.TrimSpace(rule.MatchValue))
+		for _, role := range ctx.Roles {
+			if strings.ToLower(strings.TrimSpace(role)) == target {
+				return true
+			}
+		}
+		return false
+	case RuleTypeOrg:
+		return strings.EqualFold(strings.TrimSpace(rule.MatchValue), strings.TrimSpace(ctx.Org))
+	case RuleTypeEnvironment:
+		return strings.EqualFold(strings.TrimSpace(rule.MatchValue), strings.TrimSpace(ctx.Environment))
+	case RuleTypeAttribute:
+		return matchesClauses(rule, ctx)
+	case RuleTypePercent:
+		if rule.Percentage <= 0 || ctx.UserID == 0 {
+			return false
+		}
+		if rule.Percentage >= 100 {
+			return true
+		}
+		return stableBucket(fmt.Sprintf("%d:%d", rule.FeatureFlagID, ctx.UserID)) < rule.Percentage
+	default:
+		return false
+	}
+}
+
+// stableBucket maps src to [0, 100) using the server's rejection-sampled
+// SHA-256 scheme, so rollouts assign every user identically on both sides.
+func stableBucket(src string) int {
+	sum := sha256.Sum256([]byte(src))
+	const bucketCount uint16 = 100
+	const maxUint16 = ^uint16(0)
+	limit := maxUint16 - (maxUint16 % bucketCount)
+	for i := 0; i+2 <= len(sum); i += 2 {
+		candidate := binary.BigEndian.Uint16(sum[i : i+2])
+		if candidate < limit {
+			return int(candidate % bucketCount)
+		}
+	}
+	return int(binary.BigEndian.Uint16(sum[:2]) % bucketCount)
+}
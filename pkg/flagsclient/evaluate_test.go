@@ -0,0 +1,98 @@
+package flagsclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+type conformanceExpectation struct {
+	Enabled bool            `json:"enabled"`
+	Source  string          `json:"source"`
+	Variant string          `json:"variant"`
+	Value   json.RawMessage `json:"value"`
+}
+
+type conformanceFixtures struct {
+	Flags []Flag `json:"flags"`
+	Cases []struct {
+		Name    string                            `json:"name"`
+		Context Context                           `json:"context"`
+		Expect  map[string]conformanceExpectation `json:"expect"`
+	} `json:"cases"`
+}
+
+func loadConformanceFixtures(t *testing.T) conformanceFixtures {
+	t.Helper()
+	raw, err := os.ReadFile("testdata/conformance.json")
+	if err != nil {
+		t.Fatalf("read fixtures: %v", err)
+	}
+	var fixtures conformanceFixtures
+	if err := json.Unmarshal(raw, &fixtures); err != nil {
+		t.Fatalf("decode fixtures: %v", err)
+	}
+	return fixtures
+}
+
+// TestEvaluateConformance runs the fixtures shared with the server evaluator
+// (internal/service) against the local evaluator.
+func TestEvaluateConformance(t *testing.T) {
+	fixtures := loadConformanceFixtures(t)
+	client, err := New(Options{BaseURL: "http://flags.invalid"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	client.LoadSnapshot(Snapshot{Version: "fixtures", Flags: fixtures.Flags})
+
+	for _, tc := range fixtures.Cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			results, err := client.EvaluateAll(tc.Context)
+			if err != nil {
+				t.Fatalf("evaluate all: %v", err)
+			}
+			if len(results) != len(tc.Expect) {
+				t.Fatalf("expected %d results, got %d", len(tc.Expect), len(results))
+			}
+			for _, result := range results {
+				want, ok := tc.Expect[result.Key]
+				if !ok {
+					t.Fatalf("unexpected flag %q", result.Key)
+				}
+				if result.Enabled != want.Enabled || result.Source != want.Source || result.Variant != want.Variant || !jsonEqual(t, result.Value, want.Value) {
+					t.Fatalf("%s: expected %+v, got enabled=%v source=%s variant=%s value=%s", result.Key, want, result.Enabled, result.Source, result.Variant, result.Value)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluateSortsRulesByPriority(t *testing.T) {
+	flag := Flag{ID: 1, Key: "f", Rules: []Rule{
+		{ID: 2, FeatureFlagID: 1, Type: RuleTypeRole, MatchValue: "admin", Enabled: false, Priority: 50},
+		{ID: 1, FeatureFlagID: 1, Type: RuleTypeRole, MatchValue: "admin", Enabled: true, Priority: 10},
+	}}
+	if result := Evaluate(flag, Context{Roles: []string{"admin"}}); !result.Enabled || result.Source != "rule:role" {
+		t.Fatalf("expected lowest priority rule to win, got %+v", result)
+	}
+}
+
+func jsonEqual(t *testing.T, a, b json.RawMessage) bool {
+	t.Helper()
+	var ca, cb bytes.Buffer
+	if err := json.Compact(&ca, a); err != nil {
+		t.Fatalf("compact %s: %v", a, err)
+	}
+	if err := json.Compact(&cb, b); err != nil {
+		t.Fatalf("compact %s: %v", b, err)
+	}
+	return ca.String() == cb.String()
+}
+
+func TestEvaluateStandaloneTreatsPrerequisitesAsUnmet(t *testing.T) {
+	flag := Flag{ID: 2, Key: "new_checkout_v2", Enabled: true, Type: TypeBoolean, Prerequisites: []Prerequisite{{PrerequisiteFlagID: 1}}}
+	if result := Evaluate(flag, Context{UserID: 7}); result.Enabled || result.Source != "prerequisite:1" {
+		t.Fatalf("expected prerequisite without its flag to be unmet, got %+v", result)
+	}
+}
@@ -0,0 +1,128 @@
+package flagsclient
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+const (
+	TypeBoolean = "boolean"
+	TypeString  = "string"
+	TypeNumber  = "number"
+	TypeJSON    = "json"
+
+	RuleTypeUser        = "user"
+	RuleTypeRole        = "role"
+	RuleTypeOrg         = "org"
+	RuleTypeEnvironment = "environment"
+	RuleTypeAttribute   = "attribute"
+	RuleTypePercent     = "percent"
+)
+
+// Snapshot is the flag definition set served by GET /api/v1/feature-flags/snapshot.
+type Snapshot struct {
+	Version string `json:"version"`
+	Flags   []Flag `json:"flags"`
+}
+
+type Flag struct {
+	ID             uint      `json:"id"`
+	Key            string    `json:"key"`
+	Description    string    `json:"description"`
+	Enabled        bool      `json:"enabled"`
+	Type           string    `json:"type"`
+	DefaultVariant string    `json:"default_variant,omitempty"`
+	Variants       []Variant `json:"variants,omitempty"`
+	Rules          []Rule    `json:"rules,omitempty"`
+	// Prerequisites are checked before Rules; any unmet one makes the flag
+	// serve its off state.
+	Prerequisites []Prerequisite `json:"prerequisites,omitempty"`
+}
+
+// Prerequisite requires the flag with PrerequisiteFlagID to serve Variant
+// or, when Variant is empty, to evaluate to Enabled (true when unset).
+type Prerequisite struct {
+	PrerequisiteFlagID uint   `json:"prerequisite_flag_id"`
+	Variant            string `json:"variant,omitempty"`
+	Enabled            *bool  `json:"enabled,omitempty"`
+}
+
+type Variant struct {
+	Key    string          `json:"key"`
+	Value  json.RawMessage `json:"value"`
+	Weight int             `json:"weight"`
+}
+
+type Rule struct {
+	ID             uint     `json:"id"`
+	FeatureFlagID  uint     `json:"feature_flag_id"`
+	Type           string   `json:"type"`
+	MatchValue     string   `json:"match_value"`
+	Percentage     int      `json:"percentage"`
+	Enabled        bool     `json:"enabled"`
+	Priority       int      `json:"priority"`
+	Variant        string   `json:"variant,omitempty"`
+	ClauseOperator string   `json:"clause_operator,omitempty"`
+	Clauses        []Clause `json:"clauses,omitempty"`
+}
+
+type Clause struct {
+	Attribute string   `json:"attribute"`
+	Operator  string   `json:"operator"`
+	Values    []string `json:"values"`
+	Negate    bool     `json:"negate,omitempty"`
+}
+
+// Context is the subject a flag is evaluated for. Attribute keys are
+// lower-case, matching the server's attr.<name> query parameters.
+type Context struct {
+	UserID      uint              `json:"user_id"`
+	Roles       []string          `json:"roles,omitempty"`
+	Org         string            `json:"org,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+}
+
+// Result mirrors the server's evaluation response for one flag.
+type Result struct {
+	Key         string          `json:"key"`
+	Enabled     bool            `json:"enabled"`
+	Source      string          `json:"source"`
+	Description string          `json:"description"`
+	Type        string          `json:"type"`
+	Variant     string          `json:"variant,omitempty"`
+	Value       json.RawMessage `json:"value"`
+}
+
+func (f Flag) findVariant(key string) (Variant, bool) {
+	for _, v := range f.Variants {
+		if v.Key == key {
+			return v, true
+		}
+	}
+	return Variant{}, false
+}
+
+// index returns the snapshot flags by key and by ID with rules in
+// evaluation order.
+func (s *Snapshot) index() (map[string]Flag, map[uint]Flag) {
+	byKey := make(map[string]Flag, len(s.Flags))
+	byID := make(map[uint]Flag, len(s.Flags))
+	for _, flag := range s.Flags {
+		flag.Rules = sortedRules(flag.Rules)
+		byKey[flag.Key] = flag
+		byID[flag.ID] = flag
+	}
+	return byKey, byID
+}
+
+func sortedRules(rules []Rule) []Rule {
+	out := append([]Rule(nil), rules...)
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Priority == out[j].Priority {
+			return out[i].ID < out[j].ID
+		}
+		return out[i].Priority < out[j].Priority
+	})
+	return out
+}
@@ -0,0 +1,252 @@
+package flagsclient
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	clauseOpIn         = "in"
+	clauseOpStartsWith = "starts_with"
+	clauseOpEndsWith   = "ends_with"
+	clauseOpRegex      = "regex"
+	clauseOpSemverEq   = "semver_eq"
+	clauseOpSemverGt   = "semver_gt"
+	clauseOpSemverGte  = "semver_gte"
+	clauseOpSemverLt   = "semver_lt"
+	clauseOpSemverLte  = "semver_lte"
+	clauseOpNumGt      = "num_gt"
+	clauseOpNumGte     = "num_gte"
+	clauseOpNumLt      = "num_lt"
+	clauseOpNumLte     = "num_lte"
+	clauseOpNumBetween = "num_between"
+
+	clauseCombineOr = "or"
+)
+
+var regexCache sync.Map
+
+// attributeValues resolves a clause attribute; built-in attributes take
+// precedence over custom ones of the same name.
+func (c Context) attributeValues(name string) []string {
+	switch name {
+	case "user_id":
+		if c.UserID == 0 {
+			return nil
+		}
+		return []string{strconv.FormatUint(uint64(c.UserID), 10)}
+	case "role":
+		return c.Roles
+	case "org":
+		if c.Org == "" {
+			return nil
+		}
+		return []string{c.Org}
+	case "environment":
+		if c.Environment == "" {
+			return nil
+		}
+		return []string{c.Environment}
+	}
+	if value, ok := c.Attributes[name]; ok {
+		return []string{value}
+	}
+	return nil
+}
+
+func matchesClauses(rule Rule, ctx Context) bool {
+	if len(rule.Clauses) == 0 {
+		return false
+	}
+	anyMode := rule.ClauseOperator == clauseCombineOr
+	for _, clause := range rule.Clauses {
+		matched := matchesClause(clause, ctx)
+		if anyMode && matched {
+			return true
+		}
+		if !anyMode && !matched {
+			return false
+		}
+	}
+	return !anyMode
+}
+
+// matchesClause reports whether any attribute value satisfies the clause. A
+// missing attribute never matches, even when the clause is negated.
+func matchesClause(clause Clause, ctx Context) bool {
+	values := ctx.attributeValues(clause.Attribute)
+	if len(values) == 0 {
+		return false
+	}
+	matched := false
+	for _, value := range values {
+		if matchesClauseValue(clause, strings.TrimSpace(value)) {
+			matched = true
+			break
+		}
+	}
+	return matched != clause.Negate
+}
+
+func matchesClauseValue(clause Clause, value string) bool {
+	switch clause.Operator {
+	case clauseOpIn:
+		for _, candidate := range clause.Values {
+			if strings.EqualFold(candidate, value) {
+				return true
+			}
+		}
+		return false
+	case clauseOpStartsWith:
+		lower := strings.ToLower(value)
+		for _, candidate := range clause.Values {
+			if strings.HasPrefix(lower, candidate) {
+				return true
+			}
+		}
+		return false
+	case clauseOpEndsWith:
+		lower := strings.ToLower(value)
+		for _, candidate := range clause.Values {
+			if strings.HasSuffix(lower, candidate) {
+				return true
+			}
+		}
+		return false
+	case clauseOpRegex:
+		for _, pattern := range clause.Values {
+			re, err := compileRegex(pattern)
+			if err == nil && re.MatchString(value) {
+				return true
+			}
+		}
+		return false
+	case clauseOpSemverEq, clauseOpSemverGt, clauseOpSemverGte, clauseOpSemverLt, clauseOpSemverLte:
+		actual, ok := parseSemver(value)
+		if !ok || len(clause.Values) != 1 {
+			return false
+		}
+		target, ok := parseSemver(clause.Values[0])
+		if !ok {
+			return false
+		}
+		return compareWith(clause.Operator, compareSemver(actual, target))
+	case clauseOpNumGt, clauseOpNumGte, clauseOpNumLt, clauseOpNumLte:
+		actual, err := strconv.ParseFloat(value, 64)
+		if err != nil || len(clause.Values) != 1 {
+			return false
+		}
+		target, err := strconv.ParseFloat(clause.Values[0], 64)
+		if err != nil {
+			return false
+		}
+		switch {
+		case actual < target:
+			return compareWith(clause.Operator, -1)
+		case actual > target:
+			return compareWith(clause.Operator, 1)
+		default:
+			return compareWith(clause.Operator, 0)
+		}
+	case clauseOpNumBetween:
+		actual, err := strconv.ParseFloat(value, 64)
+		if err != nil || len(clause.Values) != 2 {
+			return false
+		}
+		lo, errLo := strconv.ParseFloat(clause.Values[0], 64)
+		hi, errHi := strconv.ParseFloat(clause.Values[1], 64)
+		return errLo == nil && errHi == nil && actual >= lo && actual <= hi
+	default:
+		return false
+	}
+}
+
+func compareWith(operator string, cmp int) bool {
+	switch operator {
+	case clauseOpSemverEq:
+		return cmp == 0
+	case clauseOpSemverGt, clauseOpNumGt:
+		return cmp > 0
+	case clauseOpSemverGte, clauseOpNumGte:
+		return cmp >= 0
+	case clauseOpSemverLt, clauseOpNumLt:
+		return cmp < 0
+	case clauseOpSemverLte, clauseOpNumLte:
+		return cmp <= 0
+	default:
+		return false
+	}
+}
+
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := regexCache.Load(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	regexCache.Store(pattern, re)
+	return re, nil
+}
+
+type semver struct {
+	major, minor, patch uint64
+	prerelease          string
+}
+
+// parseSemver accepts MAJOR[.MINOR[.PATCH]][-PRERELEASE][+BUILD] with an
+// optional leading "v"; missing minor/patch parts are treated as zero.
+func parseSemver(raw string) (semver, bool) {
+	raw = strings.TrimPrefix(strings.TrimSpace(raw), "v")
+	if i := strings.IndexByte(raw, '+'); i >= 0 {
+		raw = raw[:i]
+	}
+	var v semver
+	if i := strings.IndexByte(raw, '-'); i >= 0 {
+		v.prerelease = raw[i+1:]
+		raw = raw[:i]
+		if v.prerelease == "" {
+			return semver{}, false
+		}
+	}
+	parts := strings.Split(raw, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return semver{}, false
+	}
+	nums := [3]uint64{}
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return semver{}, false
+		}
+		nums[i] = n
+	}
+	v.major, v.minor, v.patch = nums[0], nums[1], nums[2]
+	return v, true
+}
+
+func compareSemver(a, b semver) int {
+	for _, pair := range [][2]uint64{{a.major, b.major}, {a.minor, b.minor}, {a.patch, b.patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case a.prerelease == b.prerelease:
+		return 0
+	case a.prerelease == "":
+		return 1
+	case b.prerelease == "":
+		return -1
+	case a.prerelease < b.prerelease:
+		return -1
+	default:
+		return 1
+	}
+}
@@ -376,9 +376,9 @@ func newAuthTestServerWithOptions(t *testing.T, opts authTestServerOptions) (str
 	}
 	var adminHandler *handler.AdminHandler
 	if opts.adminListCache != nil {
-		adminHandler = handler.NewAdminHandler(adminUserSvc, userRepo, roleRepo, permRepo, rbac, permissionResolver, opts.adminListCache, negativeCache, db, cfg)
+		adminHandler = handler.NewAdminHandler(adminUserSvc, userRepo, roleRepo, permRepo, rbac, permissionResolver, opts.adminListCache, negativeCache, db, cfg, nil)
 	} else {
-		adminHandler = handler.NewAdminHandler(adminUserSvc, userRepo, roleRepo, permRepo, rbac, permissionResolver, service.NewNoopAdminListCacheStore(), negativeCache, db, cfg)
+		adminHandler = handler.NewAdminHandler(adminUserSvc, userRepo, roleRepo, permRepo, rbac, permissionResolver, service.NewNoopAdminListCacheStore(), negativeCache, db, cfg, nil)
 	}
 	var idempotencyFactory router.IdempotencyMiddlewareFactory
 	if cfg.IdempotencyEnabled {
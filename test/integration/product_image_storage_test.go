@@ -0,0 +1,50 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sandeepkv93/everything-backend-starter-kit/internal/service"
+)
+
+func TestProductImageDeletePrefixInMinIOKeepsOtherProducts(t *testing.T) {
+	env := newMinIOIntegrationEnv(t)
+	ctx := context.Background()
+
+	upload := func(productID uint) string {
+		t.Helper()
+		content := jpegFixtureBytes()
+		key, contentType, err := env.storage.UploadImage(ctx, service.ProductImagePrefix(productID), bytes.NewReader(content), int64(len(content)), map[string]string{"Product-ID": "1"})
+		if err != nil {
+			t.Fatalf("upload product %d image: %v", productID, err)
+		}
+		if contentType != "image/jpeg" || !strings.HasPrefix(key, service.ProductImagePrefix(productID)+"/") {
+			t.Fatalf("unexpected upload result key=%q content_type=%q", key, contentType)
+		}
+		return key
+	}
+	first := upload(1)
+	second := upload(1)
+	// products/12 shares the "products/1" string prefix but is another product.
+	other := upload(12)
+
+	url, err := env.storage.GenerateURL(ctx, first)
+	if err != nil || !strings.Contains(url, first) {
+		t.Fatalf("expected presigned url for %q, got %q err=%v", first, url, err)
+	}
+
+	if err := env.storage.DeletePrefix(ctx, service.ProductImagePrefix(1)); err != nil {
+		t.Fatalf("delete prefix: %v", err)
+	}
+	if env.mustObjectExists(t, first) || env.mustObjectExists(t, second) {
+		t.Fatal("expected product 1 images to be removed")
+	}
+	if !env.mustObjectExists(t, other) {
+		t.Fatal("expected product 12 image to be kept")
+	}
+	if err := env.storage.DeleteObject(ctx, service.ProductImagePrefix(1), other); err == nil {
+		t.Fatal("expected delete outside the prefix to be rejected")
+	}
+}